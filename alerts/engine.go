@@ -0,0 +1,324 @@
+package alerts
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+var (
+	DefaultQueueSize       = 1000
+	DefaultWebhookTimeout  = 10 * time.Second
+	NotifyRetries          = 3
+	seenLabelsLimit        = 100000
+	dispatchQueueSize      = 10000
+	dispatchRetryBaseDelay = 1 * time.Second
+)
+
+// Alert is a notification about label matched by rule.
+type Alert struct {
+	RuleID          string                 `json:"rule_id"`
+	CustomerID      string                 `json:"customer_id"`
+	Blockchain      string                 `json:"blockchain"`
+	LabelType       string                 `json:"label_type"`
+	LabelName       string                 `json:"label_name"`
+	Address         string                 `json:"address"`
+	TransactionHash string                 `json:"transaction_hash"`
+	LogIndex        *uint64                `json:"log_index,omitempty"`
+	BlockNumber     uint64                 `json:"block_number"`
+	BlockTimestamp  uint64                 `json:"block_timestamp"`
+	Args            map[string]interface{} `json:"args"`
+	MatchesInWindow int                    `json:"matches_in_window"`
+	FiredAt         time.Time              `json:"fired_at"`
+}
+
+type dispatchItem struct {
+	alert    Alert
+	notifier Notifier
+}
+
+// Engine evaluates rules over labels written to customer databases and dispatches fired
+// alerts to notifiers in background, so labels processing is not slowed down by receivers.
+type Engine struct {
+	blockchain string
+	rules      []Rule
+	notifiers  map[string]Notifier
+	queues     map[string]*QueueNotifier
+
+	mu        sync.Mutex
+	windows   map[string][]uint64
+	seen      map[string]bool
+	seenOrder []string
+
+	dispatch chan dispatchItem
+	done     chan struct{}
+}
+
+// NewEngine creates engine with rules of blockchain, rules without blockchain apply to any chain.
+func NewEngine(blockchain string, rules []Rule) (*Engine, error) {
+	engine := &Engine{
+		blockchain: blockchain,
+		notifiers:  make(map[string]Notifier),
+		queues:     make(map[string]*QueueNotifier),
+		windows:    make(map[string][]uint64),
+		seen:       make(map[string]bool),
+		dispatch:   make(chan dispatchItem, dispatchQueueSize),
+		done:       make(chan struct{}),
+	}
+
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		if rule.Blockchain != "" && rule.Blockchain != blockchain {
+			continue
+		}
+
+		switch rule.Notify.Type {
+		case NotifierTypeWebhook:
+			engine.notifiers[rule.ID] = NewWebhookNotifier(rule.Notify.URL, rule.Notify.Headers, DefaultWebhookTimeout)
+		case NotifierTypeQueue:
+			if _, exists := engine.queues[rule.Notify.Name]; !exists {
+				engine.queues[rule.Notify.Name] = NewQueueNotifier(rule.Notify.Name, DefaultQueueSize)
+			}
+			engine.notifiers[rule.ID] = engine.queues[rule.Notify.Name]
+		}
+
+		engine.rules = append(engine.rules, rule)
+	}
+
+	go engine.runDispatcher()
+
+	log.Printf("Initialized alerts engine with %d rules for blockchain %s", len(engine.rules), blockchain)
+
+	return engine, nil
+}
+
+// Queue returns queue notifier by name to consume alerts from, nil if no rule uses it.
+func (e *Engine) Queue(name string) *QueueNotifier {
+	return e.queues[name]
+}
+
+// Queues returns all queue notifiers used by rules.
+func (e *Engine) Queues() []*QueueNotifier {
+	queues := make([]*QueueNotifier, 0, len(e.queues))
+	for _, queue := range e.queues {
+		queues = append(queues, queue)
+	}
+	return queues
+}
+
+// Close stops accepting new alerts and waits until already fired ones are delivered.
+func (e *Engine) Close() {
+	close(e.dispatch)
+	<-e.done
+}
+
+func (e *Engine) runDispatcher() {
+	defer close(e.done)
+
+	for item := range e.dispatch {
+		var err error
+		for attempt := 0; attempt < NotifyRetries; attempt++ {
+			if err = item.notifier.Notify(item.alert); err == nil {
+				break
+			}
+			time.Sleep(dispatchRetryBaseDelay * time.Duration(attempt+1))
+		}
+		if err != nil {
+			log.Printf("Failed to deliver alert of rule %s for customer %s: %v", item.alert.RuleID, item.alert.CustomerID, err)
+		}
+	}
+}
+
+// candidate is a label prepared for rules evaluation.
+type candidate struct {
+	labelType       string
+	labelName       string
+	address         string
+	transactionHash string
+	logIndex        *uint64
+	blockNumber     uint64
+	blockTimestamp  uint64
+	labelData       string
+	args            map[string]interface{}
+}
+
+func (c *candidate) key() string {
+	if c.logIndex != nil {
+		return fmt.Sprintf("%s:%d", c.transactionHash, *c.logIndex)
+	}
+	return c.transactionHash
+}
+
+func (c *candidate) decodeArgs() map[string]interface{} {
+	if c.args != nil {
+		return c.args
+	}
+
+	var labelData struct {
+		Args map[string]interface{} `json:"args"`
+	}
+	decoder := json.NewDecoder(strings.NewReader(c.labelData))
+	decoder.UseNumber()
+	if err := decoder.Decode(&labelData); err != nil || labelData.Args == nil {
+		c.args = map[string]interface{}{}
+	} else {
+		c.args = labelData.Args
+	}
+
+	return c.args
+}
+
+func (r *Rule) matches(c *candidate) bool {
+	if r.LabelType != "" && r.LabelType != c.labelType {
+		return false
+	}
+	if len(r.LabelNames) > 0 && !containsFold(r.LabelNames, c.labelName) {
+		return false
+	}
+	if len(r.Addresses) > 0 && !containsFold(r.Addresses, c.address) {
+		return false
+	}
+	for _, condition := range r.Conditions {
+		if !matchCondition(c.decodeArgs(), condition) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// markSeen remembers rule and label pair, returns false if it was already evaluated, so
+// retried writes of the same batch do not fire alerts twice. Caller should hold lock.
+func (e *Engine) markSeen(ruleID string, c *candidate) bool {
+	key := ruleID + ":" + c.key()
+	if e.seen[key] {
+		return false
+	}
+
+	e.seen[key] = true
+	e.seenOrder = append(e.seenOrder, key)
+	if len(e.seenOrder) > seenLabelsLimit {
+		delete(e.seen, e.seenOrder[0])
+		e.seenOrder = e.seenOrder[1:]
+	}
+
+	return true
+}
+
+// Evaluate checks labels of customer against rules and returns fired alerts.
+func (e *Engine) Evaluate(customerID string, events []indexer.EventLabel, transactions []indexer.TransactionLabel) []Alert {
+	var candidates []*candidate
+	for _, event := range events {
+		logIndex := event.LogIndex
+		candidates = append(candidates, &candidate{
+			labelType:       "event",
+			labelName:       event.LabelName,
+			address:         event.Address,
+			transactionHash: event.TransactionHash,
+			logIndex:        &logIndex,
+			blockNumber:     event.BlockNumber,
+			blockTimestamp:  event.BlockTimestamp,
+			labelData:       event.LabelData,
+		})
+	}
+	for _, transaction := range transactions {
+		candidates = append(candidates, &candidate{
+			labelType:       "tx_call",
+			labelName:       transaction.LabelName,
+			address:         transaction.Address,
+			transactionHash: transaction.TransactionHash,
+			blockNumber:     transaction.BlockNumber,
+			blockTimestamp:  transaction.BlockTimestamp,
+			labelData:       transaction.LabelData,
+		})
+	}
+
+	// Frequency windows are calculated in order labels appeared on chain
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].blockNumber != candidates[j].blockNumber {
+			return candidates[i].blockNumber < candidates[j].blockNumber
+		}
+		if candidates[i].logIndex == nil || candidates[j].logIndex == nil {
+			return candidates[i].logIndex == nil && candidates[j].logIndex != nil
+		}
+		return *candidates[i].logIndex < *candidates[j].logIndex
+	})
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var alerts []Alert
+	for i := range e.rules {
+		rule := &e.rules[i]
+		if rule.CustomerID != customerID {
+			continue
+		}
+
+		for _, c := range candidates {
+			if !rule.matches(c) || !e.markSeen(rule.ID, c) {
+				continue
+			}
+
+			matchesInWindow := 1
+			if rule.Frequency != nil {
+				window := append(e.windows[rule.ID], c.blockTimestamp)
+				for len(window) > 0 && window[0]+rule.Frequency.WindowSeconds < c.blockTimestamp {
+					window = window[1:]
+				}
+				matchesInWindow = len(window)
+				if matchesInWindow < rule.Frequency.Count {
+					e.windows[rule.ID] = window
+					continue
+				}
+				// Start new window after rule fired
+				e.windows[rule.ID] = nil
+			}
+
+			alerts = append(alerts, Alert{
+				RuleID:          rule.ID,
+				CustomerID:      customerID,
+				Blockchain:      e.blockchain,
+				LabelType:       c.labelType,
+				LabelName:       c.labelName,
+				Address:         c.address,
+				TransactionHash: c.transactionHash,
+				LogIndex:        c.logIndex,
+				BlockNumber:     c.blockNumber,
+				BlockTimestamp:  c.blockTimestamp,
+				Args:            c.decodeArgs(),
+				MatchesInWindow: matchesInWindow,
+				FiredAt:         time.Now(),
+			})
+		}
+	}
+
+	return alerts
+}
+
+// Process evaluates labels and puts fired alerts to dispatch queue.
+func (e *Engine) Process(customerID string, events []indexer.EventLabel, transactions []indexer.TransactionLabel) {
+	for _, alert := range e.Evaluate(customerID, events, transactions) {
+		select {
+		case e.dispatch <- dispatchItem{alert: alert, notifier: e.notifiers[alert.RuleID]}:
+		default:
+			log.Printf("Alerts dispatch queue is full, dropped alert of rule %s for customer %s", alert.RuleID, alert.CustomerID)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Notifier delivers fired alert to its destination.
+type Notifier interface {
+	Notify(alert Alert) error
+}
+
+type WebhookNotifier struct {
+	URL     string
+	Headers map[string]string
+
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string, headers map[string]string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		URL:     url,
+		Headers: headers,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (n *WebhookNotifier) Notify(alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range n.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s responded with status %d", n.URL, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// QueueNotifier puts alerts into buffered in-process queue, consumers read it with Alerts.
+// Alerts are dropped with error if queue is full, so slow consumer does not block labels writes.
+type QueueNotifier struct {
+	Name string
+
+	queue chan Alert
+}
+
+func NewQueueNotifier(name string, size int) *QueueNotifier {
+	return &QueueNotifier{
+		Name:  name,
+		queue: make(chan Alert, size),
+	}
+}
+
+func (n *QueueNotifier) Notify(alert Alert) error {
+	select {
+	case n.queue <- alert:
+		return nil
+	default:
+		return fmt.Errorf("queue %s is full", n.Name)
+	}
+}
+
+func (n *QueueNotifier) Alerts() <-chan Alert {
+	return n.queue
+}
@@ -0,0 +1,177 @@
+package alerts
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+)
+
+const (
+	NotifierTypeWebhook = "webhook"
+	NotifierTypeQueue   = "queue"
+)
+
+// Condition compares decoded argument of label with value. For gt, gte, lt and lte both sides
+// are compared as numbers, eq and ne compare case-insensitive strings.
+type Condition struct {
+	Arg      string `json:"arg"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+// Frequency requires at least Count matching labels within WindowSeconds of block time
+// before rule fires.
+type Frequency struct {
+	Count         int    `json:"count"`
+	WindowSeconds uint64 `json:"window_seconds"`
+}
+
+type NotifyConfig struct {
+	Type    string            `json:"type"`
+	URL     string            `json:"url,omitempty"`
+	Name    string            `json:"name,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// Rule describes which labels customer is interested in and where to send alerts. Empty
+// filters match any label.
+type Rule struct {
+	ID         string       `json:"id"`
+	CustomerID string       `json:"customer_id"`
+	Blockchain string       `json:"blockchain,omitempty"`
+	Addresses  []string     `json:"addresses,omitempty"`
+	LabelType  string       `json:"label_type,omitempty"`
+	LabelNames []string     `json:"label_names,omitempty"`
+	Conditions []Condition  `json:"conditions,omitempty"`
+	Frequency  *Frequency   `json:"frequency,omitempty"`
+	Notify     NotifyConfig `json:"notify"`
+}
+
+func (r *Rule) Validate() error {
+	if r.ID == "" {
+		return fmt.Errorf("rule id is required")
+	}
+	if r.CustomerID == "" {
+		return fmt.Errorf("rule %s: customer_id is required", r.ID)
+	}
+	if r.LabelType != "" && r.LabelType != "event" && r.LabelType != "tx_call" {
+		return fmt.Errorf("rule %s: label_type should be event or tx_call", r.ID)
+	}
+	for _, condition := range r.Conditions {
+		if condition.Arg == "" {
+			return fmt.Errorf("rule %s: condition arg is required", r.ID)
+		}
+		switch condition.Operator {
+		case "eq", "ne":
+		case "gt", "gte", "lt", "lte":
+			if _, ok := new(big.Rat).SetString(condition.Value); !ok {
+				return fmt.Errorf("rule %s: value %q of %s condition should be a number", r.ID, condition.Value, condition.Arg)
+			}
+		default:
+			return fmt.Errorf("rule %s: unsupported operator %q", r.ID, condition.Operator)
+		}
+	}
+	if r.Frequency != nil && (r.Frequency.Count < 1 || r.Frequency.WindowSeconds == 0) {
+		return fmt.Errorf("rule %s: frequency count and window_seconds should be positive", r.ID)
+	}
+	switch r.Notify.Type {
+	case NotifierTypeWebhook:
+		if r.Notify.URL == "" {
+			return fmt.Errorf("rule %s: url is required for webhook notifier", r.ID)
+		}
+	case NotifierTypeQueue:
+		if r.Notify.Name == "" {
+			return fmt.Errorf("rule %s: name is required for queue notifier", r.ID)
+		}
+	default:
+		return fmt.Errorf("rule %s: unsupported notifier type %q", r.ID, r.Notify.Type)
+	}
+
+	return nil
+}
+
+// LoadRules reads JSON list of rules from file and validates them.
+func LoadRules(path string) ([]Rule, error) {
+	rulesBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %v", err)
+	}
+
+	var rules []Rule
+	if err := json.Unmarshal(rulesBytes, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse rules file: %v", err)
+	}
+
+	ids := make(map[string]bool)
+	for i := range rules {
+		if err := rules[i].Validate(); err != nil {
+			return nil, err
+		}
+		if ids[rules[i].ID] {
+			return nil, fmt.Errorf("duplicated rule id %s", rules[i].ID)
+		}
+		ids[rules[i].ID] = true
+
+		for j, address := range rules[i].Addresses {
+			rules[i].Addresses[j] = strings.ToLower(address)
+		}
+	}
+
+	return rules, nil
+}
+
+// matchCondition checks decoded argument against condition, missing argument never matches.
+func matchCondition(args map[string]interface{}, condition Condition) bool {
+	arg, ok := args[condition.Arg]
+	if !ok || arg == nil {
+		return false
+	}
+
+	var argStr string
+	switch v := arg.(type) {
+	case string:
+		argStr = v
+	case json.Number:
+		argStr = v.String()
+	case bool:
+		argStr = fmt.Sprintf("%t", v)
+	default:
+		argBytes, err := json.Marshal(v)
+		if err != nil {
+			return false
+		}
+		argStr = string(argBytes)
+	}
+
+	switch condition.Operator {
+	case "eq":
+		return strings.EqualFold(argStr, condition.Value)
+	case "ne":
+		return !strings.EqualFold(argStr, condition.Value)
+	}
+
+	argNum, ok := new(big.Rat).SetString(argStr)
+	if !ok {
+		return false
+	}
+	valueNum, ok := new(big.Rat).SetString(condition.Value)
+	if !ok {
+		return false
+	}
+
+	cmp := argNum.Cmp(valueNum)
+	switch condition.Operator {
+	case "gt":
+		return cmp > 0
+	case "gte":
+		return cmp >= 0
+	case "lt":
+		return cmp < 0
+	case "lte":
+		return cmp <= 0
+	}
+
+	return false
+}
@@ -8,7 +8,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"log/slog"
 	"math/big"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -18,30 +20,54 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/rpc"
 	"google.golang.org/protobuf/proto"
 
 	seer_common "github.com/G7DAO/seer/blockchain/common"
 	"github.com/G7DAO/seer/indexer"
+	"github.com/G7DAO/seer/logging"
 	"github.com/G7DAO/seer/version"
 )
 
+func init() {
+	seer_common.RegisterClient("arbitrum_sepolia", func(url string, timeout int) (seer_common.ChainClient, error) {
+		client, err := NewClient(url, timeout)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	})
+}
+
+var _ seer_common.ChainClient = (*Client)(nil)
+
+// NewClient connects to RPC endpoints, url could be comma separated list of endpoints
+// to balance calls between them, see seer_common.ParseRPCEndpoints.
 func NewClient(url string, timeout int) (*Client, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
 	defer cancel()
 
-	rpcClient, err := rpc.DialContext(ctx, url)
+	rpcClient, err := seer_common.DialRPCPool(ctx, url)
 	if err != nil {
 		return nil, err
 	}
-	return &Client{rpcClient: rpcClient, timeout: time.Duration(timeout) * time.Second}, nil
+	rpcClient.SetChain("arbitrum_sepolia")
+
+	return &Client{
+		rpcClient: rpcClient,
+		receipts:  seer_common.NewReceiptsFetcher("arbitrum_sepolia", rpcClient),
+		timeout:   time.Duration(timeout) * time.Second,
+		logger:    logging.Chain("arbitrum_sepolia"),
+	}, nil
 }
 
 // Client is a wrapper around the Ethereum JSON-RPC client.
 
 type Client struct {
-	rpcClient *rpc.Client
+	rpcClient *seer_common.RPCPool
+	receipts  *seer_common.ReceiptsFetcher
 	timeout   time.Duration
+	tracing   bool
+	logger    *slog.Logger
 }
 
 // Client common
@@ -51,11 +77,37 @@ func (c *Client) ChainType() string {
 	return "arbitrum_sepolia"
 }
 
+// SetRateLimit limits rate of requests to RPC endpoints.
+func (c *Client) SetRateLimit(config seer_common.RateLimitConfig) {
+	c.rpcClient.SetRateLimit(config)
+}
+
+// SetRetryPolicy sets how requests failed with transient errors are retried.
+func (c *Client) SetRetryPolicy(policy seer_common.RetryPolicy) {
+	c.rpcClient.SetRetryPolicy(policy)
+}
+
+// SetCrawlSchedule holds RPC requests of client inside of historical crawl windows.
+func (c *Client) SetCrawlSchedule(schedule *seer_common.CrawlSchedule) {
+	c.rpcClient.SetCrawlSchedule(schedule)
+}
+
+// SetTracing enables labeling of internal transactions found in call traces of blocks,
+// RPC should support debug_traceBlockByNumber with callTracer.
+func (c *Client) SetTracing(enabled bool) {
+	c.tracing = enabled
+}
+
 // Close closes the underlying RPC client.
 func (c *Client) Close() {
 	c.rpcClient.Close()
 }
 
+// Probe reports capabilities and latency of RPC endpoints of client.
+func (c *Client) Probe(ctx context.Context) (*seer_common.ProbeReport, error) {
+	return seer_common.ProbeRPC(ctx, c.ChainType(), c.rpcClient, c.timeout)
+}
+
 // GetLatestBlockNumber returns the latest block number.
 func (c *Client) GetLatestBlockNumber() (*big.Int, error) {
 	var result string
@@ -77,12 +129,43 @@ func (c *Client) GetLatestBlockNumber() (*big.Int, error) {
 	return blockNumber, nil
 }
 
+// GetTaggedBlockNumber returns number of block for tag of eth_getBlockByNumber, such as "safe"
+// or "finalized".
+func (c *Client) GetTaggedBlockNumber(ctx context.Context, tag string) (*big.Int, error) {
+	var block *struct {
+		Number string `json:"number"`
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if err := c.rpcClient.CallContext(ctxWithTimeout, &block, "eth_getBlockByNumber", tag, false); err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("node returned no %s block", tag)
+	}
+
+	blockNumber, ok := new(big.Int).SetString(block.Number, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid block number format: %s", block.Number)
+	}
+
+	return blockNumber, nil
+}
+
+// SubscribeNewHeads sends numbers of new blocks to heads channel, it requires WebSocket
+// endpoint among RPC URLs of client.
+func (c *Client) SubscribeNewHeads(ctx context.Context, heads chan<- *big.Int) (ethereum.Subscription, error) {
+	return seer_common.SubscribeNewHeads(ctx, c.rpcClient, heads)
+}
+
 // GetBlockByNumber returns the block with the given number.
 func (c *Client) GetBlockByNumber(ctx context.Context, number *big.Int, withTransactions bool) (*seer_common.BlockJson, error) {
 	var block *seer_common.BlockJson
 	err := c.rpcClient.CallContext(ctx, &block, "eth_getBlockByNumber", fmt.Sprintf("0x%x", number), withTransactions)
 	if err != nil {
-		fmt.Println("Error calling eth_getBlockByNumber:", err)
+		c.logger.Error("Failed to call eth_getBlockByNumber", logging.BlockKey, number.String(), logging.ErrorKey, err)
 		return nil, err
 	}
 
@@ -103,6 +186,12 @@ func (c *Client) TransactionReceipt(ctx context.Context, hash common.Hash) (*typ
 	return receipt, err
 }
 
+// BlockTransactionReceipt returns the receipt of a transaction from cached receipts of its
+// block, fetched with eth_getBlockReceipts if RPC supports it.
+func (c *Client) BlockTransactionReceipt(ctx context.Context, blockNumber uint64, blockHash string, hash common.Hash) (*types.Receipt, error) {
+	return c.receipts.TransactionReceipt(ctx, blockNumber, blockHash, hash)
+}
+
 // Get bytecode of a contract by address.
 func (c *Client) GetCode(ctx context.Context, address common.Address, blockNumber uint64) ([]byte, error) {
 	var code hexutil.Bytes
@@ -115,7 +204,7 @@ func (c *Client) GetCode(ctx context.Context, address common.Address, blockNumbe
 	}
 	err := c.rpcClient.CallContext(ctx, &code, "eth_getCode", address, "0x"+fmt.Sprintf("%x", blockNumber))
 	if err != nil {
-		log.Printf("Failed to get code for address %s at block %d: %v", address.Hex(), blockNumber, err)
+		c.logger.Warn("Failed to get code", logging.AddressKey, address.Hex(), logging.BlockKey, blockNumber, logging.ErrorKey, err)
 		return nil, err
 	}
 
@@ -124,47 +213,81 @@ func (c *Client) GetCode(ctx context.Context, address common.Address, blockNumbe
 	}
 	return code, nil
 }
+
+// FindDeploymentBlock returns the first block at which address has code.
+func (c *Client) FindDeploymentBlock(ctx context.Context, address common.Address) (uint64, error) {
+	return seer_common.FindDeploymentBlock(ctx, c, address)
+}
+
+// CallContract executes eth_call with data to address at given block, zero block number means
+// latest block.
+func (c *Client) CallContract(ctx context.Context, address common.Address, data []byte, blockNumber uint64) ([]byte, error) {
+	block := "latest"
+	if blockNumber > 0 {
+		block = "0x" + fmt.Sprintf("%x", blockNumber)
+	}
+
+	var result hexutil.Bytes
+	err := c.rpcClient.CallContext(ctx, &result, "eth_call", map[string]interface{}{
+		"to":   address,
+		"data": hexutil.Bytes(data),
+	}, block)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetStorageAt reads storage slot of contract at given block, zero block number means latest
+// block.
+func (c *Client) GetStorageAt(ctx context.Context, address common.Address, slot common.Hash, blockNumber uint64) (common.Hash, error) {
+	block := "latest"
+	if blockNumber > 0 {
+		block = "0x" + fmt.Sprintf("%x", blockNumber)
+	}
+
+	var result common.Hash
+	err := c.rpcClient.CallContext(ctx, &result, "eth_getStorageAt", address, slot, block)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return result, nil
+}
+
+// ClientFilterLogs fetches logs of query block range. Range is split in halves while provider
+// rejects it because of too many logs, block which still could not be fetched in one request
+// is fetched with separate request for each address of query. If logs of block could not be
+// fetched even then, error is returned, blocks are never skipped.
 func (c *Client) ClientFilterLogs(ctx context.Context, q ethereum.FilterQuery, debug bool) ([]*seer_common.EventJson, error) {
 	var logs []*seer_common.EventJson
 	fromBlock := q.FromBlock
 	toBlock := q.ToBlock
 	batchStep := new(big.Int).Sub(toBlock, fromBlock) // Calculate initial batch step
 
-	for {
+	for fromBlock.Cmp(toBlock) <= 0 {
 		// Calculate the next "lastBlock" within the batch step or adjust to "toBlock" if exceeding
 		nextBlock := new(big.Int).Add(fromBlock, batchStep)
 		if nextBlock.Cmp(toBlock) > 0 {
 			nextBlock = new(big.Int).Set(toBlock)
 		}
 
-		var result []*seer_common.EventJson
-		err := c.rpcClient.CallContext(ctx, &result, "eth_getLogs", struct {
-			FromBlock string           `json:"fromBlock"`
-			ToBlock   string           `json:"toBlock"`
-			Addresses []common.Address `json:"addresses"`
-			Topics    [][]common.Hash  `json:"topics"`
-		}{
-			FromBlock: toHex(fromBlock),
-			ToBlock:   toHex(nextBlock),
-			Addresses: q.Addresses,
-			Topics:    q.Topics,
-		})
-
+		result, err := c.getLogs(ctx, fromBlock, nextBlock, q.Addresses, q.Topics)
 		if err != nil {
-			if strings.Contains(err.Error(), "query returned more than 10000 results") {
+			if !seer_common.IsLogsLimitError(err) {
+				// For any other error, return immediately
+				return nil, err
+			}
+
+			if nextBlock.Cmp(fromBlock) > 0 {
 				// Halve the batch step if too many results and retry
 				batchStep.Div(batchStep, big.NewInt(2))
-				if batchStep.Cmp(big.NewInt(1)) < 0 {
-					// If the batch step is too small we will skip that block
-					fromBlock = new(big.Int).Add(nextBlock, big.NewInt(1))
-					if fromBlock.Cmp(toBlock) > 0 {
-						break
-					}
-					continue
-				}
 				continue
-			} else {
-				// For any other error, return immediately
+			}
+
+			// Single block has more logs than provider returns for one request
+			c.logger.Warn("Too many logs in block, fetching them by address", logging.BlockKey, fromBlock.String(), logging.ErrorKey, err)
+			result, err = c.filterBlockLogsByAddress(ctx, fromBlock, q)
+			if err != nil {
 				return nil, err
 			}
 		}
@@ -174,15 +297,49 @@ func (c *Client) ClientFilterLogs(ctx context.Context, q ethereum.FilterQuery, d
 		fromBlock = new(big.Int).Add(nextBlock, big.NewInt(1))
 
 		if debug {
-			log.Printf("Fetched logs: %d", len(result))
+			c.logger.Debug("Fetched logs", "logs", len(result))
 		}
+	}
+
+	return logs, nil
+}
+
+func (c *Client) getLogs(ctx context.Context, fromBlock, toBlock *big.Int, addresses []common.Address, topics [][]common.Hash) ([]*seer_common.EventJson, error) {
+	var result []*seer_common.EventJson
+	err := c.rpcClient.CallContext(ctx, &result, "eth_getLogs", struct {
+		FromBlock string           `json:"fromBlock"`
+		ToBlock   string           `json:"toBlock"`
+		Addresses []common.Address `json:"addresses"`
+		Topics    [][]common.Hash  `json:"topics"`
+	}{
+		FromBlock: toHex(fromBlock),
+		ToBlock:   toHex(toBlock),
+		Addresses: addresses,
+		Topics:    topics,
+	})
+	return result, err
+}
 
-		// Break the loop if we've reached or exceeded "toBlock"
-		if fromBlock.Cmp(toBlock) > 0 {
-			break
+// filterBlockLogsByAddress fetches logs of one block with separate request for each address of
+// query. Logs are returned in order of their index in block.
+func (c *Client) filterBlockLogsByAddress(ctx context.Context, block *big.Int, q ethereum.FilterQuery) ([]*seer_common.EventJson, error) {
+	if len(q.Addresses) < 2 {
+		return nil, fmt.Errorf("unable to fetch logs of block %s, provider limit is exceeded by logs of one block and query could not be split by address", block.String())
+	}
+
+	var logs []*seer_common.EventJson
+	for _, address := range q.Addresses {
+		result, err := c.getLogs(ctx, block, block, []common.Address{address}, q.Topics)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch logs of address %s in block %s: %w", address.Hex(), block.String(), err)
 		}
+		logs = append(logs, result...)
 	}
 
+	sort.SliceStable(logs, func(i, j int) bool {
+		return fromHex(logs[i].LogIndex).Cmp(fromHex(logs[j].LogIndex)) < 0
+	})
+
 	return logs, nil
 }
 
@@ -215,7 +372,7 @@ func (c *Client) FetchBlocksInRange(from, to *big.Int, debug bool) ([]*seer_comm
 
 		blocks = append(blocks, block)
 		if debug {
-			log.Printf("Fetched block number: %d", i)
+			c.logger.Debug("Fetched block", logging.BlockKey, i.String())
 		}
 	}
 
@@ -225,9 +382,7 @@ func (c *Client) FetchBlocksInRange(from, to *big.Int, debug bool) ([]*seer_comm
 // FetchBlocksInRangeAsync fetches blocks within a specified range concurrently.
 func (c *Client) FetchBlocksInRangeAsync(from, to *big.Int, debug bool, maxRequests int) ([]*seer_common.BlockJson, error) {
 	var (
-		blocks          []*seer_common.BlockJson
 		collectedErrors []error
-		mu              sync.Mutex
 		wg              sync.WaitGroup
 		ctx             = context.Background()
 	)
@@ -237,47 +392,49 @@ func (c *Client) FetchBlocksInRangeAsync(from, to *big.Int, debug bool, maxReque
 		blockNumbersRange = append(blockNumbersRange, new(big.Int).Set(i))
 	}
 
-	sem := make(chan struct{}, maxRequests)             // Semaphore to control concurrency
-	errChan := make(chan error, len(blockNumbersRange)) // Channel to collect errors from goroutines
+	// Each goroutine writes to its own index, so blocks are returned in ascending order
+	// regardless of completion order of requests
+	blocks := make([]*seer_common.BlockJson, len(blockNumbersRange))
+
+	limiter := seer_common.NewAdaptiveLimiter(maxRequests) // Concurrency is reduced when provider rate limits requests
+	errChan := make(chan error, len(blockNumbersRange))    // Channel to collect errors from goroutines
 
-	for _, b := range blockNumbersRange {
+	for i, b := range blockNumbersRange {
 		wg.Add(1)
-		go func(b *big.Int) {
+		go func(i int, b *big.Int) {
 			defer wg.Done()
 
-			sem <- struct{}{} // Acquire semaphore
-			defer func() { <-sem }()
-
 			defer func() {
 				if r := recover(); r != nil {
 					errChan <- fmt.Errorf("panic in goroutine for block %s: %v", b.String(), r)
 				}
 			}()
 
-			ctxWithTimeout, cancel := context.WithTimeout(ctx, c.timeout)
-
-			defer cancel()
+			var block *seer_common.BlockJson
+			getErr := seer_common.RetryRateLimited(ctx, limiter, func() error {
+				ctxWithTimeout, cancel := context.WithTimeout(ctx, c.timeout)
+				defer cancel()
 
-			block, getErr := c.GetBlockByNumber(ctxWithTimeout, b, true)
+				var err error
+				block, err = c.GetBlockByNumber(ctxWithTimeout, b, true)
+				return err
+			})
 			if getErr != nil {
-				log.Printf("Failed to fetch block number: %d, error: %v", b, getErr)
+				c.logger.Error("Failed to fetch block", logging.BlockKey, b.String(), logging.ErrorKey, getErr)
 				errChan <- getErr
 				return
 			}
 
-			mu.Lock()
-			blocks = append(blocks, block)
-			mu.Unlock()
+			blocks[i] = block
 
 			if debug {
-				log.Printf("Fetched block number: %d", b)
+				c.logger.Debug("Fetched block", logging.BlockKey, b.String())
 			}
 
-		}(b)
+		}(i, b)
 	}
 
 	wg.Wait()
-	close(sem)
 	close(errChan)
 
 	for err := range errChan {
@@ -308,6 +465,11 @@ func (c *Client) ParseBlocksWithTransactions(from, to *big.Int, debug bool, maxR
 		return nil, fetchErr
 	}
 
+	// Blocks of inconsistent batch should be fetched again instead of being stored
+	if continuityErr := seer_common.VerifyBlocksContinuity(blocksWithTxsJson); continuityErr != nil {
+		return nil, continuityErr
+	}
+
 	var parsedBlocks []*ArbitrumSepoliaBlock
 	for _, blockAndTxsJson := range blocksWithTxsJson {
 		// Convert BlockJson to Block and Transactions as required.
@@ -316,6 +478,11 @@ func (c *Client) ParseBlocksWithTransactions(from, to *big.Int, debug bool, maxR
 		for _, txJson := range blockAndTxsJson.Transactions {
 			txJson.BlockTimestamp = blockAndTxsJson.Timestamp
 
+			// Legacy transactions could miss chain ID and sender fields in old blocks
+			if normErr := seer_common.NormalizeLegacyTransaction(&txJson); normErr != nil {
+				c.logger.Warn("Unable to normalize legacy transaction", logging.TxKey, txJson.Hash, logging.ErrorKey, normErr)
+			}
+
 			parsedTransaction := ToProtoSingleTransaction(&txJson)
 			parsedBlock.Transactions = append(parsedBlock.Transactions, parsedTransaction)
 		}
@@ -338,7 +505,7 @@ func (c *Client) ParseEvents(from, to *big.Int, blocksCache map[uint64]indexer.B
 	}, debug)
 
 	if err != nil {
-		fmt.Println("Error fetching logs: ", err)
+		c.logger.Error("Failed to fetch logs", logging.FromBlockKey, from.String(), logging.ToBlockKey, to.String(), logging.ErrorKey, err)
 		return nil, err
 	}
 
@@ -353,27 +520,99 @@ func (c *Client) ParseEvents(from, to *big.Int, blocksCache map[uint64]indexer.B
 	return parsedEvents, nil
 }
 
-func (c *Client) FetchAsProtoBlocksWithEvents(from, to *big.Int, debug bool, maxRequests int) ([]proto.Message, []indexer.BlockIndex, uint64, error) {
-	blocks, err := c.ParseBlocksWithTransactions(from, to, debug, maxRequests)
-	if err != nil {
-		return nil, nil, 0, err
+// ParseEventsFromReceipts takes logs of blocks from receipts of their transactions, so logs
+// removed by reorg are never attached and logs are ordered as in canonical receipts.
+func (c *Client) ParseEventsFromReceipts(blocks []*ArbitrumSepoliaBlock, maxRequests int) ([]*ArbitrumSepoliaEventLog, error) {
+	if maxRequests < 1 {
+		maxRequests = 1
 	}
 
-	var blocksSize uint64
+	blocksEvents := make([][]*ArbitrumSepoliaEventLog, len(blocks))
+	blocksErrs := make([]error, len(blocks))
+	sem := make(chan struct{}, maxRequests)
 
-	blocksCache := make(map[uint64]indexer.BlockCache)
+	var wg sync.WaitGroup
+	for i, block := range blocks {
+		if len(block.Transactions) == 0 {
+			continue
+		}
 
-	for _, block := range blocks {
-		blocksCache[block.BlockNumber] = indexer.BlockCache{
-			BlockNumber:    block.BlockNumber,
-			BlockHash:      block.Hash,
-			BlockTimestamp: block.Timestamp,
-		} // Assuming block.BlockNumber is int64 and block.Hash is string
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, block *ArbitrumSepoliaBlock) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			txHashes := make([]string, len(block.Transactions))
+			for j, tx := range block.Transactions {
+				txHashes[j] = tx.Hash
+			}
+
+			ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
+			defer cancel()
+
+			logs, err := c.receipts.LogsOfTransactions(ctxWithTimeout, block.BlockNumber, block.Hash, txHashes)
+			if err != nil {
+				blocksErrs[i] = err
+				return
+			}
+			for _, log := range logs {
+				blocksEvents[i] = append(blocksEvents[i], ToProtoSingleEventLog(log))
+			}
+		}(i, block)
 	}
+	wg.Wait()
 
-	events, err := c.ParseEvents(from, to, blocksCache, debug)
-	if err != nil {
-		return nil, nil, 0, err
+	var events []*ArbitrumSepoliaEventLog
+	for i := range blocks {
+		if blocksErrs[i] != nil {
+			return nil, blocksErrs[i]
+		}
+		events = append(events, blocksEvents[i]...)
+	}
+
+	return events, nil
+}
+
+func (c *Client) FetchAsProtoBlocksWithEvents(from, to *big.Int, debug bool, maxRequests int) ([]proto.Message, []indexer.BlockIndex, uint64, error) {
+	var blocks []*ArbitrumSepoliaBlock
+	var events []*ArbitrumSepoliaEventLog
+	var blocksErr, eventsErr error
+
+	// Logs of range do not depend on its blocks, both are fetched at the same time. Logs from
+	// receipts are requested for transactions of fetched blocks.
+	logsFromReceipts := seer_common.LogsFromReceiptsFor("arbitrum_sepolia")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		blocks, blocksErr = c.ParseBlocksWithTransactions(from, to, debug, maxRequests)
+	}()
+	if !logsFromReceipts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			events, eventsErr = c.ParseEvents(from, to, nil, debug)
+		}()
+	}
+	wg.Wait()
+
+	if blocksErr != nil {
+		return nil, nil, 0, blocksErr
+	}
+	if logsFromReceipts {
+		events, eventsErr = c.ParseEventsFromReceipts(blocks, maxRequests)
+	}
+	if eventsErr != nil {
+		return nil, nil, 0, eventsErr
+	}
+
+	var blocksSize uint64
+
+	eventsByTransaction := make(map[string][]*ArbitrumSepoliaEventLog)
+	for _, event := range events {
+		eventsByTransaction[event.TransactionHash] = append(eventsByTransaction[event.TransactionHash], event)
 	}
 
 	var blocksProto []proto.Message
@@ -381,15 +620,11 @@ func (c *Client) FetchAsProtoBlocksWithEvents(from, to *big.Int, debug bool, max
 
 	for bI, block := range blocks {
 		for _, tx := range block.Transactions {
-			for _, event := range events {
-				if tx.Hash == event.TransactionHash {
-					tx.Logs = append(tx.Logs, event)
-				}
-			}
+			tx.Logs = append(tx.Logs, eventsByTransaction[tx.Hash]...)
 		}
 
 		// Prepare blocks to index
-		blocksIndex = append(blocksIndex, indexer.NewBlockIndex("arbitrum_sepolia",
+		blockIndex := indexer.NewBlockIndex("arbitrum_sepolia",
 			block.BlockNumber,
 			block.Hash,
 			block.Timestamp,
@@ -397,7 +632,9 @@ func (c *Client) FetchAsProtoBlocksWithEvents(from, to *big.Int, debug bool, max
 			uint64(bI),
 			"",
 			block.L1BlockNumber,
-		))
+		)
+		blockIndex.Miner = block.Miner
+		blocksIndex = append(blocksIndex, blockIndex)
 
 		blocksSize += uint64(proto.Size(block))
 		blocksProto = append(blocksProto, block) // Assuming block is already a proto.Message
@@ -475,31 +712,48 @@ func ToEntireBlocksBatchFromLogProto(obj *ArbitrumSepoliaBlocksBatch) *seer_comm
 				BlockTimestamp:       fmt.Sprintf("%d", tx.BlockTimestamp),
 				AccessList:           accessList,
 				YParity:              tx.YParity,
+				MaxFeePerBlobGas:     tx.MaxFeePerBlobGas,
+				BlobVersionedHashes:  tx.BlobVersionedHashes,
 
 				Events: events,
 			})
 		}
 
+		var withdrawals []seer_common.WithdrawalJson
+		for _, w := range b.Withdrawals {
+			withdrawals = append(withdrawals, seer_common.WithdrawalJson{
+				Index:          fmt.Sprintf("%d", w.Index),
+				ValidatorIndex: fmt.Sprintf("%d", w.ValidatorIndex),
+				Address:        w.Address,
+				Amount:         fmt.Sprintf("%d", w.Amount),
+			})
+		}
+
 		blocksBatchJson.Blocks = append(blocksBatchJson.Blocks, seer_common.BlockJson{
-			Difficulty:       fmt.Sprintf("%d", b.Difficulty),
-			ExtraData:        b.ExtraData,
-			GasLimit:         fmt.Sprintf("%d", b.GasLimit),
-			GasUsed:          fmt.Sprintf("%d", b.GasUsed),
-			Hash:             b.Hash,
-			LogsBloom:        b.LogsBloom,
-			Miner:            b.Miner,
-			Nonce:            b.Nonce,
-			BlockNumber:      fmt.Sprintf("%d", b.BlockNumber),
-			ParentHash:       b.ParentHash,
-			ReceiptsRoot:     b.ReceiptsRoot,
-			Sha3Uncles:       b.Sha3Uncles,
-			StateRoot:        b.StateRoot,
-			Timestamp:        fmt.Sprintf("%d", b.Timestamp),
-			TotalDifficulty:  b.TotalDifficulty,
-			TransactionsRoot: b.TransactionsRoot,
-			Size:             fmt.Sprintf("%d", b.Size),
-			BaseFeePerGas:    b.BaseFeePerGas,
-			IndexedAt:        fmt.Sprintf("%d", b.IndexedAt),
+			Difficulty:            fmt.Sprintf("%d", b.Difficulty),
+			ExtraData:             b.ExtraData,
+			GasLimit:              fmt.Sprintf("%d", b.GasLimit),
+			GasUsed:               fmt.Sprintf("%d", b.GasUsed),
+			Hash:                  b.Hash,
+			LogsBloom:             b.LogsBloom,
+			Miner:                 b.Miner,
+			Nonce:                 b.Nonce,
+			BlockNumber:           fmt.Sprintf("%d", b.BlockNumber),
+			ParentHash:            b.ParentHash,
+			ReceiptsRoot:          b.ReceiptsRoot,
+			Sha3Uncles:            b.Sha3Uncles,
+			StateRoot:             b.StateRoot,
+			Timestamp:             fmt.Sprintf("%d", b.Timestamp),
+			TotalDifficulty:       b.TotalDifficulty,
+			TransactionsRoot:      b.TransactionsRoot,
+			Size:                  fmt.Sprintf("%d", b.Size),
+			BaseFeePerGas:         b.BaseFeePerGas,
+			IndexedAt:             fmt.Sprintf("%d", b.IndexedAt),
+			BlobGasUsed:           fmt.Sprintf("%d", b.BlobGasUsed),
+			ExcessBlobGas:         fmt.Sprintf("%d", b.ExcessBlobGas),
+			Withdrawals:           withdrawals,
+			WithdrawalsRoot:       b.WithdrawalsRoot,
+			ParentBeaconBlockRoot: b.ParentBeaconBlockRoot,
 
 			MixHash:       b.MixHash,
 			SendCount:     b.SendCount,
@@ -514,26 +768,41 @@ func ToEntireBlocksBatchFromLogProto(obj *ArbitrumSepoliaBlocksBatch) *seer_comm
 }
 
 func ToProtoSingleBlock(obj *seer_common.BlockJson) *ArbitrumSepoliaBlock {
+	var withdrawals []*ArbitrumSepoliaWithdrawal
+	for _, w := range obj.Withdrawals {
+		withdrawals = append(withdrawals, &ArbitrumSepoliaWithdrawal{
+			Index:          fromHex(w.Index).Uint64(),
+			ValidatorIndex: fromHex(w.ValidatorIndex).Uint64(),
+			Address:        w.Address,
+			Amount:         fromHex(w.Amount).Uint64(),
+		})
+	}
+
 	return &ArbitrumSepoliaBlock{
-		BlockNumber:      fromHex(obj.BlockNumber).Uint64(),
-		Difficulty:       fromHex(obj.Difficulty).Uint64(),
-		ExtraData:        obj.ExtraData,
-		GasLimit:         fromHex(obj.GasLimit).Uint64(),
-		GasUsed:          fromHex(obj.GasUsed).Uint64(),
-		BaseFeePerGas:    obj.BaseFeePerGas,
-		Hash:             obj.Hash,
-		LogsBloom:        obj.LogsBloom,
-		Miner:            obj.Miner,
-		Nonce:            obj.Nonce,
-		ParentHash:       obj.ParentHash,
-		ReceiptsRoot:     obj.ReceiptsRoot,
-		Sha3Uncles:       obj.Sha3Uncles,
-		Size:             fromHex(obj.Size).Uint64(),
-		StateRoot:        obj.StateRoot,
-		Timestamp:        fromHex(obj.Timestamp).Uint64(),
-		TotalDifficulty:  obj.TotalDifficulty,
-		TransactionsRoot: obj.TransactionsRoot,
-		IndexedAt:        fromHex(obj.IndexedAt).Uint64(),
+		BlockNumber:           fromHex(obj.BlockNumber).Uint64(),
+		Difficulty:            fromHex(obj.Difficulty).Uint64(),
+		ExtraData:             obj.ExtraData,
+		GasLimit:              fromHex(obj.GasLimit).Uint64(),
+		GasUsed:               fromHex(obj.GasUsed).Uint64(),
+		BaseFeePerGas:         obj.BaseFeePerGas,
+		Hash:                  obj.Hash,
+		LogsBloom:             obj.LogsBloom,
+		Miner:                 obj.Miner,
+		Nonce:                 obj.Nonce,
+		ParentHash:            obj.ParentHash,
+		ReceiptsRoot:          obj.ReceiptsRoot,
+		Sha3Uncles:            obj.Sha3Uncles,
+		Size:                  fromHex(obj.Size).Uint64(),
+		StateRoot:             obj.StateRoot,
+		Timestamp:             fromHex(obj.Timestamp).Uint64(),
+		TotalDifficulty:       obj.TotalDifficulty,
+		TransactionsRoot:      obj.TransactionsRoot,
+		IndexedAt:             fromHex(obj.IndexedAt).Uint64(),
+		BlobGasUsed:           fromHex(obj.BlobGasUsed).Uint64(),
+		ExcessBlobGas:         fromHex(obj.ExcessBlobGas).Uint64(),
+		Withdrawals:           withdrawals,
+		WithdrawalsRoot:       obj.WithdrawalsRoot,
+		ParentBeaconBlockRoot: obj.ParentBeaconBlockRoot,
 
 		MixHash:       obj.MixHash,
 		SendCount:     obj.SendCount,
@@ -576,6 +845,9 @@ func ToProtoSingleTransaction(obj *seer_common.TransactionJson) *ArbitrumSepolia
 
 		AccessList: accessList,
 		YParity:    obj.YParity,
+
+		MaxFeePerBlobGas:    obj.MaxFeePerBlobGas,
+		BlobVersionedHashes: obj.BlobVersionedHashes,
 	}
 }
 
@@ -678,6 +950,23 @@ func (c *Client) DecodeProtoEntireBlockToLabels(rawData *bytes.Buffer, abiMap ma
 		return nil, nil, nil, fmt.Errorf("failed to unmarshal data: %v", err)
 	}
 
+	borStateSyncMode, err := seer_common.BorStateSyncModeFor("arbitrum_sepolia")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	abiCoverage := seer_common.AbiCoverageFor(abiMap)
+
+	standardDecoders, err := seer_common.EnabledStandardDecoders()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	signatureDatabase, err := seer_common.EnabledSignatureDatabase()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	// Shared slices to collect labels
 	var labels []indexer.EventLabel
 	var txLabels []indexer.TransactionLabel
@@ -717,7 +1006,16 @@ func (c *Client) DecodeProtoEntireBlockToLabels(rawData *bytes.Buffer, abiMap ma
 
 				label := indexer.SeerCrawlerLabel
 
-				if addRawTransactions {
+				// Bor state-sync system transactions carry only logs of bridged state
+				eventLabelType := "event"
+				if borStateSyncMode != seer_common.BorStateSyncModeKeep && seer_common.IsBorStateSyncTransaction(tx.Hash, tx.FromAddress, tx.ToAddress, b.BlockNumber, b.Hash) {
+					if borStateSyncMode == seer_common.BorStateSyncModeSkip {
+						continue
+					}
+					eventLabelType = seer_common.BorStateSyncLabelType
+				}
+
+				if addRawTransactions && eventLabelType == "event" {
 					localRawTransactions = append(localRawTransactions, indexer.RawTransaction{
 						Hash:                 tx.Hash,
 						BlockHash:            tx.BlockHash,
@@ -734,88 +1032,156 @@ func (c *Client) DecodeProtoEntireBlockToLabels(rawData *bytes.Buffer, abiMap ma
 						BlockNumber:          b.BlockNumber,
 						TransactionIndex:     tx.TransactionIndex,
 						TransactionType:      tx.TransactionType,
+						MaxFeePerBlobGas:     tx.MaxFeePerBlobGas,
+						BlobVersionedHashes:  tx.BlobVersionedHashes,
 						L1BlockNumber:        &b.L1BlockNumber,
 					})
 				}
 
-				if len(tx.Input) < 10 { // If input is less than 3 characters then it direct transfer
-					continue
-				}
+				// State-sync transactions have no call to decode, only their logs are labeled
+				if eventLabelType == "event" {
+					if standardDecoders.ContractDeployments && seer_common.IsContractCreation(tx.ToAddress) {
+						ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
+						receipt, err := c.BlockTransactionReceipt(ctxWithTimeout, tx.BlockNumber, tx.BlockHash, common.HexToHash(tx.Hash))
+						cancel()
+						if err != nil {
+							errorChan <- fmt.Errorf("error getting receipt of contract creation tx %s: %v", tx.Hash, err)
+							continue
+						}
 
-				// Process transaction labels
-				selector := tx.Input[:10]
+						deploymentLabel, err := seer_common.ContractDeploymentLabel(receipt, tx.FromAddress, tx.Hash, tx.BlockHash, tx.Input, tx.BlockNumber, b.Timestamp)
+						if err != nil {
+							errorChan <- err
+							continue
+						}
+						if deploymentLabel != nil {
+							localTxLabels = append(localTxLabels, *deploymentLabel)
+						}
+					}
 
-				if abiMap[tx.ToAddress] != nil && abiMap[tx.ToAddress][selector] != nil {
+					if len(tx.Input) < 10 { // If input is less than 3 characters then it direct transfer
+						if standardDecoders.NativeTransfers && seer_common.IsWatchedNativeTransfer(abiMap, tx.FromAddress, tx.ToAddress, tx.Value) {
+							ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
+							receipt, err := c.BlockTransactionReceipt(ctxWithTimeout, tx.BlockNumber, tx.BlockHash, common.HexToHash(tx.Hash))
+							cancel()
+							if err != nil {
+								errorChan <- fmt.Errorf("error getting receipt of native transfer tx %s: %v", tx.Hash, err)
+								continue
+							}
+
+							transferLabel, err := seer_common.NativeTransferLabel(receipt, abiMap, tx.FromAddress, tx.ToAddress, tx.Value, tx.Hash, tx.BlockHash, tx.BlockNumber, b.Timestamp)
+							if err != nil {
+								errorChan <- err
+								continue
+							}
+							if transferLabel != nil {
+								localTxLabels = append(localTxLabels, *transferLabel)
+							}
+						}
+						continue
+					}
 
-					txAbiEntry := abiMap[tx.ToAddress][selector]
+					// Process transaction labels
+					selector := tx.Input[:10]
 
-					var initErr error
-					txAbiEntry.Once.Do(func() {
-						txAbiEntry.Abi, initErr = seer_common.GetABI(txAbiEntry.AbiJSON)
+					safeInnerLabel, safeErr := seer_common.SafeInnerCallLabel(c.logger, tx.ToAddress, tx.Input, tx.FromAddress, tx.Hash, tx.BlockHash, tx.BlockNumber, b.Timestamp, abiMap, func() (bool, error) {
+						for _, e := range tx.Logs {
+							if seer_common.IsSafeExecutionSuccess(tx.ToAddress, e.Address, e.Topics) {
+								return true, nil
+							}
+						}
+						return false, nil
 					})
-
-					// Check if an error occurred during ABI parsing
-					if initErr != nil || txAbiEntry.Abi == nil {
-						errorChan <- fmt.Errorf("error getting ABI for address %s: %v", tx.ToAddress, initErr)
+					if safeErr != nil {
+						errorChan <- fmt.Errorf("error decoding Safe inner call for tx %s: %v", tx.Hash, safeErr)
 						continue
 					}
+					if safeInnerLabel != nil {
+						localTxLabels = append(localTxLabels, *safeInnerLabel)
+					}
 
-					inputData, err := hex.DecodeString(tx.Input[2:])
-					if err != nil {
-						errorChan <- fmt.Errorf("error decoding input data for tx %s: %v", tx.Hash, err)
-						continue
+					var txAbiEntry *indexer.AbiEntry
+					if abiCoverage.MayContain(tx.ToAddress) {
+						txAbiEntry = seer_common.LookupAbiEntry(abiMap, tx.ToAddress, selector)
 					}
-					decodedArgsTx, decodeErr = seer_common.DecodeTransactionInputDataToInterface(txAbiEntry.Abi, inputData)
-					if decodeErr != nil {
-						fmt.Println("Error decoding transaction not decoded data: ", tx.Hash, decodeErr)
-						decodedArgsTx = map[string]interface{}{
-							"input_raw": tx,
-							"abi":       txAbiEntry.AbiJSON,
-							"selector":  selector,
-							"error":     decodeErr,
+
+					if txAbiEntry != nil {
+
+						var initErr error
+						txAbiEntry.Once.Do(func() {
+							txAbiEntry.Abi, initErr = seer_common.GetABI(txAbiEntry.AbiJSON)
+						})
+
+						// Check if an error occurred during ABI parsing
+						if initErr != nil || txAbiEntry.Abi == nil {
+							errorChan <- fmt.Errorf("error getting ABI for address %s: %v", tx.ToAddress, initErr)
+							continue
 						}
-						label = indexer.SeerCrawlerRawLabel
-					}
 
-					ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
+						inputData, err := hex.DecodeString(tx.Input[2:])
+						if err != nil {
+							errorChan <- fmt.Errorf("error decoding input data for tx %s: %v", tx.Hash, err)
+							continue
+						}
+						decodedArgsTx, decodeErr = seer_common.DecodeTransactionInputDataToInterface(txAbiEntry.Abi, inputData)
+						if decodeErr != nil {
+							c.logger.Warn("Unable to decode transaction input, raw label is written", logging.TxKey, tx.Hash, logging.ErrorKey, decodeErr)
+							decodedArgsTx = map[string]interface{}{
+								"input_raw": tx,
+								"abi":       txAbiEntry.AbiJSON,
+								"selector":  selector,
+								"error":     decodeErr,
+							}
+							label = indexer.SeerCrawlerRawLabel
+						}
 
-					defer cancel()
+						ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
 
-					receipt, err := c.TransactionReceipt(ctxWithTimeout, common.HexToHash(tx.Hash))
-					if err != nil {
-						errorChan <- fmt.Errorf("error getting transaction receipt for tx %s: %v", tx.Hash, err)
-						continue
-					}
+						defer cancel()
 
-					// check if the transaction was successful
-					if receipt.Status == 1 {
-						decodedArgsTx["status"] = 1
-					} else {
-						decodedArgsTx["status"] = 0
-					}
+						receipt, err := c.BlockTransactionReceipt(ctxWithTimeout, tx.BlockNumber, tx.BlockHash, common.HexToHash(tx.Hash))
+						if err != nil {
+							errorChan <- fmt.Errorf("error getting transaction receipt for tx %s: %v", tx.Hash, err)
+							continue
+						}
 
-					txLabelDataBytes, err := json.Marshal(decodedArgsTx)
-					if err != nil {
-						errorChan <- fmt.Errorf("error converting decodedArgsTx to JSON for tx %s: %v", tx.Hash, err)
-						continue
-					}
+						// check if the transaction was successful
+						if receipt.Status == 1 {
+							decodedArgsTx["status"] = 1
+						} else {
+							decodedArgsTx["status"] = 0
+						}
 
-					// Convert transaction to label
-					transactionLabel := indexer.TransactionLabel{
-						Address:         tx.ToAddress,
-						BlockNumber:     tx.BlockNumber,
-						BlockHash:       tx.BlockHash,
-						CallerAddress:   tx.FromAddress,
-						LabelName:       txAbiEntry.AbiName,
-						LabelType:       "tx_call",
-						OriginAddress:   tx.FromAddress,
-						Label:           label,
-						TransactionHash: tx.Hash,
-						LabelData:       string(txLabelDataBytes), // Convert JSON byte slice to string
-						BlockTimestamp:  b.Timestamp,
-					}
+						if safeInnerLabel != nil {
+							decodedArgsTx["inner_call"] = map[string]interface{}{
+								"address":    safeInnerLabel.Address,
+								"label_name": safeInnerLabel.LabelName,
+							}
+						}
+
+						txLabelDataBytes, err := json.Marshal(decodedArgsTx)
+						if err != nil {
+							errorChan <- fmt.Errorf("error converting decodedArgsTx to JSON for tx %s: %v", tx.Hash, err)
+							continue
+						}
 
-					localTxLabels = append(localTxLabels, transactionLabel)
+						// Convert transaction to label
+						transactionLabel := indexer.TransactionLabel{
+							Address:         tx.ToAddress,
+							BlockNumber:     tx.BlockNumber,
+							BlockHash:       tx.BlockHash,
+							CallerAddress:   tx.FromAddress,
+							LabelName:       txAbiEntry.AbiName,
+							LabelType:       "tx_call",
+							OriginAddress:   tx.FromAddress,
+							Label:           label,
+							TransactionHash: tx.Hash,
+							LabelData:       string(txLabelDataBytes), // Convert JSON byte slice to string
+							BlockTimestamp:  b.Timestamp,
+						}
+
+						localTxLabels = append(localTxLabels, transactionLabel)
+					}
 				}
 
 				// Process events
@@ -823,6 +1189,23 @@ func (c *Client) DecodeProtoEntireBlockToLabels(rawData *bytes.Buffer, abiMap ma
 					var decodedArgsLogs map[string]interface{}
 					label = indexer.SeerCrawlerLabel
 
+					// Standard events are labeled for any address, without ABI jobs
+					if standardLabel := standardDecoders.EventLabel(abiMap, eventLabelType, e.Address, e.Topics, e.Data, tx.FromAddress, e.TransactionHash, e.BlockHash, e.BlockNumber, b.Timestamp, e.LogIndex); standardLabel != nil {
+						localEventLabels = append(localEventLabels, *standardLabel)
+						continue
+					}
+
+					// Logs without jobs in transactions of watched addresses are labeled with local
+					// signature database
+					if signatureLabel := signatureDatabase.EventLabel(abiMap, eventLabelType, tx.ToAddress, e.Address, e.Topics, e.Data, e, tx.FromAddress, e.TransactionHash, e.BlockHash, e.BlockNumber, b.Timestamp, e.LogIndex); signatureLabel != nil {
+						localEventLabels = append(localEventLabels, *signatureLabel)
+						continue
+					}
+
+					if !abiCoverage.MayContain(e.Address) {
+						continue
+					}
+
 					var topicSelector string
 
 					if len(e.Topics) > 0 {
@@ -832,34 +1215,50 @@ func (c *Client) DecodeProtoEntireBlockToLabels(rawData *bytes.Buffer, abiMap ma
 						topicSelector = "0x0"
 					}
 
-					if abiMap[e.Address] == nil || abiMap[e.Address][topicSelector] == nil {
+					abiEntryLog := seer_common.LookupAbiEntry(abiMap, e.Address, topicSelector)
+					if abiEntryLog != nil && !abiEntryLog.MatchesTopics(e.Topics) {
 						continue
 					}
+					if abiEntryLog == nil {
+						if abiMap[e.Address] == nil {
+							continue
+						}
 
-					abiEntryLog := abiMap[e.Address][topicSelector]
-
-					var initErr error
-					abiEntryLog.Once.Do(func() {
-						abiEntryLog.Abi, initErr = seer_common.GetABI(abiEntryLog.AbiJSON)
-					})
-
-					// Check if an error occurred during ABI parsing
-					if initErr != nil || abiEntryLog.Abi == nil {
-						errorChan <- fmt.Errorf("error getting ABI for log address %s: %v", e.Address, initErr)
-						continue
-					}
+						// Anonymous events have no signature topic, log is matched by its shape
+						// with jobs of address which opted in
+						anonymousEntry, anonymousSelector, anonymousArgs, matchErr := seer_common.MatchAnonymousEvent(abiMap[e.Address], e.Topics, e.Data)
+						if matchErr != nil {
+							c.logger.Debug("Skipping log without matching anonymous event", logging.TxKey, e.TransactionHash, "log_index", e.LogIndex, logging.ErrorKey, matchErr)
+							continue
+						}
+						if anonymousEntry == nil {
+							continue
+						}
+						abiEntryLog, topicSelector, decodedArgsLogs = anonymousEntry, anonymousSelector, anonymousArgs
+					} else {
+						var initErr error
+						abiEntryLog.Once.Do(func() {
+							abiEntryLog.Abi, initErr = seer_common.GetABI(abiEntryLog.AbiJSON)
+						})
+
+						// Check if an error occurred during ABI parsing
+						if initErr != nil || abiEntryLog.Abi == nil {
+							errorChan <- fmt.Errorf("error getting ABI for log address %s: %v", e.Address, initErr)
+							continue
+						}
 
-					// Decode the event data
-					decodedArgsLogs, decodeErr = seer_common.DecodeLogArgsToLabelData(abiEntryLog.Abi, e.Topics, e.Data)
-					if decodeErr != nil {
-						fmt.Println("Error decoding event not decoded data: ", e.TransactionHash, decodeErr)
-						decodedArgsLogs = map[string]interface{}{
-							"input_raw": e,
-							"abi":       abiEntryLog.AbiJSON,
-							"selector":  topicSelector,
-							"error":     decodeErr,
+						// Decode the event data
+						decodedArgsLogs, decodeErr = seer_common.DecodeLogArgsToLabelData(abiEntryLog.Abi, e.Topics, e.Data)
+						if decodeErr != nil {
+							c.logger.Warn("Unable to decode event, raw label is written", logging.TxKey, e.TransactionHash, logging.ErrorKey, decodeErr)
+							decodedArgsLogs = map[string]interface{}{
+								"input_raw": e,
+								"abi":       abiEntryLog.AbiJSON,
+								"selector":  topicSelector,
+								"error":     decodeErr,
+							}
+							label = indexer.SeerCrawlerRawLabel
 						}
-						label = indexer.SeerCrawlerRawLabel
 					}
 
 					// Convert decodedArgsLogs map to JSON
@@ -872,7 +1271,7 @@ func (c *Client) DecodeProtoEntireBlockToLabels(rawData *bytes.Buffer, abiMap ma
 					eventLabel := indexer.EventLabel{
 						Label:           label,
 						LabelName:       abiEntryLog.AbiName,
-						LabelType:       "event",
+						LabelType:       eventLabelType,
 						BlockNumber:     e.BlockNumber,
 						BlockHash:       e.BlockHash,
 						Address:         e.Address,
@@ -887,6 +1286,22 @@ func (c *Client) DecodeProtoEntireBlockToLabels(rawData *bytes.Buffer, abiMap ma
 				}
 			}
 
+			if c.tracing {
+				txHashes := make([]string, len(b.Transactions))
+				originAddresses := make(map[string]string)
+				for i, tx := range b.Transactions {
+					txHashes[i] = tx.Hash
+					originAddresses[tx.Hash] = tx.FromAddress
+				}
+
+				internalTxLabels, err := c.internalTransactionLabels(b.BlockNumber, b.Hash, b.Timestamp, txHashes, originAddresses, abiMap)
+				if err != nil {
+					errorChan <- fmt.Errorf("error tracing internal transactions of block %d: %v", b.BlockNumber, err)
+					return
+				}
+				localTxLabels = append(localTxLabels, internalTxLabels...)
+			}
+
 			// Append local labels to shared slices under mutex
 			labelsMutex.Lock()
 			labels = append(labels, localEventLabels...)
@@ -934,21 +1349,21 @@ func (c *Client) DecodeProtoTransactionsToLabels(transactions []string, blocksCa
 		if abiMap[transaction.ToAddress][selector].Abi == nil {
 			abiMap[transaction.ToAddress][selector].Abi, err = seer_common.GetABI(abiMap[transaction.ToAddress][selector].AbiJSON)
 			if err != nil {
-				fmt.Println("Error getting ABI: ", err)
+				c.logger.Error("Failed to parse ABI", logging.AddressKey, transaction.ToAddress, logging.ErrorKey, err)
 				return nil, err
 			}
 		}
 
 		inputData, err := hex.DecodeString(transaction.Input[2:])
 		if err != nil {
-			fmt.Println("Error decoding input data: ", err)
+			c.logger.Error("Failed to decode input data", logging.TxKey, transaction.Hash, logging.ErrorKey, err)
 			return nil, err
 		}
 
 		decodedArgs, decodeErr = seer_common.DecodeTransactionInputDataToInterface(abiMap[transaction.ToAddress][selector].Abi, inputData)
 
 		if decodeErr != nil {
-			fmt.Println("Error decoding transaction not decoded data: ", transaction.Hash, decodeErr)
+			c.logger.Warn("Unable to decode transaction input, raw label is written", logging.TxKey, transaction.Hash, logging.ErrorKey, decodeErr)
 			decodedArgs = map[string]interface{}{
 				"input_raw": transaction,
 				"abi":       abiMap[transaction.ToAddress][selector].AbiJSON,
@@ -960,7 +1375,7 @@ func (c *Client) DecodeProtoTransactionsToLabels(transactions []string, blocksCa
 
 		labelDataBytes, err := json.Marshal(decodedArgs)
 		if err != nil {
-			fmt.Println("Error converting decodedArgs to JSON: ", err)
+			c.logger.Error("Failed to marshal decoded arguments", logging.TxKey, transaction.Hash, logging.ErrorKey, err)
 			return nil, err
 		}
 
@@ -989,6 +1404,19 @@ func (c *Client) DecodeProtoTransactionsToLabels(transactions []string, blocksCa
 	return labels, nil
 }
 
+// internalTransactionLabels traces block and labels value transfers made by contracts with jobs.
+func (c *Client) internalTransactionLabels(blockNumber uint64, blockHash string, blockTimestamp uint64, txHashes []string, originAddresses map[string]string, abiMap map[string]map[string]*indexer.AbiEntry) ([]indexer.TransactionLabel, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	traces, err := seer_common.TraceBlockByNumber(ctxWithTimeout, c.rpcClient, blockNumber, txHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	return seer_common.InternalTransactionLabels(traces, originAddresses, blockNumber, blockHash, blockTimestamp, abiMap)
+}
+
 func (c *Client) GetTransactionByHash(ctx context.Context, hash string) (*seer_common.TransactionJson, error) {
 	var tx *seer_common.TransactionJson
 	err := c.rpcClient.CallContext(ctx, &tx, "eth_getTransactionByHash", hash)
@@ -1033,6 +1461,21 @@ func (c *Client) GetTransactionsLabels(startBlock uint64, endBlock uint64, abiMa
 			Transactions:   make(map[string]seer_common.TransactionJson),
 		}
 
+		if c.tracing {
+			txHashes := make([]string, len(block.Transactions))
+			originAddresses := make(map[string]string)
+			for i, tx := range block.Transactions {
+				txHashes[i] = tx.Hash
+				originAddresses[tx.Hash] = tx.FromAddress
+			}
+
+			internalTxLabels, err := c.internalTransactionLabels(blockNumber, block.Hash, blockTimestamp, txHashes, originAddresses, abiMap)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error tracing internal transactions of block %d: %v", blockNumber, err)
+			}
+			transactionsLabels = append(transactionsLabels, internalTxLabels...)
+		}
+
 		for _, tx := range block.Transactions {
 
 			label := indexer.SeerCrawlerLabel
@@ -1047,34 +1490,58 @@ func (c *Client) GetTransactionsLabels(startBlock uint64, endBlock uint64, abiMa
 
 			selector := tx.Input[:10]
 
-			if abiMap[tx.ToAddress] != nil && abiMap[tx.ToAddress][selector] != nil {
+			safeInnerLabel, safeErr := seer_common.SafeInnerCallLabel(c.logger, tx.ToAddress, tx.Input, tx.FromAddress, tx.Hash, tx.BlockHash, blockNumber, blockTimestamp, abiMap, func() (bool, error) {
+				ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
+				defer cancel()
 
-				abiEntryTx := abiMap[tx.ToAddress][selector]
+				receipt, err := c.BlockTransactionReceipt(ctxWithTimeout, blockNumber, block.Hash, common.HexToHash(tx.Hash))
+				if err != nil {
+					return false, err
+				}
+				for _, l := range receipt.Logs {
+					topics := make([]string, len(l.Topics))
+					for i, topic := range l.Topics {
+						topics[i] = topic.Hex()
+					}
+					if seer_common.IsSafeExecutionSuccess(tx.ToAddress, l.Address.Hex(), topics) {
+						return true, nil
+					}
+				}
+				return false, nil
+			})
+			if safeErr != nil {
+				c.logger.Error("Failed to decode Safe inner call", logging.TxKey, tx.Hash, logging.ErrorKey, safeErr)
+				return nil, nil, safeErr
+			}
+			if safeInnerLabel != nil {
+				transactionsLabels = append(transactionsLabels, *safeInnerLabel)
+			}
 
+			if abiEntryTx := seer_common.LookupAbiEntry(abiMap, tx.ToAddress, selector); abiEntryTx != nil {
 				var err error
 				abiEntryTx.Once.Do(func() {
 					abiEntryTx.Abi, err = seer_common.GetABI(abiEntryTx.AbiJSON)
 					if err != nil {
-						fmt.Println("Error getting ABI: ", err)
+						c.logger.Error("Failed to parse ABI", logging.AddressKey, tx.ToAddress, logging.ErrorKey, err)
 						return
 					}
 				})
 
 				// Check if an error occurred during ABI parsing
 				if abiEntryTx.Abi == nil {
-					fmt.Println("Error getting ABI: ", err)
+					c.logger.Error("Failed to parse ABI", logging.AddressKey, tx.ToAddress, logging.ErrorKey, err)
 					return nil, nil, err
 				}
 
 				inputData, err := hex.DecodeString(tx.Input[2:])
 				if err != nil {
-					fmt.Println("Error decoding input data: ", err)
+					c.logger.Error("Failed to decode input data", logging.TxKey, tx.Hash, logging.ErrorKey, err)
 					return nil, nil, err
 				}
 
 				decodedArgsTx, decodeErr := seer_common.DecodeTransactionInputDataToInterface(abiEntryTx.Abi, inputData)
 				if decodeErr != nil {
-					fmt.Println("Error decoding transaction not decoded data: ", tx.Hash, decodeErr)
+					c.logger.Warn("Unable to decode transaction input, raw label is written", logging.TxKey, tx.Hash, logging.ErrorKey, decodeErr)
 					decodedArgsTx = map[string]interface{}{
 						"input_raw": tx,
 						"abi":       abiEntryTx.AbiJSON,
@@ -1088,10 +1555,10 @@ func (c *Client) GetTransactionsLabels(startBlock uint64, endBlock uint64, abiMa
 
 				defer cancel()
 
-				receipt, err := c.TransactionReceipt(ctxWithTimeout, common.HexToHash(tx.Hash))
+				receipt, err := c.BlockTransactionReceipt(ctxWithTimeout, blockNumber, block.Hash, common.HexToHash(tx.Hash))
 
 				if err != nil {
-					fmt.Println("Error fetching transaction receipt: ", err)
+					c.logger.Error("Failed to fetch transaction receipt", logging.TxKey, tx.Hash, logging.ErrorKey, err)
 					return nil, nil, err
 				}
 
@@ -1102,9 +1569,16 @@ func (c *Client) GetTransactionsLabels(startBlock uint64, endBlock uint64, abiMa
 					decodedArgsTx["status"] = 0
 				}
 
+				if safeInnerLabel != nil {
+					decodedArgsTx["inner_call"] = map[string]interface{}{
+						"address":    safeInnerLabel.Address,
+						"label_name": safeInnerLabel.LabelName,
+					}
+				}
+
 				txLabelDataBytes, err := json.Marshal(decodedArgsTx)
 				if err != nil {
-					fmt.Println("Error converting decodedArgsTx to JSON: ", err)
+					c.logger.Error("Failed to marshal decoded arguments", logging.TxKey, tx.Hash, logging.ErrorKey, err)
 					return nil, nil, err
 				}
 
@@ -1137,6 +1611,11 @@ func (c *Client) GetTransactionsLabels(startBlock uint64, endBlock uint64, abiMa
 func (c *Client) GetEventsLabels(startBlock uint64, endBlock uint64, abiMap map[string]map[string]*indexer.AbiEntry, blocksCache map[uint64]seer_common.BlockWithTransactions) ([]indexer.EventLabel, error) {
 	var eventsLabels []indexer.EventLabel
 
+	borStateSyncMode, err := seer_common.BorStateSyncModeFor("arbitrum_sepolia")
+	if err != nil {
+		return nil, err
+	}
+
 	if blocksCache == nil {
 		blocksCache = make(map[uint64]seer_common.BlockWithTransactions)
 	}
@@ -1148,9 +1627,15 @@ func (c *Client) GetEventsLabels(startBlock uint64, endBlock uint64, abiMap map[
 
 	for address, selectorMap := range abiMap {
 		for selector, _ := range selectorMap {
+			if indexer.IsAnonymousEventSelector(selector) {
+				continue
+			}
 			topics = append(topics, common.HexToHash(selector))
 		}
 
+		if address == indexer.WildcardAddress {
+			continue
+		}
 		addresses = append(addresses, common.HexToAddress(address))
 	}
 
@@ -1159,7 +1644,17 @@ func (c *Client) GetEventsLabels(startBlock uint64, endBlock uint64, abiMap map[
 		FromBlock: big.NewInt(int64(startBlock)),
 		ToBlock:   big.NewInt(int64(endBlock)),
 		Addresses: addresses,
-		Topics:    [][]common.Hash{topics},
+		Topics:    append([][]common.Hash{topics}, seer_common.IndexedTopicsFilter(abiMap)...),
+	}
+
+	// Wildcard jobs match logs of any address, so only topics are filtered
+	if seer_common.HasWildcardJobs(abiMap) {
+		filter.Addresses = nil
+	}
+
+	// Logs of anonymous events have arbitrary first topic, so only addresses are filtered
+	if seer_common.HasAnonymousEventJobs(abiMap) {
+		filter.Topics = nil
 	}
 
 	ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
@@ -1185,40 +1680,55 @@ func (c *Client) GetEventsLabels(startBlock uint64, endBlock uint64, abiMap map[
 			topicSelector = "0x0"
 		}
 
-		if abiMap[log.Address] == nil || abiMap[log.Address][topicSelector] == nil {
+		abiEntryLog := seer_common.LookupAbiEntry(abiMap, log.Address, topicSelector)
+		if abiEntryLog != nil && !abiEntryLog.MatchesTopics(log.Topics) {
 			continue
 		}
+		if abiEntryLog == nil {
+			if abiMap[log.Address] == nil {
+				continue
+			}
 
-		abiEntryLog := abiMap[log.Address][topicSelector]
-
-		var initErr error
-		abiEntryLog.Once.Do(func() {
-			abiEntryLog.Abi, initErr = seer_common.GetABI(abiEntryLog.AbiJSON)
-		})
+			anonymousEntry, anonymousSelector, anonymousArgs, matchErr := seer_common.MatchAnonymousEvent(abiMap[log.Address], log.Topics, log.Data)
+			if matchErr != nil {
+				c.logger.Debug("Skipping log without matching anonymous event", logging.TxKey, log.TransactionHash, logging.ErrorKey, matchErr)
+				continue
+			}
+			if anonymousEntry == nil {
+				continue
+			}
+			abiEntryLog, topicSelector, decodedArgsLogs = anonymousEntry, anonymousSelector, anonymousArgs
+		} else {
+			var initErr error
+			abiEntryLog.Once.Do(func() {
+				abiEntryLog.Abi, initErr = seer_common.GetABI(abiEntryLog.AbiJSON)
+			})
 
-		// Check if an error occurred during ABI parsing
-		if initErr != nil || abiEntryLog.Abi == nil {
-			fmt.Println("Error getting ABI: ", initErr)
-			return nil, initErr
-		}
+			// Check if an error occurred during ABI parsing
+			if initErr != nil || abiEntryLog.Abi == nil {
+				c.logger.Error("Failed to parse ABI", logging.AddressKey, log.Address, logging.ErrorKey, initErr)
+				return nil, initErr
+			}
 
-		// Decode the event data
-		decodedArgsLogs, decodeErr := seer_common.DecodeLogArgsToLabelData(abiEntryLog.Abi, log.Topics, log.Data)
-		if decodeErr != nil {
-			fmt.Println("Error decoding event not decoded data: ", log.TransactionHash, decodeErr)
-			decodedArgsLogs = map[string]interface{}{
-				"input_raw": log,
-				"abi":       abiEntryLog.AbiJSON,
-				"selector":  topicSelector,
-				"error":     decodeErr,
+			// Decode the event data
+			var decodeErr error
+			decodedArgsLogs, decodeErr = seer_common.DecodeLogArgsToLabelData(abiEntryLog.Abi, log.Topics, log.Data)
+			if decodeErr != nil {
+				c.logger.Warn("Unable to decode event, raw label is written", logging.TxKey, log.TransactionHash, logging.ErrorKey, decodeErr)
+				decodedArgsLogs = map[string]interface{}{
+					"input_raw": log,
+					"abi":       abiEntryLog.AbiJSON,
+					"selector":  topicSelector,
+					"error":     decodeErr,
+				}
+				label = indexer.SeerCrawlerRawLabel
 			}
-			label = indexer.SeerCrawlerRawLabel
 		}
 
 		// Convert decodedArgsLogs map to JSON
 		labelDataBytes, err := json.Marshal(decodedArgsLogs)
 		if err != nil {
-			fmt.Println("Error converting decodedArgsLogs to JSON: ", err)
+			c.logger.Error("Failed to marshal decoded arguments", logging.TxKey, log.TransactionHash, logging.ErrorKey, err)
 			return nil, err
 		}
 
@@ -1264,11 +1774,20 @@ func (c *Client) GetEventsLabels(startBlock uint64, endBlock uint64, abiMap map[
 			return nil, err
 		}
 
+		// Transaction of bor state-sync logs could be missing in block, it is matched by hash
+		eventLabelType := "event"
+		if borStateSyncMode != seer_common.BorStateSyncModeKeep && seer_common.IsBorStateSyncTransaction(log.TransactionHash, transaction.FromAddress, transaction.ToAddress, blockNumber, log.BlockHash) {
+			if borStateSyncMode == seer_common.BorStateSyncModeSkip {
+				continue
+			}
+			eventLabelType = seer_common.BorStateSyncLabelType
+		}
+
 		// Convert event to label
 		eventLabel := indexer.EventLabel{
 			Label:           label,
 			LabelName:       abiEntryLog.AbiName,
-			LabelType:       "event",
+			LabelType:       eventLabelType,
 			BlockNumber:     blockNumber,
 			BlockHash:       log.BlockHash,
 			Address:         log.Address,
@@ -0,0 +1,157 @@
+package common
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+// Number of ABI maps which coverage filters are kept, synchronizer builds new map for every
+// customer on each cycle, so filters of previous cycles are evicted first
+var AbiCoverageCacheSize = 64
+
+// Bits of coverage filter per address with jobs and number of bits set for each address,
+// false positive rate is below 0.5%
+const (
+	abiCoverageBitsPerAddress = 16
+	abiCoverageHashes         = 3
+	abiCoverageMinBits        = 1024
+)
+
+// AbiCoverage is a bloom filter of addresses of ABI map. Most of transactions and logs of busy
+// blocks belong to contracts without jobs, filter rejects them without hashing address string
+// for map lookups. Address which may be covered is checked against ABI map as before.
+type AbiCoverage struct {
+	bits []uint64
+	mask uint64
+	// Wildcard jobs could match any address, so filter rejects nothing
+	wildcard bool
+}
+
+// NewAbiCoverage builds coverage filter of addresses of ABI map.
+func NewAbiCoverage(abiMap map[string]map[string]*indexer.AbiEntry) *AbiCoverage {
+	size := uint64(abiCoverageMinBits)
+	for size < uint64(len(abiMap))*abiCoverageBitsPerAddress {
+		size <<= 1
+	}
+
+	_, wildcard := abiMap[indexer.WildcardAddress]
+	coverage := &AbiCoverage{bits: make([]uint64, size/64), mask: size - 1, wildcard: wildcard}
+	for address := range abiMap {
+		h1, h2 := abiCoverageHash(address)
+		for i := uint64(0); i < abiCoverageHashes; i++ {
+			bit := (h1 + i*h2) & coverage.mask
+			coverage.bits[bit/64] |= 1 << (bit % 64)
+		}
+	}
+
+	return coverage
+}
+
+// MayContain returns false if address is not in ABI map of filter, true result could be false
+// positive.
+func (c *AbiCoverage) MayContain(address string) bool {
+	if c.wildcard {
+		return true
+	}
+
+	h1, h2 := abiCoverageHash(address)
+	for i := uint64(0); i < abiCoverageHashes; i++ {
+		bit := (h1 + i*h2) & c.mask
+		if c.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// LookupAbiEntry returns job of address for selector, wildcard job of selector is used if
+// address has no such job. Anonymous events are matched by shape of log, they could not be
+// matched at every address and wildcard jobs of them are ignored.
+func LookupAbiEntry(abiMap map[string]map[string]*indexer.AbiEntry, address, selector string) *indexer.AbiEntry {
+	if entry := abiMap[address][selector]; entry != nil {
+		return entry
+	}
+	if indexer.IsAnonymousEventSelector(selector) {
+		return nil
+	}
+	return abiMap[indexer.WildcardAddress][selector]
+}
+
+// HasWildcardJobs returns true if ABI map has jobs without address.
+func HasWildcardJobs(abiMap map[string]map[string]*indexer.AbiEntry) bool {
+	return len(abiMap[indexer.WildcardAddress]) > 0
+}
+
+// abiCoverageHash mixes characters of hex address into two hashes. Addresses are uniformly
+// distributed, so 16 characters after 0x prefix are enough and no allocation is made.
+func abiCoverageHash(address string) (uint64, uint64) {
+	var lo, hi uint64
+	if len(address) >= 18 {
+		for i := 2; i < 10; i++ {
+			lo = lo<<8 | uint64(address[i])
+		}
+		for i := 10; i < 18; i++ {
+			hi = hi<<8 | uint64(address[i])
+		}
+	} else {
+		for i := 0; i < len(address); i++ {
+			lo = lo<<8 | uint64(address[i])
+		}
+	}
+
+	h1 := mix64(lo ^ mix64(hi))
+	return h1, mix64(h1) | 1
+}
+
+// mix64 is finalizer of splitmix64, every bit of result depends on every bit of x.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	return x ^ x>>31
+}
+
+type abiCoverageEntry struct {
+	abiMap    map[string]map[string]*indexer.AbiEntry
+	addresses int
+	coverage  *AbiCoverage
+}
+
+var (
+	abiCoverageMu    sync.Mutex
+	abiCoverageCache []abiCoverageEntry
+)
+
+// AbiCoverageFor returns coverage filter of ABI map, filter is built once per map and rebuilt
+// when addresses are added to it. Cache references maps of its filters, so map of entry could
+// not be collected and its address taken by a new map with other jobs.
+func AbiCoverageFor(abiMap map[string]map[string]*indexer.AbiEntry) *AbiCoverage {
+	pointer := reflect.ValueOf(abiMap).UnsafePointer()
+
+	abiCoverageMu.Lock()
+	defer abiCoverageMu.Unlock()
+
+	for i, entry := range abiCoverageCache {
+		if reflect.ValueOf(entry.abiMap).UnsafePointer() != pointer {
+			continue
+		}
+		if entry.addresses != len(abiMap) {
+			entry.addresses = len(abiMap)
+			entry.coverage = NewAbiCoverage(abiMap)
+			abiCoverageCache[i] = entry
+		}
+		return entry.coverage
+	}
+
+	coverage := NewAbiCoverage(abiMap)
+	abiCoverageCache = append(abiCoverageCache, abiCoverageEntry{abiMap: abiMap, addresses: len(abiMap), coverage: coverage})
+	if len(abiCoverageCache) > AbiCoverageCacheSize {
+		abiCoverageCache[0] = abiCoverageEntry{}
+		abiCoverageCache = abiCoverageCache[1:]
+	}
+
+	return coverage
+}
@@ -0,0 +1,166 @@
+package common
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/G7DAO/seer/indexer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// staticSize returns size of ABI encoded type in head of data, second value is false for
+// dynamic types which are encoded as offset in head and value in tail.
+func staticSize(t abi.Type) (int, bool) {
+	switch t.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy:
+		return 32, false
+	case abi.ArrayTy:
+		elemSize, static := staticSize(*t.Elem)
+		if !static {
+			return 32, false
+		}
+		return t.Size * elemSize, true
+	case abi.TupleTy:
+		size := 0
+		for _, elem := range t.TupleElems {
+			elemSize, static := staticSize(*elem)
+			if !static {
+				return 32, false
+			}
+			size += elemSize
+		}
+		return size, true
+	default:
+		return 32, true
+	}
+}
+
+// anonymousEventFits checks if shape of log matches anonymous event: every topic is an indexed
+// argument and data has exact size of non indexed static arguments, or at least size of head
+// if some of them are dynamic.
+func anonymousEventFits(event abi.Event, topicsCount int, data []byte) bool {
+	indexedCount := 0
+	headSize := 0
+	allStatic := true
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexedCount++
+			continue
+		}
+		size, static := staticSize(input.Type)
+		headSize += size
+		allStatic = allStatic && static
+	}
+
+	if indexedCount != topicsCount || len(data)%32 != 0 {
+		return false
+	}
+	if allStatic {
+		return len(data) == headSize
+	}
+	return len(data) >= headSize
+}
+
+// decodeAnonymousLog decodes topics and data of log as arguments of anonymous event.
+func decodeAnonymousLog(event abi.Event, topics []string, data []byte) (map[string]interface{}, error) {
+	var topicHashes []common.Hash
+	for _, topic := range topics {
+		topicHashes = append(topicHashes, common.HexToHash(topic))
+	}
+
+	labelData := map[string]interface{}{
+		"type":      "event",
+		"name":      event.Name,
+		"anonymous": true,
+	}
+	args := make(map[string]interface{})
+
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if err := abi.ParseTopicsIntoMap(args, indexed, topicHashes); err != nil {
+		return nil, err
+	}
+	if err := event.Inputs.UnpackIntoMap(args, data); err != nil {
+		return nil, err
+	}
+	labelDataArgs(event.Inputs, args)
+
+	labelData["args"] = args
+	return labelData, nil
+}
+
+// MatchAnonymousEvent looks for anonymous event job of address which log could be decoded
+// with. Log matches job if count of topics and size of data fit event and decoding succeeds,
+// logs which fit several jobs are ambiguous and returned with error.
+func MatchAnonymousEvent(addressAbis map[string]*indexer.AbiEntry, topics []string, data string) (*indexer.AbiEntry, string, map[string]interface{}, error) {
+	var selectors []string
+	for selector := range addressAbis {
+		if indexer.IsAnonymousEventSelector(selector) {
+			selectors = append(selectors, selector)
+		}
+	}
+	if len(selectors) == 0 {
+		return nil, "", nil, nil
+	}
+	sort.Strings(selectors)
+
+	dataBytes, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to decode data string: %v", err)
+	}
+
+	var matchedEntry *indexer.AbiEntry
+	var matchedSelector string
+	var matchedData map[string]interface{}
+	var matchedNames []string
+	for _, selector := range selectors {
+		abiEntry := addressAbis[selector]
+
+		var initErr error
+		abiEntry.Once.Do(func() {
+			abiEntry.Abi, initErr = GetABI(abiEntry.AbiJSON)
+		})
+		if initErr != nil || abiEntry.Abi == nil {
+			return nil, "", nil, fmt.Errorf("error getting ABI of anonymous event job %s: %v", selector, initErr)
+		}
+
+		for _, event := range abiEntry.Abi.Events {
+			if !event.Anonymous || !anonymousEventFits(event, len(topics), dataBytes) {
+				continue
+			}
+
+			labelData, decodeErr := decodeAnonymousLog(event, topics, dataBytes)
+			if decodeErr != nil {
+				continue
+			}
+
+			matchedEntry, matchedSelector, matchedData = abiEntry, selector, labelData
+			matchedNames = append(matchedNames, event.Name)
+		}
+	}
+
+	if len(matchedNames) > 1 {
+		return nil, "", nil, fmt.Errorf("log fits %d anonymous events: %s", len(matchedNames), strings.Join(matchedNames, ", "))
+	}
+
+	return matchedEntry, matchedSelector, matchedData, nil
+}
+
+// HasAnonymousEventJobs returns true if any address of map has anonymous event job.
+func HasAnonymousEventJobs(abiMap map[string]map[string]*indexer.AbiEntry) bool {
+	for _, addressAbis := range abiMap {
+		for selector := range addressAbis {
+			if indexer.IsAnonymousEventSelector(selector) {
+				return true
+			}
+		}
+	}
+	return false
+}
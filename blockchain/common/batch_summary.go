@@ -0,0 +1,75 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// BlocksBatchSummary describes contents of decoded batch of blocks.
+type BlocksBatchSummary struct {
+	SeerVersion       string   `json:"seer_version"`
+	Blocks            int      `json:"blocks"`
+	FromBlock         uint64   `json:"from_block"`
+	ToBlock           uint64   `json:"to_block"`
+	FromTimestamp     uint64   `json:"from_timestamp"`
+	ToTimestamp       uint64   `json:"to_timestamp"`
+	Transactions      int      `json:"transactions"`
+	Logs              int      `json:"logs"`
+	MissingBlocks     []uint64 `json:"missing_blocks,omitempty"`
+	DuplicatedBlocks  []uint64 `json:"duplicated_blocks,omitempty"`
+	MismatchedParents []uint64 `json:"mismatched_parents,omitempty"`
+}
+
+// SummarizeBlocksBatch counts blocks, transactions and logs of batch and finds blocks of its
+// range which are missing, duplicated or do not follow parent hash of previous block.
+func SummarizeBlocksBatch(batch *BlocksBatchJson) (*BlocksBatchSummary, error) {
+	summary := &BlocksBatchSummary{SeerVersion: batch.SeerVersion, Blocks: len(batch.Blocks)}
+
+	blocks := make(map[uint64]BlockJson, len(batch.Blocks))
+	for i, block := range batch.Blocks {
+		number, err := strconv.ParseUint(block.BlockNumber, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q of block %d of batch: %v", block.BlockNumber, i, err)
+		}
+		timestamp, err := strconv.ParseUint(block.Timestamp, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp %q of block %d: %v", block.Timestamp, number, err)
+		}
+
+		if i == 0 || number < summary.FromBlock {
+			summary.FromBlock, summary.FromTimestamp = number, timestamp
+		}
+		if i == 0 || number > summary.ToBlock {
+			summary.ToBlock, summary.ToTimestamp = number, timestamp
+		}
+
+		if _, exists := blocks[number]; exists {
+			summary.DuplicatedBlocks = append(summary.DuplicatedBlocks, number)
+		}
+		blocks[number] = block
+
+		summary.Transactions += len(block.Transactions)
+		for _, tx := range block.Transactions {
+			summary.Logs += len(tx.Events)
+		}
+	}
+
+	if len(blocks) == 0 {
+		return summary, nil
+	}
+
+	for number := summary.FromBlock; number <= summary.ToBlock; number++ {
+		block, exists := blocks[number]
+		if !exists {
+			summary.MissingBlocks = append(summary.MissingBlocks, number)
+			continue
+		}
+		if parent, parentExists := blocks[number-1]; parentExists && number > summary.FromBlock && block.ParentHash != parent.Hash {
+			summary.MismatchedParents = append(summary.MismatchedParents, number)
+		}
+	}
+	sort.Slice(summary.DuplicatedBlocks, func(i, j int) bool { return summary.DuplicatedBlocks[i] < summary.DuplicatedBlocks[j] })
+
+	return summary, nil
+}
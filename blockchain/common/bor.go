@@ -0,0 +1,104 @@
+package common
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// BorStateSyncLabelType is label type of events emitted by Polygon bor state-sync system
+// transactions, e.g. mints of tokens bridged from L1. They are not initiated by any account
+// and are kept apart from events of user transactions.
+const BorStateSyncLabelType = "bor_state_sync"
+
+// How labels of bor state-sync transactions are handled
+const (
+	// Events get BorStateSyncLabelType, raw transactions are not written
+	BorStateSyncModeLabel = "label"
+	// Events and raw transactions are dropped
+	BorStateSyncModeSkip = "skip"
+	// State-sync transactions are handled as any other transaction
+	BorStateSyncModeKeep = "keep"
+)
+
+var borReceiptPrefix = []byte("matic-bor-receipt-")
+
+// BorStateSyncTxHash returns hash bor node derives for state-sync transaction of block:
+// keccak256 of "matic-bor-receipt-", 8 bytes of block number and block hash.
+func BorStateSyncTxHash(blockNumber uint64, blockHash string) common.Hash {
+	key := make([]byte, 0, len(borReceiptPrefix)+8+common.HashLength)
+	key = append(key, borReceiptPrefix...)
+	key = binary.BigEndian.AppendUint64(key, blockNumber)
+	key = append(key, common.HexToHash(blockHash).Bytes()...)
+
+	return crypto.Keccak256Hash(key)
+}
+
+// IsBorStateSyncTransaction checks if transaction is bor state-sync system transaction. It has
+// zero sender and recipient and its hash is derived from block, so regular transactions from
+// zero address are not matched.
+func IsBorStateSyncTransaction(hash, fromAddress, toAddress string, blockNumber uint64, blockHash string) bool {
+	if !isZeroAddress(fromAddress) || !isZeroAddress(toAddress) {
+		return false
+	}
+
+	return common.HexToHash(hash) == BorStateSyncTxHash(blockNumber, blockHash)
+}
+
+func isZeroAddress(address string) bool {
+	return address == "" || common.HexToAddress(address) == (common.Address{})
+}
+
+var (
+	borStateSyncModesOnce sync.Once
+	borStateSyncModes     map[string]string
+	borStateSyncModesErr  error
+)
+
+// ParseBorStateSyncModes parses modes of chains in format "<chain>=<label|skip|keep>,...".
+func ParseBorStateSyncModes(raw string) (map[string]string, error) {
+	modes := make(map[string]string)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		chain, mode, found := strings.Cut(entry, "=")
+		chain, mode = strings.TrimSpace(chain), strings.TrimSpace(mode)
+		if !found || chain == "" {
+			return nil, fmt.Errorf("invalid bor state-sync mode %q, expected <chain>=<%s|%s|%s>", entry, BorStateSyncModeLabel, BorStateSyncModeSkip, BorStateSyncModeKeep)
+		}
+
+		switch mode {
+		case BorStateSyncModeLabel, BorStateSyncModeSkip, BorStateSyncModeKeep:
+			modes[chain] = mode
+		default:
+			return nil, fmt.Errorf("invalid bor state-sync mode %q of chain %s, expected %s, %s or %s", mode, chain, BorStateSyncModeLabel, BorStateSyncModeSkip, BorStateSyncModeKeep)
+		}
+	}
+
+	return modes, nil
+}
+
+// BorStateSyncModeFor returns mode of chain configured with SEER_BOR_STATE_SYNC environment
+// variable, BorStateSyncModeLabel by default. Only bor chains have state-sync transactions, so
+// default mode does not change labels of other chains.
+func BorStateSyncModeFor(chain string) (string, error) {
+	borStateSyncModesOnce.Do(func() {
+		borStateSyncModes, borStateSyncModesErr = ParseBorStateSyncModes(os.Getenv("SEER_BOR_STATE_SYNC"))
+	})
+	if borStateSyncModesErr != nil {
+		return "", fmt.Errorf("invalid SEER_BOR_STATE_SYNC environment variable: %w", borStateSyncModesErr)
+	}
+
+	if mode, exists := borStateSyncModes[chain]; exists {
+		return mode, nil
+	}
+	return BorStateSyncModeLabel, nil
+}
@@ -0,0 +1,134 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestIsBorStateSyncTransaction(t *testing.T) {
+	const (
+		blockNumber      = uint64(61000000)
+		blockHash        = "0x8b5e4f9c2d1a7e3b6c0f4a9d8e7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a29180706"
+		zeroAddress      = "0x0000000000000000000000000000000000000000"
+		stateReceiver    = "0x0000000000000000000000000000000000001001"
+		userAddress      = "0x5a52e96bacdabb82fd05763e25335261b270efcb"
+		regularTxHash    = "0x3f0b2a4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708"
+		otherBlockHash   = "0x1111111111111111111111111111111111111111111111111111111111111111"
+		otherBlockNumber = blockNumber + 1
+	)
+	stateSyncHash := BorStateSyncTxHash(blockNumber, blockHash).Hex()
+
+	testCases := []struct {
+		name        string
+		hash        string
+		fromAddress string
+		toAddress   string
+		blockNumber uint64
+		blockHash   string
+		expected    bool
+	}{
+		{
+			name:        "state-sync transaction from zero address without recipient",
+			hash:        stateSyncHash,
+			fromAddress: zeroAddress,
+			toAddress:   "",
+			blockNumber: blockNumber,
+			blockHash:   blockHash,
+			expected:    true,
+		},
+		{
+			name:        "state-sync transaction from zero address to zero address",
+			hash:        stateSyncHash,
+			fromAddress: zeroAddress,
+			toAddress:   zeroAddress,
+			blockNumber: blockNumber,
+			blockHash:   blockHash,
+			expected:    true,
+		},
+		{
+			// Zero gas and zero sender alone do not identify state-sync, hash should be derived from block
+			name:        "zero gas transaction from zero address with regular hash",
+			hash:        regularTxHash,
+			fromAddress: zeroAddress,
+			toAddress:   zeroAddress,
+			blockNumber: blockNumber,
+			blockHash:   blockHash,
+			expected:    false,
+		},
+		{
+			name:        "transaction from zero address to state receiver system contract",
+			hash:        stateSyncHash,
+			fromAddress: zeroAddress,
+			toAddress:   stateReceiver,
+			blockNumber: blockNumber,
+			blockHash:   blockHash,
+			expected:    false,
+		},
+		{
+			name:        "user transaction to state receiver system contract",
+			hash:        regularTxHash,
+			fromAddress: userAddress,
+			toAddress:   stateReceiver,
+			blockNumber: blockNumber,
+			blockHash:   blockHash,
+			expected:    false,
+		},
+		{
+			name:        "hash of state-sync transaction of other block",
+			hash:        stateSyncHash,
+			fromAddress: zeroAddress,
+			toAddress:   "",
+			blockNumber: otherBlockNumber,
+			blockHash:   otherBlockHash,
+			expected:    false,
+		},
+		{
+			name:        "regular transaction of non-bor chain",
+			hash:        regularTxHash,
+			fromAddress: userAddress,
+			toAddress:   "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+			blockNumber: blockNumber,
+			blockHash:   blockHash,
+			expected:    false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := IsBorStateSyncTransaction(testCase.hash, testCase.fromAddress, testCase.toAddress, testCase.blockNumber, testCase.blockHash)
+			if actual != testCase.expected {
+				t.Fatalf("IsBorStateSyncTransaction = %v, want %v", actual, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestBorStateSyncTxHashDependsOnBlock(t *testing.T) {
+	blockHash := "0x8b5e4f9c2d1a7e3b6c0f4a9d8e7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a29180706"
+
+	hash := BorStateSyncTxHash(100, blockHash)
+	if hash != BorStateSyncTxHash(100, blockHash) {
+		t.Fatal("hash of state-sync transaction is not deterministic")
+	}
+	if hash == BorStateSyncTxHash(101, blockHash) {
+		t.Fatal("hash of state-sync transaction does not depend on block number")
+	}
+	if hash == BorStateSyncTxHash(100, "0x01") {
+		t.Fatal("hash of state-sync transaction does not depend on block hash")
+	}
+}
+
+func TestParseBorStateSyncModes(t *testing.T) {
+	modes, err := ParseBorStateSyncModes(" polygon=skip, amoy = keep ,")
+	if err != nil {
+		t.Fatalf("ParseBorStateSyncModes: %v", err)
+	}
+	if len(modes) != 2 || modes["polygon"] != BorStateSyncModeSkip || modes["amoy"] != BorStateSyncModeKeep {
+		t.Fatalf("unexpected modes %v", modes)
+	}
+
+	for _, raw := range []string{"polygon", "=skip", "polygon=drop"} {
+		if _, err := ParseBorStateSyncModes(raw); err == nil {
+			t.Fatalf("ParseBorStateSyncModes(%q) should fail", raw)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/G7DAO/seer/indexer"
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/protobuf/proto"
+)
+
+// ChainClient is implemented by client of every supported chain, crawler, synchronizer
+// and CLI work with chains only through it.
+type ChainClient interface {
+	GetLatestBlockNumber() (*big.Int, error)
+	FetchAsProtoBlocksWithEvents(*big.Int, *big.Int, bool, int) ([]proto.Message, []indexer.BlockIndex, uint64, error)
+	ProcessBlocksToBatch([]proto.Message) (proto.Message, error)
+	DecodeProtoEntireBlockToJson(*bytes.Buffer) (*BlocksBatchJson, error)
+	DecodeProtoEntireBlockToLabels(*bytes.Buffer, map[string]map[string]*indexer.AbiEntry, bool, int) ([]indexer.EventLabel, []indexer.TransactionLabel, []indexer.RawTransaction, error)
+	DecodeProtoTransactionsToLabels([]string, map[uint64]uint64, map[string]map[string]*indexer.AbiEntry) ([]indexer.TransactionLabel, error)
+	ChainType() string
+	GetCode(context.Context, common.Address, uint64) ([]byte, error)
+	CallContract(context.Context, common.Address, []byte, uint64) ([]byte, error)
+	FindDeploymentBlock(context.Context, common.Address) (uint64, error)
+	GetTransactionsLabels(uint64, uint64, map[string]map[string]*indexer.AbiEntry, int) ([]indexer.TransactionLabel, map[uint64]BlockWithTransactions, error)
+	GetEventsLabels(uint64, uint64, map[string]map[string]*indexer.AbiEntry, map[uint64]BlockWithTransactions) ([]indexer.EventLabel, error)
+}
+
+// ClientConstructor creates client of chain connected to RPC url.
+type ClientConstructor func(url string, timeout int) (ChainClient, error)
+
+var (
+	clientConstructorsMu sync.RWMutex
+	clientConstructors   = make(map[string]ClientConstructor)
+)
+
+// RegisterClient makes chain available for NewClient, chain packages call it from init.
+func RegisterClient(chain string, constructor ClientConstructor) {
+	clientConstructorsMu.Lock()
+	defer clientConstructorsMu.Unlock()
+
+	if _, exists := clientConstructors[chain]; exists {
+		panic(fmt.Sprintf("client for chain %s is already registered", chain))
+	}
+	clientConstructors[chain] = constructor
+}
+
+// NewClient creates client of registered chain.
+func NewClient(chain, url string, timeout int) (ChainClient, error) {
+	clientConstructorsMu.RLock()
+	constructor, exists := clientConstructors[chain]
+	clientConstructorsMu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("unsupported chain type: %s", chain)
+	}
+
+	client, err := constructor(url, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimit, limited, err := RPCRateLimitFor(chain)
+	if err != nil {
+		return nil, err
+	}
+	if limited {
+		rateLimitedClient, ok := client.(RateLimitedClient)
+		if !ok {
+			return nil, fmt.Errorf("client of chain %s does not support rate limit", chain)
+		}
+		rateLimitedClient.SetRateLimit(rateLimit)
+	}
+
+	retryPolicy, retryConfigured, err := RPCRetryPolicyFor(chain)
+	if err != nil {
+		return nil, err
+	}
+	if retryConfigured {
+		retryingClient, ok := client.(RetryingClient)
+		if !ok {
+			return nil, fmt.Errorf("client of chain %s does not support retry policy", chain)
+		}
+		retryingClient.SetRetryPolicy(retryPolicy)
+	}
+
+	if TracingEnabledFor(chain) {
+		tracingClient, ok := client.(TracingClient)
+		if !ok {
+			return nil, fmt.Errorf("client of chain %s does not support tracing", chain)
+		}
+		tracingClient.SetTracing(true)
+	}
+
+	return client, nil
+}
+
+// RegisteredChains returns sorted names of chains with registered clients.
+func RegisteredChains() []string {
+	clientConstructorsMu.RLock()
+	defer clientConstructorsMu.RUnlock()
+
+	chains := make([]string, 0, len(clientConstructors))
+	for chain := range clientConstructors {
+		chains = append(chains, chain)
+	}
+	sort.Strings(chains)
+
+	return chains
+}
@@ -0,0 +1,254 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CrawlWindow is a time of day range in UTC when historical crawl is allowed to run. Window
+// with End before Start crosses midnight, equal Start and End mean the whole day. Budget
+// limits number of RPC requests during one occurrence of window, zero means no limit.
+type CrawlWindow struct {
+	Start  time.Duration
+	End    time.Duration
+	Budget int64
+}
+
+func (w CrawlWindow) String() string {
+	window := fmt.Sprintf("%02d:%02d-%02d:%02d", int(w.Start.Hours()), int(w.Start.Minutes())%60, int(w.End.Hours()), int(w.End.Minutes())%60)
+	if w.Budget > 0 {
+		window += fmt.Sprintf("/%d", w.Budget)
+	}
+	return window
+}
+
+// occurrence returns start and end of window occurrence which contains now, or of the next
+// one if now is outside of window.
+func (w CrawlWindow) occurrence(now time.Time) (time.Time, time.Time, bool) {
+	day := now.UTC().Truncate(24 * time.Hour)
+
+	length := w.End - w.Start
+	if length <= 0 {
+		length += 24 * time.Hour
+	}
+
+	for _, start := range []time.Time{day.Add(w.Start - 24*time.Hour), day.Add(w.Start), day.Add(w.Start + 24*time.Hour)} {
+		end := start.Add(length)
+		if !now.Before(start) && now.Before(end) {
+			return start, end, true
+		}
+		if now.Before(start) {
+			return start, end, false
+		}
+	}
+
+	// Unreachable, next day occurrence always starts after now
+	return day.Add(w.Start + 24*time.Hour), day.Add(w.Start + 24*time.Hour + length), false
+}
+
+func parseTimeOfDay(raw string) (time.Duration, error) {
+	hours, minutes, found := strings.Cut(raw, ":")
+	if !found {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", raw)
+	}
+
+	h, err := strconv.Atoi(hours)
+	if err != nil || h < 0 || h > 24 {
+		return 0, fmt.Errorf("invalid hours of time %q", raw)
+	}
+	m, err := strconv.Atoi(minutes)
+	if err != nil || m < 0 || m > 59 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("invalid minutes of time %q", raw)
+	}
+
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+// ParseCrawlWindowList parses windows of one chain in format
+// "<HH:MM>-<HH:MM>[/<budget>]|<HH:MM>-<HH:MM>[/<budget>]", e.g. "00:00-06:00/200000".
+func ParseCrawlWindowList(raw string) ([]CrawlWindow, error) {
+	var windows []CrawlWindow
+	for _, spec := range strings.Split(raw, "|") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		timeRange, budget, budgetFound := strings.Cut(spec, "/")
+		from, to, found := strings.Cut(timeRange, "-")
+		if !found {
+			return nil, fmt.Errorf("invalid crawl window %q, expected <HH:MM>-<HH:MM>[/<budget>]", spec)
+		}
+
+		var window CrawlWindow
+		var err error
+		if window.Start, err = parseTimeOfDay(strings.TrimSpace(from)); err != nil {
+			return nil, err
+		}
+		if window.End, err = parseTimeOfDay(strings.TrimSpace(to)); err != nil {
+			return nil, err
+		}
+		window.Start %= 24 * time.Hour
+		window.End %= 24 * time.Hour
+
+		if budgetFound {
+			window.Budget, err = strconv.ParseInt(budget, 10, 64)
+			if err != nil || window.Budget <= 0 {
+				return nil, fmt.Errorf("invalid budget %q of crawl window %s, it should be positive integer", budget, timeRange)
+			}
+		}
+
+		windows = append(windows, window)
+	}
+
+	return windows, nil
+}
+
+// ParseCrawlWindows parses crawl windows of chains in format "<chain>[@<rpc host>]=<windows>,...",
+// e.g. "ethereum=00:00-06:00/200000|22:00-23:00,polygon@polygon-rpc.com=01:00-05:00". Windows of
+// chain and RPC host take precedence over windows of chain.
+func ParseCrawlWindows(raw string) (map[string][]CrawlWindow, error) {
+	windows := make(map[string][]CrawlWindow)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, spec, found := strings.Cut(entry, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid crawl windows %q, expected <chain>[@<rpc host>]=<windows>", entry)
+		}
+
+		chainWindows, err := ParseCrawlWindowList(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid crawl windows of %s: %w", key, err)
+		}
+		windows[key] = chainWindows
+	}
+
+	return windows, nil
+}
+
+// CrawlWindowsFor returns crawl windows of chain configured with SEER_HISTORICAL_CRAWL_WINDOWS
+// environment variable, windows of host of any endpoint in rpcUrls are preferred.
+func CrawlWindowsFor(chain, rpcUrls string) ([]CrawlWindow, error) {
+	windows, err := ParseCrawlWindows(os.Getenv("SEER_HISTORICAL_CRAWL_WINDOWS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SEER_HISTORICAL_CRAWL_WINDOWS environment variable: %w", err)
+	}
+
+	endpoints, _ := ParseRPCEndpoints(rpcUrls)
+	for _, endpoint := range endpoints {
+		parsedUrl, parseErr := url.Parse(endpoint.URL)
+		if parseErr != nil || parsedUrl.Hostname() == "" {
+			continue
+		}
+		if hostWindows, ok := windows[chain+"@"+parsedUrl.Hostname()]; ok {
+			return hostWindows, nil
+		}
+	}
+
+	return windows[chain], nil
+}
+
+// CrawlSchedule holds historical crawl inside of crawl windows and counts RPC requests
+// against budget of current window.
+type CrawlSchedule struct {
+	windows []CrawlWindow
+
+	mu          sync.Mutex
+	occurrences []time.Time
+	used        []int64
+}
+
+func NewCrawlSchedule(windows []CrawlWindow) *CrawlSchedule {
+	return &CrawlSchedule{
+		windows:     windows,
+		occurrences: make([]time.Time, len(windows)),
+		used:        make([]int64, len(windows)),
+	}
+}
+
+func (s *CrawlSchedule) String() string {
+	var windows []string
+	for _, window := range s.windows {
+		windows = append(windows, window.String())
+	}
+	return strings.Join(windows, ", ") + " UTC"
+}
+
+// reserve consumes requests from active window with remaining budget, batch started with the
+// last requests of budget could exceed it. If there is no such window it returns time when
+// the next window starts.
+func (s *CrawlSchedule) reserve(now time.Time, requests int64) (bool, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var next time.Time
+	for i, window := range s.windows {
+		start, _, active := window.occurrence(now)
+		if active {
+			if !s.occurrences[i].Equal(start) {
+				s.occurrences[i] = start
+				s.used[i] = 0
+			}
+			if window.Budget == 0 || s.used[i] < window.Budget {
+				s.used[i] += requests
+				return true, time.Time{}
+			}
+			// Budget of this occurrence is spent, the next one starts in a day
+			start = start.Add(24 * time.Hour)
+		}
+		if next.IsZero() || start.Before(next) {
+			next = start
+		}
+	}
+
+	return false, next
+}
+
+func (s *CrawlSchedule) take(ctx context.Context, requests int64) error {
+	if s == nil || len(s.windows) == 0 {
+		return nil
+	}
+
+	for {
+		ok, next := s.reserve(time.Now(), requests)
+		if ok {
+			return nil
+		}
+
+		slog.Info("Historical crawl is outside of crawl windows or their budget is spent, waiting", "windows", s.String(), "until", next.Format(time.RFC3339))
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Take blocks until requests are allowed by schedule and counts them against budget.
+func (s *CrawlSchedule) Take(ctx context.Context, requests int) error {
+	return s.take(ctx, int64(requests))
+}
+
+// Wait blocks until crawl window is open and has budget left, budget is not consumed.
+func (s *CrawlSchedule) Wait(ctx context.Context) error {
+	return s.take(ctx, 0)
+}
+
+// ScheduledClient is implemented by chain clients which could hold RPC requests inside of
+// crawl windows.
+type ScheduledClient interface {
+	SetCrawlSchedule(*CrawlSchedule)
+}
@@ -5,11 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
+	"reflect"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/G7DAO/seer/indexer"
+	"github.com/G7DAO/seer/logging"
 )
 
 type BlocksBatchJson struct {
@@ -38,14 +43,32 @@ type BlockJson struct {
 	BaseFeePerGas    string `json:"baseFeePerGas"`
 	IndexedAt        string `json:"indexed_at"`
 
+	BlobGasUsed   string `json:"blobGasUsed,omitempty"`
+	ExcessBlobGas string `json:"excessBlobGas,omitempty"`
+
+	Withdrawals           []WithdrawalJson `json:"withdrawals,omitempty"`
+	WithdrawalsRoot       string           `json:"withdrawalsRoot,omitempty"`
+	ParentBeaconBlockRoot string           `json:"parentBeaconBlockRoot,omitempty"`
+
 	MixHash       string `json:"mixHash,omitempty"`
 	SendCount     string `json:"sendCount,omitempty"`
 	SendRoot      string `json:"sendRoot,omitempty"`
 	L1BlockNumber string `json:"l1BlockNumber,omitempty"`
 
+	L1BatchNumber    string `json:"l1BatchNumber,omitempty"`
+	L1BatchTimestamp string `json:"l1BatchTimestamp,omitempty"`
+
 	Transactions []TransactionJson `json:"transactions,omitempty"`
 }
 
+// WithdrawalJson is a validator withdrawal of post-Shanghai blocks, amount is in Gwei.
+type WithdrawalJson struct {
+	Index          string `json:"index"`
+	ValidatorIndex string `json:"validatorIndex"`
+	Address        string `json:"address"`
+	Amount         string `json:"amount"`
+}
+
 type TransactionJson struct {
 	BlockHash            string `json:"blockHash"`
 	BlockNumber          string `json:"blockNumber"`
@@ -71,6 +94,16 @@ type TransactionJson struct {
 	AccessList []AccessList `json:"accessList,omitempty"`
 	YParity    string       `json:"yParity,omitempty"`
 
+	MaxFeePerBlobGas    string   `json:"maxFeePerBlobGas,omitempty"`
+	BlobVersionedHashes []string `json:"blobVersionedHashes,omitempty"`
+
+	SourceHash string `json:"sourceHash,omitempty"`
+	Mint       string `json:"mint,omitempty"`
+	IsSystemTx bool   `json:"isSystemTx,omitempty"`
+
+	L1BatchNumber  string `json:"l1BatchNumber,omitempty"`
+	L1BatchTxIndex string `json:"l1BatchTxIndex,omitempty"`
+
 	Events []EventJson `json:"events,omitempty"`
 }
 
@@ -169,18 +202,78 @@ func ReadJsonEventLogs() []*EventJson {
 	return eventLogs
 }
 
+// labelDataValue converts value unpacked by go-ethereum into form which is readable in JSON.
+// Structs of tuples become objects with names of components, bytes become hex strings and
+// arrays are converted element by element, so nested tuples and arrays of bytes are handled.
+// Dynamic indexed arguments are stored in topics as hashes and are kept as them.
+func labelDataValue(argType abi.Type, value interface{}) interface{} {
+	if hash, ok := value.(common.Hash); ok {
+		return hash.Hex()
+	}
+
+	v := reflect.ValueOf(value)
+	switch argType.T {
+	case abi.TupleTy:
+		if v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct || v.NumField() != len(argType.TupleElems) {
+			return value
+		}
+
+		tuple := make(map[string]interface{}, len(argType.TupleElems))
+		for i, elem := range argType.TupleElems {
+			tuple[indexer.TupleFieldName(argType, i)] = labelDataValue(*elem, v.Field(i).Interface())
+		}
+		return tuple
+	case abi.SliceTy, abi.ArrayTy:
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return value
+		}
+
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = labelDataValue(*argType.Elem, v.Index(i).Interface())
+		}
+		return items
+	case abi.BytesTy, abi.FixedBytesTy, abi.FunctionTy:
+		if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || v.Type().Elem().Kind() != reflect.Uint8 {
+			return value
+		}
+
+		data := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(data), v)
+		return "0x" + hex.EncodeToString(data)
+	}
+
+	return value
+}
+
+// labelDataArgs converts unpacked arguments in place with labelDataValue.
+func labelDataArgs(arguments abi.Arguments, args map[string]interface{}) {
+	for _, argument := range arguments {
+		if value, exists := args[argument.Name]; exists {
+			args[argument.Name] = labelDataValue(argument.Type, value)
+		}
+	}
+}
+
 func DecodeTransactionInputDataToInterface(contractABI *abi.ABI, data []byte) (map[string]interface{}, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("input data of %d bytes has no method selector", len(data))
+	}
 	methodSigData := data[:4]
 	inputsSigData := data[4:]
 	method, err := contractABI.MethodById(methodSigData)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	inputsMap := make(map[string]interface{})
 	if err := method.Inputs.UnpackIntoMap(inputsMap, inputsSigData); err != nil {
-		fmt.Println("Cannot unpack data: ", inputsSigData, " for method: ", method)
+		slog.Warn("Cannot unpack input data of method", "method", method.Sig, logging.ErrorKey, err)
 		return nil, fmt.Errorf("cannot unpack data: %v for method: %v", inputsSigData, method)
 	}
+	labelDataArgs(method.Inputs, inputsMap)
 
 	// Prepare the extended map
 	labelData := make(map[string]interface{})
@@ -205,17 +298,21 @@ func DecodeLogArgsToLabelData(contractABI *abi.ABI, topics []string, data string
 		topicHashes = append(topicHashes, common.HexToHash(topic))
 	}
 
+	if len(topicHashes) == 0 {
+		return nil, fmt.Errorf("log has no topics")
+	}
+
 	event, err := contractABI.EventByID(
 		topicHashes[0],
 	)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	// Decode the data string from hex to bytes
 	dataBytes, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
 	if err != nil {
-		log.Fatalf("Failed to decode data string: %v", err)
+		return nil, fmt.Errorf("failed to decode data string: %w", err)
 	}
 
 	// Prepare the map to hold the input data
@@ -241,6 +338,7 @@ func DecodeLogArgsToLabelData(contractABI *abi.ABI, topics []string, data string
 	if err := event.Inputs.UnpackIntoMap(labelData["args"].(map[string]interface{}), dataBytes); err != nil {
 		return nil, err
 	}
+	labelDataArgs(event.Inputs, labelData["args"].(map[string]interface{}))
 
 	return labelData, nil
 }
@@ -0,0 +1,340 @@
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+// exactInput of Uniswap V3 SwapRouter, params tuple holds encoded swap path as bytes.
+const uniswapV3RouterABI = `[{"type":"function","name":"exactInput","stateMutability":"payable","inputs":[{"name":"params","type":"tuple","components":[{"name":"path","type":"bytes"},{"name":"recipient","type":"address"},{"name":"deadline","type":"uint256"},{"name":"amountIn","type":"uint256"},{"name":"amountOutMinimum","type":"uint256"}]}],"outputs":[{"name":"amountOut","type":"uint256"}]}]`
+
+// USDC -> WETH swap through 0.05% pool.
+const uniswapV3ExactInputCalldata = "0xc04b8d59" +
+	"0000000000000000000000000000000000000000000000000000000000000020" +
+	"00000000000000000000000000000000000000000000000000000000000000a0" +
+	"0000000000000000000000001111111111111111111111111111111111111111" +
+	"000000000000000000000000000000000000000000000000000000006553f100" +
+	"000000000000000000000000000000000000000000000000000000003b9aca00" +
+	"00000000000000000000000000000000000000000000000006f05b59d3b20000" +
+	"000000000000000000000000000000000000000000000000000000000000002b" +
+	"a0b86991c6218b36c1d19d4a2e9eb0ce3606eb480001f4c02aaa39b223fe8d0a" +
+	"0e5c4f27ead9083c756cc2000000000000000000000000000000000000000000"
+
+const uniswapV3ExactInputLabelData = `{
+	"type": "tx_call",
+	"gas_used": 0,
+	"args": {
+		"params": {
+			"path": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb480001f4c02aaa39b223fe8d0a0e5c4f27ead9083c756cc2",
+			"recipient": "0x1111111111111111111111111111111111111111",
+			"deadline": 1700000000,
+			"amountIn": 1000000000,
+			"amountOutMinimum": 500000000000000000
+		}
+	}
+}`
+
+// OrderFulfilled of Seaport, offer and consideration are arrays of structs.
+const seaportABI = `[{"type":"event","name":"OrderFulfilled","anonymous":false,"inputs":[{"name":"orderHash","type":"bytes32","indexed":false},{"name":"offerer","type":"address","indexed":true},{"name":"zone","type":"address","indexed":true},{"name":"recipient","type":"address","indexed":false},{"name":"offer","type":"tuple[]","indexed":false,"components":[{"name":"itemType","type":"uint8"},{"name":"token","type":"address"},{"name":"identifier","type":"uint256"},{"name":"amount","type":"uint256"}]},{"name":"consideration","type":"tuple[]","indexed":false,"components":[{"name":"itemType","type":"uint8"},{"name":"token","type":"address"},{"name":"identifier","type":"uint256"},{"name":"amount","type":"uint256"},{"name":"recipient","type":"address"}]}]}]`
+
+// Sale of ERC721 token for ETH paid to seller and fee recipient.
+var seaportOrderFulfilledTopics = []string{
+	"0x9d9af8e38d66c62e2c12f0225249fd9d721c54b83f48d9352c97c6cacdcb6f31",
+	"0x0000000000000000000000004444444444444444444444444444444444444444",
+	"0x0000000000000000000000000000000000000000000000000000000000000000",
+}
+
+const seaportOrderFulfilledData = "0x" +
+	"5b1f0c7e8a8e4f7bd7a1b0c0f9e6d5c4b3a29180706050403020100f0e0d0c0b" +
+	"0000000000000000000000002222222222222222222222222222222222222222" +
+	"0000000000000000000000000000000000000000000000000000000000000080" +
+	"0000000000000000000000000000000000000000000000000000000000000120" +
+	"0000000000000000000000000000000000000000000000000000000000000001" +
+	"0000000000000000000000000000000000000000000000000000000000000002" +
+	"000000000000000000000000bc4ca0eda7647a8ab7c2061c2e118a18a936f13d" +
+	"00000000000000000000000000000000000000000000000000000000000004d2" +
+	"0000000000000000000000000000000000000000000000000000000000000001" +
+	"0000000000000000000000000000000000000000000000000000000000000002" +
+	"0000000000000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000000000000000000000000000000000000d87e55590018000" +
+	"0000000000000000000000003333333333333333333333333333333333333333" +
+	"0000000000000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000000000000000000000000000000000000058d15e17628000" +
+	"0000000000000000000000000000a26b00c1f0df003000390027140000faa719"
+
+const seaportOrderFulfilledLabelData = `{
+	"type": "event",
+	"name": "OrderFulfilled",
+	"args": {
+		"orderHash": "0x5b1f0c7e8a8e4f7bd7a1b0c0f9e6d5c4b3a29180706050403020100f0e0d0c0b",
+		"offerer": "0x4444444444444444444444444444444444444444",
+		"zone": "0x0000000000000000000000000000000000000000",
+		"recipient": "0x2222222222222222222222222222222222222222",
+		"offer": [
+			{"itemType": 2, "token": "0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d", "identifier": 1234, "amount": 1}
+		],
+		"consideration": [
+			{"itemType": 0, "token": "0x0000000000000000000000000000000000000000", "identifier": 0, "amount": 975000000000000000, "recipient": "0x3333333333333333333333333333333333333333"},
+			{"itemType": 0, "token": "0x0000000000000000000000000000000000000000", "identifier": 0, "amount": 25000000000000000, "recipient": "0x0000a26b00c1f0df003000390027140000faa719"}
+		]
+	}
+}`
+
+func parseTestABI(t *testing.T, abiJSON string) *abi.ABI {
+	t.Helper()
+
+	contractABI, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		t.Fatalf("invalid ABI: %v", err)
+	}
+	return &contractABI
+}
+
+// assertLabelData compares label data with expected JSON after both are normalized by
+// encoding/json, so order of keys and formatting of expected JSON do not matter.
+func assertLabelData(t *testing.T, labelData map[string]interface{}, expected string) {
+	t.Helper()
+
+	encoded, err := json.Marshal(labelData)
+	if err != nil {
+		t.Fatalf("label data is not encodable to JSON: %v", err)
+	}
+
+	var actualValue, expectedValue interface{}
+	if err := json.Unmarshal(encoded, &actualValue); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(expected), &expectedValue); err != nil {
+		t.Fatalf("invalid expected JSON: %v", err)
+	}
+
+	actualNormalized, _ := json.Marshal(actualValue)
+	expectedNormalized, _ := json.Marshal(expectedValue)
+	if string(actualNormalized) != string(expectedNormalized) {
+		t.Fatalf("unexpected label data\n got: %s\nwant: %s", actualNormalized, expectedNormalized)
+	}
+}
+
+func TestDecodeTransactionInputDataToInterfaceTuple(t *testing.T) {
+	contractABI := parseTestABI(t, uniswapV3RouterABI)
+
+	calldata, err := hex.DecodeString(strings.TrimPrefix(uniswapV3ExactInputCalldata, "0x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	labelData, err := DecodeTransactionInputDataToInterface(contractABI, calldata)
+	if err != nil {
+		t.Fatalf("DecodeTransactionInputDataToInterface: %v", err)
+	}
+
+	assertLabelData(t, labelData, uniswapV3ExactInputLabelData)
+}
+
+func TestDecodeLogArgsToLabelDataNestedStructArrays(t *testing.T) {
+	contractABI := parseTestABI(t, seaportABI)
+
+	labelData, err := DecodeLogArgsToLabelData(contractABI, seaportOrderFulfilledTopics, seaportOrderFulfilledData)
+	if err != nil {
+		t.Fatalf("DecodeLogArgsToLabelData: %v", err)
+	}
+
+	assertLabelData(t, labelData, seaportOrderFulfilledLabelData)
+}
+
+func TestLabelDataValueBytes(t *testing.T) {
+	bytesType, _ := abi.NewType("bytes", "", nil)
+	bytes4Type, _ := abi.NewType("bytes4", "", nil)
+	bytesArrayType, _ := abi.NewType("bytes[]", "", nil)
+
+	testCases := []struct {
+		name     string
+		argType  abi.Type
+		value    interface{}
+		expected interface{}
+	}{
+		{"bytes", bytesType, []byte{0xde, 0xad, 0xbe, 0xef}, "0xdeadbeef"},
+		{"empty bytes", bytesType, []byte{}, "0x"},
+		{"bytes4", bytes4Type, [4]byte{0x01, 0x02, 0x03, 0x04}, "0x01020304"},
+		{"bytes array", bytesArrayType, [][]byte{{0x01}, {0x02, 0x03}}, []interface{}{"0x01", "0x0203"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual, _ := json.Marshal(labelDataValue(testCase.argType, testCase.value))
+			expected, _ := json.Marshal(testCase.expected)
+			if string(actual) != string(expected) {
+				t.Fatalf("got %s, want %s", actual, expected)
+			}
+		})
+	}
+}
+
+// Order book with arguments of all byte-like types, tuples and arrays.
+const orderBookABI = `[{"type":"function","name":"submit","stateMutability":"nonpayable","inputs":[{"name":"payload","type":"bytes"},{"name":"tag","type":"bytes4"},{"name":"callback","type":"function"},{"name":"order","type":"tuple","components":[{"name":"maker","type":"address"},{"name":"amounts","type":"uint256[]"},{"name":"salt","type":"bytes32"}]},{"name":"flags","type":"bool[2]"},{"name":"note","type":"string"}],"outputs":[]},{"type":"event","name":"Submitted","anonymous":false,"inputs":[{"name":"id","type":"bytes32","indexed":true},{"name":"maker","type":"address","indexed":true},{"name":"payload","type":"bytes","indexed":false},{"name":"order","type":"tuple","indexed":false,"components":[{"name":"maker","type":"address"},{"name":"amounts","type":"uint256[]"},{"name":"salt","type":"bytes32"}]}]}]`
+
+type testOrder struct {
+	Maker   common.Address
+	Amounts []*big.Int
+	Salt    [32]byte
+}
+
+// validateJSONSchema checks value decoded from JSON against schema, it supports keywords
+// used by label data schemas.
+func validateJSONSchema(t *testing.T, path string, schema map[string]interface{}, value interface{}) {
+	t.Helper()
+
+	if expected, exists := schema["const"]; exists && expected != value {
+		t.Fatalf("%s is %v, want %v", path, value, expected)
+	}
+
+	switch schema["type"] {
+	case "integer":
+		if number, ok := value.(float64); !ok || number != float64(int64(number)) {
+			t.Fatalf("%s is %v, want integer", path, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			t.Fatalf("%s is %v, want boolean", path, value)
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			t.Fatalf("%s is %v, want string", path, value)
+		}
+		if pattern, exists := schema["pattern"].(string); exists && !regexp.MustCompile(pattern).MatchString(str) {
+			t.Fatalf("%s is %q, does not match %s", path, str, pattern)
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			t.Fatalf("%s is %v, want array", path, value)
+		}
+		if minItems, exists := schema["minItems"].(float64); exists && float64(len(items)) < minItems {
+			t.Fatalf("%s has %d items, want at least %v", path, len(items), minItems)
+		}
+		if maxItems, exists := schema["maxItems"].(float64); exists && float64(len(items)) > maxItems {
+			t.Fatalf("%s has %d items, want at most %v", path, len(items), maxItems)
+		}
+		for i, item := range items {
+			validateJSONSchema(t, fmt.Sprintf("%s[%d]", path, i), schema["items"].(map[string]interface{}), item)
+		}
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			t.Fatalf("%s is %v, want object", path, value)
+		}
+		required, _ := schema["required"].([]interface{})
+		for _, key := range required {
+			if _, exists := object[key.(string)]; !exists {
+				t.Fatalf("%s has no required %s", path, key)
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		for key, item := range object {
+			propertySchema, exists := properties[key].(map[string]interface{})
+			if !exists {
+				t.Fatalf("%s has %s which is not in schema", path, key)
+			}
+			validateJSONSchema(t, path+"."+key, propertySchema, item)
+		}
+	}
+}
+
+func assertLabelDataMatchesSchema(t *testing.T, schemas map[string]indexer.JSONSchema, key string, labelData map[string]interface{}) {
+	t.Helper()
+
+	if schemas[key] == nil {
+		t.Fatalf("no schema %s", key)
+	}
+
+	// Both are compared in the form they take in JSON
+	toJSONValue := func(source interface{}) map[string]interface{} {
+		encoded, err := json.Marshal(source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var target map[string]interface{}
+		if err := json.Unmarshal(encoded, &target); err != nil {
+			t.Fatal(err)
+		}
+		return target
+	}
+
+	validateJSONSchema(t, key, toJSONValue(schemas[key]), toJSONValue(labelData))
+}
+
+func TestLabelDataMatchesABISchema(t *testing.T) {
+	contractABI := parseTestABI(t, orderBookABI)
+	schemas, err := indexer.AbiLabelDataJSONSchemas(orderBookABI)
+	if err != nil {
+		t.Fatalf("AbiLabelDataJSONSchemas: %v", err)
+	}
+
+	maker := common.HexToAddress("0x4444444444444444444444444444444444444444")
+	order := testOrder{Maker: maker, Amounts: []*big.Int{big.NewInt(1), big.NewInt(2)}, Salt: [32]byte{0xab, 0xcd}}
+	var callback [24]byte
+	copy(callback[:], common.FromHex("0x5555555555555555555555555555555555555555a9059cbb"))
+
+	calldata, err := contractABI.Pack("submit", []byte{0xde, 0xad}, [4]byte{0x01, 0x02, 0x03, 0x04}, callback, order, [2]bool{true, false}, "limit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	callLabelData, err := DecodeTransactionInputDataToInterface(contractABI, calldata)
+	if err != nil {
+		t.Fatalf("DecodeTransactionInputDataToInterface: %v", err)
+	}
+	assertLabelDataMatchesSchema(t, schemas, "tx_call:submit", callLabelData)
+
+	event := contractABI.Events["Submitted"]
+	data, err := event.Inputs.NonIndexed().Pack([]byte{}, order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	topics := []string{event.ID.Hex(), common.BytesToHash([]byte{0x01}).Hex(), common.BytesToHash(maker.Bytes()).Hex()}
+	eventLabelData, err := DecodeLogArgsToLabelData(contractABI, topics, "0x"+hex.EncodeToString(data))
+	if err != nil {
+		t.Fatalf("DecodeLogArgsToLabelData: %v", err)
+	}
+	assertLabelDataMatchesSchema(t, schemas, "event:Submitted", eventLabelData)
+}
+
+func TestLabelDataKeysOfUnnamedTupleComponents(t *testing.T) {
+	uintType, _ := abi.NewType("uint256", "", nil)
+	bytesType, _ := abi.NewType("bytes", "", nil)
+	tupleType := abi.Type{
+		T:             abi.TupleTy,
+		TupleElems:    []*abi.Type{&uintType, &bytesType},
+		TupleRawNames: []string{"", "payload"},
+		TupleType:     reflect.TypeOf(struct{ Field0, Payload interface{} }{}),
+	}
+
+	value := labelDataValue(tupleType, struct {
+		Field0  *big.Int
+		Payload []byte
+	}{big.NewInt(7), []byte{0x01}})
+	tuple, ok := value.(map[string]interface{})
+	if !ok || tuple["field0"] == nil || tuple["payload"] != "0x01" {
+		t.Fatalf("unexpected tuple %v", value)
+	}
+	for i := range tupleType.TupleElems {
+		if _, exists := tuple[indexer.TupleFieldName(tupleType, i)]; !exists {
+			t.Fatalf("label data has no key %s of schema", indexer.TupleFieldName(tupleType, i))
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrContractNotDeployed is returned by FindDeploymentBlock for addresses without code at the
+// latest block: accounts, contracts not deployed yet and self-destructed contracts.
+var ErrContractNotDeployed = errors.New("contract has no code at latest block")
+
+// Timeout of each eth_getCode call of deployment block search
+var DeploymentBlockCallTimeout = 30 * time.Second
+
+// FindDeploymentBlock binary searches eth_getCode over block numbers for the first block at
+// which address has code. It takes about log2(latest block) calls and requires node with state
+// of historical blocks. Contracts deployed at genesis are reported at block 1, as zero block
+// number means latest block for GetCode.
+func FindDeploymentBlock(ctx context.Context, client ChainClient, address common.Address) (uint64, error) {
+	latestBlockNumber, err := client.GetLatestBlockNumber()
+	if err != nil {
+		return 0, err
+	}
+
+	hasCode := func(blockNumber uint64) (bool, error) {
+		callCtx, cancel := context.WithTimeout(ctx, DeploymentBlockCallTimeout)
+		defer cancel()
+
+		code, err := client.GetCode(callCtx, address, blockNumber)
+		if err != nil {
+			return false, fmt.Errorf("failed to get code of %s at block %d: %w", address.Hex(), blockNumber, err)
+		}
+		return len(code) > 0, nil
+	}
+
+	left, right := uint64(1), latestBlockNumber.Uint64()
+	deployed, err := hasCode(right)
+	if err != nil {
+		return 0, err
+	}
+	if !deployed {
+		return 0, fmt.Errorf("%s: %w", address.Hex(), ErrContractNotDeployed)
+	}
+
+	for left < right {
+		mid := left + (right-left)/2
+
+		deployed, err := hasCode(mid)
+		if err != nil {
+			return 0, err
+		}
+
+		if deployed {
+			right = mid
+		} else {
+			left = mid + 1
+		}
+	}
+
+	return left, nil
+}
@@ -0,0 +1,115 @@
+package common
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ENSRegistryAddress is address of ENS registry at Ethereum mainnet.
+var ENSRegistryAddress = common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e")
+
+const (
+	ensResolverSelector = "0178b8bf" // resolver(bytes32)
+	ensNameSelector     = "691f3431" // name(bytes32)
+	ensAddrSelector     = "3b3b57de" // addr(bytes32)
+)
+
+// ENSNamehash returns node of ENS name as defined by EIP-137. Name is expected to be
+// normalized already, names returned by reverse records are.
+func ENSNamehash(name string) common.Hash {
+	var node common.Hash
+	if name == "" {
+		return node
+	}
+
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		node = crypto.Keccak256Hash(node.Bytes(), crypto.Keccak256([]byte(labels[i])))
+	}
+	return node
+}
+
+// ensCall calls function of ENS contract with node argument. Reverts mean that contract has
+// no record of node, so they are returned as empty result and only transport errors fail.
+func ensCall(ctx context.Context, client ChainClient, address common.Address, selector string, node common.Hash) ([]byte, error) {
+	data, err := hex.DecodeString(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.CallContract(ctx, address, append(data, node.Bytes()...), 0)
+	if err != nil {
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func ensAddressResult(result []byte) common.Address {
+	if len(result) < 32 {
+		return common.Address{}
+	}
+	return common.BytesToAddress(result[12:32])
+}
+
+// ENSReverseName returns primary ENS name of address. Reverse record could be set to any
+// name, so name is returned only if it resolves back to address, otherwise result is empty.
+func ENSReverseName(ctx context.Context, client ChainClient, address common.Address) (string, error) {
+	reverseNode := ENSNamehash(strings.ToLower(strings.TrimPrefix(address.Hex(), "0x")) + ".addr.reverse")
+
+	result, err := ensCall(ctx, client, ENSRegistryAddress, ensResolverSelector, reverseNode)
+	if err != nil {
+		return "", err
+	}
+	reverseResolver := ensAddressResult(result)
+	if reverseResolver == (common.Address{}) {
+		return "", nil
+	}
+
+	result, err = ensCall(ctx, client, reverseResolver, ensNameSelector, reverseNode)
+	if err != nil || len(result) == 0 {
+		return "", err
+	}
+	stringType, err := abi.NewType("string", "", nil)
+	if err != nil {
+		return "", err
+	}
+	values, err := abi.Arguments{{Type: stringType}}.Unpack(result)
+	if err != nil || len(values) != 1 {
+		return "", nil
+	}
+	name, _ := values[0].(string)
+	if name == "" {
+		return "", nil
+	}
+
+	node := ENSNamehash(name)
+	result, err = ensCall(ctx, client, ENSRegistryAddress, ensResolverSelector, node)
+	if err != nil {
+		return "", err
+	}
+	resolver := ensAddressResult(result)
+	if resolver == (common.Address{}) {
+		return "", nil
+	}
+
+	result, err = ensCall(ctx, client, resolver, ensAddrSelector, node)
+	if err != nil {
+		return "", err
+	}
+	if ensAddressResult(result) != address {
+		return "", nil
+	}
+
+	return name, nil
+}
@@ -0,0 +1,127 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Block tags of eth_getBlockByNumber which point to blocks considered final by consensus client.
+const (
+	FinalityTagSafe      = "safe"
+	FinalityTagFinalized = "finalized"
+)
+
+// Finality defines which blocks of chain are safe from reorgs: blocks with at least
+// Confirmations blocks on top of them, or blocks not above block returned by node for Tag.
+type Finality struct {
+	Confirmations int64
+	Tag           string
+}
+
+func (f Finality) String() string {
+	if f.Tag != "" {
+		return f.Tag
+	}
+	return fmt.Sprintf("%d confirmations", f.Confirmations)
+}
+
+// ParseFinality parses finality in format "<confirmations>", "safe" or "finalized".
+func ParseFinality(spec string) (Finality, error) {
+	spec = strings.TrimSpace(spec)
+	switch spec {
+	case FinalityTagSafe, FinalityTagFinalized:
+		return Finality{Tag: spec}, nil
+	}
+
+	confirmations, err := strconv.ParseInt(spec, 10, 64)
+	if err != nil || confirmations < 0 {
+		return Finality{}, fmt.Errorf("invalid finality %q, expected number of confirmations, %s or %s", spec, FinalityTagSafe, FinalityTagFinalized)
+	}
+
+	return Finality{Confirmations: confirmations}, nil
+}
+
+var (
+	chainFinalityOnce sync.Once
+	chainFinality     map[string]Finality
+	chainFinalityErr  error
+)
+
+// ParseChainFinality parses finality of chains in format "<chain>=<finality>,...",
+// e.g. "ethereum=finalized,polygon=256".
+func ParseChainFinality(raw string) (map[string]Finality, error) {
+	finality := make(map[string]Finality)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		chain, spec, found := strings.Cut(entry, "=")
+		chain = strings.TrimSpace(chain)
+		if !found || chain == "" {
+			return nil, fmt.Errorf("invalid finality %q, expected <chain>=<confirmations|safe|finalized>", entry)
+		}
+
+		chainFinality, err := ParseFinality(spec)
+		if err != nil {
+			return nil, fmt.Errorf("chain %s: %w", chain, err)
+		}
+		finality[chain] = chainFinality
+	}
+
+	return finality, nil
+}
+
+// ChainFinalityFor returns finality of chain configured with SEER_CHAIN_FINALITY environment
+// variable.
+func ChainFinalityFor(chain string) (Finality, bool, error) {
+	chainFinalityOnce.Do(func() {
+		chainFinality, chainFinalityErr = ParseChainFinality(os.Getenv("SEER_CHAIN_FINALITY"))
+	})
+	if chainFinalityErr != nil {
+		return Finality{}, false, fmt.Errorf("invalid SEER_CHAIN_FINALITY environment variable: %w", chainFinalityErr)
+	}
+
+	finality, exists := chainFinality[chain]
+	return finality, exists, nil
+}
+
+// FinalityClient is implemented by chain clients which could resolve block tags of finality.
+type FinalityClient interface {
+	GetTaggedBlockNumber(ctx context.Context, tag string) (*big.Int, error)
+}
+
+// SafeHead returns the highest block of chain which satisfies finality, latest is the current
+// latest block number of chain.
+func SafeHead(ctx context.Context, client ChainClient, finality Finality, latest *big.Int) (*big.Int, error) {
+	if finality.Tag == "" {
+		safe := new(big.Int).Sub(latest, big.NewInt(finality.Confirmations))
+		if safe.Sign() < 0 {
+			safe.SetInt64(0)
+		}
+		return safe, nil
+	}
+
+	finalityClient, ok := client.(FinalityClient)
+	if !ok {
+		return nil, fmt.Errorf("client of chain type %s does not support %s block tag", client.ChainType(), finality.Tag)
+	}
+
+	safe, err := finalityClient.GetTaggedBlockNumber(ctx, finality.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s block: %w", finality.Tag, err)
+	}
+
+	// Node could be behind latest block known from other endpoint or subscription
+	if safe.Cmp(latest) > 0 {
+		safe = new(big.Int).Set(latest)
+	}
+
+	return safe, nil
+}
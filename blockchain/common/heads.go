@@ -0,0 +1,54 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// HeadSubscriber is implemented by chain clients which could follow chain tip with
+// eth_subscribe instead of polling eth_blockNumber.
+type HeadSubscriber interface {
+	SubscribeNewHeads(ctx context.Context, heads chan<- *big.Int) (ethereum.Subscription, error)
+}
+
+type newHead struct {
+	Number string `json:"number"`
+}
+
+// SubscribeNewHeads subscribes to newHeads of WebSocket endpoint of pool and sends numbers
+// of new blocks to heads channel until subscription is unsubscribed or fails.
+func SubscribeNewHeads(ctx context.Context, pool *RPCPool, heads chan<- *big.Int) (ethereum.Subscription, error) {
+	rawHeads := make(chan newHead)
+	sub, err := pool.EthSubscribe(ctx, rawHeads, "newHeads")
+	if err != nil {
+		return nil, err
+	}
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case head := <-rawHeads:
+				blockNumber, ok := new(big.Int).SetString(head.Number, 0)
+				if !ok {
+					return fmt.Errorf("invalid block number format of new head: %s", head.Number)
+				}
+
+				select {
+				case heads <- blockNumber:
+				case <-quit:
+					return nil
+				}
+			case subErr := <-sub.Err():
+				return subErr
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
@@ -0,0 +1,162 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var ErrUnsignedTransaction = errors.New("transaction is not signed")
+
+// IsLegacyTransaction checks if transaction has legacy (type 0) envelope.
+func IsLegacyTransaction(tx *TransactionJson) bool {
+	txType := strings.TrimPrefix(tx.TransactionType, "0x")
+	return txType == "" || strings.Trim(txType, "0") == ""
+}
+
+func parseHexBig(value string) (*big.Int, error) {
+	if value == "" || value == "0x" {
+		return new(big.Int), nil
+	}
+	result, ok := new(big.Int).SetString(strings.TrimPrefix(value, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid hex number %s", value)
+	}
+	return result, nil
+}
+
+// LegacySigner returns signer for legacy transaction according to its V value. Transactions
+// signed before EIP-155 have V equal 27 or 28 and do not contain chain ID.
+func LegacySigner(v *big.Int) (types.Signer, *big.Int, bool) {
+	if v.BitLen() <= 8 && (v.Uint64() == 27 || v.Uint64() == 28) {
+		return types.HomesteadSigner{}, nil, true
+	}
+
+	if v.Cmp(big.NewInt(35)) < 0 {
+		return nil, nil, false
+	}
+
+	// V = chainId * 2 + 35 + {0,1}
+	chainId := new(big.Int).Sub(v, big.NewInt(35))
+	chainId.Rsh(chainId, 1)
+
+	return types.NewEIP155Signer(chainId), chainId, true
+}
+
+// RecoverLegacySender recovers sender address of legacy transaction from its signature.
+func RecoverLegacySender(tx *TransactionJson) (common.Address, error) {
+	v, err := parseHexBig(tx.V)
+	if err != nil {
+		return common.Address{}, err
+	}
+	r, err := parseHexBig(tx.R)
+	if err != nil {
+		return common.Address{}, err
+	}
+	s, err := parseHexBig(tx.S)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if r.Sign() == 0 || s.Sign() == 0 {
+		return common.Address{}, ErrUnsignedTransaction
+	}
+
+	signer, _, ok := LegacySigner(v)
+	if !ok {
+		return common.Address{}, fmt.Errorf("unsupported legacy signature v=%s for transaction %s", tx.V, tx.Hash)
+	}
+
+	nonce, err := parseHexBig(tx.Nonce)
+	if err != nil {
+		return common.Address{}, err
+	}
+	gasPrice, err := parseHexBig(tx.GasPrice)
+	if err != nil {
+		return common.Address{}, err
+	}
+	gas, err := parseHexBig(tx.Gas)
+	if err != nil {
+		return common.Address{}, err
+	}
+	value, err := parseHexBig(tx.Value)
+	if err != nil {
+		return common.Address{}, err
+	}
+	input, err := hexutil.Decode(tx.Input)
+	if err != nil && tx.Input != "" && tx.Input != "0x" {
+		return common.Address{}, fmt.Errorf("invalid input of transaction %s: %v", tx.Hash, err)
+	}
+
+	var to *common.Address
+	if tx.ToAddress != "" {
+		toAddress := common.HexToAddress(tx.ToAddress)
+		to = &toAddress
+	}
+
+	signedTx := types.NewTx(&types.LegacyTx{
+		Nonce:    nonce.Uint64(),
+		GasPrice: gasPrice,
+		Gas:      gas.Uint64(),
+		To:       to,
+		Value:    value,
+		Data:     input,
+		V:        v,
+		R:        r,
+		S:        s,
+	})
+
+	return types.Sender(signer, signedTx)
+}
+
+// NormalizeLegacyTransaction fills fields of legacy transaction which are missing or inconsistent
+// across node implementations: chain ID derived from EIP-155 V value, y-parity and sender address
+// recovered from signature. Sender returned by node is kept, chains with their own signing
+// schemes and system transactions (Arbitrum, zkSync, OP deposits, bor state-sync) report senders
+// which could not be recovered from signature, so sender is recovered only if it is missing.
+func NormalizeLegacyTransaction(tx *TransactionJson) error {
+	if !IsLegacyTransaction(tx) {
+		return nil
+	}
+
+	v, err := parseHexBig(tx.V)
+	if err != nil {
+		return err
+	}
+
+	_, chainId, ok := LegacySigner(v)
+	if !ok {
+		return nil
+	}
+
+	if chainId != nil {
+		if tx.ChainId == "" {
+			tx.ChainId = hexutil.EncodeBig(chainId)
+		}
+		if tx.YParity == "" {
+			tx.YParity = hexutil.EncodeUint64(uint64(new(big.Int).Sub(v, big.NewInt(35)).Bit(0)))
+		}
+	} else if tx.YParity == "" {
+		tx.YParity = hexutil.EncodeUint64(v.Uint64() - 27)
+	}
+
+	if tx.FromAddress != "" && tx.FromAddress != "0x" {
+		return nil
+	}
+
+	sender, err := RecoverLegacySender(tx)
+	if err != nil {
+		if errors.Is(err, ErrUnsignedTransaction) {
+			return nil
+		}
+		return err
+	}
+
+	tx.FromAddress = strings.ToLower(sender.Hex())
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package common
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// signedLegacyTransaction signs legacy transfer with signer and returns it as node returns it,
+// sender is left out.
+func signedLegacyTransaction(t *testing.T, signer types.Signer) (*TransactionJson, string) {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := common.HexToAddress("0x5a52e96bacdabb82fd05763e25335261b270efcb")
+	tx, err := types.SignNewTx(key, signer, &types.LegacyTx{
+		Nonce:    7,
+		GasPrice: big.NewInt(20000000000),
+		Gas:      21000,
+		To:       &to,
+		Value:    big.NewInt(1000000000000000000),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, r, s := tx.RawSignatureValues()
+	txJson := &TransactionJson{
+		Hash:            tx.Hash().Hex(),
+		Nonce:           hexutil.EncodeUint64(tx.Nonce()),
+		GasPrice:        hexutil.EncodeBig(tx.GasPrice()),
+		Gas:             hexutil.EncodeUint64(tx.Gas()),
+		ToAddress:       strings.ToLower(to.Hex()),
+		Value:           hexutil.EncodeBig(tx.Value()),
+		Input:           "0x",
+		TransactionType: "0x0",
+		V:               hexutil.EncodeBig(v),
+		R:               hexutil.EncodeBig(r),
+		S:               hexutil.EncodeBig(s),
+	}
+
+	return txJson, strings.ToLower(crypto.PubkeyToAddress(key.PublicKey).Hex())
+}
+
+func TestNormalizeLegacyTransactionRecoversMissingSender(t *testing.T) {
+	testCases := []struct {
+		name            string
+		signer          types.Signer
+		expectedChainId string
+	}{
+		{"pre-EIP-155 transaction", types.HomesteadSigner{}, ""},
+		{"EIP-155 transaction", types.NewEIP155Signer(big.NewInt(1)), "0x1"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			txJson, sender := signedLegacyTransaction(t, testCase.signer)
+
+			if err := NormalizeLegacyTransaction(txJson); err != nil {
+				t.Fatalf("NormalizeLegacyTransaction: %v", err)
+			}
+			if txJson.FromAddress != sender {
+				t.Fatalf("sender is %s, want %s", txJson.FromAddress, sender)
+			}
+			if txJson.ChainId != testCase.expectedChainId {
+				t.Fatalf("chain ID is %q, want %q", txJson.ChainId, testCase.expectedChainId)
+			}
+			if txJson.YParity != "0x0" && txJson.YParity != "0x1" {
+				t.Fatalf("unexpected y-parity %q", txJson.YParity)
+			}
+		})
+	}
+}
+
+func TestNormalizeLegacyTransactionKeepsSenderOfNode(t *testing.T) {
+	// Signature of other key, as for chains which sign transactions with their own schemes
+	signedTx, _ := signedLegacyTransaction(t, types.NewEIP155Signer(big.NewInt(42161)))
+	signedTx.FromAddress = "0x00000000000000000000000000000000000a4b05"
+
+	testCases := []struct {
+		name string
+		tx   *TransactionJson
+	}{
+		{"legacy transaction signed by other scheme", signedTx},
+		{
+			name: "bor state-sync transaction",
+			tx: &TransactionJson{
+				Hash:            "0x3f0b2a4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708",
+				FromAddress:     "0x0000000000000000000000000000000000000000",
+				ToAddress:       "0x0000000000000000000000000000000000000000",
+				TransactionType: "0x0",
+				V:               "0x0",
+				R:               "0x0",
+				S:               "0x0",
+			},
+		},
+		{
+			name: "OP deposit transaction",
+			tx: &TransactionJson{
+				Hash:            "0x1111111111111111111111111111111111111111111111111111111111111111",
+				FromAddress:     "0xdeaddeaddeaddeaddeaddeaddeaddeaddead0001",
+				ToAddress:       "0x4200000000000000000000000000000000000015",
+				TransactionType: "0x7e",
+				SourceHash:      "0x2222222222222222222222222222222222222222222222222222222222222222",
+				IsSystemTx:      true,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			from := testCase.tx.FromAddress
+
+			if err := NormalizeLegacyTransaction(testCase.tx); err != nil {
+				t.Fatalf("NormalizeLegacyTransaction: %v", err)
+			}
+			if testCase.tx.FromAddress != from {
+				t.Fatalf("sender of node %s is replaced by %s", from, testCase.tx.FromAddress)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package common
+
+import "strings"
+
+// DepositTransactionType is type of OP stack deposit transactions, they are derived from L1
+// and have no signature, sender is set by L1 portal contract.
+const DepositTransactionType = 0x7e
+
+// IsDepositTransaction checks if transaction is OP stack deposit.
+func IsDepositTransaction(tx *TransactionJson) bool {
+	return strings.EqualFold(strings.TrimPrefix(tx.TransactionType, "0x"), "7e")
+}
+
+// DepositLabelData describes deposit in label data of transaction call.
+func DepositLabelData(sourceHash, mint string, isSystemTx bool) map[string]interface{} {
+	data := map[string]interface{}{
+		"source_hash":  sourceHash,
+		"is_system_tx": isSystemTx,
+	}
+	if mint != "" {
+		if value, err := parseHexBig(mint); err == nil {
+			data["mint"] = value.String()
+		}
+	}
+	return data
+}
@@ -0,0 +1,123 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Widths of eth_getLogs block ranges tried by probe, the widest accepted one is reported
+var ProbeLogsRanges = []uint64{100000, 50000, 10000, 5000, 2000, 1000, 500, 100, 10, 1}
+
+// Number of eth_blockNumber requests latency of endpoint is averaged over
+var ProbeLatencySamples = 3
+
+// ProbeReport describes capabilities of RPC endpoint which crawler and synchronizer depend on.
+type ProbeReport struct {
+	Chain           string            `json:"chain"`
+	Endpoints       []string          `json:"endpoints"`
+	ChainID         uint64            `json:"chain_id"`
+	ExpectedChainID uint64            `json:"expected_chain_id,omitempty"`
+	LatestBlock     uint64            `json:"latest_block"`
+	LatencyMs       int64             `json:"latency_ms"`
+	BlockReceipts   bool              `json:"block_receipts"`
+	DebugTrace      bool              `json:"debug_trace"`
+	Archive         bool              `json:"archive"`
+	MaxLogsRange    uint64            `json:"max_logs_range"`
+	Errors          map[string]string `json:"errors,omitempty"`
+}
+
+// ProbingClient is implemented by chain clients which could report capabilities of their RPC.
+type ProbingClient interface {
+	Probe(context.Context) (*ProbeReport, error)
+}
+
+// ProbeRPC calls RPC with requests of every capability, each request is limited by timeout.
+// Unreachable endpoint is an error, capabilities which are rejected by node are reported as
+// unsupported and unexpected errors of them are kept in report.
+func ProbeRPC(ctx context.Context, chain string, pool *RPCPool, timeout time.Duration) (*ProbeReport, error) {
+	report := &ProbeReport{Chain: chain, Errors: make(map[string]string)}
+	for _, endpoint := range pool.endpoints {
+		report.Endpoints = append(report.Endpoints, redactRPCURL(endpoint.URL))
+	}
+
+	call := func(result interface{}, method string, args ...interface{}) error {
+		callCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return pool.CallContext(callCtx, result, method, args...)
+	}
+
+	var elapsed time.Duration
+	for i := 0; i < ProbeLatencySamples; i++ {
+		var latest hexutil.Uint64
+		started := time.Now()
+		if err := call(&latest, "eth_blockNumber"); err != nil {
+			return nil, fmt.Errorf("failed to get latest block number: %w", err)
+		}
+		elapsed += time.Since(started)
+		report.LatestBlock = uint64(latest)
+	}
+	if ProbeLatencySamples > 0 {
+		report.LatencyMs = (elapsed / time.Duration(ProbeLatencySamples)).Milliseconds()
+	}
+
+	var chainID hexutil.Uint64
+	if err := call(&chainID, "eth_chainId"); err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	report.ChainID = uint64(chainID)
+
+	latestHex := hexutil.EncodeUint64(report.LatestBlock)
+
+	var receipts json.RawMessage
+	report.BlockReceipts = probeCapability(report, "eth_getBlockReceipts", call(&receipts, "eth_getBlockReceipts", latestHex))
+
+	// Only top call is traced, probe should not make node replay every transaction of block
+	var traces json.RawMessage
+	report.DebugTrace = probeCapability(report, "debug_traceBlockByNumber", call(&traces, "debug_traceBlockByNumber", latestHex,
+		map[string]interface{}{"tracer": "callTracer", "tracerConfig": map[string]interface{}{"onlyTopCall": true}}))
+
+	// Full nodes prune state of old blocks, balance at block 1 is available only on archive node
+	var balance hexutil.Big
+	report.Archive = probeCapability(report, "archive", call(&balance, "eth_getBalance", common.Address{}, "0x1"))
+
+	var lastLogsErr error
+	for _, width := range ProbeLogsRanges {
+		fromBlock := uint64(0)
+		if report.LatestBlock+1 > width {
+			fromBlock = report.LatestBlock + 1 - width
+		}
+
+		var logs []json.RawMessage
+		lastLogsErr = call(&logs, "eth_getLogs", map[string]interface{}{
+			"fromBlock": hexutil.EncodeUint64(fromBlock),
+			"toBlock":   latestHex,
+			"address":   []common.Address{{}},
+		})
+		if lastLogsErr == nil {
+			report.MaxLogsRange = report.LatestBlock - fromBlock + 1
+			break
+		}
+	}
+	if report.MaxLogsRange == 0 && lastLogsErr != nil {
+		report.Errors["eth_getLogs"] = lastLogsErr.Error()
+	}
+
+	return report, nil
+}
+
+// probeCapability reports whether capability is supported, errors other than not supported
+// method are kept in report.
+func probeCapability(report *ProbeReport, capability string, err error) bool {
+	if err == nil {
+		return true
+	}
+	if !isMethodNotSupportedError(err) {
+		report.Errors[capability] = err.Error()
+	}
+	return false
+}
@@ -0,0 +1,269 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	// EIP-1967 slot of implementation address, keccak256("eip1967.proxy.implementation") - 1
+	EIP1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+	// EIP-1967 slot of beacon address, keccak256("eip1967.proxy.beacon") - 1
+	EIP1967BeaconSlot = common.HexToHash("0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaf59d2a60e1af0d0ebb9b1")
+
+	// Bytecode of EIP-1167 minimal proxy is prefix, implementation address and suffix
+	eip1167Prefix = common.FromHex("0x363d3d373d3d3d363d73")
+	eip1167Suffix = common.FromHex("0x5af43d82803e903d91602b57fd5bf3")
+
+	// Selector of implementation() function of beacon
+	beaconImplementationSelector = common.FromHex("0x5c60da1b")
+)
+
+// StorageClient is implemented by chain clients which could read storage slots of contracts.
+type StorageClient interface {
+	GetStorageAt(ctx context.Context, address common.Address, slot common.Hash, blockNumber uint64) (common.Hash, error)
+}
+
+// MinimalProxyImplementation returns implementation address if code is EIP-1167 minimal proxy.
+func MinimalProxyImplementation(code []byte) (common.Address, bool) {
+	if len(code) != len(eip1167Prefix)+common.AddressLength+len(eip1167Suffix) {
+		return common.Address{}, false
+	}
+	if !bytes.HasPrefix(code, eip1167Prefix) || !bytes.HasSuffix(code, eip1167Suffix) {
+		return common.Address{}, false
+	}
+
+	return common.BytesToAddress(code[len(eip1167Prefix) : len(eip1167Prefix)+common.AddressLength]), true
+}
+
+// ImplementationRange is a range of blocks in which proxy delegated to Implementation, zero
+// Implementation means that contract was not a proxy in the range.
+type ImplementationRange struct {
+	FromBlock      uint64
+	ToBlock        uint64
+	Implementation common.Address
+}
+
+// ProxyResolver resolves implementations of EIP-1967 and EIP-1167 proxies. EIP-1967
+// implementation is read from implementation slot, or from beacon in beacon slot, at blocks
+// of requested range. Upgrades are found by bisection of range between blocks with different
+// implementations, upgrade and rollback to the same implementation between two reads is not
+// detected. Resolved ranges are cached, so contiguous ranges of sync cost one read per address.
+type ProxyResolver struct {
+	client ChainClient
+
+	mu            sync.Mutex
+	minimalProxy  map[common.Address]*common.Address
+	resolved      map[common.Address][]ImplementationRange
+	storageClient StorageClient
+}
+
+func NewProxyResolver(client ChainClient) (*ProxyResolver, error) {
+	storageClient, ok := client.(StorageClient)
+	if !ok {
+		return nil, fmt.Errorf("client of chain type %s does not support reading of storage slots", client.ChainType())
+	}
+
+	return &ProxyResolver{
+		client:        client,
+		minimalProxy:  make(map[common.Address]*common.Address),
+		resolved:      make(map[common.Address][]ImplementationRange),
+		storageClient: storageClient,
+	}, nil
+}
+
+// Implementations returns ranges of implementations of address intersecting with block range,
+// ranges without implementation are omitted.
+func (r *ProxyResolver) Implementations(ctx context.Context, address common.Address, fromBlock, toBlock uint64) ([]ImplementationRange, error) {
+	if toBlock < fromBlock {
+		return nil, fmt.Errorf("invalid block range %d-%d", fromBlock, toBlock)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Minimal proxy could not be upgraded, its implementation is in immutable bytecode
+	implementation, checked := r.minimalProxy[address]
+	if !checked {
+		code, err := r.client.GetCode(ctx, address, 0)
+		if err != nil {
+			return nil, err
+		}
+		if minimal, ok := MinimalProxyImplementation(code); ok {
+			implementation = &minimal
+		}
+		r.minimalProxy[address] = implementation
+	}
+	if implementation != nil {
+		return []ImplementationRange{{FromBlock: fromBlock, ToBlock: toBlock, Implementation: *implementation}}, nil
+	}
+
+	if err := r.extend(ctx, address, fromBlock, toBlock); err != nil {
+		return nil, err
+	}
+
+	var ranges []ImplementationRange
+	for _, resolved := range r.resolved[address] {
+		if resolved.ToBlock < fromBlock || resolved.FromBlock > toBlock || resolved.Implementation == (common.Address{}) {
+			continue
+		}
+		ranges = append(ranges, ImplementationRange{
+			FromBlock:      max(resolved.FromBlock, fromBlock),
+			ToBlock:        min(resolved.ToBlock, toBlock),
+			Implementation: resolved.Implementation,
+		})
+	}
+
+	return ranges, nil
+}
+
+// extend resolves blocks of range not covered by cached ranges of address, caller should
+// hold lock.
+func (r *ProxyResolver) extend(ctx context.Context, address common.Address, fromBlock, toBlock uint64) error {
+	cached := r.resolved[address]
+
+	if len(cached) == 0 {
+		ranges, err := r.bisect(ctx, address, fromBlock, toBlock, nil, nil)
+		if err != nil {
+			return err
+		}
+		r.resolved[address] = ranges
+		return nil
+	}
+
+	first, last := cached[0], cached[len(cached)-1]
+	if fromBlock < first.FromBlock {
+		implementationAtFirst := first.Implementation
+		ranges, err := r.bisect(ctx, address, fromBlock, first.FromBlock, nil, &implementationAtFirst)
+		if err != nil {
+			return err
+		}
+		cached = mergeImplementationRanges(ranges, cached)
+	}
+	if toBlock > last.ToBlock {
+		implementationAtLast := last.Implementation
+		ranges, err := r.bisect(ctx, address, last.ToBlock, toBlock, &implementationAtLast, nil)
+		if err != nil {
+			return err
+		}
+		cached = mergeImplementationRanges(cached, ranges)
+	}
+
+	r.resolved[address] = cached
+	return nil
+}
+
+// bisect splits range at blocks where implementation changes, known implementations at
+// bounds are not read again.
+func (r *ProxyResolver) bisect(ctx context.Context, address common.Address, fromBlock, toBlock uint64, atFrom, atTo *common.Address) ([]ImplementationRange, error) {
+	if atFrom == nil {
+		implementation, err := r.implementationAt(ctx, address, fromBlock)
+		if err != nil {
+			return nil, err
+		}
+		atFrom = &implementation
+	}
+	if fromBlock == toBlock {
+		return []ImplementationRange{{FromBlock: fromBlock, ToBlock: toBlock, Implementation: *atFrom}}, nil
+	}
+	if atTo == nil {
+		implementation, err := r.implementationAt(ctx, address, toBlock)
+		if err != nil {
+			return nil, err
+		}
+		atTo = &implementation
+	}
+
+	if *atFrom == *atTo {
+		return []ImplementationRange{{FromBlock: fromBlock, ToBlock: toBlock, Implementation: *atFrom}}, nil
+	}
+	if toBlock == fromBlock+1 {
+		return []ImplementationRange{
+			{FromBlock: fromBlock, ToBlock: fromBlock, Implementation: *atFrom},
+			{FromBlock: toBlock, ToBlock: toBlock, Implementation: *atTo},
+		}, nil
+	}
+
+	middle := fromBlock + (toBlock-fromBlock)/2
+	implementationAtMiddle, err := r.implementationAt(ctx, address, middle)
+	if err != nil {
+		return nil, err
+	}
+
+	lower, err := r.bisect(ctx, address, fromBlock, middle, atFrom, &implementationAtMiddle)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := r.bisect(ctx, address, middle, toBlock, &implementationAtMiddle, atTo)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeImplementationRanges(lower, upper), nil
+}
+
+// implementationAt reads implementation of EIP-1967 proxy at block, zero address is returned
+// for contracts which are not proxies.
+func (r *ProxyResolver) implementationAt(ctx context.Context, address common.Address, blockNumber uint64) (common.Address, error) {
+	slot, err := r.storageClient.GetStorageAt(ctx, address, EIP1967ImplementationSlot, blockNumber)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to read implementation slot of %s at block %d: %w", address.Hex(), blockNumber, err)
+	}
+	if implementation := common.BytesToAddress(slot.Bytes()); implementation != (common.Address{}) {
+		return implementation, nil
+	}
+
+	slot, err = r.storageClient.GetStorageAt(ctx, address, EIP1967BeaconSlot, blockNumber)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to read beacon slot of %s at block %d: %w", address.Hex(), blockNumber, err)
+	}
+	beacon := common.BytesToAddress(slot.Bytes())
+	if beacon == (common.Address{}) {
+		return common.Address{}, nil
+	}
+
+	result, err := r.client.CallContract(ctx, beacon, beaconImplementationSelector, blockNumber)
+	if err != nil {
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) {
+			return common.Address{}, nil
+		}
+		return common.Address{}, fmt.Errorf("failed to call implementation of beacon %s at block %d: %w", beacon.Hex(), blockNumber, err)
+	}
+	if len(result) < 32 {
+		return common.Address{}, nil
+	}
+
+	return common.BytesToAddress(result[12:32]), nil
+}
+
+// mergeImplementationRanges concatenates adjacent ranges, ranges with the same implementation
+// which touch or overlap at bound are joined.
+func mergeImplementationRanges(lower, upper []ImplementationRange) []ImplementationRange {
+	merged := append([]ImplementationRange{}, lower...)
+	for _, next := range upper {
+		if len(merged) > 0 {
+			last := &merged[len(merged)-1]
+			if next.FromBlock <= last.ToBlock+1 {
+				if last.Implementation == next.Implementation {
+					last.ToBlock = max(last.ToBlock, next.ToBlock)
+					continue
+				}
+				// Shared bound block belongs to range which was resolved at it
+				if next.FromBlock <= last.ToBlock {
+					next.FromBlock = last.ToBlock + 1
+				}
+			}
+		}
+		if next.FromBlock <= next.ToBlock {
+			merged = append(merged, next)
+		}
+	}
+	return merged
+}
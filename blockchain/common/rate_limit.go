@@ -0,0 +1,210 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	// Number of retries of request rejected by provider rate limit
+	RPCRateLimitRetries = 5
+	RPCRateLimitBackoff = 500 * time.Millisecond
+	// Minimal time between two decreases of concurrency, requests started before decrease
+	// fail together and should be counted as one signal
+	AdaptiveConcurrencyCooldown = time.Second
+)
+
+// RateLimitConfig limits rate of requests sent by chain client to its RPC endpoints.
+type RateLimitConfig struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+var (
+	rpcRateLimitsOnce sync.Once
+	rpcRateLimits     map[string]RateLimitConfig
+	rpcRateLimitsErr  error
+)
+
+// ParseRPCRateLimits parses rate limits of chain clients in format
+// "<chain>=<requests per second>[:<burst>],...", e.g. "ethereum=10,polygon=25:50".
+func ParseRPCRateLimits(raw string) (map[string]RateLimitConfig, error) {
+	limits := make(map[string]RateLimitConfig)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		chain, spec, found := strings.Cut(entry, "=")
+		if !found || chain == "" {
+			return nil, fmt.Errorf("invalid RPC rate limit %q, expected <chain>=<requests per second>[:<burst>]", entry)
+		}
+
+		rps, burst, burstFound := strings.Cut(spec, ":")
+		requestsPerSecond, err := strconv.ParseFloat(rps, 64)
+		if err != nil || requestsPerSecond <= 0 {
+			return nil, fmt.Errorf("invalid requests per second %q of chain %s, it should be positive number", rps, chain)
+		}
+
+		config := RateLimitConfig{RequestsPerSecond: requestsPerSecond, Burst: int(requestsPerSecond)}
+		if burstFound {
+			config.Burst, err = strconv.Atoi(burst)
+			if err != nil || config.Burst <= 0 {
+				return nil, fmt.Errorf("invalid burst %q of chain %s, it should be positive integer", burst, chain)
+			}
+		}
+		if config.Burst < 1 {
+			config.Burst = 1
+		}
+
+		limits[chain] = config
+	}
+
+	return limits, nil
+}
+
+// RPCRateLimitFor returns rate limit of chain client configured with SEER_RPC_RATE_LIMITS
+// environment variable.
+func RPCRateLimitFor(chain string) (RateLimitConfig, bool, error) {
+	rpcRateLimitsOnce.Do(func() {
+		rpcRateLimits, rpcRateLimitsErr = ParseRPCRateLimits(os.Getenv("SEER_RPC_RATE_LIMITS"))
+	})
+	if rpcRateLimitsErr != nil {
+		return RateLimitConfig{}, false, fmt.Errorf("invalid SEER_RPC_RATE_LIMITS environment variable: %w", rpcRateLimitsErr)
+	}
+
+	config, exists := rpcRateLimits[chain]
+	return config, exists, nil
+}
+
+// RateLimitedClient is implemented by chain clients which support limiting rate of requests.
+type RateLimitedClient interface {
+	SetRateLimit(RateLimitConfig)
+}
+
+// IsRateLimitError returns true if provider rejected request because of its rate limit,
+// either with HTTP 429 status or with JSON-RPC error as Alchemy and Infura do.
+func IsRateLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && (rpcErr.ErrorCode() == 429 || rpcErr.ErrorCode() == -32005) {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "too many requests") || strings.Contains(message, "rate limit") || strings.Contains(message, "exceeded its compute units")
+}
+
+// AdaptiveLimiter limits number of concurrent requests. Limit is halved when provider
+// rejects request with rate limit error and grows by one after limit successful requests
+// in a row, up to initial maximum.
+type AdaptiveLimiter struct {
+	max int
+
+	mu           sync.Mutex
+	cond         *sync.Cond
+	limit        int
+	inFlight     int
+	successes    int
+	lastDecrease time.Time
+}
+
+func NewAdaptiveLimiter(max int) *AdaptiveLimiter {
+	if max < 1 {
+		max = 1
+	}
+
+	l := &AdaptiveLimiter{max: max, limit: max}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+// Acquire blocks until request could be sent within current limit.
+func (l *AdaptiveLimiter) Acquire() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for l.inFlight >= l.limit {
+		l.cond.Wait()
+	}
+	l.inFlight++
+}
+
+// Release frees slot of finished request and adjusts limit by its result.
+func (l *AdaptiveLimiter) Release(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+
+	switch {
+	case IsRateLimitError(err):
+		l.successes = 0
+		if time.Since(l.lastDecrease) >= AdaptiveConcurrencyCooldown && l.limit > 1 {
+			l.limit /= 2
+			l.lastDecrease = time.Now()
+		}
+	case err == nil:
+		l.successes++
+		if l.successes >= l.limit && l.limit < l.max {
+			l.limit++
+			l.successes = 0
+		}
+	}
+
+	l.cond.Broadcast()
+}
+
+// Do calls fn within limit, slot is released even if fn panics.
+func (l *AdaptiveLimiter) Do(fn func() error) (err error) {
+	l.Acquire()
+	defer func() { l.Release(err) }()
+
+	return fn()
+}
+
+// Limit returns current concurrency limit.
+func (l *AdaptiveLimiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.limit
+}
+
+// RetryRateLimited calls fn through limiter and retries it with exponential backoff while
+// provider rejects it with rate limit error.
+func RetryRateLimited(ctx context.Context, limiter *AdaptiveLimiter, fn func() error) error {
+	backoff := RPCRateLimitBackoff
+	for attempt := 0; ; attempt++ {
+		err := limiter.Do(fn)
+
+		if !IsRateLimitError(err) || attempt >= RPCRateLimitRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,240 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/G7DAO/seer/logging"
+)
+
+// Number of blocks which receipts are kept in cache of receipts fetcher
+var ReceiptCacheBlocks = 128
+
+const (
+	blockReceiptsUnknown int32 = iota
+	blockReceiptsSupported
+	blockReceiptsUnsupported
+)
+
+var (
+	blockReceiptsSupportMu sync.Mutex
+	blockReceiptsSupport   = make(map[string]int32)
+)
+
+func blockReceiptsCapability(chain string) int32 {
+	blockReceiptsSupportMu.Lock()
+	defer blockReceiptsSupportMu.Unlock()
+	return blockReceiptsSupport[chain]
+}
+
+func setBlockReceiptsCapability(chain string, capability int32) {
+	blockReceiptsSupportMu.Lock()
+	defer blockReceiptsSupportMu.Unlock()
+	if blockReceiptsSupport[chain] != capability {
+		if capability == blockReceiptsUnsupported {
+			logging.Chain(chain).Warn("RPC does not support eth_getBlockReceipts, receipts are fetched per transaction")
+		}
+		blockReceiptsSupport[chain] = capability
+	}
+}
+
+// isMethodNotSupportedError returns true if RPC endpoint rejected call because method
+// is not implemented or not enabled at provider.
+func isMethodNotSupportedError(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32601 {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "method not found") ||
+		strings.Contains(message, "does not exist/is not available") ||
+		strings.Contains(message, "not supported") ||
+		strings.Contains(message, "unsupported method")
+}
+
+type blockReceipts struct {
+	once     sync.Once
+	receipts map[common.Hash]*types.Receipt
+	err      error
+}
+
+// ReceiptsFetcher fetches receipts of whole block with eth_getBlockReceipts and caches them
+// by block hash, so transactions of the same block cost one request. Chains which RPC does
+// not support the method fall back to eth_getTransactionReceipt.
+type ReceiptsFetcher struct {
+	chain string
+	rpc   *RPCPool
+
+	mu     sync.Mutex
+	blocks map[string]*blockReceipts
+	order  []string
+}
+
+func NewReceiptsFetcher(chain string, rpcPool *RPCPool) *ReceiptsFetcher {
+	return &ReceiptsFetcher{
+		chain:  chain,
+		rpc:    rpcPool,
+		blocks: make(map[string]*blockReceipts),
+	}
+}
+
+func (f *ReceiptsFetcher) block(blockHash string) *blockReceipts {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := strings.ToLower(blockHash)
+	if entry, ok := f.blocks[key]; ok {
+		return entry
+	}
+
+	entry := &blockReceipts{}
+	f.blocks[key] = entry
+	f.order = append(f.order, key)
+	for len(f.order) > ReceiptCacheBlocks {
+		delete(f.blocks, f.order[0])
+		f.order = f.order[1:]
+	}
+
+	return entry
+}
+
+func (f *ReceiptsFetcher) forget(blockHash string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.blocks, strings.ToLower(blockHash))
+}
+
+func (f *ReceiptsFetcher) fetchBlock(ctx context.Context, blockNumber uint64, blockHash string) (map[common.Hash]*types.Receipt, error) {
+	var receipts []*types.Receipt
+	err := f.rpc.CallContext(ctx, &receipts, "eth_getBlockReceipts", fmt.Sprintf("0x%x", blockNumber))
+	if err != nil {
+		return nil, err
+	}
+
+	expectedHash := common.HexToHash(blockHash)
+	result := make(map[common.Hash]*types.Receipt, len(receipts))
+	for _, receipt := range receipts {
+		if receipt == nil {
+			continue
+		}
+		// Block at number could be replaced by reorg since it was fetched
+		if receipt.BlockHash != expectedHash {
+			return nil, fmt.Errorf("receipts of block %d belong to block %s instead of %s", blockNumber, receipt.BlockHash.Hex(), blockHash)
+		}
+		result[receipt.TxHash] = receipt
+	}
+
+	return result, nil
+}
+
+// TransactionReceipt returns receipt of transaction from receipts of its block, block receipts
+// are requested once and reused for other transactions of block.
+func (f *ReceiptsFetcher) TransactionReceipt(ctx context.Context, blockNumber uint64, blockHash string, txHash common.Hash) (*types.Receipt, error) {
+	if blockHash != "" && blockReceiptsCapability(f.chain) != blockReceiptsUnsupported {
+		entry := f.block(blockHash)
+		entry.once.Do(func() {
+			entry.receipts, entry.err = f.fetchBlock(ctx, blockNumber, blockHash)
+		})
+
+		if entry.err == nil {
+			setBlockReceiptsCapability(f.chain, blockReceiptsSupported)
+			if receipt, ok := entry.receipts[txHash]; ok {
+				return receipt, nil
+			}
+		} else {
+			f.forget(blockHash)
+			if blockReceiptsCapability(f.chain) == blockReceiptsUnknown && isMethodNotSupportedError(entry.err) {
+				setBlockReceiptsCapability(f.chain, blockReceiptsUnsupported)
+			} else {
+				logging.Chain(f.chain).Warn("Failed to fetch receipts of block, fetching receipt of transaction", logging.BlockKey, blockNumber, logging.TxKey, txHash.Hex(), logging.ErrorKey, entry.err)
+			}
+		}
+	}
+
+	var receipt *types.Receipt
+	err := f.rpc.CallContext(ctx, &receipt, "eth_getTransactionReceipt", txHash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt == nil {
+		return nil, fmt.Errorf("receipt of transaction %s not found", txHash.Hex())
+	}
+
+	return receipt, nil
+}
+
+var (
+	logsFromReceiptsChainsOnce sync.Once
+	logsFromReceiptsChains     map[string]bool
+)
+
+// LogsFromReceiptsFor returns true if crawler should attach logs of chain from transaction
+// receipts instead of eth_getLogs, chains are listed in SEER_LOGS_FROM_RECEIPTS environment
+// variable separated by comma.
+func LogsFromReceiptsFor(chain string) bool {
+	logsFromReceiptsChainsOnce.Do(func() {
+		logsFromReceiptsChains = make(map[string]bool)
+		for _, receiptsChain := range strings.Split(os.Getenv("SEER_LOGS_FROM_RECEIPTS"), ",") {
+			receiptsChain = strings.TrimSpace(receiptsChain)
+			if receiptsChain != "" {
+				logsFromReceiptsChains[receiptsChain] = true
+			}
+		}
+	})
+
+	return logsFromReceiptsChains[chain]
+}
+
+// LogsOfTransactions returns logs of transactions of block taken from their receipts, in order
+// of transactions and of logs inside of receipt. Receipts belonging to other block mean block
+// was replaced by reorg after it was fetched, the block should be fetched again.
+func (f *ReceiptsFetcher) LogsOfTransactions(ctx context.Context, blockNumber uint64, blockHash string, txHashes []string) ([]*EventJson, error) {
+	expectedHash := common.HexToHash(blockHash)
+
+	var logs []*EventJson
+	for _, txHash := range txHashes {
+		receipt, err := f.TransactionReceipt(ctx, blockNumber, blockHash, common.HexToHash(txHash))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch receipt of transaction %s: %w", txHash, err)
+		}
+		if receipt.BlockHash != expectedHash {
+			return nil, fmt.Errorf("receipt of transaction %s belongs to block %s instead of %s", txHash, receipt.BlockHash.Hex(), blockHash)
+		}
+
+		for _, receiptLog := range receipt.Logs {
+			logs = append(logs, eventJsonFromLog(receiptLog))
+		}
+	}
+
+	return logs, nil
+}
+
+// eventJsonFromLog formats log of receipt the same way as eth_getLogs returns it.
+func eventJsonFromLog(receiptLog *types.Log) *EventJson {
+	topics := make([]string, len(receiptLog.Topics))
+	for i, topic := range receiptLog.Topics {
+		topics[i] = topic.Hex()
+	}
+
+	return &EventJson{
+		Address:          strings.ToLower(receiptLog.Address.Hex()),
+		Topics:           topics,
+		Data:             hexutil.Encode(receiptLog.Data),
+		BlockNumber:      fmt.Sprintf("0x%x", receiptLog.BlockNumber),
+		TransactionHash:  receiptLog.TxHash.Hex(),
+		BlockHash:        receiptLog.BlockHash.Hex(),
+		Removed:          receiptLog.Removed,
+		LogIndex:         fmt.Sprintf("0x%x", receiptLog.Index),
+		TransactionIndex: fmt.Sprintf("0x%x", receiptLog.TxIndex),
+	}
+}
@@ -0,0 +1,55 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ReorgDetected is returned when fetched blocks do not form a chain: parent hash of block at
+// Height is not the hash of previous block. Chain was reorganized while range was fetched,
+// the range should be fetched again.
+type ReorgDetected struct {
+	Height            uint64
+	ParentHash        string
+	PreviousBlockHash string
+}
+
+func (e *ReorgDetected) Error() string {
+	return fmt.Sprintf("reorg detected at block %d: parent hash %s does not match hash %s of previous block", e.Height, e.ParentHash, e.PreviousBlockHash)
+}
+
+// VerifyBlocksContinuity sorts blocks by number and checks that each block is a child of
+// previous one. Blocks fetched concurrently could come from different forks, in such case
+// ReorgDetected is returned with the first divergent height.
+func VerifyBlocksContinuity(blocks []*BlockJson) error {
+	numbers := make(map[*BlockJson]uint64, len(blocks))
+	for _, block := range blocks {
+		number, err := strconv.ParseUint(strings.TrimPrefix(block.BlockNumber, "0x"), 16, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q of block %s: %v", block.BlockNumber, block.Hash, err)
+		}
+		numbers[block] = number
+	}
+
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return numbers[blocks[i]] < numbers[blocks[j]]
+	})
+
+	for i := 1; i < len(blocks); i++ {
+		previous, current := blocks[i-1], blocks[i]
+		if numbers[current] != numbers[previous]+1 {
+			return fmt.Errorf("blocks are not consecutive, block %d follows block %d", numbers[current], numbers[previous])
+		}
+		if !strings.EqualFold(current.ParentHash, previous.Hash) {
+			return &ReorgDetected{
+				Height:            numbers[current],
+				ParentHash:        current.ParentHash,
+				PreviousBlockHash: previous.Hash,
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// RetryPolicy describes how RPC requests failed with transient errors are retried. Attempts
+// includes the first request, so policy with one attempt does not retry.
+type RetryPolicy struct {
+	Attempts       int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultRPCRetryPolicy is used by chain clients of chains without configured policy.
+var DefaultRPCRetryPolicy = RetryPolicy{
+	Attempts:       4,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+}
+
+// Backoff returns wait before retry which follows given number of failed attempts.
+func (p RetryPolicy) Backoff(failedAttempts int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < failedAttempts && (p.MaxBackoff == 0 || backoff < p.MaxBackoff); i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	return backoff
+}
+
+// Retry calls fn until it succeeds, fails with error which is not retryable or attempts are
+// exhausted. Error of last attempt is returned.
+func (p RetryPolicy) Retry(ctx context.Context, fn func() error) error {
+	attempts := p.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn()
+		if attempt >= attempts || ctx.Err() != nil || !IsRetryableRPCError(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(p.Backoff(attempt)):
+		}
+	}
+}
+
+// IsRetryableRPCError returns true for errors after which the same request could succeed:
+// rate limits, timeouts, 5xx responses of provider and dropped connections. JSON-RPC errors
+// returned by node mean that request was processed and are not retried, except of rate limit
+// and timeout ones.
+func IsRetryableRPCError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	// Infura reports too wide eth_getLogs range with the same code as rate limit, callers
+	// narrow the range instead.
+	if IsLogsLimitError(err) {
+		return false
+	}
+
+	if IsRateLimitError(err) {
+		return true
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusInternalServerError || httpErr.StatusCode == http.StatusRequestTimeout
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, transient := range []string{"timeout", "timed out", "temporarily unavailable", "service unavailable", "bad gateway", "connection reset"} {
+		if strings.Contains(message, transient) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// logsLimitMessages are returned by providers when eth_getLogs matches too many logs or its
+// block range is too wide.
+var logsLimitMessages = []string{
+	"query returned more than",
+	"log response size exceeded",
+	"response size exceeded",
+	"block range is too large",
+	"block range too large",
+	"exceed maximum block range",
+	"limited to a 10,000",
+	"too many logs",
+}
+
+// IsLogsLimitError returns true if eth_getLogs was rejected because of number of matched logs
+// or width of block range, the same request with narrower filter could succeed.
+func IsLogsLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, limitMessage := range logsLimitMessages {
+		if strings.Contains(message, limitMessage) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	rpcRetryPoliciesOnce sync.Once
+	rpcRetryPolicies     map[string]RetryPolicy
+	rpcRetryPoliciesErr  error
+)
+
+// ParseRPCRetryPolicies parses retry policies of chain clients in format
+// "<chain>=<attempts>[:<initial backoff>[:<max backoff>]],...", e.g. "ethereum=6:1s:30s,polygon=2".
+// Omitted backoffs are taken from DefaultRPCRetryPolicy.
+func ParseRPCRetryPolicies(raw string) (map[string]RetryPolicy, error) {
+	policies := make(map[string]RetryPolicy)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		chain, spec, found := strings.Cut(entry, "=")
+		if !found || chain == "" {
+			return nil, fmt.Errorf("invalid RPC retry policy %q, expected <chain>=<attempts>[:<initial backoff>[:<max backoff>]]", entry)
+		}
+
+		parts := strings.Split(spec, ":")
+		if len(parts) > 3 {
+			return nil, fmt.Errorf("invalid RPC retry policy %q of chain %s, expected <attempts>[:<initial backoff>[:<max backoff>]]", spec, chain)
+		}
+
+		policy := DefaultRPCRetryPolicy
+
+		var err error
+		policy.Attempts, err = strconv.Atoi(parts[0])
+		if err != nil || policy.Attempts < 1 {
+			return nil, fmt.Errorf("invalid attempts %q of chain %s, it should be positive integer", parts[0], chain)
+		}
+
+		if len(parts) > 1 {
+			policy.InitialBackoff, err = time.ParseDuration(parts[1])
+			if err != nil || policy.InitialBackoff <= 0 {
+				return nil, fmt.Errorf("invalid initial backoff %q of chain %s, it should be positive duration", parts[1], chain)
+			}
+		}
+		if len(parts) > 2 {
+			policy.MaxBackoff, err = time.ParseDuration(parts[2])
+			if err != nil || policy.MaxBackoff < policy.InitialBackoff {
+				return nil, fmt.Errorf("invalid max backoff %q of chain %s, it should be duration not less than initial backoff", parts[2], chain)
+			}
+		}
+		if policy.MaxBackoff < policy.InitialBackoff {
+			policy.MaxBackoff = policy.InitialBackoff
+		}
+
+		policies[chain] = policy
+	}
+
+	return policies, nil
+}
+
+// RPCRetryPolicyFor returns retry policy of chain client configured with SEER_RPC_RETRY_POLICIES
+// environment variable.
+func RPCRetryPolicyFor(chain string) (RetryPolicy, bool, error) {
+	rpcRetryPoliciesOnce.Do(func() {
+		rpcRetryPolicies, rpcRetryPoliciesErr = ParseRPCRetryPolicies(os.Getenv("SEER_RPC_RETRY_POLICIES"))
+	})
+	if rpcRetryPoliciesErr != nil {
+		return RetryPolicy{}, false, fmt.Errorf("invalid SEER_RPC_RETRY_POLICIES environment variable: %w", rpcRetryPoliciesErr)
+	}
+
+	policy, exists := rpcRetryPolicies[chain]
+	return policy, exists, nil
+}
+
+// RetryingClient is implemented by chain clients which retry transient RPC errors.
+type RetryingClient interface {
+	SetRetryPolicy(RetryPolicy)
+}
@@ -0,0 +1,494 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/G7DAO/seer/logging"
+	"github.com/G7DAO/seer/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+	"golang.org/x/time/rate"
+)
+
+var (
+	// Endpoint is excluded from balancing after this number of consecutive transport failures
+	RPCFailureThreshold = 3
+	// Time excluded endpoint is not used before it is checked again
+	RPCUnhealthyCooldown   = 30 * time.Second
+	RPCHealthCheckInterval = 30 * time.Second
+	// Endpoint with latest block behind the best one by more than this is excluded
+	RPCMaxBlockLag uint64 = 20
+)
+
+// RPCEndpoint is a single RPC provider of pool with its balancing weight.
+type RPCEndpoint struct {
+	URL    string
+	Weight int
+
+	client *rpc.Client
+
+	mu                  sync.Mutex
+	currentWeight       int
+	consecutiveFailures int
+	unhealthyUntil      time.Time
+	latestBlock         uint64
+	lagging             bool
+	lastErr             error
+}
+
+// RPCEndpointStatus is a snapshot of endpoint health.
+type RPCEndpointStatus struct {
+	URL                 string `json:"url"`
+	Weight              int    `json:"weight"`
+	Healthy             bool   `json:"healthy"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+	LatestBlock         uint64 `json:"latest_block"`
+	LastError           string `json:"last_error,omitempty"`
+}
+
+func (e *RPCEndpoint) healthy(now time.Time) bool {
+	return !e.lagging && now.After(e.unhealthyUntil)
+}
+
+func (e *RPCEndpoint) recordSuccess() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.consecutiveFailures = 0
+	e.unhealthyUntil = time.Time{}
+}
+
+func (e *RPCEndpoint) recordFailure(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.consecutiveFailures++
+	e.lastErr = err
+	if e.consecutiveFailures >= RPCFailureThreshold {
+		if time.Now().After(e.unhealthyUntil) {
+			slog.Warn("RPC endpoint excluded after failures", "endpoint", redactRPCURL(e.URL), "cooldown", RPCUnhealthyCooldown.String(), "failures", e.consecutiveFailures, logging.ErrorKey, err)
+		}
+		e.unhealthyUntil = time.Now().Add(RPCUnhealthyCooldown)
+	}
+}
+
+// ParseRPCEndpoints parses comma separated list of RPC URLs, each URL could be followed by
+// |<weight> for weighted balancing, e.g. "https://a.example,https://b.example|3".
+func ParseRPCEndpoints(urls string) ([]*RPCEndpoint, error) {
+	var endpoints []*RPCEndpoint
+	for _, raw := range strings.Split(urls, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		endpoint := &RPCEndpoint{URL: raw, Weight: 1}
+		if url, weight, found := strings.Cut(raw, "|"); found {
+			parsedWeight, err := strconv.Atoi(weight)
+			if err != nil || parsedWeight <= 0 {
+				return nil, fmt.Errorf("invalid weight %q of RPC endpoint, it should be positive integer", weight)
+			}
+			endpoint.URL = url
+			endpoint.Weight = parsedWeight
+		}
+
+		endpoints = append(endpoints, endpoint)
+	}
+
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("at least one RPC URL is required")
+	}
+
+	return endpoints, nil
+}
+
+// redactRPCURL hides path and query of URL in logs, providers often put API keys there.
+func redactRPCURL(url string) string {
+	scheme, rest, found := strings.Cut(url, "://")
+	if !found {
+		return url
+	}
+	host, _, _ := strings.Cut(rest, "/")
+	return scheme + "://" + host
+}
+
+// RPCPool balances calls between RPC endpoints with smooth weighted round-robin and fails
+// over to next endpoint on transport errors. JSON-RPC errors returned by node are not retried,
+// other provider would most likely respond with the same error. When every endpoint failed
+// with transient error, request is retried with backoff according to retry policy.
+type RPCPool struct {
+	endpoints []*RPCEndpoint
+	limiter   *rate.Limiter
+	schedule  *CrawlSchedule
+	retry     RetryPolicy
+
+	mu         sync.Mutex
+	chain      string
+	headMethod string
+	stop       chan struct{}
+	once       sync.Once
+}
+
+// DialRPCPool connects to every endpoint of urls list, health checks are started if there
+// is more than one endpoint.
+func DialRPCPool(ctx context.Context, urls string) (*RPCPool, error) {
+	endpoints, err := ParseRPCEndpoints(urls)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, endpoint := range endpoints {
+		client, dialErr := rpc.DialContext(ctx, endpoint.URL)
+		if dialErr != nil {
+			for _, dialed := range endpoints {
+				if dialed.client != nil {
+					dialed.client.Close()
+				}
+			}
+			return nil, fmt.Errorf("failed to connect to RPC endpoint %s: %w", redactRPCURL(endpoint.URL), dialErr)
+		}
+		endpoint.client = client
+	}
+
+	pool := &RPCPool{endpoints: endpoints, retry: DefaultRPCRetryPolicy, headMethod: "eth_blockNumber", stop: make(chan struct{})}
+	if len(endpoints) > 1 {
+		go pool.healthCheckLoop()
+	}
+
+	return pool, nil
+}
+
+// order returns endpoints in order they should be tried: next one by weighted round-robin
+// among healthy endpoints first, then other healthy ones and unhealthy ones as last resort.
+func (p *RPCPool) order() []*RPCEndpoint {
+	if len(p.endpoints) == 1 {
+		return p.endpoints
+	}
+
+	now := time.Now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var healthy, unhealthy []*RPCEndpoint
+	totalWeight := 0
+	var best *RPCEndpoint
+	for _, endpoint := range p.endpoints {
+		endpoint.mu.Lock()
+		isHealthy := endpoint.healthy(now)
+		endpoint.mu.Unlock()
+
+		if !isHealthy {
+			unhealthy = append(unhealthy, endpoint)
+			continue
+		}
+
+		healthy = append(healthy, endpoint)
+		endpoint.currentWeight += endpoint.Weight
+		totalWeight += endpoint.Weight
+		if best == nil || endpoint.currentWeight > best.currentWeight {
+			best = endpoint
+		}
+	}
+
+	ordered := make([]*RPCEndpoint, 0, len(p.endpoints))
+	if best != nil {
+		best.currentWeight -= totalWeight
+		ordered = append(ordered, best)
+	}
+	for _, endpoint := range healthy {
+		if endpoint != best {
+			ordered = append(ordered, endpoint)
+		}
+	}
+
+	return append(ordered, unhealthy...)
+}
+
+// SetRateLimit limits rate of requests sent through pool, batch request counts as one.
+func (p *RPCPool) SetRateLimit(config RateLimitConfig) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.limiter = rate.NewLimiter(rate.Limit(config.RequestsPerSecond), config.Burst)
+}
+
+// SetCrawlSchedule holds requests sent through pool inside of crawl windows, each element of
+// batch request counts against window budget.
+func (p *RPCPool) SetCrawlSchedule(schedule *CrawlSchedule) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.schedule = schedule
+}
+
+// SetRetryPolicy replaces retry policy of requests sent through pool.
+func (p *RPCPool) SetRetryPolicy(policy RetryPolicy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.retry = policy
+}
+
+// SetChain sets chain which requests of pool are counted for in metrics.
+func (p *RPCPool) SetChain(chain string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.chain = chain
+}
+
+// recordRequest counts request in metrics, failed request is counted once after retries.
+func (p *RPCPool) recordRequest(method string, err error) {
+	p.mu.Lock()
+	chain := p.chain
+	p.mu.Unlock()
+
+	metrics.RPCRequests.WithLabelValues(chain, method).Inc()
+	if err != nil {
+		metrics.RPCErrors.WithLabelValues(chain, method).Inc()
+	}
+}
+
+// SetHeadMethod sets method health checks request latest block with, chains without Ethereum
+// JSON-RPC API use their own method. Result could be hex string or number.
+func (p *RPCPool) SetHeadMethod(method string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.headMethod = method
+}
+
+func (p *RPCPool) retryPolicy() RetryPolicy {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.retry
+}
+
+func (p *RPCPool) wait(ctx context.Context, requests int) error {
+	p.mu.Lock()
+	limiter := p.limiter
+	schedule := p.schedule
+	p.mu.Unlock()
+
+	if err := schedule.Take(ctx, requests); err != nil {
+		return err
+	}
+
+	if limiter == nil {
+		return nil
+	}
+	return limiter.Wait(ctx)
+}
+
+func isTransportError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+// CallContext performs JSON-RPC call with failover between endpoints and retries.
+func (p *RPCPool) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	err := p.retryPolicy().Retry(ctx, func() error {
+		err := p.wait(ctx, 1)
+		if err != nil {
+			return err
+		}
+
+		for _, endpoint := range p.order() {
+			err = endpoint.client.CallContext(ctx, result, method, args...)
+			if !isTransportError(ctx, err) {
+				endpoint.recordSuccess()
+				return err
+			}
+
+			endpoint.recordFailure(err)
+		}
+
+		return err
+	})
+	p.recordRequest(method, err)
+
+	return err
+}
+
+// BatchCallContext sends batch request with failover between endpoints and retries. Errors
+// of separate elements of batch are left to caller.
+func (p *RPCPool) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	err := p.retryPolicy().Retry(ctx, func() error {
+		err := p.wait(ctx, len(batch))
+		if err != nil {
+			return err
+		}
+
+		for _, endpoint := range p.order() {
+			err = endpoint.client.BatchCallContext(ctx, batch)
+			if !isTransportError(ctx, err) {
+				endpoint.recordSuccess()
+				return err
+			}
+
+			endpoint.recordFailure(err)
+		}
+
+		return err
+	})
+	p.recordRequest("batch", err)
+
+	return err
+}
+
+// EthSubscribe creates subscription at first endpoint which supports notifications, only
+// WebSocket and IPC endpoints do. Subscription is not moved to other endpoint on failure.
+func (p *RPCPool) EthSubscribe(ctx context.Context, channel interface{}, args ...interface{}) (*rpc.ClientSubscription, error) {
+	var err error
+	for _, endpoint := range p.order() {
+		var sub *rpc.ClientSubscription
+		sub, err = endpoint.client.EthSubscribe(ctx, channel, args...)
+		if err == nil {
+			return sub, nil
+		}
+		if !errors.Is(err, rpc.ErrNotificationsUnsupported) {
+			endpoint.recordFailure(err)
+		}
+	}
+
+	if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+		return nil, fmt.Errorf("subscriptions require WebSocket RPC endpoint: %w", err)
+	}
+	return nil, err
+}
+
+// checkHealth requests latest block of every endpoint, endpoints which do not respond or
+// lag behind the best one are excluded until next check.
+func (p *RPCPool) checkHealth() {
+	latestBlocks := make([]uint64, len(p.endpoints))
+	var maxBlock uint64
+
+	p.mu.Lock()
+	headMethod := p.headMethod
+	p.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for i, endpoint := range p.endpoints {
+		wg.Add(1)
+		go func(i int, endpoint *RPCEndpoint) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), RPCHealthCheckInterval/2)
+			defer cancel()
+
+			var result json.RawMessage
+			if err := endpoint.client.CallContext(ctx, &result, headMethod); err != nil {
+				endpoint.recordFailure(err)
+				return
+			}
+
+			blockNumber, err := parseHeadNumber(result)
+			if err != nil {
+				endpoint.recordFailure(err)
+				return
+			}
+
+			endpoint.recordSuccess()
+			latestBlocks[i] = blockNumber
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	for _, latestBlock := range latestBlocks {
+		if latestBlock > maxBlock {
+			maxBlock = latestBlock
+		}
+	}
+
+	for i, endpoint := range p.endpoints {
+		if latestBlocks[i] == 0 {
+			continue
+		}
+
+		endpoint.mu.Lock()
+		lagging := maxBlock-latestBlocks[i] > RPCMaxBlockLag
+		if lagging && !endpoint.lagging {
+			logging.Chain(p.chain).Warn("RPC endpoint excluded, it is behind other endpoints", "endpoint", redactRPCURL(endpoint.URL), "blocks_behind", maxBlock-latestBlocks[i])
+		}
+		endpoint.latestBlock = latestBlocks[i]
+		endpoint.lagging = lagging
+		endpoint.mu.Unlock()
+	}
+}
+
+func parseHeadNumber(result json.RawMessage) (uint64, error) {
+	var hexNumber string
+	if err := json.Unmarshal(result, &hexNumber); err == nil {
+		blockNumber, ok := new(big.Int).SetString(hexNumber, 0)
+		if !ok || !blockNumber.IsUint64() {
+			return 0, fmt.Errorf("invalid block number format: %s", hexNumber)
+		}
+		return blockNumber.Uint64(), nil
+	}
+
+	var blockNumber uint64
+	if err := json.Unmarshal(result, &blockNumber); err != nil {
+		return 0, fmt.Errorf("invalid block number format: %s", string(result))
+	}
+	return blockNumber, nil
+}
+
+func (p *RPCPool) healthCheckLoop() {
+	ticker := time.NewTicker(RPCHealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.checkHealth()
+		}
+	}
+}
+
+// Status returns health of every endpoint of pool.
+func (p *RPCPool) Status() []RPCEndpointStatus {
+	now := time.Now()
+
+	statuses := make([]RPCEndpointStatus, 0, len(p.endpoints))
+	for _, endpoint := range p.endpoints {
+		endpoint.mu.Lock()
+		status := RPCEndpointStatus{
+			URL:                 redactRPCURL(endpoint.URL),
+			Weight:              endpoint.Weight,
+			Healthy:             endpoint.healthy(now),
+			ConsecutiveFailures: endpoint.consecutiveFailures,
+			LatestBlock:         endpoint.latestBlock,
+		}
+		if endpoint.lastErr != nil {
+			status.LastError = endpoint.lastErr.Error()
+		}
+		endpoint.mu.Unlock()
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+func (p *RPCPool) Close() {
+	p.once.Do(func() {
+		close(p.stop)
+		for _, endpoint := range p.endpoints {
+			endpoint.client.Close()
+		}
+	})
+}
@@ -0,0 +1,193 @@
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/G7DAO/seer/indexer"
+	"github.com/G7DAO/seer/logging"
+)
+
+// SafeExecTransactionSelector is selector of Safe (Gnosis Safe) multisig
+// execTransaction(address,uint256,bytes,uint8,uint256,uint256,uint256,address,address,bytes).
+const SafeExecTransactionSelector = "0x6a761202"
+
+// SafeInnerCallLabelType is label type of calls executed by Safe, they share transaction hash
+// with tx_call label of execTransaction itself.
+const SafeInnerCallLabelType = "safe_inner_call"
+
+const safeExecTransactionABI = `[{"type":"function","name":"execTransaction","stateMutability":"payable","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"},{"name":"operation","type":"uint8"},{"name":"safeTxGas","type":"uint256"},{"name":"baseGas","type":"uint256"},{"name":"gasPrice","type":"uint256"},{"name":"gasToken","type":"address"},{"name":"refundReceiver","type":"address"},{"name":"signatures","type":"bytes"}],"outputs":[{"name":"success","type":"bool"}]}]`
+
+// SafeExecutionSuccessTopic is emitted by Safe when inner call of execTransaction succeeded,
+// on failure Safe emits ExecutionFailure and outer transaction still succeeds.
+var SafeExecutionSuccessTopic = crypto.Keccak256Hash([]byte("ExecutionSuccess(bytes32,uint256)")).Hex()
+
+var (
+	safeABI     *abi.ABI
+	safeABIErr  error
+	safeABIOnce sync.Once
+)
+
+// SafeExecTransaction is a call executed by Safe on behalf of its owners.
+type SafeExecTransaction struct {
+	Safe      string `json:"safe"`
+	To        string `json:"to"`
+	Value     string `json:"value"`
+	Data      string `json:"data"`
+	Operation uint8  `json:"operation"`
+}
+
+// DecodeSafeExecTransaction unpacks execTransaction input sent to safe address, returns
+// nil without error if input is not execTransaction call.
+func DecodeSafeExecTransaction(safe, input string) (*SafeExecTransaction, error) {
+	if len(input) < 10 || !strings.EqualFold(input[:10], SafeExecTransactionSelector) {
+		return nil, nil
+	}
+
+	safeABIOnce.Do(func() {
+		safeABI, safeABIErr = GetABI(safeExecTransactionABI)
+	})
+	if safeABIErr != nil {
+		return nil, safeABIErr
+	}
+
+	inputData, err := hex.DecodeString(input[10:])
+	if err != nil {
+		return nil, fmt.Errorf("error decoding execTransaction input: %v", err)
+	}
+
+	args := make(map[string]interface{})
+	if err := safeABI.Methods["execTransaction"].Inputs.UnpackIntoMap(args, inputData); err != nil {
+		return nil, fmt.Errorf("cannot unpack execTransaction input: %v", err)
+	}
+
+	to, toOk := args["to"].(common.Address)
+	value, valueOk := args["value"].(*big.Int)
+	data, dataOk := args["data"].([]byte)
+	operation, operationOk := args["operation"].(uint8)
+	if !toOk || !valueOk || !dataOk || !operationOk {
+		return nil, fmt.Errorf("unexpected execTransaction arguments types")
+	}
+
+	return &SafeExecTransaction{
+		Safe:      safe,
+		To:        strings.ToLower(to.Hex()),
+		Value:     value.String(),
+		Data:      "0x" + hex.EncodeToString(data),
+		Operation: operation,
+	}, nil
+}
+
+// DecodeSafeInnerCall decodes call wrapped into execTransaction against ABIs registered for
+// its target contract. Returns nil entry if target or its method is not registered, entry with
+// error if call data could not be decoded. Label data has additional "safe" field, so label of
+// target contract could be attributed to the multisig as well.
+func DecodeSafeInnerCall(execTx *SafeExecTransaction, abiMap map[string]map[string]*indexer.AbiEntry) (*indexer.AbiEntry, map[string]interface{}, error) {
+	if execTx == nil || len(execTx.Data) < 10 {
+		return nil, nil, nil
+	}
+
+	selector := execTx.Data[:10]
+	abiEntry := LookupAbiEntry(abiMap, execTx.To, selector)
+	if abiEntry == nil {
+		return nil, nil, nil
+	}
+
+	var initErr error
+	abiEntry.Once.Do(func() {
+		abiEntry.Abi, initErr = GetABI(abiEntry.AbiJSON)
+	})
+	if initErr != nil || abiEntry.Abi == nil {
+		return nil, nil, fmt.Errorf("error getting ABI for address %s: %v", execTx.To, initErr)
+	}
+
+	inputData, err := hex.DecodeString(execTx.Data[2:])
+	if err != nil {
+		return abiEntry, nil, fmt.Errorf("error decoding Safe inner call data: %v", err)
+	}
+	if _, err := abiEntry.Abi.MethodById(inputData[:4]); err != nil {
+		return abiEntry, nil, err
+	}
+
+	decodedArgs, err := DecodeTransactionInputDataToInterface(abiEntry.Abi, inputData)
+	if err != nil {
+		return abiEntry, nil, err
+	}
+	decodedArgs["safe"] = execTx
+
+	return abiEntry, decodedArgs, nil
+}
+
+// IsSafeExecutionSuccess checks if log is ExecutionSuccess event emitted by safe.
+func IsSafeExecutionSuccess(safe, logAddress string, topics []string) bool {
+	return strings.EqualFold(safe, logAddress) && len(topics) > 0 && strings.EqualFold(topics[0], SafeExecutionSuccessTopic)
+}
+
+// SafeInnerCallLabel unwraps execTransaction of Safe multisig and labels inner call for its target
+// contract if it is registered in abiMap. Caller address of label is the Safe, origin is the owner
+// who sent transaction. Status of inner call is requested with executed only when label is built.
+func SafeInnerCallLabel(logger *slog.Logger, safe, input, originAddress, transactionHash, blockHash string, blockNumber, blockTimestamp uint64, abiMap map[string]map[string]*indexer.AbiEntry, executed func() (bool, error)) (*indexer.TransactionLabel, error) {
+	execTx, err := DecodeSafeExecTransaction(safe, input)
+	if err != nil {
+		// Contract has the same selector but it is not a Safe
+		return nil, nil
+	}
+	if execTx == nil {
+		return nil, nil
+	}
+
+	label := indexer.SeerCrawlerLabel
+
+	abiEntry, decodedArgs, decodeErr := DecodeSafeInnerCall(execTx, abiMap)
+	if abiEntry == nil {
+		return nil, decodeErr
+	}
+	if decodeErr != nil {
+		logger.Warn("Unable to decode Safe inner call, raw label is written", logging.TxKey, transactionHash, logging.ErrorKey, decodeErr)
+		decodedArgs = map[string]interface{}{
+			"input_raw": execTx,
+			"abi":       abiEntry.AbiJSON,
+			"selector":  execTx.Data[:10],
+			"error":     decodeErr.Error(),
+		}
+		label = indexer.SeerCrawlerRawLabel
+	}
+
+	success, err := executed()
+	if err != nil {
+		return nil, err
+	}
+	if success {
+		decodedArgs["status"] = 1
+	} else {
+		decodedArgs["status"] = 0
+	}
+
+	labelDataBytes, err := json.Marshal(decodedArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &indexer.TransactionLabel{
+		Address:         execTx.To,
+		BlockNumber:     blockNumber,
+		BlockHash:       blockHash,
+		CallerAddress:   safe,
+		LabelName:       abiEntry.AbiName,
+		LabelType:       SafeInnerCallLabelType,
+		OriginAddress:   originAddress,
+		Label:           label,
+		TransactionHash: transactionHash,
+		LabelData:       string(labelDataBytes),
+		BlockTimestamp:  blockTimestamp,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+const erc20TransferABI = `[{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}]`
+
+// safeExecTransactionInput packs execTransaction of Safe calling target with data.
+func safeExecTransactionInput(t *testing.T, target string, data []byte) string {
+	t.Helper()
+
+	safeExecABI, err := GetABI(safeExecTransactionABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input, err := safeExecABI.Pack("execTransaction", common.HexToAddress(target), big.NewInt(0), data, uint8(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), common.Address{}, common.Address{}, []byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return "0x" + hex.EncodeToString(input)
+}
+
+func TestSafeInnerCallLabel(t *testing.T) {
+	const safe = "0x5a52e96bacdabb82fd05763e25335261b270efcb"
+	const token = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+
+	tokenABI, err := GetABI(erc20TransferABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transferData, err := tokenABI.Pack("transfer", common.HexToAddress("0x1111111111111111111111111111111111111111"), big.NewInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name          string
+		data          []byte
+		expectedError bool
+	}{
+		{"decoded inner call", transferData, false},
+		{"truncated inner call", transferData[:8], true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			abiMap := map[string]map[string]*indexer.AbiEntry{
+				token: {"0xa9059cbb": {AbiJSON: erc20TransferABI, AbiName: "transfer", AbiType: "function"}},
+			}
+			input := safeExecTransactionInput(t, token, testCase.data)
+
+			label, err := SafeInnerCallLabel(slog.Default(), safe, input, "0x2222222222222222222222222222222222222222", "0xabc", "0x01", 100, 1700000000, abiMap, func() (bool, error) {
+				return true, nil
+			})
+			if err != nil {
+				t.Fatalf("SafeInnerCallLabel: %v", err)
+			}
+			if label == nil {
+				t.Fatal("label of inner call is not built")
+			}
+			if label.LabelType != SafeInnerCallLabelType || label.Address != token || label.CallerAddress != safe {
+				t.Fatalf("unexpected label %+v", *label)
+			}
+
+			var labelData map[string]interface{}
+			if err := json.Unmarshal([]byte(label.LabelData), &labelData); err != nil {
+				t.Fatalf("invalid label data: %v", err)
+			}
+			if labelData["status"] != float64(1) {
+				t.Fatalf("status is %v, want 1", labelData["status"])
+			}
+			decodeErr, _ := labelData["error"].(string)
+			if testCase.expectedError && decodeErr == "" {
+				t.Fatalf("label data has no decoding error: %s", label.LabelData)
+			}
+			if !testCase.expectedError && labelData["error"] != nil {
+				t.Fatalf("label data of decoded call has error: %s", label.LabelData)
+			}
+		})
+	}
+}
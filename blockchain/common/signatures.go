@@ -0,0 +1,556 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+// SignatureDatabaseLabelSource is value of source key of label_data of labels decoded with
+// signature database.
+const SignatureDatabaseLabelSource = "signature_database"
+
+// Signatures do not tell which arguments are indexed, every placement of indexed arguments is
+// tried up to this number of placements.
+var maxIndexedPlacements = 256
+
+// EventSignature is text signature of event, e.g. Transfer(address,address,uint256), with
+// its topic.
+type EventSignature struct {
+	Topic     string
+	Signature string
+}
+
+// EventSignatureTopic returns topic of signature in 0x prefixed lowercase hex.
+func EventSignatureTopic(signature string) string {
+	return crypto.Keccak256Hash([]byte(signature)).Hex()
+}
+
+type parsedSignature struct {
+	name      string
+	arguments abi.Arguments
+	err       error
+}
+
+// SignatureDatabase is local database of event signatures, it is file with topic and
+// signature separated by space on each line. Database is built from dumps of 4byte and
+// openchain with ReadSignatureDump and WriteSignatureDatabase.
+type SignatureDatabase struct {
+	signatures map[string][]string
+
+	mu     sync.RWMutex
+	parsed map[string]parsedSignature
+}
+
+// LoadSignatureDatabase reads signature database from file.
+func LoadSignatureDatabase(path string) (*SignatureDatabase, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	db := &SignatureDatabase{
+		signatures: make(map[string][]string),
+		parsed:     make(map[string]parsedSignature),
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		topic, signature, found := strings.Cut(text, " ")
+		if !found {
+			return nil, fmt.Errorf("invalid line %d of signature database %s", line, path)
+		}
+		topic = strings.ToLower(topic)
+		db.signatures[topic] = append(db.signatures[topic], strings.TrimSpace(signature))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Len returns number of topics in database.
+func (db *SignatureDatabase) Len() int {
+	if db == nil {
+		return 0
+	}
+	return len(db.signatures)
+}
+
+// Lookup returns signatures of topic.
+func (db *SignatureDatabase) Lookup(topic string) []string {
+	if db == nil {
+		return nil
+	}
+	return db.signatures[strings.ToLower(topic)]
+}
+
+// ReadSignatureDump reads event signatures from dump. Supported dumps are JSON of 4byte
+// event-signatures API, as page with results or list of results, and text with one signature
+// per line, optionally preceded by its topic and comma, tab or space as in openchain export.
+// Signatures which do not match their topic or could not be parsed are counted as skipped.
+func ReadSignatureDump(r io.Reader) ([]EventSignature, int, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	type fourByteSignature struct {
+		HexSignature  string `json:"hex_signature"`
+		TextSignature string `json:"text_signature"`
+	}
+
+	var candidates []EventSignature
+	trimmed := bytes.TrimSpace(content)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		var page struct {
+			Results []fourByteSignature `json:"results"`
+		}
+		if err := json.Unmarshal(trimmed, &page); err != nil {
+			return nil, 0, fmt.Errorf("invalid 4byte dump: %w", err)
+		}
+		for _, result := range page.Results {
+			candidates = append(candidates, EventSignature{Topic: result.HexSignature, Signature: result.TextSignature})
+		}
+	case bytes.HasPrefix(trimmed, []byte("[")):
+		var results []fourByteSignature
+		if err := json.Unmarshal(trimmed, &results); err != nil {
+			return nil, 0, fmt.Errorf("invalid 4byte dump: %w", err)
+		}
+		for _, result := range results {
+			candidates = append(candidates, EventSignature{Topic: result.HexSignature, Signature: result.TextSignature})
+		}
+	default:
+		for _, line := range strings.Split(string(trimmed), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			var topic string
+			if strings.HasPrefix(line, "0x") {
+				separator := strings.IndexAny(line, ",\t ")
+				if separator < 0 {
+					candidates = append(candidates, EventSignature{Topic: line})
+					continue
+				}
+				topic, line = line[:separator], strings.TrimSpace(line[separator+1:])
+			}
+			candidates = append(candidates, EventSignature{Topic: topic, Signature: line})
+		}
+	}
+
+	var signatures []EventSignature
+	skipped := 0
+	for _, candidate := range candidates {
+		signature := strings.ReplaceAll(strings.TrimSpace(candidate.Signature), " ", "")
+		topic := EventSignatureTopic(signature)
+		if candidate.Topic != "" && !strings.EqualFold(candidate.Topic, topic) {
+			skipped++
+			continue
+		}
+		if _, _, err := parseEventSignature(signature); err != nil {
+			skipped++
+			continue
+		}
+		signatures = append(signatures, EventSignature{Topic: topic, Signature: signature})
+	}
+
+	return signatures, skipped, nil
+}
+
+// WriteSignatureDatabase writes signatures to database file, signatures already in file are
+// kept. Number of signatures in database is returned.
+func WriteSignatureDatabase(path string, signatures []EventSignature) (int, error) {
+	lines := make(map[string]bool)
+
+	existing, err := LoadSignatureDatabase(path)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	if existing != nil {
+		for topic, topicSignatures := range existing.signatures {
+			for _, signature := range topicSignatures {
+				lines[topic+" "+signature] = true
+			}
+		}
+	}
+	for _, signature := range signatures {
+		lines[strings.ToLower(signature.Topic)+" "+signature.Signature] = true
+	}
+
+	sorted := make([]string, 0, len(lines))
+	for line := range lines {
+		sorted = append(sorted, line)
+	}
+	sort.Strings(sorted)
+
+	// Database is replaced at once, so synchronizers never read partially written file
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(strings.Join(sorted, "\n")+"\n"), 0644); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return 0, err
+	}
+
+	return len(sorted), nil
+}
+
+var (
+	signatureDatabaseOnce sync.Once
+	signatureDatabase     *SignatureDatabase
+	signatureDatabaseErr  error
+)
+
+// EnabledSignatureDatabase returns database at path of SEER_EVENT_SIGNATURES_DB environment
+// variable, nil if it is not set.
+func EnabledSignatureDatabase() (*SignatureDatabase, error) {
+	signatureDatabaseOnce.Do(func() {
+		path := os.Getenv("SEER_EVENT_SIGNATURES_DB")
+		if path == "" {
+			return
+		}
+		signatureDatabase, signatureDatabaseErr = LoadSignatureDatabase(path)
+	})
+	if signatureDatabaseErr != nil {
+		return nil, fmt.Errorf("invalid SEER_EVENT_SIGNATURES_DB environment variable: %w", signatureDatabaseErr)
+	}
+
+	return signatureDatabase, nil
+}
+
+// IsSignatureDatabaseLabel returns true if label_data was decoded with signature database.
+func IsSignatureDatabaseLabel(labelData string) bool {
+	return strings.Contains(labelData, `"source":"`+SignatureDatabaseLabelSource+`"`)
+}
+
+// EventLabel decodes log with signature database. Only logs of addresses with jobs or logs
+// emitted in transactions sent to addresses with jobs are decoded, and only if no job decodes
+// their topic. Label is raw, so it is promoted when job of its address and selector is added.
+func (db *SignatureDatabase) EventLabel(abiMap map[string]map[string]*indexer.AbiEntry, labelType, transactionTo, address string, topics []string, data string, inputRaw interface{}, originAddress, transactionHash, blockHash string, blockNumber, blockTimestamp, logIndex uint64) *indexer.EventLabel {
+	if db == nil || len(topics) == 0 {
+		return nil
+	}
+	if abiMap[address] == nil && abiMap[transactionTo] == nil {
+		return nil
+	}
+	if LookupAbiEntry(abiMap, address, topics[0]) != nil {
+		return nil
+	}
+	// Logs of addresses with anonymous events are matched with jobs by their shape
+	for selector := range abiMap[address] {
+		if indexer.IsAnonymousEventSelector(selector) {
+			return nil
+		}
+	}
+
+	signature, labelData, ok := db.DecodeLog(topics, data)
+	if !ok {
+		return nil
+	}
+	labelData["signature"] = signature
+	labelData["selector"] = topics[0]
+	labelData["source"] = SignatureDatabaseLabelSource
+	labelData["input_raw"] = inputRaw
+
+	labelDataBytes, err := json.Marshal(labelData)
+	if err != nil {
+		return nil
+	}
+	labelName, _ := labelData["name"].(string)
+
+	return &indexer.EventLabel{
+		Label:           indexer.SeerCrawlerRawLabel,
+		LabelName:       labelName,
+		LabelType:       labelType,
+		BlockNumber:     blockNumber,
+		BlockHash:       blockHash,
+		Address:         address,
+		OriginAddress:   originAddress,
+		TransactionHash: transactionHash,
+		LabelData:       string(labelDataBytes),
+		BlockTimestamp:  blockTimestamp,
+		LogIndex:        logIndex,
+	}
+}
+
+// DecodeLog decodes log with first signature of its topic which fits it. Indexed arguments
+// are placed first in order of arguments, as most contracts declare them, and then in other
+// positions. Placement fits if topics hold valid values of their types and data is exactly
+// ABI encoding of other arguments.
+func (db *SignatureDatabase) DecodeLog(topics []string, data string) (string, map[string]interface{}, bool) {
+	if db == nil || len(topics) == 0 {
+		return "", nil, false
+	}
+
+	dataBytes, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	if err != nil {
+		return "", nil, false
+	}
+
+	for _, signature := range db.Lookup(topics[0]) {
+		name, arguments, err := db.parse(signature)
+		if err != nil || len(topics)-1 > len(arguments) {
+			continue
+		}
+
+		placements := 0
+		var labelData map[string]interface{}
+		forEachCombination(len(arguments), len(topics)-1, func(indexed []int) bool {
+			placements++
+			labelData = decodeWithIndexed(name, arguments, indexed, topics, dataBytes, data)
+			return labelData == nil && placements < maxIndexedPlacements
+		})
+		if labelData != nil {
+			return signature, labelData, true
+		}
+	}
+
+	return "", nil, false
+}
+
+func (db *SignatureDatabase) parse(signature string) (string, abi.Arguments, error) {
+	db.mu.RLock()
+	parsed, exists := db.parsed[signature]
+	db.mu.RUnlock()
+	if exists {
+		return parsed.name, parsed.arguments, parsed.err
+	}
+
+	parsed.name, parsed.arguments, parsed.err = parseEventSignature(signature)
+
+	db.mu.Lock()
+	db.parsed[signature] = parsed
+	db.mu.Unlock()
+
+	return parsed.name, parsed.arguments, parsed.err
+}
+
+// forEachCombination calls f with k of n indexes in lexicographic order while f returns true.
+func forEachCombination(n, k int, f func([]int) bool) {
+	combination := make([]int, k)
+	for i := range combination {
+		combination[i] = i
+	}
+
+	for {
+		if !f(append([]int(nil), combination...)) {
+			return
+		}
+
+		i := k - 1
+		for i >= 0 && combination[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		combination[i]++
+		for j := i + 1; j < k; j++ {
+			combination[j] = combination[j-1] + 1
+		}
+	}
+}
+
+func decodeWithIndexed(name string, arguments abi.Arguments, indexed []int, topics []string, dataBytes []byte, data string) map[string]interface{} {
+	inputs := make(abi.Arguments, len(arguments))
+	copy(inputs, arguments)
+	for position, i := range indexed {
+		inputs[i].Indexed = true
+		if !topicFitsType(inputs[i].Type, common.HexToHash(topics[position+1])) {
+			return nil
+		}
+	}
+
+	nonIndexed := inputs.NonIndexed()
+	values, err := nonIndexed.Unpack(dataBytes)
+	if err != nil {
+		return nil
+	}
+	packed, err := nonIndexed.Pack(values...)
+	if err != nil || !bytes.Equal(packed, dataBytes) {
+		return nil
+	}
+	for i, argument := range nonIndexed {
+		if address, ok := values[i].(common.Address); ok && argument.Type.T == abi.AddressTy && !plausibleAddress(address.Bytes()) {
+			return nil
+		}
+	}
+
+	event := abi.NewEvent(name, name, false, inputs)
+	contractABI := &abi.ABI{Events: map[string]abi.Event{name: event}}
+	labelData, err := DecodeLogArgsToLabelData(contractABI, topics, data)
+	if err != nil {
+		return nil
+	}
+
+	return labelData
+}
+
+// topicFitsType checks padding of indexed value of static type, values of dynamic types are
+// hashes and fit any topic.
+func topicFitsType(argType abi.Type, topic common.Hash) bool {
+	switch argType.T {
+	case abi.AddressTy:
+		return common.BytesToHash(topic[12:]) == topic && plausibleAddress(topic[12:])
+	case abi.BoolTy:
+		return common.BytesToHash(topic[31:]) == topic && topic[31] <= 1
+	case abi.UintTy:
+		return topic.Big().BitLen() <= argType.Size
+	case abi.FixedBytesTy:
+		for _, b := range topic[argType.Size:] {
+			if b != 0 {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
+
+// plausibleAddress rejects small numbers taken for addresses when arguments are placed
+// wrong, only zero address and precompiles are that small among real addresses.
+func plausibleAddress(address []byte) bool {
+	leadingZeros := 0
+	for _, b := range address {
+		if b != 0 {
+			break
+		}
+		leadingZeros++
+	}
+	return leadingZeros == len(address) || leadingZeros < len(address)-8
+}
+
+// parseEventSignature parses text signature into name and arguments named arg0, arg1, ...
+// Tuples are written in parentheses, components of tuples are named field0, field1, ...
+func parseEventSignature(signature string) (string, abi.Arguments, error) {
+	open := strings.Index(signature, "(")
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return "", nil, fmt.Errorf("invalid event signature %s", signature)
+	}
+	name := signature[:open]
+	for _, r := range name {
+		if !(r == '_' || r == '$' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			return "", nil, fmt.Errorf("invalid event name %s", name)
+		}
+	}
+
+	types, err := splitSignatureTypes(signature[open+1 : len(signature)-1])
+	if err != nil {
+		return "", nil, err
+	}
+
+	arguments := make(abi.Arguments, len(types))
+	for i, typeName := range types {
+		marshaling, err := signatureTypeMarshaling(fmt.Sprintf("arg%d", i), typeName)
+		if err != nil {
+			return "", nil, err
+		}
+		argType, err := abi.NewType(marshaling.Type, "", marshaling.Components)
+		if err != nil {
+			return "", nil, err
+		}
+		arguments[i] = abi.Argument{Name: marshaling.Name, Type: argType}
+	}
+
+	// Signature could be written in other form than canonical one topic is computed from
+	if EventSignatureTopic(name+"("+strings.Join(argumentTypes(arguments), ",")+")") != EventSignatureTopic(signature) {
+		return "", nil, fmt.Errorf("signature %s is not canonical", signature)
+	}
+
+	return name, arguments, nil
+}
+
+func argumentTypes(arguments abi.Arguments) []string {
+	types := make([]string, len(arguments))
+	for i, argument := range arguments {
+		types[i] = argument.Type.String()
+	}
+	return types
+}
+
+// splitSignatureTypes splits list of types by commas outside of tuples.
+func splitSignatureTypes(list string) ([]string, error) {
+	if list == "" {
+		return nil, nil
+	}
+
+	var types []string
+	depth, start := 0, 0
+	for i, r := range list {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses in %s", list)
+			}
+		case ',':
+			if depth == 0 {
+				types = append(types, list[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses in %s", list)
+	}
+	types = append(types, list[start:])
+
+	for _, typeName := range types {
+		if typeName == "" {
+			return nil, fmt.Errorf("empty type in %s", list)
+		}
+	}
+	return types, nil
+}
+
+func signatureTypeMarshaling(name, typeName string) (abi.ArgumentMarshaling, error) {
+	typeName = strings.TrimPrefix(typeName, "tuple")
+	if !strings.HasPrefix(typeName, "(") {
+		return abi.ArgumentMarshaling{Name: name, Type: typeName}, nil
+	}
+
+	closing := strings.LastIndex(typeName, ")")
+	components, err := splitSignatureTypes(typeName[1:closing])
+	if err != nil {
+		return abi.ArgumentMarshaling{}, err
+	}
+
+	marshaling := abi.ArgumentMarshaling{Name: name, Type: "tuple" + typeName[closing+1:]}
+	for i, component := range components {
+		componentMarshaling, err := signatureTypeMarshaling(fmt.Sprintf("field%d", i), component)
+		if err != nil {
+			return abi.ArgumentMarshaling{}, err
+		}
+		marshaling.Components = append(marshaling.Components, componentMarshaling)
+	}
+	return marshaling, nil
+}
@@ -0,0 +1,416 @@
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+// Names of standard events decoders
+const (
+	StandardDecoderERC20   = "erc20"
+	StandardDecoderERC721  = "erc721"
+	StandardDecoderERC1155 = "erc1155"
+	// Transactions without recipient are labeled with contract they created
+	StandardDecoderContractDeployment = "contract_deployment"
+	// Transactions without call data moving native tokens from or to addresses with jobs
+	StandardDecoderNativeTransfer = "native_transfer"
+)
+
+// Label names of normalized standard events
+const (
+	ERC20TransferLabelName = "erc20_transfer"
+	ERC20ApprovalLabelName = "erc20_approval"
+	// Ownership change of ERC-721 token or ERC-1155 single transfer
+	NFTTransferLabelName = "nft_transfer"
+	// ERC-1155 batch transfer, log could have only one label, so tokens are listed in it
+	NFTTransferBatchLabelName   = "nft_transfer_batch"
+	ContractDeploymentLabelName = "contract_deployment"
+	NativeTransferLabelName     = "native_transfer"
+)
+
+// NativeTransferLabelType is label type of native transfers, they have unique index of their
+// own and do not compete with tx_call labels of the same transaction.
+const NativeTransferLabelType = "native_transfer"
+
+var (
+	erc20TransferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")).Hex()
+	erc20ApprovalTopic = crypto.Keccak256Hash([]byte("Approval(address,address,uint256)")).Hex()
+
+	erc1155TransferSingleTopic = crypto.Keccak256Hash([]byte("TransferSingle(address,address,address,uint256,uint256)")).Hex()
+	erc1155TransferBatchTopic  = crypto.Keccak256Hash([]byte("TransferBatch(address,address,address,uint256[],uint256[])")).Hex()
+
+	erc1155TransferBatchArguments = func() abi.Arguments {
+		uint256Array, err := abi.NewType("uint256[]", "", nil)
+		if err != nil {
+			panic(err)
+		}
+		return abi.Arguments{{Name: "ids", Type: uint256Array}, {Name: "values", Type: uint256Array}}
+	}()
+)
+
+// standardEventDecoder returns label name and normalized data of log, ok is false if log is
+// not an event of standard.
+type standardEventDecoder func(address string, topics []string, data []byte) (labelName string, labelData map[string]interface{}, ok bool)
+
+var standardEventDecoders = map[string]standardEventDecoder{
+	StandardDecoderERC20:   decodeERC20Event,
+	StandardDecoderERC721:  decodeERC721Event,
+	StandardDecoderERC1155: decodeERC1155Event,
+}
+
+// StandardDecoders decode logs of standard events emitted by any address, so customers get
+// labels of all tokens without ABI job for each of them.
+type StandardDecoders struct {
+	events []standardEventDecoder
+	// ContractDeployments makes chain clients label contract creation transactions
+	ContractDeployments bool
+	// NativeTransfers makes chain clients label plain transfers of native tokens
+	NativeTransfers bool
+}
+
+var (
+	standardDecodersOnce sync.Once
+	standardDecoders     StandardDecoders
+	standardDecodersErr  error
+)
+
+// ParseStandardDecoders parses comma separated names of decoders.
+func ParseStandardDecoders(raw string) (StandardDecoders, error) {
+	var decoders StandardDecoders
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		switch name {
+		case StandardDecoderContractDeployment:
+			decoders.ContractDeployments = true
+			continue
+		case StandardDecoderNativeTransfer:
+			decoders.NativeTransfers = true
+			continue
+		}
+
+		decoder, exists := standardEventDecoders[name]
+		if !exists {
+			return StandardDecoders{}, fmt.Errorf("unknown standard decoder %q, expected one of: %s", name, strings.Join(StandardDecoderNames(), ", "))
+		}
+		decoders.events = append(decoders.events, decoder)
+	}
+
+	return decoders, nil
+}
+
+// StandardDecoderNames returns names of available decoders.
+func StandardDecoderNames() []string {
+	names := []string{StandardDecoderContractDeployment, StandardDecoderNativeTransfer}
+	for name := range standardEventDecoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EnabledStandardDecoders returns decoders enabled with SEER_STANDARD_DECODERS environment
+// variable, none by default.
+func EnabledStandardDecoders() (StandardDecoders, error) {
+	standardDecodersOnce.Do(func() {
+		standardDecoders, standardDecodersErr = ParseStandardDecoders(os.Getenv("SEER_STANDARD_DECODERS"))
+	})
+	if standardDecodersErr != nil {
+		return StandardDecoders{}, fmt.Errorf("invalid SEER_STANDARD_DECODERS environment variable: %w", standardDecodersErr)
+	}
+
+	return standardDecoders, nil
+}
+
+// EventLabel decodes log with enabled decoders, nil is returned if log is not standard event
+// or ABI job of address or wildcard job decodes it, label of job is kept then.
+func (d StandardDecoders) EventLabel(abiMap map[string]map[string]*indexer.AbiEntry, labelType, address string, topics []string, data string, originAddress, transactionHash, blockHash string, blockNumber, blockTimestamp, logIndex uint64) *indexer.EventLabel {
+	if len(d.events) == 0 || len(topics) == 0 {
+		return nil
+	}
+	if LookupAbiEntry(abiMap, address, topics[0]) != nil {
+		return nil
+	}
+
+	dataBytes, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	if err != nil {
+		return nil
+	}
+
+	for _, decode := range d.events {
+		labelName, labelData, ok := decode(address, topics, dataBytes)
+		if !ok {
+			continue
+		}
+
+		labelDataBytes, err := json.Marshal(labelData)
+		if err != nil {
+			return nil
+		}
+
+		return &indexer.EventLabel{
+			Label:           indexer.SeerCrawlerLabel,
+			LabelName:       labelName,
+			LabelType:       labelType,
+			BlockNumber:     blockNumber,
+			BlockHash:       blockHash,
+			Address:         address,
+			OriginAddress:   originAddress,
+			TransactionHash: transactionHash,
+			LabelData:       string(labelDataBytes),
+			BlockTimestamp:  blockTimestamp,
+			LogIndex:        logIndex,
+		}
+	}
+
+	return nil
+}
+
+// IsContractCreation returns true for transaction without recipient.
+func IsContractCreation(toAddress string) bool {
+	return toAddress == "" || toAddress == "0x"
+}
+
+// ContractDeploymentLabel returns label of contract created by transaction according to its
+// receipt, nil if creation failed and there is no contract. Label is written under address of
+// created contract, hash of init code identifies contracts deployed from the same source.
+func ContractDeploymentLabel(receipt *types.Receipt, deployer, transactionHash, blockHash, input string, blockNumber, blockTimestamp uint64) (*indexer.TransactionLabel, error) {
+	if receipt == nil || receipt.Status != types.ReceiptStatusSuccessful || receipt.ContractAddress == (common.Address{}) {
+		return nil, nil
+	}
+
+	initCode, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid init code of transaction %s: %w", transactionHash, err)
+	}
+
+	contract := strings.ToLower(receipt.ContractAddress.Hex())
+	labelDataBytes, err := json.Marshal(map[string]interface{}{
+		"deployer":       strings.ToLower(deployer),
+		"contract":       contract,
+		"init_code_hash": crypto.Keccak256Hash(initCode).Hex(),
+		"block_number":   blockNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &indexer.TransactionLabel{
+		Address:         contract,
+		BlockNumber:     blockNumber,
+		BlockHash:       blockHash,
+		CallerAddress:   deployer,
+		LabelName:       ContractDeploymentLabelName,
+		LabelType:       "tx_call",
+		OriginAddress:   deployer,
+		Label:           indexer.SeerCrawlerLabel,
+		TransactionHash: transactionHash,
+		LabelData:       string(labelDataBytes),
+		BlockTimestamp:  blockTimestamp,
+	}, nil
+}
+
+// nativeTransferAddress returns address with jobs the transfer is labeled under, recipient is
+// preferred over sender. False is returned for transfers without value or watched address.
+func nativeTransferAddress(abiMap map[string]map[string]*indexer.AbiEntry, fromAddress, toAddress, value string) (string, bool) {
+	amount, ok := new(big.Int).SetString(strings.TrimPrefix(value, "0x"), 16)
+	if !ok || amount.Sign() <= 0 || IsContractCreation(toAddress) {
+		return "", false
+	}
+
+	for _, address := range []string{toAddress, fromAddress} {
+		address = strings.ToLower(address)
+		if _, exists := abiMap[address]; exists {
+			return address, true
+		}
+	}
+
+	return "", false
+}
+
+// IsWatchedNativeTransfer returns true if transaction moves native tokens from or to address
+// with jobs, receipt of such transaction is needed for its label.
+func IsWatchedNativeTransfer(abiMap map[string]map[string]*indexer.AbiEntry, fromAddress, toAddress, value string) bool {
+	_, ok := nativeTransferAddress(abiMap, fromAddress, toAddress, value)
+	return ok
+}
+
+// NativeTransferLabel returns label of transaction without call data moving native tokens, nil
+// if transfer is not watched. Transfer is labeled once, transfer between two addresses with
+// jobs is labeled under recipient and sender is its caller address.
+func NativeTransferLabel(receipt *types.Receipt, abiMap map[string]map[string]*indexer.AbiEntry, fromAddress, toAddress, value, transactionHash, blockHash string, blockNumber, blockTimestamp uint64) (*indexer.TransactionLabel, error) {
+	address, ok := nativeTransferAddress(abiMap, fromAddress, toAddress, value)
+	if !ok {
+		return nil, nil
+	}
+
+	amount, _ := new(big.Int).SetString(strings.TrimPrefix(value, "0x"), 16)
+	status := 0
+	if receipt != nil && receipt.Status == types.ReceiptStatusSuccessful {
+		status = 1
+	}
+
+	labelDataBytes, err := json.Marshal(map[string]interface{}{
+		"from":   strings.ToLower(fromAddress),
+		"to":     strings.ToLower(toAddress),
+		"value":  amount.String(),
+		"status": status,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error converting native transfer of tx %s to JSON: %w", transactionHash, err)
+	}
+
+	return &indexer.TransactionLabel{
+		Address:         address,
+		BlockNumber:     blockNumber,
+		BlockHash:       blockHash,
+		CallerAddress:   fromAddress,
+		LabelName:       NativeTransferLabelName,
+		LabelType:       NativeTransferLabelType,
+		OriginAddress:   fromAddress,
+		Label:           indexer.SeerCrawlerLabel,
+		TransactionHash: transactionHash,
+		LabelData:       string(labelDataBytes),
+		BlockTimestamp:  blockTimestamp,
+	}, nil
+}
+
+// topicAddress returns address stored in indexed topic.
+func topicAddress(topic string) (string, bool) {
+	topic = strings.TrimPrefix(strings.ToLower(topic), "0x")
+	if len(topic) != 64 {
+		return "", false
+	}
+	return "0x" + topic[24:], true
+}
+
+// decodeERC20Event decodes Transfer and Approval of ERC-20 tokens. ERC-721 events have the
+// same signatures with token ID as third indexed argument, they have one more topic and no data.
+func decodeERC20Event(address string, topics []string, data []byte) (string, map[string]interface{}, bool) {
+	if len(topics) != 3 || len(data) != 32 {
+		return "", nil, false
+	}
+
+	first, firstOk := topicAddress(topics[1])
+	second, secondOk := topicAddress(topics[2])
+	if !firstOk || !secondOk {
+		return "", nil, false
+	}
+	amount := new(big.Int).SetBytes(data).String()
+
+	switch strings.ToLower(topics[0]) {
+	case erc20TransferTopic:
+		return ERC20TransferLabelName, map[string]interface{}{
+			"token":  strings.ToLower(address),
+			"from":   first,
+			"to":     second,
+			"amount": amount,
+		}, true
+	case erc20ApprovalTopic:
+		return ERC20ApprovalLabelName, map[string]interface{}{
+			"token":   strings.ToLower(address),
+			"owner":   first,
+			"spender": second,
+			"amount":  amount,
+		}, true
+	}
+
+	return "", nil, false
+}
+
+// decodeERC721Event decodes Transfer of ERC-721 token, token ID is its fourth topic.
+func decodeERC721Event(address string, topics []string, data []byte) (string, map[string]interface{}, bool) {
+	if len(topics) != 4 || len(data) != 0 || strings.ToLower(topics[0]) != erc20TransferTopic {
+		return "", nil, false
+	}
+
+	from, fromOk := topicAddress(topics[1])
+	to, toOk := topicAddress(topics[2])
+	tokenID, tokenIDOk := new(big.Int).SetString(strings.TrimPrefix(strings.ToLower(topics[3]), "0x"), 16)
+	if !fromOk || !toOk || !tokenIDOk {
+		return "", nil, false
+	}
+
+	return NFTTransferLabelName, map[string]interface{}{
+		"standard": StandardDecoderERC721,
+		"contract": strings.ToLower(address),
+		"token_id": tokenID.String(),
+		"from":     from,
+		"to":       to,
+		"amount":   "1",
+	}, true
+}
+
+// decodeERC1155Event decodes TransferSingle and TransferBatch of ERC-1155 tokens.
+func decodeERC1155Event(address string, topics []string, data []byte) (string, map[string]interface{}, bool) {
+	if len(topics) != 4 {
+		return "", nil, false
+	}
+
+	operator, operatorOk := topicAddress(topics[1])
+	from, fromOk := topicAddress(topics[2])
+	to, toOk := topicAddress(topics[3])
+	if !operatorOk || !fromOk || !toOk {
+		return "", nil, false
+	}
+
+	labelData := map[string]interface{}{
+		"standard": StandardDecoderERC1155,
+		"contract": strings.ToLower(address),
+		"operator": operator,
+		"from":     from,
+		"to":       to,
+	}
+
+	switch strings.ToLower(topics[0]) {
+	case erc1155TransferSingleTopic:
+		if len(data) != 64 {
+			return "", nil, false
+		}
+		labelData["token_id"] = new(big.Int).SetBytes(data[:32]).String()
+		labelData["amount"] = new(big.Int).SetBytes(data[32:]).String()
+
+		return NFTTransferLabelName, labelData, true
+	case erc1155TransferBatchTopic:
+		values, err := erc1155TransferBatchArguments.Unpack(data)
+		if err != nil || len(values) != 2 {
+			return "", nil, false
+		}
+		ids, idsOk := values[0].([]*big.Int)
+		amounts, amountsOk := values[1].([]*big.Int)
+		if !idsOk || !amountsOk || len(ids) != len(amounts) {
+			return "", nil, false
+		}
+
+		tokenIDs := make([]string, len(ids))
+		tokenAmounts := make([]string, len(amounts))
+		for i := range ids {
+			tokenIDs[i] = ids[i].String()
+			tokenAmounts[i] = amounts[i].String()
+		}
+		labelData["token_ids"] = tokenIDs
+		labelData["amounts"] = tokenAmounts
+
+		return NFTTransferBatchLabelName, labelData, true
+	}
+
+	return "", nil, false
+}
@@ -0,0 +1,38 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+func TestNativeTransferLabel(t *testing.T) {
+	const sender = "0x5a52e96bacdabb82fd05763e25335261b270efcb"
+	const recipient = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+
+	abiMap := map[string]map[string]*indexer.AbiEntry{
+		sender:    {},
+		recipient: {},
+	}
+	receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful}
+
+	label, err := NativeTransferLabel(receipt, abiMap, sender, recipient, "0xde0b6b3a7640000", "0xabc", "0x01", 100, 1700000000)
+	if err != nil {
+		t.Fatalf("NativeTransferLabel: %v", err)
+	}
+	if label == nil {
+		t.Fatal("watched transfer is not labeled")
+	}
+	if label.LabelType != NativeTransferLabelType || label.Address != recipient || label.CallerAddress != sender {
+		t.Fatalf("unexpected label %+v", *label)
+	}
+	if label.LabelData != `{"from":"0x5a52e96bacdabb82fd05763e25335261b270efcb","status":1,"to":"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48","value":"1000000000000000000"}` {
+		t.Fatalf("unexpected label data %s", label.LabelData)
+	}
+
+	if label, _ := NativeTransferLabel(receipt, abiMap, sender, recipient, "0x0", "0xabc", "0x01", 100, 1700000000); label != nil {
+		t.Fatal("transfer without value is labeled")
+	}
+}
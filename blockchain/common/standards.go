@@ -0,0 +1,222 @@
+package common
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ContractStandard is a token or contract standard which could be recognized by ERC-165
+// interface ID or by selectors of its required functions found in contract bytecode.
+type ContractStandard struct {
+	Name string
+	// ERC-165 interface ID, empty if standard does not define one
+	InterfaceID string
+	// Signatures of functions every implementation has
+	RequiredFunctions []string
+	// ABI of standard events and functions used to create ABI jobs
+	ABI string
+}
+
+const erc20ABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"name":"owner","type":"address"},{"indexed":true,"name":"spender","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Approval","type":"event"},{"inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"name":"transferFrom","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"name":"spender","type":"address"},{"name":"value","type":"uint256"}],"name":"approve","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"}]`
+
+const erc721ABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":true,"name":"tokenId","type":"uint256"}],"name":"Transfer","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"name":"owner","type":"address"},{"indexed":true,"name":"approved","type":"address"},{"indexed":true,"name":"tokenId","type":"uint256"}],"name":"Approval","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"name":"owner","type":"address"},{"indexed":true,"name":"operator","type":"address"},{"indexed":false,"name":"approved","type":"bool"}],"name":"ApprovalForAll","type":"event"},{"inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"}],"name":"transferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"}],"name":"safeTransferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"}],"name":"approve","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"name":"operator","type":"address"},{"name":"approved","type":"bool"}],"name":"setApprovalForAll","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+
+const erc1155ABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"operator","type":"address"},{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"id","type":"uint256"},{"indexed":false,"name":"value","type":"uint256"}],"name":"TransferSingle","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"name":"operator","type":"address"},{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"ids","type":"uint256[]"},{"indexed":false,"name":"values","type":"uint256[]"}],"name":"TransferBatch","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"name":"account","type":"address"},{"indexed":true,"name":"operator","type":"address"},{"indexed":false,"name":"approved","type":"bool"}],"name":"ApprovalForAll","type":"event"},{"anonymous":false,"inputs":[{"indexed":false,"name":"value","type":"string"},{"indexed":true,"name":"id","type":"uint256"}],"name":"URI","type":"event"},{"inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"id","type":"uint256"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"}],"name":"safeTransferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"ids","type":"uint256[]"},{"name":"values","type":"uint256[]"},{"name":"data","type":"bytes"}],"name":"safeBatchTransferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"name":"operator","type":"address"},{"name":"approved","type":"bool"}],"name":"setApprovalForAll","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+
+const erc2981ABI = `[{"inputs":[{"name":"tokenId","type":"uint256"},{"name":"salePrice","type":"uint256"}],"name":"royaltyInfo","outputs":[{"name":"receiver","type":"address"},{"name":"royaltyAmount","type":"uint256"}],"stateMutability":"view","type":"function"}]`
+
+const erc4626ABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"sender","type":"address"},{"indexed":true,"name":"owner","type":"address"},{"indexed":false,"name":"assets","type":"uint256"},{"indexed":false,"name":"shares","type":"uint256"}],"name":"Deposit","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"name":"sender","type":"address"},{"indexed":true,"name":"receiver","type":"address"},{"indexed":true,"name":"owner","type":"address"},{"indexed":false,"name":"assets","type":"uint256"},{"indexed":false,"name":"shares","type":"uint256"}],"name":"Withdraw","type":"event"},{"inputs":[{"name":"assets","type":"uint256"},{"name":"receiver","type":"address"}],"name":"deposit","outputs":[{"name":"shares","type":"uint256"}],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"name":"shares","type":"uint256"},{"name":"receiver","type":"address"}],"name":"mint","outputs":[{"name":"assets","type":"uint256"}],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"name":"assets","type":"uint256"},{"name":"receiver","type":"address"},{"name":"owner","type":"address"}],"name":"withdraw","outputs":[{"name":"shares","type":"uint256"}],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"name":"shares","type":"uint256"},{"name":"receiver","type":"address"},{"name":"owner","type":"address"}],"name":"redeem","outputs":[{"name":"assets","type":"uint256"}],"stateMutability":"nonpayable","type":"function"}]`
+
+// KnownStandards are standards recognized by DetectStandards.
+var KnownStandards = []ContractStandard{
+	{
+		Name:              "erc20",
+		RequiredFunctions: []string{"totalSupply()", "balanceOf(address)", "transfer(address,uint256)", "transferFrom(address,address,uint256)", "approve(address,uint256)", "allowance(address,address)"},
+		ABI:               erc20ABI,
+	},
+	{
+		Name:              "erc721",
+		InterfaceID:       "0x80ac58cd",
+		RequiredFunctions: []string{"balanceOf(address)", "ownerOf(uint256)", "safeTransferFrom(address,address,uint256)", "transferFrom(address,address,uint256)", "approve(address,uint256)", "setApprovalForAll(address,bool)", "getApproved(uint256)", "isApprovedForAll(address,address)"},
+		ABI:               erc721ABI,
+	},
+	{
+		Name:              "erc1155",
+		InterfaceID:       "0xd9b67a26",
+		RequiredFunctions: []string{"balanceOf(address,uint256)", "balanceOfBatch(address[],uint256[])", "setApprovalForAll(address,bool)", "isApprovedForAll(address,address)", "safeTransferFrom(address,address,uint256,uint256,bytes)", "safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)"},
+		ABI:               erc1155ABI,
+	},
+	{
+		Name:              "erc2981",
+		InterfaceID:       "0x2a55205a",
+		RequiredFunctions: []string{"royaltyInfo(uint256,uint256)"},
+		ABI:               erc2981ABI,
+	},
+	{
+		Name:              "erc4626",
+		RequiredFunctions: []string{"asset()", "totalAssets()", "convertToShares(uint256)", "convertToAssets(uint256)", "deposit(uint256,address)", "mint(uint256,address)", "withdraw(uint256,address,address)", "redeem(uint256,address,address)"},
+		ABI:               erc4626ABI,
+	},
+}
+
+// LookupStandard returns known standard by name.
+func LookupStandard(name string) (ContractStandard, bool) {
+	for _, standard := range KnownStandards {
+		if standard.Name == strings.ToLower(name) {
+			return standard, true
+		}
+	}
+	return ContractStandard{}, false
+}
+
+// DetectedStandard is a standard implemented by contract with evidence it was detected by.
+type DetectedStandard struct {
+	Name string `json:"name"`
+	// Contract reported standard interface ID in supportsInterface
+	ERC165 bool `json:"erc165"`
+	// Number of required functions which selectors were found in bytecode
+	MatchedFunctions int `json:"matched_functions"`
+	TotalFunctions   int `json:"total_functions"`
+}
+
+// StandardsReport is the result of contract inspection.
+type StandardsReport struct {
+	Address             string             `json:"address"`
+	CodeSize            int                `json:"code_size"`
+	SupportsERC165      bool               `json:"supports_erc165"`
+	SelectorsInBytecode int                `json:"bytecode_selectors"`
+	Standards           []DetectedStandard `json:"standards"`
+}
+
+// Names returns names of detected standards.
+func (r *StandardsReport) Names() []string {
+	names := make([]string, len(r.Standards))
+	for i, standard := range r.Standards {
+		names[i] = standard.Name
+	}
+	return names
+}
+
+const (
+	supportsInterfaceSelector = "01ffc9a7"
+	erc165InterfaceID         = "0x01ffc9a7"
+	invalidInterfaceID        = "0xffffffff"
+)
+
+// FunctionSelector returns 4 bytes selector of function signature in 0x prefixed hex.
+func FunctionSelector(signature string) string {
+	return "0x" + hex.EncodeToString(crypto.Keccak256([]byte(signature))[:4])
+}
+
+// BytecodeSelectors collects values pushed by PUSH1-PUSH4 opcodes of bytecode as 4 bytes
+// selectors. Solidity and Vyper dispatchers compare calldata selector with such constants,
+// selectors with leading zero bytes are pushed with shorter opcodes. Data of longer pushes is
+// skipped, so it is not mistaken for opcodes.
+func BytecodeSelectors(code []byte) map[string]bool {
+	selectors := make(map[string]bool)
+	for i := 0; i < len(code); i++ {
+		op := code[i]
+		if op < 0x60 || op > 0x7f {
+			continue
+		}
+
+		size := int(op) - 0x5f
+		if size <= 4 && i+size < len(code) {
+			selector := make([]byte, 4)
+			copy(selector[4-size:], code[i+1:i+1+size])
+			selectors["0x"+hex.EncodeToString(selector)] = true
+		}
+		i += size
+	}
+	return selectors
+}
+
+// supportsInterface calls ERC-165 supportsInterface of contract. Reverts and other errors
+// returned by node mean that contract does not implement it, transport errors are returned.
+func supportsInterface(ctx context.Context, client ChainClient, address common.Address, interfaceID string) (bool, error) {
+	data, err := hex.DecodeString(supportsInterfaceSelector + strings.TrimPrefix(interfaceID, "0x") + strings.Repeat("0", 56))
+	if err != nil {
+		return false, fmt.Errorf("invalid interface ID %s: %v", interfaceID, err)
+	}
+
+	result, err := client.CallContract(ctx, address, data, 0)
+	if err != nil {
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return len(result) == 32 && result[31] == 1, nil
+}
+
+// DetectStandards inspects contract at address and returns standards it implements. Standard
+// is detected if contract reports its interface ID with ERC-165 or if selectors of all its
+// required functions are in bytecode. Proxies could be recognized only with ERC-165, their
+// bytecode does not contain selectors of implementation.
+func DetectStandards(ctx context.Context, client ChainClient, address string) (*StandardsReport, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid address %s", address)
+	}
+	contract := common.HexToAddress(address)
+
+	code, err := client.GetCode(ctx, contract, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(code) == 0 {
+		return nil, fmt.Errorf("there is no contract code at address %s", address)
+	}
+
+	selectors := BytecodeSelectors(code)
+	report := StandardsReport{
+		Address:             strings.ToLower(contract.Hex()),
+		CodeSize:            len(code),
+		SelectorsInBytecode: len(selectors),
+		Standards:           []DetectedStandard{},
+	}
+
+	// Contract which answers true for any interface ID does not really implement ERC-165
+	supportsERC165, err := supportsInterface(ctx, client, contract, erc165InterfaceID)
+	if err != nil {
+		return nil, err
+	}
+	if supportsERC165 {
+		supportsInvalid, err := supportsInterface(ctx, client, contract, invalidInterfaceID)
+		if err != nil {
+			return nil, err
+		}
+		report.SupportsERC165 = !supportsInvalid
+	}
+
+	for _, standard := range KnownStandards {
+		detected := DetectedStandard{Name: standard.Name, TotalFunctions: len(standard.RequiredFunctions)}
+
+		if report.SupportsERC165 && standard.InterfaceID != "" {
+			detected.ERC165, err = supportsInterface(ctx, client, contract, standard.InterfaceID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, signature := range standard.RequiredFunctions {
+			if selectors[FunctionSelector(signature)] {
+				detected.MatchedFunctions++
+			}
+		}
+
+		if detected.ERC165 || detected.MatchedFunctions == detected.TotalFunctions {
+			report.Standards = append(report.Standards, detected)
+		}
+	}
+
+	return &report, nil
+}
@@ -0,0 +1,56 @@
+package common
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+// IndexedTopicsFilter returns filters of topics after signature for logs request of ABI map.
+// Node filters logs of all jobs at once, so position is filtered only if every event job
+// filters it and values of jobs are joined. Logs of other jobs values are skipped after
+// request with AbiEntry.MatchesTopics.
+func IndexedTopicsFilter(abiMap map[string]map[string]*indexer.AbiEntry) [][]common.Hash {
+	var filters [][]common.Hash
+	events := 0
+	for _, selectorMap := range abiMap {
+		for selector, entry := range selectorMap {
+			if entry.AbiType != "event" || indexer.IsAnonymousEventSelector(selector) {
+				continue
+			}
+
+			if events == 0 {
+				for _, values := range entry.TopicFilters {
+					filters = append(filters, topicHashes(values))
+				}
+			} else {
+				for i := range filters {
+					if i >= len(entry.TopicFilters) || len(entry.TopicFilters[i]) == 0 || len(filters[i]) == 0 {
+						filters[i] = nil
+						continue
+					}
+					filters[i] = append(filters[i], topicHashes(entry.TopicFilters[i])...)
+				}
+			}
+			events++
+		}
+	}
+
+	for len(filters) > 0 && len(filters[len(filters)-1]) == 0 {
+		filters = filters[:len(filters)-1]
+	}
+
+	return filters
+}
+
+func topicHashes(values []string) []common.Hash {
+	if len(values) == 0 {
+		return nil
+	}
+
+	hashes := make([]common.Hash, len(values))
+	for i, value := range values {
+		hashes[i] = common.HexToHash(value)
+	}
+	return hashes
+}
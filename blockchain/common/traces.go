@@ -0,0 +1,202 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+// InternalTransactionLabelType is label type of value transfers made by contracts, found in
+// call traces of transactions.
+const InternalTransactionLabelType = "internal_tx"
+
+var (
+	tracingChainsOnce sync.Once
+	tracingChains     map[string]bool
+)
+
+// TracingEnabledFor returns true if internal transactions of chain should be traced, chains
+// are listed in SEER_TRACE_CHAINS environment variable separated by comma.
+func TracingEnabledFor(chain string) bool {
+	tracingChainsOnce.Do(func() {
+		tracingChains = make(map[string]bool)
+		for _, tracingChain := range strings.Split(os.Getenv("SEER_TRACE_CHAINS"), ",") {
+			tracingChain = strings.TrimSpace(tracingChain)
+			if tracingChain != "" {
+				tracingChains[tracingChain] = true
+			}
+		}
+	})
+
+	return tracingChains[chain]
+}
+
+// TracingClient is implemented by chain clients which could fetch call traces of blocks.
+type TracingClient interface {
+	SetTracing(bool)
+}
+
+// CallFrame is a call of callTracer result, nested calls are made by contract during
+// execution of parent call.
+type CallFrame struct {
+	Type    string      `json:"type"`
+	From    string      `json:"from"`
+	To      string      `json:"to"`
+	Value   string      `json:"value,omitempty"`
+	Gas     string      `json:"gas,omitempty"`
+	GasUsed string      `json:"gasUsed,omitempty"`
+	Input   string      `json:"input,omitempty"`
+	Output  string      `json:"output,omitempty"`
+	Error   string      `json:"error,omitempty"`
+	Calls   []CallFrame `json:"calls,omitempty"`
+}
+
+// TransactionTrace is a trace of one transaction of block.
+type TransactionTrace struct {
+	TxHash string     `json:"txHash"`
+	Result *CallFrame `json:"result"`
+	Error  string     `json:"error,omitempty"`
+}
+
+// InternalTransaction is a value transfer made by contract inside of transaction.
+type InternalTransaction struct {
+	TransactionHash string `json:"-"`
+	CallType        string `json:"call_type"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	Value           string `json:"value"`
+	TraceAddress    []int  `json:"trace_address"`
+	Status          int    `json:"status"`
+	Error           string `json:"error,omitempty"`
+}
+
+// TraceBlockByNumber fetches call traces of all transactions of block with callTracer. Nodes
+// which do not return transaction hash in trace are matched with txHashes by position.
+func TraceBlockByNumber(ctx context.Context, pool *RPCPool, blockNumber uint64, txHashes []string) ([]TransactionTrace, error) {
+	var traces []TransactionTrace
+	err := pool.CallContext(ctx, &traces, "debug_traceBlockByNumber", fmt.Sprintf("0x%x", blockNumber), map[string]interface{}{"tracer": "callTracer"})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(traces) != len(txHashes) {
+		return nil, fmt.Errorf("trace of block %d has %d transactions, block has %d, block could be replaced by reorg", blockNumber, len(traces), len(txHashes))
+	}
+	for i := range traces {
+		if traces[i].TxHash == "" {
+			traces[i].TxHash = txHashes[i]
+		} else if !strings.EqualFold(traces[i].TxHash, txHashes[i]) {
+			return nil, fmt.Errorf("trace of transaction %d of block %d has hash %s instead of %s, block could be replaced by reorg", i, blockNumber, traces[i].TxHash, txHashes[i])
+		}
+	}
+
+	return traces, nil
+}
+
+// transfersValue returns true for calls which move value, delegate and static calls only
+// run code in context of caller.
+func transfersValue(frame *CallFrame) bool {
+	switch strings.ToUpper(frame.Type) {
+	case "DELEGATECALL", "STATICCALL":
+		return false
+	}
+
+	value, ok := new(big.Int).SetString(strings.TrimPrefix(frame.Value, "0x"), 16)
+	return ok && value.Sign() > 0
+}
+
+func collectInternalTransactions(txHash string, frame *CallFrame, traceAddress []int, reverted bool, result *[]InternalTransaction) {
+	reverted = reverted || frame.Error != ""
+
+	if len(traceAddress) > 0 && transfersValue(frame) {
+		value, _ := new(big.Int).SetString(strings.TrimPrefix(frame.Value, "0x"), 16)
+		internalTx := InternalTransaction{
+			TransactionHash: txHash,
+			CallType:        strings.ToUpper(frame.Type),
+			From:            frame.From,
+			To:              frame.To,
+			Value:           value.String(),
+			TraceAddress:    append([]int{}, traceAddress...),
+			Status:          1,
+			Error:           frame.Error,
+		}
+		if reverted {
+			internalTx.Status = 0
+		}
+		*result = append(*result, internalTx)
+	}
+
+	for i := range frame.Calls {
+		collectInternalTransactions(txHash, &frame.Calls[i], append(traceAddress, i), reverted, result)
+	}
+}
+
+// InternalTransactions flattens nested calls of transaction trace into value transfers made
+// by contracts, top level call is the transaction itself and is not included.
+func InternalTransactions(trace TransactionTrace) []InternalTransaction {
+	var result []InternalTransaction
+	if trace.Result == nil {
+		return result
+	}
+
+	collectInternalTransactions(trace.TxHash, trace.Result, []int{}, false, &result)
+
+	return result
+}
+
+// InternalTransactionLabels converts internal transactions of block traces into labels of
+// addresses with jobs, transfer is labeled for both sender and receiver if both have jobs.
+func InternalTransactionLabels(traces []TransactionTrace, originAddresses map[string]string, blockNumber uint64, blockHash string, blockTimestamp uint64, abiMap map[string]map[string]*indexer.AbiEntry) ([]indexer.TransactionLabel, error) {
+	var labels []indexer.TransactionLabel
+	for _, trace := range traces {
+		for _, internalTx := range InternalTransactions(trace) {
+			var addresses []string
+			for _, address := range []string{internalTx.From, internalTx.To} {
+				address = strings.ToLower(address)
+				if _, tracked := abiMap[address]; tracked && (len(addresses) == 0 || addresses[0] != address) {
+					addresses = append(addresses, address)
+				}
+			}
+			if len(addresses) == 0 {
+				continue
+			}
+
+			labelData, err := json.Marshal(map[string]interface{}{
+				"type":          InternalTransactionLabelType,
+				"call_type":     internalTx.CallType,
+				"from":          internalTx.From,
+				"to":            internalTx.To,
+				"value":         internalTx.Value,
+				"trace_address": internalTx.TraceAddress,
+				"status":        internalTx.Status,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, address := range addresses {
+				labels = append(labels, indexer.TransactionLabel{
+					Address:         address,
+					BlockNumber:     blockNumber,
+					BlockHash:       blockHash,
+					CallerAddress:   internalTx.From,
+					Label:           indexer.SeerCrawlerLabel,
+					LabelName:       "InternalTransfer",
+					LabelType:       InternalTransactionLabelType,
+					OriginAddress:   originAddresses[trace.TxHash],
+					TransactionHash: internalTx.TransactionHash,
+					LabelData:       string(labelData),
+					BlockTimestamp:  blockTimestamp,
+				})
+			}
+		}
+	}
+
+	return labels, nil
+}
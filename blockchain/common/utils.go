@@ -1,10 +1,12 @@
 package common
 
 import (
-	"fmt"
+	"log/slog"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/G7DAO/seer/logging"
 )
 
 func GetABI(abistr string) (*abi.ABI, error) {
@@ -13,8 +15,7 @@ func GetABI(abistr string) (*abi.ABI, error) {
 
 	parsedABI, err := abi.JSON(strings.NewReader(abistr))
 	if err != nil {
-		fmt.Println("string: ", abistr)
-		fmt.Println("Error parsing ABI: ", err)
+		slog.Debug("Unable to parse ABI", "abi", abistr, logging.ErrorKey, err)
 		return nil, err
 	}
 
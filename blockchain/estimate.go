@@ -0,0 +1,197 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	seer_common "github.com/G7DAO/seer/blockchain/common"
+	"github.com/G7DAO/seer/logging"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BackfillEstimateOptions controls sampling of logs and parameters of planned backfill.
+type BackfillEstimateOptions struct {
+	Samples    uint64
+	SampleSize uint64
+	BatchSize  uint64
+	Threads    int
+	Timeout    int
+}
+
+// BackfillEstimate is expected cost of historical crawl of address, extrapolated from
+// sampled eth_getLogs probes.
+type BackfillEstimate struct {
+	Chain     string `json:"chain"`
+	Address   string `json:"address"`
+	FromBlock uint64 `json:"from_block"`
+	ToBlock   uint64 `json:"to_block"`
+	Blocks    uint64 `json:"blocks"`
+
+	SampledBlocks uint64  `json:"sampled_blocks"`
+	SampledLogs   uint64  `json:"sampled_logs"`
+	LogsPerBlock  float64 `json:"logs_per_block"`
+
+	EstimatedLogs    uint64  `json:"estimated_logs"`
+	GetLogsCalls     uint64  `json:"get_logs_calls"`
+	GetBlockCalls    uint64  `json:"get_block_calls"`
+	RPCCalls         uint64  `json:"rpc_calls"`
+	StorageBytes     uint64  `json:"storage_bytes"`
+	DBRows           uint64  `json:"db_rows"`
+	WallClockSeconds float64 `json:"wall_clock_seconds"`
+	WallClock        string  `json:"wall_clock"`
+}
+
+// Approximate size of label row besides raw log data: hashes, addresses, block fields and
+// label name, used to estimate storage of decoded labels.
+const labelRowOverheadBytes = 400
+
+type logsFilter struct {
+	FromBlock string   `json:"fromBlock"`
+	ToBlock   string   `json:"toBlock"`
+	Addresses []string `json:"address"`
+}
+
+// probeLogs requests logs of address in range, if provider rejects range as too large it is
+// narrowed down and logs count is returned for narrowed range.
+func probeLogs(ctx context.Context, pool *seer_common.RPCPool, address string, fromBlock, toBlock uint64, timeout time.Duration) ([]json.RawMessage, uint64, time.Duration, error) {
+	for {
+		var logs []json.RawMessage
+
+		callCtx, cancel := context.WithTimeout(ctx, timeout)
+		started := time.Now()
+		err := pool.CallContext(callCtx, &logs, "eth_getLogs", logsFilter{
+			FromBlock: hexutil.EncodeUint64(fromBlock),
+			ToBlock:   hexutil.EncodeUint64(toBlock),
+			Addresses: []string{address},
+		})
+		latency := time.Since(started)
+		cancel()
+
+		if err == nil {
+			return logs, toBlock - fromBlock + 1, latency, nil
+		}
+		if toBlock == fromBlock {
+			return nil, 0, 0, err
+		}
+
+		slog.Debug("Probe of logs failed, narrowing range", logging.AddressKey, address, logging.FromBlockKey, fromBlock, logging.ToBlockKey, toBlock, logging.ErrorKey, err)
+		toBlock = fromBlock + (toBlock-fromBlock)/2
+	}
+}
+
+// EstimateBackfill samples logs of address in evenly spread windows of range and
+// extrapolates number of RPC calls, storage, database rows and time of its backfill.
+func EstimateBackfill(chain, rpcURL, address string, fromBlock, toBlock uint64, options BackfillEstimateOptions) (*BackfillEstimate, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid address %s", address)
+	}
+	if options.Samples == 0 || options.SampleSize == 0 || options.BatchSize == 0 || options.Threads <= 0 {
+		return nil, fmt.Errorf("samples, sample size, batch size and threads should be positive")
+	}
+
+	if err := VerifyChainID(chain, rpcURL); err != nil {
+		return nil, err
+	}
+
+	timeout := time.Duration(options.Timeout) * time.Second
+	ctx := context.Background()
+
+	dialCtx, cancel := context.WithTimeout(ctx, timeout)
+	pool, err := seer_common.DialRPCPool(dialCtx, rpcURL)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	defer pool.Close()
+
+	if toBlock == 0 {
+		var latest string
+		latestCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := pool.CallContext(latestCtx, &latest, "eth_blockNumber")
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest block number: %w", err)
+		}
+		latestBlock, ok := new(big.Int).SetString(latest, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid block number format: %s", latest)
+		}
+		toBlock = latestBlock.Uint64()
+	}
+	if fromBlock > toBlock {
+		return nil, fmt.Errorf("from block %d is greater than to block %d", fromBlock, toBlock)
+	}
+
+	estimate := &BackfillEstimate{
+		Chain:     chain,
+		Address:   strings.ToLower(address),
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+		Blocks:    toBlock - fromBlock + 1,
+	}
+
+	// Windows are placed at equal steps, so both early and recent activity of contract
+	// is represented in sample
+	samples := options.Samples
+	if samples*options.SampleSize > estimate.Blocks {
+		samples = (estimate.Blocks + options.SampleSize - 1) / options.SampleSize
+	}
+	step := estimate.Blocks / samples
+
+	var logsBytes uint64
+	var logsLatency time.Duration
+	var probes uint64
+	for i := uint64(0); i < samples; i++ {
+		windowFrom := fromBlock + i*step
+		windowTo := windowFrom + options.SampleSize - 1
+		if windowTo > toBlock {
+			windowTo = toBlock
+		}
+
+		logs, probedBlocks, latency, probeErr := probeLogs(ctx, pool, estimate.Address, windowFrom, windowTo, timeout)
+		if probeErr != nil {
+			return nil, fmt.Errorf("failed to probe logs in blocks %d-%d: %w", windowFrom, windowTo, probeErr)
+		}
+
+		estimate.SampledBlocks += probedBlocks
+		estimate.SampledLogs += uint64(len(logs))
+		for _, rawLog := range logs {
+			logsBytes += uint64(len(rawLog))
+		}
+		logsLatency += latency
+		probes++
+	}
+
+	estimate.LogsPerBlock = float64(estimate.SampledLogs) / float64(estimate.SampledBlocks)
+	estimate.EstimatedLogs = uint64(math.Round(estimate.LogsPerBlock * float64(estimate.Blocks)))
+
+	// Logs are requested per batch of blocks and every block with logs is fetched for
+	// timestamp and transaction of label, at most one block per log
+	estimate.GetLogsCalls = (estimate.Blocks + options.BatchSize - 1) / options.BatchSize
+	estimate.GetBlockCalls = estimate.EstimatedLogs
+	if estimate.GetBlockCalls > estimate.Blocks {
+		estimate.GetBlockCalls = estimate.Blocks
+	}
+	estimate.RPCCalls = estimate.GetLogsCalls + estimate.GetBlockCalls
+
+	estimate.DBRows = estimate.EstimatedLogs
+	if estimate.SampledLogs > 0 {
+		averageLogBytes := float64(logsBytes) / float64(estimate.SampledLogs)
+		estimate.StorageBytes = uint64(math.Round((averageLogBytes + labelRowOverheadBytes) * float64(estimate.EstimatedLogs)))
+	}
+
+	// Latency of probe is used for every call, probes cover larger ranges than blocks
+	// requests so it is an upper bound
+	averageLatency := logsLatency.Seconds() / float64(probes)
+	estimate.WallClockSeconds = averageLatency * float64(estimate.RPCCalls) / float64(options.Threads)
+	estimate.WallClock = time.Duration(estimate.WallClockSeconds * float64(time.Second)).Round(time.Millisecond).String()
+
+	return estimate, nil
+}
@@ -0,0 +1,92 @@
+package ethereum
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type rpcRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// newOutOfOrderRPC starts JSON-RPC stub answering eth_getBlockByNumber for blocks up to
+// lastBlock, higher blocks are answered sooner, so responses arrive in reverse order.
+func newOutOfOrderRPC(t *testing.T, lastBlock uint64) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if request.Method != "eth_getBlockByNumber" || len(request.Params) == 0 {
+			http.Error(w, "unexpected method "+request.Method, http.StatusBadRequest)
+			return
+		}
+
+		var numberHex string
+		if err := json.Unmarshal(request.Params[0], &numberHex); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		number, err := strconv.ParseUint(strings.TrimPrefix(numberHex, "0x"), 16, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		time.Sleep(time.Duration(lastBlock-number) * 10 * time.Millisecond)
+
+		block := map[string]interface{}{
+			"number":       numberHex,
+			"hash":         fmt.Sprintf("0x%064x", number),
+			"parentHash":   fmt.Sprintf("0x%064x", number-1),
+			"timestamp":    "0x1",
+			"transactions": []interface{}{},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": block})
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestFetchBlocksInRangeAsyncReturnsBlocksInOrder(t *testing.T) {
+	const fromBlock, toBlock = 100, 115
+	server := newOutOfOrderRPC(t, toBlock)
+
+	client, err := NewClient(server.URL, 10)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	blocks, err := client.FetchBlocksInRangeAsync(big.NewInt(fromBlock), big.NewInt(toBlock), false, 16)
+	if err != nil {
+		t.Fatalf("FetchBlocksInRangeAsync: %v", err)
+	}
+	if len(blocks) != toBlock-fromBlock+1 {
+		t.Fatalf("got %d blocks, want %d", len(blocks), toBlock-fromBlock+1)
+	}
+
+	for i, block := range blocks {
+		number, err := strconv.ParseUint(strings.TrimPrefix(block.BlockNumber, "0x"), 16, 64)
+		if err != nil {
+			t.Fatalf("invalid number %q of block %d: %v", block.BlockNumber, i, err)
+		}
+		if number != uint64(fromBlock+i) {
+			t.Fatalf("block %d has number %d, want %d", i, number, fromBlock+i)
+		}
+	}
+}
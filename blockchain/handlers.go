@@ -1,38 +1,25 @@
 package blockchain
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"log"
 	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
-	"github.com/G7DAO/seer/blockchain/arbitrum_one"
-	"github.com/G7DAO/seer/blockchain/arbitrum_sepolia"
-	"github.com/G7DAO/seer/blockchain/b3"
-	"github.com/G7DAO/seer/blockchain/b3_sepolia"
 	seer_common "github.com/G7DAO/seer/blockchain/common"
-	"github.com/G7DAO/seer/blockchain/ethereum"
-	"github.com/G7DAO/seer/blockchain/game7"
-	"github.com/G7DAO/seer/blockchain/game7_orbit_arbitrum_sepolia"
-	"github.com/G7DAO/seer/blockchain/game7_testnet"
-	"github.com/G7DAO/seer/blockchain/imx_zkevm"
-	"github.com/G7DAO/seer/blockchain/imx_zkevm_sepolia"
-	"github.com/G7DAO/seer/blockchain/mantle"
-	"github.com/G7DAO/seer/blockchain/mantle_sepolia"
-	"github.com/G7DAO/seer/blockchain/polygon"
-	"github.com/G7DAO/seer/blockchain/ronin"
-	"github.com/G7DAO/seer/blockchain/ronin_saigon"
-	"github.com/G7DAO/seer/blockchain/sepolia"
-	"github.com/G7DAO/seer/blockchain/xai"
-	"github.com/G7DAO/seer/blockchain/xai_sepolia"
 	"github.com/G7DAO/seer/indexer"
+	"github.com/G7DAO/seer/logging"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -55,9 +42,85 @@ var BlockchainChainIDs = map[string]int64{
 	"b3_sepolia":                   1993,
 	"ronin":                        2020,
 	"ronin_saigon":                 2021,
+	"hyperevm":                     999,
+	"hyperevm_testnet":             998,
+	"base":                         8453,
+	"base_sepolia":                 84532,
+	"optimism":                     10,
+	"op_sepolia":                   11155420,
+	"zksync_era":                   324,
 }
 
-func NewClient(chain, url string, timeout int) (BlockchainClient, error) {
+// BlockchainGenesisHashes identifies chains without EVM chain ID by hash of genesis block.
+var BlockchainGenesisHashes = map[string]string{
+	"solana": "5eykt4UsFv8P8NJdTREpY1vzqKqZKvdpKuc147dW2N9d",
+}
+
+// BlockchainStarknetChainIDs are short string chain IDs returned by starknet_chainId.
+var BlockchainStarknetChainIDs = map[string]string{
+	"starknet":         "SN_MAIN",
+	"starknet_sepolia": "SN_SEPOLIA",
+}
+
+// DefaultLocalChainID is chain ID of Anvil and Hardhat nodes started without options.
+const DefaultLocalChainID int64 = 31337
+
+// LocalChainID returns chain ID of local chain configured with SEER_LOCAL_CHAIN_ID environment
+// variable.
+func LocalChainID() (int64, error) {
+	raw := os.Getenv("SEER_LOCAL_CHAIN_ID")
+	if raw == "" {
+		return DefaultLocalChainID, nil
+	}
+
+	chainID, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || chainID <= 0 {
+		return 0, fmt.Errorf("invalid SEER_LOCAL_CHAIN_ID environment variable %q, positive integer is expected", raw)
+	}
+	return chainID, nil
+}
+
+// SupportedChains returns sorted names of chains with known chain identifiers.
+func SupportedChains() []string {
+	var chains []string
+	for chain := range BlockchainChainIDs {
+		chains = append(chains, chain)
+	}
+	for chain := range BlockchainGenesisHashes {
+		chains = append(chains, chain)
+	}
+	for chain := range BlockchainStarknetChainIDs {
+		chains = append(chains, chain)
+	}
+	sort.Strings(chains)
+
+	return chains
+}
+
+// CheckRegisteredClients returns error if any supported chain has no registered client,
+// it means that package of chain is not imported by generated chains.go.
+func CheckRegisteredClients() error {
+	registered := make(map[string]bool)
+	for _, chain := range seer_common.RegisteredChains() {
+		registered[chain] = true
+	}
+
+	var missing []string
+	for _, chain := range SupportedChains() {
+		if !registered[chain] {
+			missing = append(missing, chain)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("clients of chains %s are not registered, regenerate blockchain/chains.go with seer blockchain generate", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// NewClient verifies chain ID of RPC endpoint and creates client of chain registered
+// by its package.
+func NewClient(chain, url string, timeout int) (ChainClient, error) {
 	verifyErr := VerifyChainID(chain, url)
 	if verifyErr != nil {
 		return nil, verifyErr
@@ -65,63 +128,8 @@ func NewClient(chain, url string, timeout int) (BlockchainClient, error) {
 
 	fmt.Printf("Chain: %s\n", chain)
 	fmt.Printf("URL: %s\n", url)
-	if chain == "ethereum" {
-		client, err := ethereum.NewClient(url, timeout)
-		return client, err
-	} else if chain == "sepolia" {
-		client, err := sepolia.NewClient(url, timeout)
-		return client, err
-	} else if chain == "polygon" {
-		client, err := polygon.NewClient(url, timeout)
-		return client, err
-	} else if chain == "arbitrum_one" {
-		client, err := arbitrum_one.NewClient(url, timeout)
-		return client, err
-	} else if chain == "arbitrum_sepolia" {
-		client, err := arbitrum_sepolia.NewClient(url, timeout)
-		return client, err
-	} else if chain == "game7" {
-		client, err := game7.NewClient(url, timeout)
-		return client, err
-	} else if chain == "game7_orbit_arbitrum_sepolia" {
-		client, err := game7_orbit_arbitrum_sepolia.NewClient(url, timeout)
-		return client, err
-	} else if chain == "game7_testnet" {
-		client, err := game7_testnet.NewClient(url, timeout)
-		return client, err
-	} else if chain == "mantle" {
-		client, err := mantle.NewClient(url, timeout)
-		return client, err
-	} else if chain == "mantle_sepolia" {
-		client, err := mantle_sepolia.NewClient(url, timeout)
-		return client, err
-	} else if chain == "xai" {
-		client, err := xai.NewClient(url, timeout)
-		return client, err
-	} else if chain == "xai_sepolia" {
-		client, err := xai_sepolia.NewClient(url, timeout)
-		return client, err
-	} else if chain == "imx_zkevm" {
-		client, err := imx_zkevm.NewClient(url, timeout)
-		return client, err
-	} else if chain == "imx_zkevm_sepolia" {
-		client, err := imx_zkevm_sepolia.NewClient(url, timeout)
-		return client, err
-	} else if chain == "b3" {
-		client, err := b3.NewClient(url, timeout)
-		return client, err
-	} else if chain == "b3_sepolia" {
-		client, err := b3_sepolia.NewClient(url, timeout)
-		return client, err
-	} else if chain == "ronin" {
-		client, err := ronin.NewClient(url, timeout)
-		return client, err
-	} else if chain == "ronin_saigon" {
-		client, err := ronin_saigon.NewClient(url, timeout)
-		return client, err
-	} else {
-		return nil, errors.New("unsupported chain type")
-	}
+
+	return seer_common.NewClient(chain, url, timeout)
 }
 
 type BlockData struct {
@@ -132,20 +140,9 @@ type BlockData struct {
 	Data           map[string]interface{}
 }
 
-type BlockchainClient interface {
-	GetLatestBlockNumber() (*big.Int, error)
-	FetchAsProtoBlocksWithEvents(*big.Int, *big.Int, bool, int) ([]proto.Message, []indexer.BlockIndex, uint64, error)
-	ProcessBlocksToBatch([]proto.Message) (proto.Message, error)
-	DecodeProtoEntireBlockToJson(*bytes.Buffer) (*seer_common.BlocksBatchJson, error)
-	DecodeProtoEntireBlockToLabels(*bytes.Buffer, map[string]map[string]*indexer.AbiEntry, bool, int) ([]indexer.EventLabel, []indexer.TransactionLabel, []indexer.RawTransaction, error)
-	DecodeProtoTransactionsToLabels([]string, map[uint64]uint64, map[string]map[string]*indexer.AbiEntry) ([]indexer.TransactionLabel, error)
-	ChainType() string
-	GetCode(context.Context, common.Address, uint64) ([]byte, error)
-	GetTransactionsLabels(uint64, uint64, map[string]map[string]*indexer.AbiEntry, int) ([]indexer.TransactionLabel, map[uint64]seer_common.BlockWithTransactions, error)
-	GetEventsLabels(uint64, uint64, map[string]map[string]*indexer.AbiEntry, map[uint64]seer_common.BlockWithTransactions) ([]indexer.EventLabel, error)
-}
+type ChainClient = seer_common.ChainClient
 
-func GetLatestBlockNumberWithRetry(client BlockchainClient, retryAttempts int, retryWaitTime time.Duration) (*big.Int, error) {
+func GetLatestBlockNumberWithRetry(client ChainClient, retryAttempts int, retryWaitTime time.Duration) (*big.Int, error) {
 	for {
 		latestBlockNumber, latestErr := client.GetLatestBlockNumber()
 		if latestErr != nil {
@@ -164,7 +161,7 @@ func GetLatestBlockNumberWithRetry(client BlockchainClient, retryAttempts int, r
 	}
 }
 
-func CrawlEntireBlocks(client BlockchainClient, startBlock *big.Int, endBlock *big.Int, debug bool, maxRequests int) ([]proto.Message, []indexer.BlockIndex, uint64, error) {
+func CrawlEntireBlocks(client ChainClient, startBlock *big.Int, endBlock *big.Int, debug bool, maxRequests int) ([]proto.Message, []indexer.BlockIndex, uint64, error) {
 	log.Printf("Operates with batch of blocks: %d-%d", startBlock, endBlock)
 
 	blocks, blocksIndex, blocksSize, pBlockErr := client.FetchAsProtoBlocksWithEvents(startBlock, endBlock, debug, maxRequests)
@@ -192,143 +189,269 @@ func DecodeTransactionInputData(contractABI *abi.ABI, data []byte) {
 	fmt.Printf("Method inputs: %v\n", inputsMap)
 }
 
+// DeployBlocksLookUpAndUpdate fills deployment blocks of jobs of blockchain which do not have them.
 func DeployBlocksLookUpAndUpdate(blockchain string, rpcUrl string, rpcTimeout int) error {
+	client, err := NewClient(blockchain, rpcUrl, rpcTimeout)
+	if err != nil {
+		return err
+	}
 
-	// get all abi jobs without deployed block
-
-	chainsAddresses, err := indexer.DBConnection.GetAbiJobsWithoutDeployBlocks(blockchain)
+	_, err = FillDeploymentBlocks(client, indexer.DBConnection, blockchain, 5)
+	return err
+}
 
+// FillDeploymentBlocks finds deployment blocks of addresses of jobs without them with binary
+// search over eth_getCode and stores them, so jobs become available for historical sync.
+// Addresses without code at latest block are left as is. Returns number of updated addresses.
+func FillDeploymentBlocks(client ChainClient, store indexer.JobStore, blockchain string, threads int) (int, error) {
+	chainsAddresses, err := store.GetAbiJobsWithoutDeployBlocks(blockchain)
 	if err != nil {
-		log.Printf("Failed to get abi jobs without deployed blocks: %v", err)
-		return err
+		logging.Chain(blockchain).Error("Failed to get ABI jobs without deployment blocks", logging.ErrorKey, err)
+		return 0, err
 	}
 
-	if len(chainsAddresses) == 0 {
-		log.Printf("No abi jobs without deployed blocks")
-		return nil
+	addresses := chainsAddresses[blockchain]
+	if len(addresses) == 0 {
+		logging.Chain(blockchain).Info("No ABI jobs without deployment blocks")
+		return 0, nil
 	}
 
-	for chain, addresses := range chainsAddresses {
+	if threads <= 0 {
+		threads = 1
+	}
 
-		var wg sync.WaitGroup
+	logging.Chain(blockchain).Info("Finding deployment blocks of addresses", "addresses", len(addresses))
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	updated := 0
+
+	sem := make(chan struct{}, threads)
+	for address, ids := range addresses {
+		wg.Add(1)
+		go func(address string, ids []string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			deployedBlock, err := client.FindDeploymentBlock(context.Background(), common.HexToAddress(address))
+			if errors.Is(err, seer_common.ErrContractNotDeployed) {
+				logging.Chain(blockchain).Info("Address has no code, deployment block is not set", logging.AddressKey, address)
+				return
+			}
+			if err == nil {
+				logging.Chain(blockchain).Info("Found deployment block", logging.AddressKey, address, logging.BlockKey, deployedBlock)
+				err = store.UpdateAbiJobsDeployBlock(deployedBlock, ids)
+			}
 
-		sem := make(chan struct{}, 5)  // Semaphore to control
-		errChan := make(chan error, 1) // Buffered channel for error handling
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("address %s: %w", address, err))
+				return
+			}
+			updated++
+		}(address, ids)
+	}
+	wg.Wait()
 
-		log.Printf("Processing chain: %s with amount of addresses: %d\n", chain, len(addresses))
+	if len(errs) > 0 {
+		logging.Chain(blockchain).Warn("Failed to get deployment blocks of addresses", "addresses", len(errs))
+		return updated, errors.Join(errs...)
+	}
 
-		for address, ids := range addresses {
+	return updated, nil
+}
 
-			wg.Add(1)
-			go func(address string, chain string, ids []string) {
-				defer wg.Done()
-				sem <- struct{}{}
-				defer func() { <-sem }()
+func VerifyChainID(chainName string, rpcURL string) error {
+	consent := "y"
+	if expectedGenesisHash, exists := BlockchainGenesisHashes[chainName]; exists {
+		return verifyChainIdentifier(chainName, rpcURL, "genesis hash", expectedGenesisHash, fetchGenesisHash)
+	}
+	if expectedChainID, exists := BlockchainStarknetChainIDs[chainName]; exists {
+		return verifyChainIdentifier(chainName, rpcURL, "chain ID", expectedChainID, fetchStarknetChainID)
+	}
 
-				client, err := NewClient(chain, rpcUrl, rpcTimeout)
+	expectedChainID, exists := BlockchainChainIDs[chainName]
+	if chainName == indexer.LocalChain {
+		localChainID, localErr := LocalChainID()
+		if localErr != nil {
+			return localErr
+		}
+		expectedChainID, exists = localChainID, true
+	}
+	if !exists {
+		log.Printf("Unknown blockchain: %s", chainName)
+		fmt.Printf("Do you want to continue? (y/n): ")
+		fmt.Scanln(&consent)
+		if consent != "y" {
+			return fmt.Errorf("unknown blockchain: %s", chainName)
+		}
+		return nil
+	}
 
-				if err != nil {
-					errChan <- err
-					return
-				}
+	endpoints, err := seer_common.ParseRPCEndpoints(rpcURL)
+	if err != nil {
+		return err
+	}
+
+	// Every endpoint of pool should serve the same chain
+	for _, endpoint := range endpoints {
+		RPCChainID, err := fetchChainID(endpoint.URL)
+		if err != nil {
+			return err
+		}
 
-				// get all abi jobs without deployed block
-				deployedBlock, err := FindDeployedBlock(client, address)
+		log.Printf("RPC chain ID: %d", RPCChainID.Int64())
 
-				if err != nil {
-					errChan <- err
-					return
-				}
+		// Devnets run unattended in CI, wrong node is never confirmed interactively
+		if RPCChainID.Int64() != expectedChainID && chainName == indexer.LocalChain {
+			return fmt.Errorf("chain ID mismatch: expected %d for %s but got %d from RPC endpoint, set SEER_LOCAL_CHAIN_ID to chain ID of node",
+				expectedChainID, chainName, RPCChainID.Int64())
+		}
+		if RPCChainID.Int64() != expectedChainID {
+			log.Printf("Chain ID mismatch: expected %d for %s but got %d from RPC endpoint",
+				expectedChainID, chainName, RPCChainID.Int64())
+			fmt.Printf("Do you want to continue? (y/n): ")
+			fmt.Scanln(&consent)
+			if consent != "y" {
+				return fmt.Errorf("chain ID mismatch: expected %d for %s but got %d from RPC endpoint",
+					expectedChainID, chainName, RPCChainID.Int64())
+			}
+		}
+	}
 
-				log.Printf("Deployed block: %d for address: %s in chain: %s\n", deployedBlock, address, chain)
+	return nil
+}
 
-				if deployedBlock != 0 {
-					// update abi job with deployed block
-					err := indexer.DBConnection.UpdateAbiJobsDeployBlock(deployedBlock, ids)
-					if err != nil {
-						errChan <- err
-						return
-					}
-				}
+// ProbeEndpoints probes every endpoint of RPC URLs list separately, so provider which lacks
+// capability is visible even if pool would fail over to another one. Chain ID is not verified
+// beforehand, probe reports expected chain ID next to the one returned by endpoint.
+func ProbeEndpoints(chain, rpcURL string, timeout int) ([]*seer_common.ProbeReport, error) {
+	endpoints, err := seer_common.ParseRPCEndpoints(rpcURL)
+	if err != nil {
+		return nil, err
+	}
 
-			}(address, chain, ids)
+	expectedChainID, known := BlockchainChainIDs[chain]
+	if chain == indexer.LocalChain {
+		expectedChainID, err = LocalChainID()
+		if err != nil {
+			return nil, err
+		}
+		known = true
+	}
 
+	var reports []*seer_common.ProbeReport
+	for _, endpoint := range endpoints {
+		client, clientErr := seer_common.NewClient(chain, endpoint.URL, timeout)
+		if clientErr != nil {
+			return nil, clientErr
 		}
 
-		go func() {
-			wg.Wait()
-			close(errChan)
-		}()
+		probingClient, ok := client.(seer_common.ProbingClient)
+		if !ok {
+			return nil, fmt.Errorf("client of chain %s does not support probing", chain)
+		}
 
-		for err := range errChan {
-			if err != nil {
-				log.Printf("Failed to get deployed block: %v", err)
-				return err
-			}
+		report, probeErr := probingClient.Probe(context.Background())
+		if closer, ok := client.(interface{ Close() }); ok {
+			closer.Close()
+		}
+		if probeErr != nil {
+			return nil, probeErr
+		}
+		if known {
+			report.ExpectedChainID = uint64(expectedChainID)
 		}
 
+		reports = append(reports, report)
 	}
 
-	return nil
-
+	return reports, nil
 }
 
-func FindDeployedBlock(client BlockchainClient, address string) (uint64, error) {
+// verifyChainIdentifier checks identifier of chain without EVM chain ID, which is fetched
+// from every endpoint of pool.
+func verifyChainIdentifier(chainName, rpcURL, identifierName, expected string, fetch func(rpcURL string) (string, error)) error {
+	consent := "y"
 
-	// Binary search by get code
+	endpoints, err := seer_common.ParseRPCEndpoints(rpcURL)
+	if err != nil {
+		return err
+	}
 
-	ctx := context.Background()
+	for _, endpoint := range endpoints {
+		identifier, err := fetch(endpoint.URL)
+		if err != nil {
+			return err
+		}
 
-	latestBlockNumber, err := client.GetLatestBlockNumber()
+		log.Printf("RPC %s: %s", identifierName, identifier)
 
-	if err != nil {
-		return 0, err
+		if identifier != expected {
+			log.Printf("%s mismatch: expected %s for %s but got %s from RPC endpoint",
+				strings.ToUpper(identifierName[:1])+identifierName[1:], expected, chainName, identifier)
+			fmt.Printf("Do you want to continue? (y/n): ")
+			fmt.Scanln(&consent)
+			if consent != "y" {
+				return fmt.Errorf("%s mismatch: expected %s for %s but got %s from RPC endpoint",
+					identifierName, expected, chainName, identifier)
+			}
+		}
 	}
 
-	var left uint64 = 1
-	var right uint64 = latestBlockNumber.Uint64()
-	var code []byte
+	return nil
+}
 
-	for left < right {
-		mid := (left + right) / 2
+func fetchGenesisHash(rpcURL string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-		// with timeout
+	client, err := rpc.DialContext(ctx, rpcURL)
+	if err != nil {
+		log.Printf("Failed to connect to RPC URL: %v", err)
+		return "", fmt.Errorf("failed to connect to RPC URL: %w", err)
+	}
+	defer client.Close()
 
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	var genesisHash string
+	if err := client.CallContext(ctx, &genesisHash, "getGenesisHash"); err != nil {
+		log.Printf("Failed to retrieve genesis hash: %v", err)
+		return "", fmt.Errorf("failed to retrieve genesis hash: %w", err)
+	}
 
-		defer cancel()
+	return genesisHash, nil
+}
 
-		code, err = client.GetCode(ctx, common.HexToAddress(address), mid)
+// fetchStarknetChainID returns chain ID of Starknet endpoint decoded from felt to short string.
+func fetchStarknetChainID(rpcURL string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-		if err != nil {
-			log.Printf("Failed to get code: %v", err)
-			return 0, err
-		}
+	client, err := rpc.DialContext(ctx, rpcURL)
+	if err != nil {
+		log.Printf("Failed to connect to RPC URL: %v", err)
+		return "", fmt.Errorf("failed to connect to RPC URL: %w", err)
+	}
+	defer client.Close()
 
-		if len(code) == 0 {
-			left = mid + 1
-		} else {
-			right = mid
-		}
+	var chainIDHex string
+	if err := client.CallContext(ctx, &chainIDHex, "starknet_chainId"); err != nil {
+		log.Printf("Failed to retrieve chain ID: %v", err)
+		return "", fmt.Errorf("failed to retrieve chain ID: %w", err)
+	}
 
+	chainID, ok := new(big.Int).SetString(strings.TrimPrefix(chainIDHex, "0x"), 16)
+	if !ok {
+		return "", fmt.Errorf("invalid chain ID %q", chainIDHex)
 	}
 
-	return left, nil
+	return string(chainID.Bytes()), nil
 }
 
-func VerifyChainID(chainName string, rpcURL string) error {
-	consent := "y"
-	expectedChainID, exists := BlockchainChainIDs[chainName]
-	if !exists {
-		log.Printf("Unknown blockchain: %s", chainName)
-		fmt.Printf("Do you want to continue? (y/n): ")
-		fmt.Scanln(&consent)
-		if consent != "y" {
-			return fmt.Errorf("unknown blockchain: %s", chainName)
-		}
-		return nil
-	}
-
+func fetchChainID(rpcURL string) (*big.Int, error) {
 	// Create a temporary client to query chain ID if it possible
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -336,28 +459,15 @@ func VerifyChainID(chainName string, rpcURL string) error {
 	client, err := ethclient.DialContext(ctx, rpcURL)
 	if err != nil {
 		log.Printf("Failed to connect to RPC URL: %v", err)
-		return fmt.Errorf("failed to connect to RPC URL: %w", err)
+		return nil, fmt.Errorf("failed to connect to RPC URL: %w", err)
 	}
 	defer client.Close()
 
 	RPCChainID, err := client.ChainID(ctx)
 	if err != nil {
 		log.Printf("Failed to retrieve chain ID: %v", err)
-		return fmt.Errorf("failed to retrieve chain ID: %w", err)
+		return nil, fmt.Errorf("failed to retrieve chain ID: %w", err)
 	}
 
-	log.Printf("RPC chain ID: %d", RPCChainID.Int64())
-
-	if RPCChainID.Int64() != expectedChainID {
-		log.Printf("Chain ID mismatch: expected %d for %s but got %d from RPC endpoint",
-			expectedChainID, chainName, RPCChainID.Int64())
-		fmt.Printf("Do you want to continue? (y/n): ")
-		fmt.Scanln(&consent)
-		if consent != "y" {
-			return fmt.Errorf("chain ID mismatch: expected %d for %s but got %d from RPC endpoint",
-				expectedChainID, chainName, RPCChainID.Int64())
-		}
-	}
-
-	return nil
+	return RPCChainID, nil
 }
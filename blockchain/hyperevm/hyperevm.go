@@ -0,0 +1,1796 @@
+package hyperevm
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"log/slog"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"google.golang.org/protobuf/proto"
+
+	seer_common "github.com/G7DAO/seer/blockchain/common"
+	"github.com/G7DAO/seer/indexer"
+	"github.com/G7DAO/seer/logging"
+	"github.com/G7DAO/seer/version"
+)
+
+func init() {
+	seer_common.RegisterClient("hyperevm", func(url string, timeout int) (seer_common.ChainClient, error) {
+		client, err := NewClient(url, timeout)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	})
+}
+
+var _ seer_common.ChainClient = (*Client)(nil)
+
+// NewClient connects to RPC endpoints, url could be comma separated list of endpoints
+// to balance calls between them, see seer_common.ParseRPCEndpoints.
+func NewClient(url string, timeout int) (*Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	rpcClient, err := seer_common.DialRPCPool(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	rpcClient.SetChain("hyperevm")
+
+	return &Client{
+		rpcClient: rpcClient,
+		receipts:  seer_common.NewReceiptsFetcher("hyperevm", rpcClient),
+		timeout:   time.Duration(timeout) * time.Second,
+		logger:    logging.Chain("hyperevm"),
+	}, nil
+}
+
+// Client is a wrapper around the Ethereum JSON-RPC client.
+
+type Client struct {
+	rpcClient *seer_common.RPCPool
+	receipts  *seer_common.ReceiptsFetcher
+	timeout   time.Duration
+	tracing   bool
+	logger    *slog.Logger
+}
+
+// Client common
+
+// ChainType returns the chain type.
+func (c *Client) ChainType() string {
+	return "hyperevm"
+}
+
+// SetRateLimit limits rate of requests to RPC endpoints.
+func (c *Client) SetRateLimit(config seer_common.RateLimitConfig) {
+	c.rpcClient.SetRateLimit(config)
+}
+
+// SetRetryPolicy sets how requests failed with transient errors are retried.
+func (c *Client) SetRetryPolicy(policy seer_common.RetryPolicy) {
+	c.rpcClient.SetRetryPolicy(policy)
+}
+
+// SetCrawlSchedule holds RPC requests of client inside of historical crawl windows.
+func (c *Client) SetCrawlSchedule(schedule *seer_common.CrawlSchedule) {
+	c.rpcClient.SetCrawlSchedule(schedule)
+}
+
+// SetTracing enables labeling of internal transactions found in call traces of blocks,
+// RPC should support debug_traceBlockByNumber with callTracer.
+func (c *Client) SetTracing(enabled bool) {
+	c.tracing = enabled
+}
+
+// Close closes the underlying RPC client.
+func (c *Client) Close() {
+	c.rpcClient.Close()
+}
+
+// Probe reports capabilities and latency of RPC endpoints of client.
+func (c *Client) Probe(ctx context.Context) (*seer_common.ProbeReport, error) {
+	return seer_common.ProbeRPC(ctx, c.ChainType(), c.rpcClient, c.timeout)
+}
+
+// GetLatestBlockNumber returns the latest block number.
+func (c *Client) GetLatestBlockNumber() (*big.Int, error) {
+	var result string
+
+	ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
+
+	defer cancel()
+
+	if err := c.rpcClient.CallContext(ctxWithTimeout, &result, "eth_blockNumber"); err != nil {
+		return nil, err
+	}
+
+	// Convert the hex string to a big.Int
+	blockNumber, ok := new(big.Int).SetString(result, 0) // The 0 base lets the function infer the base from the string prefix.
+	if !ok {
+		return nil, fmt.Errorf("invalid block number format: %s", result)
+	}
+
+	return blockNumber, nil
+}
+
+// GetTaggedBlockNumber returns number of block for tag of eth_getBlockByNumber, such as "safe"
+// or "finalized".
+func (c *Client) GetTaggedBlockNumber(ctx context.Context, tag string) (*big.Int, error) {
+	var block *struct {
+		Number string `json:"number"`
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if err := c.rpcClient.CallContext(ctxWithTimeout, &block, "eth_getBlockByNumber", tag, false); err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("node returned no %s block", tag)
+	}
+
+	blockNumber, ok := new(big.Int).SetString(block.Number, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid block number format: %s", block.Number)
+	}
+
+	return blockNumber, nil
+}
+
+// SubscribeNewHeads sends numbers of new blocks to heads channel, it requires WebSocket
+// endpoint among RPC URLs of client.
+func (c *Client) SubscribeNewHeads(ctx context.Context, heads chan<- *big.Int) (ethereum.Subscription, error) {
+	return seer_common.SubscribeNewHeads(ctx, c.rpcClient, heads)
+}
+
+// GetBlockByNumber returns the block with the given number.
+func (c *Client) GetBlockByNumber(ctx context.Context, number *big.Int, withTransactions bool) (*seer_common.BlockJson, error) {
+	var block *seer_common.BlockJson
+	err := c.rpcClient.CallContext(ctx, &block, "eth_getBlockByNumber", fmt.Sprintf("0x%x", number), withTransactions)
+	if err != nil {
+		c.logger.Error("Failed to call eth_getBlockByNumber", logging.BlockKey, number.String(), logging.ErrorKey, err)
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// BlockByHash returns the block with the given hash.
+func (c *Client) BlockByHash(ctx context.Context, hash common.Hash) (*seer_common.BlockJson, error) {
+	var block *seer_common.BlockJson
+	err := c.rpcClient.CallContext(ctx, &block, "eth_getBlockByHash", hash, true) // true to include transactions
+	return block, err
+}
+
+// TransactionReceipt returns the receipt of a transaction by transaction hash.
+func (c *Client) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	var receipt *types.Receipt
+	err := c.rpcClient.CallContext(ctx, &receipt, "eth_getTransactionReceipt", hash)
+	return receipt, err
+}
+
+// BlockTransactionReceipt returns the receipt of a transaction from cached receipts of its
+// block, fetched with eth_getBlockReceipts if RPC supports it.
+func (c *Client) BlockTransactionReceipt(ctx context.Context, blockNumber uint64, blockHash string, hash common.Hash) (*types.Receipt, error) {
+	return c.receipts.TransactionReceipt(ctx, blockNumber, blockHash, hash)
+}
+
+// Get bytecode of a contract by address.
+func (c *Client) GetCode(ctx context.Context, address common.Address, blockNumber uint64) ([]byte, error) {
+	var code hexutil.Bytes
+	if blockNumber == 0 {
+		latestBlockNumber, err := c.GetLatestBlockNumber()
+		if err != nil {
+			return nil, err
+		}
+		blockNumber = latestBlockNumber.Uint64()
+	}
+	err := c.rpcClient.CallContext(ctx, &code, "eth_getCode", address, "0x"+fmt.Sprintf("%x", blockNumber))
+	if err != nil {
+		c.logger.Warn("Failed to get code", logging.AddressKey, address.Hex(), logging.BlockKey, blockNumber, logging.ErrorKey, err)
+		return nil, err
+	}
+
+	if len(code) == 0 {
+		return nil, nil
+	}
+	return code, nil
+}
+
+// FindDeploymentBlock returns the first block at which address has code.
+func (c *Client) FindDeploymentBlock(ctx context.Context, address common.Address) (uint64, error) {
+	return seer_common.FindDeploymentBlock(ctx, c, address)
+}
+
+// CallContract executes eth_call with data to address at given block, zero block number means
+// latest block.
+func (c *Client) CallContract(ctx context.Context, address common.Address, data []byte, blockNumber uint64) ([]byte, error) {
+	block := "latest"
+	if blockNumber > 0 {
+		block = "0x" + fmt.Sprintf("%x", blockNumber)
+	}
+
+	var result hexutil.Bytes
+	err := c.rpcClient.CallContext(ctx, &result, "eth_call", map[string]interface{}{
+		"to":   address,
+		"data": hexutil.Bytes(data),
+	}, block)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetStorageAt reads storage slot of contract at given block, zero block number means latest
+// block.
+func (c *Client) GetStorageAt(ctx context.Context, address common.Address, slot common.Hash, blockNumber uint64) (common.Hash, error) {
+	block := "latest"
+	if blockNumber > 0 {
+		block = "0x" + fmt.Sprintf("%x", blockNumber)
+	}
+
+	var result common.Hash
+	err := c.rpcClient.CallContext(ctx, &result, "eth_getStorageAt", address, slot, block)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return result, nil
+}
+
+// ClientFilterLogs fetches logs of query block range. Range is split in halves while provider
+// rejects it because of too many logs, block which still could not be fetched in one request
+// is fetched with separate request for each address of query. If logs of block could not be
+// fetched even then, error is returned, blocks are never skipped.
+func (c *Client) ClientFilterLogs(ctx context.Context, q ethereum.FilterQuery, debug bool) ([]*seer_common.EventJson, error) {
+	var logs []*seer_common.EventJson
+	fromBlock := q.FromBlock
+	toBlock := q.ToBlock
+	batchStep := new(big.Int).Sub(toBlock, fromBlock) // Calculate initial batch step
+
+	for fromBlock.Cmp(toBlock) <= 0 {
+		// Calculate the next "lastBlock" within the batch step or adjust to "toBlock" if exceeding
+		nextBlock := new(big.Int).Add(fromBlock, batchStep)
+		if nextBlock.Cmp(toBlock) > 0 {
+			nextBlock = new(big.Int).Set(toBlock)
+		}
+
+		result, err := c.getLogs(ctx, fromBlock, nextBlock, q.Addresses, q.Topics)
+		if err != nil {
+			if !seer_common.IsLogsLimitError(err) {
+				// For any other error, return immediately
+				return nil, err
+			}
+
+			if nextBlock.Cmp(fromBlock) > 0 {
+				// Halve the batch step if too many results and retry
+				batchStep.Div(batchStep, big.NewInt(2))
+				continue
+			}
+
+			// Single block has more logs than provider returns for one request
+			c.logger.Warn("Too many logs in block, fetching them by address", logging.BlockKey, fromBlock.String(), logging.ErrorKey, err)
+			result, err = c.filterBlockLogsByAddress(ctx, fromBlock, q)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Append the results and adjust "fromBlock" for the next batch
+		logs = append(logs, result...)
+		fromBlock = new(big.Int).Add(nextBlock, big.NewInt(1))
+
+		if debug {
+			c.logger.Debug("Fetched logs", "logs", len(result))
+		}
+	}
+
+	return logs, nil
+}
+
+func (c *Client) getLogs(ctx context.Context, fromBlock, toBlock *big.Int, addresses []common.Address, topics [][]common.Hash) ([]*seer_common.EventJson, error) {
+	var result []*seer_common.EventJson
+	err := c.rpcClient.CallContext(ctx, &result, "eth_getLogs", struct {
+		FromBlock string           `json:"fromBlock"`
+		ToBlock   string           `json:"toBlock"`
+		Addresses []common.Address `json:"addresses"`
+		Topics    [][]common.Hash  `json:"topics"`
+	}{
+		FromBlock: toHex(fromBlock),
+		ToBlock:   toHex(toBlock),
+		Addresses: addresses,
+		Topics:    topics,
+	})
+	return result, err
+}
+
+// filterBlockLogsByAddress fetches logs of one block with separate request for each address of
+// query. Logs are returned in order of their index in block.
+func (c *Client) filterBlockLogsByAddress(ctx context.Context, block *big.Int, q ethereum.FilterQuery) ([]*seer_common.EventJson, error) {
+	if len(q.Addresses) < 2 {
+		return nil, fmt.Errorf("unable to fetch logs of block %s, provider limit is exceeded by logs of one block and query could not be split by address", block.String())
+	}
+
+	var logs []*seer_common.EventJson
+	for _, address := range q.Addresses {
+		result, err := c.getLogs(ctx, block, block, []common.Address{address}, q.Topics)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch logs of address %s in block %s: %w", address.Hex(), block.String(), err)
+		}
+		logs = append(logs, result...)
+	}
+
+	sort.SliceStable(logs, func(i, j int) bool {
+		return fromHex(logs[i].LogIndex).Cmp(fromHex(logs[j].LogIndex)) < 0
+	})
+
+	return logs, nil
+}
+
+// Utility function to convert big.Int to its hexadecimal representation.
+func toHex(number *big.Int) string {
+	return fmt.Sprintf("0x%x", number)
+}
+
+func fromHex(hex string) *big.Int {
+	number := new(big.Int)
+	number.SetString(hex, 0)
+	return number
+}
+
+// FetchBlocksInRange fetches blocks within a specified range.
+// This could be useful for batch processing or analysis.
+func (c *Client) FetchBlocksInRange(from, to *big.Int, debug bool) ([]*seer_common.BlockJson, error) {
+	var blocks []*seer_common.BlockJson
+	ctx := context.Background() // For simplicity, using a background context; consider timeouts for production.
+
+	for i := new(big.Int).Set(from); i.Cmp(to) <= 0; i.Add(i, big.NewInt(1)) {
+
+		ctxWithTimeout, cancel := context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+
+		block, err := c.GetBlockByNumber(ctxWithTimeout, i, true)
+		if err != nil {
+			return nil, err
+		}
+
+		blocks = append(blocks, block)
+		if debug {
+			c.logger.Debug("Fetched block", logging.BlockKey, i.String())
+		}
+	}
+
+	return blocks, nil
+}
+
+// FetchBlocksInRangeAsync fetches blocks within a specified range concurrently.
+func (c *Client) FetchBlocksInRangeAsync(from, to *big.Int, debug bool, maxRequests int) ([]*seer_common.BlockJson, error) {
+	var (
+		collectedErrors []error
+		wg              sync.WaitGroup
+		ctx             = context.Background()
+	)
+
+	var blockNumbersRange []*big.Int
+	for i := new(big.Int).Set(from); i.Cmp(to) <= 0; i.Add(i, big.NewInt(1)) {
+		blockNumbersRange = append(blockNumbersRange, new(big.Int).Set(i))
+	}
+
+	// Each goroutine writes to its own index, so blocks are returned in ascending order
+	// regardless of completion order of requests
+	blocks := make([]*seer_common.BlockJson, len(blockNumbersRange))
+
+	limiter := seer_common.NewAdaptiveLimiter(maxRequests) // Concurrency is reduced when provider rate limits requests
+	errChan := make(chan error, len(blockNumbersRange))    // Channel to collect errors from goroutines
+
+	for i, b := range blockNumbersRange {
+		wg.Add(1)
+		go func(i int, b *big.Int) {
+			defer wg.Done()
+
+			defer func() {
+				if r := recover(); r != nil {
+					errChan <- fmt.Errorf("panic in goroutine for block %s: %v", b.String(), r)
+				}
+			}()
+
+			var block *seer_common.BlockJson
+			getErr := seer_common.RetryRateLimited(ctx, limiter, func() error {
+				ctxWithTimeout, cancel := context.WithTimeout(ctx, c.timeout)
+				defer cancel()
+
+				var err error
+				block, err = c.GetBlockByNumber(ctxWithTimeout, b, true)
+				return err
+			})
+			if getErr != nil {
+				c.logger.Error("Failed to fetch block", logging.BlockKey, b.String(), logging.ErrorKey, getErr)
+				errChan <- getErr
+				return
+			}
+
+			blocks[i] = block
+
+			if debug {
+				c.logger.Debug("Fetched block", logging.BlockKey, b.String())
+			}
+
+		}(i, b)
+	}
+
+	wg.Wait()
+	close(errChan)
+
+	for err := range errChan {
+		collectedErrors = append(collectedErrors, err)
+	}
+
+	if len(collectedErrors) > 0 {
+		var errStrings []string
+		for _, err := range collectedErrors {
+			errStrings = append(errStrings, err.Error())
+		}
+		return nil, fmt.Errorf("errors occurred during crawling: %s", strings.Join(errStrings, "; "))
+	}
+	return blocks, nil
+}
+
+// ParseBlocksWithTransactions parses blocks and their transactions into custom data structure.
+// This method showcases how to handle and transform detailed block and transaction data.
+func (c *Client) ParseBlocksWithTransactions(from, to *big.Int, debug bool, maxRequests int) ([]*HyperevmBlock, error) {
+	var blocksWithTxsJson []*seer_common.BlockJson
+	var fetchErr error
+	if maxRequests > 1 {
+		blocksWithTxsJson, fetchErr = c.FetchBlocksInRangeAsync(from, to, debug, maxRequests)
+	} else {
+		blocksWithTxsJson, fetchErr = c.FetchBlocksInRange(from, to, debug)
+	}
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+
+	// Blocks of inconsistent batch should be fetched again instead of being stored
+	if continuityErr := seer_common.VerifyBlocksContinuity(blocksWithTxsJson); continuityErr != nil {
+		return nil, continuityErr
+	}
+
+	var parsedBlocks []*HyperevmBlock
+	for _, blockAndTxsJson := range blocksWithTxsJson {
+		// Convert BlockJson to Block and Transactions as required.
+		parsedBlock := ToProtoSingleBlock(blockAndTxsJson)
+
+		for _, txJson := range blockAndTxsJson.Transactions {
+			txJson.BlockTimestamp = blockAndTxsJson.Timestamp
+
+			// Legacy transactions could miss chain ID and sender fields in old blocks
+			if normErr := seer_common.NormalizeLegacyTransaction(&txJson); normErr != nil {
+				c.logger.Warn("Unable to normalize legacy transaction", logging.TxKey, txJson.Hash, logging.ErrorKey, normErr)
+			}
+
+			parsedTransaction := ToProtoSingleTransaction(&txJson)
+			parsedBlock.Transactions = append(parsedBlock.Transactions, parsedTransaction)
+		}
+
+		parsedBlocks = append(parsedBlocks, parsedBlock)
+	}
+
+	return parsedBlocks, nil
+}
+
+func (c *Client) ParseEvents(from, to *big.Int, blocksCache map[uint64]indexer.BlockCache, debug bool) ([]*HyperevmEventLog, error) {
+
+	ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
+
+	defer cancel()
+
+	logs, err := c.ClientFilterLogs(ctxWithTimeout, ethereum.FilterQuery{
+		FromBlock: from,
+		ToBlock:   to,
+	}, debug)
+
+	if err != nil {
+		c.logger.Error("Failed to fetch logs", logging.FromBlockKey, from.String(), logging.ToBlockKey, to.String(), logging.ErrorKey, err)
+		return nil, err
+	}
+
+	var parsedEvents []*HyperevmEventLog
+
+	for _, log := range logs {
+		parsedEvent := ToProtoSingleEventLog(log)
+		parsedEvents = append(parsedEvents, parsedEvent)
+
+	}
+
+	return parsedEvents, nil
+}
+
+// ParseEventsFromReceipts takes logs of blocks from receipts of their transactions, so logs
+// removed by reorg are never attached and logs are ordered as in canonical receipts.
+func (c *Client) ParseEventsFromReceipts(blocks []*HyperevmBlock, maxRequests int) ([]*HyperevmEventLog, error) {
+	if maxRequests < 1 {
+		maxRequests = 1
+	}
+
+	blocksEvents := make([][]*HyperevmEventLog, len(blocks))
+	blocksErrs := make([]error, len(blocks))
+	sem := make(chan struct{}, maxRequests)
+
+	var wg sync.WaitGroup
+	for i, block := range blocks {
+		if len(block.Transactions) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, block *HyperevmBlock) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			txHashes := make([]string, len(block.Transactions))
+			for j, tx := range block.Transactions {
+				txHashes[j] = tx.Hash
+			}
+
+			ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
+			defer cancel()
+
+			logs, err := c.receipts.LogsOfTransactions(ctxWithTimeout, block.BlockNumber, block.Hash, txHashes)
+			if err != nil {
+				blocksErrs[i] = err
+				return
+			}
+			for _, log := range logs {
+				blocksEvents[i] = append(blocksEvents[i], ToProtoSingleEventLog(log))
+			}
+		}(i, block)
+	}
+	wg.Wait()
+
+	var events []*HyperevmEventLog
+	for i := range blocks {
+		if blocksErrs[i] != nil {
+			return nil, blocksErrs[i]
+		}
+		events = append(events, blocksEvents[i]...)
+	}
+
+	return events, nil
+}
+
+func (c *Client) FetchAsProtoBlocksWithEvents(from, to *big.Int, debug bool, maxRequests int) ([]proto.Message, []indexer.BlockIndex, uint64, error) {
+	var blocks []*HyperevmBlock
+	var events []*HyperevmEventLog
+	var blocksErr, eventsErr error
+
+	// Logs of range do not depend on its blocks, both are fetched at the same time. Logs from
+	// receipts are requested for transactions of fetched blocks.
+	logsFromReceipts := seer_common.LogsFromReceiptsFor("hyperevm")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		blocks, blocksErr = c.ParseBlocksWithTransactions(from, to, debug, maxRequests)
+	}()
+	if !logsFromReceipts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			events, eventsErr = c.ParseEvents(from, to, nil, debug)
+		}()
+	}
+	wg.Wait()
+
+	if blocksErr != nil {
+		return nil, nil, 0, blocksErr
+	}
+	if logsFromReceipts {
+		events, eventsErr = c.ParseEventsFromReceipts(blocks, maxRequests)
+	}
+	if eventsErr != nil {
+		return nil, nil, 0, eventsErr
+	}
+
+	var blocksSize uint64
+
+	eventsByTransaction := make(map[string][]*HyperevmEventLog)
+	for _, event := range events {
+		eventsByTransaction[event.TransactionHash] = append(eventsByTransaction[event.TransactionHash], event)
+	}
+
+	var blocksProto []proto.Message
+	var blocksIndex []indexer.BlockIndex
+
+	for bI, block := range blocks {
+		for _, tx := range block.Transactions {
+			tx.Logs = append(tx.Logs, eventsByTransaction[tx.Hash]...)
+		}
+
+		// Prepare blocks to index
+		blockIndex := indexer.NewBlockIndex("hyperevm",
+			block.BlockNumber,
+			block.Hash,
+			block.Timestamp,
+			block.ParentHash,
+			uint64(bI),
+			"",
+			0,
+		)
+		blockIndex.Miner = block.Miner
+		blocksIndex = append(blocksIndex, blockIndex)
+
+		blocksSize += uint64(proto.Size(block))
+		blocksProto = append(blocksProto, block) // Assuming block is already a proto.Message
+	}
+
+	return blocksProto, blocksIndex, blocksSize, nil
+}
+
+func (c *Client) ProcessBlocksToBatch(msgs []proto.Message) (proto.Message, error) {
+	var blocks []*HyperevmBlock
+	for _, msg := range msgs {
+		block, ok := msg.(*HyperevmBlock)
+		if !ok {
+			return nil, fmt.Errorf("failed to type assert proto.Message to *HyperevmBlock")
+		}
+		blocks = append(blocks, block)
+	}
+
+	return &HyperevmBlocksBatch{
+		Blocks:      blocks,
+		SeerVersion: version.SeerVersion,
+	}, nil
+}
+
+func ToEntireBlocksBatchFromLogProto(obj *HyperevmBlocksBatch) *seer_common.BlocksBatchJson {
+	blocksBatchJson := seer_common.BlocksBatchJson{
+		Blocks:      []seer_common.BlockJson{},
+		SeerVersion: obj.SeerVersion,
+	}
+
+	for _, b := range obj.Blocks {
+		var txs []seer_common.TransactionJson
+		for _, tx := range b.Transactions {
+			var accessList []seer_common.AccessList
+			for _, al := range tx.AccessList {
+				accessList = append(accessList, seer_common.AccessList{
+					Address:     al.Address,
+					StorageKeys: al.StorageKeys,
+				})
+			}
+			var events []seer_common.EventJson
+			for _, e := range tx.Logs {
+				events = append(events, seer_common.EventJson{
+					Address:          e.Address,
+					Topics:           e.Topics,
+					Data:             e.Data,
+					BlockNumber:      fmt.Sprintf("%d", e.BlockNumber),
+					TransactionHash:  e.TransactionHash,
+					BlockHash:        e.BlockHash,
+					Removed:          e.Removed,
+					LogIndex:         fmt.Sprintf("%d", e.LogIndex),
+					TransactionIndex: fmt.Sprintf("%d", e.TransactionIndex),
+				})
+			}
+			txs = append(txs, seer_common.TransactionJson{
+				BlockHash:            tx.BlockHash,
+				BlockNumber:          fmt.Sprintf("%d", tx.BlockNumber),
+				ChainId:              tx.ChainId,
+				FromAddress:          tx.FromAddress,
+				Gas:                  tx.Gas,
+				GasPrice:             tx.GasPrice,
+				Hash:                 tx.Hash,
+				Input:                tx.Input,
+				MaxFeePerGas:         tx.MaxFeePerGas,
+				MaxPriorityFeePerGas: tx.MaxPriorityFeePerGas,
+				Nonce:                tx.Nonce,
+				V:                    tx.V,
+				R:                    tx.R,
+				S:                    tx.S,
+				ToAddress:            tx.ToAddress,
+				TransactionIndex:     fmt.Sprintf("%d", tx.TransactionIndex),
+				TransactionType:      fmt.Sprintf("%d", tx.TransactionType),
+				Value:                tx.Value,
+				IndexedAt:            fmt.Sprintf("%d", tx.IndexedAt),
+				BlockTimestamp:       fmt.Sprintf("%d", tx.BlockTimestamp),
+				AccessList:           accessList,
+				YParity:              tx.YParity,
+				MaxFeePerBlobGas:     tx.MaxFeePerBlobGas,
+				BlobVersionedHashes:  tx.BlobVersionedHashes,
+
+				Events: events,
+			})
+		}
+
+		var withdrawals []seer_common.WithdrawalJson
+		for _, w := range b.Withdrawals {
+			withdrawals = append(withdrawals, seer_common.WithdrawalJson{
+				Index:          fmt.Sprintf("%d", w.Index),
+				ValidatorIndex: fmt.Sprintf("%d", w.ValidatorIndex),
+				Address:        w.Address,
+				Amount:         fmt.Sprintf("%d", w.Amount),
+			})
+		}
+
+		blocksBatchJson.Blocks = append(blocksBatchJson.Blocks, seer_common.BlockJson{
+			Difficulty:            fmt.Sprintf("%d", b.Difficulty),
+			ExtraData:             b.ExtraData,
+			GasLimit:              fmt.Sprintf("%d", b.GasLimit),
+			GasUsed:               fmt.Sprintf("%d", b.GasUsed),
+			Hash:                  b.Hash,
+			LogsBloom:             b.LogsBloom,
+			Miner:                 b.Miner,
+			Nonce:                 b.Nonce,
+			BlockNumber:           fmt.Sprintf("%d", b.BlockNumber),
+			ParentHash:            b.ParentHash,
+			ReceiptsRoot:          b.ReceiptsRoot,
+			Sha3Uncles:            b.Sha3Uncles,
+			StateRoot:             b.StateRoot,
+			Timestamp:             fmt.Sprintf("%d", b.Timestamp),
+			TotalDifficulty:       b.TotalDifficulty,
+			TransactionsRoot:      b.TransactionsRoot,
+			Size:                  fmt.Sprintf("%d", b.Size),
+			BaseFeePerGas:         b.BaseFeePerGas,
+			IndexedAt:             fmt.Sprintf("%d", b.IndexedAt),
+			BlobGasUsed:           fmt.Sprintf("%d", b.BlobGasUsed),
+			ExcessBlobGas:         fmt.Sprintf("%d", b.ExcessBlobGas),
+			Withdrawals:           withdrawals,
+			WithdrawalsRoot:       b.WithdrawalsRoot,
+			ParentBeaconBlockRoot: b.ParentBeaconBlockRoot,
+
+			Transactions: txs,
+		})
+	}
+
+	return &blocksBatchJson
+}
+
+func ToProtoSingleBlock(obj *seer_common.BlockJson) *HyperevmBlock {
+	var withdrawals []*HyperevmWithdrawal
+	for _, w := range obj.Withdrawals {
+		withdrawals = append(withdrawals, &HyperevmWithdrawal{
+			Index:          fromHex(w.Index).Uint64(),
+			ValidatorIndex: fromHex(w.ValidatorIndex).Uint64(),
+			Address:        w.Address,
+			Amount:         fromHex(w.Amount).Uint64(),
+		})
+	}
+
+	return &HyperevmBlock{
+		BlockNumber:           fromHex(obj.BlockNumber).Uint64(),
+		Difficulty:            fromHex(obj.Difficulty).Uint64(),
+		ExtraData:             obj.ExtraData,
+		GasLimit:              fromHex(obj.GasLimit).Uint64(),
+		GasUsed:               fromHex(obj.GasUsed).Uint64(),
+		BaseFeePerGas:         obj.BaseFeePerGas,
+		Hash:                  obj.Hash,
+		LogsBloom:             obj.LogsBloom,
+		Miner:                 obj.Miner,
+		Nonce:                 obj.Nonce,
+		ParentHash:            obj.ParentHash,
+		ReceiptsRoot:          obj.ReceiptsRoot,
+		Sha3Uncles:            obj.Sha3Uncles,
+		Size:                  fromHex(obj.Size).Uint64(),
+		StateRoot:             obj.StateRoot,
+		Timestamp:             fromHex(obj.Timestamp).Uint64(),
+		TotalDifficulty:       obj.TotalDifficulty,
+		TransactionsRoot:      obj.TransactionsRoot,
+		IndexedAt:             fromHex(obj.IndexedAt).Uint64(),
+		BlobGasUsed:           fromHex(obj.BlobGasUsed).Uint64(),
+		ExcessBlobGas:         fromHex(obj.ExcessBlobGas).Uint64(),
+		Withdrawals:           withdrawals,
+		WithdrawalsRoot:       obj.WithdrawalsRoot,
+		ParentBeaconBlockRoot: obj.ParentBeaconBlockRoot,
+	}
+}
+
+func ToProtoSingleTransaction(obj *seer_common.TransactionJson) *HyperevmTransaction {
+	var accessList []*HyperevmTransactionAccessList
+	for _, al := range obj.AccessList {
+		accessList = append(accessList, &HyperevmTransactionAccessList{
+			Address:     al.Address,
+			StorageKeys: al.StorageKeys,
+		})
+	}
+
+	return &HyperevmTransaction{
+		Hash:                 obj.Hash,
+		BlockNumber:          fromHex(obj.BlockNumber).Uint64(),
+		BlockHash:            obj.BlockHash,
+		FromAddress:          obj.FromAddress,
+		ToAddress:            obj.ToAddress,
+		Gas:                  obj.Gas,
+		GasPrice:             obj.GasPrice,
+		MaxFeePerGas:         obj.MaxFeePerGas,
+		MaxPriorityFeePerGas: obj.MaxPriorityFeePerGas,
+		Input:                obj.Input,
+		Nonce:                obj.Nonce,
+		TransactionIndex:     fromHex(obj.TransactionIndex).Uint64(),
+		TransactionType:      fromHex(obj.TransactionType).Uint64(),
+		Value:                obj.Value,
+		IndexedAt:            fromHex(obj.IndexedAt).Uint64(),
+		BlockTimestamp:       fromHex(obj.BlockTimestamp).Uint64(),
+
+		ChainId: obj.ChainId,
+		V:       obj.V,
+		R:       obj.R,
+		S:       obj.S,
+
+		AccessList: accessList,
+		YParity:    obj.YParity,
+
+		MaxFeePerBlobGas:    obj.MaxFeePerBlobGas,
+		BlobVersionedHashes: obj.BlobVersionedHashes,
+	}
+}
+
+func ToEvenFromLogProto(obj *HyperevmEventLog) *seer_common.EventJson {
+	return &seer_common.EventJson{
+		Address:         obj.Address,
+		Topics:          obj.Topics,
+		Data:            obj.Data,
+		BlockNumber:     fmt.Sprintf("%d", obj.BlockNumber),
+		TransactionHash: obj.TransactionHash,
+		LogIndex:        fmt.Sprintf("%d", obj.LogIndex),
+		BlockHash:       obj.BlockHash,
+		Removed:         obj.Removed,
+	}
+}
+
+func ToProtoSingleEventLog(obj *seer_common.EventJson) *HyperevmEventLog {
+	return &HyperevmEventLog{
+		Address:         obj.Address,
+		Topics:          obj.Topics,
+		Data:            obj.Data,
+		BlockNumber:     fromHex(obj.BlockNumber).Uint64(),
+		TransactionHash: obj.TransactionHash,
+		LogIndex:        fromHex(obj.LogIndex).Uint64(),
+		BlockHash:       obj.BlockHash,
+		Removed:         obj.Removed,
+	}
+}
+
+func (c *Client) DecodeProtoEventLogs(data []string) ([]*HyperevmEventLog, error) {
+	var events []*HyperevmEventLog
+	for _, d := range data {
+		var event HyperevmEventLog
+		base64Decoded, err := base64.StdEncoding.DecodeString(d)
+		if err != nil {
+			return nil, err
+		}
+		if err := proto.Unmarshal(base64Decoded, &event); err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+	return events, nil
+}
+
+func (c *Client) DecodeProtoTransactions(data []string) ([]*HyperevmTransaction, error) {
+	var transactions []*HyperevmTransaction
+	for _, d := range data {
+		var transaction HyperevmTransaction
+		base64Decoded, err := base64.StdEncoding.DecodeString(d)
+		if err != nil {
+			return nil, err
+		}
+		if err := proto.Unmarshal(base64Decoded, &transaction); err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, &transaction)
+	}
+	return transactions, nil
+}
+
+func (c *Client) DecodeProtoBlocks(data []string) ([]*HyperevmBlock, error) {
+	var blocks []*HyperevmBlock
+	for _, d := range data {
+		var block HyperevmBlock
+		base64Decoded, err := base64.StdEncoding.DecodeString(d)
+		if err != nil {
+			return nil, err
+		}
+		if err := proto.Unmarshal(base64Decoded, &block); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, &block)
+	}
+	return blocks, nil
+}
+
+func (c *Client) DecodeProtoEntireBlockToJson(rawData *bytes.Buffer) (*seer_common.BlocksBatchJson, error) {
+	var protoBlocksBatch HyperevmBlocksBatch
+
+	dataBytes := rawData.Bytes()
+
+	err := proto.Unmarshal(dataBytes, &protoBlocksBatch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal data: %v", err)
+	}
+
+	blocksBatchJson := ToEntireBlocksBatchFromLogProto(&protoBlocksBatch)
+
+	return blocksBatchJson, nil
+}
+
+func (c *Client) DecodeProtoEntireBlockToLabels(rawData *bytes.Buffer, abiMap map[string]map[string]*indexer.AbiEntry, addRawTransactions bool, threads int) ([]indexer.EventLabel, []indexer.TransactionLabel, []indexer.RawTransaction, error) {
+	var protoBlocksBatch HyperevmBlocksBatch
+
+	dataBytes := rawData.Bytes()
+
+	err := proto.Unmarshal(dataBytes, &protoBlocksBatch)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to unmarshal data: %v", err)
+	}
+
+	borStateSyncMode, err := seer_common.BorStateSyncModeFor("hyperevm")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	abiCoverage := seer_common.AbiCoverageFor(abiMap)
+
+	standardDecoders, err := seer_common.EnabledStandardDecoders()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	signatureDatabase, err := seer_common.EnabledSignatureDatabase()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Shared slices to collect labels
+	var labels []indexer.EventLabel
+	var txLabels []indexer.TransactionLabel
+	var rawTransactions []indexer.RawTransaction
+	var labelsMutex sync.Mutex
+
+	var decodeErr error
+
+	var wg sync.WaitGroup
+
+	// Concurrency limit (e.g., 10 goroutines at a time)
+	concurrencyLimit := threads
+	semaphoreChan := make(chan struct{}, concurrencyLimit)
+
+	// Channel to collect errors from goroutines
+	errorChan := make(chan error, len(protoBlocksBatch.Blocks))
+
+	// Iterate over blocks and launch goroutines
+	for _, b := range protoBlocksBatch.Blocks {
+		wg.Add(1)
+		semaphoreChan <- struct{}{}
+		go func(b *HyperevmBlock) {
+			defer wg.Done()
+			defer func() { <-semaphoreChan }()
+			defer func() {
+				if r := recover(); r != nil {
+					errorChan <- fmt.Errorf("panic in goroutine for block %d: %v", b.BlockNumber, r)
+				}
+			}()
+
+			// Local slices to collect labels for this block
+			var localEventLabels []indexer.EventLabel
+			var localTxLabels []indexer.TransactionLabel
+			var localRawTransactions []indexer.RawTransaction
+			for _, tx := range b.Transactions {
+				var decodedArgsTx map[string]interface{}
+
+				label := indexer.SeerCrawlerLabel
+
+				// Bor state-sync system transactions carry only logs of bridged state
+				eventLabelType := "event"
+				if borStateSyncMode != seer_common.BorStateSyncModeKeep && seer_common.IsBorStateSyncTransaction(tx.Hash, tx.FromAddress, tx.ToAddress, b.BlockNumber, b.Hash) {
+					if borStateSyncMode == seer_common.BorStateSyncModeSkip {
+						continue
+					}
+					eventLabelType = seer_common.BorStateSyncLabelType
+				}
+
+				if addRawTransactions && eventLabelType == "event" {
+					localRawTransactions = append(localRawTransactions, indexer.RawTransaction{
+						Hash:                 tx.Hash,
+						BlockHash:            tx.BlockHash,
+						FromAddress:          tx.FromAddress,
+						ToAddress:            tx.ToAddress,
+						Input:                tx.Input,
+						Gas:                  tx.Gas,
+						GasPrice:             tx.GasPrice,
+						Nonce:                tx.Nonce,
+						Value:                tx.Value,
+						MaxFeePerGas:         tx.MaxFeePerGas,
+						MaxPriorityFeePerGas: tx.MaxPriorityFeePerGas,
+						BlockTimestamp:       b.Timestamp,
+						BlockNumber:          b.BlockNumber,
+						TransactionIndex:     tx.TransactionIndex,
+						TransactionType:      tx.TransactionType,
+						MaxFeePerBlobGas:     tx.MaxFeePerBlobGas,
+						BlobVersionedHashes:  tx.BlobVersionedHashes,
+					})
+				}
+
+				// State-sync transactions have no call to decode, only their logs are labeled
+				if eventLabelType == "event" {
+					if standardDecoders.ContractDeployments && seer_common.IsContractCreation(tx.ToAddress) {
+						ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
+						receipt, err := c.BlockTransactionReceipt(ctxWithTimeout, tx.BlockNumber, tx.BlockHash, common.HexToHash(tx.Hash))
+						cancel()
+						if err != nil {
+							errorChan <- fmt.Errorf("error getting receipt of contract creation tx %s: %v", tx.Hash, err)
+							continue
+						}
+
+						deploymentLabel, err := seer_common.ContractDeploymentLabel(receipt, tx.FromAddress, tx.Hash, tx.BlockHash, tx.Input, tx.BlockNumber, b.Timestamp)
+						if err != nil {
+							errorChan <- err
+							continue
+						}
+						if deploymentLabel != nil {
+							localTxLabels = append(localTxLabels, *deploymentLabel)
+						}
+					}
+
+					if len(tx.Input) < 10 { // If input is less than 3 characters then it direct transfer
+						if standardDecoders.NativeTransfers && seer_common.IsWatchedNativeTransfer(abiMap, tx.FromAddress, tx.ToAddress, tx.Value) {
+							ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
+							receipt, err := c.BlockTransactionReceipt(ctxWithTimeout, tx.BlockNumber, tx.BlockHash, common.HexToHash(tx.Hash))
+							cancel()
+							if err != nil {
+								errorChan <- fmt.Errorf("error getting receipt of native transfer tx %s: %v", tx.Hash, err)
+								continue
+							}
+
+							transferLabel, err := seer_common.NativeTransferLabel(receipt, abiMap, tx.FromAddress, tx.ToAddress, tx.Value, tx.Hash, tx.BlockHash, tx.BlockNumber, b.Timestamp)
+							if err != nil {
+								errorChan <- err
+								continue
+							}
+							if transferLabel != nil {
+								localTxLabels = append(localTxLabels, *transferLabel)
+							}
+						}
+						continue
+					}
+
+					// Process transaction labels
+					selector := tx.Input[:10]
+
+					safeInnerLabel, safeErr := seer_common.SafeInnerCallLabel(c.logger, tx.ToAddress, tx.Input, tx.FromAddress, tx.Hash, tx.BlockHash, tx.BlockNumber, b.Timestamp, abiMap, func() (bool, error) {
+						for _, e := range tx.Logs {
+							if seer_common.IsSafeExecutionSuccess(tx.ToAddress, e.Address, e.Topics) {
+								return true, nil
+							}
+						}
+						return false, nil
+					})
+					if safeErr != nil {
+						errorChan <- fmt.Errorf("error decoding Safe inner call for tx %s: %v", tx.Hash, safeErr)
+						continue
+					}
+					if safeInnerLabel != nil {
+						localTxLabels = append(localTxLabels, *safeInnerLabel)
+					}
+
+					var txAbiEntry *indexer.AbiEntry
+					if abiCoverage.MayContain(tx.ToAddress) {
+						txAbiEntry = seer_common.LookupAbiEntry(abiMap, tx.ToAddress, selector)
+					}
+
+					if txAbiEntry != nil {
+
+						var initErr error
+						txAbiEntry.Once.Do(func() {
+							txAbiEntry.Abi, initErr = seer_common.GetABI(txAbiEntry.AbiJSON)
+						})
+
+						// Check if an error occurred during ABI parsing
+						if initErr != nil || txAbiEntry.Abi == nil {
+							errorChan <- fmt.Errorf("error getting ABI for address %s: %v", tx.ToAddress, initErr)
+							continue
+						}
+
+						inputData, err := hex.DecodeString(tx.Input[2:])
+						if err != nil {
+							errorChan <- fmt.Errorf("error decoding input data for tx %s: %v", tx.Hash, err)
+							continue
+						}
+						decodedArgsTx, decodeErr = seer_common.DecodeTransactionInputDataToInterface(txAbiEntry.Abi, inputData)
+						if decodeErr != nil {
+							c.logger.Warn("Unable to decode transaction input, raw label is written", logging.TxKey, tx.Hash, logging.ErrorKey, decodeErr)
+							decodedArgsTx = map[string]interface{}{
+								"input_raw": tx,
+								"abi":       txAbiEntry.AbiJSON,
+								"selector":  selector,
+								"error":     decodeErr,
+							}
+							label = indexer.SeerCrawlerRawLabel
+						}
+
+						ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
+
+						defer cancel()
+
+						receipt, err := c.BlockTransactionReceipt(ctxWithTimeout, tx.BlockNumber, tx.BlockHash, common.HexToHash(tx.Hash))
+						if err != nil {
+							errorChan <- fmt.Errorf("error getting transaction receipt for tx %s: %v", tx.Hash, err)
+							continue
+						}
+
+						// check if the transaction was successful
+						if receipt.Status == 1 {
+							decodedArgsTx["status"] = 1
+						} else {
+							decodedArgsTx["status"] = 0
+						}
+
+						if safeInnerLabel != nil {
+							decodedArgsTx["inner_call"] = map[string]interface{}{
+								"address":    safeInnerLabel.Address,
+								"label_name": safeInnerLabel.LabelName,
+							}
+						}
+
+						txLabelDataBytes, err := json.Marshal(decodedArgsTx)
+						if err != nil {
+							errorChan <- fmt.Errorf("error converting decodedArgsTx to JSON for tx %s: %v", tx.Hash, err)
+							continue
+						}
+
+						// Convert transaction to label
+						transactionLabel := indexer.TransactionLabel{
+							Address:         tx.ToAddress,
+							BlockNumber:     tx.BlockNumber,
+							BlockHash:       tx.BlockHash,
+							CallerAddress:   tx.FromAddress,
+							LabelName:       txAbiEntry.AbiName,
+							LabelType:       "tx_call",
+							OriginAddress:   tx.FromAddress,
+							Label:           label,
+							TransactionHash: tx.Hash,
+							LabelData:       string(txLabelDataBytes), // Convert JSON byte slice to string
+							BlockTimestamp:  b.Timestamp,
+						}
+
+						localTxLabels = append(localTxLabels, transactionLabel)
+					}
+				}
+
+				// Process events
+				for _, e := range tx.Logs {
+					var decodedArgsLogs map[string]interface{}
+					label = indexer.SeerCrawlerLabel
+
+					// Standard events are labeled for any address, without ABI jobs
+					if standardLabel := standardDecoders.EventLabel(abiMap, eventLabelType, e.Address, e.Topics, e.Data, tx.FromAddress, e.TransactionHash, e.BlockHash, e.BlockNumber, b.Timestamp, e.LogIndex); standardLabel != nil {
+						localEventLabels = append(localEventLabels, *standardLabel)
+						continue
+					}
+
+					// Logs without jobs in transactions of watched addresses are labeled with local
+					// signature database
+					if signatureLabel := signatureDatabase.EventLabel(abiMap, eventLabelType, tx.ToAddress, e.Address, e.Topics, e.Data, e, tx.FromAddress, e.TransactionHash, e.BlockHash, e.BlockNumber, b.Timestamp, e.LogIndex); signatureLabel != nil {
+						localEventLabels = append(localEventLabels, *signatureLabel)
+						continue
+					}
+
+					if !abiCoverage.MayContain(e.Address) {
+						continue
+					}
+
+					var topicSelector string
+
+					if len(e.Topics) > 0 {
+						topicSelector = e.Topics[0]
+					} else {
+						// 0x0 is the default topic selector
+						topicSelector = "0x0"
+					}
+
+					abiEntryLog := seer_common.LookupAbiEntry(abiMap, e.Address, topicSelector)
+					if abiEntryLog != nil && !abiEntryLog.MatchesTopics(e.Topics) {
+						continue
+					}
+					if abiEntryLog == nil {
+						if abiMap[e.Address] == nil {
+							continue
+						}
+
+						// Anonymous events have no signature topic, log is matched by its shape
+						// with jobs of address which opted in
+						anonymousEntry, anonymousSelector, anonymousArgs, matchErr := seer_common.MatchAnonymousEvent(abiMap[e.Address], e.Topics, e.Data)
+						if matchErr != nil {
+							c.logger.Debug("Skipping log without matching anonymous event", logging.TxKey, e.TransactionHash, "log_index", e.LogIndex, logging.ErrorKey, matchErr)
+							continue
+						}
+						if anonymousEntry == nil {
+							continue
+						}
+						abiEntryLog, topicSelector, decodedArgsLogs = anonymousEntry, anonymousSelector, anonymousArgs
+					} else {
+						var initErr error
+						abiEntryLog.Once.Do(func() {
+							abiEntryLog.Abi, initErr = seer_common.GetABI(abiEntryLog.AbiJSON)
+						})
+
+						// Check if an error occurred during ABI parsing
+						if initErr != nil || abiEntryLog.Abi == nil {
+							errorChan <- fmt.Errorf("error getting ABI for log address %s: %v", e.Address, initErr)
+							continue
+						}
+
+						// Decode the event data
+						decodedArgsLogs, decodeErr = seer_common.DecodeLogArgsToLabelData(abiEntryLog.Abi, e.Topics, e.Data)
+						if decodeErr != nil {
+							c.logger.Warn("Unable to decode event, raw label is written", logging.TxKey, e.TransactionHash, logging.ErrorKey, decodeErr)
+							decodedArgsLogs = map[string]interface{}{
+								"input_raw": e,
+								"abi":       abiEntryLog.AbiJSON,
+								"selector":  topicSelector,
+								"error":     decodeErr,
+							}
+							label = indexer.SeerCrawlerRawLabel
+						}
+					}
+
+					// Convert decodedArgsLogs map to JSON
+					labelDataBytes, err := json.Marshal(decodedArgsLogs)
+					if err != nil {
+						errorChan <- fmt.Errorf("error converting decodedArgsLogs to JSON for tx %s: %v", e.TransactionHash, err)
+						continue
+					}
+					// Convert event to label
+					eventLabel := indexer.EventLabel{
+						Label:           label,
+						LabelName:       abiEntryLog.AbiName,
+						LabelType:       eventLabelType,
+						BlockNumber:     e.BlockNumber,
+						BlockHash:       e.BlockHash,
+						Address:         e.Address,
+						OriginAddress:   tx.FromAddress,
+						TransactionHash: e.TransactionHash,
+						LabelData:       string(labelDataBytes), // Convert JSON byte slice to string
+						BlockTimestamp:  b.Timestamp,
+						LogIndex:        e.LogIndex,
+					}
+
+					localEventLabels = append(localEventLabels, eventLabel)
+				}
+			}
+
+			if c.tracing {
+				txHashes := make([]string, len(b.Transactions))
+				originAddresses := make(map[string]string)
+				for i, tx := range b.Transactions {
+					txHashes[i] = tx.Hash
+					originAddresses[tx.Hash] = tx.FromAddress
+				}
+
+				internalTxLabels, err := c.internalTransactionLabels(b.BlockNumber, b.Hash, b.Timestamp, txHashes, originAddresses, abiMap)
+				if err != nil {
+					errorChan <- fmt.Errorf("error tracing internal transactions of block %d: %v", b.BlockNumber, err)
+					return
+				}
+				localTxLabels = append(localTxLabels, internalTxLabels...)
+			}
+
+			// Append local labels to shared slices under mutex
+			labelsMutex.Lock()
+			labels = append(labels, localEventLabels...)
+			txLabels = append(txLabels, localTxLabels...)
+			rawTransactions = append(rawTransactions, localRawTransactions...)
+			labelsMutex.Unlock()
+		}(b)
+	}
+	// Wait for all block processing goroutines to finish
+	wg.Wait()
+	close(errorChan)
+
+	// Collect all errors
+	var errorMessages []string
+	for err := range errorChan {
+		errorMessages = append(errorMessages, err.Error())
+	}
+
+	// If any errors occurred, return them
+	if len(errorMessages) > 0 {
+		return nil, nil, nil, fmt.Errorf("errors occurred during processing:\n%s", strings.Join(errorMessages, "\n"))
+	}
+
+	return labels, txLabels, rawTransactions, nil
+}
+
+func (c *Client) DecodeProtoTransactionsToLabels(transactions []string, blocksCache map[uint64]uint64, abiMap map[string]map[string]*indexer.AbiEntry) ([]indexer.TransactionLabel, error) {
+
+	decodedTransactions, err := c.DecodeProtoTransactions(transactions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var labels []indexer.TransactionLabel
+	var decodedArgs map[string]interface{}
+	var decodeErr error
+
+	for _, transaction := range decodedTransactions {
+
+		label := indexer.SeerCrawlerLabel
+
+		selector := transaction.Input[:10]
+
+		if abiMap[transaction.ToAddress][selector].Abi == nil {
+			abiMap[transaction.ToAddress][selector].Abi, err = seer_common.GetABI(abiMap[transaction.ToAddress][selector].AbiJSON)
+			if err != nil {
+				c.logger.Error("Failed to parse ABI", logging.AddressKey, transaction.ToAddress, logging.ErrorKey, err)
+				return nil, err
+			}
+		}
+
+		inputData, err := hex.DecodeString(transaction.Input[2:])
+		if err != nil {
+			c.logger.Error("Failed to decode input data", logging.TxKey, transaction.Hash, logging.ErrorKey, err)
+			return nil, err
+		}
+
+		decodedArgs, decodeErr = seer_common.DecodeTransactionInputDataToInterface(abiMap[transaction.ToAddress][selector].Abi, inputData)
+
+		if decodeErr != nil {
+			c.logger.Warn("Unable to decode transaction input, raw label is written", logging.TxKey, transaction.Hash, logging.ErrorKey, decodeErr)
+			decodedArgs = map[string]interface{}{
+				"input_raw": transaction,
+				"abi":       abiMap[transaction.ToAddress][selector].AbiJSON,
+				"selector":  selector,
+				"error":     decodeErr,
+			}
+			label = indexer.SeerCrawlerRawLabel
+		}
+
+		labelDataBytes, err := json.Marshal(decodedArgs)
+		if err != nil {
+			c.logger.Error("Failed to marshal decoded arguments", logging.TxKey, transaction.Hash, logging.ErrorKey, err)
+			return nil, err
+		}
+
+		// Convert JSON byte slice to string
+		labelDataString := string(labelDataBytes)
+
+		// Convert transaction to label
+		transactionLabel := indexer.TransactionLabel{
+			Address:         transaction.ToAddress,
+			BlockNumber:     transaction.BlockNumber,
+			BlockHash:       transaction.BlockHash,
+			CallerAddress:   transaction.FromAddress,
+			LabelName:       abiMap[transaction.ToAddress][selector].AbiName,
+			LabelType:       "tx_call",
+			OriginAddress:   transaction.FromAddress,
+			Label:           label,
+			TransactionHash: transaction.Hash,
+			LabelData:       labelDataString,
+			BlockTimestamp:  blocksCache[transaction.BlockNumber],
+		}
+
+		labels = append(labels, transactionLabel)
+
+	}
+
+	return labels, nil
+}
+
+// internalTransactionLabels traces block and labels value transfers made by contracts with jobs.
+func (c *Client) internalTransactionLabels(blockNumber uint64, blockHash string, blockTimestamp uint64, txHashes []string, originAddresses map[string]string, abiMap map[string]map[string]*indexer.AbiEntry) ([]indexer.TransactionLabel, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	traces, err := seer_common.TraceBlockByNumber(ctxWithTimeout, c.rpcClient, blockNumber, txHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	return seer_common.InternalTransactionLabels(traces, originAddresses, blockNumber, blockHash, blockTimestamp, abiMap)
+}
+
+func (c *Client) GetTransactionByHash(ctx context.Context, hash string) (*seer_common.TransactionJson, error) {
+	var tx *seer_common.TransactionJson
+	err := c.rpcClient.CallContext(ctx, &tx, "eth_getTransactionByHash", hash)
+	return tx, err
+}
+
+func (c *Client) GetTransactionsLabels(startBlock uint64, endBlock uint64, abiMap map[string]map[string]*indexer.AbiEntry, threads int) ([]indexer.TransactionLabel, map[uint64]seer_common.BlockWithTransactions, error) {
+	var transactionsLabels []indexer.TransactionLabel
+
+	var blocksCache map[uint64]seer_common.BlockWithTransactions
+
+	// Get blocks in range
+	blocks, err := c.FetchBlocksInRangeAsync(big.NewInt(int64(startBlock)), big.NewInt(int64(endBlock)), false, threads)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Get transactions in range
+
+	for _, block := range blocks {
+
+		blockNumber, err := strconv.ParseUint(block.BlockNumber, 0, 64)
+		if err != nil {
+			log.Fatalf("Failed to convert BlockNumber to uint64: %v", err)
+		}
+
+		blockTimestamp, err := strconv.ParseUint(block.Timestamp, 0, 64)
+
+		if err != nil {
+			log.Fatalf("Failed to convert BlockTimestamp to uint64: %v", err)
+		}
+
+		if blocksCache == nil {
+			blocksCache = make(map[uint64]seer_common.BlockWithTransactions)
+		}
+
+		blocksCache[blockNumber] = seer_common.BlockWithTransactions{
+			BlockNumber:    blockNumber,
+			BlockHash:      block.Hash,
+			BlockTimestamp: blockTimestamp,
+			Transactions:   make(map[string]seer_common.TransactionJson),
+		}
+
+		if c.tracing {
+			txHashes := make([]string, len(block.Transactions))
+			originAddresses := make(map[string]string)
+			for i, tx := range block.Transactions {
+				txHashes[i] = tx.Hash
+				originAddresses[tx.Hash] = tx.FromAddress
+			}
+
+			internalTxLabels, err := c.internalTransactionLabels(blockNumber, block.Hash, blockTimestamp, txHashes, originAddresses, abiMap)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error tracing internal transactions of block %d: %v", blockNumber, err)
+			}
+			transactionsLabels = append(transactionsLabels, internalTxLabels...)
+		}
+
+		for _, tx := range block.Transactions {
+
+			label := indexer.SeerCrawlerLabel
+
+			if len(tx.Input) < 10 { // If input is less than 3 characters then it direct transfer
+				continue
+			}
+			// Fill blocks cache
+			blocksCache[blockNumber].Transactions[tx.Hash] = tx
+
+			// Process transaction labels
+
+			selector := tx.Input[:10]
+
+			safeInnerLabel, safeErr := seer_common.SafeInnerCallLabel(c.logger, tx.ToAddress, tx.Input, tx.FromAddress, tx.Hash, tx.BlockHash, blockNumber, blockTimestamp, abiMap, func() (bool, error) {
+				ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
+				defer cancel()
+
+				receipt, err := c.BlockTransactionReceipt(ctxWithTimeout, blockNumber, block.Hash, common.HexToHash(tx.Hash))
+				if err != nil {
+					return false, err
+				}
+				for _, l := range receipt.Logs {
+					topics := make([]string, len(l.Topics))
+					for i, topic := range l.Topics {
+						topics[i] = topic.Hex()
+					}
+					if seer_common.IsSafeExecutionSuccess(tx.ToAddress, l.Address.Hex(), topics) {
+						return true, nil
+					}
+				}
+				return false, nil
+			})
+			if safeErr != nil {
+				c.logger.Error("Failed to decode Safe inner call", logging.TxKey, tx.Hash, logging.ErrorKey, safeErr)
+				return nil, nil, safeErr
+			}
+			if safeInnerLabel != nil {
+				transactionsLabels = append(transactionsLabels, *safeInnerLabel)
+			}
+
+			if abiEntryTx := seer_common.LookupAbiEntry(abiMap, tx.ToAddress, selector); abiEntryTx != nil {
+				var err error
+				abiEntryTx.Once.Do(func() {
+					abiEntryTx.Abi, err = seer_common.GetABI(abiEntryTx.AbiJSON)
+					if err != nil {
+						c.logger.Error("Failed to parse ABI", logging.AddressKey, tx.ToAddress, logging.ErrorKey, err)
+						return
+					}
+				})
+
+				// Check if an error occurred during ABI parsing
+				if abiEntryTx.Abi == nil {
+					c.logger.Error("Failed to parse ABI", logging.AddressKey, tx.ToAddress, logging.ErrorKey, err)
+					return nil, nil, err
+				}
+
+				inputData, err := hex.DecodeString(tx.Input[2:])
+				if err != nil {
+					c.logger.Error("Failed to decode input data", logging.TxKey, tx.Hash, logging.ErrorKey, err)
+					return nil, nil, err
+				}
+
+				decodedArgsTx, decodeErr := seer_common.DecodeTransactionInputDataToInterface(abiEntryTx.Abi, inputData)
+				if decodeErr != nil {
+					c.logger.Warn("Unable to decode transaction input, raw label is written", logging.TxKey, tx.Hash, logging.ErrorKey, decodeErr)
+					decodedArgsTx = map[string]interface{}{
+						"input_raw": tx,
+						"abi":       abiEntryTx.AbiJSON,
+						"selector":  selector,
+						"error":     decodeErr,
+					}
+					label = indexer.SeerCrawlerRawLabel
+				}
+
+				ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
+
+				defer cancel()
+
+				receipt, err := c.BlockTransactionReceipt(ctxWithTimeout, blockNumber, block.Hash, common.HexToHash(tx.Hash))
+
+				if err != nil {
+					c.logger.Error("Failed to fetch transaction receipt", logging.TxKey, tx.Hash, logging.ErrorKey, err)
+					return nil, nil, err
+				}
+
+				// check if the transaction was successful
+				if receipt.Status == 1 {
+					decodedArgsTx["status"] = 1
+				} else {
+					decodedArgsTx["status"] = 0
+				}
+
+				if safeInnerLabel != nil {
+					decodedArgsTx["inner_call"] = map[string]interface{}{
+						"address":    safeInnerLabel.Address,
+						"label_name": safeInnerLabel.LabelName,
+					}
+				}
+
+				txLabelDataBytes, err := json.Marshal(decodedArgsTx)
+				if err != nil {
+					c.logger.Error("Failed to marshal decoded arguments", logging.TxKey, tx.Hash, logging.ErrorKey, err)
+					return nil, nil, err
+				}
+
+				// Convert transaction to label
+				transactionLabel := indexer.TransactionLabel{
+					Address:         tx.ToAddress,
+					BlockNumber:     blockNumber,
+					BlockHash:       tx.BlockHash,
+					CallerAddress:   tx.FromAddress,
+					LabelName:       abiEntryTx.AbiName,
+					LabelType:       "tx_call",
+					OriginAddress:   tx.FromAddress,
+					Label:           label,
+					TransactionHash: tx.Hash,
+					LabelData:       string(txLabelDataBytes), // Convert JSON byte slice to string
+					BlockTimestamp:  blockTimestamp,
+				}
+
+				transactionsLabels = append(transactionsLabels, transactionLabel)
+			}
+
+		}
+
+	}
+
+	return transactionsLabels, blocksCache, nil
+
+}
+
+func (c *Client) GetEventsLabels(startBlock uint64, endBlock uint64, abiMap map[string]map[string]*indexer.AbiEntry, blocksCache map[uint64]seer_common.BlockWithTransactions) ([]indexer.EventLabel, error) {
+	var eventsLabels []indexer.EventLabel
+
+	borStateSyncMode, err := seer_common.BorStateSyncModeFor("hyperevm")
+	if err != nil {
+		return nil, err
+	}
+
+	if blocksCache == nil {
+		blocksCache = make(map[uint64]seer_common.BlockWithTransactions)
+	}
+
+	// Get events in range
+
+	var addresses []common.Address
+	var topics []common.Hash
+
+	for address, selectorMap := range abiMap {
+		for selector, _ := range selectorMap {
+			if indexer.IsAnonymousEventSelector(selector) {
+				continue
+			}
+			topics = append(topics, common.HexToHash(selector))
+		}
+
+		if address == indexer.WildcardAddress {
+			continue
+		}
+		addresses = append(addresses, common.HexToAddress(address))
+	}
+
+	// query filter from abiMap
+	filter := ethereum.FilterQuery{
+		FromBlock: big.NewInt(int64(startBlock)),
+		ToBlock:   big.NewInt(int64(endBlock)),
+		Addresses: addresses,
+		Topics:    append([][]common.Hash{topics}, seer_common.IndexedTopicsFilter(abiMap)...),
+	}
+
+	// Wildcard jobs match logs of any address, so only topics are filtered
+	if seer_common.HasWildcardJobs(abiMap) {
+		filter.Addresses = nil
+	}
+
+	// Logs of anonymous events have arbitrary first topic, so only addresses are filtered
+	if seer_common.HasAnonymousEventJobs(abiMap) {
+		filter.Topics = nil
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
+
+	defer cancel()
+
+	logs, err := c.ClientFilterLogs(ctxWithTimeout, filter, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, log := range logs {
+		var decodedArgsLogs map[string]interface{}
+		label := indexer.SeerCrawlerLabel
+
+		var topicSelector string
+
+		if len(log.Topics) > 0 {
+			topicSelector = log.Topics[0]
+		} else {
+			// 0x0 is the default topic selector
+			topicSelector = "0x0"
+		}
+
+		abiEntryLog := seer_common.LookupAbiEntry(abiMap, log.Address, topicSelector)
+		if abiEntryLog != nil && !abiEntryLog.MatchesTopics(log.Topics) {
+			continue
+		}
+		if abiEntryLog == nil {
+			if abiMap[log.Address] == nil {
+				continue
+			}
+
+			anonymousEntry, anonymousSelector, anonymousArgs, matchErr := seer_common.MatchAnonymousEvent(abiMap[log.Address], log.Topics, log.Data)
+			if matchErr != nil {
+				c.logger.Debug("Skipping log without matching anonymous event", logging.TxKey, log.TransactionHash, logging.ErrorKey, matchErr)
+				continue
+			}
+			if anonymousEntry == nil {
+				continue
+			}
+			abiEntryLog, topicSelector, decodedArgsLogs = anonymousEntry, anonymousSelector, anonymousArgs
+		} else {
+			var initErr error
+			abiEntryLog.Once.Do(func() {
+				abiEntryLog.Abi, initErr = seer_common.GetABI(abiEntryLog.AbiJSON)
+			})
+
+			// Check if an error occurred during ABI parsing
+			if initErr != nil || abiEntryLog.Abi == nil {
+				c.logger.Error("Failed to parse ABI", logging.AddressKey, log.Address, logging.ErrorKey, initErr)
+				return nil, initErr
+			}
+
+			// Decode the event data
+			var decodeErr error
+			decodedArgsLogs, decodeErr = seer_common.DecodeLogArgsToLabelData(abiEntryLog.Abi, log.Topics, log.Data)
+			if decodeErr != nil {
+				c.logger.Warn("Unable to decode event, raw label is written", logging.TxKey, log.TransactionHash, logging.ErrorKey, decodeErr)
+				decodedArgsLogs = map[string]interface{}{
+					"input_raw": log,
+					"abi":       abiEntryLog.AbiJSON,
+					"selector":  topicSelector,
+					"error":     decodeErr,
+				}
+				label = indexer.SeerCrawlerRawLabel
+			}
+		}
+
+		// Convert decodedArgsLogs map to JSON
+		labelDataBytes, err := json.Marshal(decodedArgsLogs)
+		if err != nil {
+			c.logger.Error("Failed to marshal decoded arguments", logging.TxKey, log.TransactionHash, logging.ErrorKey, err)
+			return nil, err
+		}
+
+		blockNumber, err := strconv.ParseUint(log.BlockNumber, 0, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := blocksCache[blockNumber]; !ok {
+
+			ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
+
+			defer cancel()
+
+			// get block from rpc
+			block, err := c.GetBlockByNumber(ctxWithTimeout, big.NewInt(int64(blockNumber)), true)
+			if err != nil {
+				return nil, err
+			}
+
+			blockTimestamp, err := strconv.ParseUint(block.Timestamp, 0, 64)
+			if err != nil {
+				return nil, err
+			}
+
+			blocksCache[blockNumber] = seer_common.BlockWithTransactions{
+				BlockNumber:    blockNumber,
+				BlockHash:      block.Hash,
+				BlockTimestamp: blockTimestamp,
+				Transactions:   make(map[string]seer_common.TransactionJson),
+			}
+
+			for _, tx := range block.Transactions {
+				blocksCache[blockNumber].Transactions[tx.Hash] = tx
+			}
+
+		}
+
+		transaction := blocksCache[blockNumber].Transactions[log.TransactionHash]
+
+		logIndex, err := strconv.ParseUint(log.LogIndex, 0, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		// Transaction of bor state-sync logs could be missing in block, it is matched by hash
+		eventLabelType := "event"
+		if borStateSyncMode != seer_common.BorStateSyncModeKeep && seer_common.IsBorStateSyncTransaction(log.TransactionHash, transaction.FromAddress, transaction.ToAddress, blockNumber, log.BlockHash) {
+			if borStateSyncMode == seer_common.BorStateSyncModeSkip {
+				continue
+			}
+			eventLabelType = seer_common.BorStateSyncLabelType
+		}
+
+		// Convert event to label
+		eventLabel := indexer.EventLabel{
+			Label:           label,
+			LabelName:       abiEntryLog.AbiName,
+			LabelType:       eventLabelType,
+			BlockNumber:     blockNumber,
+			BlockHash:       log.BlockHash,
+			Address:         log.Address,
+			OriginAddress:   transaction.FromAddress,
+			TransactionHash: log.TransactionHash,
+			LabelData:       string(labelDataBytes), // Convert JSON byte slice to string
+			BlockTimestamp:  blocksCache[blockNumber].BlockTimestamp,
+			LogIndex:        logIndex,
+		}
+
+		eventsLabels = append(eventsLabels, eventLabel)
+
+	}
+
+	return eventsLabels, nil
+
+}
@@ -0,0 +1,509 @@
+package solana
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/iancoleman/strcase"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var base58Indexes = func() [256]int {
+	var indexes [256]int
+	for i := range indexes {
+		indexes[i] = -1
+	}
+	for i := 0; i < len(base58Alphabet); i++ {
+		indexes[base58Alphabet[i]] = i
+	}
+	return indexes
+}()
+
+// Base58Decode decodes base58 string used by Solana for public keys, signatures and
+// instruction data.
+func Base58Decode(encoded string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(encoded) && encoded[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+
+	// log(58) / log(256), rounded up
+	decoded := make([]byte, (len(encoded)-zeros)*733/1000+1)
+	length := 0
+	for i := zeros; i < len(encoded); i++ {
+		carry := base58Indexes[encoded[i]]
+		if carry < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q at position %d", encoded[i], i)
+		}
+		j := 0
+		for k := len(decoded) - 1; k >= 0 && (carry != 0 || j < length); k-- {
+			carry += 58 * int(decoded[k])
+			decoded[k] = byte(carry % 256)
+			carry /= 256
+			j++
+		}
+		length = j
+	}
+
+	result := make([]byte, zeros+length)
+	copy(result[zeros:], decoded[len(decoded)-length:])
+	return result, nil
+}
+
+// Base58Encode encodes bytes to base58 string.
+func Base58Encode(data []byte) string {
+	zeros := 0
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
+	}
+
+	// log(256) / log(58), rounded up
+	encoded := make([]byte, (len(data)-zeros)*138/100+1)
+	length := 0
+	for _, b := range data[zeros:] {
+		carry := int(b)
+		j := 0
+		for k := len(encoded) - 1; k >= 0 && (carry != 0 || j < length); k-- {
+			carry += 256 * int(encoded[k])
+			encoded[k] = byte(carry % 58)
+			carry /= 58
+			j++
+		}
+		length = j
+	}
+
+	var sb strings.Builder
+	sb.Grow(zeros + length)
+	for i := 0; i < zeros; i++ {
+		sb.WriteByte(base58Alphabet[0])
+	}
+	for _, digit := range encoded[len(encoded)-length:] {
+		sb.WriteByte(base58Alphabet[digit])
+	}
+	return sb.String()
+}
+
+// PublicKeyToHex converts base58 public key to hex form labels and jobs use for addresses, so
+// Solana accounts are stored in the same address columns as EVM ones.
+func PublicKeyToHex(publicKey string) (string, error) {
+	decoded, err := Base58Decode(publicKey)
+	if err != nil {
+		return "", err
+	}
+	if len(decoded) != 32 {
+		return "", fmt.Errorf("invalid public key %s of %d bytes", publicKey, len(decoded))
+	}
+	return fmt.Sprintf("0x%x", decoded), nil
+}
+
+// Anchor prefixes instruction which carries event emitted with emit_cpi! by this tag
+var eventInstructionTag = []byte{0xe4, 0x45, 0xa5, 0x2e, 0x51, 0xcb, 0x9a, 0x1d}
+
+// ProgramEntry is an entry of Anchor IDL of program: instruction with args and accounts or
+// event with fields. Jobs of Solana programs keep entry in abi column and hex of its
+// discriminator as selector.
+type ProgramEntry struct {
+	Name          string       `json:"name"`
+	Type          string       `json:"type"`
+	Discriminator []byte       `json:"-"`
+	Args          []ProgramArg `json:"args,omitempty"`
+	Fields        []ProgramArg `json:"fields,omitempty"`
+	Accounts      []struct {
+		Name string `json:"name"`
+	} `json:"accounts,omitempty"`
+}
+
+// ProgramArg is a named Borsh encoded argument of instruction or field of event.
+type ProgramArg struct {
+	Name string          `json:"name"`
+	Type json.RawMessage `json:"type"`
+}
+
+// ParseProgramEntry parses IDL entry with type "instruction" or "event". Discriminator is taken
+// from entry if IDL lists it, as Anchor 0.30 does, otherwise it is derived from name.
+func ParseProgramEntry(entryJSON string) (*ProgramEntry, error) {
+	var raw struct {
+		ProgramEntry
+		Discriminator []int `json:"discriminator"`
+	}
+	if err := json.Unmarshal([]byte(entryJSON), &raw); err != nil {
+		return nil, fmt.Errorf("invalid program entry: %w", err)
+	}
+
+	entry := raw.ProgramEntry
+	if entry.Name == "" {
+		return nil, errors.New("program entry has no name")
+	}
+
+	if len(raw.Discriminator) > 0 {
+		for _, b := range raw.Discriminator {
+			if b < 0 || b > math.MaxUint8 {
+				return nil, fmt.Errorf("invalid discriminator byte %d of %s", b, entry.Name)
+			}
+			entry.Discriminator = append(entry.Discriminator, byte(b))
+		}
+		return &entry, nil
+	}
+
+	var preimage string
+	switch entry.Type {
+	case "event":
+		preimage = "event:" + entry.Name
+	case "instruction":
+		preimage = "global:" + strcase.ToSnake(entry.Name)
+	default:
+		return nil, fmt.Errorf("unknown type %q of program entry %s, expected instruction or event", entry.Type, entry.Name)
+	}
+	hash := sha256.Sum256([]byte(preimage))
+	entry.Discriminator = hash[:8]
+
+	return &entry, nil
+}
+
+// ProgramEntrySelector returns selector of program entry for abi_selector column of job.
+func ProgramEntrySelector(entryJSON string) (string, error) {
+	entry, err := ParseProgramEntry(entryJSON)
+	if err != nil {
+		return "", err
+	}
+	return "0x" + hex.EncodeToString(entry.Discriminator), nil
+}
+
+func discriminatorSelector(data []byte) (string, bool) {
+	if len(data) < 8 {
+		return "", false
+	}
+	return "0x" + hex.EncodeToString(data[:8]), true
+}
+
+// Parsed entries are shared by jobs with the same IDL entry
+var programEntries sync.Map
+
+func cachedProgramEntry(entryJSON string) (*ProgramEntry, error) {
+	if cached, ok := programEntries.Load(entryJSON); ok {
+		return cached.(*ProgramEntry), nil
+	}
+	entry, err := ParseProgramEntry(entryJSON)
+	if err != nil {
+		return nil, err
+	}
+	programEntries.Store(entryJSON, entry)
+	return entry, nil
+}
+
+// DecodeInstructionToLabelData decodes Borsh encoded args of instruction data and names its
+// accounts.
+func DecodeInstructionToLabelData(entry *ProgramEntry, data []byte, accounts []string) (map[string]interface{}, error) {
+	args, err := decodeBorshArgs(entry.Args, data[len(entry.Discriminator):])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode args of instruction %s: %w", entry.Name, err)
+	}
+
+	namedAccounts := make(map[string]interface{})
+	for i, account := range accounts {
+		if i < len(entry.Accounts) {
+			namedAccounts[entry.Accounts[i].Name] = account
+		} else {
+			namedAccounts[fmt.Sprintf("remaining_%d", i-len(entry.Accounts))] = account
+		}
+	}
+
+	return map[string]interface{}{
+		"type":     "tx_call",
+		"name":     entry.Name,
+		"args":     args,
+		"accounts": namedAccounts,
+	}, nil
+}
+
+// DecodeEventToLabelData decodes Borsh encoded fields of event data.
+func DecodeEventToLabelData(entry *ProgramEntry, data []byte) (map[string]interface{}, error) {
+	args, err := decodeBorshArgs(entry.Fields, data[len(entry.Discriminator):])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode fields of event %s: %w", entry.Name, err)
+	}
+
+	return map[string]interface{}{
+		"type": "event",
+		"name": entry.Name,
+		"args": args,
+	}, nil
+}
+
+type borshReader struct {
+	data []byte
+}
+
+func (r *borshReader) read(n int) ([]byte, error) {
+	if n < 0 || len(r.data) < n {
+		return nil, fmt.Errorf("unexpected end of data, %d bytes left but %d required", len(r.data), n)
+	}
+	chunk := r.data[:n]
+	r.data = r.data[n:]
+	return chunk, nil
+}
+
+func decodeBorshArgs(args []ProgramArg, data []byte) (map[string]interface{}, error) {
+	reader := &borshReader{data: data}
+	decoded := make(map[string]interface{}, len(args))
+	for _, arg := range args {
+		value, err := reader.decode(arg.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", arg.Name, err)
+		}
+		decoded[arg.Name] = value
+	}
+	return decoded, nil
+}
+
+// decode reads value of IDL type. Integers wider than 32 bits are returned as decimal strings,
+// they do not fit into JSON numbers. User defined types are not supported.
+func (r *borshReader) decode(idlType json.RawMessage) (interface{}, error) {
+	var primitive string
+	if err := json.Unmarshal(idlType, &primitive); err == nil {
+		return r.decodePrimitive(primitive)
+	}
+
+	var composite struct {
+		Vec     json.RawMessage   `json:"vec"`
+		Option  json.RawMessage   `json:"option"`
+		Array   []json.RawMessage `json:"array"`
+		Defined json.RawMessage   `json:"defined"`
+	}
+	if err := json.Unmarshal(idlType, &composite); err != nil {
+		return nil, fmt.Errorf("invalid type %s", string(idlType))
+	}
+
+	switch {
+	case composite.Vec != nil:
+		lengthBytes, err := r.read(4)
+		if err != nil {
+			return nil, err
+		}
+		length := binary.LittleEndian.Uint32(lengthBytes)
+		if int(length) > len(r.data) {
+			return nil, fmt.Errorf("vector length %d exceeds data", length)
+		}
+		values := make([]interface{}, 0, length)
+		for i := uint32(0); i < length; i++ {
+			value, err := r.decode(composite.Vec)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	case composite.Option != nil:
+		tag, err := r.read(1)
+		if err != nil {
+			return nil, err
+		}
+		if tag[0] == 0 {
+			return nil, nil
+		}
+		return r.decode(composite.Option)
+	case len(composite.Array) == 2:
+		var length int
+		if err := json.Unmarshal(composite.Array[1], &length); err != nil {
+			return nil, fmt.Errorf("invalid array length %s", string(composite.Array[1]))
+		}
+		values := make([]interface{}, 0, length)
+		for i := 0; i < length; i++ {
+			value, err := r.decode(composite.Array[0])
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	case composite.Defined != nil:
+		return nil, fmt.Errorf("defined type %s is not supported", string(composite.Defined))
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", string(idlType))
+}
+
+func (r *borshReader) decodePrimitive(primitive string) (interface{}, error) {
+	switch primitive {
+	case "bool":
+		b, err := r.read(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0] != 0, nil
+	case "u8":
+		b, err := r.read(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0], nil
+	case "i8":
+		b, err := r.read(1)
+		if err != nil {
+			return nil, err
+		}
+		return int8(b[0]), nil
+	case "u16":
+		b, err := r.read(2)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.Uint16(b), nil
+	case "i16":
+		b, err := r.read(2)
+		if err != nil {
+			return nil, err
+		}
+		return int16(binary.LittleEndian.Uint16(b)), nil
+	case "u32":
+		b, err := r.read(4)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.Uint32(b), nil
+	case "i32":
+		b, err := r.read(4)
+		if err != nil {
+			return nil, err
+		}
+		return int32(binary.LittleEndian.Uint32(b)), nil
+	case "f32":
+		b, err := r.read(4)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float32frombits(binary.LittleEndian.Uint32(b)), nil
+	case "f64":
+		b, err := r.read(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	case "u64", "i64", "u128", "i128":
+		size := 8
+		if strings.HasSuffix(primitive, "128") {
+			size = 16
+		}
+		b, err := r.read(size)
+		if err != nil {
+			return nil, err
+		}
+		return littleEndianInteger(b, primitive[0] == 'i').String(), nil
+	case "string":
+		lengthBytes, err := r.read(4)
+		if err != nil {
+			return nil, err
+		}
+		b, err := r.read(int(binary.LittleEndian.Uint32(lengthBytes)))
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case "bytes":
+		lengthBytes, err := r.read(4)
+		if err != nil {
+			return nil, err
+		}
+		b, err := r.read(int(binary.LittleEndian.Uint32(lengthBytes)))
+		if err != nil {
+			return nil, err
+		}
+		return "0x" + hex.EncodeToString(b), nil
+	case "publicKey", "pubkey":
+		b, err := r.read(32)
+		if err != nil {
+			return nil, err
+		}
+		return Base58Encode(b), nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %q", primitive)
+}
+
+func littleEndianInteger(b []byte, signed bool) *big.Int {
+	bigEndian := make([]byte, len(b))
+	for i := range b {
+		bigEndian[len(b)-1-i] = b[i]
+	}
+
+	value := new(big.Int).SetBytes(bigEndian)
+	if signed && len(b) > 0 && b[len(b)-1]&0x80 != 0 {
+		value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+	}
+	return value
+}
+
+// ProgramLog is data logged by program with sol_log_data, Anchor emits events this way.
+type ProgramLog struct {
+	ProgramID string
+	// Program which invoked ProgramID, empty for programs of top-level instructions
+	CallerProgramID string
+	Data            []byte
+}
+
+// ParseProgramLogs follows invocations in log messages of transaction and attributes
+// "Program data:" entries to programs which logged them. Logs truncated by node end with
+// "Log truncated", entries before it are returned.
+func ParseProgramLogs(logMessages []string) []ProgramLog {
+	var stack []string
+	var logs []ProgramLog
+
+	for _, message := range logMessages {
+		if data, found := strings.CutPrefix(message, "Program data: "); found {
+			if len(stack) == 0 {
+				continue
+			}
+
+			var decoded []byte
+			valid := true
+			for _, chunk := range strings.Fields(data) {
+				chunkBytes, err := base64.StdEncoding.DecodeString(chunk)
+				if err != nil {
+					valid = false
+					break
+				}
+				decoded = append(decoded, chunkBytes...)
+			}
+			if !valid {
+				continue
+			}
+
+			programLog := ProgramLog{ProgramID: stack[len(stack)-1], Data: decoded}
+			if len(stack) > 1 {
+				programLog.CallerProgramID = stack[len(stack)-2]
+			}
+			logs = append(logs, programLog)
+			continue
+		}
+
+		rest, found := strings.CutPrefix(message, "Program ")
+		if !found {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) < 2 {
+			continue
+		}
+
+		switch {
+		case fields[1] == "invoke":
+			stack = append(stack, fields[0])
+		case fields[1] == "success" || strings.HasPrefix(fields[1], "failed"):
+			if len(stack) > 0 && stack[len(stack)-1] == fields[0] {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+
+	return logs
+}
@@ -0,0 +1,813 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"google.golang.org/protobuf/proto"
+
+	seer_common "github.com/G7DAO/seer/blockchain/common"
+	"github.com/G7DAO/seer/indexer"
+	"github.com/G7DAO/seer/version"
+)
+
+// Solana client is written by hand, it is not generated from blockchain templates as clients
+// of EVM chains. Block numbers of seer interfaces are slots, blocks of skipped slots do not exist.
+
+func init() {
+	seer_common.RegisterClient("solana", func(url string, timeout int) (seer_common.ChainClient, error) {
+		client, err := NewClient(url, timeout)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	})
+}
+
+var _ seer_common.ChainClient = (*Client)(nil)
+
+// Commitment of all requests, only finalized blocks are indexed and they could not be rolled back
+const Commitment = "finalized"
+
+// JSON-RPC error codes of getBlock for slots without block
+const (
+	errorCodeSlotSkipped            = -32007
+	errorCodeLongTermStorageSkipped = -32009
+)
+
+var errNotSupported = errors.New("not supported by solana client")
+
+// NewClient connects to RPC endpoints, url could be comma separated list of endpoints
+// to balance calls between them, see seer_common.ParseRPCEndpoints.
+func NewClient(url string, timeout int) (*Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	rpcClient, err := seer_common.DialRPCPool(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	rpcClient.SetHeadMethod("getSlot")
+
+	return &Client{
+		rpcClient: rpcClient,
+		timeout:   time.Duration(timeout) * time.Second,
+	}, nil
+}
+
+// Client is a wrapper around the Solana JSON-RPC client.
+type Client struct {
+	rpcClient *seer_common.RPCPool
+	timeout   time.Duration
+}
+
+// ChainType returns the chain type.
+func (c *Client) ChainType() string {
+	return "solana"
+}
+
+// SetRateLimit limits rate of requests to RPC endpoints.
+func (c *Client) SetRateLimit(config seer_common.RateLimitConfig) {
+	c.rpcClient.SetRateLimit(config)
+}
+
+// SetRetryPolicy sets how requests failed with transient errors are retried.
+func (c *Client) SetRetryPolicy(policy seer_common.RetryPolicy) {
+	c.rpcClient.SetRetryPolicy(policy)
+}
+
+// SetCrawlSchedule holds RPC requests of client inside of historical crawl windows.
+func (c *Client) SetCrawlSchedule(schedule *seer_common.CrawlSchedule) {
+	c.rpcClient.SetCrawlSchedule(schedule)
+}
+
+// Close closes the underlying RPC client.
+func (c *Client) Close() {
+	c.rpcClient.Close()
+}
+
+// GetLatestBlockNumber returns the latest finalized slot.
+func (c *Client) GetLatestBlockNumber() (*big.Int, error) {
+	var slot uint64
+
+	ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if err := c.rpcClient.CallContext(ctxWithTimeout, &slot, "getSlot", map[string]interface{}{"commitment": Commitment}); err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetUint64(slot), nil
+}
+
+type rpcInstruction struct {
+	ProgramIDIndex int     `json:"programIdIndex"`
+	Accounts       []int   `json:"accounts"`
+	Data           string  `json:"data"`
+	StackHeight    *uint32 `json:"stackHeight"`
+}
+
+type rpcTransactionWithMeta struct {
+	Slot      uint64 `json:"slot"`
+	BlockTime *int64 `json:"blockTime"`
+
+	Transaction struct {
+		Signatures []string `json:"signatures"`
+		Message    struct {
+			AccountKeys     []string         `json:"accountKeys"`
+			RecentBlockhash string           `json:"recentBlockhash"`
+			Instructions    []rpcInstruction `json:"instructions"`
+		} `json:"message"`
+	} `json:"transaction"`
+
+	Meta *struct {
+		Err               json.RawMessage `json:"err"`
+		Fee               uint64          `json:"fee"`
+		InnerInstructions []struct {
+			Index        int              `json:"index"`
+			Instructions []rpcInstruction `json:"instructions"`
+		} `json:"innerInstructions"`
+		LogMessages     []string `json:"logMessages"`
+		LoadedAddresses *struct {
+			Writable []string `json:"writable"`
+			Readonly []string `json:"readonly"`
+		} `json:"loadedAddresses"`
+		ComputeUnitsConsumed *uint64 `json:"computeUnitsConsumed"`
+	} `json:"meta"`
+
+	Version json.RawMessage `json:"version"`
+}
+
+type rpcBlock struct {
+	BlockHeight       *uint64                  `json:"blockHeight"`
+	BlockTime         *int64                   `json:"blockTime"`
+	Blockhash         string                   `json:"blockhash"`
+	ParentSlot        uint64                   `json:"parentSlot"`
+	PreviousBlockhash string                   `json:"previousBlockhash"`
+	Transactions      []rpcTransactionWithMeta `json:"transactions"`
+}
+
+func isSkippedSlotError(err error) bool {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	return rpcErr.ErrorCode() == errorCodeSlotSkipped || rpcErr.ErrorCode() == errorCodeLongTermStorageSkipped
+}
+
+// GetBlock returns block produced in slot, nil is returned for skipped slots.
+func (c *Client) GetBlock(ctx context.Context, slot uint64) (*SolanaBlock, error) {
+	var block *rpcBlock
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	err := c.rpcClient.CallContext(ctxWithTimeout, &block, "getBlock", slot, map[string]interface{}{
+		"commitment":                     Commitment,
+		"encoding":                       "json",
+		"transactionDetails":             "full",
+		"rewards":                        false,
+		"maxSupportedTransactionVersion": 0,
+	})
+	if err != nil {
+		if isSkippedSlotError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get block of slot %d: %w", slot, err)
+	}
+	if block == nil {
+		return nil, nil
+	}
+
+	return toProtoBlock(slot, block)
+}
+
+// GetTransaction returns transaction by its signature.
+func (c *Client) GetTransaction(ctx context.Context, signature string) (*SolanaTransaction, error) {
+	var transaction *rpcTransactionWithMeta
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	err := c.rpcClient.CallContext(ctxWithTimeout, &transaction, "getTransaction", signature, map[string]interface{}{
+		"commitment":                     Commitment,
+		"encoding":                       "json",
+		"maxSupportedTransactionVersion": 0,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if transaction == nil {
+		return nil, fmt.Errorf("transaction %s not found", signature)
+	}
+
+	var blockTime uint64
+	if transaction.BlockTime != nil {
+		blockTime = uint64(*transaction.BlockTime)
+	}
+
+	// Response of getTransaction has no block hash, it is known only from block
+	return toProtoTransaction(transaction, transaction.Slot, "", blockTime, 0)
+}
+
+func toProtoBlock(slot uint64, block *rpcBlock) (*SolanaBlock, error) {
+	solanaBlock := &SolanaBlock{
+		Slot:              slot,
+		BlockHash:         block.Blockhash,
+		PreviousBlockHash: block.PreviousBlockhash,
+		ParentSlot:        block.ParentSlot,
+		IndexedAt:         uint64(time.Now().Unix()),
+	}
+	if block.BlockHeight != nil {
+		solanaBlock.BlockHeight = *block.BlockHeight
+	}
+	if block.BlockTime != nil {
+		solanaBlock.BlockTime = uint64(*block.BlockTime)
+	}
+
+	for i := range block.Transactions {
+		transaction, err := toProtoTransaction(&block.Transactions[i], slot, block.Blockhash, solanaBlock.BlockTime, uint64(i))
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d of slot %d: %w", i, slot, err)
+		}
+		solanaBlock.Transactions = append(solanaBlock.Transactions, transaction)
+	}
+
+	return solanaBlock, nil
+}
+
+func toProtoTransaction(tx *rpcTransactionWithMeta, slot uint64, blockHash string, blockTime uint64, index uint64) (*SolanaTransaction, error) {
+	if len(tx.Transaction.Signatures) == 0 || len(tx.Transaction.Message.AccountKeys) == 0 {
+		return nil, errors.New("transaction has no signatures or account keys")
+	}
+
+	// Instructions of versioned transactions refer to static keys followed by writable and
+	// read-only keys loaded from address lookup tables
+	accountKeys := append([]string{}, tx.Transaction.Message.AccountKeys...)
+	if tx.Meta != nil && tx.Meta.LoadedAddresses != nil {
+		accountKeys = append(accountKeys, tx.Meta.LoadedAddresses.Writable...)
+		accountKeys = append(accountKeys, tx.Meta.LoadedAddresses.Readonly...)
+	}
+
+	toProtoInstruction := func(instruction rpcInstruction) (*SolanaInstruction, error) {
+		if instruction.ProgramIDIndex < 0 || instruction.ProgramIDIndex >= len(accountKeys) {
+			return nil, fmt.Errorf("program index %d out of %d account keys", instruction.ProgramIDIndex, len(accountKeys))
+		}
+		solanaInstruction := &SolanaInstruction{
+			ProgramId: accountKeys[instruction.ProgramIDIndex],
+			Data:      instruction.Data,
+		}
+		for _, accountIndex := range instruction.Accounts {
+			if accountIndex < 0 || accountIndex >= len(accountKeys) {
+				return nil, fmt.Errorf("account index %d out of %d account keys", accountIndex, len(accountKeys))
+			}
+			solanaInstruction.Accounts = append(solanaInstruction.Accounts, accountKeys[accountIndex])
+		}
+		if instruction.StackHeight != nil {
+			solanaInstruction.StackHeight = *instruction.StackHeight
+		}
+		return solanaInstruction, nil
+	}
+
+	solanaTransaction := &SolanaTransaction{
+		Signature:        tx.Transaction.Signatures[0],
+		Slot:             slot,
+		BlockHash:        blockHash,
+		BlockTimestamp:   blockTime,
+		TransactionIndex: index,
+		FeePayer:         accountKeys[0],
+		Success:          true,
+		Version:          "legacy",
+		RecentBlockHash:  tx.Transaction.Message.RecentBlockhash,
+		Signatures:       tx.Transaction.Signatures,
+		AccountKeys:      accountKeys,
+		IndexedAt:        uint64(time.Now().Unix()),
+	}
+	if len(tx.Version) > 0 {
+		solanaTransaction.Version = strings.Trim(string(tx.Version), `"`)
+	}
+
+	for _, instruction := range tx.Transaction.Message.Instructions {
+		solanaInstruction, err := toProtoInstruction(instruction)
+		if err != nil {
+			return nil, err
+		}
+		solanaInstruction.StackHeight = 1
+		solanaTransaction.Instructions = append(solanaTransaction.Instructions, solanaInstruction)
+	}
+
+	if tx.Meta != nil {
+		solanaTransaction.Fee = tx.Meta.Fee
+		if len(tx.Meta.Err) > 0 && string(tx.Meta.Err) != "null" {
+			solanaTransaction.Success = false
+			solanaTransaction.Error = string(tx.Meta.Err)
+		}
+		solanaTransaction.LogMessages = tx.Meta.LogMessages
+		if tx.Meta.ComputeUnitsConsumed != nil {
+			solanaTransaction.ComputeUnitsConsumed = *tx.Meta.ComputeUnitsConsumed
+		}
+
+		for _, inner := range tx.Meta.InnerInstructions {
+			if inner.Index < 0 || inner.Index >= len(solanaTransaction.Instructions) {
+				return nil, fmt.Errorf("inner instructions of missing instruction %d", inner.Index)
+			}
+			outer := solanaTransaction.Instructions[inner.Index]
+			for _, instruction := range inner.Instructions {
+				solanaInstruction, err := toProtoInstruction(instruction)
+				if err != nil {
+					return nil, err
+				}
+				outer.InnerInstructions = append(outer.InnerInstructions, solanaInstruction)
+			}
+		}
+	}
+
+	return solanaTransaction, nil
+}
+
+// FetchAsProtoBlocksWithEvents fetches blocks of slots in range, skipped slots are omitted.
+// Logs of programs are part of transactions, there is no separate request for events.
+func (c *Client) FetchAsProtoBlocksWithEvents(from, to *big.Int, debug bool, maxRequests int) ([]proto.Message, []indexer.BlockIndex, uint64, error) {
+	if maxRequests < 1 {
+		maxRequests = 1
+	}
+
+	var blocks []*SolanaBlock
+	var blocksMu sync.Mutex
+	var fetchErr error
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, maxRequests)
+	for slot := from.Uint64(); slot <= to.Uint64(); slot++ {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(slot uint64) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			block, err := c.GetBlock(context.Background(), slot)
+
+			blocksMu.Lock()
+			defer blocksMu.Unlock()
+			if err != nil {
+				if fetchErr == nil {
+					fetchErr = err
+				}
+				return
+			}
+			if block == nil {
+				if debug {
+					log.Printf("Slot %d was skipped", slot)
+				}
+				return
+			}
+			blocks = append(blocks, block)
+		}(slot)
+	}
+	wg.Wait()
+
+	if fetchErr != nil {
+		return nil, nil, 0, fetchErr
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].Slot < blocks[j].Slot
+	})
+
+	if err := verifySlotsContinuity(blocks); err != nil {
+		return nil, nil, 0, err
+	}
+
+	var blocksSize uint64
+	var blocksProto []proto.Message
+	var blocksIndex []indexer.BlockIndex
+
+	for bI, block := range blocks {
+		blockIndex := indexer.NewBlockIndex("solana",
+			block.Slot,
+			block.BlockHash,
+			block.BlockTime,
+			block.PreviousBlockHash,
+			uint64(bI),
+			"",
+			0,
+		)
+		blocksIndex = append(blocksIndex, blockIndex)
+
+		blocksSize += uint64(proto.Size(block))
+		blocksProto = append(blocksProto, block)
+	}
+
+	if debug {
+		log.Printf("Fetched %d blocks of slots %d-%d", len(blocks), from.Uint64(), to.Uint64())
+	}
+
+	return blocksProto, blocksIndex, blocksSize, nil
+}
+
+// verifySlotsContinuity checks that every block which parent slot is in range refers to hash of
+// block fetched for it. Slots of parents between them were skipped.
+func verifySlotsContinuity(blocks []*SolanaBlock) error {
+	hashes := make(map[uint64]string, len(blocks))
+	for _, block := range blocks {
+		hashes[block.Slot] = block.BlockHash
+	}
+
+	for _, block := range blocks {
+		parentHash, exists := hashes[block.ParentSlot]
+		if exists && parentHash != block.PreviousBlockHash {
+			return &seer_common.ReorgDetected{
+				Height:            block.Slot,
+				ParentHash:        block.PreviousBlockHash,
+				PreviousBlockHash: parentHash,
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) ProcessBlocksToBatch(msgs []proto.Message) (proto.Message, error) {
+	var blocks []*SolanaBlock
+	for _, msg := range msgs {
+		block, ok := msg.(*SolanaBlock)
+		if !ok {
+			return nil, fmt.Errorf("failed to type assert proto.Message to *SolanaBlock")
+		}
+		blocks = append(blocks, block)
+	}
+
+	return &SolanaBlocksBatch{
+		Blocks:      blocks,
+		SeerVersion: version.SeerVersion,
+	}, nil
+}
+
+// ToEntireBlocksBatchFromLogProto converts batch to JSON of EVM shape: transaction is sent by
+// fee payer, program logs are events of programs which emitted them.
+func ToEntireBlocksBatchFromLogProto(obj *SolanaBlocksBatch) *seer_common.BlocksBatchJson {
+	blocksBatchJson := seer_common.BlocksBatchJson{
+		Blocks:      []seer_common.BlockJson{},
+		SeerVersion: obj.SeerVersion,
+	}
+
+	for _, b := range obj.Blocks {
+		var txs []seer_common.TransactionJson
+		for _, tx := range b.Transactions {
+			var events []seer_common.EventJson
+			if tx.Success {
+				for logIndex, programLog := range ParseProgramLogs(tx.LogMessages) {
+					var topics []string
+					if selector, ok := discriminatorSelector(programLog.Data); ok {
+						topics = []string{selector}
+					}
+					events = append(events, seer_common.EventJson{
+						Address:          programLog.ProgramID,
+						Topics:           topics,
+						Data:             "0x" + hex.EncodeToString(programLog.Data),
+						BlockNumber:      fmt.Sprintf("%d", tx.Slot),
+						TransactionHash:  tx.Signature,
+						BlockHash:        tx.BlockHash,
+						LogIndex:         fmt.Sprintf("%d", logIndex),
+						TransactionIndex: fmt.Sprintf("%d", tx.TransactionIndex),
+					})
+				}
+			}
+
+			var toAddress string
+			if len(tx.Instructions) > 0 {
+				toAddress = tx.Instructions[0].ProgramId
+			}
+
+			txs = append(txs, seer_common.TransactionJson{
+				BlockHash:        tx.BlockHash,
+				BlockNumber:      fmt.Sprintf("%d", tx.Slot),
+				FromAddress:      tx.FeePayer,
+				GasPrice:         fmt.Sprintf("%d", tx.Fee),
+				Gas:              fmt.Sprintf("%d", tx.ComputeUnitsConsumed),
+				Hash:             tx.Signature,
+				ToAddress:        toAddress,
+				TransactionIndex: fmt.Sprintf("%d", tx.TransactionIndex),
+				TransactionType:  tx.Version,
+				IndexedAt:        fmt.Sprintf("%d", tx.IndexedAt),
+				BlockTimestamp:   fmt.Sprintf("%d", tx.BlockTimestamp),
+				Events:           events,
+			})
+		}
+
+		blocksBatchJson.Blocks = append(blocksBatchJson.Blocks, seer_common.BlockJson{
+			Hash:         b.BlockHash,
+			BlockNumber:  fmt.Sprintf("%d", b.Slot),
+			ParentHash:   b.PreviousBlockHash,
+			Timestamp:    fmt.Sprintf("%d", b.BlockTime),
+			IndexedAt:    fmt.Sprintf("%d", b.IndexedAt),
+			Transactions: txs,
+		})
+	}
+
+	return &blocksBatchJson
+}
+
+func (c *Client) DecodeProtoEntireBlockToJson(rawData *bytes.Buffer) (*seer_common.BlocksBatchJson, error) {
+	var protoBlocksBatch SolanaBlocksBatch
+
+	err := proto.Unmarshal(rawData.Bytes(), &protoBlocksBatch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal data: %v", err)
+	}
+
+	return ToEntireBlocksBatchFromLogProto(&protoBlocksBatch), nil
+}
+
+// DecodeProtoEntireBlockToLabels labels instructions and events of programs with jobs. Solana
+// has no raw transactions of EVM shape, addRawTransactions is ignored.
+func (c *Client) DecodeProtoEntireBlockToLabels(rawData *bytes.Buffer, abiMap map[string]map[string]*indexer.AbiEntry, addRawTransactions bool, threads int) ([]indexer.EventLabel, []indexer.TransactionLabel, []indexer.RawTransaction, error) {
+	var protoBlocksBatch SolanaBlocksBatch
+
+	err := proto.Unmarshal(rawData.Bytes(), &protoBlocksBatch)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to unmarshal data: %v", err)
+	}
+
+	if threads < 1 {
+		threads = 1
+	}
+
+	var labels []indexer.EventLabel
+	var txLabels []indexer.TransactionLabel
+	var labelsMutex sync.Mutex
+
+	var wg sync.WaitGroup
+	semaphoreChan := make(chan struct{}, threads)
+	errorChan := make(chan error, len(protoBlocksBatch.Blocks))
+
+	for _, b := range protoBlocksBatch.Blocks {
+		wg.Add(1)
+		semaphoreChan <- struct{}{}
+		go func(b *SolanaBlock) {
+			defer wg.Done()
+			defer func() { <-semaphoreChan }()
+
+			var localEventLabels []indexer.EventLabel
+			var localTxLabels []indexer.TransactionLabel
+			for _, tx := range b.Transactions {
+				eventLabels, transactionLabels, err := labelTransaction(tx, abiMap)
+				if err != nil {
+					errorChan <- fmt.Errorf("error labeling transaction %s of slot %d: %v", tx.Signature, b.Slot, err)
+					return
+				}
+				localEventLabels = append(localEventLabels, eventLabels...)
+				localTxLabels = append(localTxLabels, transactionLabels...)
+			}
+
+			labelsMutex.Lock()
+			labels = append(labels, localEventLabels...)
+			txLabels = append(txLabels, localTxLabels...)
+			labelsMutex.Unlock()
+		}(b)
+	}
+	wg.Wait()
+	close(errorChan)
+
+	var errorMessages []string
+	for err := range errorChan {
+		errorMessages = append(errorMessages, err.Error())
+	}
+	if len(errorMessages) > 0 {
+		return nil, nil, nil, fmt.Errorf("errors occurred during processing:\n%s", strings.Join(errorMessages, "\n"))
+	}
+
+	return labels, txLabels, nil, nil
+}
+
+// DecodeProtoTransactionsToLabels labels instructions of base64 encoded proto transactions.
+func (c *Client) DecodeProtoTransactionsToLabels(transactions []string, blocksCache map[uint64]uint64, abiMap map[string]map[string]*indexer.AbiEntry) ([]indexer.TransactionLabel, error) {
+	var labels []indexer.TransactionLabel
+	for _, data := range transactions {
+		dataBytes, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode base64 data: %v", err)
+		}
+
+		var transaction SolanaTransaction
+		if err := proto.Unmarshal(dataBytes, &transaction); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal data: %v", err)
+		}
+		if timestamp, exists := blocksCache[transaction.Slot]; exists {
+			transaction.BlockTimestamp = timestamp
+		}
+
+		_, transactionLabels, err := labelTransaction(&transaction, abiMap)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, transactionLabels...)
+	}
+
+	return labels, nil
+}
+
+// labelTransaction decodes instructions of programs with jobs to tx_call labels and data logged
+// by them to event labels. Anchor events emitted with emit_cpi! are instructions of program to
+// itself, they are labeled as events. Failed transactions have no events, their instructions
+// are labeled with status 0.
+func labelTransaction(tx *SolanaTransaction, abiMap map[string]map[string]*indexer.AbiEntry) ([]indexer.EventLabel, []indexer.TransactionLabel, error) {
+	feePayer, err := PublicKeyToHex(tx.FeePayer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status := 0
+	if tx.Success {
+		status = 1
+	}
+
+	var eventLabels []indexer.EventLabel
+	var txLabels []indexer.TransactionLabel
+	var logIndex uint64
+
+	newEventLabel := func(program, caller string, entry *indexer.AbiEntry, selector string, data []byte) error {
+		label := indexer.SeerCrawlerLabel
+		var labelData map[string]interface{}
+		programEntry, parseErr := cachedProgramEntry(entry.AbiJSON)
+		if parseErr == nil {
+			labelData, parseErr = DecodeEventToLabelData(programEntry, data)
+		}
+		if parseErr != nil {
+			labelData = map[string]interface{}{
+				"input_raw": "0x" + hex.EncodeToString(data),
+				"abi":       entry.AbiJSON,
+				"selector":  selector,
+				"error":     parseErr.Error(),
+			}
+			label = indexer.SeerCrawlerRawLabel
+		}
+
+		labelDataBytes, err := json.Marshal(labelData)
+		if err != nil {
+			return err
+		}
+
+		eventLabels = append(eventLabels, indexer.EventLabel{
+			Label:           label,
+			LabelName:       entry.AbiName,
+			LabelType:       "event",
+			BlockNumber:     tx.Slot,
+			BlockHash:       tx.BlockHash,
+			Address:         program,
+			CallerAddress:   caller,
+			OriginAddress:   feePayer,
+			TransactionHash: tx.Signature,
+			LabelData:       string(labelDataBytes),
+			BlockTimestamp:  tx.BlockTimestamp,
+			LogIndex:        logIndex,
+		})
+		logIndex++
+		return nil
+	}
+
+	if tx.Success {
+		for _, programLog := range ParseProgramLogs(tx.LogMessages) {
+			program, err := PublicKeyToHex(programLog.ProgramID)
+			if err != nil {
+				return nil, nil, err
+			}
+			selector, ok := discriminatorSelector(programLog.Data)
+			if !ok || abiMap[program] == nil || abiMap[program][selector] == nil {
+				continue
+			}
+
+			caller := feePayer
+			if programLog.CallerProgramID != "" {
+				if caller, err = PublicKeyToHex(programLog.CallerProgramID); err != nil {
+					return nil, nil, err
+				}
+			}
+
+			if err := newEventLabel(program, caller, abiMap[program][selector], selector, programLog.Data); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	for _, outer := range tx.Instructions {
+		// Programs invoking instructions by stack height, top-level instructions are invoked
+		// by fee payer
+		callers := map[uint32]string{1: feePayer}
+		instructions := append([]*SolanaInstruction{outer}, outer.InnerInstructions...)
+		for _, instruction := range instructions {
+			program, err := PublicKeyToHex(instruction.ProgramId)
+			if err != nil {
+				return nil, nil, err
+			}
+			caller, exists := callers[instruction.StackHeight]
+			if !exists {
+				caller = feePayer
+			}
+			callers[instruction.StackHeight+1] = program
+
+			if abiMap[program] == nil {
+				continue
+			}
+
+			data, err := Base58Decode(instruction.Data)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid data of instruction of program %s: %w", instruction.ProgramId, err)
+			}
+
+			if bytes.HasPrefix(data, eventInstructionTag) {
+				if !tx.Success {
+					continue
+				}
+				selector, ok := discriminatorSelector(data[len(eventInstructionTag):])
+				if ok && abiMap[program][selector] != nil {
+					if err := newEventLabel(program, caller, abiMap[program][selector], selector, data[len(eventInstructionTag):]); err != nil {
+						return nil, nil, err
+					}
+				}
+				continue
+			}
+
+			selector, ok := discriminatorSelector(data)
+			if !ok || abiMap[program][selector] == nil {
+				continue
+			}
+			entry := abiMap[program][selector]
+
+			label := indexer.SeerCrawlerLabel
+			var labelData map[string]interface{}
+			programEntry, parseErr := cachedProgramEntry(entry.AbiJSON)
+			if parseErr == nil {
+				labelData, parseErr = DecodeInstructionToLabelData(programEntry, data, instruction.Accounts)
+			}
+			if parseErr != nil {
+				labelData = map[string]interface{}{
+					"input_raw": "0x" + hex.EncodeToString(data),
+					"abi":       entry.AbiJSON,
+					"selector":  selector,
+					"error":     parseErr.Error(),
+				}
+				label = indexer.SeerCrawlerRawLabel
+			}
+			labelData["status"] = status
+
+			labelDataBytes, err := json.Marshal(labelData)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			txLabels = append(txLabels, indexer.TransactionLabel{
+				Address:         program,
+				BlockNumber:     tx.Slot,
+				BlockHash:       tx.BlockHash,
+				CallerAddress:   caller,
+				LabelName:       entry.AbiName,
+				LabelType:       "tx_call",
+				OriginAddress:   feePayer,
+				Label:           label,
+				TransactionHash: tx.Signature,
+				LabelData:       string(labelDataBytes),
+				BlockTimestamp:  tx.BlockTimestamp,
+			})
+		}
+	}
+
+	return eventLabels, txLabels, nil
+}
+
+// EVM specific methods of ChainClient
+
+func (c *Client) GetCode(ctx context.Context, address common.Address, blockNumber uint64) ([]byte, error) {
+	return nil, fmt.Errorf("GetCode is %w", errNotSupported)
+}
+
+func (c *Client) CallContract(ctx context.Context, address common.Address, data []byte, blockNumber uint64) ([]byte, error) {
+	return nil, fmt.Errorf("CallContract is %w", errNotSupported)
+}
+
+func (c *Client) FindDeploymentBlock(ctx context.Context, address common.Address) (uint64, error) {
+	return 0, fmt.Errorf("FindDeploymentBlock is %w", errNotSupported)
+}
+
+// GetTransactionsLabels is not supported, labels of Solana are decoded only from crawled batches.
+func (c *Client) GetTransactionsLabels(startBlock uint64, endBlock uint64, abiMap map[string]map[string]*indexer.AbiEntry, threads int) ([]indexer.TransactionLabel, map[uint64]seer_common.BlockWithTransactions, error) {
+	return nil, nil, fmt.Errorf("GetTransactionsLabels is %w", errNotSupported)
+}
+
+// GetEventsLabels is not supported, labels of Solana are decoded only from crawled batches.
+func (c *Client) GetEventsLabels(startBlock uint64, endBlock uint64, abiMap map[string]map[string]*indexer.AbiEntry, blocksCache map[uint64]seer_common.BlockWithTransactions) ([]indexer.EventLabel, error) {
+	return nil, fmt.Errorf("GetEventsLabels is %w", errNotSupported)
+}
@@ -0,0 +1,463 @@
+package starknet
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	seer_starknet "github.com/G7DAO/seer/starknet"
+)
+
+// Prime of Starknet field, felts above half of it are negative values of signed integers
+var fieldPrime, _ = new(big.Int).SetString("800000000000011000000000000000000000000000000000000000000000001", 16)
+
+var errUnsupportedType = errors.New("unsupported type")
+
+// NormalizeFelt returns felt as 0x prefixed lower case hex string of 32 bytes, the form of
+// addresses and selectors of jobs.
+func NormalizeFelt(value string) (string, error) {
+	felt, err := parseFelt(value)
+	if err != nil {
+		return "", err
+	}
+	return feltToHex(felt), nil
+}
+
+func parseFelt(value string) (*big.Int, error) {
+	felt, ok := new(big.Int).SetString(strings.TrimPrefix(strings.ToLower(value), "0x"), 16)
+	if !ok || felt.Sign() < 0 || felt.Cmp(fieldPrime) >= 0 {
+		return nil, fmt.Errorf("invalid felt %q", value)
+	}
+	return felt, nil
+}
+
+func feltToHex(felt *big.Int) string {
+	return fmt.Sprintf("0x%064x", felt)
+}
+
+// SelectorFromName returns selector of event or entry point with name, only the last
+// component of qualified event name is hashed.
+func SelectorFromName(name string) (string, error) {
+	hash, err := seer_starknet.HashFromName(name)
+	if err != nil {
+		return "", err
+	}
+	return NormalizeFelt(hash)
+}
+
+// ContractEntry is an event or function of job. Abi of job holds ABI item of entry, or whole
+// contract ABI as a list, then entry is found by name of job and types used by entry are
+// decoded with structs and enums of that ABI.
+type ContractEntry struct {
+	Name     string
+	Selector string
+	Event    *seer_starknet.EventStruct
+	Function *seer_starknet.Function
+
+	structs map[string]*seer_starknet.Struct
+	enums   map[string]*seer_starknet.Enum
+}
+
+// ParseContractEntry parses ABI of job, name is used to choose entry of ABI with many items.
+func ParseContractEntry(abiJSON, name string) (*ContractEntry, error) {
+	rawABI := []byte(strings.TrimSpace(abiJSON))
+	if len(rawABI) > 0 && rawABI[0] == '{' {
+		rawABI = append(append([]byte("["), rawABI...), ']')
+	}
+
+	parsedABI, err := seer_starknet.ParseABI(rawABI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+
+	entry := &ContractEntry{
+		structs: make(map[string]*seer_starknet.Struct),
+		enums:   make(map[string]*seer_starknet.Enum),
+	}
+	for _, structItem := range parsedABI.Structs {
+		entry.structs[structItem.Name] = structItem
+	}
+	for _, enumItem := range parsedABI.Enums {
+		entry.enums[enumItem.Name] = enumItem
+	}
+
+	matches := func(itemName string) bool {
+		components := strings.Split(itemName, "::")
+		return name == "" || itemName == name || components[len(components)-1] == name
+	}
+
+	for _, event := range parsedABI.Events {
+		if matches(event.Name) {
+			entry.Event = event
+			entry.Name = event.Name
+			break
+		}
+	}
+	if entry.Event == nil {
+		for _, function := range parsedABI.Functions {
+			if matches(function.Name) {
+				entry.Function = function
+				entry.Name = function.Name
+				break
+			}
+		}
+	}
+	if entry.Event == nil && entry.Function == nil {
+		return nil, fmt.Errorf("no event or function %q in ABI", name)
+	}
+
+	entry.Selector, err = SelectorFromName(entry.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// Parsed entries are shared by jobs with the same ABI
+var contractEntries sync.Map
+
+func cachedContractEntry(abiJSON, name string) (*ContractEntry, error) {
+	key := name + "\x00" + abiJSON
+	if cached, ok := contractEntries.Load(key); ok {
+		return cached.(*ContractEntry), nil
+	}
+	entry, err := ParseContractEntry(abiJSON, name)
+	if err != nil {
+		return nil, err
+	}
+	contractEntries.Store(key, entry)
+	return entry, nil
+}
+
+// DecodeEventToLabelData decodes members of event, key members are read from keys after
+// selector and data members from data.
+func DecodeEventToLabelData(entry *ContractEntry, keys, data []string) (map[string]interface{}, error) {
+	if entry.Event == nil {
+		return nil, fmt.Errorf("%s is not an event", entry.Name)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("event has no keys")
+	}
+
+	keysReader, err := newFeltReader(keys[1:])
+	if err != nil {
+		return nil, err
+	}
+	dataReader, err := newFeltReader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make(map[string]interface{})
+	for _, member := range entry.Event.Members {
+		var reader *feltReader
+		switch member.Kind {
+		case "key":
+			reader = keysReader
+		case "data":
+			reader = dataReader
+		default:
+			return nil, fmt.Errorf("member %s of kind %q: %w", member.Name, member.Kind, errUnsupportedType)
+		}
+
+		value, err := entry.decode(reader, member.Type)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode member %s of event %s: %w", member.Name, entry.Name, err)
+		}
+		args[member.Name] = value
+	}
+
+	return map[string]interface{}{
+		"type": "event",
+		"name": entry.Name,
+		"args": args,
+	}, nil
+}
+
+// DecodeCallToLabelData decodes calldata of call to function of contract.
+func DecodeCallToLabelData(entry *ContractEntry, calldata []string) (map[string]interface{}, error) {
+	if entry.Function == nil {
+		return nil, fmt.Errorf("%s is not a function", entry.Name)
+	}
+
+	reader, err := newFeltReader(calldata)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make(map[string]interface{})
+	for _, input := range entry.Function.Inputs {
+		value, err := entry.decode(reader, input.Type)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode input %s of function %s: %w", input.Name, entry.Name, err)
+		}
+		args[input.Name] = value
+	}
+
+	return map[string]interface{}{
+		"type": "tx_call",
+		"name": entry.Name,
+		"args": args,
+	}, nil
+}
+
+type feltReader struct {
+	felts []*big.Int
+	pos   int
+}
+
+func newFeltReader(values []string) (*feltReader, error) {
+	felts := make([]*big.Int, len(values))
+	for i, value := range values {
+		felt, err := parseFelt(value)
+		if err != nil {
+			return nil, err
+		}
+		felts[i] = felt
+	}
+	return &feltReader{felts: felts}, nil
+}
+
+func (r *feltReader) next() (*big.Int, error) {
+	if r.pos >= len(r.felts) {
+		return nil, errors.New("not enough felts")
+	}
+	felt := r.felts[r.pos]
+	r.pos++
+	return felt, nil
+}
+
+// splitTuple splits types of tuple "(A, B)" at top level commas.
+func splitTuple(typeName string) []string {
+	inner := strings.TrimSpace(typeName[1 : len(typeName)-1])
+	if inner == "" {
+		return nil
+	}
+
+	var types []string
+	depth, start := 0, 0
+	for i, c := range inner {
+		switch c {
+		case '(', '<':
+			depth++
+		case ')', '>':
+			depth--
+		case ',':
+			if depth == 0 {
+				types = append(types, strings.TrimSpace(inner[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(types, strings.TrimSpace(inner[start:]))
+}
+
+func genericArgument(typeName, prefix string) (string, bool) {
+	if !strings.HasPrefix(typeName, prefix+"<") || !strings.HasSuffix(typeName, ">") {
+		return "", false
+	}
+	return typeName[len(prefix)+1 : len(typeName)-1], true
+}
+
+// decode reads value of Cairo type serialized to felts. Integers wider than 32 bits are
+// returned as decimal strings, felts, addresses and hashes as hex strings.
+func (e *ContractEntry) decode(r *feltReader, typeName string) (interface{}, error) {
+	typeName = strings.TrimPrefix(strings.TrimSpace(typeName), "@")
+
+	if strings.HasPrefix(typeName, "(") && strings.HasSuffix(typeName, ")") {
+		types := splitTuple(typeName)
+		values := make([]interface{}, 0, len(types))
+		for _, elementType := range types {
+			value, err := e.decode(r, elementType)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return values, nil
+	}
+
+	// Structs and enums defined by ABI, including generic ones as Option::<u32>
+	if structItem, exists := e.structs[typeName]; exists && typeName != "core::integer::u256" {
+		value := make(map[string]interface{}, len(structItem.Members))
+		for _, member := range structItem.Members {
+			memberValue, err := e.decode(r, member.Type)
+			if err != nil {
+				return nil, err
+			}
+			value[member.Name] = memberValue
+		}
+		return value, nil
+	}
+	if enumItem, exists := e.enums[typeName]; exists && typeName != "core::bool" {
+		index, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		if !index.IsInt64() || index.Int64() >= int64(len(enumItem.Variants)) {
+			return nil, fmt.Errorf("variant %s of enum %s does not exist", index, typeName)
+		}
+		variant := enumItem.Variants[index.Int64()]
+		if variant.Type == "" || variant.Type == "()" {
+			return variant.Name, nil
+		}
+		value, err := e.decode(r, variant.Type)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{variant.Name: value}, nil
+	}
+
+	if elementType, ok := genericArgument(typeName, "core::array::Array::"); ok {
+		return e.decodeArray(r, elementType)
+	}
+	if elementType, ok := genericArgument(typeName, "core::array::Span::"); ok {
+		return e.decodeArray(r, elementType)
+	}
+	if valueType, ok := genericArgument(typeName, "core::zeroable::NonZero::"); ok {
+		return e.decode(r, valueType)
+	}
+	if valueType, ok := genericArgument(typeName, "core::option::Option::"); ok {
+		index, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		if index.Sign() != 0 {
+			return nil, nil
+		}
+		return e.decode(r, valueType)
+	}
+
+	switch typeName {
+	case "core::bool":
+		felt, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		return felt.Sign() != 0, nil
+	case "core::integer::u8", "core::integer::u16", "core::integer::u32":
+		felt, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		return felt.Uint64(), nil
+	case "core::integer::u64", "core::integer::u128", "core::integer::usize":
+		felt, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		return felt.String(), nil
+	case "core::integer::i8", "core::integer::i16", "core::integer::i32", "core::integer::i64", "core::integer::i128":
+		felt, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		value := new(big.Int).Set(felt)
+		if value.Cmp(new(big.Int).Rsh(fieldPrime, 1)) > 0 {
+			value.Sub(value, fieldPrime)
+		}
+		return value.String(), nil
+	case "core::integer::u256":
+		low, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		high, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).Add(new(big.Int).Lsh(high, 128), low).String(), nil
+	case "core::starknet::contract_address::ContractAddress", "core::starknet::class_hash::ClassHash":
+		felt, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		return feltToHex(felt), nil
+	case "core::starknet::eth_address::EthAddress":
+		felt, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		return fmt.Sprintf("0x%040x", felt), nil
+	case "core::felt252", "core::bytes_31::bytes31":
+		felt, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		return fmt.Sprintf("0x%x", felt), nil
+	case "core::byte_array::ByteArray":
+		return e.decodeByteArray(r)
+	}
+
+	return nil, fmt.Errorf("%s: %w", typeName, errUnsupportedType)
+}
+
+func (e *ContractEntry) decodeArray(r *feltReader, elementType string) (interface{}, error) {
+	length, err := r.next()
+	if err != nil {
+		return nil, err
+	}
+	if !length.IsInt64() || length.Int64() > int64(len(r.felts)-r.pos) && elementType != "()" {
+		return nil, fmt.Errorf("array length %s exceeds data", length)
+	}
+
+	values := make([]interface{}, 0, length.Int64())
+	for i := int64(0); i < length.Int64(); i++ {
+		value, err := e.decode(r, elementType)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// decodeByteArray reads full 31 bytes words followed by pending word and its length.
+func (e *ContractEntry) decodeByteArray(r *feltReader) (interface{}, error) {
+	wordsCount, err := r.next()
+	if err != nil {
+		return nil, err
+	}
+	if !wordsCount.IsInt64() || wordsCount.Int64() > int64(len(r.felts)-r.pos) {
+		return nil, fmt.Errorf("byte array length %s exceeds data", wordsCount)
+	}
+
+	var data []byte
+	for i := int64(0); i < wordsCount.Int64(); i++ {
+		word, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		if word.BitLen() > 31*8 {
+			return nil, fmt.Errorf("byte array word %x is longer than 31 bytes", word)
+		}
+		data = append(data, word.FillBytes(make([]byte, 31))...)
+	}
+
+	pendingWord, err := r.next()
+	if err != nil {
+		return nil, err
+	}
+	pendingLength, err := r.next()
+	if err != nil {
+		return nil, err
+	}
+	if !pendingLength.IsInt64() || pendingLength.Int64() > 30 || pendingWord.BitLen() > int(pendingLength.Int64())*8 {
+		return nil, fmt.Errorf("invalid pending word of length %s", pendingLength)
+	}
+	if pendingLength.Int64() > 0 {
+		data = append(data, pendingWord.FillBytes(make([]byte, pendingLength.Int64()))...)
+	}
+
+	if utf8.Valid(data) {
+		return string(data), nil
+	}
+	return fmt.Sprintf("0x%x", data), nil
+}
@@ -0,0 +1,918 @@
+package starknet
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/protobuf/proto"
+
+	seer_common "github.com/G7DAO/seer/blockchain/common"
+	"github.com/G7DAO/seer/indexer"
+	"github.com/G7DAO/seer/version"
+)
+
+// Starknet client is written by hand, it is not generated from blockchain templates as clients
+// of EVM chains. Addresses, hashes and selectors are felts kept as hex strings of 32 bytes.
+
+func init() {
+	for _, chain := range []string{"starknet", "starknet_sepolia"} {
+		chain := chain
+		seer_common.RegisterClient(chain, func(url string, timeout int) (seer_common.ChainClient, error) {
+			client, err := NewClient(chain, url, timeout)
+			if err != nil {
+				return nil, err
+			}
+			return client, nil
+		})
+	}
+}
+
+var _ seer_common.ChainClient = (*Client)(nil)
+
+// Maximum number of events returned by one starknet_getEvents request
+var EventsChunkSize = 1000
+
+var errNotSupported = errors.New("not supported by starknet client")
+
+// NewClient connects to RPC endpoints, url could be comma separated list of endpoints
+// to balance calls between them, see seer_common.ParseRPCEndpoints.
+func NewClient(chain, url string, timeout int) (*Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	rpcClient, err := seer_common.DialRPCPool(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	rpcClient.SetHeadMethod("starknet_blockNumber")
+
+	return &Client{
+		chain:     chain,
+		rpcClient: rpcClient,
+		timeout:   time.Duration(timeout) * time.Second,
+	}, nil
+}
+
+// Client is a wrapper around the Starknet JSON-RPC client.
+type Client struct {
+	chain     string
+	rpcClient *seer_common.RPCPool
+	timeout   time.Duration
+}
+
+// ChainType returns the chain type.
+func (c *Client) ChainType() string {
+	return c.chain
+}
+
+// SetRateLimit limits rate of requests to RPC endpoints.
+func (c *Client) SetRateLimit(config seer_common.RateLimitConfig) {
+	c.rpcClient.SetRateLimit(config)
+}
+
+// SetRetryPolicy sets how requests failed with transient errors are retried.
+func (c *Client) SetRetryPolicy(policy seer_common.RetryPolicy) {
+	c.rpcClient.SetRetryPolicy(policy)
+}
+
+// SetCrawlSchedule holds RPC requests of client inside of historical crawl windows.
+func (c *Client) SetCrawlSchedule(schedule *seer_common.CrawlSchedule) {
+	c.rpcClient.SetCrawlSchedule(schedule)
+}
+
+// Close closes the underlying RPC client.
+func (c *Client) Close() {
+	c.rpcClient.Close()
+}
+
+// GetLatestBlockNumber returns number of the latest accepted block.
+func (c *Client) GetLatestBlockNumber() (*big.Int, error) {
+	var blockNumber uint64
+
+	ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if err := c.rpcClient.CallContext(ctxWithTimeout, &blockNumber, "starknet_blockNumber"); err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetUint64(blockNumber), nil
+}
+
+type rpcBlockID struct {
+	BlockNumber uint64 `json:"block_number"`
+}
+
+type rpcTransaction struct {
+	TransactionHash     string   `json:"transaction_hash"`
+	Type                string   `json:"type"`
+	Version             string   `json:"version"`
+	SenderAddress       string   `json:"sender_address"`
+	ContractAddress     string   `json:"contract_address"`
+	EntryPointSelector  string   `json:"entry_point_selector"`
+	Calldata            []string `json:"calldata"`
+	Signature           []string `json:"signature"`
+	Nonce               string   `json:"nonce"`
+	MaxFee              string   `json:"max_fee"`
+	ClassHash           string   `json:"class_hash"`
+	ContractAddressSalt string   `json:"contract_address_salt"`
+	ConstructorCalldata []string `json:"constructor_calldata"`
+}
+
+type rpcBlock struct {
+	Status           string `json:"status"`
+	BlockHash        string `json:"block_hash"`
+	ParentHash       string `json:"parent_hash"`
+	BlockNumber      uint64 `json:"block_number"`
+	NewRoot          string `json:"new_root"`
+	Timestamp        uint64 `json:"timestamp"`
+	SequencerAddress string `json:"sequencer_address"`
+	StarknetVersion  string `json:"starknet_version"`
+	L1GasPrice       struct {
+		PriceInFri string `json:"price_in_fri"`
+		PriceInWei string `json:"price_in_wei"`
+	} `json:"l1_gas_price"`
+	Transactions []rpcTransaction `json:"transactions"`
+}
+
+type rpcEvent struct {
+	FromAddress     string   `json:"from_address"`
+	Keys            []string `json:"keys"`
+	Data            []string `json:"data"`
+	BlockHash       string   `json:"block_hash"`
+	BlockNumber     uint64   `json:"block_number"`
+	TransactionHash string   `json:"transaction_hash"`
+}
+
+type rpcEventsChunk struct {
+	Events            []rpcEvent `json:"events"`
+	ContinuationToken string     `json:"continuation_token"`
+}
+
+// normalizeFelts normalizes felts of list, empty lists stay nil.
+func normalizeFelts(values []string) ([]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	normalized := make([]string, len(values))
+	for i, value := range values {
+		var err error
+		if normalized[i], err = NormalizeFelt(value); err != nil {
+			return nil, err
+		}
+	}
+	return normalized, nil
+}
+
+// normalizeOptionalFelt normalizes felt which is absent in some transaction types.
+func normalizeOptionalFelt(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	return NormalizeFelt(value)
+}
+
+// GetBlock returns accepted block with its transactions, events are not included.
+func (c *Client) GetBlock(ctx context.Context, blockNumber uint64) (*StarknetBlock, error) {
+	var block *rpcBlock
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if err := c.rpcClient.CallContext(ctxWithTimeout, &block, "starknet_getBlockWithTxs", rpcBlockID{BlockNumber: blockNumber}); err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", blockNumber, err)
+	}
+	if block == nil || block.BlockHash == "" {
+		return nil, fmt.Errorf("block %d is not accepted yet", blockNumber)
+	}
+
+	return toProtoBlock(block)
+}
+
+func toProtoBlock(block *rpcBlock) (*StarknetBlock, error) {
+	blockHash, err := NormalizeFelt(block.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	parentHash, err := NormalizeFelt(block.ParentHash)
+	if err != nil {
+		return nil, err
+	}
+	sequencerAddress, err := normalizeOptionalFelt(block.SequencerAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	starknetBlock := &StarknetBlock{
+		BlockNumber:      block.BlockNumber,
+		BlockHash:        blockHash,
+		ParentHash:       parentHash,
+		Timestamp:        block.Timestamp,
+		SequencerAddress: sequencerAddress,
+		NewRoot:          block.NewRoot,
+		Status:           block.Status,
+		StarknetVersion:  block.StarknetVersion,
+		L1GasPriceWei:    block.L1GasPrice.PriceInWei,
+		L1GasPriceFri:    block.L1GasPrice.PriceInFri,
+		IndexedAt:        uint64(time.Now().Unix()),
+	}
+
+	for i, tx := range block.Transactions {
+		transaction := &StarknetTransaction{
+			BlockNumber:      block.BlockNumber,
+			BlockHash:        blockHash,
+			BlockTimestamp:   block.Timestamp,
+			TransactionIndex: uint64(i),
+			Type:             tx.Type,
+			Version:          tx.Version,
+			Nonce:            tx.Nonce,
+			MaxFee:           tx.MaxFee,
+			Signature:        tx.Signature,
+			IndexedAt:        starknetBlock.IndexedAt,
+		}
+
+		fields := []struct {
+			value  string
+			target *string
+		}{
+			{tx.TransactionHash, &transaction.Hash},
+			{tx.SenderAddress, &transaction.SenderAddress},
+			{tx.ContractAddress, &transaction.ContractAddress},
+			{tx.EntryPointSelector, &transaction.EntryPointSelector},
+			{tx.ClassHash, &transaction.ClassHash},
+		}
+		for _, field := range fields {
+			if *field.target, err = normalizeOptionalFelt(field.value); err != nil {
+				return nil, fmt.Errorf("transaction %d of block %d: %w", i, block.BlockNumber, err)
+			}
+		}
+		if transaction.Hash == "" {
+			return nil, fmt.Errorf("transaction %d of block %d has no hash", i, block.BlockNumber)
+		}
+		if transaction.Calldata, err = normalizeFelts(tx.Calldata); err != nil {
+			return nil, fmt.Errorf("calldata of transaction %s: %w", transaction.Hash, err)
+		}
+		if transaction.ConstructorCalldata, err = normalizeFelts(tx.ConstructorCalldata); err != nil {
+			return nil, fmt.Errorf("constructor calldata of transaction %s: %w", transaction.Hash, err)
+		}
+		transaction.ContractAddressSalt = tx.ContractAddressSalt
+
+		starknetBlock.Transactions = append(starknetBlock.Transactions, transaction)
+	}
+
+	return starknetBlock, nil
+}
+
+// GetEvents returns all events emitted in blocks of range, following continuation tokens.
+func (c *Client) GetEvents(ctx context.Context, fromBlock, toBlock uint64) ([]rpcEvent, error) {
+	var events []rpcEvent
+	var continuationToken string
+	for {
+		filter := map[string]interface{}{
+			"from_block": rpcBlockID{BlockNumber: fromBlock},
+			"to_block":   rpcBlockID{BlockNumber: toBlock},
+			"chunk_size": EventsChunkSize,
+		}
+		if continuationToken != "" {
+			filter["continuation_token"] = continuationToken
+		}
+
+		var chunk rpcEventsChunk
+		ctxWithTimeout, cancel := context.WithTimeout(ctx, c.timeout)
+		err := c.rpcClient.CallContext(ctxWithTimeout, &chunk, "starknet_getEvents", filter)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get events of blocks %d-%d: %w", fromBlock, toBlock, err)
+		}
+
+		events = append(events, chunk.Events...)
+		if chunk.ContinuationToken == "" {
+			return events, nil
+		}
+		continuationToken = chunk.ContinuationToken
+	}
+}
+
+// FetchAsProtoBlocksWithEvents fetches blocks of range and attaches events to transactions
+// which emitted them.
+func (c *Client) FetchAsProtoBlocksWithEvents(from, to *big.Int, debug bool, maxRequests int) ([]proto.Message, []indexer.BlockIndex, uint64, error) {
+	if maxRequests < 1 {
+		maxRequests = 1
+	}
+
+	var blocks []*StarknetBlock
+	var blocksMu sync.Mutex
+	var fetchErr error
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, maxRequests)
+	for blockNumber := from.Uint64(); blockNumber <= to.Uint64(); blockNumber++ {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(blockNumber uint64) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			block, err := c.GetBlock(context.Background(), blockNumber)
+
+			blocksMu.Lock()
+			defer blocksMu.Unlock()
+			if err != nil {
+				if fetchErr == nil {
+					fetchErr = err
+				}
+				return
+			}
+			blocks = append(blocks, block)
+		}(blockNumber)
+	}
+	wg.Wait()
+
+	if fetchErr != nil {
+		return nil, nil, 0, fetchErr
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].BlockNumber < blocks[j].BlockNumber
+	})
+
+	if err := verifyBlocksContinuity(blocks); err != nil {
+		return nil, nil, 0, err
+	}
+
+	events, err := c.GetEvents(context.Background(), from.Uint64(), to.Uint64())
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if err := attachEvents(blocks, events); err != nil {
+		return nil, nil, 0, err
+	}
+
+	var blocksSize uint64
+	var blocksProto []proto.Message
+	var blocksIndex []indexer.BlockIndex
+
+	for bI, block := range blocks {
+		blockIndex := indexer.NewBlockIndex(c.chain,
+			block.BlockNumber,
+			block.BlockHash,
+			block.Timestamp,
+			block.ParentHash,
+			uint64(bI),
+			"",
+			0,
+		)
+		blocksIndex = append(blocksIndex, blockIndex)
+
+		blocksSize += uint64(proto.Size(block))
+		blocksProto = append(blocksProto, block)
+	}
+
+	if debug {
+		log.Printf("Fetched %d blocks and %d events of blocks %d-%d", len(blocks), len(events), from.Uint64(), to.Uint64())
+	}
+
+	return blocksProto, blocksIndex, blocksSize, nil
+}
+
+// verifyBlocksContinuity checks that every block refers to hash of previous fetched block.
+func verifyBlocksContinuity(blocks []*StarknetBlock) error {
+	for i := 1; i < len(blocks); i++ {
+		if blocks[i].ParentHash != blocks[i-1].BlockHash {
+			return &seer_common.ReorgDetected{
+				Height:            blocks[i].BlockNumber,
+				ParentHash:        blocks[i].ParentHash,
+				PreviousBlockHash: blocks[i-1].BlockHash,
+			}
+		}
+	}
+	return nil
+}
+
+// attachEvents adds events to their transactions, events of blocks changed after blocks were
+// fetched are reported as reorg.
+func attachEvents(blocks []*StarknetBlock, events []rpcEvent) error {
+	blocksByNumber := make(map[uint64]*StarknetBlock, len(blocks))
+	transactions := make(map[string]*StarknetTransaction)
+	for _, block := range blocks {
+		blocksByNumber[block.BlockNumber] = block
+		for _, tx := range block.Transactions {
+			transactions[tx.Hash] = tx
+		}
+	}
+
+	eventIndexes := make(map[uint64]uint64)
+	for _, event := range events {
+		block, exists := blocksByNumber[event.BlockNumber]
+		if !exists {
+			continue
+		}
+
+		blockHash, err := NormalizeFelt(event.BlockHash)
+		if err != nil {
+			return err
+		}
+		if blockHash != block.BlockHash {
+			return &seer_common.ReorgDetected{
+				Height:            block.BlockNumber,
+				ParentHash:        block.ParentHash,
+				PreviousBlockHash: blockHash,
+			}
+		}
+
+		transactionHash, err := NormalizeFelt(event.TransactionHash)
+		if err != nil {
+			return err
+		}
+		tx, exists := transactions[transactionHash]
+		if !exists {
+			return fmt.Errorf("event of unknown transaction %s in block %d", transactionHash, event.BlockNumber)
+		}
+
+		fromAddress, err := NormalizeFelt(event.FromAddress)
+		if err != nil {
+			return err
+		}
+		keys, err := normalizeFelts(event.Keys)
+		if err != nil {
+			return err
+		}
+		data, err := normalizeFelts(event.Data)
+		if err != nil {
+			return err
+		}
+
+		tx.Events = append(tx.Events, &StarknetEvent{
+			FromAddress:      fromAddress,
+			Keys:             keys,
+			Data:             data,
+			BlockNumber:      block.BlockNumber,
+			BlockHash:        block.BlockHash,
+			TransactionHash:  tx.Hash,
+			TransactionIndex: tx.TransactionIndex,
+			EventIndex:       eventIndexes[block.BlockNumber],
+		})
+		eventIndexes[block.BlockNumber]++
+	}
+
+	return nil
+}
+
+func (c *Client) ProcessBlocksToBatch(msgs []proto.Message) (proto.Message, error) {
+	var blocks []*StarknetBlock
+	for _, msg := range msgs {
+		block, ok := msg.(*StarknetBlock)
+		if !ok {
+			return nil, fmt.Errorf("failed to type assert proto.Message to *StarknetBlock")
+		}
+		blocks = append(blocks, block)
+	}
+
+	return &StarknetBlocksBatch{
+		Blocks:      blocks,
+		SeerVersion: version.SeerVersion,
+	}, nil
+}
+
+// Call is a call of account transaction to contract entry point.
+type Call struct {
+	To       string
+	Selector string
+	Calldata []string
+}
+
+// TransactionCalls returns calls made by transaction. Calldata of account transactions is
+// decoded as multicall of __execute__, both Cairo 1 layout with calldata of every call and
+// Cairo 0 layout with offsets into shared calldata are recognized. Transactions of version 0
+// call contract entry point directly.
+func TransactionCalls(tx *StarknetTransaction) []Call {
+	if tx.Type != "INVOKE" {
+		return nil
+	}
+	if tx.SenderAddress == "" {
+		if tx.ContractAddress == "" || tx.EntryPointSelector == "" {
+			return nil
+		}
+		return []Call{{To: tx.ContractAddress, Selector: tx.EntryPointSelector, Calldata: tx.Calldata}}
+	}
+
+	if calls, ok := parseMulticall(tx.Calldata); ok {
+		return calls
+	}
+	if calls, ok := parseLegacyMulticall(tx.Calldata); ok {
+		return calls
+	}
+	return nil
+}
+
+func feltToInt(value string, limit int) (int, bool) {
+	felt, err := parseFelt(value)
+	if err != nil || !felt.IsInt64() || felt.Int64() > int64(limit) {
+		return 0, false
+	}
+	return int(felt.Int64()), true
+}
+
+func parseMulticall(calldata []string) ([]Call, bool) {
+	if len(calldata) == 0 {
+		return nil, false
+	}
+	callsCount, ok := feltToInt(calldata[0], len(calldata))
+	if !ok {
+		return nil, false
+	}
+
+	calls := make([]Call, 0, callsCount)
+	pos := 1
+	for i := 0; i < callsCount; i++ {
+		if pos+3 > len(calldata) {
+			return nil, false
+		}
+		dataLength, ok := feltToInt(calldata[pos+2], len(calldata)-pos-3)
+		if !ok {
+			return nil, false
+		}
+		calls = append(calls, Call{
+			To:       calldata[pos],
+			Selector: calldata[pos+1],
+			Calldata: calldata[pos+3 : pos+3+dataLength],
+		})
+		pos += 3 + dataLength
+	}
+
+	return calls, pos == len(calldata)
+}
+
+func parseLegacyMulticall(calldata []string) ([]Call, bool) {
+	if len(calldata) == 0 {
+		return nil, false
+	}
+	callsCount, ok := feltToInt(calldata[0], len(calldata)/4)
+	if !ok {
+		return nil, false
+	}
+
+	dataStart := 1 + 4*callsCount + 1
+	if dataStart > len(calldata) {
+		return nil, false
+	}
+	dataLength, ok := feltToInt(calldata[dataStart-1], len(calldata)-dataStart)
+	if !ok || dataStart+dataLength != len(calldata) {
+		return nil, false
+	}
+	data := calldata[dataStart:]
+
+	calls := make([]Call, 0, callsCount)
+	for i := 0; i < callsCount; i++ {
+		call := calldata[1+4*i : 1+4*i+4]
+		offset, ok := feltToInt(call[2], len(data))
+		if !ok {
+			return nil, false
+		}
+		length, ok := feltToInt(call[3], len(data)-offset)
+		if !ok {
+			return nil, false
+		}
+		calls = append(calls, Call{To: call[0], Selector: call[1], Calldata: data[offset : offset+length]})
+	}
+
+	return calls, true
+}
+
+func joinFelts(values []string) string {
+	var builder strings.Builder
+	builder.WriteString("0x")
+	for _, value := range values {
+		builder.WriteString(strings.TrimPrefix(value, "0x"))
+	}
+	return builder.String()
+}
+
+// ToEntireBlocksBatchFromLogProto converts batch to JSON of EVM shape: transaction is sent by
+// account to target of its first call, keys of events are topics and data felts are
+// concatenated.
+func ToEntireBlocksBatchFromLogProto(obj *StarknetBlocksBatch) *seer_common.BlocksBatchJson {
+	blocksBatchJson := seer_common.BlocksBatchJson{
+		Blocks:      []seer_common.BlockJson{},
+		SeerVersion: obj.SeerVersion,
+	}
+
+	for _, b := range obj.Blocks {
+		var txs []seer_common.TransactionJson
+		for _, tx := range b.Transactions {
+			var events []seer_common.EventJson
+			for _, event := range tx.Events {
+				events = append(events, seer_common.EventJson{
+					Address:          event.FromAddress,
+					Topics:           event.Keys,
+					Data:             joinFelts(event.Data),
+					BlockNumber:      fmt.Sprintf("%d", event.BlockNumber),
+					TransactionHash:  event.TransactionHash,
+					BlockHash:        event.BlockHash,
+					LogIndex:         fmt.Sprintf("%d", event.EventIndex),
+					TransactionIndex: fmt.Sprintf("%d", event.TransactionIndex),
+				})
+			}
+
+			fromAddress := tx.SenderAddress
+			toAddress := tx.ContractAddress
+			if calls := TransactionCalls(tx); len(calls) > 0 && tx.SenderAddress != "" {
+				toAddress = calls[0].To
+			}
+
+			txs = append(txs, seer_common.TransactionJson{
+				BlockHash:        tx.BlockHash,
+				BlockNumber:      fmt.Sprintf("%d", tx.BlockNumber),
+				FromAddress:      fromAddress,
+				Hash:             tx.Hash,
+				Input:            joinFelts(tx.Calldata),
+				MaxFeePerGas:     tx.MaxFee,
+				Nonce:            tx.Nonce,
+				ToAddress:        toAddress,
+				TransactionIndex: fmt.Sprintf("%d", tx.TransactionIndex),
+				TransactionType:  tx.Type,
+				IndexedAt:        fmt.Sprintf("%d", tx.IndexedAt),
+				BlockTimestamp:   fmt.Sprintf("%d", tx.BlockTimestamp),
+				Events:           events,
+			})
+		}
+
+		blocksBatchJson.Blocks = append(blocksBatchJson.Blocks, seer_common.BlockJson{
+			Hash:         b.BlockHash,
+			BlockNumber:  fmt.Sprintf("%d", b.BlockNumber),
+			ParentHash:   b.ParentHash,
+			Miner:        b.SequencerAddress,
+			StateRoot:    b.NewRoot,
+			Timestamp:    fmt.Sprintf("%d", b.Timestamp),
+			IndexedAt:    fmt.Sprintf("%d", b.IndexedAt),
+			Transactions: txs,
+		})
+	}
+
+	return &blocksBatchJson
+}
+
+func (c *Client) DecodeProtoEntireBlockToJson(rawData *bytes.Buffer) (*seer_common.BlocksBatchJson, error) {
+	var protoBlocksBatch StarknetBlocksBatch
+
+	err := proto.Unmarshal(rawData.Bytes(), &protoBlocksBatch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal data: %v", err)
+	}
+
+	return ToEntireBlocksBatchFromLogProto(&protoBlocksBatch), nil
+}
+
+// DecodeProtoEntireBlockToLabels labels events and calls of contracts with jobs. Starknet has
+// no raw transactions of EVM shape, addRawTransactions is ignored.
+func (c *Client) DecodeProtoEntireBlockToLabels(rawData *bytes.Buffer, abiMap map[string]map[string]*indexer.AbiEntry, addRawTransactions bool, threads int) ([]indexer.EventLabel, []indexer.TransactionLabel, []indexer.RawTransaction, error) {
+	var protoBlocksBatch StarknetBlocksBatch
+
+	err := proto.Unmarshal(rawData.Bytes(), &protoBlocksBatch)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to unmarshal data: %v", err)
+	}
+
+	if threads < 1 {
+		threads = 1
+	}
+
+	abiMap = normalizeAbiMap(abiMap)
+
+	var labels []indexer.EventLabel
+	var txLabels []indexer.TransactionLabel
+	var labelsMutex sync.Mutex
+
+	var wg sync.WaitGroup
+	semaphoreChan := make(chan struct{}, threads)
+	errorChan := make(chan error, len(protoBlocksBatch.Blocks))
+
+	for _, b := range protoBlocksBatch.Blocks {
+		wg.Add(1)
+		semaphoreChan <- struct{}{}
+		go func(b *StarknetBlock) {
+			defer wg.Done()
+			defer func() { <-semaphoreChan }()
+
+			var localEventLabels []indexer.EventLabel
+			var localTxLabels []indexer.TransactionLabel
+			for _, tx := range b.Transactions {
+				eventLabels, transactionLabels, err := labelTransaction(tx, abiMap)
+				if err != nil {
+					errorChan <- fmt.Errorf("error labeling transaction %s of block %d: %v", tx.Hash, b.BlockNumber, err)
+					return
+				}
+				localEventLabels = append(localEventLabels, eventLabels...)
+				localTxLabels = append(localTxLabels, transactionLabels...)
+			}
+
+			labelsMutex.Lock()
+			labels = append(labels, localEventLabels...)
+			txLabels = append(txLabels, localTxLabels...)
+			labelsMutex.Unlock()
+		}(b)
+	}
+	wg.Wait()
+	close(errorChan)
+
+	var errorMessages []string
+	for err := range errorChan {
+		errorMessages = append(errorMessages, err.Error())
+	}
+	if len(errorMessages) > 0 {
+		return nil, nil, nil, fmt.Errorf("errors occurred during processing:\n%s", strings.Join(errorMessages, "\n"))
+	}
+
+	return labels, txLabels, nil, nil
+}
+
+// DecodeProtoTransactionsToLabels labels calls of base64 encoded proto transactions.
+func (c *Client) DecodeProtoTransactionsToLabels(transactions []string, blocksCache map[uint64]uint64, abiMap map[string]map[string]*indexer.AbiEntry) ([]indexer.TransactionLabel, error) {
+	abiMap = normalizeAbiMap(abiMap)
+
+	var labels []indexer.TransactionLabel
+	for _, data := range transactions {
+		dataBytes, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode base64 data: %v", err)
+		}
+
+		var transaction StarknetTransaction
+		if err := proto.Unmarshal(dataBytes, &transaction); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal data: %v", err)
+		}
+		if timestamp, exists := blocksCache[transaction.BlockNumber]; exists {
+			transaction.BlockTimestamp = timestamp
+		}
+
+		_, transactionLabels, err := labelTransaction(&transaction, abiMap)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, transactionLabels...)
+	}
+
+	return labels, nil
+}
+
+// normalizeAbiMap keys jobs by normalized felts, so address and selector of job could be
+// stored without leading zeros. Jobs with keys which are not felts are dropped.
+func normalizeAbiMap(abiMap map[string]map[string]*indexer.AbiEntry) map[string]map[string]*indexer.AbiEntry {
+	normalized := make(map[string]map[string]*indexer.AbiEntry, len(abiMap))
+	for address, entries := range abiMap {
+		normalizedAddress, err := NormalizeFelt(address)
+		if err != nil {
+			continue
+		}
+		if normalized[normalizedAddress] == nil {
+			normalized[normalizedAddress] = make(map[string]*indexer.AbiEntry, len(entries))
+		}
+		for selector, entry := range entries {
+			normalizedSelector, err := NormalizeFelt(selector)
+			if err != nil {
+				continue
+			}
+			normalized[normalizedAddress][normalizedSelector] = entry
+		}
+	}
+	return normalized
+}
+
+// labelTransaction decodes events emitted by contracts with jobs and calls of account
+// transaction to them. Receipts are not fetched, so labels of calls have no status.
+func labelTransaction(tx *StarknetTransaction, abiMap map[string]map[string]*indexer.AbiEntry) ([]indexer.EventLabel, []indexer.TransactionLabel, error) {
+	origin := tx.SenderAddress
+	if origin == "" {
+		origin = tx.ContractAddress
+	}
+
+	var eventLabels []indexer.EventLabel
+	var txLabels []indexer.TransactionLabel
+
+	for _, event := range tx.Events {
+		if len(event.Keys) == 0 || abiMap[event.FromAddress] == nil {
+			continue
+		}
+		selector := event.Keys[0]
+		entry := abiMap[event.FromAddress][selector]
+		if entry == nil {
+			continue
+		}
+
+		label := indexer.SeerCrawlerLabel
+		var labelData map[string]interface{}
+		contractEntry, parseErr := cachedContractEntry(entry.AbiJSON, entry.AbiName)
+		if parseErr == nil {
+			labelData, parseErr = DecodeEventToLabelData(contractEntry, event.Keys, event.Data)
+		}
+		if parseErr != nil {
+			labelData = map[string]interface{}{
+				"keys":     event.Keys,
+				"data":     event.Data,
+				"abi":      entry.AbiJSON,
+				"selector": selector,
+				"error":    parseErr.Error(),
+			}
+			label = indexer.SeerCrawlerRawLabel
+		}
+
+		labelDataBytes, err := json.Marshal(labelData)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		eventLabels = append(eventLabels, indexer.EventLabel{
+			Label:           label,
+			LabelName:       entry.AbiName,
+			LabelType:       "event",
+			BlockNumber:     tx.BlockNumber,
+			BlockHash:       tx.BlockHash,
+			Address:         event.FromAddress,
+			CallerAddress:   origin,
+			OriginAddress:   origin,
+			TransactionHash: tx.Hash,
+			LabelData:       string(labelDataBytes),
+			BlockTimestamp:  tx.BlockTimestamp,
+			LogIndex:        event.EventIndex,
+		})
+	}
+
+	for _, call := range TransactionCalls(tx) {
+		if abiMap[call.To] == nil || abiMap[call.To][call.Selector] == nil {
+			continue
+		}
+		entry := abiMap[call.To][call.Selector]
+
+		label := indexer.SeerCrawlerLabel
+		var labelData map[string]interface{}
+		contractEntry, parseErr := cachedContractEntry(entry.AbiJSON, entry.AbiName)
+		if parseErr == nil {
+			labelData, parseErr = DecodeCallToLabelData(contractEntry, call.Calldata)
+		}
+		if parseErr != nil {
+			labelData = map[string]interface{}{
+				"calldata": call.Calldata,
+				"abi":      entry.AbiJSON,
+				"selector": call.Selector,
+				"error":    parseErr.Error(),
+			}
+			label = indexer.SeerCrawlerRawLabel
+		}
+
+		labelDataBytes, err := json.Marshal(labelData)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		txLabels = append(txLabels, indexer.TransactionLabel{
+			Address:         call.To,
+			BlockNumber:     tx.BlockNumber,
+			BlockHash:       tx.BlockHash,
+			CallerAddress:   origin,
+			LabelName:       entry.AbiName,
+			LabelType:       "tx_call",
+			OriginAddress:   origin,
+			Label:           label,
+			TransactionHash: tx.Hash,
+			LabelData:       string(labelDataBytes),
+			BlockTimestamp:  tx.BlockTimestamp,
+		})
+	}
+
+	return eventLabels, txLabels, nil
+}
+
+// EVM specific methods of ChainClient
+
+func (c *Client) GetCode(ctx context.Context, address common.Address, blockNumber uint64) ([]byte, error) {
+	return nil, fmt.Errorf("GetCode is %w", errNotSupported)
+}
+
+func (c *Client) CallContract(ctx context.Context, address common.Address, data []byte, blockNumber uint64) ([]byte, error) {
+	return nil, fmt.Errorf("CallContract is %w", errNotSupported)
+}
+
+func (c *Client) FindDeploymentBlock(ctx context.Context, address common.Address) (uint64, error) {
+	return 0, fmt.Errorf("FindDeploymentBlock is %w", errNotSupported)
+}
+
+// GetTransactionsLabels is not supported, labels of Starknet are decoded only from crawled batches.
+func (c *Client) GetTransactionsLabels(startBlock uint64, endBlock uint64, abiMap map[string]map[string]*indexer.AbiEntry, threads int) ([]indexer.TransactionLabel, map[uint64]seer_common.BlockWithTransactions, error) {
+	return nil, nil, fmt.Errorf("GetTransactionsLabels is %w", errNotSupported)
+}
+
+// GetEventsLabels is not supported, labels of Starknet are decoded only from crawled batches.
+func (c *Client) GetEventsLabels(startBlock uint64, endBlock uint64, abiMap map[string]map[string]*indexer.AbiEntry, blocksCache map[uint64]seer_common.BlockWithTransactions) ([]indexer.EventLabel, error) {
+	return nil, fmt.Errorf("GetEventsLabels is %w", errNotSupported)
+}
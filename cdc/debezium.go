@@ -0,0 +1,234 @@
+package cdc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/G7DAO/seer/indexer"
+	"github.com/G7DAO/seer/version"
+)
+
+// Debezium operation types, seer only inserts labels
+const (
+	OperationCreate = "c"
+)
+
+// Field is a field of Kafka Connect JSON schema which Debezium puts next to payload.
+type Field struct {
+	Field    string  `json:"field,omitempty"`
+	Type     string  `json:"type"`
+	Optional bool    `json:"optional"`
+	Name     string  `json:"name,omitempty"`
+	Fields   []Field `json:"fields,omitempty"`
+}
+
+// Source describes origin of change, fields of Debezium PostgreSQL connector are kept and
+// extended with chain and customer.
+type Source struct {
+	Version    string `json:"version"`
+	Connector  string `json:"connector"`
+	Name       string `json:"name"`
+	TsMs       int64  `json:"ts_ms"`
+	Snapshot   string `json:"snapshot"`
+	Db         string `json:"db"`
+	Schema     string `json:"schema"`
+	Table      string `json:"table"`
+	Chain      string `json:"chain"`
+	CustomerID string `json:"customer_id"`
+	InstanceID int    `json:"instance_id"`
+}
+
+// LabelRow is a row of labels table as it is written into customer database.
+type LabelRow struct {
+	Label           string  `json:"label"`
+	LabelType       string  `json:"label_type"`
+	LabelName       string  `json:"label_name"`
+	Address         string  `json:"address"`
+	CallerAddress   string  `json:"caller_address"`
+	OriginAddress   string  `json:"origin_address"`
+	TransactionHash string  `json:"transaction_hash"`
+	LogIndex        *uint64 `json:"log_index"`
+	BlockNumber     uint64  `json:"block_number"`
+	BlockHash       string  `json:"block_hash"`
+	BlockTimestamp  uint64  `json:"block_timestamp"`
+	LabelData       string  `json:"label_data"`
+}
+
+type Payload struct {
+	Before *LabelRow `json:"before"`
+	After  *LabelRow `json:"after"`
+	Source Source    `json:"source"`
+	Op     string    `json:"op"`
+	TsMs   int64     `json:"ts_ms"`
+}
+
+// Envelope is a Debezium change event with schema, consumers with JSON converter and
+// schemas enabled read it without adapter.
+type Envelope struct {
+	Schema  Field   `json:"schema"`
+	Payload Payload `json:"payload"`
+}
+
+type KeyPayload struct {
+	TransactionHash string  `json:"transaction_hash"`
+	LogIndex        *uint64 `json:"log_index"`
+	LabelType       string  `json:"label_type"`
+}
+
+type Key struct {
+	Schema  Field      `json:"schema"`
+	Payload KeyPayload `json:"payload"`
+}
+
+// Record is a keyed change event addressed to topic.
+type Record struct {
+	Topic string
+	Key   Key
+	Value Envelope
+}
+
+var labelRowFields = []Field{
+	{Field: "label", Type: "string"},
+	{Field: "label_type", Type: "string"},
+	{Field: "label_name", Type: "string", Optional: true},
+	{Field: "address", Type: "string"},
+	{Field: "caller_address", Type: "string", Optional: true},
+	{Field: "origin_address", Type: "string", Optional: true},
+	{Field: "transaction_hash", Type: "string"},
+	{Field: "log_index", Type: "int64", Optional: true},
+	{Field: "block_number", Type: "int64"},
+	{Field: "block_hash", Type: "string"},
+	{Field: "block_timestamp", Type: "int64"},
+	{Field: "label_data", Type: "string", Optional: true, Name: "io.debezium.data.Json"},
+}
+
+var sourceFields = []Field{
+	{Field: "version", Type: "string"},
+	{Field: "connector", Type: "string"},
+	{Field: "name", Type: "string"},
+	{Field: "ts_ms", Type: "int64"},
+	{Field: "snapshot", Type: "string", Optional: true},
+	{Field: "db", Type: "string"},
+	{Field: "schema", Type: "string"},
+	{Field: "table", Type: "string"},
+	{Field: "chain", Type: "string"},
+	{Field: "customer_id", Type: "string"},
+	{Field: "instance_id", Type: "int32"},
+}
+
+var keyFields = []Field{
+	{Field: "transaction_hash", Type: "string"},
+	{Field: "log_index", Type: "int64", Optional: true},
+	{Field: "label_type", Type: "string"},
+}
+
+// Topic returns Debezium topic name <server>.<schema>.<table> of labels table of chain.
+func Topic(serverName, blockchain string) string {
+	return fmt.Sprintf("%s.public.%s", serverName, indexer.LabelsTableName(blockchain))
+}
+
+func envelopeSchema(topic string) Field {
+	return Field{
+		Type: "struct",
+		Name: topic + ".Envelope",
+		Fields: []Field{
+			{Field: "before", Type: "struct", Optional: true, Name: topic + ".Value", Fields: labelRowFields},
+			{Field: "after", Type: "struct", Optional: true, Name: topic + ".Value", Fields: labelRowFields},
+			{Field: "source", Type: "struct", Name: "io.seer.connector.Source", Fields: sourceFields},
+			{Field: "op", Type: "string"},
+			{Field: "ts_ms", Type: "int64", Optional: true},
+		},
+	}
+}
+
+// Emitter converts labels written to customer database into Debezium change events and
+// sends them to sink.
+type Emitter struct {
+	ServerName string
+	Sink       Sink
+}
+
+func NewEmitter(serverName string, sink Sink) *Emitter {
+	return &Emitter{ServerName: serverName, Sink: sink}
+}
+
+func (e *Emitter) record(topic string, source Source, row LabelRow) Record {
+	return Record{
+		Topic: topic,
+		Key: Key{
+			Schema:  Field{Type: "struct", Name: topic + ".Key", Fields: keyFields},
+			Payload: KeyPayload{TransactionHash: row.TransactionHash, LogIndex: row.LogIndex, LabelType: row.LabelType},
+		},
+		Value: Envelope{
+			Schema: envelopeSchema(topic),
+			Payload: Payload{
+				After:  &row,
+				Source: source,
+				Op:     OperationCreate,
+				TsMs:   source.TsMs,
+			},
+		},
+	}
+}
+
+// EmitLabels sends insert events of labels written to customer instance database.
+func (e *Emitter) EmitLabels(blockchain, customerID string, instanceID int, events []indexer.EventLabel, transactions []indexer.TransactionLabel) error {
+	if len(events) == 0 && len(transactions) == 0 {
+		return nil
+	}
+
+	topic := Topic(e.ServerName, blockchain)
+	source := Source{
+		Version:    version.SeerVersion,
+		Connector:  "seer",
+		Name:       e.ServerName,
+		TsMs:       time.Now().UnixMilli(),
+		Snapshot:   "false",
+		Db:         fmt.Sprintf("%s_%d", customerID, instanceID),
+		Schema:     "public",
+		Table:      indexer.LabelsTableName(blockchain),
+		Chain:      blockchain,
+		CustomerID: customerID,
+		InstanceID: instanceID,
+	}
+
+	records := make([]Record, 0, len(events)+len(transactions))
+	for _, event := range events {
+		logIndex := event.LogIndex
+		records = append(records, e.record(topic, source, LabelRow{
+			Label:           event.Label,
+			LabelType:       event.LabelType,
+			LabelName:       event.LabelName,
+			Address:         event.Address,
+			CallerAddress:   event.CallerAddress,
+			OriginAddress:   event.OriginAddress,
+			TransactionHash: event.TransactionHash,
+			LogIndex:        &logIndex,
+			BlockNumber:     event.BlockNumber,
+			BlockHash:       event.BlockHash,
+			BlockTimestamp:  event.BlockTimestamp,
+			LabelData:       event.LabelData,
+		}))
+	}
+	for _, transaction := range transactions {
+		records = append(records, e.record(topic, source, LabelRow{
+			Label:           transaction.Label,
+			LabelType:       transaction.LabelType,
+			LabelName:       transaction.LabelName,
+			Address:         transaction.Address,
+			CallerAddress:   transaction.CallerAddress,
+			OriginAddress:   transaction.OriginAddress,
+			TransactionHash: transaction.TransactionHash,
+			BlockNumber:     transaction.BlockNumber,
+			BlockHash:       transaction.BlockHash,
+			BlockTimestamp:  transaction.BlockTimestamp,
+			LabelData:       transaction.LabelData,
+		}))
+	}
+
+	return e.Sink.Write(records)
+}
+
+func (e *Emitter) Close() error {
+	return e.Sink.Close()
+}
@@ -0,0 +1,153 @@
+package cdc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	DefaultKafkaRestTimeout   = 10 * time.Second
+	DefaultKafkaRestBatchSize = 500
+)
+
+// Sink delivers change events to destination.
+type Sink interface {
+	Write(records []Record) error
+	Close() error
+}
+
+// FileSink appends change events to file as JSON lines, each line is an object with topic,
+// key and value of record.
+type FileSink struct {
+	mu     sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+}
+
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileSink{file: file, writer: bufio.NewWriter(file)}, nil
+}
+
+type fileLine struct {
+	Topic string   `json:"topic"`
+	Key   Key      `json:"key"`
+	Value Envelope `json:"value"`
+}
+
+func (s *FileSink) Write(records []Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	encoder := json.NewEncoder(s.writer)
+	for _, record := range records {
+		if err := encoder.Encode(fileLine{Topic: record.Topic, Key: record.Key, Value: record.Value}); err != nil {
+			return err
+		}
+	}
+
+	return s.writer.Flush()
+}
+
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.writer.Flush(); err != nil {
+		s.file.Close()
+		return err
+	}
+	return s.file.Close()
+}
+
+// KafkaRestSink produces change events to Kafka through Confluent REST Proxy API v2.
+type KafkaRestSink struct {
+	URL       string
+	BatchSize int
+
+	client *http.Client
+}
+
+func NewKafkaRestSink(url string, timeout time.Duration) *KafkaRestSink {
+	return &KafkaRestSink{
+		URL:       strings.TrimSuffix(url, "/"),
+		BatchSize: DefaultKafkaRestBatchSize,
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+type kafkaRestRecord struct {
+	Key   Key      `json:"key"`
+	Value Envelope `json:"value"`
+}
+
+type kafkaRestRequest struct {
+	Records []kafkaRestRecord `json:"records"`
+}
+
+func (s *KafkaRestSink) produce(topic string, records []kafkaRestRecord) error {
+	body, err := json.Marshal(kafkaRestRequest{Records: records})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/topics/%s", s.URL, topic), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("kafka rest proxy %s responded with status %d", s.URL, resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (s *KafkaRestSink) Write(records []Record) error {
+	byTopic := make(map[string][]kafkaRestRecord)
+	var topics []string
+	for _, record := range records {
+		if _, exists := byTopic[record.Topic]; !exists {
+			topics = append(topics, record.Topic)
+		}
+		byTopic[record.Topic] = append(byTopic[record.Topic], kafkaRestRecord{Key: record.Key, Value: record.Value})
+	}
+
+	for _, topic := range topics {
+		topicRecords := byTopic[topic]
+		for i := 0; i < len(topicRecords); i += s.BatchSize {
+			end := i + s.BatchSize
+			if end > len(topicRecords) {
+				end = len(topicRecords)
+			}
+			if err := s.produce(topic, topicRecords[i:end]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *KafkaRestSink) Close() error {
+	return nil
+}
@@ -2,15 +2,21 @@ package crawler
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	seer_blockchain "github.com/G7DAO/seer/blockchain"
+	seer_common "github.com/G7DAO/seer/blockchain/common"
+	"github.com/G7DAO/seer/health"
 	"github.com/G7DAO/seer/indexer"
+	"github.com/G7DAO/seer/metrics"
 	"github.com/G7DAO/seer/storage"
 	"google.golang.org/protobuf/proto"
 )
@@ -18,9 +24,11 @@ import (
 var CurrentBlockchainState BlockchainState
 
 // BlockchainState represents the current state of the blockchain, including the latest block number and the time when it was fetched.
+// SafeBlockNumber is the highest block which satisfies finality of chain, nil until it is calculated.
 type BlockchainState struct {
 	LatestUpdateTs    time.Time
 	LatestBlockNumber *big.Int
+	SafeBlockNumber   *big.Int
 
 	mux sync.RWMutex
 }
@@ -41,6 +49,21 @@ func (bs *BlockchainState) GetLatestBlockNumber() *big.Int {
 	return blockNumber
 }
 
+func (bs *BlockchainState) RaiseSafeBlockNumber(blockNumber *big.Int) {
+	bs.mux.Lock()
+	if bs.SafeBlockNumber == nil || bs.SafeBlockNumber.Cmp(blockNumber) == -1 {
+		bs.SafeBlockNumber = blockNumber
+	}
+	bs.mux.Unlock()
+}
+
+func (bs *BlockchainState) GetSafeBlockNumber() *big.Int {
+	bs.mux.RLock()
+	blockNumber := bs.SafeBlockNumber
+	bs.mux.RUnlock()
+	return blockNumber
+}
+
 func (bs *BlockchainState) GetLatestUpdateTs() time.Time {
 	bs.mux.RLock()
 	latestUpdateTs := bs.LatestUpdateTs
@@ -81,8 +104,18 @@ func (db *DynamicBatch) DynamicDecreaseSize(diff int64) {
 
 // Crawler defines the crawler structure.
 type Crawler struct {
-	Client          seer_blockchain.BlockchainClient
+	Client          seer_blockchain.ChainClient
 	StorageInstance storage.Storer
+	Store           indexer.IndexWriter
+
+	// Finality defines safe head of chain, by default it is latest block minus confirmations
+	Finality seer_common.Finality
+	// FinalizedOnly limits crawling to safe head instead of latest block minus confirmations
+	FinalizedOnly bool
+
+	// State keeps latest and safe blocks of chain, crawlers of different chains in one
+	// process should not share it
+	State *BlockchainState
 
 	blockchain      string
 	startBlock      int64
@@ -95,28 +128,56 @@ type Crawler struct {
 	protoTimeLimit  int
 	retryWait       int
 	retryMultiplier int
+	recoveryDepth   int64
+	writeWorkers    int
+
+	writePipeline *indexer.WritePipeline
+
+	headsNotify     chan struct{}
+	headsSubscribed atomic.Bool
 }
 
 // NewCrawler creates a new crawler instance with the given blockchain handler.
-func NewCrawler(blockchain, rpcUrl string, startBlock, finalBlock, confirmations, batchSize int64, timeout int, baseDir string, protoSizeLimit uint64, protoTimeLimit, retryWait, retryMultiplier int) (*Crawler, error) {
+func NewCrawler(blockchain, rpcUrl string, startBlock, finalBlock, confirmations, batchSize int64, timeout int, baseDir string, protoSizeLimit uint64, protoTimeLimit, retryWait, retryMultiplier int, recoveryDepth int64, writeWorkers int) (*Crawler, error) {
 	var crawler Crawler
 
-	basePath := filepath.Join(baseDir, SeerCrawlerStoragePrefix, "data", blockchain)
+	// Keys of batches are rendered by storage layout relative to data directory of all chains
+	basePath := filepath.Join(baseDir, SeerCrawlerStoragePrefix, "data")
+
+	// Crawler is the only writer of batches, so it records their replication status
+	if len(storage.SeerCrawlerStorageReplicas) > 0 && indexer.DBConnection != nil {
+		if err := indexer.DBConnection.EnsureStorageReplicationStatusTable(); err != nil {
+			return nil, fmt.Errorf("failed to ensure storage replication status table: %v", err)
+		}
+		storage.ReplicationStatusTracker = indexer.DBConnection
+	}
+
+	if indexer.DBConnection != nil {
+		if blockchain == indexer.LocalChain {
+			if err := indexer.DBConnection.EnsureBlocksTable(indexer.LocalChain); err != nil {
+				return nil, fmt.Errorf("failed to ensure blocks index of local chain: %v", err)
+			}
+		}
+		if err := indexer.DBConnection.EnsureBlocksMinerColumn(blockchain); err != nil {
+			return nil, fmt.Errorf("failed to ensure miner column of blocks index: %v", err)
+		}
+	}
+
 	storageInstance, err := storage.NewStorage(storage.SeerCrawlerStorageType, basePath)
 	if err != nil {
-		log.Fatalf("Failed to create storage instance: %v", err)
-		panic(err)
+		return nil, fmt.Errorf("failed to create storage instance: %w", err)
 	}
 
 	client, err := seer_blockchain.NewClient(blockchain, rpcUrl, timeout)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	log.Printf("Initialized new crawler at blockchain: %s, startBlock: %d, finalBlock: %d", blockchain, startBlock, finalBlock)
 	crawler = Crawler{
 		Client:          client,
 		StorageInstance: storageInstance,
+		Store:           indexer.DBConnection,
 
 		blockchain:      blockchain,
 		startBlock:      startBlock,
@@ -129,6 +190,10 @@ func NewCrawler(blockchain, rpcUrl string, startBlock, finalBlock, confirmations
 		protoTimeLimit:  protoTimeLimit,
 		retryWait:       retryWait,
 		retryMultiplier: retryMultiplier,
+		recoveryDepth:   recoveryDepth,
+		writeWorkers:    writeWorkers,
+		Finality:        seer_common.Finality{Confirmations: confirmations},
+		State:           &CurrentBlockchainState,
 	}
 
 	return &crawler, nil
@@ -170,8 +235,8 @@ func (cp *CrawlPack) Initialize(startBlock int64) {
 }
 
 // ProcessAndPush makes preparations for blocks, txs, and logs data and pushes them to the database with storage.
-func (cp *CrawlPack) ProcessAndPush(client seer_blockchain.BlockchainClient, crawler *Crawler) error {
-	packRange := fmt.Sprintf("%d-%d", cp.PackStartBlock, cp.PackEndBlock)
+func (cp *CrawlPack) ProcessAndPush(client seer_blockchain.ChainClient, crawler *Crawler) error {
+	batchKey := storage.SeerCrawlerStorageLayout.Key(crawler.blockchain, uint64(cp.PackStartBlock), uint64(cp.PackEndBlock), storage.SeerCrawlerStorageCompression)
 
 	// Prepare and save proto data
 	blocksBatch, batchErr := client.ProcessBlocksToBatch(cp.BlocksPack)
@@ -184,19 +249,27 @@ func (cp *CrawlPack) ProcessAndPush(client seer_blockchain.BlockchainClient, cra
 		return fmt.Errorf("failed to marshal blocks: %v", marshalErr)
 	}
 
-	if err := crawler.StorageInstance.Save(packRange, "data.proto", *bytes.NewBuffer(dataBytes)); err != nil {
-		return fmt.Errorf("failed to save data.proto: %w", err)
+	if err := crawler.StorageInstance.Save(filepath.Dir(batchKey), filepath.Base(batchKey), *bytes.NewBuffer(dataBytes)); err != nil {
+		return fmt.Errorf("failed to save %s: %w", batchKey, err)
 	}
-	log.Printf("Saved .proto blocks with transactions and events to %s", packRange)
+	log.Printf("Saved .proto blocks with transactions and events to %s", batchKey)
 
 	// Prepare and save indexes data
 	var interfaceBlocksIndexPack []indexer.BlockIndex
 	for _, v := range cp.BlocksIndexPack {
-		v.Path = filepath.Join(crawler.basePath, packRange, "data.proto")
+		v.Path = filepath.Join(crawler.basePath, batchKey)
 		interfaceBlocksIndexPack = append(interfaceBlocksIndexPack, v)
 	}
 
-	err := indexer.WriteIndicesToDatabase(crawler.blockchain, interfaceBlocksIndexPack)
+	// With write pipeline indexes are written in background while next pack is crawled
+	if crawler.writePipeline != nil {
+		if err := crawler.writePipeline.SubmitIndexes(crawler.blockchain, interfaceBlocksIndexPack); err != nil {
+			return fmt.Errorf("failed to submit indices to write pipeline: %w", err)
+		}
+		return nil
+	}
+
+	err := crawler.Store.WriteIndexes(crawler.blockchain, interfaceBlocksIndexPack)
 	if err != nil {
 		return fmt.Errorf("failed to write indices to database: %w", err)
 	}
@@ -204,8 +277,127 @@ func (cp *CrawlPack) ProcessAndPush(client seer_blockchain.BlockchainClient, cra
 	return nil
 }
 
-// Main crawler loop.
-func (c *Crawler) Start(threads int) {
+// RecoverBatches looks through batches indexed in last recoveryDepth blocks and re-crawls ranges
+// with incomplete indexing markers or with batch objects missing in storage.
+func (c *Crawler) RecoverBatches(threads int) error {
+	if c.recoveryDepth <= 0 {
+		return nil
+	}
+
+	latestIndexedBlock, latestErr := c.Store.GetLatestDBBlockNumber(c.blockchain, false)
+	if latestErr != nil {
+		if errors.Is(latestErr, indexer.ErrNoRowsIndexed) {
+			return nil
+		}
+		return fmt.Errorf("failed to get latest indexed block: %w", latestErr)
+	}
+
+	var fromBlock uint64
+	if latestIndexedBlock > uint64(c.recoveryDepth) {
+		fromBlock = latestIndexedBlock - uint64(c.recoveryDepth)
+	}
+
+	batches, batchesErr := c.Store.ReadIndexedBatches(c.blockchain, fromBlock)
+	if batchesErr != nil {
+		return fmt.Errorf("failed to read indexed batches: %w", batchesErr)
+	}
+
+	for _, batch := range batches {
+		reason := ""
+		if batch.Incomplete {
+			reason = "incomplete indexing markers"
+		} else if _, readErr := c.StorageInstance.Read(batch.Path); readErr != nil {
+			reason = fmt.Sprintf("batch object is not available in storage: %v", readErr)
+		}
+		if reason == "" {
+			continue
+		}
+
+		if err := c.RecoverBatch(batch, reason, threads); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RecoverBatch removes object and index rows of batch and crawls its blocks again.
+func (c *Crawler) RecoverBatch(batch indexer.IndexedBatch, reason string, threads int) error {
+	retryAttempts := 3
+	retryWaitTime := time.Duration(c.retryWait) * time.Millisecond
+
+	log.Printf("Recovering batch %s with blocks from %d to %d, reason: %s", batch.Path, batch.MinBlockNumber, batch.MaxBlockNumber, reason)
+
+	// Storage backends could append to existing object, so it should be removed before saving new one
+	if deleteErr := c.StorageInstance.Delete(batch.Path); deleteErr != nil && SEER_CRAWLER_DEBUG {
+		log.Printf("[DEBUG] [crawler.RecoverBatch] unable to delete batch object %s: %v", batch.Path, deleteErr)
+	}
+
+	deletedRows, deleteErr := c.Store.DeleteBlockIndexRange(c.blockchain, batch.MinBlockNumber, batch.MaxBlockNumber)
+	if deleteErr != nil {
+		return fmt.Errorf("failed to delete index rows for batch %s: %w", batch.Path, deleteErr)
+	}
+	log.Printf("Deleted %d index rows of batch %s", deletedRows, batch.Path)
+
+	crawlPack := CrawlPack{}
+	crawlPack.Initialize(int64(batch.MinBlockNumber))
+	crawlPack.PackEndBlock = int64(batch.MaxBlockNumber)
+
+	if retryErr := retryOperation(retryAttempts, retryWaitTime, func() error {
+		blocks, blocksIndex, _, crawlErr := seer_blockchain.CrawlEntireBlocks(c.Client, new(big.Int).SetUint64(batch.MinBlockNumber), new(big.Int).SetUint64(batch.MaxBlockNumber), SEER_CRAWLER_DEBUG, threads)
+		if crawlErr != nil {
+			var reorgErr *seer_common.ReorgDetected
+			if errors.As(crawlErr, &reorgErr) {
+				log.Printf("Reorg detected at block %d while crawling %d-%d, batch will be fetched again", reorgErr.Height, batch.MinBlockNumber, batch.MaxBlockNumber)
+			}
+			return fmt.Errorf("failed to crawl blocks, txs and events: %w", crawlErr)
+		}
+
+		crawlPack.BlocksPack = blocks
+		crawlPack.BlocksIndexPack = blocksIndex
+
+		return crawlPack.ProcessAndPush(c.Client, c)
+	}); retryErr != nil {
+		return fmt.Errorf("failed to recover batch %s: %w", batch.Path, retryErr)
+	}
+
+	return nil
+}
+
+// updateSafeHead recalculates safe head of blockchain state from latest block number.
+func (c *Crawler) updateSafeHead() error {
+	latestBlockNumber := c.State.GetLatestBlockNumber()
+	if latestBlockNumber == nil {
+		return fmt.Errorf("latest block number is unknown")
+	}
+
+	safeBlockNumber, err := seer_common.SafeHead(context.Background(), c.Client, c.Finality, latestBlockNumber)
+	if err != nil {
+		return err
+	}
+	c.State.RaiseSafeBlockNumber(safeBlockNumber)
+
+	return nil
+}
+
+// crawlHead returns the highest block crawler could fetch.
+func (c *Crawler) crawlHead() int64 {
+	if c.FinalizedOnly {
+		safeBlockNumber := c.State.GetSafeBlockNumber()
+		if safeBlockNumber == nil {
+			return 0
+		}
+		return safeBlockNumber.Int64()
+	}
+
+	return c.State.GetLatestBlockNumber().Int64() - c.confirmations
+}
+
+// Main crawler loop. When ctx is cancelled crawler finishes batch in flight, pushes crawled
+// blocks of pack to storage and database and waits for write pipeline before return. Error
+// is returned if blocks could not be crawled or pushed after retries, blocks of pack which
+// were not pushed are crawled again by next start from indexes database.
+func (c *Crawler) Start(ctx context.Context, threads int) (err error) {
 	protoBufferSizeLimit := int64(c.protoSizeLimit * 1024 * 1024) // In Mb
 	protoDurationTimeLimit := time.Duration(c.protoTimeLimit) * time.Second
 
@@ -218,17 +410,42 @@ func (c *Crawler) Start(threads int) {
 	waitForBlocksTime := retryWaitTime
 	maxWaitForBlocksTime := time.Duration(c.retryMultiplier) * retryWaitTime
 
+	if safeErr := c.updateSafeHead(); safeErr != nil {
+		if c.FinalizedOnly {
+			return fmt.Errorf("failed to get safe head of %s with finality %s: %w", c.blockchain, c.Finality, safeErr)
+		}
+		log.Printf("Failed to get safe head of %s with finality %s: %v", c.blockchain, c.Finality, safeErr)
+	}
+
+	// Before following the head, reprocess batches left partially indexed by previous runs
+	if recoverErr := c.RecoverBatches(threads); recoverErr != nil {
+		return fmt.Errorf("failed to recover partially indexed batches: %w", recoverErr)
+	}
+
+	if c.writeWorkers > 0 {
+		c.writePipeline = indexer.NewWritePipeline(c.Store, nil, indexer.WritePipelineConfig{
+			Workers:       c.writeWorkers,
+			FlushInterval: protoDurationTimeLimit,
+		})
+		defer func() {
+			if closeErr := c.writePipeline.Close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("failed to flush write pipeline: %w", closeErr)
+			}
+			c.writePipeline = nil
+		}()
+	}
+
 	// If Start block is not set, using last crawled block from indexes database
 	if c.startBlock == 0 {
-		latestIndexedBlock, latestErr := indexer.DBConnection.GetLatestDBBlockNumber(c.blockchain, false)
+		latestIndexedBlock, latestErr := c.Store.GetLatestDBBlockNumber(c.blockchain, false)
 
 		// If there are no rows in result then set startBlock with shift
 		if latestErr != nil {
-			if latestErr.Error() != "no rows in result set" {
-				log.Fatalf("Failed to get latest indexed block: %v", latestErr)
+			if !errors.Is(latestErr, indexer.ErrNoRowsIndexed) {
+				return fmt.Errorf("failed to get latest indexed block: %w", latestErr)
 			}
 
-			latestIndexedBlock = uint64(CurrentBlockchainState.GetLatestBlockNumber().Int64() - c.confirmations - SeerDefaultBlockShift)
+			latestIndexedBlock = uint64(c.State.GetLatestBlockNumber().Int64() - c.confirmations - SeerDefaultBlockShift)
 			log.Printf("There are no records in database, applied shift %d to latest block number", SeerDefaultBlockShift)
 		}
 
@@ -241,10 +458,15 @@ func (c *Crawler) Start(threads int) {
 	var crawlPack CrawlPack
 
 	for {
+		if ctx.Err() != nil {
+			log.Printf("Crawler of %s is stopping at block %d", c.blockchain, c.startBlock)
+			break
+		}
+
 		endBlock = c.startBlock + dynamicBatch.GetSize()
 
 		if SEER_CRAWLER_DEBUG {
-			log.Printf("[DEBUG] [crawler.Start.1] latestBlock: %d, c.endBlock: %d, c.startBlock: %d, dynamicBatchSize: %d, PackStartBlock: %d", CurrentBlockchainState.GetLatestBlockNumber().Uint64(), endBlock, c.startBlock, dynamicBatch.GetSize(), crawlPack.PackStartBlock)
+			log.Printf("[DEBUG] [crawler.Start.1] latestBlock: %d, c.endBlock: %d, c.startBlock: %d, dynamicBatchSize: %d, PackStartBlock: %d", c.State.GetLatestBlockNumber().Uint64(), endBlock, c.startBlock, dynamicBatch.GetSize(), crawlPack.PackStartBlock)
 		}
 
 		// Check if final block specified at trigger stop
@@ -257,32 +479,39 @@ func (c *Crawler) Start(threads int) {
 		if len(crawlPack.BlocksPack) > 0 {
 			if crawlPack.PackCrawlStartTs.Add(protoDurationTimeLimit).Before(time.Now()) || crawlPack.PackSize >= protoBufferSizeLimit {
 				if papErr := crawlPack.ProcessAndPush(c.Client, c); papErr != nil {
-					log.Fatalf("failed to process and push: %v", papErr)
+					return fmt.Errorf("failed to process and push: %w", papErr)
 				}
 				crawlPack = CrawlPack{}
 			}
 		}
 
-		// Check if next iteration will overtake blockchain latest block minus confirmation
-		safeBlock := CurrentBlockchainState.GetLatestBlockNumber().Int64() - c.confirmations
+		// Check if next iteration will overtake blockchain latest block minus confirmation,
+		// or safe head if only finalized blocks are crawled
+		// Latest block is pushed by new heads subscription if it is active
+		safeBlock := c.crawlHead()
 		if endBlock >= safeBlock {
-			latestBlockNumber, latestErr := seer_blockchain.GetLatestBlockNumberWithRetry(c.Client, retryAttempts, retryWaitTime)
-			if latestErr != nil {
-				log.Fatalf("failed to fetch latest block from blockchain: %v", latestErr)
+			if !c.headsSubscribed.Load() {
+				latestBlockNumber, latestErr := seer_blockchain.GetLatestBlockNumberWithRetry(c.Client, retryAttempts, retryWaitTime)
+				if latestErr != nil {
+					return fmt.Errorf("failed to fetch latest block from blockchain: %w", latestErr)
+				}
+				c.State.RaiseLatestBlockNumber(latestBlockNumber)
+			}
+			if safeErr := c.updateSafeHead(); safeErr != nil {
+				log.Printf("Failed to update safe head of %s: %v", c.blockchain, safeErr)
 			}
-			CurrentBlockchainState.RaiseLatestBlockNumber(latestBlockNumber)
 		}
 
 		// Check if next iteration is again overtake blockchain latest block minus confirmation
 		if endBlock > safeBlock {
 			// Identified slow blockchain, reducing batch size
-			if time.Since(CurrentBlockchainState.GetLatestUpdateTs()) >= retryWaitTime {
+			if time.Since(c.State.GetLatestUpdateTs()) >= retryWaitTime {
 				dynamicBatch.DynamicDecreaseSize(safeBlock - c.startBlock)
 			}
 
-			log.Printf("Waiting %d seconds for new blocks to be mined. Current blockchain latest block number: %d, calculated crawler end block: %d and dynamic batch size set to: %d", int(waitForBlocksTime.Seconds()), CurrentBlockchainState.GetLatestBlockNumber().Int64(), endBlock, dynamicBatch.GetSize())
+			log.Printf("Waiting %d seconds for new blocks to be mined. Current blockchain latest block number: %d, safe block number: %v, calculated crawler end block: %d and dynamic batch size set to: %d", int(waitForBlocksTime.Seconds()), c.State.GetLatestBlockNumber().Int64(), c.State.GetSafeBlockNumber(), endBlock, dynamicBatch.GetSize())
 
-			time.Sleep(waitForBlocksTime)
+			c.waitForHeads(ctx, waitForBlocksTime)
 			if waitForBlocksTime < maxWaitForBlocksTime {
 				waitForBlocksTime = waitForBlocksTime * 2
 			}
@@ -293,7 +522,7 @@ func (c *Crawler) Start(threads int) {
 		dynamicBatch.Size = c.batchSize
 
 		if SEER_CRAWLER_DEBUG {
-			log.Printf("[DEBUG] [crawler.Start.2] latestBlock: %d, c.endBlock: %d, c.startBlock: %d, dynamicBatchSize: %d, PackStartBlock: %d", CurrentBlockchainState.GetLatestBlockNumber().Uint64(), endBlock, c.startBlock, dynamicBatch.GetSize(), crawlPack.PackStartBlock)
+			log.Printf("[DEBUG] [crawler.Start.2] latestBlock: %d, c.endBlock: %d, c.startBlock: %d, dynamicBatchSize: %d, PackStartBlock: %d", c.State.GetLatestBlockNumber().Uint64(), endBlock, c.startBlock, dynamicBatch.GetSize(), crawlPack.PackStartBlock)
 		}
 
 		// Check if crawlPack not yet initialized
@@ -305,9 +534,17 @@ func (c *Crawler) Start(threads int) {
 			// Fetch blocks with transactions
 			blocks, blocksIndex, blocksSize, crawlErr := seer_blockchain.CrawlEntireBlocks(c.Client, new(big.Int).SetInt64(c.startBlock), new(big.Int).SetInt64(endBlock), SEER_CRAWLER_DEBUG, threads)
 			if crawlErr != nil {
+				var reorgErr *seer_common.ReorgDetected
+				if errors.As(crawlErr, &reorgErr) {
+					log.Printf("Reorg detected at block %d while crawling %d-%d, batch will be fetched again", reorgErr.Height, c.startBlock, endBlock)
+				}
 				return fmt.Errorf("failed to crawl blocks, txs and events: %w", crawlErr)
 			}
 
+			metrics.BlocksCrawled.WithLabelValues(c.blockchain).Add(float64(endBlock - c.startBlock + 1))
+			metrics.SetBlocksBehindHead(c.blockchain, "crawler", c.State.GetLatestBlockNumber().Uint64(), uint64(endBlock))
+			health.SetProcessedBlock(uint64(endBlock))
+
 			crawlPack.PackSize += int64(blocksSize)
 			crawlPack.BlocksPack = append(crawlPack.BlocksPack, blocks...)
 			crawlPack.BlocksIndexPack = append(crawlPack.BlocksIndexPack, blocksIndex...)
@@ -324,12 +561,12 @@ func (c *Crawler) Start(threads int) {
 			}
 
 			if SEER_CRAWLER_DEBUG {
-				log.Printf("[DEBUG] [crawler.Start.3] latestBlock: %d, c.endBlock: %d, c.startBlock: %d, dynamicBatchSize: %d, PackStartBlock: %d", CurrentBlockchainState.GetLatestBlockNumber().Uint64(), endBlock, c.startBlock, dynamicBatch.GetSize(), crawlPack.PackStartBlock)
+				log.Printf("[DEBUG] [crawler.Start.3] latestBlock: %d, c.endBlock: %d, c.startBlock: %d, dynamicBatchSize: %d, PackStartBlock: %d", c.State.GetLatestBlockNumber().Uint64(), endBlock, c.startBlock, dynamicBatch.GetSize(), crawlPack.PackStartBlock)
 			}
 
 			return nil
 		}); retryErr != nil {
-			log.Fatalf("Crawl retry operation failed: %v", retryErr)
+			return fmt.Errorf("crawl retry operation failed: %w", retryErr)
 		}
 
 		if isFinal {
@@ -342,10 +579,11 @@ func (c *Crawler) Start(threads int) {
 	// If after stop there are some blocks, do not leave it
 	if len(crawlPack.BlocksPack) > 0 {
 		if papErr := crawlPack.ProcessAndPush(c.Client, c); papErr != nil {
-			log.Fatalf("failed to process and push: %v", papErr)
+			return fmt.Errorf("failed to process and push: %w", papErr)
 		}
-		crawlPack = CrawlPack{}
 	}
+
+	return nil
 }
 
 // TODO: methods here for additional functionalities
@@ -0,0 +1,196 @@
+package crawler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	seer_blockchain "github.com/G7DAO/seer/blockchain"
+	"github.com/G7DAO/seer/blockchain/ethereum"
+	"github.com/G7DAO/seer/indexer"
+	"github.com/G7DAO/seer/storage"
+)
+
+const testChain = "ethereum"
+
+// memoryStorage keeps batch objects by their keys.
+type memoryStorage struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{objects: make(map[string][]byte)}
+}
+
+func (s *memoryStorage) Save(batchDir, filename string, bf bytes.Buffer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.objects[filepath.Join(batchDir, filename)] = bf.Bytes()
+	return nil
+}
+
+func (s *memoryStorage) Read(key string) (bytes.Buffer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, exists := s.objects[key]
+	if !exists {
+		return bytes.Buffer{}, fmt.Errorf("object %s not found", key)
+	}
+	return *bytes.NewBuffer(data), nil
+}
+
+func (s *memoryStorage) ReadBatch(readItems []storage.ReadItem) (map[string][]string, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (s *memoryStorage) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.objects, key)
+	return nil
+}
+
+func (s *memoryStorage) List(ctx context.Context, delim, blockBatch string, timeout int, returnFunc storage.ListReturnFunc) ([]string, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+// stubClient returns empty blocks for requested ranges and records these ranges, methods
+// which are not overridden panic.
+type stubClient struct {
+	seer_blockchain.ChainClient
+
+	fetched [][2]uint64
+}
+
+func (c *stubClient) FetchAsProtoBlocksWithEvents(from, to *big.Int, debug bool, maxRequests int) ([]proto.Message, []indexer.BlockIndex, uint64, error) {
+	c.fetched = append(c.fetched, [2]uint64{from.Uint64(), to.Uint64()})
+
+	var blocks []proto.Message
+	var blocksIndex []indexer.BlockIndex
+	for number := from.Uint64(); number <= to.Uint64(); number++ {
+		blocks = append(blocks, &ethereum.EthereumBlock{BlockNumber: number})
+		blocksIndex = append(blocksIndex, indexer.NewBlockIndex(testChain, number, fmt.Sprintf("0x%064x", number), 0, fmt.Sprintf("0x%064x", number-1), 0, "", 0))
+	}
+
+	return blocks, blocksIndex, 0, nil
+}
+
+func (c *stubClient) ProcessBlocksToBatch(msgs []proto.Message) (proto.Message, error) {
+	batch := &ethereum.EthereumBlocksBatch{}
+	for _, msg := range msgs {
+		batch.Blocks = append(batch.Blocks, msg.(*ethereum.EthereumBlock))
+	}
+	return batch, nil
+}
+
+// indexBatch writes index rows of blocks from start to end with path of their batch object.
+func indexBatch(t *testing.T, store *indexer.MemoryStore, start, end uint64) string {
+	t.Helper()
+
+	path := storage.SeerCrawlerStorageLayout.Key(testChain, start, end, storage.SeerCrawlerStorageCompression)
+	var blocksIndex []indexer.BlockIndex
+	for number := start; number <= end; number++ {
+		blocksIndex = append(blocksIndex, indexer.NewBlockIndex(testChain, number, "", 0, "", 0, path, 0))
+	}
+	if err := store.WriteIndexes(testChain, blocksIndex); err != nil {
+		t.Fatalf("WriteIndexes: %v", err)
+	}
+
+	return path
+}
+
+func newTestCrawler(client *stubClient, storageInstance *memoryStorage, store *indexer.MemoryStore) *Crawler {
+	return &Crawler{
+		Client:          client,
+		StorageInstance: storageInstance,
+		Store:           store,
+		blockchain:      testChain,
+		recoveryDepth:   100,
+	}
+}
+
+func TestRecoverBatchesRecrawlsMissingObjects(t *testing.T) {
+	store := indexer.NewMemoryStore()
+	storageInstance := newMemoryStorage()
+	client := &stubClient{}
+
+	savedPath := indexBatch(t, store, 100, 109)
+	storageInstance.Save(filepath.Dir(savedPath), filepath.Base(savedPath), *bytes.NewBufferString("batch"))
+	missingPath := indexBatch(t, store, 110, 119)
+
+	crawler := newTestCrawler(client, storageInstance, store)
+	if err := crawler.RecoverBatches(1); err != nil {
+		t.Fatalf("RecoverBatches: %v", err)
+	}
+
+	if len(client.fetched) != 1 || client.fetched[0] != [2]uint64{110, 119} {
+		t.Fatalf("fetched ranges %v, want only [110 119]", client.fetched)
+	}
+
+	data, err := storageInstance.Read(missingPath)
+	if err != nil {
+		t.Fatalf("batch object of recovered blocks is missing: %v", err)
+	}
+	var batch ethereum.EthereumBlocksBatch
+	if err := proto.Unmarshal(data.Bytes(), &batch); err != nil {
+		t.Fatalf("invalid batch object: %v", err)
+	}
+	if len(batch.Blocks) != 10 {
+		t.Fatalf("batch object has %d blocks, want 10", len(batch.Blocks))
+	}
+
+	batches, err := store.ReadIndexedBatches(testChain, 0)
+	if err != nil {
+		t.Fatalf("ReadIndexedBatches: %v", err)
+	}
+	if len(batches) != 2 {
+		t.Fatalf("got %d indexed batches, want 2", len(batches))
+	}
+	for i, expected := range []indexer.IndexedBatch{
+		{Path: savedPath, MinBlockNumber: 100, MaxBlockNumber: 109, BlocksCount: 10},
+		{Path: missingPath, MinBlockNumber: 110, MaxBlockNumber: 119, BlocksCount: 10},
+	} {
+		if batches[i] != expected {
+			t.Fatalf("indexed batch %d is %+v, want %+v", i, batches[i], expected)
+		}
+	}
+}
+
+func TestRecoverBatchesSkipsBatchesOutOfDepth(t *testing.T) {
+	store := indexer.NewMemoryStore()
+	client := &stubClient{}
+
+	indexBatch(t, store, 100, 109)
+	indexBatch(t, store, 300, 309)
+
+	crawler := newTestCrawler(client, newMemoryStorage(), store)
+	if err := crawler.RecoverBatches(1); err != nil {
+		t.Fatalf("RecoverBatches: %v", err)
+	}
+
+	if len(client.fetched) != 1 || client.fetched[0] != [2]uint64{300, 309} {
+		t.Fatalf("fetched ranges %v, want only [300 309]", client.fetched)
+	}
+}
+
+func TestRecoverBatchesWithoutIndexedBlocks(t *testing.T) {
+	client := &stubClient{}
+
+	crawler := newTestCrawler(client, newMemoryStorage(), indexer.NewMemoryStore())
+	if err := crawler.RecoverBatches(1); err != nil {
+		t.Fatalf("RecoverBatches: %v", err)
+	}
+	if len(client.fetched) != 0 {
+		t.Fatalf("fetched ranges %v without indexed blocks", client.fetched)
+	}
+}
@@ -0,0 +1,83 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	seer_common "github.com/G7DAO/seer/blockchain/common"
+)
+
+// Time between attempts to restore failed new heads subscription, crawler falls back to
+// polling latest block number meanwhile.
+var HeadsResubscribeInterval = 5 * time.Second
+
+// SubscribeHeads follows chain tip with WebSocket subscription in background. Every new head
+// raises latest block number of blockchain state and wakes up crawler waiting for new blocks.
+func (c *Crawler) SubscribeHeads(ctx context.Context) error {
+	subscriber, ok := c.Client.(seer_common.HeadSubscriber)
+	if !ok {
+		return fmt.Errorf("client of chain %s does not support new heads subscription", c.blockchain)
+	}
+
+	heads := make(chan *big.Int, 16)
+	sub, err := subscriber.SubscribeNewHeads(ctx, heads)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to new heads: %w", err)
+	}
+
+	c.headsNotify = make(chan struct{}, 1)
+	c.headsSubscribed.Store(true)
+	log.Printf("Subscribed to new heads of %s blockchain", c.blockchain)
+
+	go func() {
+		for {
+			select {
+			case head := <-heads:
+				c.State.RaiseLatestBlockNumber(head)
+				select {
+				case c.headsNotify <- struct{}{}:
+				default:
+				}
+			case subErr := <-sub.Err():
+				c.headsSubscribed.Store(false)
+				log.Printf("New heads subscription failed, polling latest block until it is restored: %v", subErr)
+
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(HeadsResubscribeInterval):
+					}
+
+					sub, err = subscriber.SubscribeNewHeads(ctx, heads)
+					if err == nil {
+						break
+					}
+					log.Printf("Failed to resubscribe to new heads: %v", err)
+				}
+
+				c.headsSubscribed.Store(true)
+				log.Printf("New heads subscription restored")
+			case <-ctx.Done():
+				sub.Unsubscribe()
+				c.headsSubscribed.Store(false)
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// waitForHeads sleeps until new head arrives from subscription, timeout passes or ctx is
+// cancelled.
+func (c *Crawler) waitForHeads(ctx context.Context, timeout time.Duration) {
+	select {
+	case <-c.headsNotify:
+	case <-time.After(timeout):
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,243 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	seer_common "github.com/G7DAO/seer/blockchain/common"
+	"github.com/G7DAO/seer/logging"
+)
+
+// ChainCrawlConfig is configuration of crawler of one chain, zero fields are taken from
+// defaults of supervisor config. Fields match flags of crawler command.
+type ChainCrawlConfig struct {
+	Chain           string `yaml:"chain"`
+	RPCURL          string `yaml:"rpc_url"`
+	StartBlock      int64  `yaml:"start_block"`
+	FinalBlock      int64  `yaml:"final_block"`
+	Confirmations   int64  `yaml:"confirmations"`
+	BatchSize       int64  `yaml:"batch_size"`
+	Timeout         int    `yaml:"timeout"`
+	Threads         int    `yaml:"threads"`
+	ProtoSizeLimit  uint64 `yaml:"proto_size_limit"`
+	ProtoTimeLimit  int    `yaml:"proto_time_limit"`
+	RetryWait       int    `yaml:"retry_wait"`
+	RetryMultiplier int    `yaml:"retry_multiplier"`
+	RecoveryDepth   int64  `yaml:"recovery_depth"`
+	WriteWorkers    int    `yaml:"write_workers"`
+	SubscribeHeads  bool   `yaml:"subscribe_heads"`
+	Finality        string `yaml:"finality"`
+	FinalizedOnly   bool   `yaml:"finalized_only"`
+}
+
+// SupervisorConfig lists chains crawled by one process.
+type SupervisorConfig struct {
+	BaseDir string `yaml:"base_dir"`
+	// Seconds to wait before failed crawler is started again
+	RestartDelay int                `yaml:"restart_delay"`
+	Defaults     ChainCrawlConfig   `yaml:"defaults"`
+	Chains       []ChainCrawlConfig `yaml:"chains"`
+}
+
+// DefaultChainCrawlConfig has the same values as defaults of crawler command flags.
+var DefaultChainCrawlConfig = ChainCrawlConfig{
+	Confirmations:   10,
+	BatchSize:       10,
+	Timeout:         30,
+	Threads:         1,
+	ProtoSizeLimit:  25,
+	ProtoTimeLimit:  300,
+	RetryWait:       5000,
+	RetryMultiplier: 24,
+	RecoveryDepth:   10000,
+}
+
+// withDefaults fills zero fields of config from defaults.
+func (cfg ChainCrawlConfig) withDefaults(defaults ChainCrawlConfig) ChainCrawlConfig {
+	if cfg.RPCURL == "" {
+		cfg.RPCURL = defaults.RPCURL
+	}
+	if cfg.Confirmations == 0 {
+		cfg.Confirmations = defaults.Confirmations
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = defaults.BatchSize
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaults.Timeout
+	}
+	if cfg.Threads == 0 {
+		cfg.Threads = defaults.Threads
+	}
+	if cfg.ProtoSizeLimit == 0 {
+		cfg.ProtoSizeLimit = defaults.ProtoSizeLimit
+	}
+	if cfg.ProtoTimeLimit == 0 {
+		cfg.ProtoTimeLimit = defaults.ProtoTimeLimit
+	}
+	if cfg.RetryWait == 0 {
+		cfg.RetryWait = defaults.RetryWait
+	}
+	if cfg.RetryMultiplier == 0 {
+		cfg.RetryMultiplier = defaults.RetryMultiplier
+	}
+	if cfg.RecoveryDepth == 0 {
+		cfg.RecoveryDepth = defaults.RecoveryDepth
+	}
+	if cfg.WriteWorkers == 0 {
+		cfg.WriteWorkers = defaults.WriteWorkers
+	}
+	if cfg.Finality == "" {
+		cfg.Finality = defaults.Finality
+	}
+	cfg.SubscribeHeads = cfg.SubscribeHeads || defaults.SubscribeHeads
+	cfg.FinalizedOnly = cfg.FinalizedOnly || defaults.FinalizedOnly
+
+	return cfg
+}
+
+// LoadSupervisorConfig reads YAML config of supervisor. Environment variables in RPC URLs are
+// expanded, so API keys could be kept out of config file.
+func LoadSupervisorConfig(path string) (*SupervisorConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read supervisor config: %w", err)
+	}
+
+	var config SupervisorConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse supervisor config %s: %w", path, err)
+	}
+
+	if len(config.Chains) == 0 {
+		return nil, fmt.Errorf("no chains in supervisor config %s", path)
+	}
+	if config.RestartDelay <= 0 {
+		config.RestartDelay = 30
+	}
+
+	defaults := config.Defaults.withDefaults(DefaultChainCrawlConfig)
+	seen := make(map[string]bool)
+	for i, chainConfig := range config.Chains {
+		if chainConfig.Chain == "" {
+			return nil, fmt.Errorf("chain of entry %d of supervisor config is empty", i)
+		}
+		if seen[chainConfig.Chain] {
+			return nil, fmt.Errorf("chain %s is listed in supervisor config twice", chainConfig.Chain)
+		}
+		seen[chainConfig.Chain] = true
+
+		chainConfig = chainConfig.withDefaults(defaults)
+		chainConfig.RPCURL = os.ExpandEnv(chainConfig.RPCURL)
+		if chainConfig.Finality != "" {
+			if _, err := seer_common.ParseFinality(chainConfig.Finality); err != nil {
+				return nil, fmt.Errorf("invalid finality of chain %s: %w", chainConfig.Chain, err)
+			}
+		}
+		config.Chains[i] = chainConfig
+	}
+
+	return &config, nil
+}
+
+// Supervisor runs crawlers of several chains in one process. Each crawler has its own client,
+// blockchain state, write pipeline and threads, and continues from blocks indexed for its
+// chain. Failed crawler is started again after restart delay, others are not affected.
+type Supervisor struct {
+	Crawlers map[string]*Crawler
+
+	config *SupervisorConfig
+}
+
+// NewSupervisor creates crawlers of all chains of config, indexes database connection should
+// be initialized before.
+func NewSupervisor(config *SupervisorConfig) (*Supervisor, error) {
+	crawlers := make(map[string]*Crawler, len(config.Chains))
+	for _, cfg := range config.Chains {
+		crawler, err := NewCrawler(cfg.Chain, cfg.RPCURL, cfg.StartBlock, cfg.FinalBlock, cfg.Confirmations, cfg.BatchSize, cfg.Timeout, config.BaseDir, cfg.ProtoSizeLimit, cfg.ProtoTimeLimit, cfg.RetryWait, cfg.RetryMultiplier, cfg.RecoveryDepth, cfg.WriteWorkers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create crawler of %s: %w", cfg.Chain, err)
+		}
+
+		crawler.State = &BlockchainState{}
+		if cfg.Finality != "" {
+			finality, _ := seer_common.ParseFinality(cfg.Finality)
+			crawler.Finality = finality
+		} else if finality, configured, finalityErr := seer_common.ChainFinalityFor(cfg.Chain); finalityErr != nil {
+			return nil, finalityErr
+		} else if configured {
+			crawler.Finality = finality
+		}
+		crawler.FinalizedOnly = cfg.FinalizedOnly
+
+		crawlers[cfg.Chain] = crawler
+	}
+
+	return &Supervisor{Crawlers: crawlers, config: config}, nil
+}
+
+// Run starts crawlers and blocks until all of them stop. Crawlers stop on cancellation of ctx
+// or when their final blocks are reached.
+func (s *Supervisor) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, cfg := range s.config.Chains {
+		wg.Add(1)
+		go func(cfg ChainCrawlConfig) {
+			defer wg.Done()
+			s.runChain(ctx, cfg, s.Crawlers[cfg.Chain])
+		}(cfg)
+	}
+	wg.Wait()
+
+	log.Printf("All crawlers of supervisor are stopped")
+}
+
+// runChain runs crawler of chain until it returns without error or ctx is cancelled.
+func (s *Supervisor) runChain(ctx context.Context, cfg ChainCrawlConfig, crawler *Crawler) {
+	logger := logging.Chain(cfg.Chain)
+	restartDelay := time.Duration(s.config.RestartDelay) * time.Second
+
+	if cfg.SubscribeHeads {
+		if err := crawler.SubscribeHeads(ctx); err != nil {
+			logger.Error("Failed to subscribe to new heads, latest block is polled", logging.ErrorKey, err)
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := s.startCrawler(ctx, crawler, cfg.Threads)
+		if err == nil {
+			logger.Info("Crawler is stopped")
+			return
+		}
+
+		logger.Error("Crawler failed, it is started again after delay", "attempt", attempt, "delay", restartDelay.String(), logging.ErrorKey, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(restartDelay):
+		}
+
+		// Blocks of pack which was not pushed are crawled again from indexes database
+		crawler.startBlock = 0
+	}
+}
+
+func (s *Supervisor) startCrawler(ctx context.Context, crawler *Crawler, threads int) error {
+	latestBlockNumber, err := crawler.Client.GetLatestBlockNumber()
+	if err != nil {
+		return fmt.Errorf("failed to get latest block number: %w", err)
+	}
+	if crawler.startBlock > latestBlockNumber.Int64() {
+		return fmt.Errorf("start block %d is greater than latest block %d", crawler.startBlock, latestBlockNumber.Int64())
+	}
+	crawler.State.RaiseLatestBlockNumber(latestBlockNumber)
+
+	return crawler.Start(ctx, threads)
+}
@@ -0,0 +1,118 @@
+// Package devsync replays batches already stored by crawler into local database, so labels of
+// a block range could be inspected and queried during development without running synchronizer
+// against customer databases.
+package devsync
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	seer_common "github.com/G7DAO/seer/blockchain/common"
+	"github.com/G7DAO/seer/indexer"
+	"github.com/G7DAO/seer/storage"
+)
+
+// Options of replay, Abis are keyed by lowercase contract address and selector in the same
+// way as synchronizer gets them from ABI jobs.
+type Options struct {
+	Blockchain string
+	FromBlock  uint64
+	ToBlock    uint64
+	Abis       map[string]map[string]*indexer.AbiEntry
+	Threads    int
+}
+
+// Report summarizes replay.
+type Report struct {
+	Batches      int `json:"batches"`
+	Events       int `json:"events"`
+	Transactions int `json:"transactions"`
+}
+
+// Replay reads batches of block range from storage through blocks index, decodes them with
+// ABIs of options and writes labels of blocks in range into sink.
+func Replay(client seer_common.ChainClient, store indexer.IndexStore, storageInstance storage.Storer, sink Sink, opts Options) (*Report, error) {
+	if opts.ToBlock < opts.FromBlock {
+		return nil, fmt.Errorf("block range %d-%d is empty", opts.FromBlock, opts.ToBlock)
+	}
+	if len(opts.Abis) == 0 {
+		return nil, fmt.Errorf("at least one ABI is required to decode labels")
+	}
+	if opts.Threads <= 0 {
+		opts.Threads = 1
+	}
+
+	abis := make(map[string]map[string]*indexer.AbiEntry, len(opts.Abis))
+	for address, entries := range opts.Abis {
+		abis[strings.ToLower(address)] = entries
+	}
+
+	batches, err := store.ReadIndexedBatches(opts.Blockchain, opts.FromBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read indexed batches: %w", err)
+	}
+
+	if err := sink.Prepare(opts.Blockchain); err != nil {
+		return nil, fmt.Errorf("failed to prepare target database: %w", err)
+	}
+
+	report := &Report{}
+	for _, batch := range batches {
+		if batch.MinBlockNumber > opts.ToBlock {
+			break
+		}
+		if batch.Incomplete {
+			log.Printf("Batch %s of blocks %d-%d is not fully indexed, labels could be missing", batch.Path, batch.MinBlockNumber, batch.MaxBlockNumber)
+		}
+
+		rawData, err := storageInstance.Read(batch.Path)
+		if err != nil {
+			return report, fmt.Errorf("failed to read batch %s: %w", batch.Path, err)
+		}
+
+		events, transactions, _, err := client.DecodeProtoEntireBlockToLabels(&rawData, abis, false, opts.Threads)
+		if err != nil {
+			return report, fmt.Errorf("failed to decode batch %s: %w", batch.Path, err)
+		}
+
+		// Batch boundaries do not follow requested range
+		events = filterEvents(events, opts.FromBlock, opts.ToBlock)
+		transactions = filterTransactions(transactions, opts.FromBlock, opts.ToBlock)
+
+		if err := sink.Write(opts.Blockchain, events, transactions); err != nil {
+			return report, fmt.Errorf("failed to write labels of batch %s: %w", batch.Path, err)
+		}
+
+		report.Batches++
+		report.Events += len(events)
+		report.Transactions += len(transactions)
+		log.Printf("Replayed batch %s of blocks %d-%d: %d events, %d transactions", batch.Path, batch.MinBlockNumber, batch.MaxBlockNumber, len(events), len(transactions))
+	}
+
+	if report.Batches == 0 {
+		log.Printf("No stored batches of %s found for blocks %d-%d", opts.Blockchain, opts.FromBlock, opts.ToBlock)
+	}
+
+	return report, nil
+}
+
+func filterEvents(events []indexer.EventLabel, fromBlock, toBlock uint64) []indexer.EventLabel {
+	filtered := events[:0]
+	for _, event := range events {
+		if event.BlockNumber >= fromBlock && event.BlockNumber <= toBlock {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+func filterTransactions(transactions []indexer.TransactionLabel, fromBlock, toBlock uint64) []indexer.TransactionLabel {
+	filtered := transactions[:0]
+	for _, transaction := range transactions {
+		if transaction.BlockNumber >= fromBlock && transaction.BlockNumber <= toBlock {
+			filtered = append(filtered, transaction)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,177 @@
+package devsync
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+// Sink is a target database of replayed labels.
+type Sink interface {
+	// Prepare creates labels table of chain if it does not exist
+	Prepare(blockchain string) error
+	Write(blockchain string, events []indexer.EventLabel, transactions []indexer.TransactionLabel) error
+	Close() error
+}
+
+// NewSink creates sink of target in format postgres://... or sqlite://<path>.
+func NewSink(target string) (Sink, error) {
+	switch {
+	case strings.HasPrefix(target, "postgres://"), strings.HasPrefix(target, "postgresql://"):
+		store, err := indexer.NewPostgreSQLpgxWithCustomURI(target)
+		if err != nil {
+			return nil, err
+		}
+		return &PostgresSink{store: store}, nil
+	case strings.HasPrefix(target, "sqlite://"):
+		path := strings.TrimPrefix(target, "sqlite://")
+		if path == "" {
+			return nil, fmt.Errorf("path of SQLite database is required, e.g. sqlite://dev.db")
+		}
+		return NewSQLiteSink(path)
+	}
+
+	return nil, fmt.Errorf("unsupported target %q, expected postgres:// or sqlite:// URI", target)
+}
+
+// PostgresSink writes labels with the same writer as synchronizer uses for customer databases.
+type PostgresSink struct {
+	store *indexer.PostgreSQLpgx
+}
+
+func (s *PostgresSink) Prepare(blockchain string) error {
+	return s.store.EnsureLabelsTable(blockchain)
+}
+
+func (s *PostgresSink) Write(blockchain string, events []indexer.EventLabel, transactions []indexer.TransactionLabel) error {
+	return s.store.WriteDataToCustomerDB(blockchain, transactions, events, nil)
+}
+
+func (s *PostgresSink) Close() error {
+	s.store.Close()
+	return nil
+}
+
+// SQLiteSink writes labels into SQLite database file through sqlite3 command line shell, so
+// no database driver is linked into binary. Labels have the same IDs as in customer
+// databases and repeated replays of the same range do not create duplicates.
+type SQLiteSink struct {
+	path   string
+	binary string
+}
+
+func NewSQLiteSink(path string) (*SQLiteSink, error) {
+	binary, err := exec.LookPath("sqlite3")
+	if err != nil {
+		return nil, fmt.Errorf("sqlite3 command line shell is required for sqlite:// target: %w", err)
+	}
+
+	return &SQLiteSink{path: path, binary: binary}, nil
+}
+
+func (s *SQLiteSink) exec(script string) error {
+	cmd := exec.Command(s.binary, "-bail", s.path)
+	cmd.Stdin = strings.NewReader(script)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("sqlite3 failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+func (s *SQLiteSink) Prepare(blockchain string) error {
+	tableName := indexer.LabelsTableName(blockchain)
+
+	return s.exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %[1]s (
+	id TEXT PRIMARY KEY,
+	label TEXT NOT NULL,
+	transaction_hash TEXT NOT NULL,
+	log_index INTEGER,
+	block_number INTEGER NOT NULL,
+	block_hash TEXT NOT NULL,
+	block_timestamp INTEGER NOT NULL,
+	caller_address BLOB,
+	origin_address BLOB,
+	address BLOB NOT NULL,
+	label_name TEXT,
+	label_type TEXT,
+	label_data TEXT
+);
+CREATE INDEX IF NOT EXISTS ix_%[1]s_address_block_number ON %[1]s (address, block_number);
+CREATE INDEX IF NOT EXISTS ix_%[1]s_label_name ON %[1]s (label_name);
+`, tableName))
+}
+
+func (s *SQLiteSink) Write(blockchain string, events []indexer.EventLabel, transactions []indexer.TransactionLabel) error {
+	if len(events) == 0 && len(transactions) == 0 {
+		return nil
+	}
+
+	tableName := indexer.LabelsTableName(blockchain)
+
+	var script strings.Builder
+	script.WriteString("BEGIN;\n")
+	for _, event := range events {
+		fmt.Fprintf(&script, "INSERT OR IGNORE INTO %s (id, label, transaction_hash, log_index, block_number, block_hash, block_timestamp, caller_address, origin_address, address, label_name, label_type, label_data) VALUES (%s, %s, %s, %d, %d, %s, %d, %s, %s, %s, %s, %s, %s);\n",
+			tableName,
+			sqliteText(indexer.EventLabelID(blockchain, event).String()),
+			sqliteText(event.Label),
+			sqliteText(event.TransactionHash),
+			event.LogIndex,
+			event.BlockNumber,
+			sqliteText(event.BlockHash),
+			event.BlockTimestamp,
+			sqliteAddress(event.CallerAddress),
+			sqliteAddress(event.OriginAddress),
+			sqliteAddress(event.Address),
+			sqliteText(event.LabelName),
+			sqliteText(event.LabelType),
+			sqliteText(event.LabelData),
+		)
+	}
+	for _, transaction := range transactions {
+		fmt.Fprintf(&script, "INSERT OR IGNORE INTO %s (id, label, transaction_hash, block_number, block_hash, block_timestamp, caller_address, origin_address, address, label_name, label_type, label_data) VALUES (%s, %s, %s, %d, %s, %d, %s, %s, %s, %s, %s, %s);\n",
+			tableName,
+			sqliteText(indexer.TransactionLabelID(blockchain, transaction).String()),
+			sqliteText(transaction.Label),
+			sqliteText(transaction.TransactionHash),
+			transaction.BlockNumber,
+			sqliteText(transaction.BlockHash),
+			transaction.BlockTimestamp,
+			sqliteAddress(transaction.CallerAddress),
+			sqliteAddress(transaction.OriginAddress),
+			sqliteAddress(transaction.Address),
+			sqliteText(transaction.LabelName),
+			sqliteText(transaction.LabelType),
+			sqliteText(transaction.LabelData),
+		)
+	}
+	script.WriteString("COMMIT;\n")
+
+	return s.exec(script.String())
+}
+
+func (s *SQLiteSink) Close() error {
+	return nil
+}
+
+func sqliteText(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// sqliteAddress returns address as blob literal, the same bytes as BYTEA columns of customer
+// databases contain.
+func sqliteAddress(address string) string {
+	decoded, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(address), "0x"))
+	if err != nil || len(decoded) == 0 {
+		return "NULL"
+	}
+	return fmt.Sprintf("X'%x'", decoded)
+}
@@ -0,0 +1,255 @@
+// Package export writes decoded labels of customer database to files in formats used by
+// analysts, labels are read page by page so large ranges are not kept in memory.
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+// Formats of export
+const (
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+)
+
+// Label types of export, LabelTypeAll exports events and then transactions
+const (
+	LabelTypeEvent       = "event"
+	LabelTypeTransaction = "tx_call"
+	LabelTypeAll         = "all"
+)
+
+// LabelColumns are columns of exported labels in default order, log_index is empty for
+// transactions.
+var LabelColumns = []string{
+	"block_number",
+	"block_timestamp",
+	"block_hash",
+	"transaction_hash",
+	"log_index",
+	"address",
+	"caller_address",
+	"origin_address",
+	"label",
+	"label_type",
+	"label_name",
+	"label_data",
+}
+
+// ParseColumns validates comma separated columns, empty spec selects all columns.
+func ParseColumns(spec string) ([]string, error) {
+	if strings.TrimSpace(spec) == "" {
+		return LabelColumns, nil
+	}
+
+	known := make(map[string]bool, len(LabelColumns))
+	for _, column := range LabelColumns {
+		known[column] = true
+	}
+
+	var columns []string
+	for _, column := range strings.Split(spec, ",") {
+		column = strings.TrimSpace(column)
+		if !known[column] {
+			return nil, fmt.Errorf("unknown column %q, expected some of %s", column, strings.Join(LabelColumns, ","))
+		}
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+// row is exported label with values of all columns.
+type row map[string]any
+
+func eventRow(label indexer.EventLabel) row {
+	return row{
+		"block_number":     label.BlockNumber,
+		"block_timestamp":  label.BlockTimestamp,
+		"block_hash":       label.BlockHash,
+		"transaction_hash": label.TransactionHash,
+		"log_index":        label.LogIndex,
+		"address":          label.Address,
+		"caller_address":   label.CallerAddress,
+		"origin_address":   label.OriginAddress,
+		"label":            label.Label,
+		"label_type":       label.LabelType,
+		"label_name":       label.LabelName,
+		"label_data":       label.LabelData,
+	}
+}
+
+func transactionRow(label indexer.TransactionLabel) row {
+	return row{
+		"block_number":     label.BlockNumber,
+		"block_timestamp":  label.BlockTimestamp,
+		"block_hash":       label.BlockHash,
+		"transaction_hash": label.TransactionHash,
+		"log_index":        nil,
+		"address":          label.Address,
+		"caller_address":   label.CallerAddress,
+		"origin_address":   label.OriginAddress,
+		"label":            label.Label,
+		"label_type":       label.LabelType,
+		"label_name":       label.LabelName,
+		"label_data":       label.LabelData,
+	}
+}
+
+// Writer writes labels with selected columns.
+type Writer interface {
+	write(r row) error
+	Flush() error
+}
+
+func NewWriter(format string, output io.Writer, columns []string) (Writer, error) {
+	switch format {
+	case FormatJSONL:
+		return &jsonlWriter{output: output, columns: columns}, nil
+	case FormatCSV:
+		return &csvWriter{writer: csv.NewWriter(output), columns: columns}, nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q, expected jsonl or csv", format)
+	}
+}
+
+// jsonlWriter writes label per line as JSON object with keys in order of columns. Decoded
+// label_data is embedded as JSON object.
+type jsonlWriter struct {
+	output  io.Writer
+	columns []string
+}
+
+func (w *jsonlWriter) write(r row) error {
+	var line bytes.Buffer
+	line.WriteByte('{')
+	for i, column := range w.columns {
+		if i > 0 {
+			line.WriteByte(',')
+		}
+
+		key, _ := json.Marshal(column)
+		line.Write(key)
+		line.WriteByte(':')
+
+		value := r[column]
+		if data, ok := value.(string); ok && column == "label_data" && json.Valid([]byte(data)) {
+			value = json.RawMessage(data)
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		line.Write(encoded)
+	}
+	line.WriteString("}\n")
+
+	_, err := w.output.Write(line.Bytes())
+	return err
+}
+
+func (w *jsonlWriter) Flush() error {
+	return nil
+}
+
+// csvWriter writes header with the first label, empty values are written for missing ones.
+type csvWriter struct {
+	writer        *csv.Writer
+	columns       []string
+	headerWritten bool
+}
+
+func (w *csvWriter) write(r row) error {
+	if !w.headerWritten {
+		if err := w.writer.Write(w.columns); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+
+	record := make([]string, len(w.columns))
+	for i, column := range w.columns {
+		switch value := r[column].(type) {
+		case nil:
+		case string:
+			record[i] = value
+		case uint64:
+			record[i] = strconv.FormatUint(value, 10)
+		default:
+			record[i] = fmt.Sprint(value)
+		}
+	}
+
+	return w.writer.Write(record)
+}
+
+func (w *csvWriter) Flush() error {
+	if !w.headerWritten {
+		if err := w.writer.Write(w.columns); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// ExportLabels writes labels of chain matching filter page by page, Limit of filter is used as
+// size of page. Returns number of written labels.
+func ExportLabels(dbConn *indexer.PostgreSQLpgx, chain, labelType string, filter indexer.LabelsFilter, writer Writer) (int, error) {
+	if labelType != LabelTypeEvent && labelType != LabelTypeTransaction && labelType != LabelTypeAll {
+		return 0, fmt.Errorf("unsupported label type %q, expected event, tx_call or all", labelType)
+	}
+
+	var count int
+	if labelType == LabelTypeEvent || labelType == LabelTypeAll {
+		pageFilter := filter
+		for {
+			page, err := dbConn.GetEventLabels(chain, pageFilter)
+			if err != nil {
+				return count, fmt.Errorf("failed to read event labels: %w", err)
+			}
+			for _, label := range page.Labels {
+				if err := writer.write(eventRow(label)); err != nil {
+					return count, err
+				}
+				count++
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			pageFilter.Cursor = page.NextCursor
+		}
+	}
+
+	if labelType == LabelTypeTransaction || labelType == LabelTypeAll {
+		pageFilter := filter
+		for {
+			page, err := dbConn.GetTransactionLabels(chain, pageFilter)
+			if err != nil {
+				return count, fmt.Errorf("failed to read transaction labels: %w", err)
+			}
+			for _, label := range page.Labels {
+				if err := writer.write(transactionRow(label)); err != nil {
+					return count, err
+				}
+				count++
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			pageFilter.Cursor = page.NextCursor
+		}
+	}
+
+	return count, writer.Flush()
+}
@@ -0,0 +1,171 @@
+// Package health serves liveness and readiness probes of long-running commands. Crawler and
+// synchronizer report processed blocks and successful database writes, readiness is decided
+// by checks of their dependencies registered at start.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Process is not alive if no block was processed for StallTimeout, zero disables the check
+var StallTimeout time.Duration
+
+// Each readiness check is limited by CheckTimeout
+var CheckTimeout = 5 * time.Second
+
+// Check returns error if dependency of process is not available.
+type Check func(ctx context.Context) error
+
+// Report is body of /healthz and /readyz responses.
+type Report struct {
+	Status             string            `json:"status"`
+	StartedAt          time.Time         `json:"started_at"`
+	LastProcessedBlock uint64            `json:"last_processed_block"`
+	LastProcessedAt    *time.Time        `json:"last_processed_at,omitempty"`
+	LastDBWriteAt      *time.Time        `json:"last_db_write_at,omitempty"`
+	Checks             map[string]string `json:"checks,omitempty"`
+}
+
+var (
+	mu                 sync.Mutex
+	startedAt          = time.Now()
+	lastProcessedBlock uint64
+	lastProcessedAt    time.Time
+	lastDBWriteAt      time.Time
+	checks             = make(map[string]Check)
+)
+
+// SetProcessedBlock records the last block processed by crawler or synchronizer.
+func SetProcessedBlock(block uint64) {
+	mu.Lock()
+	defer mu.Unlock()
+	lastProcessedBlock = block
+	lastProcessedAt = time.Now()
+}
+
+// RecordDBWrite records time of successful write to database.
+func RecordDBWrite() {
+	mu.Lock()
+	defer mu.Unlock()
+	lastDBWriteAt = time.Now()
+}
+
+// AddCheck registers readiness check, check with the same name is replaced.
+func AddCheck(name string, check Check) {
+	mu.Lock()
+	defer mu.Unlock()
+	checks[name] = check
+}
+
+func snapshot() (Report, map[string]Check) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	report := Report{StartedAt: startedAt, LastProcessedBlock: lastProcessedBlock}
+	if !lastProcessedAt.IsZero() {
+		processedAt := lastProcessedAt
+		report.LastProcessedAt = &processedAt
+	}
+	if !lastDBWriteAt.IsZero() {
+		writeAt := lastDBWriteAt
+		report.LastDBWriteAt = &writeAt
+	}
+
+	registered := make(map[string]Check, len(checks))
+	for name, check := range checks {
+		registered[name] = check
+	}
+
+	return report, registered
+}
+
+// Stalled reports whether process did not make progress for StallTimeout, process which has
+// not processed any block yet is measured from its start.
+func (r Report) Stalled(now time.Time) bool {
+	if StallTimeout <= 0 {
+		return false
+	}
+	since := r.StartedAt
+	if r.LastProcessedAt != nil {
+		since = *r.LastProcessedAt
+	}
+	return now.Sub(since) > StallTimeout
+}
+
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	report, _ := snapshot()
+
+	report.Status = "ok"
+	statusCode := http.StatusOK
+	if report.Stalled(time.Now()) {
+		report.Status = "stalled"
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	writeReport(w, statusCode, report)
+}
+
+func handleReadyz(w http.ResponseWriter, r *http.Request) {
+	report, registered := snapshot()
+
+	names := make([]string, 0, len(registered))
+	for name := range registered {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Checks are run concurrently, so slow dependency does not delay the others
+	results := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(r.Context(), CheckTimeout)
+			defer cancel()
+			results[i] = check(ctx)
+		}(i, registered[name])
+	}
+	wg.Wait()
+
+	report.Status = "ok"
+	statusCode := http.StatusOK
+	report.Checks = make(map[string]string, len(names))
+	for i, name := range names {
+		if results[i] != nil {
+			report.Checks[name] = results[i].Error()
+			report.Status = "unavailable"
+			statusCode = http.StatusServiceUnavailable
+			continue
+		}
+		report.Checks[name] = "ok"
+	}
+
+	writeReport(w, statusCode, report)
+}
+
+func writeReport(w http.ResponseWriter, statusCode int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.Printf("Failed to write health report: %v", err)
+	}
+}
+
+// Serve exposes /healthz and /readyz at addr, process exits if listener could not be started.
+func Serve(addr string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", handleHealthz)
+	mux.HandleFunc("/readyz", handleReadyz)
+
+	log.Printf("Serving health probes at %s", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Fatalf("Failed to serve health probes: %v", err)
+	}
+}
@@ -0,0 +1,262 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/jackc/pgx/v5"
+)
+
+type AbiValidationSeverity string
+
+// AnonymousEventSelectorPrefix marks jobs of anonymous events. Their logs have no signature
+// topic, so they are matched by address and shape of topics and data instead of selector.
+const AnonymousEventSelectorPrefix = "anonymous:"
+
+// AnonymousEventSelector returns selector of anonymous event job from its signature hash.
+func AnonymousEventSelector(eventID string) string {
+	return AnonymousEventSelectorPrefix + eventID
+}
+
+// IsAnonymousEventSelector returns true if job selector belongs to anonymous event.
+func IsAnonymousEventSelector(selector string) bool {
+	return strings.HasPrefix(selector, AnonymousEventSelectorPrefix)
+}
+
+const (
+	AbiValidationError   AbiValidationSeverity = "error"
+	AbiValidationWarning AbiValidationSeverity = "warning"
+	AbiValidationInfo    AbiValidationSeverity = "info"
+)
+
+// AbiValidationIssue is a problem of single ABI entry which would prevent or degrade decoding.
+type AbiValidationIssue struct {
+	Severity AbiValidationSeverity `json:"severity"`
+	Code     string                `json:"code"`
+	Index    int                   `json:"index"`
+	Name     string                `json:"name,omitempty"`
+	Selector string                `json:"selector,omitempty"`
+	Message  string                `json:"message"`
+}
+
+// AbiValidationReport is a result of ABI file check before jobs are created from it.
+type AbiValidationReport struct {
+	Chain     string               `json:"chain"`
+	Address   string               `json:"address"`
+	Entries   int                  `json:"entries"`
+	Jobs      int                  `json:"jobs"`
+	Selectors []string             `json:"selectors"`
+	Issues    []AbiValidationIssue `json:"issues"`
+}
+
+func (r *AbiValidationReport) add(severity AbiValidationSeverity, code string, index int, name, selector, message string) {
+	r.Issues = append(r.Issues, AbiValidationIssue{
+		Severity: severity,
+		Code:     code,
+		Index:    index,
+		Name:     name,
+		Selector: selector,
+		Message:  message,
+	})
+}
+
+// HasErrors returns true if at least one issue makes jobs unusable for decoding.
+func (r *AbiValidationReport) HasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == AbiValidationError {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateAbiEntries checks raw ABI entries and returns report with selectors of entries
+// which could be used as jobs. Anonymous events are accepted only if allowAnonymous is set.
+func ValidateAbiEntries(entries []map[string]interface{}, allowAnonymous bool) *AbiValidationReport {
+	report := &AbiValidationReport{Entries: len(entries), Issues: []AbiValidationIssue{}}
+	seenSelectors := make(map[string]int)
+
+	for i, entry := range entries {
+		entryType, _ := entry["type"].(string)
+		name, _ := entry["name"].(string)
+
+		if entryType != "event" && entryType != "function" {
+			report.add(AbiValidationInfo, "unsupported_type", i, name, "", fmt.Sprintf("ABI entry of type %q is skipped, only events and functions are crawled", entryType))
+			continue
+		}
+		if name == "" {
+			report.add(AbiValidationError, "missing_name", i, "", "", fmt.Sprintf("%s without name could not be matched to job", entryType))
+			continue
+		}
+
+		entryJson, err := json.Marshal(entry)
+		if err != nil {
+			report.add(AbiValidationError, "invalid_abi", i, name, "", err.Error())
+			continue
+		}
+
+		abiObj, err := abi.JSON(strings.NewReader("[" + string(entryJson) + "]"))
+		if err != nil {
+			report.add(AbiValidationError, "invalid_abi", i, name, "", err.Error())
+			continue
+		}
+
+		var selector, signature string
+		var arguments abi.Arguments
+		if entryType == "event" {
+			event := abiObj.Events[name]
+			selector = event.ID.String()
+			signature = event.Sig
+			arguments = event.Inputs
+
+			if event.Anonymous {
+				if allowAnonymous {
+					selector = AnonymousEventSelector(selector)
+					report.add(AbiValidationWarning, "anonymous_event", i, name, selector, "anonymous event is matched by count of topics and size of data, logs of other events with the same shape could be decoded as this event")
+				} else {
+					report.add(AbiValidationError, "anonymous_event", i, name, selector, "anonymous event has no signature topic, its logs could not be matched by selector, set --allow-anonymous to match them by shape")
+				}
+			}
+
+			indexedCount := 0
+			for _, input := range event.Inputs {
+				if !input.Indexed {
+					continue
+				}
+				indexedCount++
+
+				switch input.Type.T {
+				case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+					report.add(AbiValidationWarning, "indexed_dynamic_argument", i, name, selector, fmt.Sprintf("indexed argument %q of type %s is stored as hash in topic, decoded value is the hash", input.Name, input.Type.String()))
+				}
+			}
+			if indexedCount > 3 {
+				report.add(AbiValidationError, "indexed_arguments_count", i, name, selector, fmt.Sprintf("event has %d indexed arguments, at most 3 are allowed besides signature topic", indexedCount))
+			}
+		} else {
+			method := abiObj.Methods[name]
+			selector = fmt.Sprintf("0x%x", method.ID)
+			signature = method.Sig
+			arguments = method.Inputs
+		}
+
+		for _, argument := range arguments {
+			if argument.Name == "" {
+				report.add(AbiValidationWarning, "unnamed_argument", i, name, selector, fmt.Sprintf("argument of type %s has no name, unnamed arguments overwrite each other in label data", argument.Type.String()))
+			}
+			if hasTuple(&argument.Type) {
+				report.add(AbiValidationInfo, "tuple_argument", i, name, selector, fmt.Sprintf("argument %q of type %s is decoded as nested object", argument.Name, argument.Type.String()))
+			}
+		}
+
+		// Some ABI exporters put signature or selector into entry, it should match calculated one
+		for _, key := range []string{"signature", "selector"} {
+			declared, ok := entry[key].(string)
+			if !ok || declared == "" || !strings.HasPrefix(declared, "0x") {
+				continue
+			}
+			if !strings.EqualFold(declared, strings.TrimPrefix(selector, AnonymousEventSelectorPrefix)) {
+				report.add(AbiValidationError, "selector_mismatch", i, name, selector, fmt.Sprintf("declared %s %s does not match selector %s calculated from %s", key, declared, selector, signature))
+			}
+		}
+
+		if first, exists := seenSelectors[selector]; exists {
+			report.add(AbiValidationWarning, "duplicate_selector", i, name, selector, fmt.Sprintf("selector is already defined by entry %d, only one job is created", first))
+			continue
+		}
+		seenSelectors[selector] = i
+
+		report.Selectors = append(report.Selectors, selector)
+		report.Jobs++
+	}
+
+	return report
+}
+
+func hasTuple(t *abi.Type) bool {
+	if t.T == abi.TupleTy {
+		return true
+	}
+	if t.Elem != nil {
+		return hasTuple(t.Elem)
+	}
+	return false
+}
+
+// ValidateJobsFromAbi validates ABI file for jobs of address and reports selectors which
+// customer already has jobs for at other addresses of the chain.
+func (p *PostgreSQLpgx) ValidateJobsFromAbi(chain, address, abiFile, customerID string, allowAnonymous bool) (*AbiValidationReport, error) {
+	abiData, err := os.ReadFile(abiFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []map[string]interface{}
+	if err := json.Unmarshal(abiData, &entries); err != nil {
+		return nil, fmt.Errorf("ABI file should contain JSON array of entries: %w", err)
+	}
+
+	report := ValidateAbiEntries(entries, allowAnonymous)
+	report.Chain = chain
+	report.Address = address
+
+	addressBytes, err := decodeJobAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(report.Selectors) == 0 {
+		return report, nil
+	}
+
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(context.Background(), "SELECT abi_selector, abi_name, COALESCE('0x' || encode(address, 'hex'), @wildcard) FROM abi_jobs WHERE chain = @chain AND customer_id = @customer_id AND address IS DISTINCT FROM @address AND abi_selector = ANY(@selectors)", pgx.NamedArgs{
+		"chain":       chain,
+		"customer_id": customerID,
+		"address":     addressBytes,
+		"selectors":   report.Selectors,
+		"wildcard":    WildcardAddress,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	otherAddresses := make(map[string][]string)
+	names := make(map[string]string)
+	for rows.Next() {
+		var selector, name, otherAddress string
+		if err := rows.Scan(&selector, &name, &otherAddress); err != nil {
+			return nil, err
+		}
+		otherAddresses[selector] = append(otherAddresses[selector], otherAddress)
+		names[selector] = name
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	selectors := make([]string, 0, len(otherAddresses))
+	for selector := range otherAddresses {
+		selectors = append(selectors, selector)
+	}
+	sort.Strings(selectors)
+
+	for _, selector := range selectors {
+		report.add(AbiValidationInfo, "selector_at_other_addresses", -1, names[selector], selector, fmt.Sprintf("customer already has jobs with this selector at %d other addresses: %s", len(otherAddresses[selector]), strings.Join(otherAddresses[selector], ", ")))
+	}
+
+	return report, nil
+}
@@ -0,0 +1,169 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v5"
+)
+
+const AddressBookTableName = "address_book"
+
+// Common categories of address book entries, other categories are stored as is.
+const (
+	AddressCategoryExchange = "exchange"
+	AddressCategoryBridge   = "bridge"
+	AddressCategoryContract = "contract"
+	AddressCategoryENS      = "ens"
+)
+
+// AddressBookEntry is human-readable label of known address of blockchain.
+type AddressBookEntry struct {
+	Blockchain string    `json:"blockchain" db:"blockchain"`
+	Address    string    `json:"address" db:"address"`
+	Label      string    `json:"label" db:"label"`
+	Category   string    `json:"category,omitempty" db:"category"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (e *AddressBookEntry) Validate() error {
+	if e.Blockchain == "" {
+		return fmt.Errorf("blockchain of address %s is required", e.Address)
+	}
+	if !common.IsHexAddress(e.Address) {
+		return fmt.Errorf("invalid address %s", e.Address)
+	}
+	if strings.TrimSpace(e.Label) == "" {
+		return fmt.Errorf("label of address %s is required", e.Address)
+	}
+
+	return nil
+}
+
+// EnsureAddressBookTable creates address book table if it does not exist.
+func (p *PostgreSQLpgx) EnsureAddressBookTable() error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		blockchain VARCHAR(128) NOT NULL,
+		address BYTEA NOT NULL,
+		label VARCHAR(256) NOT NULL,
+		category VARCHAR(64) NOT NULL DEFAULT '',
+		updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+		PRIMARY KEY (blockchain, address)
+	)`, AddressBookTableName))
+
+	return err
+}
+
+// UpsertAddressBookEntries adds entries to address book, labels of already known addresses
+// are replaced.
+func (p *PostgreSQLpgx) UpsertAddressBookEntries(entries []AddressBookEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	batch := &pgx.Batch{}
+	for _, entry := range entries {
+		if err := entry.Validate(); err != nil {
+			return err
+		}
+		addressBytes, err := decodeAddress(entry.Address)
+		if err != nil {
+			return err
+		}
+
+		batch.Queue(fmt.Sprintf(`INSERT INTO %s (blockchain, address, label, category, updated_at)
+			VALUES ($1, $2, $3, $4, now())
+			ON CONFLICT (blockchain, address) DO UPDATE SET label = EXCLUDED.label, category = EXCLUDED.category, updated_at = now()`, AddressBookTableName),
+			entry.Blockchain, addressBytes, strings.TrimSpace(entry.Label), entry.Category)
+	}
+
+	return conn.SendBatch(context.Background(), batch).Close()
+}
+
+// DeleteAddressBookEntry removes label of address, false is returned if address is not in
+// address book.
+func (p *PostgreSQLpgx) DeleteAddressBookEntry(blockchain, address string) (bool, error) {
+	addressBytes, err := decodeAddress(address)
+	if err != nil {
+		return false, err
+	}
+
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return false, err
+	}
+	defer conn.Release()
+
+	tag, err := conn.Exec(context.Background(), fmt.Sprintf("DELETE FROM %s WHERE blockchain = $1 AND address = $2", AddressBookTableName), blockchain, addressBytes)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetAddressBookEntries returns entries of addresses of blockchain keyed by lowercase
+// address, addresses without labels are absent. Empty addresses return all entries.
+func (p *PostgreSQLpgx) GetAddressBookEntries(ctx context.Context, blockchain string, addresses []string) (map[string]AddressBookEntry, error) {
+	var addressesBytes [][]byte
+	for _, address := range addresses {
+		addressBytes, err := decodeAddress(address)
+		if err != nil {
+			continue
+		}
+		addressesBytes = append(addressesBytes, addressBytes)
+	}
+	if len(addresses) > 0 && len(addressesBytes) == 0 {
+		return map[string]AddressBookEntry{}, nil
+	}
+
+	pool := p.GetReadPool()
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	query := fmt.Sprintf(`SELECT blockchain, '0x' || encode(address, 'hex') AS address, label, category, updated_at
+		FROM %s
+		WHERE blockchain = $1 AND ($2::bytea[] IS NULL OR address = ANY($2))`, AddressBookTableName)
+
+	rows, err := conn.Query(ctx, query, blockchain, addressesBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := pgx.CollectRows(rows, pgx.RowToStructByName[AddressBookEntry])
+	if err != nil {
+		return nil, err
+	}
+
+	entriesMap := make(map[string]AddressBookEntry, len(entries))
+	for _, entry := range entries {
+		entriesMap[entry.Address] = entry
+	}
+
+	return entriesMap, nil
+}
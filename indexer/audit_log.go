@@ -0,0 +1,172 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const AuditLogTableName = "api_audit_log"
+
+// AuditLogEntry is a record of read API call made by consumer.
+type AuditLogEntry struct {
+	ID           int64           `json:"id" db:"id"`
+	ConsumerID   string          `json:"consumer_id" db:"consumer_id"`
+	ConsumerName string          `json:"consumer_name" db:"consumer_name"`
+	Method       string          `json:"method" db:"method"`
+	Endpoint     string          `json:"endpoint" db:"endpoint"`
+	Parameters   json.RawMessage `json:"parameters" db:"parameters"`
+	Status       int             `json:"status" db:"status"`
+	RowCount     int             `json:"row_count" db:"row_count"`
+	DurationMs   int64           `json:"duration_ms" db:"duration_ms"`
+	RemoteAddr   string          `json:"remote_addr" db:"remote_addr"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+}
+
+// AuditLogFilter selects entries of audit log, zero fields are not filtered.
+type AuditLogFilter struct {
+	ConsumerID string
+	Endpoint   string
+	From       time.Time
+	To         time.Time
+	Limit      int
+}
+
+// EnsureAuditLogTable creates audit log table if it does not exist.
+func (p *PostgreSQLpgx) EnsureAuditLogTable() error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %[1]s (
+		id BIGSERIAL PRIMARY KEY,
+		consumer_id VARCHAR(256) NOT NULL,
+		consumer_name VARCHAR(256) NOT NULL DEFAULT '',
+		method VARCHAR(16) NOT NULL,
+		endpoint VARCHAR(256) NOT NULL,
+		parameters JSONB NOT NULL DEFAULT '{}',
+		status INTEGER NOT NULL,
+		row_count INTEGER NOT NULL DEFAULT 0,
+		duration_ms BIGINT NOT NULL,
+		remote_addr VARCHAR(256) NOT NULL DEFAULT '',
+		created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+	);
+	CREATE INDEX IF NOT EXISTS idx_%[1]s_created_at ON %[1]s (created_at);
+	CREATE INDEX IF NOT EXISTS idx_%[1]s_consumer_id_created_at ON %[1]s (consumer_id, created_at)`, AuditLogTableName))
+
+	return err
+}
+
+// InsertAuditLogEntries writes entries in one batch, IDs of entries are ignored.
+func (p *PostgreSQLpgx) InsertAuditLogEntries(entries []AuditLogEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	rows := make([][]interface{}, len(entries))
+	for i, entry := range entries {
+		parameters := entry.Parameters
+		if len(parameters) == 0 {
+			parameters = json.RawMessage("{}")
+		}
+		rows[i] = []interface{}{
+			entry.ConsumerID,
+			entry.ConsumerName,
+			entry.Method,
+			entry.Endpoint,
+			parameters,
+			entry.Status,
+			entry.RowCount,
+			entry.DurationMs,
+			entry.RemoteAddr,
+			entry.CreatedAt,
+		}
+	}
+
+	_, err = conn.CopyFrom(
+		context.Background(),
+		pgx.Identifier{AuditLogTableName},
+		[]string{"consumer_id", "consumer_name", "method", "endpoint", "parameters", "status", "row_count", "duration_ms", "remote_addr", "created_at"},
+		pgx.CopyFromRows(rows),
+	)
+
+	return err
+}
+
+// SelectAuditLogEntries returns entries matching filter ordered by time of call.
+func (p *PostgreSQLpgx) SelectAuditLogEntries(filter AuditLogFilter) ([]AuditLogEntry, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	queryArgs := pgx.NamedArgs{}
+	query := fmt.Sprintf(`SELECT id, consumer_id, consumer_name, method, endpoint, parameters, status, row_count, duration_ms, remote_addr, created_at
+		FROM %s WHERE true`, AuditLogTableName)
+
+	if filter.ConsumerID != "" {
+		query += " AND consumer_id = @consumer_id"
+		queryArgs["consumer_id"] = filter.ConsumerID
+	}
+	if filter.Endpoint != "" {
+		query += " AND endpoint = @endpoint"
+		queryArgs["endpoint"] = filter.Endpoint
+	}
+	if !filter.From.IsZero() {
+		query += " AND created_at >= @from"
+		queryArgs["from"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		query += " AND created_at < @to"
+		queryArgs["to"] = filter.To
+	}
+	query += " ORDER BY created_at, id"
+	if filter.Limit > 0 {
+		query += " LIMIT @limit"
+		queryArgs["limit"] = filter.Limit
+	}
+
+	rows, err := conn.Query(context.Background(), query, queryArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[AuditLogEntry])
+}
+
+// DeleteAuditLogEntriesBefore removes entries older than cutoff and returns number of
+// removed entries.
+func (p *PostgreSQLpgx) DeleteAuditLogEntriesBefore(cutoff time.Time) (int64, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
+
+	tag, err := conn.Exec(context.Background(), fmt.Sprintf("DELETE FROM %s WHERE created_at < $1", AuditLogTableName), cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
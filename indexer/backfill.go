@@ -0,0 +1,435 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	BackfillJobsTableName   = "seer_backfill_jobs"
+	BackfillChunksTableName = "seer_backfill_chunks"
+)
+
+// Statuses of backfill jobs and their chunks
+const (
+	BackfillPending = "pending"
+	BackfillRunning = "running"
+	BackfillDone    = "done"
+	BackfillFailed  = "failed"
+)
+
+// BackfillJob is historical crawl of address of customer in blocks range, range is split into
+// chunks which progress is persisted, so interrupted backfill continues from chunks which are
+// not done yet.
+type BackfillJob struct {
+	ID         string    `json:"id"`
+	Chain      string    `json:"chain"`
+	Address    string    `json:"address"`
+	CustomerID string    `json:"customer_id"`
+	FromBlock  uint64    `json:"from_block"`
+	ToBlock    uint64    `json:"to_block"`
+	ChunkSize  uint64    `json:"chunk_size"`
+	Status     string    `json:"status"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// BackfillChunk is blocks range of backfill job crawled as a whole.
+type BackfillChunk struct {
+	JobID     string    `json:"job_id"`
+	FromBlock uint64    `json:"from_block"`
+	ToBlock   uint64    `json:"to_block"`
+	Status    string    `json:"status"`
+	Attempts  int       `json:"attempts"`
+	Error     string    `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BackfillProgress counts chunks of backfill job by status.
+type BackfillProgress struct {
+	Job        BackfillJob `json:"job"`
+	Chunks     int         `json:"chunks"`
+	Pending    int         `json:"pending"`
+	Running    int         `json:"running"`
+	Done       int         `json:"done"`
+	Failed     int         `json:"failed"`
+	BlocksDone uint64      `json:"blocks_done"`
+}
+
+// Percent returns share of blocks of job in chunks which are done.
+func (p BackfillProgress) Percent() int {
+	total := p.Job.ToBlock - p.Job.FromBlock + 1
+	if p.Job.ToBlock < p.Job.FromBlock || total == 0 {
+		return 100
+	}
+	return int(p.BlocksDone * 100 / total)
+}
+
+// PlanBackfillChunks splits blocks range into consecutive non-overlapping chunks of chunkSize
+// blocks, the last chunk could be shorter.
+func PlanBackfillChunks(fromBlock, toBlock, chunkSize uint64) []BackfillChunk {
+	if chunkSize == 0 || toBlock < fromBlock {
+		return nil
+	}
+
+	var chunks []BackfillChunk
+	for start := fromBlock; start <= toBlock; start += chunkSize {
+		end := start + chunkSize - 1
+		if end > toBlock || end < start {
+			end = toBlock
+		}
+		chunks = append(chunks, BackfillChunk{FromBlock: start, ToBlock: end, Status: BackfillPending})
+		if end == toBlock {
+			break
+		}
+	}
+
+	return chunks
+}
+
+// EnsureBackfillTables creates tables of backfill jobs and chunks if they do not exist.
+func (p *PostgreSQLpgx) EnsureBackfillTables() error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		id UUID PRIMARY KEY,
+		chain VARCHAR NOT NULL,
+		address VARCHAR NOT NULL,
+		customer_id VARCHAR NOT NULL,
+		from_block BIGINT NOT NULL,
+		to_block BIGINT NOT NULL,
+		chunk_size BIGINT NOT NULL,
+		status VARCHAR NOT NULL,
+		created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+		updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+	)`, BackfillJobsTableName))
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		job_id UUID NOT NULL REFERENCES %s (id) ON DELETE CASCADE,
+		from_block BIGINT NOT NULL,
+		to_block BIGINT NOT NULL,
+		status VARCHAR NOT NULL,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		error TEXT NOT NULL DEFAULT '',
+		updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+		PRIMARY KEY (job_id, from_block)
+	)`, BackfillChunksTableName, BackfillJobsTableName))
+
+	return err
+}
+
+// CreateBackfillJob saves job with its chunks in one transaction.
+func (p *PostgreSQLpgx) CreateBackfillJob(job BackfillJob, chunks []BackfillChunk) (*BackfillJob, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	ctx := context.Background()
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if job.ID == "" {
+		job.ID = uuid.NewString()
+	}
+	job.Status = BackfillPending
+
+	err = tx.QueryRow(ctx, fmt.Sprintf(`INSERT INTO %s (id, chain, address, customer_id, from_block, to_block, chunk_size, status)
+		VALUES (@id, @chain, @address, @customer_id, @from_block, @to_block, @chunk_size, @status)
+		RETURNING created_at, updated_at`, BackfillJobsTableName), pgx.NamedArgs{
+		"id":          job.ID,
+		"chain":       job.Chain,
+		"address":     job.Address,
+		"customer_id": job.CustomerID,
+		"from_block":  job.FromBlock,
+		"to_block":    job.ToBlock,
+		"chunk_size":  job.ChunkSize,
+		"status":      job.Status,
+	}).Scan(&job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]interface{}, len(chunks))
+	for i, chunk := range chunks {
+		rows[i] = []interface{}{job.ID, chunk.FromBlock, chunk.ToBlock, BackfillPending}
+	}
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{BackfillChunksTableName}, []string{"job_id", "from_block", "to_block", "status"}, pgx.CopyFromRows(rows)); err != nil {
+		return nil, fmt.Errorf("failed to save chunks of backfill job: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+const backfillJobColumns = "id, chain, address, customer_id, from_block, to_block, chunk_size, status, created_at, updated_at"
+
+func scanBackfillJob(row pgx.Row) (*BackfillJob, error) {
+	var job BackfillJob
+	err := row.Scan(&job.ID, &job.Chain, &job.Address, &job.CustomerID, &job.FromBlock, &job.ToBlock, &job.ChunkSize, &job.Status, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetBackfillJob returns backfill job by ID.
+func (p *PostgreSQLpgx) GetBackfillJob(id string) (*BackfillJob, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	job, err := scanBackfillJob(conn.QueryRow(context.Background(), fmt.Sprintf("SELECT %s FROM %s WHERE id = $1", backfillJobColumns, BackfillJobsTableName), id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("backfill job %s not found", id)
+	}
+
+	return job, err
+}
+
+// FindUnfinishedBackfillJob returns the latest backfill job of address of customer which is not
+// done, nil is returned if there is no such job.
+func (p *PostgreSQLpgx) FindUnfinishedBackfillJob(chain, address, customerID string) (*BackfillJob, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	job, err := scanBackfillJob(conn.QueryRow(context.Background(), fmt.Sprintf(`SELECT %s FROM %s
+		WHERE chain = $1 AND lower(address) = lower($2) AND customer_id = $3 AND status != $4
+		ORDER BY created_at DESC LIMIT 1`, backfillJobColumns, BackfillJobsTableName), chain, address, customerID, BackfillDone))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return job, err
+}
+
+// ListBackfillJobs returns backfill jobs of chain, or of all chains if chain is empty, latest
+// first.
+func (p *PostgreSQLpgx) ListBackfillJobs(chain string) ([]BackfillJob, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(context.Background(), fmt.Sprintf(`SELECT %s FROM %s
+		WHERE $1 = '' OR chain = $1 ORDER BY created_at DESC`, backfillJobColumns, BackfillJobsTableName), chain)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []BackfillJob
+	for rows.Next() {
+		job, err := scanBackfillJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+
+	return jobs, rows.Err()
+}
+
+// SetBackfillJobStatus updates status of backfill job.
+func (p *PostgreSQLpgx) SetBackfillJobStatus(id, status string) error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf("UPDATE %s SET status = $2, updated_at = now() WHERE id = $1", BackfillJobsTableName), id, status)
+
+	return err
+}
+
+// ResetStaleBackfillChunks returns chunks left running longer than staleAfter to pending, they
+// were taken by process which stopped without finishing them. Returns number of reset chunks.
+func (p *PostgreSQLpgx) ResetStaleBackfillChunks(jobID string, staleAfter time.Duration) (int64, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
+
+	commandTag, err := conn.Exec(context.Background(), fmt.Sprintf(`UPDATE %s SET status = $2, updated_at = now()
+		WHERE job_id = $1 AND status = $3 AND updated_at < now() - make_interval(secs => $4)`, BackfillChunksTableName),
+		jobID, BackfillPending, BackfillRunning, staleAfter.Seconds())
+	if err != nil {
+		return 0, err
+	}
+
+	return commandTag.RowsAffected(), nil
+}
+
+// ClaimBackfillChunk marks the first pending chunk of job as running and returns it, nil is
+// returned if there are no pending chunks. Locked rows are skipped, so concurrent workers of
+// one or several processes never take the same chunk.
+func (p *PostgreSQLpgx) ClaimBackfillChunk(jobID string) (*BackfillChunk, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	var chunk BackfillChunk
+	err = conn.QueryRow(context.Background(), fmt.Sprintf(`UPDATE %[1]s SET status = $2, attempts = attempts + 1, updated_at = now()
+		WHERE (job_id, from_block) = (
+			SELECT job_id, from_block FROM %[1]s
+			WHERE job_id = $1 AND status = $3
+			ORDER BY from_block
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING job_id, from_block, to_block, status, attempts, error, updated_at`, BackfillChunksTableName),
+		jobID, BackfillRunning, BackfillPending).Scan(&chunk.JobID, &chunk.FromBlock, &chunk.ToBlock, &chunk.Status, &chunk.Attempts, &chunk.Error, &chunk.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &chunk, nil
+}
+
+// CompleteBackfillChunk marks chunk as done.
+func (p *PostgreSQLpgx) CompleteBackfillChunk(jobID string, fromBlock uint64) error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf("UPDATE %s SET status = $3, error = '', updated_at = now() WHERE job_id = $1 AND from_block = $2", BackfillChunksTableName), jobID, fromBlock, BackfillDone)
+
+	return err
+}
+
+// FailBackfillChunk records error of chunk, chunk is returned to pending until it is attempted
+// maxAttempts times and is marked as failed after that.
+func (p *PostgreSQLpgx) FailBackfillChunk(jobID string, fromBlock uint64, chunkErr error, maxAttempts int) error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf(`UPDATE %s
+		SET status = CASE WHEN attempts >= $4 THEN $5 ELSE $6 END, error = $3, updated_at = now()
+		WHERE job_id = $1 AND from_block = $2`, BackfillChunksTableName),
+		jobID, fromBlock, chunkErr.Error(), maxAttempts, BackfillFailed, BackfillPending)
+
+	return err
+}
+
+// RetryFailedBackfillChunks returns failed chunks of job to pending with reset attempts.
+func (p *PostgreSQLpgx) RetryFailedBackfillChunks(jobID string) (int64, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
+
+	commandTag, err := conn.Exec(context.Background(), fmt.Sprintf("UPDATE %s SET status = $2, attempts = 0, updated_at = now() WHERE job_id = $1 AND status = $3", BackfillChunksTableName), jobID, BackfillPending, BackfillFailed)
+	if err != nil {
+		return 0, err
+	}
+
+	return commandTag.RowsAffected(), nil
+}
+
+// GetBackfillProgress counts chunks of job by status.
+func (p *PostgreSQLpgx) GetBackfillProgress(jobID string) (*BackfillProgress, error) {
+	job, err := p.GetBackfillJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(context.Background(), fmt.Sprintf(`SELECT status, count(*), coalesce(sum(to_block - from_block + 1), 0)
+		FROM %s WHERE job_id = $1 GROUP BY status`, BackfillChunksTableName), jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	progress := &BackfillProgress{Job: *job}
+	for rows.Next() {
+		var status string
+		var count int
+		var blocks int64
+		if err := rows.Scan(&status, &count, &blocks); err != nil {
+			return nil, err
+		}
+
+		progress.Chunks += count
+		switch status {
+		case BackfillPending:
+			progress.Pending = count
+		case BackfillRunning:
+			progress.Running = count
+		case BackfillDone:
+			progress.Done = count
+			progress.BlocksDone = uint64(blocks)
+		case BackfillFailed:
+			progress.Failed = count
+		}
+	}
+
+	return progress, rows.Err()
+}
@@ -0,0 +1,49 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/G7DAO/seer/logging"
+)
+
+// hasBlobFields returns true if any transaction carries EIP-4844 blob fields.
+func hasBlobFields(rawTransactions []RawTransaction) bool {
+	for _, rawTransaction := range rawTransactions {
+		if rawTransaction.MaxFeePerBlobGas != "" || len(rawTransaction.BlobVersionedHashes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ensureBlobTransactionColumns adds columns of blob transactions to raw transactions table of
+// customer database created before EIP-4844. Result is cached per table, if columns could not
+// be added (for example, seer role does not own the table) blob fields are not written.
+func (p *PostgreSQLpgx) ensureBlobTransactionColumns(ctx context.Context, tableName string) bool {
+	p.blobColumnsMu.Lock()
+	defer p.blobColumnsMu.Unlock()
+
+	if ensured, checked := p.blobColumns[tableName]; checked {
+		return ensured
+	}
+	if p.blobColumns == nil {
+		p.blobColumns = make(map[string]bool)
+	}
+
+	ensured := true
+	for _, statement := range []string{
+		fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS max_fee_per_blob_gas NUMERIC", tableName),
+		fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS blob_versioned_hashes JSONB", tableName),
+	} {
+		if _, err := p.GetPool().Exec(ctx, statement); err != nil {
+			slog.Warn("Unable to add blob transaction columns, blob fields are not written", logging.TableKey, tableName, logging.ErrorKey, err)
+			ensured = false
+			break
+		}
+	}
+	p.blobColumns[tableName] = ensured
+
+	return ensured
+}
@@ -0,0 +1,251 @@
+package indexer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G7DAO/seer/logging"
+)
+
+const (
+	DefaultBlocksPageLimit = 100
+	MaxBlocksPageLimit     = 1000
+)
+
+// BlocksFilter describes conditions of blocks index search. Search by miner scans many rows,
+// so it requires block range or time window.
+type BlocksFilter struct {
+	Hash          string
+	ParentHash    string
+	Miner         string
+	FromBlock     uint64
+	ToBlock       uint64
+	FromTimestamp uint64
+	ToTimestamp   uint64
+	Descending    bool
+	Limit         int
+	Cursor        string
+}
+
+// BlockHeader is a row of blocks index.
+type BlockHeader struct {
+	BlockNumber    uint64 `json:"block_number"`
+	BlockHash      string `json:"block_hash"`
+	ParentHash     string `json:"parent_hash"`
+	BlockTimestamp uint64 `json:"block_timestamp"`
+	Miner          string `json:"miner,omitempty"`
+	L1BlockNumber  uint64 `json:"l1_block_number,omitempty"`
+	Path           string `json:"path"`
+	RowID          uint64 `json:"row_id"`
+}
+
+type BlocksPage struct {
+	Blocks     []BlockHeader `json:"blocks"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+func encodeBlocksCursor(blockNumber uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(blockNumber, 10)))
+}
+
+func decodeBlocksCursor(cursor string) (uint64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid cursor: %v", ErrInvalidFilter, err)
+	}
+
+	blockNumber, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid cursor block number: %v", ErrInvalidFilter, err)
+	}
+
+	return blockNumber, nil
+}
+
+func blocksLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultBlocksPageLimit
+	}
+	if limit > MaxBlocksPageLimit {
+		return MaxBlocksPageLimit
+	}
+	return limit
+}
+
+// EnsureBlocksMinerColumn adds miner column to blocks index table of blockchain. Rows indexed
+// before the column appeared have it empty.
+func (p *PostgreSQLpgx) EnsureBlocksMinerColumn(blockchain string) error {
+	blocksTableName, blocksTableErr := BlocksTableName(blockchain)
+	if blocksTableErr != nil {
+		return blocksTableErr
+	}
+
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS miner TEXT", blocksTableName))
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_miner_block_number_idx ON %s (miner, block_number)", blocksTableName, blocksTableName))
+	if err != nil {
+		return err
+	}
+
+	logging.Chain(blockchain).Info("Ensured miner column", logging.TableKey, blocksTableName)
+
+	return nil
+}
+
+// SearchBlocks returns page of blocks index rows matching filter, ordered by block number.
+func (p *PostgreSQLpgx) SearchBlocks(blockchain string, filter BlocksFilter) (*BlocksPage, error) {
+	blocksTableName, blocksTableErr := BlocksTableName(blockchain)
+	if blocksTableErr != nil {
+		return nil, blocksTableErr
+	}
+
+	if filter.FromBlock > 0 && filter.ToBlock > 0 && filter.FromBlock > filter.ToBlock {
+		return nil, fmt.Errorf("%w: from block %d is greater than to block %d", ErrInvalidFilter, filter.FromBlock, filter.ToBlock)
+	}
+	if filter.FromTimestamp > 0 && filter.ToTimestamp > 0 && filter.FromTimestamp > filter.ToTimestamp {
+		return nil, fmt.Errorf("%w: from timestamp %d is greater than to timestamp %d", ErrInvalidFilter, filter.FromTimestamp, filter.ToTimestamp)
+	}
+
+	ranged := filter.FromBlock > 0 || filter.ToBlock > 0 || filter.FromTimestamp > 0 || filter.ToTimestamp > 0
+	if filter.Miner != "" && filter.Hash == "" && !ranged {
+		return nil, fmt.Errorf("%w: search by miner requires block range or time window", ErrInvalidFilter)
+	}
+
+	pool := p.GetReadPool()
+
+	ctx := context.Background()
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	var hasMiner bool
+	err = conn.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = $1 AND column_name = 'miner')", blocksTableName).Scan(&hasMiner)
+	if err != nil {
+		return nil, err
+	}
+	if filter.Miner != "" && !hasMiner {
+		return nil, fmt.Errorf("%w: miner is not indexed in %s table yet", ErrInvalidFilter, blocksTableName)
+	}
+
+	var conditions []string
+	args := pgx.NamedArgs{}
+
+	if filter.Hash != "" {
+		conditions = append(conditions, "block_hash = @block_hash")
+		args["block_hash"] = strings.ToLower(filter.Hash)
+	}
+	if filter.ParentHash != "" {
+		conditions = append(conditions, "parent_hash = @parent_hash")
+		args["parent_hash"] = strings.ToLower(filter.ParentHash)
+	}
+	if filter.Miner != "" {
+		conditions = append(conditions, "miner = @miner")
+		args["miner"] = strings.ToLower(filter.Miner)
+	}
+	if filter.FromBlock > 0 {
+		conditions = append(conditions, "block_number >= @from_block")
+		args["from_block"] = filter.FromBlock
+	}
+	if filter.ToBlock > 0 {
+		conditions = append(conditions, "block_number <= @to_block")
+		args["to_block"] = filter.ToBlock
+	}
+	if filter.FromTimestamp > 0 {
+		conditions = append(conditions, "block_timestamp >= @from_timestamp")
+		args["from_timestamp"] = filter.FromTimestamp
+	}
+	if filter.ToTimestamp > 0 {
+		conditions = append(conditions, "block_timestamp <= @to_timestamp")
+		args["to_timestamp"] = filter.ToTimestamp
+	}
+
+	order := "ASC"
+	if filter.Descending {
+		order = "DESC"
+	}
+
+	if filter.Cursor != "" {
+		cursorBlockNumber, cursorErr := decodeBlocksCursor(filter.Cursor)
+		if cursorErr != nil {
+			return nil, cursorErr
+		}
+		if filter.Descending {
+			conditions = append(conditions, "block_number < @cursor_block_number")
+		} else {
+			conditions = append(conditions, "block_number > @cursor_block_number")
+		}
+		args["cursor_block_number"] = cursorBlockNumber
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	minerColumn := "NULL::TEXT"
+	if hasMiner {
+		minerColumn = "miner"
+	}
+	l1BlockNumberColumn := "NULL::BIGINT"
+	if IsBlockchainWithL1Chain(blockchain) {
+		l1BlockNumberColumn = "l1_block_number"
+	}
+
+	limit := blocksLimit(filter.Limit)
+	args["limit"] = limit + 1
+
+	query := fmt.Sprintf(`SELECT block_number, block_hash, parent_hash, block_timestamp, %s, %s, path, row_id
+		FROM %s
+		%s
+		ORDER BY block_number %s
+		LIMIT @limit`, minerColumn, l1BlockNumberColumn, blocksTableName, where, order)
+
+	rows, err := conn.Query(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &BlocksPage{Blocks: []BlockHeader{}}
+	for rows.Next() {
+		var header BlockHeader
+		var miner, path sql.NullString
+		var l1BlockNumber sql.NullInt64
+		if err := rows.Scan(&header.BlockNumber, &header.BlockHash, &header.ParentHash, &header.BlockTimestamp, &miner, &l1BlockNumber, &path, &header.RowID); err != nil {
+			return nil, err
+		}
+		header.Miner = miner.String
+		header.Path = path.String
+		header.L1BlockNumber = uint64(l1BlockNumber.Int64)
+		page.Blocks = append(page.Blocks, header)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(page.Blocks) > limit {
+		page.Blocks = page.Blocks[:limit]
+		page.NextCursor = encodeBlocksCursor(page.Blocks[limit-1].BlockNumber)
+	}
+
+	return page, nil
+}
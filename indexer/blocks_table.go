@@ -0,0 +1,59 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+)
+
+// EnsureBlocksTable creates blocks index table of chain if it does not exist. Index databases
+// of public chains are migrated separately, the table is created for local chain and for
+// chains being onboarded. Miner column is added by crawler, see EnsureBlocksMinerColumn.
+func (p *PostgreSQLpgx) EnsureBlocksTable(blockchain string) error {
+	tableName, err := BlocksTableName(blockchain)
+	if err != nil {
+		return err
+	}
+
+	l1BlockNumberColumn := ""
+	if IsBlockchainWithL1Chain(blockchain) {
+		l1BlockNumberColumn = "\n\t\tl1_block_number BIGINT,"
+	}
+
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		block_number BIGINT PRIMARY KEY,
+		block_hash VARCHAR NOT NULL,
+		block_timestamp BIGINT NOT NULL,
+		parent_hash VARCHAR NOT NULL,
+		row_id BIGINT NOT NULL,
+		path TEXT NOT NULL,%s
+		transactions_indexed_at TIMESTAMP WITH TIME ZONE,
+		logs_indexed_at TIMESTAMP WITH TIME ZONE,
+		indexed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+	)`, tableName, l1BlockNumberColumn))
+
+	return err
+}
+
+// BlocksRangeCoverage returns number of indexed blocks and gaps between them in range of blocks.
+func (p *PostgreSQLpgx) BlocksRangeCoverage(blockchain string, fromBlock, toBlock uint64) (uint64, uint64, error) {
+	tableName, err := BlocksTableName(blockchain)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	conn, err := p.GetPool().Acquire(context.Background())
+	if err != nil {
+		return 0, 0, err
+	}
+	defer conn.Release()
+
+	return rangeCoverage(context.Background(), conn, tableName, fromBlock, toBlock)
+}
@@ -0,0 +1,164 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const BookmarksTableName = "block_range_bookmarks"
+
+// BlockRangeBookmark is a named range of blocks at chain, e.g. "airdrop window", which could
+// be used instead of raw block numbers in commands.
+type BlockRangeBookmark struct {
+	Chain       string    `json:"chain"`
+	Name        string    `json:"name"`
+	FromBlock   uint64    `json:"from_block"`
+	ToBlock     uint64    `json:"to_block"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// EnsureBookmarksTable creates bookmarks table in index database if it does not exist.
+func (p *PostgreSQLpgx) EnsureBookmarksTable() error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		chain VARCHAR(128) NOT NULL,
+		name VARCHAR(256) NOT NULL,
+		from_block BIGINT NOT NULL,
+		to_block BIGINT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+		updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+		PRIMARY KEY (chain, name),
+		CHECK (from_block <= to_block)
+	)`, BookmarksTableName))
+
+	return err
+}
+
+// UpsertBookmark creates bookmark or updates range and description of existing one.
+func (p *PostgreSQLpgx) UpsertBookmark(bookmark BlockRangeBookmark) (*BlockRangeBookmark, error) {
+	if bookmark.Name == "" {
+		return nil, fmt.Errorf("bookmark name is required")
+	}
+	if _, err := BlocksTableName(bookmark.Chain); err != nil {
+		return nil, err
+	}
+	if bookmark.FromBlock > bookmark.ToBlock {
+		return nil, fmt.Errorf("from block %d is greater than to block %d", bookmark.FromBlock, bookmark.ToBlock)
+	}
+
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	query := fmt.Sprintf(`INSERT INTO %s (chain, name, from_block, to_block, description)
+		VALUES (@chain, @name, @from_block, @to_block, @description)
+		ON CONFLICT (chain, name) DO UPDATE SET
+			from_block = EXCLUDED.from_block,
+			to_block = EXCLUDED.to_block,
+			description = EXCLUDED.description,
+			updated_at = now()
+		RETURNING chain, name, from_block, to_block, description, created_at, updated_at`, BookmarksTableName)
+
+	var result BlockRangeBookmark
+	err = conn.QueryRow(context.Background(), query, pgx.NamedArgs{
+		"chain":       bookmark.Chain,
+		"name":        bookmark.Name,
+		"from_block":  bookmark.FromBlock,
+		"to_block":    bookmark.ToBlock,
+		"description": bookmark.Description,
+	}).Scan(&result.Chain, &result.Name, &result.FromBlock, &result.ToBlock, &result.Description, &result.CreatedAt, &result.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetBookmark returns bookmark by chain and name.
+func (p *PostgreSQLpgx) GetBookmark(chain, name string) (*BlockRangeBookmark, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	query := fmt.Sprintf("SELECT chain, name, from_block, to_block, description, created_at, updated_at FROM %s WHERE chain = $1 AND name = $2", BookmarksTableName)
+
+	var bookmark BlockRangeBookmark
+	err = conn.QueryRow(context.Background(), query, chain, name).Scan(&bookmark.Chain, &bookmark.Name, &bookmark.FromBlock, &bookmark.ToBlock, &bookmark.Description, &bookmark.CreatedAt, &bookmark.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("bookmark %q not found for chain %s", name, chain)
+		}
+		return nil, err
+	}
+
+	return &bookmark, nil
+}
+
+// ListBookmarks returns bookmarks of chain ordered by range start, all chains if chain is empty.
+func (p *PostgreSQLpgx) ListBookmarks(chain string) ([]BlockRangeBookmark, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	query := fmt.Sprintf("SELECT chain, name, from_block, to_block, description, created_at, updated_at FROM %s WHERE ($1 = '' OR chain = $1) ORDER BY chain, from_block, name", BookmarksTableName)
+
+	rows, err := conn.Query(context.Background(), query, chain)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bookmarks := []BlockRangeBookmark{}
+	for rows.Next() {
+		var bookmark BlockRangeBookmark
+		if err := rows.Scan(&bookmark.Chain, &bookmark.Name, &bookmark.FromBlock, &bookmark.ToBlock, &bookmark.Description, &bookmark.CreatedAt, &bookmark.UpdatedAt); err != nil {
+			return nil, err
+		}
+		bookmarks = append(bookmarks, bookmark)
+	}
+
+	return bookmarks, rows.Err()
+}
+
+// DeleteBookmark removes bookmark, returns false if it did not exist.
+func (p *PostgreSQLpgx) DeleteBookmark(chain, name string) (bool, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return false, err
+	}
+	defer conn.Release()
+
+	commandTag, err := conn.Exec(context.Background(), fmt.Sprintf("DELETE FROM %s WHERE chain = $1 AND name = $2", BookmarksTableName), chain, name)
+	if err != nil {
+		return false, err
+	}
+
+	return commandTag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,163 @@
+package indexer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ChainCompleteness is a contiguous coverage of blocks index of chain.
+type ChainCompleteness struct {
+	Chain                string    `json:"chain"`
+	EarliestBlock        uint64    `json:"earliest_block"`
+	LatestBlock          uint64    `json:"latest_block"`
+	IndexedBlocks        uint64    `json:"indexed_blocks"`
+	MissingBlocks        uint64    `json:"missing_blocks"`
+	GapCount             uint64    `json:"gap_count"`
+	Completeness         float64   `json:"completeness"`
+	LatestBlockTimestamp uint64    `json:"latest_block_timestamp"`
+	FinalityLagSeconds   int64     `json:"finality_lag_seconds"`
+	ComputedAt           time.Time `json:"computed_at"`
+	FullScanAt           time.Time `json:"full_scan_at"`
+}
+
+type completenessState struct {
+	stats     ChainCompleteness
+	refreshAt time.Time
+}
+
+// CompletenessTracker caches coverage statistics per chain. On refresh only blocks added
+// above latest or below earliest known block are scanned, gaps filled inside of already
+// scanned range are noticed with periodic full scan.
+type CompletenessTracker struct {
+	db                 *PostgreSQLpgx
+	cacheTTL           time.Duration
+	fullRescanInterval time.Duration
+
+	mu     sync.Mutex
+	states map[string]*completenessState
+}
+
+func NewCompletenessTracker(db *PostgreSQLpgx, cacheTTL, fullRescanInterval time.Duration) *CompletenessTracker {
+	return &CompletenessTracker{
+		db:                 db,
+		cacheTTL:           cacheTTL,
+		fullRescanInterval: fullRescanInterval,
+		states:             make(map[string]*completenessState),
+	}
+}
+
+// rangeCoverage counts indexed blocks and gaps between them in range of blocks.
+func rangeCoverage(ctx context.Context, conn *pgxpool.Conn, blocksTableName string, fromBlock, toBlock uint64) (uint64, uint64, error) {
+	query := fmt.Sprintf(`SELECT
+			count(*),
+			count(*) FILTER (WHERE block_number - prev_block_number > 1)
+		FROM (
+			SELECT block_number, lag(block_number) OVER (ORDER BY block_number) AS prev_block_number
+			FROM %s
+			WHERE block_number >= @from_block AND block_number <= @to_block
+		) AS blocks`, blocksTableName)
+
+	var indexed, gaps uint64
+	err := conn.QueryRow(ctx, query, pgx.NamedArgs{
+		"from_block": fromBlock,
+		"to_block":   toBlock,
+	}).Scan(&indexed, &gaps)
+
+	return indexed, gaps, err
+}
+
+// Get returns cached statistics of chain or refreshes them if cache is expired.
+func (t *CompletenessTracker) Get(chain string) (*ChainCompleteness, error) {
+	blocksTableName, err := BlocksTableName(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, exists := t.states[chain]
+	if exists && time.Since(state.refreshAt) < t.cacheTTL {
+		stats := state.stats
+		return &stats, nil
+	}
+
+	ctx := context.Background()
+	conn, err := t.db.GetReadPool().Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	var minBlock, maxBlock, maxBlockTimestamp sql.NullInt64
+	err = conn.QueryRow(ctx, fmt.Sprintf("SELECT min(block_number), max(block_number) FROM %s", blocksTableName)).Scan(&minBlock, &maxBlock)
+	if err != nil {
+		return nil, err
+	}
+	if !minBlock.Valid || !maxBlock.Valid {
+		return nil, fmt.Errorf("%w in %s table", ErrNoRowsIndexed, blocksTableName)
+	}
+	err = conn.QueryRow(ctx, fmt.Sprintf("SELECT block_timestamp FROM %s WHERE block_number = $1", blocksTableName), maxBlock.Int64).Scan(&maxBlockTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	earliest, latest := uint64(minBlock.Int64), uint64(maxBlock.Int64)
+	now := time.Now()
+
+	var stats ChainCompleteness
+	if !exists || now.Sub(state.stats.FullScanAt) >= t.fullRescanInterval || earliest > state.stats.EarliestBlock || latest < state.stats.LatestBlock {
+		// Full scan of the whole range, also used when blocks were deleted from index
+		indexed, gaps, scanErr := rangeCoverage(ctx, conn, blocksTableName, earliest, latest)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		stats = ChainCompleteness{IndexedBlocks: indexed, GapCount: gaps, FullScanAt: now}
+	} else {
+		stats = state.stats
+
+		// Previous boundary block is included into scanned range to catch gap next to it
+		if latest > stats.LatestBlock {
+			indexed, gaps, scanErr := rangeCoverage(ctx, conn, blocksTableName, stats.LatestBlock, latest)
+			if scanErr != nil {
+				return nil, scanErr
+			}
+			stats.IndexedBlocks += indexed - 1
+			stats.GapCount += gaps
+		}
+		if earliest < stats.EarliestBlock {
+			indexed, gaps, scanErr := rangeCoverage(ctx, conn, blocksTableName, earliest, stats.EarliestBlock)
+			if scanErr != nil {
+				return nil, scanErr
+			}
+			stats.IndexedBlocks += indexed - 1
+			stats.GapCount += gaps
+		}
+	}
+
+	stats.Chain = chain
+	stats.EarliestBlock = earliest
+	stats.LatestBlock = latest
+	if stats.IndexedBlocks < latest-earliest+1 {
+		stats.MissingBlocks = latest - earliest + 1 - stats.IndexedBlocks
+	} else {
+		stats.MissingBlocks = 0
+	}
+	stats.Completeness = float64(stats.IndexedBlocks) * 100 / float64(latest-earliest+1)
+	if maxBlockTimestamp.Valid {
+		stats.LatestBlockTimestamp = uint64(maxBlockTimestamp.Int64)
+		stats.FinalityLagSeconds = now.Unix() - maxBlockTimestamp.Int64
+	}
+	stats.ComputedAt = now
+
+	t.states[chain] = &completenessState{stats: stats, refreshAt: now}
+
+	result := stats
+	return &result, nil
+}
@@ -0,0 +1,277 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G7DAO/seer/logging"
+)
+
+// ConflictTarget describes unique index used as arbiter of ON CONFLICT clause. Empty Columns
+// means that no suitable index is known and any unique violation is skipped.
+type ConflictTarget struct {
+	Columns   []string
+	Predicate string
+}
+
+// Clause builds ON CONFLICT DO NOTHING clause for insert statement.
+func (t ConflictTarget) Clause() string {
+	if len(t.Columns) == 0 {
+		return "ON CONFLICT DO NOTHING"
+	}
+
+	clause := fmt.Sprintf("ON CONFLICT (%s)", strings.Join(t.Columns, ", "))
+	if t.Predicate != "" {
+		clause += fmt.Sprintf(" WHERE %s", t.Predicate)
+	}
+
+	return clause + " DO NOTHING"
+}
+
+// labelConflictColumns are columns identifying label of each type in labels table, unique
+// index with these columns and predicate on label type is used as arbiter of its inserts.
+// Labels of other types are inserted with untargeted ON CONFLICT DO NOTHING.
+var labelConflictColumns = map[string][]string{
+	"event":           {"transaction_hash", "log_index"},
+	"bor_state_sync":  {"transaction_hash", "log_index"},
+	"tx_call":         {"transaction_hash"},
+	"safe_inner_call": {"transaction_hash"},
+	"native_transfer": {"transaction_hash"},
+	// ID of internal transaction is derived from its trace address, see TransactionLabelID
+	"internal_tx": {"id"},
+}
+
+// LabelConflictColumns returns columns identifying label of labelType, nil for types without
+// unique index of their own.
+func LabelConflictColumns(labelType string) []string {
+	return labelConflictColumns[labelType]
+}
+
+// conflictTargetKey is key of conflict targets configuration and cache. Labels of different
+// types share labels table but have their own unique indexes, so their targets are kept apart.
+func conflictTargetKey(tableName, labelType string) string {
+	if labelType == "" {
+		return tableName
+	}
+	return tableName + ":" + labelType
+}
+
+// ParseConflictTargets parses explicit conflict targets configuration in format
+// "<table>[:<label_type>]=<column>,<column>[ WHERE <predicate>];<table>=...". Targets of labels
+// tables are configured per label type, labels of types without target are inserted with
+// untargeted ON CONFLICT DO NOTHING.
+func ParseConflictTargets(raw string) (map[string]ConflictTarget, error) {
+	targets := make(map[string]ConflictTarget)
+	for _, entry := range strings.Split(raw, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, spec, found := strings.Cut(entry, "=")
+		tableName, labelType, _ := strings.Cut(strings.TrimSpace(key), ":")
+		tableName, labelType = strings.TrimSpace(tableName), strings.TrimSpace(labelType)
+		if !found || tableName == "" {
+			return nil, fmt.Errorf("invalid conflict target %q, expected <table>[:<label_type>]=<columns>", entry)
+		}
+
+		var target ConflictTarget
+		columnsRaw, predicate, _ := strings.Cut(spec, " WHERE ")
+		for _, column := range strings.Split(columnsRaw, ",") {
+			column = strings.TrimSpace(column)
+			if column != "" {
+				target.Columns = append(target.Columns, column)
+			}
+		}
+		target.Predicate = strings.TrimSpace(predicate)
+
+		targets[conflictTargetKey(tableName, labelType)] = target
+	}
+
+	return targets, nil
+}
+
+// uniqueIndex is unique index or constraint of table with its columns in index order.
+type uniqueIndex struct {
+	Name      string
+	Columns   []string
+	Predicate string
+}
+
+func (p *PostgreSQLpgx) readUniqueIndexes(ctx context.Context, tableName string) ([]uniqueIndex, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	// Expression indexes have zero attnum and could not be used as conflict target by columns
+	rows, err := conn.Query(ctx, `SELECT
+			ic.relname,
+			array_agg(a.attname ORDER BY k.ord),
+			COALESCE(pg_get_expr(i.indpred, i.indrelid), '')
+		FROM pg_index i
+		JOIN pg_class ic ON ic.oid = i.indexrelid
+		JOIN LATERAL unnest(i.indkey) WITH ORDINALITY AS k(attnum, ord) ON true
+		JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = k.attnum
+		WHERE i.indrelid = to_regclass($1)
+			AND i.indisunique
+			AND i.indisvalid
+			AND i.indexprs IS NULL
+		GROUP BY ic.relname, i.indpred, i.indrelid
+		ORDER BY ic.relname`, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var indexes []uniqueIndex
+	for rows.Next() {
+		var index uniqueIndex
+		if err := rows.Scan(&index.Name, &index.Columns, &index.Predicate); err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, index)
+	}
+
+	return indexes, rows.Err()
+}
+
+// chooseConflictTarget picks unique index covering the most of preferred columns. Indexes with
+// columns outside of inserted ones could not be arbiters and are ignored. With labelType only
+// indexes without predicate or with predicate naming the label type are considered, index of
+// other label type would not catch conflicts of inserted rows.
+func chooseConflictTarget(indexes []uniqueIndex, insertedColumns, preferredColumns []string, labelType string) (ConflictTarget, bool) {
+	inserted := make(map[string]bool)
+	for _, column := range insertedColumns {
+		inserted[column] = true
+	}
+	preferred := make(map[string]bool)
+	for _, column := range preferredColumns {
+		preferred[column] = true
+	}
+
+	var best *uniqueIndex
+	bestScore := 0
+	for i := range indexes {
+		score := 0
+		usable := true
+		for _, column := range indexes[i].Columns {
+			if !inserted[column] {
+				usable = false
+				break
+			}
+			if preferred[column] {
+				score++
+			}
+		}
+		if !usable || score == 0 {
+			continue
+		}
+		if labelType != "" && indexes[i].Predicate != "" && !strings.Contains(indexes[i].Predicate, "'"+labelType+"'") {
+			continue
+		}
+		if best == nil || score > bestScore || (score == bestScore && best.Predicate != "" && indexes[i].Predicate == "") {
+			best = &indexes[i]
+			bestScore = score
+		}
+	}
+
+	if best == nil {
+		return ConflictTarget{}, false
+	}
+
+	return ConflictTarget{Columns: best.Columns, Predicate: best.Predicate}, true
+}
+
+// ConflictTargetFor returns ON CONFLICT target for rows of table, labelType is set for rows of
+// labels tables, all of them should have this type. Explicit configuration from
+// SEER_INDEXER_CONFLICT_TARGETS has priority, otherwise unique indexes of table are inspected
+// and result is cached per database pool. Without preferred columns or matching index target
+// is empty, so conflicts with any unique index including primary key are skipped. Detection
+// runs outside of insert transaction, so its failure does not abort the write.
+func (p *PostgreSQLpgx) ConflictTargetFor(ctx context.Context, tableName, labelType string, insertedColumns, preferredColumns []string) ConflictTarget {
+	key := conflictTargetKey(tableName, labelType)
+	if target, ok := ConflictTargetsConfig[key]; ok {
+		return target
+	}
+	if len(preferredColumns) == 0 {
+		return ConflictTarget{}
+	}
+
+	p.conflictTargetsMu.RLock()
+	target, cached := p.conflictTargets[key]
+	p.conflictTargetsMu.RUnlock()
+	if cached {
+		return target
+	}
+
+	indexes, err := p.readUniqueIndexes(ctx, tableName)
+	if err != nil {
+		// Do not cache, failed detection should not stick to connection
+		slog.Warn("Unable to detect unique indexes, conflicts are skipped by any constraint", logging.TableKey, tableName, logging.ErrorKey, err)
+		return ConflictTarget{}
+	}
+
+	target, found := chooseConflictTarget(indexes, insertedColumns, preferredColumns, labelType)
+	if !found {
+		slog.Warn("No unique index matching columns found, conflicts are skipped by any constraint", logging.TableKey, tableName, "label_type", labelType, "columns", preferredColumns)
+	}
+
+	p.conflictTargetsMu.Lock()
+	if p.conflictTargets == nil {
+		p.conflictTargets = make(map[string]ConflictTarget)
+	}
+	p.conflictTargets[key] = target
+	p.conflictTargetsMu.Unlock()
+
+	return target
+}
+
+// splitLabelsByType splits unnest values of labels by their label_type, so rows of each type
+// are inserted with conflict target of their own. Label types are returned in sorted order.
+func splitLabelsByType(columns []string, values map[string]UnnestInsertValueStruct) ([]string, map[string]map[string]UnnestInsertValueStruct) {
+	groups := make(map[string]map[string]UnnestInsertValueStruct)
+	var labelTypes []string
+	for row, value := range values["label_type"].Values {
+		labelType, _ := value.(string)
+		group, exists := groups[labelType]
+		if !exists {
+			group = make(map[string]UnnestInsertValueStruct, len(columns))
+			for _, column := range columns {
+				group[column] = UnnestInsertValueStruct{Type: values[column].Type}
+			}
+			groups[labelType] = group
+			labelTypes = append(labelTypes, labelType)
+		}
+		for _, column := range columns {
+			columnValues := group[column]
+			columnValues.Values = append(columnValues.Values, values[column].Values[row])
+			group[column] = columnValues
+		}
+	}
+	sort.Strings(labelTypes)
+
+	return labelTypes, groups
+}
+
+// executeLabelsInsert inserts labels into labels table, rows of each label type with conflict
+// target matching their unique index.
+func (p *PostgreSQLpgx) executeLabelsInsert(tx pgx.Tx, ctx context.Context, tableName string, columns []string, values map[string]UnnestInsertValueStruct) error {
+	labelTypes, groups := splitLabelsByType(columns, values)
+	for _, labelType := range labelTypes {
+		conflictTarget := p.ConflictTargetFor(ctx, tableName, labelType, columns, LabelConflictColumns(labelType))
+		if err := p.executeBatchInsert(tx, ctx, tableName, columns, groups[labelType], conflictTarget.Clause()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// labelsIndexes are unique indexes of labels table as they are read from pg_index.
+var labelsIndexes = []uniqueIndex{
+	{Name: "ethereum_labels_pkey", Columns: []string{"id"}},
+	{Name: "uk_ethereum_labels_event", Columns: []string{"transaction_hash", "log_index"}, Predicate: "((label_type)::text = ANY ((ARRAY['event'::character varying, 'bor_state_sync'::character varying])::text[]))"},
+	{Name: "uk_ethereum_labels_tx_call", Columns: []string{"transaction_hash"}, Predicate: "((label_type)::text = 'tx_call'::text)"},
+}
+
+var labelsColumns = []string{"id", "label", "transaction_hash", "log_index", "block_number", "block_hash", "block_timestamp", "caller_address", "origin_address", "address", "label_name", "label_type", "label_data"}
+
+func TestChooseConflictTargetOfLabelType(t *testing.T) {
+	testCases := []struct {
+		labelType string
+		expected  ConflictTarget
+		found     bool
+	}{
+		{"event", ConflictTarget{Columns: labelsIndexes[1].Columns, Predicate: labelsIndexes[1].Predicate}, true},
+		{"bor_state_sync", ConflictTarget{Columns: labelsIndexes[1].Columns, Predicate: labelsIndexes[1].Predicate}, true},
+		{"tx_call", ConflictTarget{Columns: labelsIndexes[2].Columns, Predicate: labelsIndexes[2].Predicate}, true},
+		{"internal_tx", ConflictTarget{Columns: []string{"id"}}, true},
+		{"unknown", ConflictTarget{}, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.labelType, func(t *testing.T) {
+			target, found := chooseConflictTarget(labelsIndexes, labelsColumns, LabelConflictColumns(testCase.labelType), testCase.labelType)
+			if found != testCase.found || !reflect.DeepEqual(target, testCase.expected) {
+				t.Fatalf("got target %+v (found %v), want %+v (found %v)", target, found, testCase.expected, testCase.found)
+			}
+		})
+	}
+
+	// Index of tx_call labels is not arbiter of events even if it covers preferred columns
+	if target, found := chooseConflictTarget(labelsIndexes[2:], labelsColumns, LabelConflictColumns("event"), "event"); found {
+		t.Fatalf("events got target of tx_call labels %+v", target)
+	}
+	if clause := (ConflictTarget{}).Clause(); clause != "ON CONFLICT DO NOTHING" {
+		t.Fatalf("clause without target is %q", clause)
+	}
+}
+
+func TestParseConflictTargetsOfLabelType(t *testing.T) {
+	targets, err := ParseConflictTargets("ethereum_labels:event=transaction_hash,log_index WHERE label_type = 'event'; ethereum_transactions=hash")
+	if err != nil {
+		t.Fatalf("ParseConflictTargets: %v", err)
+	}
+
+	expected := map[string]ConflictTarget{
+		"ethereum_labels:event": {Columns: []string{"transaction_hash", "log_index"}, Predicate: "label_type = 'event'"},
+		"ethereum_transactions": {Columns: []string{"hash"}},
+	}
+	if !reflect.DeepEqual(targets, expected) {
+		t.Fatalf("got %+v, want %+v", targets, expected)
+	}
+}
+
+func TestSplitLabelsByType(t *testing.T) {
+	values := map[string]UnnestInsertValueStruct{
+		"transaction_hash": {Type: "TEXT", Values: []interface{}{"0x1", "0x2", "0x3"}},
+		"label_type":       {Type: "TEXT", Values: []interface{}{"tx_call", "internal_tx", "tx_call"}},
+	}
+
+	labelTypes, groups := splitLabelsByType([]string{"transaction_hash", "label_type"}, values)
+	if !reflect.DeepEqual(labelTypes, []string{"internal_tx", "tx_call"}) {
+		t.Fatalf("got label types %v", labelTypes)
+	}
+	if hashes := groups["tx_call"]["transaction_hash"]; hashes.Type != "TEXT" || !reflect.DeepEqual(hashes.Values, []interface{}{"0x1", "0x3"}) {
+		t.Fatalf("got tx_call hashes %+v", hashes)
+	}
+	if hashes := groups["internal_tx"]["transaction_hash"].Values; !reflect.DeepEqual(hashes, []interface{}{"0x2"}) {
+		t.Fatalf("got internal_tx hashes %v", hashes)
+	}
+}
+
+// newTestLabelsDB connects to database from SEER_TEST_POSTGRES_URI with search path set to
+// schema of its own and creates labels table of chain in it. Test is skipped without database.
+func newTestLabelsDB(t *testing.T, blockchain string) *PostgreSQLpgx {
+	t.Helper()
+
+	uri := os.Getenv("SEER_TEST_POSTGRES_URI")
+	if uri == "" {
+		t.Skip("SEER_TEST_POSTGRES_URI is not set")
+	}
+
+	admin, err := NewPostgreSQLpgxWithCustomURI(uri)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(admin.Close)
+
+	schema := fmt.Sprintf("seer_test_%d", time.Now().UnixNano())
+	if _, err := admin.GetPool().Exec(context.Background(), "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.GetPool().Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+	})
+
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		t.Fatalf("parse uri: %v", err)
+	}
+	query := parsed.Query()
+	query.Set("search_path", schema)
+	parsed.RawQuery = query.Encode()
+
+	p, err := NewPostgreSQLpgxWithCustomURI(parsed.String())
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(p.Close)
+
+	if err := p.EnsureLabelsTable(blockchain); err != nil {
+		t.Fatalf("EnsureLabelsTable: %v", err)
+	}
+
+	return p
+}
+
+func countLabels(t *testing.T, p *PostgreSQLpgx, blockchain string) map[string]int {
+	t.Helper()
+
+	rows, err := p.GetPool().Query(context.Background(), fmt.Sprintf("SELECT label_type, count(*) FROM %s GROUP BY label_type", LabelsTableName(blockchain)))
+	if err != nil {
+		t.Fatalf("count labels: %v", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var labelType string
+		var count int
+		if err := rows.Scan(&labelType, &count); err != nil {
+			t.Fatalf("count labels: %v", err)
+		}
+		counts[labelType] = count
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("count labels: %v", err)
+	}
+
+	return counts
+}
+
+func TestWriteEventsAndTransactionsTwice(t *testing.T) {
+	const blockchain = "ethereum"
+	p := newTestLabelsDB(t, blockchain)
+
+	const txHash = "0x3f0b2a4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708"
+	const address = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+	events := []EventLabel{{
+		Label:           "seer",
+		LabelName:       "Transfer",
+		LabelType:       "event",
+		BlockNumber:     100,
+		BlockHash:       "0x01",
+		Address:         address,
+		TransactionHash: txHash,
+		LabelData:       `{"type":"event","name":"Transfer","args":{}}`,
+		LogIndex:        1,
+	}}
+	transactions := []TransactionLabel{{
+		Label:           "seer",
+		LabelName:       "transfer",
+		LabelType:       "tx_call",
+		BlockNumber:     100,
+		BlockHash:       "0x01",
+		Address:         address,
+		TransactionHash: txHash,
+		LabelData:       `{"type":"tx_call","name":"transfer","args":{}}`,
+	}, {
+		Label:           "seer",
+		LabelName:       "native_transfer",
+		LabelType:       "native_transfer",
+		BlockNumber:     100,
+		BlockHash:       "0x01",
+		Address:         address,
+		TransactionHash: txHash,
+		LabelData:       `{"from":"0x5a52e96bacdabb82fd05763e25335261b270efcb","status":1,"to":"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48","value":"1"}`,
+	}}
+
+	// Target of label type written first is not used for the other one
+	for i := 0; i < 2; i++ {
+		if err := p.WriteDataToCustomerDB(blockchain, nil, events, nil); err != nil {
+			t.Fatalf("write %d of events: %v", i, err)
+		}
+		if err := p.WriteDataToCustomerDB(blockchain, transactions, nil, nil); err != nil {
+			t.Fatalf("write %d of transactions: %v", i, err)
+		}
+	}
+
+	counts := countLabels(t, p, blockchain)
+	if counts["event"] != 1 || counts["tx_call"] != 1 || counts["native_transfer"] != 1 {
+		t.Fatalf("got labels %v, want one event, tx_call and native_transfer", counts)
+	}
+}
@@ -0,0 +1,25 @@
+package indexer
+
+// CustomerLabelsDB is customer database synchronizer writes labels and raw transactions to,
+// PostgreSQL and ClickHouse databases are supported.
+type CustomerLabelsDB interface {
+	LabelsWriter
+	// ReadLastLabel returns number of the latest block with labels, 0 if there are none.
+	ReadLastLabel(blockchain string) (uint64, error)
+	Close()
+}
+
+var (
+	_ CustomerLabelsDB = (*PostgreSQLpgx)(nil)
+	_ CustomerLabelsDB = (*ClickHouse)(nil)
+)
+
+// NewCustomerLabelsDB connects to customer database by scheme of its URI, clickhouse:// URIs
+// are served by ClickHouse and the rest by PostgreSQL with row level security of customer.
+func NewCustomerLabelsDB(uri, customerID string) (CustomerLabelsDB, error) {
+	if IsClickHouseURI(uri) {
+		return NewClickHouse(uri)
+	}
+
+	return NewCustomerPostgreSQLpgx(uri, customerID)
+}
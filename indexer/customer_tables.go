@@ -0,0 +1,101 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/G7DAO/seer/logging"
+)
+
+// EnableCreateMissingTables makes label writes create labels and transactions tables of chain
+// which do not exist yet in database instead of failing, so brand-new customer databases do
+// not have to be migrated before first sync.
+func (p *PostgreSQLpgx) EnableCreateMissingTables() {
+	p.createMissingTables = true
+}
+
+// isUndefinedTableError reports whether err is raised for table which does not exist.
+func isUndefinedTableError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "42P01"
+}
+
+// customerTransactionsTableStatements is DDL of raw transactions table of customer database.
+// Columns of blob transactions are added on first write of them, see ensureBlobTransactionColumns.
+func customerTransactionsTableStatements(blockchain, tableName string) []string {
+	l1BlockNumberColumn := ""
+	if IsBlockchainWithL1Chain(blockchain) {
+		l1BlockNumberColumn = "\n\t\t\tl1_block_number BIGINT,"
+	}
+
+	return []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			hash VARCHAR PRIMARY KEY,
+			block_hash VARCHAR NOT NULL,
+			block_timestamp BIGINT NOT NULL,
+			block_number BIGINT NOT NULL,
+			from_address BYTEA,
+			to_address BYTEA,
+			gas NUMERIC,
+			gas_price NUMERIC,
+			input TEXT,
+			nonce VARCHAR,
+			max_fee_per_gas NUMERIC,
+			max_priority_fee_per_gas NUMERIC,
+			transaction_index BIGINT,
+			transaction_type INTEGER,
+			value NUMERIC,%s
+			indexed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+		)`, tableName, l1BlockNumberColumn),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS ix_%[1]s_block_number ON %[1]s (block_number)`, tableName),
+	}
+}
+
+// createMissingTable creates table written by section of labels. Statements run under
+// transaction scoped advisory lock, so replicas writing to new database at the same time do
+// not race on creation of the same indexes.
+func (p *PostgreSQLpgx) createMissingTable(ctx context.Context, blockchain, section string) error {
+	var tableName string
+	var statements []string
+	switch section {
+	case LabelsSectionTransactions, LabelsSectionEvents:
+		tableName = LabelsTableName(blockchain)
+		statements = labelsTableStatements(tableName)
+	case LabelsSectionRawTransactions:
+		tableName = CustomerDBTransactionsTableName(blockchain)
+		statements = customerTransactionsTableStatements(blockchain, tableName)
+	default:
+		return fmt.Errorf("unknown labels section %s", section)
+	}
+
+	conn, err := p.GetPool().Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", "customer_tables:"+tableName); err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(ctx, statement); err != nil {
+			return fmt.Errorf("failed to create %s table: %w", tableName, err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	logging.Chain(blockchain).Info("Created missing labels table", logging.TableKey, tableName, "section", section)
+
+	return nil
+}
@@ -1,30 +1,34 @@
 package indexer
 
 import (
-	"bufio"
 	"context"
 	"database/sql"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
-	"log"
+	"log/slog"
 	"math/big"
-	"os"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/G7DAO/seer/health"
+	"github.com/G7DAO/seer/logging"
+	"github.com/G7DAO/seer/metrics"
 )
 
 // DB is a global variable to hold the GORM database connection.
 
 func LabelsTableName(blockchain string) string {
-	return fmt.Sprintf(blockchain + "_labels")
+	return fmt.Sprintf(ChainTablePrefix(blockchain) + "_labels")
 }
 
 func BlocksTableName(blockchain string) (string, error) {
@@ -37,6 +41,10 @@ func BlocksTableName(blockchain string) (string, error) {
 		return "b3_blocks", nil
 	case "b3_sepolia":
 		return "b3_sepolia_blocks", nil
+	case "base":
+		return "base_blocks", nil
+	case "base_sepolia":
+		return "base_sepolia_blocks", nil
 	case "ethereum":
 		return "ethereum_blocks", nil
 	case "game7":
@@ -49,10 +57,20 @@ func BlocksTableName(blockchain string) (string, error) {
 		return "imx_zkevm_blocks", nil
 	case "imx_zkevm_sepolia":
 		return "imx_zkevm_sepolia_blocks", nil
+	case LocalChain:
+		return ChainTablePrefix(blockchain) + "_blocks", nil
+	case "hyperevm":
+		return "hyperevm_blocks", nil
+	case "hyperevm_testnet":
+		return "hyperevm_testnet_blocks", nil
 	case "mantle":
 		return "mantle_blocks", nil
 	case "mantle_sepolia":
 		return "mantle_sepolia_blocks", nil
+	case "op_sepolia":
+		return "op_sepolia_blocks", nil
+	case "optimism":
+		return "optimism_blocks", nil
 	case "polygon":
 		return "polygon_blocks", nil
 	case "ronin":
@@ -61,12 +79,20 @@ func BlocksTableName(blockchain string) (string, error) {
 		return "ronin_saigon_blocks", nil
 	case "sepolia":
 		return "sepolia_blocks", nil
+	case "solana":
+		return "solana_blocks", nil
+	case "starknet":
+		return "starknet_blocks", nil
+	case "starknet_sepolia":
+		return "starknet_sepolia_blocks", nil
 	case "xai":
 		return "xai_blocks", nil
 	case "xai_sepolia":
 		return "xai_sepolia_blocks", nil
+	case "zksync_era":
+		return "zksync_era_blocks", nil
 	default:
-		return "", fmt.Errorf("Unsupported blockchain")
+		return "", unsupportedChainError(blockchain)
 	}
 }
 
@@ -80,6 +106,10 @@ func TransactionsTableName(blockchain string) (string, error) {
 		return "b3_transactions", nil
 	case "b3_sepolia":
 		return "b3_sepolia_transactions", nil
+	case "base":
+		return "base_transactions", nil
+	case "base_sepolia":
+		return "base_sepolia_transactions", nil
 	case "ethereum":
 		return "ethereum_transactions", nil
 	case "game7":
@@ -92,10 +122,20 @@ func TransactionsTableName(blockchain string) (string, error) {
 		return "imx_zkevm_transactions", nil
 	case "imx_zkevm_sepolia":
 		return "imx_zkevm_sepolia_transactions", nil
+	case LocalChain:
+		return ChainTablePrefix(blockchain) + "_transactions", nil
+	case "hyperevm":
+		return "hyperevm_transactions", nil
+	case "hyperevm_testnet":
+		return "hyperevm_testnet_transactions", nil
 	case "mantle":
 		return "mantle_transactions", nil
 	case "mantle_sepolia":
 		return "mantle_sepolia_transactions", nil
+	case "op_sepolia":
+		return "op_sepolia_transactions", nil
+	case "optimism":
+		return "optimism_transactions", nil
 	case "polygon":
 		return "polygon_transactions", nil
 	case "ronin":
@@ -104,17 +144,25 @@ func TransactionsTableName(blockchain string) (string, error) {
 		return "ronin_saigon_transactions", nil
 	case "sepolia":
 		return "sepolia_transactions", nil
+	case "solana":
+		return "solana_transactions", nil
+	case "starknet":
+		return "starknet_transactions", nil
+	case "starknet_sepolia":
+		return "starknet_sepolia_transactions", nil
 	case "xai":
 		return "xai_transactions", nil
 	case "xai_sepolia":
 		return "xai_sepolia_transactions", nil
+	case "zksync_era":
+		return "zksync_era_transactions", nil
 	default:
-		return "", fmt.Errorf("Unsupported blockchain")
+		return "", unsupportedChainError(blockchain)
 	}
 }
 
 func CustomerDBTransactionsTableName(blockchain string) string {
-	return fmt.Sprintf(blockchain + "_transactions")
+	return fmt.Sprintf(ChainTablePrefix(blockchain) + "_transactions")
 }
 
 // Helper function to convert hex string to nullable big.Int
@@ -174,6 +222,20 @@ func IsBlockchainWithL1Chain(blockchain string) bool {
 		return false
 	case "ronin_saigon":
 		return false
+	case "hyperevm":
+		return false
+	case "hyperevm_testnet":
+		return false
+	case "base":
+		return false
+	case "base_sepolia":
+		return false
+	case "optimism":
+		return false
+	case "op_sepolia":
+		return false
+	case "zksync_era":
+		return true
 	default:
 		return false
 	}
@@ -195,6 +257,18 @@ func FilterABIJobs(abiJobs []AbiJob, ids []string) []AbiJob {
 
 type PostgreSQLpgx struct {
 	pool *pgxpool.Pool
+
+	// readPool points to read replica for analytics queries, nil if replica is not configured
+	readPool *pgxpool.Pool
+
+	conflictTargetsMu sync.RWMutex
+	conflictTargets   map[string]ConflictTarget
+
+	blobColumnsMu sync.Mutex
+	blobColumns   map[string]bool
+
+	// createMissingTables makes label writes create missing tables, see EnableCreateMissingTables
+	createMissingTables bool
 }
 
 func NewPostgreSQLpgx(dbUri string) (*PostgreSQLpgx, error) {
@@ -208,13 +282,16 @@ func NewPostgreSQLpgx(dbUri string) (*PostgreSQLpgx, error) {
 	config, err := pgxpool.ParseConfig(newURL)
 
 	if err != nil {
-		log.Println("Error parsing config", err)
+		slog.Error("Unable to parse database config", logging.ErrorKey, err)
 		return nil, err
 	}
 
+	// Records statements of requests served in debug mode, see WithQueryDebug
+	config.ConnConfig.Tracer = queryDebugTracer{}
+
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
-		log.Println("Error creating pool", err)
+		slog.Error("Unable to create database pool", logging.ErrorKey, err)
 		return nil, err
 	}
 
@@ -223,13 +300,29 @@ func NewPostgreSQLpgx(dbUri string) (*PostgreSQLpgx, error) {
 	}, nil
 }
 
+// AttachReadReplica creates second pool to read replica, read-only analytics methods use it
+// while writes continue to go to primary database.
+func (p *PostgreSQLpgx) AttachReadReplica(replicaUri string) error {
+	replica, err := NewPostgreSQLpgx(replicaUri)
+	if err != nil {
+		return err
+	}
+
+	if p.readPool != nil {
+		p.readPool.Close()
+	}
+	p.readPool = replica.pool
+
+	return nil
+}
+
 func NewPostgreSQLpgxWithCustomURI(uri string) (*PostgreSQLpgx, error) {
 
 	//  create a connection to the database
 
 	pool, err := pgxpool.New(context.Background(), uri)
 	if err != nil {
-		log.Println("Error creating pool", err)
+		slog.Error("Unable to create database pool", logging.ErrorKey, err)
 		return nil, err
 	}
 
@@ -240,12 +333,28 @@ func NewPostgreSQLpgxWithCustomURI(uri string) (*PostgreSQLpgx, error) {
 
 func (p *PostgreSQLpgx) Close() {
 	p.pool.Close()
+	if p.readPool != nil {
+		p.readPool.Close()
+	}
 }
 
 func (p *PostgreSQLpgx) GetPool() *pgxpool.Pool {
 	return p.pool
 }
 
+// Ping checks that connection to database could be acquired.
+func (p *PostgreSQLpgx) Ping(ctx context.Context) error {
+	return p.pool.Ping(ctx)
+}
+
+// GetReadPool returns read replica pool, or primary pool if replica is not attached.
+func (p *PostgreSQLpgx) GetReadPool() *pgxpool.Pool {
+	if p.readPool != nil {
+		return p.readPool
+	}
+	return p.pool
+}
+
 // read from database
 
 func (p *PostgreSQLpgx) ReadBlockIndex(ctx context.Context, startBlock uint64, endBlock uint64) ([]BlockIndex, error) {
@@ -276,7 +385,9 @@ func (p *PostgreSQLpgx) ReadBlockIndex(ctx context.Context, startBlock uint64, e
 
 }
 
-func (p *PostgreSQLpgx) ReadIndexOnRange(tableName string, startBlock uint64, endBlock uint64) ([]interface{}, error) {
+// ReadIndexOnRange reads blocks of range with their transactions and logs from index tables,
+// all four topics of logs are selected so events could be decoded from index alone.
+func (p *PostgreSQLpgx) ReadIndexOnRange(startBlock uint64, endBlock uint64) ([]RangeIndex, error) {
 	pool := p.GetPool()
 
 	conn, err := pool.Acquire(context.Background())
@@ -287,28 +398,36 @@ func (p *PostgreSQLpgx) ReadIndexOnRange(tableName string, startBlock uint64, en
 
 	defer conn.Release()
 
-	var indices []interface{}
-
-	rows, err := conn.Query(context.Background(), "SELECT bt.block_number, bt.block_hash, bt.block_timestamp, tt.hash, tt.index, tt.path as transaction_, tt.input as transaction_input, lt.selector, lt.topic1, lt.topic2, lt.transaction_hash, lt.log_index, lt.path as event_path FROM block_index bt LEFT JOIN transaction_index tt ON bt.block_number = tt.block_number LEFT JOIN log_index lt ON tt.hash = lt.transaction_hash WHERE bt.block_number >= $1 AND bt.block_number <= $2", startBlock, endBlock)
+	rows, err := conn.Query(context.Background(), `SELECT bt.block_number, bt.block_hash, bt.block_timestamp,
+		tt.hash as transaction_hash, tt.index as transaction_index, tt.path as transaction_path, tt.input as transaction_input,
+		lt.selector, lt.topic1, lt.topic2, lt.topic3, lt.log_index, lt.path as event_path
+		FROM block_index bt
+		LEFT JOIN transaction_index tt ON bt.block_number = tt.block_number
+		LEFT JOIN log_index lt ON tt.hash = lt.transaction_hash
+		WHERE bt.block_number >= $1 AND bt.block_number <= $2
+		ORDER BY bt.block_number, tt.index, lt.log_index`, startBlock, endBlock)
 
 	if err != nil {
 		return nil, err
 	}
 
-	for rows.Next() {
-
-		var index interface{}
-
-		err = rows.Scan(&index)
+	return pgx.CollectRows(rows, pgx.RowToStructByName[RangeIndex])
+}
 
-		if err != nil {
-			return nil, err
-		}
+// EnsureLogIndexTopicColumns adds topic3 column to logs index, rows indexed before it
+// appeared have it empty and should be reindexed for events with three indexed arguments.
+func (p *PostgreSQLpgx) EnsureLogIndexTopicColumns() error {
+	pool := p.GetPool()
 
-		indices = append(indices, index)
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
 	}
+	defer conn.Release()
 
-	return indices, nil
+	_, err = conn.Exec(context.Background(), "ALTER TABLE log_index ADD COLUMN IF NOT EXISTS topic3 TEXT")
+
+	return err
 }
 
 func (p *PostgreSQLpgx) ReadLastLabel(blockchain string) (uint64, error) {
@@ -328,7 +447,7 @@ func (p *PostgreSQLpgx) ReadLastLabel(blockchain string) (uint64, error) {
 
 	var label uint64
 
-	query := fmt.Sprintf("SELECT block_number FROM %s ORDER BY block_number DESC LIMIT 1", blockchain+"_labels")
+	query := fmt.Sprintf("SELECT block_number FROM %s ORDER BY block_number DESC LIMIT 1", LabelsTableName(blockchain))
 
 	err = conn.QueryRow(context.Background(), query).Scan(&label)
 
@@ -343,11 +462,32 @@ func (p *PostgreSQLpgx) ReadLastLabel(blockchain string) (uint64, error) {
 	return label, nil
 }
 
+// decodeJobAddress decodes address of ABI jobs, wildcard address is stored as NULL.
+func decodeJobAddress(address string) ([]byte, error) {
+	if address == WildcardAddress {
+		return nil, nil
+	}
+	return decodeAddress(address)
+}
+
+// jobDeployBlock returns deployment block stored with new jobs. Wildcard jobs without block set
+// explicitly have NULL block, they are decoded from new blocks and skipped by historical sync.
+func jobDeployBlock(addressBytes []byte, deployBlock uint64) *uint64 {
+	if addressBytes == nil && deployBlock == 0 {
+		return nil
+	}
+	return &deployBlock
+}
+
 func decodeAddress(address string) ([]byte, error) {
 	if len(address) < 2 {
 		return []byte{0x00}, nil
 	}
-	return hex.DecodeString(address[2:])
+	addressBytes, err := hex.DecodeString(address[2:])
+	if err != nil {
+		return nil, &DecodeAddressError{Address: address, Err: err}
+	}
+	return addressBytes, nil
 }
 
 // updateValues updates the values in the map for a given key
@@ -379,13 +519,19 @@ func determineValue(val interface{}) interface{} {
 	return val
 }
 
-func (p *PostgreSQLpgx) WriteIndexes(blockchain string, blocksIndexPack []BlockIndex) error {
+func (p *PostgreSQLpgx) WriteIndexes(blockchain string, blocksIndexPack []BlockIndex) (err error) {
+	defer metrics.ObserveDBWrite(blockchain, "indexes", time.Now())
+	defer func() {
+		if err == nil {
+			health.RecordDBWrite()
+		}
+	}()
 
 	ctx := context.Background()
 	pool := p.GetPool()
 	conn, err := pool.Acquire(ctx)
 	if err != nil {
-		log.Println("Connection error", err)
+		logging.Chain(blockchain).Error("Unable to acquire database connection", logging.ErrorKey, err)
 		return err
 	}
 	defer conn.Release()
@@ -405,6 +551,11 @@ func (p *PostgreSQLpgx) WriteIndexes(blockchain string, blocksIndexPack []BlockI
 		}
 	}()
 
+	err = applySessionSettings(ctx, tx, TableClassIndexes)
+	if err != nil {
+		return err
+	}
+
 	// Write blocks index
 	if len(blocksIndexPack) > 0 {
 		err = p.writeBlockIndexToDB(tx, blockchain, blocksIndexPack)
@@ -439,7 +590,7 @@ func (p *PostgreSQLpgx) executeBatchInsert(tx pgx.Tx, ctx context.Context, table
 	// track execution time
 
 	if _, err := tx.Exec(ctx, query, valuesSlice...); err != nil {
-		log.Println("Error executing bulk insert", err)
+		slog.Error("Failed to execute bulk insert", logging.TableKey, tableName, logging.ErrorKey, err)
 		return fmt.Errorf("error executing bulk insert for batch: %w", err)
 	}
 
@@ -504,6 +655,23 @@ func (p *PostgreSQLpgx) writeBlockIndexToDB(tx pgx.Tx, blockchain string, indexe
 		}
 	}
 
+	// Miner column is added by crawler at start, indexes written by other tools do not
+	// carry it
+	withMiner := false
+	for _, index := range indexes {
+		if index.Miner != "" {
+			withMiner = true
+			break
+		}
+	}
+	if withMiner {
+		columns = append(columns, "miner")
+		valuesMap["miner"] = UnnestInsertValueStruct{
+			Type:   "TEXT",
+			Values: make([]interface{}, 0),
+		}
+	}
+
 	for _, index := range indexes {
 
 		updateValues(valuesMap, "block_number", index.BlockNumber)
@@ -518,6 +686,10 @@ func (p *PostgreSQLpgx) writeBlockIndexToDB(tx pgx.Tx, blockchain string, indexe
 		if isBlockchainWithL1Chain {
 			updateValues(valuesMap, "l1_block_number", index.L1BlockNumber)
 		}
+
+		if withMiner {
+			updateValues(valuesMap, "miner", strings.ToLower(index.Miner))
+		}
 	}
 
 	ctx := context.Background()
@@ -527,7 +699,7 @@ func (p *PostgreSQLpgx) writeBlockIndexToDB(tx pgx.Tx, blockchain string, indexe
 		return err
 	}
 
-	log.Printf("Add %d records into %s table", len(indexes), tableName)
+	logging.Chain(blockchain).Info("Adding block indexes", logging.TableKey, tableName, "rows", len(indexes))
 
 	return nil
 }
@@ -607,7 +779,10 @@ func (p *PostgreSQLpgx) GetLatestDBBlockNumber(blockchain string, reverse ...boo
 
 	err = conn.QueryRow(context.Background(), query).Scan(&blockNumber)
 	if err != nil {
-		log.Printf("No data found in %s table", blocksTableName)
+		if errors.Is(err, pgx.ErrNoRows) {
+			logging.Chain(blockchain).Info("No blocks indexed", logging.TableKey, blocksTableName)
+			return 0, fmt.Errorf("%w in %s table", ErrNoRowsIndexed, blocksTableName)
+		}
 		return 0, err
 	}
 
@@ -616,7 +791,7 @@ func (p *PostgreSQLpgx) GetLatestDBBlockNumber(blockchain string, reverse ...boo
 }
 
 func (p *PostgreSQLpgx) ReadABIJobs(blockchain string) ([]AbiJob, error) {
-	pool := p.GetPool()
+	pool := p.GetReadPool()
 
 	conn, err := pool.Acquire(context.Background())
 
@@ -626,7 +801,7 @@ func (p *PostgreSQLpgx) ReadABIJobs(blockchain string) ([]AbiJob, error) {
 
 	defer conn.Release()
 
-	rows, err := conn.Query(context.Background(), "SELECT id, address, user_id, customer_id, abi_selector, chain, abi_name, status, historical_crawl_status, progress, moonworm_task_pickedup, '[' || abi || ']' as abi, (abi::jsonb)->>'type' as abiType, created_at, updated_at, deployment_block_number FROM abi_jobs where chain=$1 and (abi::jsonb)->>'type' is not null", blockchain)
+	rows, err := conn.Query(context.Background(), "SELECT id, address, user_id, customer_id, abi_selector, chain, abi_name, status, historical_crawl_status, progress, moonworm_task_pickedup, '[' || abi || ']' as abi, (abi::jsonb)->>'type' as abiType, created_at, updated_at, deployment_block_number, to_jsonb(abi_jobs) -> 'topic_filters' AS topic_filters FROM abi_jobs where chain=$1 and (abi::jsonb)->>'type' is not null", blockchain)
 
 	if err != nil {
 		return nil, err
@@ -634,7 +809,7 @@ func (p *PostgreSQLpgx) ReadABIJobs(blockchain string) ([]AbiJob, error) {
 
 	abiJobs, err := pgx.CollectRows(rows, pgx.RowToStructByName[AbiJob])
 	if err != nil {
-		log.Println("Error collecting Abi jobs rows", err)
+		logging.Chain(blockchain).Error("Failed to collect ABI jobs rows", logging.ErrorKey, err)
 		return nil, err
 	}
 
@@ -685,6 +860,9 @@ func (p *PostgreSQLpgx) GetCustomersIDs(blockchain string) ([]string, error) {
 	return customerIds, nil
 }
 
+// ReadUpdates returns paths of batches with blocks from fromBlock and ABI jobs of chain grouped
+// by customer. Jobs are limited to customerIds if they are set, so replicas of synchronizer
+// with leased customers decode only jobs of their customers.
 func (p *PostgreSQLpgx) ReadUpdates(blockchain string, fromBlock uint64, customerIds []string, minBlocksToSync int) (uint64, uint64, []string, []CustomerUpdates, error) {
 
 	pool := p.GetPool()
@@ -704,6 +882,13 @@ func (p *PostgreSQLpgx) ReadUpdates(blockchain string, fromBlock uint64, custome
 		return 0, 0, paths, nil, blocksTableErr
 	}
 
+	queryArgs := []interface{}{fromBlock, blockchain, minBlocksToSync, WildcardAddress}
+	customersFilter := ""
+	if len(customerIds) > 0 {
+		customersFilter = "AND customer_id = ANY($5)"
+		queryArgs = append(queryArgs, customerIds)
+	}
+
 	query := fmt.Sprintf(`WITH path as (
         SELECT
             path,
@@ -726,17 +911,20 @@ func (p *PostgreSQLpgx) ReadUpdates(blockchain string, fromBlock uint64, custome
     jobs AS (
         SELECT
             address as address,
-            '0x' || encode(address, 'hex') as address_str,
+            COALESCE('0x' || encode(address, 'hex'), $4) as address_str,
             customer_id,
             abi_selector,
             abi_name,
             abi,
 			(abi)::jsonb ->> 'type' as abi_type,
-        	(abi)::jsonb ->> 'stateMutability' as abi_stateMutability
+        	(abi)::jsonb ->> 'stateMutability' as abi_stateMutability,
+			to_jsonb(abi_jobs) -> 'topic_filters' as topic_filters
         FROM
             abi_jobs
         WHERE
             chain = $2
+            AND status IS DISTINCT FROM 'inactive'
+            %s
     ),
     address_abis AS (
         SELECT
@@ -747,7 +935,8 @@ func (p *PostgreSQLpgx) ReadUpdates(blockchain string, fromBlock uint64, custome
                 json_build_object(
                     'abi', '[' || abi || ']',
                     'abi_name', abi_name,
-					'abi_type', abi_type 
+					'abi_type', abi_type,
+					'topic_filters', topic_filters
                 )
             ) AS abis_per_address
         FROM
@@ -771,12 +960,12 @@ func (p *PostgreSQLpgx) ReadUpdates(blockchain string, fromBlock uint64, custome
     	(SELECT json_agg(json_build_object(customer_id, abis)) FROM reformatted_jobs) as jobs
 	FROM
     	latest_block_of_path
-	`, blocksTableName, blocksTableName)
+	`, blocksTableName, blocksTableName, customersFilter)
 
-	rows, err := conn.Query(context.Background(), query, fromBlock, blockchain, minBlocksToSync)
+	rows, err := conn.Query(context.Background(), query, queryArgs...)
 
 	if err != nil {
-		log.Println("Error querying abi jobs from database", err)
+		logging.Chain(blockchain).Error("Failed to query ABI jobs", logging.ErrorKey, err)
 		return 0, 0, paths, nil, err
 	}
 
@@ -786,7 +975,7 @@ func (p *PostgreSQLpgx) ReadUpdates(blockchain string, fromBlock uint64, custome
 	for rows.Next() {
 		err = rows.Scan(&lastBlockNumber, &paths, &customers)
 		if err != nil {
-			log.Println("Error scanning row:", err)
+			logging.Chain(blockchain).Error("Failed to scan updates row", logging.ErrorKey, err)
 			return 0, 0, paths, nil, err
 		}
 	}
@@ -810,177 +999,231 @@ func (p *PostgreSQLpgx) ReadUpdates(blockchain string, fromBlock uint64, custome
 
 }
 
-func (p *PostgreSQLpgx) EnsureCorrectSelectors(blockchain string, WriteToDB bool, outputFilePath string, ids []string) error {
-
-	pool := p.GetPool()
-
-	conn, err := pool.Acquire(context.Background())
-
-	if err != nil {
-		return err
-	}
+// EnsureSelectorsOptions controls EnsureCorrectSelectors run. Jobs are processed in chunks
+// ordered by id, run stops after MaxJobs checked jobs and could be resumed from NextCursor.
+type EnsureSelectorsOptions struct {
+	WriteToDB bool
+	IDs       []string
+	Cursor    string
+	ChunkSize int
+	MaxJobs   int
+}
 
-	defer conn.Release()
+type SelectorMismatch struct {
+	ID              string `json:"id"`
+	AbiName         string `json:"abi_name"`
+	Address         string `json:"address"`
+	Selector        string `json:"selector"`
+	CorrectSelector string `json:"correct_selector"`
+	Updated         bool   `json:"updated"`
+}
 
-	// Get all the ABI jobs for the blockchain
+type SelectorFailure struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+}
 
-	abiJobs, err := p.ReadABIJobs(blockchain)
+// EnsureSelectorsResult is a progress of EnsureCorrectSelectors run, NextCursor is empty
+// when all jobs of blockchain are checked.
+type EnsureSelectorsResult struct {
+	Blockchain string             `json:"blockchain"`
+	WriteToDB  bool               `json:"write_to_db"`
+	Checked    int                `json:"checked"`
+	Mismatches []SelectorMismatch `json:"mismatches"`
+	Updated    int                `json:"updated"`
+	Failed     []SelectorFailure  `json:"failed"`
+	NextCursor string             `json:"next_cursor"`
+	Done       bool               `json:"done"`
+}
 
+func abiJobSelector(abiJob AbiJob) (string, error) {
+	abiObj, err := abi.JSON(strings.NewReader(abiJob.Abi))
 	if err != nil {
-		return err
+		return "", fmt.Errorf("unable to parse ABI: %v", err)
 	}
 
-	if len(ids) > 0 {
-		abiJobs = FilterABIJobs(abiJobs, ids)
-	} else {
-		log.Println("Found", len(abiJobs), "ABI jobs for blockchain:", blockchain)
+	if abiJob.AbiType == "event" {
+		event, ok := abiObj.Events[abiJob.AbiName]
+		if !ok {
+			return "", fmt.Errorf("event %s not found in ABI", abiJob.AbiName)
+		}
+		// Anonymous event jobs keep their prefix, it is the opt-in for matching by shape
+		if event.Anonymous && IsAnonymousEventSelector(abiJob.AbiSelector) {
+			return AnonymousEventSelector(event.ID.String()), nil
+		}
+		return event.ID.String(), nil
 	}
-	var writer *bufio.Writer
-	var f *os.File
-
-	// for each ABI job, check if the selector is correct
-
-	if outputFilePath != "" {
-
-		f, err := os.OpenFile(outputFilePath, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
 
-		if err != nil {
-			log.Println("Error opening file:", err)
-			return err
-		}
+	method, ok := abiObj.Methods[abiJob.AbiName]
+	if !ok {
+		return "", fmt.Errorf("method %s not found in ABI", abiJob.AbiName)
+	}
+	return fmt.Sprintf("0x%x", method.ID), nil
+}
 
-		writer := bufio.NewWriter(f)
+// EnsureCorrectSelectors recalculates selectors of ABI jobs and compares them with stored ones,
+// mismatched selectors are updated if WriteToDB is set. Failed jobs do not stop the run.
+func (p *PostgreSQLpgx) EnsureCorrectSelectors(blockchain string, options EnsureSelectorsOptions) (*EnsureSelectorsResult, error) {
+	if options.ChunkSize <= 0 {
+		options.ChunkSize = 1000
+	}
 
-		writer.WriteString(fmt.Sprintf("ABI jobs for blockchain: %s runned as WriteToDB: %v recorded at %s\n", blockchain, WriteToDB, time.Now().String()))
+	pool := p.GetPool()
 
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
 	}
+	defer conn.Release()
 
-	for _, abiJob := range abiJobs {
+	result := &EnsureSelectorsResult{
+		Blockchain: blockchain,
+		WriteToDB:  options.WriteToDB,
+		Mismatches: []SelectorMismatch{},
+		Failed:     []SelectorFailure{},
+	}
 
-		// Now you can use abiJSONStr as a string
-		abiObj, err := abi.JSON(strings.NewReader(abiJob.Abi))
-		if err != nil {
-			log.Println("Error parsing ABI for ABI job:", abiJob.ID, err)
-			return err
+	cursor := options.Cursor
+	for {
+		limit := options.ChunkSize
+		if options.MaxJobs > 0 && options.MaxJobs-result.Checked < limit {
+			limit = options.MaxJobs - result.Checked
 		}
 
-		var selector string
-
-		if abiJob.AbiType == "event" {
-			selector = abiObj.Events[abiJob.AbiName].ID.String()
-		} else {
-			selectorRaw := abiObj.Methods[abiJob.AbiName].ID
-			selector = fmt.Sprintf("0x%x", selectorRaw)
+		queryArgs := pgx.NamedArgs{
+			"chain": blockchain,
+			"limit": limit,
+		}
+		query := "SELECT id, address, user_id, customer_id, abi_selector, chain, abi_name, status, historical_crawl_status, progress, moonworm_task_pickedup, '[' || abi || ']' as abi, (abi::jsonb)->>'type' as abiType, created_at, updated_at, deployment_block_number, to_jsonb(abi_jobs) -> 'topic_filters' AS topic_filters FROM abi_jobs WHERE chain = @chain AND (abi::jsonb)->>'type' IS NOT NULL"
+		if cursor != "" {
+			query += " AND id > @cursor"
+			queryArgs["cursor"] = cursor
 		}
+		if len(options.IDs) > 0 {
+			query += " AND id = ANY(@ids::uuid[])"
+			queryArgs["ids"] = options.IDs
+		}
+		query += " ORDER BY id LIMIT @limit"
 
+		rows, err := conn.Query(context.Background(), query, queryArgs)
 		if err != nil {
-			log.Println("Error getting selector for ABI job:", abiJob.ID, err)
-			continue
+			return result, err
 		}
 
-		// Check if the selector is correct
-
-		if abiJob.AbiSelector != selector {
-
-			if WriteToDB {
-				// Update the selector in the database
-
-				_, err := conn.Exec(context.Background(), "UPDATE abi_jobs SET abi_selector = $1 WHERE id = $2", selector, abiJob.ID)
+		abiJobs, err := pgx.CollectRows(rows, pgx.RowToStructByName[AbiJob])
+		if err != nil {
+			logging.Chain(blockchain).Error("Failed to collect ABI jobs rows", logging.ErrorKey, err)
+			return result, err
+		}
 
-				if err != nil {
-					log.Println("Error updating selector for ABI job:", abiJob.ID, err)
-					continue
-				}
+		for _, abiJob := range abiJobs {
+			result.Checked++
+			cursor = abiJob.ID
 
-				log.Println("Updated selector:", abiJob.AbiSelector, " for ABI job:", abiJob.ID, " to new selector:", selector)
+			selector, selectorErr := abiJobSelector(abiJob)
+			if selectorErr != nil {
+				result.Failed = append(result.Failed, SelectorFailure{ID: abiJob.ID, Reason: selectorErr.Error()})
+				continue
+			}
 
+			if abiJob.AbiSelector == selector {
+				continue
 			}
 
-			if outputFilePath != "" {
+			mismatch := SelectorMismatch{
+				ID:              abiJob.ID,
+				AbiName:         abiJob.AbiName,
+				Address:         fmt.Sprintf("0x%x", abiJob.Address),
+				Selector:        abiJob.AbiSelector,
+				CorrectSelector: selector,
+			}
 
-				_, err = writer.WriteString(fmt.Sprintf("ABI job ID: %s, Name: %s, Address: %x, Selector: %s, Correct Selector: %s\n", abiJob.ID, abiJob.AbiName, abiJob.Address, abiJob.AbiSelector, selector))
-				if err != nil {
-					log.Println("Error writing to file:", err)
-					continue
+			if options.WriteToDB {
+				_, updateErr := conn.Exec(context.Background(), "UPDATE abi_jobs SET abi_selector = $1 WHERE id = $2", selector, abiJob.ID)
+				if updateErr != nil {
+					result.Failed = append(result.Failed, SelectorFailure{ID: abiJob.ID, Reason: fmt.Sprintf("unable to update selector: %v", updateErr)})
+				} else {
+					mismatch.Updated = true
+					result.Updated++
+					logging.Chain(blockchain).Info("Updated selector of ABI job", logging.JobKey, abiJob.ID, "old_selector", abiJob.AbiSelector, "selector", selector)
 				}
-
 			}
 
+			result.Mismatches = append(result.Mismatches, mismatch)
 		}
 
+		if len(abiJobs) < limit {
+			result.Done = true
+			result.NextCursor = ""
+			break
+		}
+
+		result.NextCursor = cursor
+		if options.MaxJobs > 0 && result.Checked >= options.MaxJobs {
+			break
+		}
 	}
 
-	if outputFilePath != "" {
-		writer.Flush()
+	logging.Chain(blockchain).Info("Checked selectors of ABI jobs", "checked", result.Checked, "mismatched", len(result.Mismatches), "updated", result.Updated, "failed", len(result.Failed))
 
-		f.Close()
-	}
-	return nil
+	return result, nil
 }
 
+// WriteDataToCustomerDB writes transaction calls, events and raw transactions, each section
+// in its own database transaction. Sections failed with transient errors are retried, if some
+// of them are still not written *LabelsWriteError describes what was persisted.
 func (p *PostgreSQLpgx) WriteDataToCustomerDB(
 	blockchain string,
 	txCalls []TransactionLabel,
 	events []EventLabel,
 	rawTransactions []RawTransaction,
 ) error {
+	defer metrics.ObserveDBWrite(blockchain, "labels", time.Now())
 
-	pool := p.GetPool()
-
-	conn, err := pool.Acquire(context.Background())
+	ctx := context.Background()
 
-	if err != nil {
-		return err
+	type labelsSection struct {
+		name  string
+		rows  int
+		write func(tx pgx.Tx) error
 	}
-
-	defer conn.Release()
-
-	tx, err := conn.Begin(context.Background())
-
-	if err != nil {
-		return err
+	sections := []labelsSection{
+		{LabelsSectionTransactions, len(txCalls), func(tx pgx.Tx) error { return p.WriteTransactions(tx, blockchain, txCalls) }},
+		{LabelsSectionEvents, len(events), func(tx pgx.Tx) error { return p.WriteEvents(tx, blockchain, events) }},
+		{LabelsSectionRawTransactions, len(rawTransactions), func(tx pgx.Tx) error { return p.WriteRawTransactions(tx, blockchain, rawTransactions) }},
 	}
 
-	defer func() {
-		if pErr := recover(); pErr != nil {
-			tx.Rollback(context.Background())
-			panic(pErr)
-		} else if err != nil {
-			tx.Rollback(context.Background())
-		} else {
-			err = tx.Commit(context.Background())
-			if err != nil {
-				log.Println("Error committing transaction:", err)
-				panic(err)
-			}
+	writeErr := &LabelsWriteError{Blockchain: blockchain}
+	for _, section := range sections {
+		if section.rows == 0 {
+			continue
 		}
-	}()
 
-	if len(txCalls) > 0 {
-		err := p.WriteTransactions(tx, blockchain, txCalls)
-		if err != nil {
-			log.Println("Error writing transactions:", err)
-			return err
+		result := p.writeLabelsSectionWithRetries(ctx, blockchain, section.name, section.rows, section.write)
+		if result.Err != nil && p.createMissingTables && isUndefinedTableError(result.Err) {
+			if createErr := p.createMissingTable(ctx, blockchain, section.name); createErr != nil {
+				logging.Chain(blockchain).Warn("Unable to create missing labels table", "section", section.name, logging.ErrorKey, createErr)
+			} else {
+				attempts := result.Attempts
+				result = p.writeLabelsSectionWithRetries(ctx, blockchain, section.name, section.rows, section.write)
+				result.Attempts += attempts
+			}
 		}
-	}
-
-	if len(events) > 0 {
-		err := p.WriteEvents(tx, blockchain, events)
-		if err != nil {
-			log.Println("Error writing events:", err)
-			return err
+		if result.Err != nil {
+			logging.Chain(blockchain).Error("Failed to write labels section", "section", section.name, "attempts", result.Attempts, logging.ErrorKey, result.Err)
+			writeErr.Failed = append(writeErr.Failed, result)
+			continue
 		}
+		writeErr.Persisted = append(writeErr.Persisted, result)
 	}
 
-	if len(rawTransactions) > 0 {
-		err := p.WriteRawTransactions(tx, blockchain, rawTransactions)
-		if err != nil {
-			log.Println("Error writing raw transactions:", err)
-			return err
-		}
+	if len(writeErr.Failed) > 0 {
+		return writeErr
 	}
 
-	return err
+	health.RecordDBWrite()
+
+	return nil
 }
 
 func (p *PostgreSQLpgx) WriteEvents(tx pgx.Tx, blockchain string, events []EventLabel) error {
@@ -1056,23 +1299,23 @@ func (p *PostgreSQLpgx) WriteEvents(tx pgx.Tx, blockchain string, events []Event
 
 	for _, event := range events {
 
-		id := uuid.New()
+		id := EventLabelID(blockchain, event)
 
 		callerAddressBytes, err := decodeAddress(event.CallerAddress)
 		if err != nil {
-			fmt.Println("Error decoding caller address:", err, event)
+			logging.Chain(blockchain).Warn("Unable to decode caller address of event", logging.TxKey, event.TransactionHash, logging.ErrorKey, err)
 			continue
 		}
 
 		originAddressBytes, err := decodeAddress(event.OriginAddress)
 		if err != nil {
-			fmt.Println("Error decoding origin address:", err, event)
+			logging.Chain(blockchain).Warn("Unable to decode origin address of event", logging.TxKey, event.TransactionHash, logging.ErrorKey, err)
 			continue
 		}
 
 		addressBytes, err := decodeAddress(event.Address)
 		if err != nil {
-			fmt.Println("Error decoding address:", err, event)
+			logging.Chain(blockchain).Warn("Unable to decode address of event", logging.TxKey, event.TransactionHash, logging.ErrorKey, err)
 			continue
 		}
 
@@ -1094,13 +1337,13 @@ func (p *PostgreSQLpgx) WriteEvents(tx pgx.Tx, blockchain string, events []Event
 
 	ctx := context.Background()
 
-	err := p.executeBatchInsert(tx, ctx, tableName, columns, valuesMap, "ON CONFLICT DO NOTHING")
+	err := p.executeLabelsInsert(tx, ctx, tableName, columns, valuesMap)
 
 	if err != nil {
 		return err
 	}
 
-	log.Printf("Saved %d events records into %s table", len(events), tableName)
+	logging.Chain(blockchain).Info("Saved events", logging.TableKey, tableName, "rows", len(events))
 
 	return nil
 }
@@ -1142,6 +1385,13 @@ func getAndBlockNumClause(lowestBlockNum uint64) string {
 	return ""
 }
 
+func getAndHighestBlockNumClause(highestBlockNum uint64) string {
+	if highestBlockNum > 0 {
+		return fmt.Sprintf("AND block_number <= %d ", highestBlockNum)
+	}
+	return ""
+}
+
 func getWhereBidiVolClause(isBidirectional bool) string {
 	if isBidirectional {
 		return fmt.Sprintf("WHERE from_address IN ($1, $2) AND to_address IN ($1, $2) ")
@@ -1149,7 +1399,11 @@ func getWhereBidiVolClause(isBidirectional bool) string {
 	return "WHERE from_address = $1 AND to_address = $2 "
 }
 
-func (p *PostgreSQLpgx) GetTransactionsVolume(blockchain, fromAddress, toAddress string, limit int, lowestBlockNum uint64, isBidirectional bool) (*TransactionsVolume, error) {
+func (p *PostgreSQLpgx) GetTransactionsVolume(ctx context.Context, blockchain, fromAddress, toAddress string, limit int, lowestBlockNum uint64, isBidirectional bool) (*TransactionsVolume, error) {
+	return p.getTransactionsVolumeInRange(ctx, blockchain, fromAddress, toAddress, limit, lowestBlockNum, 0, isBidirectional)
+}
+
+func (p *PostgreSQLpgx) getTransactionsVolumeInRange(ctx context.Context, blockchain, fromAddress, toAddress string, limit int, lowestBlockNum, highestBlockNum uint64, isBidirectional bool) (*TransactionsVolume, error) {
 	txTableName, txTableErr := TransactionsTableName(blockchain)
 	if txTableErr != nil {
 		return nil, txTableErr
@@ -1157,19 +1411,18 @@ func (p *PostgreSQLpgx) GetTransactionsVolume(blockchain, fromAddress, toAddress
 
 	fromAddressBytes, fDecErr := decodeAddress(fromAddress)
 	if fDecErr != nil {
-		log.Printf("Error decoding address %s, err: %v", fDecErr, fromAddress)
+		logging.Chain(blockchain).Warn("Unable to decode from address", logging.AddressKey, fromAddress, logging.ErrorKey, fDecErr)
 		return nil, fDecErr
 	}
 
 	toAddressBytes, tDecErr := decodeAddress(toAddress)
 	if tDecErr != nil {
-		log.Printf("Error decoding address %s, err: %v", tDecErr, toAddress)
+		logging.Chain(blockchain).Warn("Unable to decode to address", logging.AddressKey, toAddress, logging.ErrorKey, tDecErr)
 		return nil, tDecErr
 	}
 
-	pool := p.GetPool()
+	pool := p.GetReadPool()
 
-	ctx := context.Background()
 	conn, acquireErr := pool.Acquire(ctx)
 	if acquireErr != nil {
 		return nil, acquireErr
@@ -1187,12 +1440,13 @@ func (p *PostgreSQLpgx) GetTransactionsVolume(blockchain, fromAddress, toAddress
 			FROM %s
 			%s
 			%s
+			%s
 			ORDER BY block_number
 			LIMIT $3
 		) AS limited_transactions;
-	`, txTableName, getWhereBidiVolClause(isBidirectional), getAndBlockNumClause(lowestBlockNum))
+	`, txTableName, getWhereBidiVolClause(isBidirectional), getAndBlockNumClause(lowestBlockNum), getAndHighestBlockNumClause(highestBlockNum))
 
-	row := conn.QueryRow(context.Background(), query, fromAddressBytes, toAddressBytes, limit)
+	row := conn.QueryRow(ctx, query, fromAddressBytes, toAddressBytes, limit)
 
 	var minBlockNum, maxBlockNum sql.NullInt64
 	var volStr sql.NullString
@@ -1204,7 +1458,7 @@ func (p *PostgreSQLpgx) GetTransactionsVolume(blockchain, fromAddress, toAddress
 	}
 
 	if txsCount == 0 {
-		return nil, fmt.Errorf("not found")
+		return nil, ErrNoRowsIndexed
 	}
 
 	if !minBlockNum.Valid || !maxBlockNum.Valid || !volStr.Valid {
@@ -1228,7 +1482,7 @@ func (p *PostgreSQLpgx) GetTransactionsVolumeV2(blockchain, fromAddress, toAddre
 		return nil, txTableErr
 	}
 
-	pool := p.GetPool()
+	pool := p.GetReadPool()
 
 	ctx := context.Background()
 	conn, acquireErr := pool.Acquire(ctx)
@@ -1265,7 +1519,7 @@ func (p *PostgreSQLpgx) GetTransactionsVolumeV2(blockchain, fromAddress, toAddre
 	}
 
 	if txsCount == 0 {
-		return nil, fmt.Errorf("not found")
+		return nil, ErrNoRowsIndexed
 	}
 
 	if !minBlockNum.Valid || !maxBlockNum.Valid || !volStr.Valid {
@@ -1289,7 +1543,7 @@ func (p *PostgreSQLpgx) GetTransactionsVolumeBidirectionalV2(blockchain, fromAdd
 		return nil, txTableErr
 	}
 
-	pool := p.GetPool()
+	pool := p.GetReadPool()
 
 	ctx := context.Background()
 	conn, acquireErr := pool.Acquire(ctx)
@@ -1329,7 +1583,11 @@ func (p *PostgreSQLpgx) GetTransactionsVolumeBidirectionalV2(blockchain, fromAdd
 	return &txsVol, nil
 }
 
-func (p *PostgreSQLpgx) GetTransactions(blockchain string, sourceAddress []string, limit int, lowestBlockNum uint64, toAddrDistinct bool) ([]Transaction, error) {
+func (p *PostgreSQLpgx) GetTransactions(ctx context.Context, blockchain string, sourceAddress []string, limit int, lowestBlockNum uint64, toAddrDistinct bool) ([]Transaction, error) {
+	return p.getTransactionsInRange(ctx, blockchain, sourceAddress, limit, lowestBlockNum, 0, toAddrDistinct)
+}
+
+func (p *PostgreSQLpgx) getTransactionsInRange(ctx context.Context, blockchain string, sourceAddress []string, limit int, lowestBlockNum, highestBlockNum uint64, toAddrDistinct bool) ([]Transaction, error) {
 	txTableName, txTableErr := TransactionsTableName(blockchain)
 	if txTableErr != nil {
 		return nil, txTableErr
@@ -1339,15 +1597,14 @@ func (p *PostgreSQLpgx) GetTransactions(blockchain string, sourceAddress []strin
 	for _, address := range sourceAddress {
 		addressBytes, err := decodeAddress(address)
 		if err != nil {
-			log.Printf("Error decoding address %s, err: %v", err, address)
+			logging.Chain(blockchain).Warn("Unable to decode source address", logging.AddressKey, address, logging.ErrorKey, err)
 			continue
 		}
 		addressesBytes = append(addressesBytes, addressBytes)
 	}
 
-	pool := p.GetPool()
+	pool := p.GetReadPool()
 
-	ctx := context.Background()
 	conn, acquireErr := pool.Acquire(ctx)
 	if acquireErr != nil {
 		return nil, acquireErr
@@ -1363,10 +1620,11 @@ func (p *PostgreSQLpgx) GetTransactions(blockchain string, sourceAddress []strin
 		FROM %s 
 		WHERE from_address = ANY($1)
 		%s
+		%s
 		ORDER BY %s
-		LIMIT $2`, getSelectClause(toAddrDistinct), txTableName, getAndBlockNumClause(lowestBlockNum), getOrderClause(toAddrDistinct))
+		LIMIT $2`, getSelectClause(toAddrDistinct), txTableName, getAndBlockNumClause(lowestBlockNum), getAndHighestBlockNumClause(highestBlockNum), getOrderClause(toAddrDistinct))
 
-	rows, qErr := conn.Query(context.Background(), query, addressesBytes, limit)
+	rows, qErr := conn.Query(ctx, query, addressesBytes, limit)
 	if qErr != nil {
 		return nil, qErr
 	}
@@ -1378,7 +1636,7 @@ func (p *PostgreSQLpgx) GetTransactions(blockchain string, sourceAddress []strin
 
 		err = rows.Scan(&tx.BlockNumber, &tx.FromAddress, &tx.ToAddress, &valueStr)
 		if err != nil {
-			log.Printf("Unable to scan row, err: %v", err)
+			logging.Chain(blockchain).Error("Unable to scan transactions row", logging.ErrorKey, err)
 		}
 
 		tx.Value = new(big.Int)
@@ -1397,7 +1655,7 @@ func (p *PostgreSQLpgx) GetTransactionsV2(blockchain string, sourceAddress []str
 		return nil, txTableErr
 	}
 
-	pool := p.GetPool()
+	pool := p.GetReadPool()
 
 	ctx := context.Background()
 	conn, acquireErr := pool.Acquire(ctx)
@@ -1430,7 +1688,7 @@ func (p *PostgreSQLpgx) GetTransactionsV2(blockchain string, sourceAddress []str
 
 		err = rows.Scan(&tx.BlockNumber, &tx.FromAddress, &tx.ToAddress, &valueStr)
 		if err != nil {
-			log.Printf("Unable to scan row, err: %v", err)
+			logging.Chain(blockchain).Error("Unable to scan transactions row", logging.ErrorKey, err)
 		}
 
 		tx.Value = new(big.Int)
@@ -1511,23 +1769,23 @@ func (p *PostgreSQLpgx) WriteTransactions(tx pgx.Tx, blockchain string, transact
 
 	for _, transaction := range transactions {
 
-		id := uuid.New()
+		id := TransactionLabelID(blockchain, transaction)
 
 		addressBytes, err := decodeAddress(transaction.Address)
 		if err != nil {
-			fmt.Println("Error decoding address:", err, transaction)
+			logging.Chain(blockchain).Warn("Unable to decode address of transaction call", logging.TxKey, transaction.TransactionHash, logging.ErrorKey, err)
 			continue
 		}
 
 		callerAddressBytes, err := decodeAddress(transaction.CallerAddress)
 		if err != nil {
-			fmt.Println("Error decoding caller address:", err, transaction)
+			logging.Chain(blockchain).Warn("Unable to decode caller address of transaction call", logging.TxKey, transaction.TransactionHash, logging.ErrorKey, err)
 			continue
 		}
 
 		originAddressBytes, err := decodeAddress(transaction.OriginAddress)
 		if err != nil {
-			fmt.Println("Error decoding origin address:", err, transaction)
+			logging.Chain(blockchain).Warn("Unable to decode origin address of transaction call", logging.TxKey, transaction.TransactionHash, logging.ErrorKey, err)
 			continue
 		}
 
@@ -1548,13 +1806,13 @@ func (p *PostgreSQLpgx) WriteTransactions(tx pgx.Tx, blockchain string, transact
 
 	ctx := context.Background()
 
-	err := p.executeBatchInsert(tx, ctx, tableName, columns, valuesMap, "ON CONFLICT DO NOTHING")
+	err := p.executeLabelsInsert(tx, ctx, tableName, columns, valuesMap)
 
 	if err != nil {
 		return err
 	}
 
-	log.Printf("Saved %d tx_calls records into %s table", len(transactions), tableName)
+	logging.Chain(blockchain).Info("Saved transaction calls", logging.TableKey, tableName, "rows", len(transactions))
 
 	return nil
 }
@@ -1572,6 +1830,13 @@ func (p *PostgreSQLpgx) WriteRawTransactions(tx pgx.Tx, blockchain string, rawTr
 		columns = append(columns, "l1_block_number")
 	}
 
+	ctx := context.Background()
+
+	withBlobs := hasBlobFields(rawTransactions) && p.ensureBlobTransactionColumns(ctx, tableName)
+	if withBlobs {
+		columns = append(columns, "max_fee_per_blob_gas", "blob_versioned_hashes")
+	}
+
 	var valuesMap = make(map[string]UnnestInsertValueStruct)
 
 	valuesMap["hash"] = UnnestInsertValueStruct{
@@ -1661,6 +1926,19 @@ func (p *PostgreSQLpgx) WriteRawTransactions(tx pgx.Tx, blockchain string, rawTr
 		}
 	}
 
+	if withBlobs {
+		valuesMap["max_fee_per_blob_gas"] = UnnestInsertValueStruct{
+			Type:   "NUMERIC",
+			Values: make([]interface{}, 0),
+		}
+
+		// Unnest flattens multidimensional arrays, so hashes are passed as JSON
+		valuesMap["blob_versioned_hashes"] = UnnestInsertValueStruct{
+			Type:   "JSONB",
+			Values: make([]interface{}, 0),
+		}
+	}
+
 	// Now appending to the Values slice works without errors.
 	for _, rawTransaction := range rawTransactions {
 		fromAddress, err := decodeAddress(rawTransaction.FromAddress)
@@ -1675,30 +1953,30 @@ func (p *PostgreSQLpgx) WriteRawTransactions(tx pgx.Tx, blockchain string, rawTr
 
 		gas, err := hexStringToBigInt(rawTransaction.Gas)
 		if err != nil {
-			log.Printf("error parsing gas for transaction %s: %v", rawTransaction.Hash, err)
+			logging.Chain(blockchain).Warn("Unable to parse gas of transaction", logging.TxKey, rawTransaction.Hash, logging.ErrorKey, err)
 			return err
 		}
 		gasPrice, err := hexStringToBigInt(rawTransaction.GasPrice)
 		if err != nil {
-			log.Printf("error parsing gas price for transaction %s: %v", rawTransaction.Hash, err)
+			logging.Chain(blockchain).Warn("Unable to parse gas price of transaction", logging.TxKey, rawTransaction.Hash, logging.ErrorKey, err)
 			return err
 		}
 
 		maxFeePerGas, err := hexStringToBigInt(rawTransaction.MaxFeePerGas)
 		if err != nil {
-			log.Printf("error parsing max fee per gas for transaction %s: %v", rawTransaction.Hash, err)
+			logging.Chain(blockchain).Warn("Unable to parse max fee per gas of transaction", logging.TxKey, rawTransaction.Hash, logging.ErrorKey, err)
 			return err
 		}
 
 		maxPriorityFeePerGas, err := hexStringToBigInt(rawTransaction.MaxPriorityFeePerGas)
 		if err != nil {
-			log.Printf("error parsing max priority fee per gas for transaction %s: %v", rawTransaction.Hash, err)
+			logging.Chain(blockchain).Warn("Unable to parse max priority fee per gas of transaction", logging.TxKey, rawTransaction.Hash, logging.ErrorKey, err)
 			return err
 		}
 
 		value, err := hexStringToBigInt(rawTransaction.Value)
 		if err != nil {
-			log.Printf("error parsing value for transaction %s: %v", rawTransaction.Hash, err)
+			logging.Chain(blockchain).Warn("Unable to parse value of transaction", logging.TxKey, rawTransaction.Hash, logging.ErrorKey, err)
 			return err
 		}
 
@@ -1726,17 +2004,35 @@ func (p *PostgreSQLpgx) WriteRawTransactions(tx pgx.Tx, blockchain string, rawTr
 			}
 			updateValues(valuesMap, "l1_block_number", l1Bn)
 		}
+		if withBlobs {
+			var maxFeePerBlobGas, blobVersionedHashes interface{}
+			if rawTransaction.MaxFeePerBlobGas != "" {
+				maxFeePerBlobGas, err = hexStringToBigInt(rawTransaction.MaxFeePerBlobGas)
+				if err != nil {
+					logging.Chain(blockchain).Warn("Unable to parse max fee per blob gas of transaction", logging.TxKey, rawTransaction.Hash, logging.ErrorKey, err)
+					return err
+				}
+			}
+			if len(rawTransaction.BlobVersionedHashes) > 0 {
+				hashesJson, marshalErr := json.Marshal(rawTransaction.BlobVersionedHashes)
+				if marshalErr != nil {
+					return marshalErr
+				}
+				blobVersionedHashes = string(hashesJson)
+			}
+			updateValues(valuesMap, "max_fee_per_blob_gas", maxFeePerBlobGas)
+			updateValues(valuesMap, "blob_versioned_hashes", blobVersionedHashes)
+		}
 	}
 
-	ctx := context.Background()
-
 	// Insert them in batch
-	err := p.executeBatchInsert(tx, ctx, tableName, columns, valuesMap, "ON CONFLICT DO NOTHING")
+	conflictTarget := p.ConflictTargetFor(ctx, tableName, "", columns, []string{"hash"})
+	err := p.executeBatchInsert(tx, ctx, tableName, columns, valuesMap, conflictTarget.Clause())
 	if err != nil {
 		return err
 	}
 
-	log.Printf("Saved %d transactions records into %s table", len(rawTransactions), tableName)
+	logging.Chain(blockchain).Info("Saved raw transactions", logging.TableKey, tableName, "rows", len(rawTransactions))
 	return nil
 }
 
@@ -1771,7 +2067,7 @@ func (p *PostgreSQLpgx) CleanIndexes(blockchain string, batchLimit uint64, sleep
 
 	// delete indexes in batches
 
-	log.Printf("Starting deletion of transactions indexes in blocks range from %d to %d number", minBlockNumber, maxBlockNumber)
+	logging.Chain(blockchain).Info("Starting deletion of transactions indexes", logging.FromBlockKey, minBlockNumber, logging.ToBlockKey, maxBlockNumber)
 
 	for i := minBlockNumber; i <= maxBlockNumber; i += batchLimit {
 
@@ -1780,7 +2076,7 @@ func (p *PostgreSQLpgx) CleanIndexes(blockchain string, batchLimit uint64, sleep
 			return err
 		}
 
-		log.Println("Deleted", commandTag.RowsAffected(), "transactions indexes with corresponding logs")
+		logging.Chain(blockchain).Info("Deleted transactions indexes with corresponding logs", logging.FromBlockKey, i, logging.ToBlockKey, i+batchLimit-1, "rows", commandTag.RowsAffected())
 
 		// sleep for a while to avoid overloading the database
 		time.Sleep(time.Duration(sleepTime) * time.Second)
@@ -1820,6 +2116,25 @@ func (p *PostgreSQLpgx) UpdateAbiJobsStatus(blockchain string) error {
 	return nil
 }
 
+// IsCustomerOfUser returns true if user created any ABI job of customer.
+func (p *PostgreSQLpgx) IsCustomerOfUser(ctx context.Context, customerID, userID string) (bool, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Release()
+
+	var exists bool
+	err = conn.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM abi_jobs WHERE customer_id = $1 AND user_id = $2)", customerID, userID).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
 func (p *PostgreSQLpgx) SelectAbiJobs(blockchain string, addresses []string, customersIds []string, autoJobs, isDeployBlockNotNull bool, abiTypes []string) ([]AbiJob, error) {
 	pool := p.GetPool()
 
@@ -1836,7 +2151,8 @@ func (p *PostgreSQLpgx) SelectAbiJobs(blockchain string, addresses []string, cus
 	queryBuilder.WriteString(`
 		SELECT id, address, user_id, customer_id, abi_selector, chain, abi_name, status, 
 		       historical_crawl_status, progress, moonworm_task_pickedup, '[' || abi || ']' as abi, 
-		       (abi::jsonb)->>'type' AS abiType, created_at, updated_at, deployment_block_number
+		       (abi::jsonb)->>'type' AS abiType, created_at, updated_at, deployment_block_number,
+		       to_jsonb(abi_jobs) -> 'topic_filters' AS topic_filters
 		FROM abi_jobs
 		WHERE true
 	`)
@@ -1860,7 +2176,7 @@ func (p *PostgreSQLpgx) SelectAbiJobs(blockchain string, addresses []string, cus
 	}
 
 	if autoJobs {
-		queryBuilder.WriteString(" AND historical_crawl_status != 'done' ")
+		queryBuilder.WriteString(" AND historical_crawl_status != 'done' AND status IS DISTINCT FROM 'inactive' ")
 	}
 
 	if len(addresses) > 0 {
@@ -1886,13 +2202,13 @@ func (p *PostgreSQLpgx) SelectAbiJobs(blockchain string, addresses []string, cus
 
 	rows, err := conn.Query(context.Background(), queryBuilder.String(), queryArgs)
 	if err != nil {
-		log.Println("Error querying ABI jobs from database", err)
+		logging.Chain(blockchain).Error("Failed to query ABI jobs", logging.ErrorKey, err)
 		return nil, err
 	}
 
 	abiJobs, err := pgx.CollectRows(rows, pgx.RowToStructByName[AbiJob])
 	if err != nil {
-		log.Println("Error collecting ABI jobs rows", err)
+		logging.Chain(blockchain).Error("Failed to collect ABI jobs rows", logging.ErrorKey, err)
 		return nil, err
 	}
 
@@ -1949,7 +2265,7 @@ func (p *PostgreSQLpgx) CopyAbiJobs(sourceCustomerId, destCustomerId string, abi
 		jobID := uuid.New()
 
 		if len(abiJob.Abi) <= 2 || abiJob.Abi[0] != '[' || abiJob.Abi[len(abiJob.Abi)-1] != ']' {
-			log.Printf("Passed ABI job, incorrect format: %s", abiJob.Abi)
+			slog.Warn("Skipped ABI job with incorrect ABI format", logging.JobKey, abiJob.ID, logging.CustomerKey, sourceCustomerId)
 			continue
 		}
 		abi := abiJob.Abi[1 : len(abiJob.Abi)-1]
@@ -1966,7 +2282,7 @@ func (p *PostgreSQLpgx) CopyAbiJobs(sourceCustomerId, destCustomerId string, abi
 		return commitErr
 	}
 
-	log.Printf("Copied %d ABI jobs from customer %s to %s.", len(abiJobs), sourceCustomerId, destCustomerId)
+	slog.Info("Copied ABI jobs", "jobs", len(abiJobs), "source_customer_id", sourceCustomerId, logging.CustomerKey, destCustomerId)
 
 	return nil
 }
@@ -1980,7 +2296,7 @@ func ConvertToCustomerUpdatedAndDeployBlockDicts(abiJobs []AbiJob) ([]CustomerUp
 	addressDeployBlockDict := make(map[string]AbiJobsDeployInfo)
 
 	for _, abiJob := range abiJobs {
-		address := fmt.Sprintf("0x%x", abiJob.Address)
+		address := AbiJobAddress(abiJob.Address)
 
 		if _, exists := customerUpdatesDict[abiJob.CustomerID]; !exists {
 			customerUpdatesDict[abiJob.CustomerID] = CustomerUpdates{
@@ -1994,9 +2310,10 @@ func ConvertToCustomerUpdatedAndDeployBlockDicts(abiJobs []AbiJob) ([]CustomerUp
 		}
 
 		customerUpdatesDict[abiJob.CustomerID].Abis[address][abiJob.AbiSelector] = &AbiEntry{
-			AbiJSON: abiJob.Abi,
-			AbiName: abiJob.AbiName,
-			AbiType: abiJob.AbiType,
+			AbiJSON:      abiJob.Abi,
+			AbiName:      abiJob.AbiName,
+			AbiType:      abiJob.AbiType,
+			TopicFilters: abiJob.TopicFilters,
 		}
 
 		if abiJob.DeploymentBlockNumber == nil {
@@ -2100,6 +2417,78 @@ func (p *PostgreSQLpgx) FindBatchPath(blockchain string, blockNumber uint64) (st
 
 }
 
+// ReadIndexedBatches returns storage batches indexed starting from specified block, batch
+// marked as incomplete if transactions or logs indexing markers are not set for any of its blocks.
+func (p *PostgreSQLpgx) ReadIndexedBatches(blockchain string, fromBlock uint64) ([]IndexedBatch, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	blocksTableName, blocksTableErr := BlocksTableName(blockchain)
+	if blocksTableErr != nil {
+		return nil, blocksTableErr
+	}
+
+	query := fmt.Sprintf(`SELECT
+		path,
+		min(block_number) as min_block_number,
+		max(block_number) as max_block_number,
+		count(*) as blocks_count,
+		bool_or(transactions_indexed_at IS NULL OR logs_indexed_at IS NULL) as incomplete
+	FROM %s
+	WHERE block_number >= $1
+	GROUP BY path
+	ORDER BY min_block_number`, blocksTableName)
+
+	rows, err := conn.Query(context.Background(), query, fromBlock)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var batches []IndexedBatch
+	for rows.Next() {
+		var batch IndexedBatch
+		if err := rows.Scan(&batch.Path, &batch.MinBlockNumber, &batch.MaxBlockNumber, &batch.BlocksCount, &batch.Incomplete); err != nil {
+			return nil, err
+		}
+		batches = append(batches, batch)
+	}
+
+	return batches, rows.Err()
+}
+
+// DeleteBlockIndexRange removes block index rows in range, used before re-indexing of broken batches.
+func (p *PostgreSQLpgx) DeleteBlockIndexRange(blockchain string, fromBlock, toBlock uint64) (int64, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return 0, err
+	}
+
+	defer conn.Release()
+
+	blocksTableName, blocksTableErr := BlocksTableName(blockchain)
+	if blocksTableErr != nil {
+		return 0, blocksTableErr
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE block_number >= $1 AND block_number <= $2", blocksTableName)
+
+	commandTag, err := conn.Exec(context.Background(), query, fromBlock, toBlock)
+	if err != nil {
+		return 0, err
+	}
+
+	return commandTag.RowsAffected(), nil
+}
+
 func (p *PostgreSQLpgx) RetrievePathsAndBlockBounds(blockchain string, blockNumber uint64, minBlocksToSync int) ([]string, uint64, uint64, error) {
 	pool := p.GetPool()
 
@@ -2190,6 +2579,7 @@ func (p *PostgreSQLpgx) GetAbiJobsWithoutDeployBlocks(blockchain string) (map[st
 		abi_jobs
 	WHERE
 		deployment_block_number is null
+		and address is not null
 		and chain = $1
 		and (
 			(abi :: jsonb) ->> 'type' = 'event'
@@ -2199,7 +2589,7 @@ func (p *PostgreSQLpgx) GetAbiJobsWithoutDeployBlocks(blockchain string) (map[st
 			)
 		)`, blockchain)
 	if err != nil {
-		log.Println("Error querying abi jobs from database", err)
+		logging.Chain(blockchain).Error("Failed to query ABI jobs", logging.ErrorKey, err)
 		return nil, err
 	}
 
@@ -2235,10 +2625,10 @@ func (p *PostgreSQLpgx) GetAbiJobsWithoutDeployBlocks(blockchain string) (map[st
 
 		for address := range addressIds {
 
-			err := p.EnsureCorrectSelectors(chain, true, "", addressIds[address])
+			_, err := p.EnsureCorrectSelectors(chain, EnsureSelectorsOptions{WriteToDB: true, IDs: addressIds[address]})
 			if err != nil {
 
-				log.Println("Error ensuring correct selectors for chain:", chain, err)
+				logging.Chain(chain).Error("Failed to ensure correct selectors", logging.AddressKey, address, logging.ErrorKey, err)
 				return nil, err
 			}
 		}
@@ -2308,35 +2698,39 @@ func (p *PostgreSQLpgx) UpdateAbiJobsDeployBlock(blockNumber uint64, ids []strin
 
 }
 
-func (p *PostgreSQLpgx) CreateJobsFromAbi(chain string, address string, abiFile string, customerID string, userID string, deployBlock uint64) error {
-	pool := p.GetPool()
-
-	conn, err := pool.Acquire(context.Background())
-	if err != nil {
-		return err
-	}
-	defer conn.Release()
+// abiFileJob is a single event or function of ABI file with its selector.
+type abiFileJob struct {
+	Name     string
+	Type     string
+	Selector string
+	Abi      []byte
+}
 
+// readAbiFileJobs parses ABI file into jobs, entries of unsupported types are skipped as
+// well as anonymous events if allowAnonymous is not set.
+func readAbiFileJobs(abiFile string, allowAnonymous bool) ([]abiFileJob, error) {
 	abiData, err := ioutil.ReadFile(abiFile)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	return parseAbiJobs(abiData, allowAnonymous)
+}
+
+// parseAbiJobs parses ABI JSON into jobs in the same way as readAbiFileJobs.
+func parseAbiJobs(abiData []byte, allowAnonymous bool) ([]abiFileJob, error) {
 	var abiJson []map[string]interface{}
-	err = json.Unmarshal(abiData, &abiJson)
+	err := json.Unmarshal(abiData, &abiJson)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	var jobs []abiFileJob
 	for _, abiJob := range abiJson {
-
-		// Generate a new UUID for the id column
-		jobID := uuid.New()
-
 		abiJobJson, err := json.Marshal(abiJob)
 		if err != nil {
-			log.Println("Error marshalling ABI job to JSON:", abiJob, err)
-			return err
+			slog.Error("Unable to marshal ABI job to JSON", logging.ErrorKey, err)
+			return nil, err
 		}
 
 		// Wrap the JSON string in an array
@@ -2345,43 +2739,221 @@ func (p *PostgreSQLpgx) CreateJobsFromAbi(chain string, address string, abiFile
 		// Get the correct selector for the ABI
 		abiObj, err := abi.JSON(strings.NewReader(abiJsonArray))
 		if err != nil {
-			log.Println("Error parsing ABI for ABI job:", abiJsonArray, err)
-			return err
+			slog.Error("Unable to parse ABI of ABI job", "abi", abiJsonArray, logging.ErrorKey, err)
+			return nil, err
 		}
 		var selector string
 
 		if abiJob["type"] == "event" {
-			selector = abiObj.Events[abiJob["name"].(string)].ID.String()
+			event := abiObj.Events[abiJob["name"].(string)]
+			selector = event.ID.String()
+			if event.Anonymous {
+				if !allowAnonymous {
+					slog.Info("Anonymous event skipped, it requires explicit opt-in", "event", event.Name)
+					continue
+				}
+				selector = AnonymousEventSelector(selector)
+			}
 		} else if abiJob["type"] == "function" {
 			selectorRaw := abiObj.Methods[abiJob["name"].(string)].ID
 			selector = fmt.Sprintf("0x%x", selectorRaw)
 		} else {
-			log.Println("ABI type not supported:", abiJob["type"])
+			slog.Warn("ABI type not supported", "type", abiJob["type"])
 			continue
 		}
 
-		addressBytes, err := decodeAddress(address)
+		jobs = append(jobs, abiFileJob{
+			Name:     abiJob["name"].(string),
+			Type:     abiJob["type"].(string),
+			Selector: selector,
+			Abi:      abiJobJson,
+		})
+	}
 
-		if err != nil {
-			log.Println("Error decoding address:", err, address)
+	return jobs, nil
+}
+
+// AbiEntriesFromFile parses ABI file into entries keyed by selector, in the same form as
+// synchronizer gets them from jobs, so labels could be decoded without jobs in database.
+func AbiEntriesFromFile(abiFile string, allowAnonymous bool) (map[string]*AbiEntry, error) {
+	abiJobs, err := readAbiFileJobs(abiFile, allowAnonymous)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]*AbiEntry, len(abiJobs))
+	for _, abiJob := range abiJobs {
+		entries[abiJob.Selector] = &AbiEntry{
+			AbiJSON: "[" + string(abiJob.Abi) + "]",
+			AbiName: abiJob.Name,
+			AbiType: abiJob.Type,
+		}
+	}
+
+	return entries, nil
+}
+
+// AbiJobsCreateReport summarizes CreateJobsFromAbi. Jobs are matched with existing ones by
+// chain, address, selector and customer: new selectors are Created, existing jobs with the same
+// ABI are Unchanged, jobs with different ABI are Updated if it was requested or Skipped.
+type AbiJobsCreateReport struct {
+	Created   []AbiJobsReconcileEntry `json:"created"`
+	Updated   []AbiJobsReconcileEntry `json:"updated"`
+	Unchanged []AbiJobsReconcileEntry `json:"unchanged"`
+	Skipped   []AbiJobsReconcileEntry `json:"skipped"`
+}
+
+func (p *PostgreSQLpgx) CreateJobsFromAbi(chain string, address string, abiFile string, customerID string, userID string, deployBlock uint64, allowAnonymous, updateExisting bool) (*AbiJobsCreateReport, error) {
+	abiJobs, err := readAbiFileJobs(abiFile, allowAnonymous)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.createAbiJobs(chain, address, abiJobs, customerID, userID, deployBlock, updateExisting)
+}
+
+// CreateJobsFromAbiData creates jobs for events and functions of ABI JSON, it is used for
+// ABIs which are not stored in files, e.g. ABIs of detected standards.
+func (p *PostgreSQLpgx) CreateJobsFromAbiData(chain string, address string, abiData []byte, customerID string, userID string, deployBlock uint64, allowAnonymous, updateExisting bool) (*AbiJobsCreateReport, error) {
+	abiJobs, err := parseAbiJobs(abiData, allowAnonymous)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.createAbiJobs(chain, address, abiJobs, customerID, userID, deployBlock, updateExisting)
+}
+
+// lockAbiJobsOfAddress serializes changes of jobs of address and customer until the end of
+// transaction. Row locks are not enough, as concurrent transactions would not see jobs
+// inserted by each other and create duplicates.
+func lockAbiJobsOfAddress(tx pgx.Tx, chain string, addressBytes []byte, customerID string) error {
+	_, err := tx.Exec(context.Background(), "SELECT pg_advisory_xact_lock(hashtext($1))", fmt.Sprintf("abi_jobs:%s:%x:%s", chain, addressBytes, customerID))
+	return err
+}
+
+// sameAbi compares ABI entries ignoring formatting and order of keys.
+func sameAbi(a, b []byte) bool {
+	var aValue, bValue interface{}
+	if json.Unmarshal(a, &aValue) != nil || json.Unmarshal(b, &bValue) != nil {
+		return string(a) == string(b)
+	}
+	return reflect.DeepEqual(aValue, bValue)
+}
+
+// createAbiJobs creates jobs of address in one transaction, so either all of them are
+// registered or none.
+func (p *PostgreSQLpgx) createAbiJobs(chain string, address string, abiJobs []abiFileJob, customerID string, userID string, deployBlock uint64, updateExisting bool) (*AbiJobsCreateReport, error) {
+	addressBytes, err := decodeJobAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(context.Background())
+
+	if err := lockAbiJobsOfAddress(tx, chain, addressBytes, customerID); err != nil {
+		return nil, err
+	}
+
+	type existingJob struct {
+		id  string
+		abi []byte
+	}
+
+	rows, err := tx.Query(context.Background(), "SELECT id, abi_selector, abi FROM abi_jobs WHERE chain = @chain AND address IS NOT DISTINCT FROM @address AND customer_id = @customer_id ORDER BY created_at", pgx.NamedArgs{
+		"chain":       chain,
+		"address":     addressBytes,
+		"customer_id": customerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string][]existingJob)
+	for rows.Next() {
+		var job existingJob
+		var selector, abiText string
+		if err := rows.Scan(&job.id, &selector, &abiText); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		job.abi = []byte(abiText)
+		existing[selector] = append(existing[selector], job)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	report := &AbiJobsCreateReport{}
+	seen := make(map[string]bool)
+	for _, abiJob := range abiJobs {
+		if seen[abiJob.Selector] {
 			continue
 		}
+		seen[abiJob.Selector] = true
 
-		_, err = conn.Exec(context.Background(), "INSERT INTO abi_jobs (id, address, user_id, customer_id, abi_selector, chain, abi_name, status, historical_crawl_status, progress, moonworm_task_pickedup, abi, deployment_block_number, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, now(), now()) ON CONFLICT DO NOTHING", jobID, addressBytes, userID, customerID, selector, chain, abiJob["name"], "true", "pending", 0, false, abiJobJson, deployBlock)
+		entry := AbiJobsReconcileEntry{Selector: abiJob.Selector, Name: abiJob.Name, Type: abiJob.Type}
+		jobs := existing[abiJob.Selector]
 
-		if err != nil {
-			return err
+		if len(jobs) == 0 {
+			jobID := uuid.New()
+			_, err := tx.Exec(context.Background(), "INSERT INTO abi_jobs (id, address, user_id, customer_id, abi_selector, chain, abi_name, status, historical_crawl_status, progress, moonworm_task_pickedup, abi, deployment_block_number, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, now(), now())", jobID, addressBytes, userID, customerID, abiJob.Selector, chain, abiJob.Name, "true", "pending", 0, false, abiJob.Abi, jobDeployBlock(addressBytes, deployBlock))
+			if err != nil {
+				return nil, fmt.Errorf("failed to create job for %s %s: %w", abiJob.Name, abiJob.Selector, err)
+			}
+			entry.JobIDs = []string{jobID.String()}
+			report.Created = append(report.Created, entry)
+			continue
+		}
+
+		var changedIDs []string
+		for _, job := range jobs {
+			entry.JobIDs = append(entry.JobIDs, job.id)
+			if !sameAbi(job.abi, abiJob.Abi) {
+				changedIDs = append(changedIDs, job.id)
+			}
 		}
 
+		switch {
+		case len(changedIDs) == 0:
+			report.Unchanged = append(report.Unchanged, entry)
+		case updateExisting:
+			_, err := tx.Exec(context.Background(), "UPDATE abi_jobs SET abi = @abi, abi_name = @abi_name, updated_at = now() WHERE id = ANY(@ids::uuid[])", pgx.NamedArgs{
+				"abi":      string(abiJob.Abi),
+				"abi_name": abiJob.Name,
+				"ids":      changedIDs,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to update jobs of %s %s: %w", abiJob.Name, abiJob.Selector, err)
+			}
+			report.Updated = append(report.Updated, entry)
+		default:
+			report.Skipped = append(report.Skipped, entry)
+		}
 	}
 
-	return nil
+	if err := tx.Commit(context.Background()); err != nil {
+		return nil, err
+	}
 
+	return report, nil
 }
 
 func (p *PostgreSQLpgx) DeleteJobs(jobIds []string) error {
 	if len(jobIds) == 0 {
-		log.Println("Nothing to delete")
+		slog.Info("Nothing to delete")
 		return nil
 	}
 
@@ -2401,11 +2973,11 @@ func (p *PostgreSQLpgx) DeleteJobs(jobIds []string) error {
 
 	_, delErr := conn.Query(context.Background(), queryBuilder.String(), queryArgs)
 	if delErr != nil {
-		log.Printf("Error querying ABI jobs from database, err %v", delErr)
+		slog.Error("Failed to delete ABI jobs", logging.ErrorKey, delErr)
 		return delErr
 	}
 
-	log.Printf("Deleted %d jobs", len(jobIds))
+	slog.Info("Deleted ABI jobs", "jobs", len(jobIds))
 
 	return nil
 }
@@ -0,0 +1,42 @@
+package indexer
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrUnsupportedChain is returned when blockchain has no tables in index database.
+	ErrUnsupportedChain = errors.New("unsupported blockchain")
+
+	// ErrNoRowsIndexed is returned when query has nothing to return because requested range
+	// or address is not indexed yet.
+	ErrNoRowsIndexed = errors.New("no rows indexed")
+
+	// ErrInvalidFilter is returned when search conditions are inconsistent or incomplete.
+	ErrInvalidFilter = errors.New("invalid filter")
+
+	// ErrDecodeAddress is matched by DecodeAddressError.
+	ErrDecodeAddress = errors.New("unable to decode address")
+)
+
+type DecodeAddressError struct {
+	Address string
+	Err     error
+}
+
+func (e *DecodeAddressError) Error() string {
+	return fmt.Sprintf("unable to decode address %s: %v", e.Address, e.Err)
+}
+
+func (e *DecodeAddressError) Unwrap() error {
+	return e.Err
+}
+
+func (e *DecodeAddressError) Is(target error) bool {
+	return target == ErrDecodeAddress
+}
+
+func unsupportedChainError(blockchain string) error {
+	return fmt.Errorf("%w: %s", ErrUnsupportedChain, blockchain)
+}
@@ -0,0 +1,121 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const MaxTransactionGraphDepth = 5
+
+type TransactionGraphNode struct {
+	Address  string `json:"address"`
+	Depth    int    `json:"depth"`
+	SubNodes uint64 `json:"sub_nodes"`
+}
+
+type TransactionGraphEdge struct {
+	FromAddress string   `json:"from_address"`
+	ToAddress   string   `json:"to_address"`
+	BlockNumber uint64   `json:"block_number"`
+	Value       *big.Int `json:"value"`
+}
+
+type TransactionGraph struct {
+	Seeds             []string               `json:"seeds"`
+	Depth             int                    `json:"depth"`
+	LowestBlockNumber uint64                 `json:"lowest_block_number"`
+	Nodes             []TransactionGraphNode `json:"nodes"`
+	Edges             []TransactionGraphEdge `json:"edges"`
+}
+
+// GetTransactionGraph expands transactions graph breadth-first starting from seeds addresses.
+// Each level is fetched with one query limited by limit (first transaction for each unique
+// to_address) and only transactions not older than the lowest block of previous level are
+// taken into account, so graph follows funds flow. Already visited addresses are not expanded
+// again, which breaks cycles.
+func (p *PostgreSQLpgx) GetTransactionGraph(ctx context.Context, blockchain string, seeds []string, depth, limit int, lowestBlockNum uint64) (*TransactionGraph, error) {
+	if len(seeds) == 0 {
+		return nil, fmt.Errorf("at least one seed address is required")
+	}
+	if depth < 1 || depth > MaxTransactionGraphDepth {
+		return nil, fmt.Errorf("depth should be between 1 and %d", MaxTransactionGraphDepth)
+	}
+
+	graph := TransactionGraph{
+		Depth:             depth,
+		LowestBlockNumber: lowestBlockNum,
+		Nodes:             []TransactionGraphNode{},
+		Edges:             []TransactionGraphEdge{},
+	}
+
+	nodesIndex := make(map[string]int)
+	edgesIndex := make(map[string]bool)
+
+	addNode := func(address string, nodeDepth int) bool {
+		if _, exists := nodesIndex[address]; exists {
+			return false
+		}
+		nodesIndex[address] = len(graph.Nodes)
+		graph.Nodes = append(graph.Nodes, TransactionGraphNode{Address: address, Depth: nodeDepth})
+		return true
+	}
+
+	var level []string
+	for _, seed := range seeds {
+		seed = strings.ToLower(seed)
+		if addNode(seed, 0) {
+			graph.Seeds = append(graph.Seeds, seed)
+			level = append(level, seed)
+		}
+	}
+
+	levelLowestBlockNum := lowestBlockNum
+	for currentDepth := 1; currentDepth <= depth && len(level) > 0; currentDepth++ {
+		txs, txsErr := p.GetTransactions(ctx, blockchain, level, limit, levelLowestBlockNum, true)
+		if txsErr != nil {
+			return nil, txsErr
+		}
+		if len(txs) == 0 {
+			break
+		}
+
+		var nextLevel []string
+		nextLowestBlockNum := txs[0].BlockNumber
+		for _, tx := range txs {
+			if tx.BlockNumber < nextLowestBlockNum {
+				nextLowestBlockNum = tx.BlockNumber
+			}
+
+			if fromIndex, exists := nodesIndex[tx.FromAddress]; exists {
+				graph.Nodes[fromIndex].SubNodes++
+			}
+
+			if addNode(tx.ToAddress, currentDepth) {
+				nextLevel = append(nextLevel, tx.ToAddress)
+			}
+
+			// Bidirectional transfers are represented with one edge
+			if edgesIndex[fmt.Sprintf("%s-%s", tx.FromAddress, tx.ToAddress)] || edgesIndex[fmt.Sprintf("%s-%s", tx.ToAddress, tx.FromAddress)] {
+				continue
+			}
+			edgesIndex[fmt.Sprintf("%s-%s", tx.FromAddress, tx.ToAddress)] = true
+			graph.Edges = append(graph.Edges, TransactionGraphEdge{
+				FromAddress: tx.FromAddress,
+				ToAddress:   tx.ToAddress,
+				BlockNumber: tx.BlockNumber,
+				Value:       tx.Value,
+			})
+		}
+
+		if currentDepth == 1 {
+			graph.LowestBlockNumber = nextLowestBlockNum
+		}
+
+		level = nextLevel
+		levelLowestBlockNum = nextLowestBlockNum
+	}
+
+	return &graph, nil
+}
@@ -0,0 +1,307 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Labels are pre-aggregated into rollup table by triggers of labels table, so counts are
+// maintained by every writer (synchronizer, historical sync, prune) in the same transaction
+// as labels themselves. Rollup rows are counts per label name, address, day and bucket of
+// LabelCountsBucketSize blocks. Requested block range is served from rollup for whole
+// buckets and from labels table only for partial buckets at its edges.
+const LabelCountsBucketSize uint64 = 10000
+
+// Groupings supported by GetLabelCounts.
+const (
+	LabelCountsByLabelName = "label_name"
+	LabelCountsByAddress   = "address"
+	LabelCountsByDay       = "day"
+)
+
+// LabelCount is a number of labels in group, Key is label name, 0x address or date in
+// YYYY-MM-DD format depending on grouping.
+type LabelCount struct {
+	Key   string `json:"key"`
+	Count uint64 `json:"count"`
+}
+
+// LabelCountsFilter describes selection of labels to count, zero ToBlock means no upper bound.
+type LabelCountsFilter struct {
+	Label     string
+	LabelType string
+	FromBlock uint64
+	ToBlock   uint64
+	Limit     int
+}
+
+func LabelCountsTableName(blockchain string) string {
+	return fmt.Sprintf("%s_counts", LabelsTableName(blockchain))
+}
+
+// labelCountsKeyExpr returns expressions of group key for rollup and labels tables.
+func labelCountsKeyExpr(groupBy string) (string, string, error) {
+	switch groupBy {
+	case LabelCountsByLabelName:
+		return "label_name", "coalesce(label_name, '')", nil
+	case LabelCountsByAddress:
+		return "'0x' || encode(address, 'hex')", "'0x' || encode(coalesce(address, '\\x'::bytea), 'hex')", nil
+	case LabelCountsByDay:
+		return "to_char(day, 'YYYY-MM-DD')", fmt.Sprintf("to_char(%s, 'YYYY-MM-DD')", labelDayExpr()), nil
+	default:
+		return "", "", fmt.Errorf("%w: unsupported grouping %q, expected one of %s, %s, %s", ErrInvalidFilter, groupBy, LabelCountsByLabelName, LabelCountsByAddress, LabelCountsByDay)
+	}
+}
+
+// labelDayExpr returns UTC date of label, labels without timestamp are counted at epoch.
+func labelDayExpr() string {
+	return "coalesce((to_timestamp(block_timestamp) AT TIME ZONE 'UTC')::date, DATE '1970-01-01')"
+}
+
+// LabelCountsRollup generates statements which create rollup table of labels table with
+// triggers maintaining it and fill it from existing labels. Labels table is locked against
+// writes until statements are committed, so no label is missed or counted twice. For shared
+// databases rollup is partitioned by customer_id, so migration to shared database should be
+// applied before rollup is built.
+func LabelCountsRollup(blockchain string, sharedDatabase bool) []string {
+	labelsTable := LabelsTableName(blockchain)
+	countsTable := LabelCountsTableName(blockchain)
+	functionName := countsTable + "_maintain"
+
+	customerExpr := "''"
+	if sharedDatabase {
+		customerExpr = "coalesce(customer_id, '')"
+	}
+
+	aggregate := func(source, sign string) string {
+		return fmt.Sprintf(`INSERT INTO %[1]s (customer_id, label, label_type, label_name, address, day, block_bucket, count)
+			SELECT %[2]s, coalesce(label, ''), coalesce(label_type, ''), coalesce(label_name, ''), coalesce(address, '\x'::bytea), %[3]s, block_number / %[4]d, %[5]scount(*)
+			FROM %[6]s
+			GROUP BY 1, 2, 3, 4, 5, 6, 7
+			ON CONFLICT (customer_id, label, label_type, label_name, address, day, block_bucket) DO UPDATE SET count = %[1]s.count + EXCLUDED.count`,
+			countsTable, customerExpr, labelDayExpr(), LabelCountsBucketSize, sign, source)
+	}
+
+	statements := []string{
+		fmt.Sprintf("LOCK TABLE %s IN SHARE ROW EXCLUSIVE MODE", labelsTable),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			customer_id VARCHAR NOT NULL,
+			label VARCHAR NOT NULL,
+			label_type VARCHAR NOT NULL,
+			label_name VARCHAR NOT NULL,
+			address BYTEA NOT NULL,
+			day DATE NOT NULL,
+			block_bucket BIGINT NOT NULL,
+			count BIGINT NOT NULL,
+			PRIMARY KEY (customer_id, label, label_type, label_name, address, day, block_bucket)
+		)`, countsTable),
+		fmt.Sprintf(`CREATE OR REPLACE FUNCTION %s() RETURNS trigger LANGUAGE plpgsql AS $$
+		BEGIN
+			IF TG_OP IN ('DELETE', 'UPDATE') THEN
+				%s;
+			END IF;
+			IF TG_OP IN ('INSERT', 'UPDATE') THEN
+				%s;
+			END IF;
+			RETURN NULL;
+		END $$`, functionName, aggregate("old_rows", "-"), aggregate("new_rows", "")),
+	}
+
+	// Transition tables could be declared only for triggers of single event
+	for _, event := range []struct{ name, referencing string }{
+		{"insert", "NEW TABLE AS new_rows"},
+		{"update", "OLD TABLE AS old_rows NEW TABLE AS new_rows"},
+		{"delete", "OLD TABLE AS old_rows"},
+	} {
+		triggerName := fmt.Sprintf("%s_%s", countsTable, event.name)
+		statements = append(statements,
+			fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON %s", triggerName, labelsTable),
+			fmt.Sprintf("CREATE TRIGGER %s AFTER %s ON %s REFERENCING %s FOR EACH STATEMENT EXECUTE FUNCTION %s()", triggerName, strings.ToUpper(event.name), labelsTable, event.referencing, functionName),
+		)
+	}
+
+	return append(statements,
+		fmt.Sprintf("TRUNCATE %s", countsTable),
+		aggregate(labelsTable, ""),
+	)
+}
+
+// hasCustomerColumn returns true if labels table of blockchain belongs to shared database.
+func hasCustomerColumn(ctx context.Context, conn interface {
+	QueryRow(context.Context, string, ...any) pgx.Row
+}, tableName string) (bool, error) {
+	var exists bool
+	err := conn.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = $1 AND column_name = 'customer_id')", tableName).Scan(&exists)
+	return exists, err
+}
+
+// BuildLabelCountsRollup creates or rebuilds rollups of labels tables of blockchains. It
+// returns executed statements.
+func (p *PostgreSQLpgx) BuildLabelCountsRollup(blockchains []string, dryRun bool) ([]string, error) {
+	ctx := context.Background()
+
+	var statements []string
+	for _, blockchain := range blockchains {
+		tables, err := p.existingTables(ctx, []string{LabelsTableName(blockchain)})
+		if err != nil {
+			return nil, err
+		}
+		if len(tables) == 0 {
+			continue
+		}
+
+		sharedDatabase, err := hasCustomerColumn(ctx, p.GetPool(), tables[0])
+		if err != nil {
+			return nil, err
+		}
+
+		statements = append(statements, LabelCountsRollup(blockchain, sharedDatabase)...)
+	}
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("no labels tables of blockchains %s in database", strings.Join(blockchains, ", "))
+	}
+
+	return p.execStatements(ctx, statements, dryRun)
+}
+
+// GetLabelCounts returns number of labels grouped by label name, address or day, ordered by
+// count or by day for daily grouping. If rollup table is not built yet labels are counted
+// directly in labels table. customerID is applied only in shared databases.
+func (p *PostgreSQLpgx) GetLabelCounts(ctx context.Context, blockchain, customerID, groupBy string, filter LabelCountsFilter) ([]LabelCount, error) {
+	if _, err := BlocksTableName(blockchain); err != nil {
+		return nil, err
+	}
+
+	rollupKey, labelsKey, err := labelCountsKeyExpr(groupBy)
+	if err != nil {
+		return nil, err
+	}
+	if filter.ToBlock != 0 && filter.ToBlock < filter.FromBlock {
+		return nil, fmt.Errorf("%w: to block %d is lower than from block %d", ErrInvalidFilter, filter.ToBlock, filter.FromBlock)
+	}
+
+	label := filter.Label
+	if label == "" {
+		label = SeerCrawlerLabel
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultLabelsPageLimit
+	}
+	if limit > MaxLabelsPageLimit {
+		limit = MaxLabelsPageLimit
+	}
+
+	pool := p.GetReadPool()
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	labelsTable := LabelsTableName(blockchain)
+	countsTable := LabelCountsTableName(blockchain)
+
+	var rollupExists bool
+	if err := conn.QueryRow(ctx, "SELECT to_regclass($1) IS NOT NULL", countsTable).Scan(&rollupExists); err != nil {
+		return nil, err
+	}
+	sharedDatabase, err := hasCustomerColumn(ctx, conn, labelsTable)
+	if err != nil {
+		return nil, err
+	}
+
+	args := pgx.NamedArgs{"label": label, "limit": limit}
+	conditions := []string{"label = @label"}
+	if filter.LabelType != "" {
+		conditions = append(conditions, "label_type = @label_type")
+		args["label_type"] = filter.LabelType
+	}
+	if sharedDatabase {
+		conditions = append(conditions, "customer_id = @customer_id")
+		args["customer_id"] = customerID
+	}
+
+	// Block ranges counted in labels table, whole range if there is no rollup
+	var labelsRanges []string
+	addLabelsRange := func(name string, fromBlock, toBlock uint64, bounded bool) {
+		if bounded && toBlock < fromBlock {
+			return
+		}
+		rangeCondition := fmt.Sprintf("block_number >= @%s_from", name)
+		args[name+"_from"] = fromBlock
+		if bounded {
+			rangeCondition += fmt.Sprintf(" AND block_number <= @%s_to", name)
+			args[name+"_to"] = toBlock
+		}
+		labelsRanges = append(labelsRanges, "("+rangeCondition+")")
+	}
+
+	var parts []string
+	bounded := filter.ToBlock != 0
+
+	// Buckets which lay completely within range
+	firstBucket := (filter.FromBlock + LabelCountsBucketSize - 1) / LabelCountsBucketSize
+	lastBucket := uint64(0)
+	if bounded {
+		lastBucket = (filter.ToBlock + 1) / LabelCountsBucketSize
+	}
+
+	if !rollupExists || (bounded && firstBucket >= lastBucket) {
+		addLabelsRange("range", filter.FromBlock, filter.ToBlock, bounded)
+	} else {
+		rollupConditions := append([]string{"block_bucket >= @first_bucket"}, conditions...)
+		args["first_bucket"] = firstBucket
+		if bounded {
+			rollupConditions = append(rollupConditions, "block_bucket < @last_bucket")
+			args["last_bucket"] = lastBucket
+		}
+		parts = append(parts, fmt.Sprintf("SELECT %s AS key, sum(count) AS count FROM %s WHERE %s GROUP BY 1", rollupKey, countsTable, strings.Join(rollupConditions, " AND ")))
+
+		if filter.FromBlock < firstBucket*LabelCountsBucketSize {
+			addLabelsRange("head", filter.FromBlock, firstBucket*LabelCountsBucketSize-1, true)
+		}
+		if bounded {
+			addLabelsRange("tail", lastBucket*LabelCountsBucketSize, filter.ToBlock, true)
+		}
+	}
+
+	if len(labelsRanges) > 0 {
+		labelsConditions := append([]string{"(" + strings.Join(labelsRanges, " OR ") + ")"}, conditions...)
+		parts = append(parts, fmt.Sprintf("SELECT %s AS key, count(*) AS count FROM %s WHERE %s GROUP BY 1", labelsKey, labelsTable, strings.Join(labelsConditions, " AND ")))
+	}
+
+	order := "count DESC, key"
+	if groupBy == LabelCountsByDay {
+		order = "key"
+	}
+
+	query := fmt.Sprintf(`SELECT key, sum(count)::bigint AS count
+		FROM (%s) AS counts
+		GROUP BY key
+		HAVING sum(count) > 0
+		ORDER BY %s
+		LIMIT @limit`, strings.Join(parts, " UNION ALL "), order)
+
+	rows, err := conn.Query(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := []LabelCount{}
+	for rows.Next() {
+		var count LabelCount
+		if err := rows.Scan(&count.Key, &count.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, rows.Err()
+}
@@ -0,0 +1,368 @@
+package indexer
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	DefaultLabelsPageLimit = 100
+	MaxLabelsPageLimit     = 10000
+)
+
+// LabelsFilter describes conditions of decoded labels selection.
+type LabelsFilter struct {
+	Label     string
+	Address   string
+	LabelName string
+	FromBlock uint64
+	ToBlock   uint64
+	Limit     int
+	Cursor    string
+
+	// CustomerID restricts labels of shared database to customer, ignored in databases of
+	// single customer
+	CustomerID string
+}
+
+// LabelsCursor is a keyset position of the last returned label. Labels are ordered by
+// block_number, log_index (for events), transaction_hash and id, the last one makes position
+// unique when several labels of one type share transaction.
+type LabelsCursor struct {
+	BlockNumber     uint64
+	LogIndex        uint64
+	TransactionHash string
+	ID              string
+}
+
+// maxLabelID is the highest label ID, cursors of previous releases without ID get it, so they
+// skip all labels of their position as before.
+const maxLabelID = "ffffffff-ffff-ffff-ffff-ffffffffffff"
+
+// Encode returns opaque representation of cursor to pass it between pages.
+func (c LabelsCursor) Encode() string {
+	raw := fmt.Sprintf("%d:%d:%s:%s", c.BlockNumber, c.LogIndex, c.TransactionHash, c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeLabelsCursor parses cursor generated by LabelsCursor.Encode.
+func DecodeLabelsCursor(cursor string) (*LabelsCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %v", err)
+	}
+
+	parts := strings.SplitN(string(raw), ":", 4)
+	if len(parts) < 3 {
+		return nil, fmt.Errorf("invalid cursor format")
+	}
+	id := maxLabelID
+	if len(parts) == 4 {
+		if _, err := uuid.Parse(parts[3]); err != nil {
+			return nil, fmt.Errorf("invalid cursor label id: %v", err)
+		}
+		id = parts[3]
+	}
+
+	blockNumber, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor block number: %v", err)
+	}
+	logIndex, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor log index: %v", err)
+	}
+
+	return &LabelsCursor{BlockNumber: blockNumber, LogIndex: logIndex, TransactionHash: parts[2], ID: id}, nil
+}
+
+// EventLabelsPage is a page of event labels, LastCursor points to its last label and is set
+// even if there are no more labels.
+type EventLabelsPage struct {
+	Labels     []EventLabel `json:"labels"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+	LastCursor string       `json:"-"`
+}
+
+// TransactionLabelsPage is a page of transaction labels, LastCursor points to its last label
+// and is set even if there are no more labels.
+type TransactionLabelsPage struct {
+	Labels     []TransactionLabel `json:"labels"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+	LastCursor string             `json:"-"`
+}
+
+// buildLabelsConditions prepares WHERE conditions and named arguments common for events and transactions.
+func buildLabelsConditions(labelType string, filter LabelsFilter, cursor *LabelsCursor) ([]string, pgx.NamedArgs, error) {
+	label := filter.Label
+	if label == "" {
+		label = SeerCrawlerLabel
+	}
+
+	conditions := []string{"label = @label", "label_type = @label_type"}
+	args := pgx.NamedArgs{
+		"label":      label,
+		"label_type": labelType,
+	}
+
+	if filter.Address != "" {
+		addressBytes, err := decodeAddress(filter.Address)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid address %s: %v", filter.Address, err)
+		}
+		conditions = append(conditions, "address = @address")
+		args["address"] = addressBytes
+	}
+
+	if filter.LabelName != "" {
+		conditions = append(conditions, "label_name = @label_name")
+		args["label_name"] = filter.LabelName
+	}
+
+	if filter.FromBlock > 0 {
+		conditions = append(conditions, "block_number >= @from_block")
+		args["from_block"] = filter.FromBlock
+	}
+
+	if filter.ToBlock > 0 {
+		conditions = append(conditions, "block_number <= @to_block")
+		args["to_block"] = filter.ToBlock
+	}
+
+	if cursor != nil {
+		args["cursor_block_number"] = cursor.BlockNumber
+		args["cursor_transaction_hash"] = cursor.TransactionHash
+		args["cursor_id"] = cursor.ID
+		if labelType == "event" {
+			conditions = append(conditions, "(block_number, log_index, transaction_hash, id) > (@cursor_block_number, @cursor_log_index, @cursor_transaction_hash, @cursor_id::uuid)")
+			args["cursor_log_index"] = cursor.LogIndex
+		} else {
+			conditions = append(conditions, "(block_number, transaction_hash, id) > (@cursor_block_number, @cursor_transaction_hash, @cursor_id::uuid)")
+		}
+	}
+
+	return conditions, args, nil
+}
+
+// withCustomerCondition adds condition on customer if labels table belongs to shared database.
+func withCustomerCondition(ctx context.Context, conn *pgxpool.Conn, tableName, customerID string, conditions []string, args pgx.NamedArgs) ([]string, error) {
+	if customerID == "" {
+		return conditions, nil
+	}
+
+	sharedDatabase, err := hasCustomerColumn(ctx, conn, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if !sharedDatabase {
+		return conditions, nil
+	}
+
+	args["customer_id"] = customerID
+	return append(conditions, "customer_id = @customer_id"), nil
+}
+
+func labelsLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultLabelsPageLimit
+	}
+	if limit > MaxLabelsPageLimit {
+		return MaxLabelsPageLimit
+	}
+	return limit
+}
+
+// GetEventLabels returns decoded event labels page ordered by block_number, log_index, transaction_hash and id.
+func (p *PostgreSQLpgx) GetEventLabels(blockchain string, filter LabelsFilter) (*EventLabelsPage, error) {
+	cursor, err := DecodeLabelsCursor(filter.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, args, err := buildLabelsConditions("event", filter, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := labelsLimit(filter.Limit)
+	args["limit"] = limit
+
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	conditions, err = withCustomerCondition(context.Background(), conn, LabelsTableName(blockchain), filter.CustomerID, conditions, args)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`SELECT
+			'0x' || encode(address, 'hex'),
+			block_number,
+			block_hash,
+			'0x' || encode(caller_address, 'hex'),
+			label,
+			label_name,
+			label_type,
+			'0x' || encode(origin_address, 'hex'),
+			transaction_hash,
+			label_data::text,
+			block_timestamp,
+			log_index,
+			id::text
+		FROM %s
+		WHERE %s
+		ORDER BY block_number, log_index, transaction_hash, id
+		LIMIT @limit`, LabelsTableName(blockchain), strings.Join(conditions, " AND "))
+
+	rows, err := conn.Query(context.Background(), query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := EventLabelsPage{Labels: []EventLabel{}}
+	var lastID string
+	for rows.Next() {
+		var label EventLabel
+		err = rows.Scan(
+			&label.Address,
+			&label.BlockNumber,
+			&label.BlockHash,
+			&label.CallerAddress,
+			&label.Label,
+			&label.LabelName,
+			&label.LabelType,
+			&label.OriginAddress,
+			&label.TransactionHash,
+			&label.LabelData,
+			&label.BlockTimestamp,
+			&label.LogIndex,
+			&lastID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		page.Labels = append(page.Labels, label)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(page.Labels) > 0 {
+		last := page.Labels[len(page.Labels)-1]
+		page.LastCursor = LabelsCursor{BlockNumber: last.BlockNumber, LogIndex: last.LogIndex, TransactionHash: last.TransactionHash, ID: lastID}.Encode()
+	}
+	if len(page.Labels) == limit {
+		page.NextCursor = page.LastCursor
+	}
+
+	return &page, nil
+}
+
+// GetTransactionLabels returns decoded transaction call labels page ordered by block_number, transaction_hash and id.
+func (p *PostgreSQLpgx) GetTransactionLabels(blockchain string, filter LabelsFilter) (*TransactionLabelsPage, error) {
+	cursor, err := DecodeLabelsCursor(filter.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, args, err := buildLabelsConditions("tx_call", filter, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := labelsLimit(filter.Limit)
+	args["limit"] = limit
+
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	conditions, err = withCustomerCondition(context.Background(), conn, LabelsTableName(blockchain), filter.CustomerID, conditions, args)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`SELECT
+			'0x' || encode(address, 'hex'),
+			block_number,
+			block_hash,
+			'0x' || encode(caller_address, 'hex'),
+			label,
+			label_name,
+			label_type,
+			'0x' || encode(origin_address, 'hex'),
+			transaction_hash,
+			label_data::text,
+			block_timestamp,
+			id::text
+		FROM %s
+		WHERE %s
+		ORDER BY block_number, transaction_hash, id
+		LIMIT @limit`, LabelsTableName(blockchain), strings.Join(conditions, " AND "))
+
+	rows, err := conn.Query(context.Background(), query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := TransactionLabelsPage{Labels: []TransactionLabel{}}
+	var lastID string
+	for rows.Next() {
+		var label TransactionLabel
+		err = rows.Scan(
+			&label.Address,
+			&label.BlockNumber,
+			&label.BlockHash,
+			&label.CallerAddress,
+			&label.Label,
+			&label.LabelName,
+			&label.LabelType,
+			&label.OriginAddress,
+			&label.TransactionHash,
+			&label.LabelData,
+			&label.BlockTimestamp,
+			&lastID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		page.Labels = append(page.Labels, label)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(page.Labels) > 0 {
+		last := page.Labels[len(page.Labels)-1]
+		page.LastCursor = LabelsCursor{BlockNumber: last.BlockNumber, TransactionHash: last.TransactionHash, ID: lastID}.Encode()
+	}
+	if len(page.Labels) == limit {
+		page.NextCursor = page.LastCursor
+	}
+
+	return &page, nil
+}
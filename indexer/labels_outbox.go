@@ -0,0 +1,156 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const LabelsOutboxTableName = "labels_outbox"
+
+// LabelsOutboxEntry points to labels buffered in storage at Path while customer instance
+// database was unreachable.
+type LabelsOutboxEntry struct {
+	ID              string    `json:"id" db:"id"`
+	Chain           string    `json:"chain" db:"chain"`
+	CustomerID      string    `json:"customer_id" db:"customer_id"`
+	InstanceID      int       `json:"instance_id" db:"instance_id"`
+	Path            string    `json:"path" db:"path"`
+	FromBlock       uint64    `json:"from_block" db:"from_block"`
+	ToBlock         uint64    `json:"to_block" db:"to_block"`
+	Events          int       `json:"events" db:"events"`
+	Transactions    int       `json:"transactions" db:"transactions"`
+	RawTransactions int       `json:"raw_transactions" db:"raw_transactions"`
+	Attempts        int       `json:"attempts" db:"attempts"`
+	LastError       string    `json:"last_error" db:"last_error"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+// EnsureLabelsOutboxTable creates outbox table in index database if it does not exist.
+func (p *PostgreSQLpgx) EnsureLabelsOutboxTable() error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %[1]s (
+		id UUID PRIMARY KEY,
+		chain VARCHAR(128) NOT NULL,
+		customer_id VARCHAR(256) NOT NULL,
+		instance_id INTEGER NOT NULL,
+		path TEXT NOT NULL,
+		from_block BIGINT NOT NULL,
+		to_block BIGINT NOT NULL,
+		events INTEGER NOT NULL DEFAULT 0,
+		transactions INTEGER NOT NULL DEFAULT 0,
+		raw_transactions INTEGER NOT NULL DEFAULT 0,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		last_error TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+	);
+	CREATE INDEX IF NOT EXISTS idx_%[1]s_chain_created_at ON %[1]s (chain, created_at)`, LabelsOutboxTableName))
+
+	return err
+}
+
+// InsertLabelsOutboxEntry records labels buffered in storage.
+func (p *PostgreSQLpgx) InsertLabelsOutboxEntry(entry LabelsOutboxEntry) error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	query := fmt.Sprintf(`INSERT INTO %s (id, chain, customer_id, instance_id, path, from_block, to_block, events, transactions, raw_transactions, last_error)
+		VALUES (@id, @chain, @customer_id, @instance_id, @path, @from_block, @to_block, @events, @transactions, @raw_transactions, @last_error)`, LabelsOutboxTableName)
+
+	_, err = conn.Exec(context.Background(), query, pgx.NamedArgs{
+		"id":               entry.ID,
+		"chain":            entry.Chain,
+		"customer_id":      entry.CustomerID,
+		"instance_id":      entry.InstanceID,
+		"path":             entry.Path,
+		"from_block":       entry.FromBlock,
+		"to_block":         entry.ToBlock,
+		"events":           entry.Events,
+		"transactions":     entry.Transactions,
+		"raw_transactions": entry.RawTransactions,
+		"last_error":       entry.LastError,
+	})
+
+	return err
+}
+
+// SelectLabelsOutboxEntries returns up to limit oldest entries of chain, customers are not
+// filtered if customerIds is empty.
+func (p *PostgreSQLpgx) SelectLabelsOutboxEntries(chain string, customerIds []string, limit int) ([]LabelsOutboxEntry, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	queryArgs := pgx.NamedArgs{
+		"chain": chain,
+		"limit": limit,
+	}
+
+	query := fmt.Sprintf(`SELECT id::text AS id, chain, customer_id, instance_id, path, from_block, to_block, events, transactions, raw_transactions, attempts, last_error, created_at
+		FROM %s WHERE chain = @chain`, LabelsOutboxTableName)
+	if len(customerIds) > 0 {
+		query += " AND customer_id = ANY(@customer_ids)"
+		queryArgs["customer_ids"] = customerIds
+	}
+	query += " ORDER BY created_at, id LIMIT @limit"
+
+	rows, err := conn.Query(context.Background(), query, queryArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[LabelsOutboxEntry])
+}
+
+// DeleteLabelsOutboxEntry removes entry of labels replayed to customer database.
+func (p *PostgreSQLpgx) DeleteLabelsOutboxEntry(id string) error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf("DELETE FROM %s WHERE id = $1", LabelsOutboxTableName), id)
+
+	return err
+}
+
+// RecordLabelsOutboxAttempt increments attempts of entry and keeps error of the last one.
+func (p *PostgreSQLpgx) RecordLabelsOutboxAttempt(id string, attemptErr error) error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	var lastError string
+	if attemptErr != nil {
+		lastError = attemptErr.Error()
+	}
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf("UPDATE %s SET attempts = attempts + 1, last_error = $2 WHERE id = $1", LabelsOutboxTableName), id, lastError)
+
+	return err
+}
@@ -0,0 +1,59 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+)
+
+// EnsureLabelsTable creates labels table of chain with unique indexes used by label writes
+// if it does not exist. Production customer databases are migrated separately, the table is
+// created for development databases populated by devsync.
+func (p *PostgreSQLpgx) EnsureLabelsTable(blockchain string) error {
+	if _, err := BlocksTableName(blockchain); err != nil {
+		return err
+	}
+	tableName := LabelsTableName(blockchain)
+
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	for _, statement := range labelsTableStatements(tableName) {
+		if _, err := conn.Exec(context.Background(), statement); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// labelsTableStatements is DDL of labels table with unique indexes used by label writes.
+func labelsTableStatements(tableName string) []string {
+	return []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			id UUID PRIMARY KEY,
+			label VARCHAR NOT NULL,
+			transaction_hash VARCHAR NOT NULL,
+			log_index INTEGER,
+			block_number BIGINT NOT NULL,
+			block_hash VARCHAR NOT NULL,
+			block_timestamp BIGINT NOT NULL,
+			caller_address BYTEA,
+			origin_address BYTEA,
+			address BYTEA NOT NULL,
+			label_name TEXT,
+			label_type VARCHAR,
+			label_data JSONB,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+		)`, tableName),
+		fmt.Sprintf(`CREATE UNIQUE INDEX IF NOT EXISTS uk_%[1]s_event ON %[1]s (transaction_hash, log_index) WHERE label_type IN ('event', 'bor_state_sync')`, tableName),
+		fmt.Sprintf(`CREATE UNIQUE INDEX IF NOT EXISTS uk_%[1]s_tx_call ON %[1]s (transaction_hash) WHERE label_type = 'tx_call'`, tableName),
+		fmt.Sprintf(`CREATE UNIQUE INDEX IF NOT EXISTS uk_%[1]s_safe_inner_call ON %[1]s (transaction_hash) WHERE label_type = 'safe_inner_call'`, tableName),
+		fmt.Sprintf(`CREATE UNIQUE INDEX IF NOT EXISTS uk_%[1]s_native_transfer ON %[1]s (transaction_hash) WHERE label_type = 'native_transfer'`, tableName),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS ix_%[1]s_address_block_number ON %[1]s (address, block_number)`, tableName),
+	}
+}
@@ -0,0 +1,62 @@
+package indexer
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestLabelsCursorRoundTrip(t *testing.T) {
+	cursor := LabelsCursor{
+		BlockNumber:     61000000,
+		LogIndex:        7,
+		TransactionHash: "0x3f0b2a4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708",
+		ID:              "2d7c8a52-5a0e-5f0b-9d0c-2b7e8f3c1d44",
+	}
+
+	decoded, err := DecodeLabelsCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("DecodeLabelsCursor: %v", err)
+	}
+	if *decoded != cursor {
+		t.Fatalf("got %+v, want %+v", *decoded, cursor)
+	}
+}
+
+func TestDecodeLabelsCursorWithoutID(t *testing.T) {
+	raw := base64.RawURLEncoding.EncodeToString([]byte("100:0:0xabc"))
+
+	decoded, err := DecodeLabelsCursor(raw)
+	if err != nil {
+		t.Fatalf("DecodeLabelsCursor: %v", err)
+	}
+	if decoded.BlockNumber != 100 || decoded.TransactionHash != "0xabc" || decoded.ID != maxLabelID {
+		t.Fatalf("unexpected cursor %+v", *decoded)
+	}
+}
+
+func TestBuildLabelsConditionsCursorIncludesID(t *testing.T) {
+	cursor := &LabelsCursor{BlockNumber: 100, TransactionHash: "0xabc", ID: maxLabelID}
+
+	for _, testCase := range []struct {
+		labelType string
+		condition string
+	}{
+		{"event", "(block_number, log_index, transaction_hash, id) > (@cursor_block_number, @cursor_log_index, @cursor_transaction_hash, @cursor_id::uuid)"},
+		{"tx_call", "(block_number, transaction_hash, id) > (@cursor_block_number, @cursor_transaction_hash, @cursor_id::uuid)"},
+	} {
+		conditions, args, err := buildLabelsConditions(testCase.labelType, LabelsFilter{Label: "seer"}, cursor)
+		if err != nil {
+			t.Fatalf("buildLabelsConditions: %v", err)
+		}
+		if conditions[len(conditions)-1] != testCase.condition {
+			t.Fatalf("cursor condition of %s is %q, want %q", testCase.labelType, conditions[len(conditions)-1], testCase.condition)
+		}
+		if args["cursor_id"] != maxLabelID {
+			t.Fatalf("cursor id argument is %v", args["cursor_id"])
+		}
+	}
+
+	if _, err := DecodeLabelsCursor(base64.RawURLEncoding.EncodeToString([]byte("100:0:0xabc:not-uuid"))); err == nil {
+		t.Fatal("cursor with invalid id should fail")
+	}
+}
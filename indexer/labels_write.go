@@ -0,0 +1,214 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/G7DAO/seer/logging"
+)
+
+// Sections of labels write, each of them is written in its own database transaction.
+const (
+	LabelsSectionTransactions    = "transactions"
+	LabelsSectionEvents          = "events"
+	LabelsSectionRawTransactions = "raw_transactions"
+)
+
+var (
+	// Number of retries of section which failed with transient error
+	LabelsWriteRetries = 3
+	// Wait before first retry of section, doubled for each next one
+	LabelsWriteRetryWait = 500 * time.Millisecond
+)
+
+// labelIDNamespace is a namespace of label IDs, ID is derived from label content, so the same
+// label written twice gets the same ID and retried inserts do not create duplicates. Columns
+// of unique index of label type are part of its ID, otherwise retried insert would conflict
+// with primary key outside of ON CONFLICT arbiter.
+var labelIDNamespace = uuid.MustParse("6f1c3a52-5a0e-4f0b-9d0c-2b7e8f3c1d44")
+
+// EventLabelID returns ID of event label derived from its content.
+func EventLabelID(blockchain string, event EventLabel) uuid.UUID {
+	key := strings.Join([]string{blockchain, event.Label, event.LabelType, event.LabelName, strings.ToLower(event.Address), event.BlockHash, event.TransactionHash, fmt.Sprint(event.LogIndex), event.LabelData}, "|")
+	return uuid.NewSHA1(labelIDNamespace, []byte(key))
+}
+
+// TransactionLabelID returns ID of transaction label derived from its content. Internal
+// transactions have no unique index of their own and are identified by position in call trace
+// instead, primary key is the arbiter of their inserts.
+func TransactionLabelID(blockchain string, transaction TransactionLabel) uuid.UUID {
+	content := transaction.LabelData
+	if transaction.LabelType == "internal_tx" {
+		content = internalTransactionTraceAddress(transaction.LabelData)
+	}
+
+	key := strings.Join([]string{blockchain, transaction.Label, transaction.LabelType, transaction.LabelName, strings.ToLower(transaction.Address), transaction.BlockHash, transaction.TransactionHash, content}, "|")
+	return uuid.NewSHA1(labelIDNamespace, []byte(key))
+}
+
+// internalTransactionTraceAddress returns trace_address of internal transaction label data,
+// label data itself if it could not be parsed.
+func internalTransactionTraceAddress(labelData string) string {
+	var internalTx struct {
+		TraceAddress json.RawMessage `json:"trace_address"`
+	}
+	if err := json.Unmarshal([]byte(labelData), &internalTx); err != nil || internalTx.TraceAddress == nil {
+		return labelData
+	}
+
+	return "trace_address=" + string(internalTx.TraceAddress)
+}
+
+// LabelsSectionResult is an outcome of one section of labels write.
+type LabelsSectionResult struct {
+	Section  string `json:"section"`
+	Rows     int    `json:"rows"`
+	Attempts int    `json:"attempts"`
+	Err      error  `json:"-"`
+}
+
+// LabelsWriteError is returned by WriteDataToCustomerDB when at least one section was not
+// persisted after retries. Sections listed in Persisted are committed and should not be
+// written again.
+type LabelsWriteError struct {
+	Blockchain string
+	Persisted  []LabelsSectionResult
+	Failed     []LabelsSectionResult
+}
+
+func (e *LabelsWriteError) Error() string {
+	var persisted, failed []string
+	for _, result := range e.Persisted {
+		persisted = append(persisted, fmt.Sprintf("%s (%d rows)", result.Section, result.Rows))
+	}
+	for _, result := range e.Failed {
+		failed = append(failed, fmt.Sprintf("%s (%d rows, %d attempts): %v", result.Section, result.Rows, result.Attempts, result.Err))
+	}
+	if len(persisted) == 0 {
+		persisted = []string{"nothing"}
+	}
+
+	return fmt.Sprintf("failed to write labels of %s: %s; persisted: %s", e.Blockchain, strings.Join(failed, "; "), strings.Join(persisted, ", "))
+}
+
+func (e *LabelsWriteError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, result := range e.Failed {
+		errs = append(errs, result.Err)
+	}
+	return errs
+}
+
+// IsPersisted returns true if section was committed.
+func (e *LabelsWriteError) IsPersisted(section string) bool {
+	for _, result := range e.Persisted {
+		if result.Section == section {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTransientWriteError returns true for errors after which the same write could succeed:
+// lost connections, timeouts, serialization failures, deadlocks and overloaded ClickHouse.
+func IsTransientWriteError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if pgconn.SafeToRetry(err) || pgconn.Timeout(err) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var clickHouseErr *ClickHouseError
+	if errors.As(err, &clickHouseErr) {
+		return clickHouseErr.Transient()
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case strings.HasPrefix(pgErr.Code, "08"), // connection exception
+			pgErr.Code == "40001", // serialization failure
+			pgErr.Code == "40P01", // deadlock detected
+			pgErr.Code == "53300", // too many connections
+			pgErr.Code == "57P01", // admin shutdown
+			pgErr.Code == "57P03": // cannot connect now
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeLabelsSection writes section in its own transaction. Commit errors are returned as
+// any other error. Section retried after commit actually succeeded is skipped row by row: IDs
+// are derived from labels and rows of each label type target unique index covering columns
+// its ID is derived from (see ConflictTargetFor), so repeated row conflicts with the arbiter
+// before it could violate primary key.
+func (p *PostgreSQLpgx) writeLabelsSection(ctx context.Context, write func(tx pgx.Tx) error) error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := applySessionSettings(ctx, tx, TableClassLabels); err != nil {
+		return err
+	}
+
+	if err := write(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// writeLabelsSectionWithRetries retries section while errors are transient and budget is
+// not exhausted.
+func (p *PostgreSQLpgx) writeLabelsSectionWithRetries(ctx context.Context, blockchain, section string, rows int, write func(tx pgx.Tx) error) LabelsSectionResult {
+	return retryLabelsSection(blockchain, section, rows, func() error {
+		return p.writeLabelsSection(ctx, write)
+	})
+}
+
+// retryLabelsSection calls write until it succeeds, fails with error which is not transient
+// or LabelsWriteRetries are used up.
+func retryLabelsSection(blockchain, section string, rows int, write func() error) LabelsSectionResult {
+	result := LabelsSectionResult{Section: section, Rows: rows}
+	wait := LabelsWriteRetryWait
+
+	for {
+		result.Attempts++
+		result.Err = write()
+		if result.Err == nil || !IsTransientWriteError(result.Err) || result.Attempts > LabelsWriteRetries {
+			return result
+		}
+
+		logging.Chain(blockchain).Warn("Transient error writing labels section, retrying", "section", section, "attempt", result.Attempts, "attempts", LabelsWriteRetries+1, "wait", wait.String(), logging.ErrorKey, result.Err)
+		time.Sleep(wait)
+		wait *= 2
+	}
+}
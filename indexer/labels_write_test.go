@@ -0,0 +1,50 @@
+package indexer
+
+import "testing"
+
+func internalTransactionLabel(traceAddress, status string) TransactionLabel {
+	return TransactionLabel{
+		Address:         "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+		BlockNumber:     100,
+		BlockHash:       "0x01",
+		Label:           "seer",
+		LabelName:       "InternalTransfer",
+		LabelType:       "internal_tx",
+		TransactionHash: "0x3f0b2a4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708",
+		LabelData:       `{"type":"internal_tx","call_type":"CALL","value":"1000","trace_address":` + traceAddress + `,"status":` + status + `}`,
+	}
+}
+
+func TestTransactionLabelIDOfInternalTransaction(t *testing.T) {
+	first := TransactionLabelID("ethereum", internalTransactionLabel("[0]", "1"))
+
+	if TransactionLabelID("ethereum", internalTransactionLabel("[0]", "0")) != first {
+		t.Fatal("ID of internal transaction depends on label data other than trace address")
+	}
+	if TransactionLabelID("ethereum", internalTransactionLabel("[0,1]", "1")) == first {
+		t.Fatal("internal transactions of different trace addresses have the same ID")
+	}
+}
+
+func TestRewriteInternalTransactionsSection(t *testing.T) {
+	const blockchain = "ethereum"
+	p := newTestLabelsDB(t, blockchain)
+
+	receiver := internalTransactionLabel("[0]", "1")
+	receiver.Address = "0x5a52e96bacdabb82fd05763e25335261b270efcb"
+	transactions := []TransactionLabel{internalTransactionLabel("[0]", "1"), receiver, internalTransactionLabel("[1]", "1")}
+
+	// Section retried after commit, then traced again with other status of call
+	for i := 0; i < 2; i++ {
+		if err := p.WriteDataToCustomerDB(blockchain, transactions, nil, nil); err != nil {
+			t.Fatalf("write %d of internal transactions: %v", i, err)
+		}
+	}
+	if err := p.WriteDataToCustomerDB(blockchain, []TransactionLabel{internalTransactionLabel("[0]", "0")}, nil, nil); err != nil {
+		t.Fatalf("write of traced again internal transaction: %v", err)
+	}
+
+	if counts := countLabels(t, p, blockchain); counts["internal_tx"] != 3 {
+		t.Fatalf("got labels %v, want 3 internal_tx", counts)
+	}
+}
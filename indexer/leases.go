@@ -0,0 +1,121 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const LeasesTableName = "seer_leases"
+
+// Lease is a time limited ownership of resource by one process. Expired is evaluated by
+// database clock, so replicas do not depend on their own clocks being in sync.
+type Lease struct {
+	Resource    string    `json:"resource"`
+	Holder      string    `json:"holder"`
+	AcquiredAt  time.Time `json:"acquired_at"`
+	HeartbeatAt time.Time `json:"heartbeat_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Expired     bool      `json:"expired"`
+}
+
+// EnsureLeasesTable creates table of leases if it does not exist.
+func (p *PostgreSQLpgx) EnsureLeasesTable() error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		resource VARCHAR PRIMARY KEY,
+		holder VARCHAR NOT NULL,
+		acquired_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+		heartbeat_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+		expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+	)`, LeasesTableName))
+
+	return err
+}
+
+// AcquireLease takes lease of resource for ttl if it is free, expired or already held by
+// holder, in the last case lease is renewed. Returns false if resource is held by other
+// holder. Check and update are done in one statement, so two processes could not acquire
+// the same lease.
+func (p *PostgreSQLpgx) AcquireLease(resource, holder string, ttl time.Duration) (bool, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return false, err
+	}
+	defer conn.Release()
+
+	query := fmt.Sprintf(`INSERT INTO %[1]s (resource, holder, acquired_at, heartbeat_at, expires_at)
+		VALUES ($1, $2, now(), now(), now() + make_interval(secs => $3))
+		ON CONFLICT (resource) DO UPDATE SET
+			holder = EXCLUDED.holder,
+			acquired_at = CASE WHEN %[1]s.holder = EXCLUDED.holder THEN %[1]s.acquired_at ELSE now() END,
+			heartbeat_at = now(),
+			expires_at = EXCLUDED.expires_at
+		WHERE %[1]s.holder = EXCLUDED.holder OR %[1]s.expires_at < now()
+		RETURNING holder`, LeasesTableName)
+
+	rows, err := conn.Query(context.Background(), query, resource, holder, ttl.Seconds())
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	acquired := rows.Next()
+	rows.Close()
+
+	return acquired, rows.Err()
+}
+
+// ReleaseLease frees resource if it is held by holder.
+func (p *PostgreSQLpgx) ReleaseLease(resource, holder string) error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf("DELETE FROM %s WHERE resource = $1 AND holder = $2", LeasesTableName), resource, holder)
+	return err
+}
+
+// ListLeases returns leases of resources with prefix, including expired ones.
+func (p *PostgreSQLpgx) ListLeases(prefix string) ([]Lease, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(context.Background(), fmt.Sprintf(`SELECT resource, holder, acquired_at, heartbeat_at, expires_at, expires_at < now()
+		FROM %s
+		WHERE starts_with(resource, $1)
+		ORDER BY resource`, LeasesTableName), prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	leases := []Lease{}
+	for rows.Next() {
+		var lease Lease
+		if err := rows.Scan(&lease.Resource, &lease.Holder, &lease.AcquiredAt, &lease.HeartbeatAt, &lease.ExpiresAt, &lease.Expired); err != nil {
+			return nil, err
+		}
+		leases = append(leases, lease)
+	}
+
+	return leases, rows.Err()
+}
@@ -0,0 +1,60 @@
+package indexer
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sync"
+)
+
+// LocalChain is a development chain served by Anvil or Hardhat node. Its tables are named by
+// configurable prefix, so several devnets could share one database.
+const LocalChain = "local"
+
+const DefaultLocalTablePrefix = "local"
+
+var localTablePrefixRe = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+var (
+	localTablePrefix     string
+	localTablePrefixErr  error
+	localTablePrefixOnce sync.Once
+)
+
+// ParseLocalTablePrefix validates table prefix of local chain, empty prefix is the default one.
+func ParseLocalTablePrefix(raw string) (string, error) {
+	if raw == "" {
+		return DefaultLocalTablePrefix, nil
+	}
+	if !localTablePrefixRe.MatchString(raw) {
+		return "", fmt.Errorf("table prefix %q should start with a letter and contain only lowercase letters, digits and underscores", raw)
+	}
+	return raw, nil
+}
+
+// LocalTablePrefix returns table prefix of local chain configured with SEER_LOCAL_TABLE_PREFIX
+// environment variable.
+func LocalTablePrefix() (string, error) {
+	localTablePrefixOnce.Do(func() {
+		localTablePrefix, localTablePrefixErr = ParseLocalTablePrefix(os.Getenv("SEER_LOCAL_TABLE_PREFIX"))
+	})
+	if localTablePrefixErr != nil {
+		return "", fmt.Errorf("invalid SEER_LOCAL_TABLE_PREFIX environment variable: %w", localTablePrefixErr)
+	}
+	return localTablePrefix, nil
+}
+
+// ChainTablePrefix returns prefix of blocks, transactions and labels tables of blockchain.
+// Invalid prefix of local chain is reported by CheckVariablesForIndexer, the default prefix
+// is used in its place.
+func ChainTablePrefix(blockchain string) string {
+	if blockchain != LocalChain {
+		return blockchain
+	}
+
+	prefix, err := LocalTablePrefix()
+	if err != nil {
+		return DefaultLocalTablePrefix
+	}
+	return prefix
+}
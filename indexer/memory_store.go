@@ -0,0 +1,595 @@
+package indexer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// MemoryStore is an in-process IndexStore, it follows semantics of PostgreSQLpgx queries
+// and is intended for tests of crawler and synchronizer logic and for runs without database.
+// It could also serve as customer database, labels are kept per chain.
+type MemoryStore struct {
+	mu sync.RWMutex
+
+	blocks  map[string]map[uint64]BlockIndex
+	abiJobs []AbiJob
+
+	events          map[string]map[uuid.UUID]EventLabel
+	transactions    map[string]map[uuid.UUID]TransactionLabel
+	rawTransactions map[string]map[string]RawTransaction
+	// writeErr fails labels writes while it is set
+	writeErr error
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		blocks:          make(map[string]map[uint64]BlockIndex),
+		events:          make(map[string]map[uuid.UUID]EventLabel),
+		transactions:    make(map[string]map[uuid.UUID]TransactionLabel),
+		rawTransactions: make(map[string]map[string]RawTransaction),
+	}
+}
+
+// AddAbiJobs puts jobs into store as is, Abi field is expected to be wrapped in brackets
+// the same way PostgreSQLpgx returns it.
+func (m *MemoryStore) AddAbiJobs(jobs ...AbiJob) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.abiJobs = append(m.abiJobs, jobs...)
+}
+
+// sortedBlocks returns blocks of chain ordered by block number, caller should hold lock.
+func (m *MemoryStore) sortedBlocks(blockchain string) []BlockIndex {
+	chainBlocks := m.blocks[blockchain]
+	blocks := make([]BlockIndex, 0, len(chainBlocks))
+	for _, block := range chainBlocks {
+		blocks = append(blocks, block)
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].BlockNumber < blocks[j].BlockNumber })
+
+	return blocks
+}
+
+// pathBounds returns lowest and highest block numbers stored with path.
+func pathBounds(blocks []BlockIndex, path string) (uint64, uint64) {
+	var minBlock, maxBlock uint64
+	found := false
+	for _, block := range blocks {
+		if block.Path != path {
+			continue
+		}
+		if !found || block.BlockNumber < minBlock {
+			minBlock = block.BlockNumber
+		}
+		if !found || block.BlockNumber > maxBlock {
+			maxBlock = block.BlockNumber
+		}
+		found = true
+	}
+
+	return minBlock, maxBlock
+}
+
+func (m *MemoryStore) WriteIndexes(blockchain string, blocksIndexPack []BlockIndex) error {
+	if _, err := BlocksTableName(blockchain); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.blocks[blockchain] == nil {
+		m.blocks[blockchain] = make(map[uint64]BlockIndex)
+	}
+	for _, block := range blocksIndexPack {
+		// Same as ON CONFLICT (block_number) DO NOTHING
+		if _, exists := m.blocks[blockchain][block.BlockNumber]; exists {
+			continue
+		}
+		m.blocks[blockchain][block.BlockNumber] = block
+	}
+
+	return nil
+}
+
+func (m *MemoryStore) GetLatestDBBlockNumber(blockchain string, reverse ...bool) (uint64, error) {
+	if _, err := BlocksTableName(blockchain); err != nil {
+		return 0, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	blocks := m.sortedBlocks(blockchain)
+	if len(blocks) == 0 {
+		return 0, ErrNoRowsIndexed
+	}
+
+	if len(reverse) > 0 && reverse[0] {
+		return blocks[0].BlockNumber, nil
+	}
+
+	return blocks[len(blocks)-1].BlockNumber, nil
+}
+
+// ReadIndexedBatches groups blocks by path, blocks in memory are always written together with
+// their indexing markers, so batches are never incomplete.
+func (m *MemoryStore) ReadIndexedBatches(blockchain string, fromBlock uint64) ([]IndexedBatch, error) {
+	if _, err := BlocksTableName(blockchain); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var batches []IndexedBatch
+	batchesIndex := make(map[string]int)
+	for _, block := range m.sortedBlocks(blockchain) {
+		if block.BlockNumber < fromBlock {
+			continue
+		}
+
+		i, exists := batchesIndex[block.Path]
+		if !exists {
+			batchesIndex[block.Path] = len(batches)
+			batches = append(batches, IndexedBatch{
+				Path:           block.Path,
+				MinBlockNumber: block.BlockNumber,
+				MaxBlockNumber: block.BlockNumber,
+				BlocksCount:    1,
+			})
+			continue
+		}
+
+		batches[i].MaxBlockNumber = block.BlockNumber
+		batches[i].BlocksCount++
+	}
+
+	return batches, nil
+}
+
+func (m *MemoryStore) DeleteBlockIndexRange(blockchain string, fromBlock, toBlock uint64) (int64, error) {
+	if _, err := BlocksTableName(blockchain); err != nil {
+		return 0, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var deleted int64
+	for blockNumber := range m.blocks[blockchain] {
+		if blockNumber >= fromBlock && blockNumber <= toBlock {
+			delete(m.blocks[blockchain], blockNumber)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+func (m *MemoryStore) ReadUpdates(blockchain string, fromBlock uint64, customerIds []string, minBlocksToSync int) (uint64, uint64, []string, []CustomerUpdates, error) {
+	if _, err := BlocksTableName(blockchain); err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	blocks := m.sortedBlocks(blockchain)
+
+	var paths []string
+	pathsIndex := make(map[string]bool)
+	var latestPath string
+	for _, block := range blocks {
+		if block.BlockNumber < fromBlock || block.BlockNumber > fromBlock+uint64(minBlocksToSync) {
+			continue
+		}
+		if !pathsIndex[block.Path] {
+			pathsIndex[block.Path] = true
+			paths = append(paths, block.Path)
+		}
+		latestPath = block.Path
+	}
+
+	// Query returns no rows if there are no blocks in range
+	if len(paths) == 0 {
+		return 0, 0, nil, nil, nil
+	}
+	sort.Strings(paths)
+
+	_, lastBlockNumber := pathBounds(blocks, latestPath)
+
+	customersAbis := make(map[string]map[string]map[string]*AbiEntry)
+	var customersOrder []string
+	for _, job := range m.abiJobs {
+		if job.Chain != blockchain || job.Status == AbiJobStatusInactive {
+			continue
+		}
+		if len(customerIds) > 0 && !containsString(customerIds, job.CustomerID) {
+			continue
+		}
+
+		if _, exists := customersAbis[job.CustomerID]; !exists {
+			customersAbis[job.CustomerID] = make(map[string]map[string]*AbiEntry)
+			customersOrder = append(customersOrder, job.CustomerID)
+		}
+
+		addressStr := AbiJobAddress(job.Address)
+		if _, exists := customersAbis[job.CustomerID][addressStr]; !exists {
+			customersAbis[job.CustomerID][addressStr] = make(map[string]*AbiEntry)
+		}
+
+		customersAbis[job.CustomerID][addressStr][job.AbiSelector] = &AbiEntry{
+			AbiJSON:      job.Abi,
+			AbiName:      job.AbiName,
+			AbiType:      job.AbiType,
+			TopicFilters: job.TopicFilters,
+		}
+	}
+
+	var customerUpdates []CustomerUpdates
+	for _, customerID := range customersOrder {
+		customerUpdates = append(customerUpdates, CustomerUpdates{
+			CustomerID: customerID,
+			Abis:       customersAbis[customerID],
+		})
+	}
+
+	return 0, lastBlockNumber, paths, customerUpdates, nil
+}
+
+func (m *MemoryStore) RetrievePathsAndBlockBounds(blockchain string, blockNumber uint64, minBlocksToSync int) ([]string, uint64, uint64, error) {
+	if _, err := BlocksTableName(blockchain); err != nil {
+		return nil, 0, 0, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	blocks := m.sortedBlocks(blockchain)
+
+	var lowestBlockNumber uint64
+	if blockNumber > uint64(minBlocksToSync) {
+		lowestBlockNumber = blockNumber - uint64(minBlocksToSync)
+	}
+
+	var paths []string
+	pathsIndex := make(map[string]bool)
+	var earliestPath, latestPath string
+	for _, block := range blocks {
+		if block.BlockNumber < lowestBlockNumber || block.BlockNumber > blockNumber {
+			continue
+		}
+		if len(paths) == 0 {
+			earliestPath = block.Path
+		}
+		if !pathsIndex[block.Path] {
+			pathsIndex[block.Path] = true
+			paths = append(paths, block.Path)
+		}
+		latestPath = block.Path
+	}
+
+	if len(paths) == 0 {
+		return nil, 0, 0, nil
+	}
+	sort.Strings(paths)
+
+	minBlockNumber, _ := pathBounds(blocks, earliestPath)
+	_, maxBlockNumber := pathBounds(blocks, latestPath)
+
+	return paths, minBlockNumber, maxBlockNumber, nil
+}
+
+func (m *MemoryStore) ReadABIJobs(blockchain string) ([]AbiJob, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var abiJobs []AbiJob
+	for _, job := range m.abiJobs {
+		if job.Chain == blockchain && job.AbiType != "" {
+			abiJobs = append(abiJobs, job)
+		}
+	}
+
+	return abiJobs, nil
+}
+
+func (m *MemoryStore) SelectAbiJobs(blockchain string, addresses []string, customersIds []string, autoJobs, isDeployBlockNotNull bool, abiTypes []string) ([]AbiJob, error) {
+	var addressesBytes [][]byte
+	for _, address := range addresses {
+		addressBytes, err := decodeAddress(address)
+		if err != nil {
+			return nil, err
+		}
+		addressesBytes = append(addressesBytes, addressBytes)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	abiJobs := []AbiJob{}
+	for _, job := range m.abiJobs {
+		if len(abiTypes) > 0 && !containsString(abiTypes, job.AbiType) {
+			continue
+		}
+		if isDeployBlockNotNull && job.DeploymentBlockNumber == nil {
+			continue
+		}
+		if blockchain != "" && job.Chain != blockchain {
+			continue
+		}
+		if autoJobs && (job.HistoricalCrawlStatus == "done" || job.Status == AbiJobStatusInactive) {
+			continue
+		}
+		if len(addressesBytes) > 0 {
+			matched := false
+			for _, addressBytes := range addressesBytes {
+				if bytes.Equal(addressBytes, job.Address) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+		if len(customersIds) > 0 && !containsString(customersIds, job.CustomerID) {
+			continue
+		}
+
+		abiJobs = append(abiJobs, job)
+	}
+
+	return abiJobs, nil
+}
+
+func (m *MemoryStore) UpdateAbiJobsStatus(blockchain string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, job := range m.abiJobs {
+		if job.Chain == blockchain && job.HistoricalCrawlStatus == "pending" && job.Status == "active" && job.DeploymentBlockNumber != nil {
+			m.abiJobs[i].HistoricalCrawlStatus = "in_progress"
+			m.abiJobs[i].MoonwormTaskPickedup = true
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryStore) UpdateAbisAsDone(ids []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, job := range m.abiJobs {
+		if containsString(ids, job.ID) {
+			m.abiJobs[i].HistoricalCrawlStatus = "done"
+			m.abiJobs[i].Progress = 100
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryStore) UpdateAbisProgress(ids []string, process int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, job := range m.abiJobs {
+		if containsString(ids, job.ID) {
+			m.abiJobs[i].Progress = process
+		}
+	}
+
+	return nil
+}
+
+// AppendAbisProgress applies progress right away, there is no lock contention in memory.
+func (m *MemoryStore) AppendAbisProgress(progresses []AbiJobProgress) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, progress := range progresses {
+		for i, job := range m.abiJobs {
+			if job.ID == progress.ID && job.HistoricalCrawlStatus != "done" && job.Progress < progress.Progress {
+				m.abiJobs[i].Progress = progress.Progress
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetAbiJobsWithoutDeployBlocks returns IDs of event and non-view function jobs without
+// deployment block grouped by chain and address, selectors are not corrected.
+func (m *MemoryStore) GetAbiJobsWithoutDeployBlocks(blockchain string) (map[string]map[string][]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	chainsAddresses := make(map[string]map[string][]string)
+	for _, job := range m.abiJobs {
+		if job.Chain != blockchain || job.DeploymentBlockNumber != nil || len(job.Address) == 0 {
+			continue
+		}
+
+		var abiEntries []map[string]interface{}
+		if err := json.Unmarshal([]byte(job.Abi), &abiEntries); err != nil || len(abiEntries) == 0 {
+			continue
+		}
+		if abiEntries[0]["type"] != "event" && (abiEntries[0]["type"] != "function" || abiEntries[0]["stateMutability"] == "view") {
+			continue
+		}
+
+		address := fmt.Sprintf("0x%x", job.Address)
+		if chainsAddresses[job.Chain] == nil {
+			chainsAddresses[job.Chain] = make(map[string][]string)
+		}
+		chainsAddresses[job.Chain][address] = append(chainsAddresses[job.Chain][address], job.ID)
+	}
+
+	return chainsAddresses, nil
+}
+
+func (m *MemoryStore) UpdateAbiJobsDeployBlock(blockNumber uint64, ids []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, job := range m.abiJobs {
+		if containsString(ids, job.ID) {
+			deploymentBlockNumber := blockNumber
+			m.abiJobs[i].DeploymentBlockNumber = &deploymentBlockNumber
+		}
+	}
+
+	return nil
+}
+
+// SetWriteError makes labels writes fail with err until it is reset with nil.
+func (m *MemoryStore) SetWriteError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.writeErr = err
+}
+
+// WriteDataToCustomerDB keeps labels by their IDs and raw transactions by hashes, written
+// again they are skipped the same way as inserts into customer database skip conflicts.
+func (m *MemoryStore) WriteDataToCustomerDB(blockchain string, txCalls []TransactionLabel, events []EventLabel, rawTransactions []RawTransaction) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.writeErr != nil {
+		writeErr := &LabelsWriteError{Blockchain: blockchain}
+		sections := []LabelsSectionResult{
+			{Section: LabelsSectionTransactions, Rows: len(txCalls)},
+			{Section: LabelsSectionEvents, Rows: len(events)},
+			{Section: LabelsSectionRawTransactions, Rows: len(rawTransactions)},
+		}
+		for _, section := range sections {
+			if section.Rows > 0 {
+				section.Attempts, section.Err = 1, m.writeErr
+				writeErr.Failed = append(writeErr.Failed, section)
+			}
+		}
+		if len(writeErr.Failed) > 0 {
+			return writeErr
+		}
+		return nil
+	}
+
+	if m.transactions[blockchain] == nil {
+		m.transactions[blockchain] = make(map[uuid.UUID]TransactionLabel)
+		m.events[blockchain] = make(map[uuid.UUID]EventLabel)
+		m.rawTransactions[blockchain] = make(map[string]RawTransaction)
+	}
+	for _, transaction := range txCalls {
+		id := TransactionLabelID(blockchain, transaction)
+		if _, exists := m.transactions[blockchain][id]; !exists {
+			m.transactions[blockchain][id] = transaction
+		}
+	}
+	for _, event := range events {
+		id := EventLabelID(blockchain, event)
+		if _, exists := m.events[blockchain][id]; !exists {
+			m.events[blockchain][id] = event
+		}
+	}
+	for _, rawTransaction := range rawTransactions {
+		if _, exists := m.rawTransactions[blockchain][rawTransaction.Hash]; !exists {
+			m.rawTransactions[blockchain][rawTransaction.Hash] = rawTransaction
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryStore) ReadLastLabel(blockchain string) (uint64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var lastBlock uint64
+	for _, transaction := range m.transactions[blockchain] {
+		lastBlock = max(lastBlock, transaction.BlockNumber)
+	}
+	for _, event := range m.events[blockchain] {
+		lastBlock = max(lastBlock, event.BlockNumber)
+	}
+
+	return lastBlock, nil
+}
+
+// Close does nothing, data stays available for assertions.
+func (m *MemoryStore) Close() {}
+
+// EventLabels returns written events of chain ordered by block number and log index.
+func (m *MemoryStore) EventLabels(blockchain string) []EventLabel {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	events := make([]EventLabel, 0, len(m.events[blockchain]))
+	for _, event := range m.events[blockchain] {
+		events = append(events, event)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].BlockNumber != events[j].BlockNumber {
+			return events[i].BlockNumber < events[j].BlockNumber
+		}
+		return events[i].LogIndex < events[j].LogIndex
+	})
+
+	return events
+}
+
+// TransactionLabels returns written transaction calls of chain ordered by block number.
+func (m *MemoryStore) TransactionLabels(blockchain string) []TransactionLabel {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	transactions := make([]TransactionLabel, 0, len(m.transactions[blockchain]))
+	for _, transaction := range m.transactions[blockchain] {
+		transactions = append(transactions, transaction)
+	}
+	sort.Slice(transactions, func(i, j int) bool {
+		if transactions[i].BlockNumber != transactions[j].BlockNumber {
+			return transactions[i].BlockNumber < transactions[j].BlockNumber
+		}
+		return transactions[i].TransactionHash < transactions[j].TransactionHash
+	})
+
+	return transactions
+}
+
+// RawTransactions returns written raw transactions of chain ordered by block number and index.
+func (m *MemoryStore) RawTransactions(blockchain string) []RawTransaction {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rawTransactions := make([]RawTransaction, 0, len(m.rawTransactions[blockchain]))
+	for _, rawTransaction := range m.rawTransactions[blockchain] {
+		rawTransactions = append(rawTransactions, rawTransaction)
+	}
+	sort.Slice(rawTransactions, func(i, j int) bool {
+		if rawTransactions[i].BlockNumber != rawTransactions[j].BlockNumber {
+			return rawTransactions[i].BlockNumber < rawTransactions[j].BlockNumber
+		}
+		return rawTransactions[i].TransactionIndex < rawTransactions[j].TransactionIndex
+	})
+
+	return rawTransactions
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G7DAO/seer/logging"
+)
+
+const AbiJobsProgressEventsTableName = "abi_jobs_progress_events"
+
+// AbiJobProgress is a progress of historical crawl reported by worker for abi job.
+type AbiJobProgress struct {
+	ID       string
+	Progress int
+}
+
+// EnsureAbiJobsProgressEventsTable creates append-only table of progress events if it does not exist.
+func (p *PostgreSQLpgx) EnsureAbiJobsProgressEventsTable() error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		id BIGSERIAL PRIMARY KEY,
+		abi_job_id UUID NOT NULL,
+		progress INTEGER NOT NULL,
+		created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+	)`, AbiJobsProgressEventsTableName))
+
+	return err
+}
+
+// AppendAbisProgress inserts progress events of jobs without touching abi_jobs rows, so many
+// workers do not wait on each other's row locks. Events are applied by FoldAbisProgressEvents.
+func (p *PostgreSQLpgx) AppendAbisProgress(progresses []AbiJobProgress) error {
+	if len(progresses) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(progresses))
+	values := make([]int, len(progresses))
+	for i, progress := range progresses {
+		id, err := uuid.Parse(progress.ID)
+		if err != nil {
+			return err
+		}
+		ids[i] = id
+		values[i] = progress.Progress
+	}
+
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	query := fmt.Sprintf("INSERT INTO %s (abi_job_id, progress) SELECT * FROM unnest(@ids::uuid[], @progresses::int[])", AbiJobsProgressEventsTableName)
+
+	_, err = conn.Exec(context.Background(), query, pgx.NamedArgs{
+		"ids":        ids,
+		"progresses": values,
+	})
+
+	return err
+}
+
+// FoldAbisProgressEvents moves up to limit oldest events into abi_jobs in single transaction,
+// each job gets one UPDATE with the highest reported progress. Jobs already marked as done
+// are not updated, so late events do not roll progress back. Returns number of folded events.
+func (p *PostgreSQLpgx) FoldAbisProgressEvents(limit int) (int64, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(context.Background())
+
+	var folded int64
+	err = tx.QueryRow(context.Background(), fmt.Sprintf(`WITH events AS (
+		DELETE FROM %[1]s
+		WHERE id IN (
+			SELECT id FROM %[1]s ORDER BY id LIMIT @limit FOR UPDATE SKIP LOCKED
+		)
+		RETURNING abi_job_id, progress
+	), latest AS (
+		SELECT abi_job_id, max(progress) AS progress FROM events GROUP BY abi_job_id
+	), updated AS (
+		UPDATE abi_jobs
+		SET progress = latest.progress, updated_at = now()
+		FROM latest
+		WHERE abi_jobs.id = latest.abi_job_id
+			AND abi_jobs.historical_crawl_status != 'done'
+			AND abi_jobs.progress IS DISTINCT FROM latest.progress
+		RETURNING abi_jobs.id
+	)
+	SELECT (SELECT count(*) FROM events)`, AbiJobsProgressEventsTableName), pgx.NamedArgs{
+		"limit": limit,
+	}).Scan(&folded)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(context.Background()); err != nil {
+		return 0, err
+	}
+
+	return folded, nil
+}
+
+// RunAbisProgressAggregator periodically folds progress events into abi_jobs until context is
+// canceled. When fold returns full batch there is a backlog, so next batch is folded right away
+// instead of waiting for interval.
+func (p *PostgreSQLpgx) RunAbisProgressAggregator(ctx context.Context, interval time.Duration, batchLimit int) error {
+	if batchLimit <= 0 {
+		return fmt.Errorf("batch limit should be positive, got %d", batchLimit)
+	}
+
+	for {
+		folded, err := p.FoldAbisProgressEvents(batchLimit)
+		if err != nil {
+			slog.Error("Failed to fold ABI jobs progress events", logging.ErrorKey, err)
+		} else if folded > 0 {
+			slog.Info("Folded ABI jobs progress events", "events", folded)
+		}
+
+		if err == nil && folded >= int64(batchLimit) {
+			select {
+			case <-ctx.Done():
+				return nil
+			default:
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
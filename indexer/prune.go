@@ -0,0 +1,127 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G7DAO/seer/logging"
+)
+
+// PruneOptions defines which labels are removed from customer database. Rows matching
+// any of set conditions (block number or block timestamp) are removed.
+type PruneOptions struct {
+	BeforeBlock     uint64
+	BeforeTimestamp uint64
+	RawTransactions bool
+	BatchLimit      uint64
+	SleepTime       int
+	DryRun          bool
+}
+
+// PruneReport describes rows removed from table, or rows which would be removed in dry run.
+type PruneReport struct {
+	Table    string `json:"table"`
+	Rows     int64  `json:"rows"`
+	MinBlock uint64 `json:"min_block"`
+	MaxBlock uint64 `json:"max_block"`
+	DryRun   bool   `json:"dry_run"`
+}
+
+func (o *PruneOptions) condition() (string, pgx.NamedArgs, error) {
+	args := pgx.NamedArgs{}
+	var condition string
+
+	switch {
+	case o.BeforeBlock > 0 && o.BeforeTimestamp > 0:
+		condition = "(block_number < @before_block OR block_timestamp < @before_timestamp)"
+		args["before_block"] = o.BeforeBlock
+		args["before_timestamp"] = o.BeforeTimestamp
+	case o.BeforeBlock > 0:
+		condition = "block_number < @before_block"
+		args["before_block"] = o.BeforeBlock
+	case o.BeforeTimestamp > 0:
+		condition = "block_timestamp < @before_timestamp"
+		args["before_timestamp"] = o.BeforeTimestamp
+	default:
+		return "", nil, fmt.Errorf("block number or block timestamp to prune before is required")
+	}
+
+	return condition, args, nil
+}
+
+// PruneLabels deletes labels, and raw transactions if requested, older than retention
+// of customer. Rows are deleted in block ranges of BatchLimit with sleep between batches.
+func (p *PostgreSQLpgx) PruneLabels(blockchain string, options PruneOptions) ([]PruneReport, error) {
+	condition, args, err := options.condition()
+	if err != nil {
+		return nil, err
+	}
+	if options.BatchLimit == 0 {
+		return nil, fmt.Errorf("batch limit should be positive")
+	}
+
+	tables := []string{LabelsTableName(blockchain)}
+	if options.RawTransactions {
+		tables = append(tables, CustomerDBTransactionsTableName(blockchain))
+	}
+
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	var reports []PruneReport
+	for _, table := range tables {
+		report := PruneReport{Table: table, DryRun: options.DryRun}
+
+		var minBlock, maxBlock *uint64
+		query := fmt.Sprintf("SELECT count(*), min(block_number), max(block_number) FROM %s WHERE %s", table, condition)
+		err := conn.QueryRow(context.Background(), query, args).Scan(&report.Rows, &minBlock, &maxBlock)
+		if err != nil {
+			return reports, err
+		}
+		if minBlock != nil && maxBlock != nil {
+			report.MinBlock = *minBlock
+			report.MaxBlock = *maxBlock
+		}
+
+		if options.DryRun || report.Rows == 0 {
+			reports = append(reports, report)
+			continue
+		}
+
+		logging.Chain(blockchain).Info("Starting deletion of labels", logging.TableKey, table, "rows", report.Rows, logging.FromBlockKey, report.MinBlock, logging.ToBlockKey, report.MaxBlock)
+
+		var deleted int64
+		for i := report.MinBlock; i <= report.MaxBlock; i += options.BatchLimit {
+			batchArgs := pgx.NamedArgs{"from_block": i, "to_block": i + options.BatchLimit}
+			for key, value := range args {
+				batchArgs[key] = value
+			}
+
+			commandTag, err := conn.Exec(context.Background(), fmt.Sprintf("DELETE FROM %s WHERE block_number >= @from_block AND block_number < @to_block AND %s", table, condition), batchArgs)
+			if err != nil {
+				report.Rows = deleted
+				reports = append(reports, report)
+				return reports, err
+			}
+			deleted += commandTag.RowsAffected()
+
+			logging.Chain(blockchain).Info("Deleted labels", logging.TableKey, table, "rows", commandTag.RowsAffected())
+
+			// sleep for a while to avoid overloading the database
+			time.Sleep(time.Duration(options.SleepTime) * time.Second)
+		}
+
+		report.Rows = deleted
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,212 @@
+package indexer
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// QueryTrace describes one SQL statement executed while request was served in debug mode.
+type QueryTrace struct {
+	SQL        string  `json:"sql"`
+	Args       []any   `json:"args"`
+	DurationMs float64 `json:"duration_ms"`
+	Rows       int64   `json:"rows"`
+	Error      string  `json:"error,omitempty"`
+
+	// Planner estimates, filled by ExplainQueries
+	EstimatedRows float64         `json:"estimated_rows"`
+	EstimatedCost float64         `json:"estimated_cost"`
+	Plan          json.RawMessage `json:"plan,omitempty"`
+	ExplainError  string          `json:"explain_error,omitempty"`
+}
+
+// QueryDebug collects statements executed with context returned by WithQueryDebug.
+type QueryDebug struct {
+	startedAt time.Time
+
+	mu      sync.Mutex
+	queries []QueryTrace
+}
+
+// QueryDebugReport is a timing breakdown with executed statements, other_ms is time spent
+// outside of database round trips: pool acquire, scanning and processing of rows.
+type QueryDebugReport struct {
+	TotalMs   float64      `json:"total_ms"`
+	QueriesMs float64      `json:"queries_ms"`
+	OtherMs   float64      `json:"other_ms"`
+	ExplainMs float64      `json:"explain_ms"`
+	Queries   []QueryTrace `json:"queries"`
+}
+
+type queryDebugContextKey struct{}
+
+type queryTraceContextKey struct{}
+
+type queryTraceStart struct {
+	sql       string
+	args      []any
+	startedAt time.Time
+}
+
+// WithQueryDebug returns context which records all statements executed with it.
+func WithQueryDebug(ctx context.Context) (context.Context, *QueryDebug) {
+	debug := &QueryDebug{startedAt: time.Now()}
+	return context.WithValue(ctx, queryDebugContextKey{}, debug), debug
+}
+
+// QueryDebugFrom returns collector attached to context, nil if request is not in debug mode.
+func QueryDebugFrom(ctx context.Context) *QueryDebug {
+	debug, _ := ctx.Value(queryDebugContextKey{}).(*QueryDebug)
+	return debug
+}
+
+// Queries returns copy of statements recorded so far.
+func (d *QueryDebug) Queries() []QueryTrace {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	queries := make([]QueryTrace, len(d.queries))
+	copy(queries, d.queries)
+	return queries
+}
+
+func (d *QueryDebug) record(trace QueryTrace) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.queries = append(d.queries, trace)
+}
+
+func durationMs(duration time.Duration) float64 {
+	return float64(duration.Microseconds()) / 1000
+}
+
+// queryDebugTracer is set on every connection of pool, it does nothing for contexts without
+// QueryDebug so regular requests are not affected.
+type queryDebugTracer struct{}
+
+func (queryDebugTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if QueryDebugFrom(ctx) == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, queryTraceContextKey{}, queryTraceStart{
+		sql:       data.SQL,
+		args:      data.Args,
+		startedAt: time.Now(),
+	})
+}
+
+func (queryDebugTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	debug := QueryDebugFrom(ctx)
+	start, ok := ctx.Value(queryTraceContextKey{}).(queryTraceStart)
+	if debug == nil || !ok {
+		return
+	}
+
+	trace := QueryTrace{
+		SQL:        start.sql,
+		DurationMs: durationMs(time.Since(start.startedAt)),
+		Rows:       data.CommandTag.RowsAffected(),
+		// Original arguments are kept to be able to explain the same statement later
+		Args: start.args,
+	}
+	if data.Err != nil {
+		trace.Error = data.Err.Error()
+	}
+
+	debug.record(trace)
+}
+
+// explainPlan is the part of EXPLAIN (FORMAT JSON) output with planner estimates.
+type explainPlan struct {
+	Plan struct {
+		PlanRows  float64 `json:"Plan Rows"`
+		TotalCost float64 `json:"Total Cost"`
+	} `json:"Plan"`
+}
+
+// ExplainQueries runs EXPLAIN for statements recorded by debug and returns report with
+// planner row estimates. Statements are explained on read pool with the same arguments, failed
+// explain is reported in query trace and does not fail the request.
+func (p *PostgreSQLpgx) ExplainQueries(ctx context.Context, debug *QueryDebug) QueryDebugReport {
+	finishedAt := time.Now()
+
+	report := QueryDebugReport{
+		TotalMs: durationMs(finishedAt.Sub(debug.startedAt)),
+		Queries: debug.Queries(),
+	}
+
+	// EXPLAIN statements itself should not be recorded
+	ctx = context.WithValue(ctx, queryDebugContextKey{}, (*QueryDebug)(nil))
+
+	pool := p.GetReadPool()
+	for i := range report.Queries {
+		query := &report.Queries[i]
+		report.QueriesMs += query.DurationMs
+
+		var planRaw []byte
+		explainErr := pool.QueryRow(ctx, "EXPLAIN (FORMAT JSON) "+query.SQL, query.Args...).Scan(&planRaw)
+		if explainErr != nil {
+			query.ExplainError = explainErr.Error()
+		} else {
+			var plans []explainPlan
+			if err := json.Unmarshal(planRaw, &plans); err != nil || len(plans) == 0 {
+				query.ExplainError = fmt.Sprintf("unable to parse plan: %v", err)
+			} else {
+				query.EstimatedRows = plans[0].Plan.PlanRows
+				query.EstimatedCost = plans[0].Plan.TotalCost
+			}
+			query.Plan = planRaw
+		}
+
+		query.Args = debugArgs(query.Args)
+	}
+
+	report.OtherMs = report.TotalMs - report.QueriesMs
+	if report.OtherMs < 0 {
+		report.OtherMs = 0
+	}
+	report.ExplainMs = durationMs(time.Since(finishedAt))
+
+	return report
+}
+
+// debugArgs converts query arguments to values readable in JSON, addresses and other byte
+// arrays are shown in the same form as in psql.
+func debugArgs(args []any) []any {
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = debugArg(arg)
+	}
+	return values
+}
+
+func debugArg(arg any) any {
+	switch v := arg.(type) {
+	case []byte:
+		return `\x` + hex.EncodeToString(v)
+	case [][]byte:
+		values := make([]any, len(v))
+		for i, value := range v {
+			values[i] = debugArg(value)
+		}
+		return values
+	case pgx.NamedArgs:
+		values := make(map[string]any, len(v))
+		for name, value := range v {
+			values[name] = debugArg(value)
+		}
+		return values
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return v
+	}
+}
@@ -0,0 +1,176 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/G7DAO/seer/logging"
+)
+
+// RawLabel is a fallback label written when decoding with ABI of job failed, LabelData
+// keeps raw payload under input_raw key together with ABI and error of the attempt.
+type RawLabel struct {
+	ID              string
+	LabelType       string
+	TransactionHash string
+	LogIndex        *uint64
+	BlockNumber     uint64
+	BlockHash       string
+	BlockTimestamp  uint64
+	Address         string
+	CallerAddress   string
+	OriginAddress   string
+	Selector        string
+	LabelData       map[string]interface{}
+}
+
+// RawLabelFailure is a repeated decoding failure, its ABI is stored in raw label so the
+// label is not attempted again until ABI of job changes.
+type RawLabelFailure struct {
+	ID    string
+	Abi   string
+	Error string
+}
+
+// RawLabelsPromotionReport summarizes promotion of raw labels in customer database.
+type RawLabelsPromotionReport struct {
+	CustomerID string `json:"customer_id"`
+	Checked    int    `json:"checked"`
+	Promoted   int    `json:"promoted"`
+	Failed     int    `json:"failed"`
+}
+
+// ReadRawLabels returns raw labels of address and selector which were not attempted with
+// abi yet, ordered by id and starting after afterID.
+func (p *PostgreSQLpgx) ReadRawLabels(blockchain, address, selector, abi, afterID string, limit int) ([]RawLabel, error) {
+	addressBytes, err := decodeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	query := fmt.Sprintf(`SELECT
+			id::text, label_type, transaction_hash, log_index, block_number, block_hash, block_timestamp,
+			'0x' || encode(address, 'hex'), '0x' || encode(caller_address, 'hex'), '0x' || encode(origin_address, 'hex'),
+			label_data
+		FROM %s
+		WHERE label = @label
+			AND address = @address
+			AND label_data->>'selector' = @selector
+			AND label_data->>'abi' IS DISTINCT FROM @abi
+			AND id::text > @after_id
+		ORDER BY id::text
+		LIMIT @limit`, LabelsTableName(blockchain))
+
+	rows, err := conn.Query(context.Background(), query, pgx.NamedArgs{
+		"label":    SeerCrawlerRawLabel,
+		"address":  addressBytes,
+		"selector": selector,
+		"abi":      abi,
+		"after_id": afterID,
+		"limit":    limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var labels []RawLabel
+	for rows.Next() {
+		var label RawLabel
+		var callerAddress, originAddress *string
+		var labelData []byte
+		if err := rows.Scan(&label.ID, &label.LabelType, &label.TransactionHash, &label.LogIndex, &label.BlockNumber, &label.BlockHash, &label.BlockTimestamp, &label.Address, &callerAddress, &originAddress, &labelData); err != nil {
+			return nil, err
+		}
+		if callerAddress != nil {
+			label.CallerAddress = *callerAddress
+		}
+		if originAddress != nil {
+			label.OriginAddress = *originAddress
+		}
+		if err := json.Unmarshal(labelData, &label.LabelData); err != nil {
+			return nil, fmt.Errorf("invalid label data of raw label %s: %w", label.ID, err)
+		}
+		label.Selector = selector
+
+		labels = append(labels, label)
+	}
+
+	return labels, rows.Err()
+}
+
+// PromoteRawLabels writes decoded labels and marks raw labels they were decoded from as
+// superseded, failures keep being raw with ABI and error of the last attempt.
+func (p *PostgreSQLpgx) PromoteRawLabels(blockchain string, txCalls []TransactionLabel, events []EventLabel, supersededIDs []string, failures []RawLabelFailure) error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	if err := applySessionSettings(context.Background(), tx, TableClassLabels); err != nil {
+		return err
+	}
+
+	if len(txCalls) > 0 {
+		if err := p.WriteTransactions(tx, blockchain, txCalls); err != nil {
+			return err
+		}
+	}
+
+	if len(events) > 0 {
+		if err := p.WriteEvents(tx, blockchain, events); err != nil {
+			return err
+		}
+	}
+
+	tableName := LabelsTableName(blockchain)
+
+	if len(supersededIDs) > 0 {
+		_, err := tx.Exec(context.Background(), fmt.Sprintf("UPDATE %s SET label = @label, label_data = label_data || jsonb_build_object('superseded_at', extract(epoch from now())::bigint) WHERE id = ANY(@ids::uuid[])", tableName), pgx.NamedArgs{
+			"label": SeerCrawlerSupersededLabel,
+			"ids":   supersededIDs,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, failure := range failures {
+		_, err := tx.Exec(context.Background(), fmt.Sprintf("UPDATE %s SET label_data = label_data || jsonb_build_object('abi', @abi::text, 'error', @error::text) WHERE id = @id::uuid", tableName), pgx.NamedArgs{
+			"id":    failure.ID,
+			"abi":   failure.Abi,
+			"error": failure.Error,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(context.Background()); err != nil {
+		return err
+	}
+
+	logging.Chain(blockchain).Info("Promoted raw labels", logging.TableKey, tableName, "promoted", len(supersededIDs), "failed", len(failures))
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package indexer
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// AbiJobStatusInactive marks jobs deactivated by reconcile, they are skipped by synchronizer.
+const AbiJobStatusInactive = "inactive"
+
+type AbiJobsReconcileEntry struct {
+	Selector string   `json:"selector"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type,omitempty"`
+	JobIDs   []string `json:"job_ids,omitempty"`
+}
+
+// AbiJobsReconcileReport is a difference between ABI file and existing abi_jobs of address
+// and customer. Created and Deactivated are applied changes, or planned ones in dry run.
+type AbiJobsReconcileReport struct {
+	Created     []AbiJobsReconcileEntry `json:"created"`
+	Reactivated []AbiJobsReconcileEntry `json:"reactivated"`
+	Duplicates  []AbiJobsReconcileEntry `json:"duplicates"`
+	Removed     []AbiJobsReconcileEntry `json:"removed"`
+	Deactivated bool                    `json:"deactivated"`
+	Unchanged   int                     `json:"unchanged"`
+	DryRun      bool                    `json:"dry_run"`
+}
+
+type existingAbiJob struct {
+	id       string
+	selector string
+	name     string
+	status   string
+}
+
+// ReconcileJobsFromAbi diffs ABI file against abi_jobs of address and customer at chain: jobs
+// for missing selectors are created, inactive ones are reactivated, selectors with several jobs
+// are reported as duplicates and jobs absent in file are deactivated if deactivateRemoved is set.
+func (p *PostgreSQLpgx) ReconcileJobsFromAbi(chain, address, abiFile, customerID, userID string, deployBlock uint64, deactivateRemoved, dryRun, allowAnonymous bool) (*AbiJobsReconcileReport, error) {
+	fileJobs, err := readAbiFileJobs(abiFile, allowAnonymous)
+	if err != nil {
+		return nil, err
+	}
+
+	addressBytes, err := decodeJobAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(context.Background())
+
+	if err := lockAbiJobsOfAddress(tx, chain, addressBytes, customerID); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(context.Background(), "SELECT id, abi_selector, abi_name, status FROM abi_jobs WHERE chain = @chain AND address IS NOT DISTINCT FROM @address AND customer_id = @customer_id ORDER BY created_at FOR UPDATE", pgx.NamedArgs{
+		"chain":       chain,
+		"address":     addressBytes,
+		"customer_id": customerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string][]existingAbiJob)
+	for rows.Next() {
+		var job existingAbiJob
+		if err := rows.Scan(&job.id, &job.selector, &job.name, &job.status); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		existing[job.selector] = append(existing[job.selector], job)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	report := &AbiJobsReconcileReport{DryRun: dryRun, Deactivated: deactivateRemoved}
+
+	inFile := make(map[string]bool)
+	for _, fileJob := range fileJobs {
+		if inFile[fileJob.Selector] {
+			continue
+		}
+		inFile[fileJob.Selector] = true
+
+		jobs := existing[fileJob.Selector]
+		entry := AbiJobsReconcileEntry{Selector: fileJob.Selector, Name: fileJob.Name, Type: fileJob.Type}
+		for _, job := range jobs {
+			entry.JobIDs = append(entry.JobIDs, job.id)
+		}
+
+		switch {
+		case len(jobs) == 0:
+			if !dryRun {
+				jobID := uuid.New()
+				_, err := tx.Exec(context.Background(), "INSERT INTO abi_jobs (id, address, user_id, customer_id, abi_selector, chain, abi_name, status, historical_crawl_status, progress, moonworm_task_pickedup, abi, deployment_block_number, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, now(), now())", jobID, addressBytes, userID, customerID, fileJob.Selector, chain, fileJob.Name, "true", "pending", 0, false, fileJob.Abi, jobDeployBlock(addressBytes, deployBlock))
+				if err != nil {
+					return nil, err
+				}
+				entry.JobIDs = []string{jobID.String()}
+			}
+			report.Created = append(report.Created, entry)
+		case len(jobs) > 1:
+			report.Duplicates = append(report.Duplicates, entry)
+		case jobs[0].status == AbiJobStatusInactive:
+			if !dryRun {
+				_, err := tx.Exec(context.Background(), "UPDATE abi_jobs SET status = 'active', updated_at = now() WHERE id = $1", jobs[0].id)
+				if err != nil {
+					return nil, err
+				}
+			}
+			report.Reactivated = append(report.Reactivated, entry)
+		default:
+			report.Unchanged++
+		}
+	}
+
+	var removedIDs []string
+	for selector, jobs := range existing {
+		if inFile[selector] {
+			continue
+		}
+
+		entry := AbiJobsReconcileEntry{Selector: selector, Name: jobs[0].name}
+		for _, job := range jobs {
+			if job.status == AbiJobStatusInactive {
+				continue
+			}
+			entry.JobIDs = append(entry.JobIDs, job.id)
+			removedIDs = append(removedIDs, job.id)
+		}
+		if len(entry.JobIDs) > 0 {
+			report.Removed = append(report.Removed, entry)
+		}
+	}
+	sort.Slice(report.Removed, func(i, j int) bool {
+		return report.Removed[i].Selector < report.Removed[j].Selector
+	})
+
+	if deactivateRemoved && !dryRun && len(removedIDs) > 0 {
+		_, err := tx.Exec(context.Background(), "UPDATE abi_jobs SET status = @status, updated_at = now() WHERE id = ANY(@ids::uuid[])", pgx.NamedArgs{
+			"status": AbiJobStatusInactive,
+			"ids":    removedIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if dryRun {
+		return report, nil
+	}
+
+	if err := tx.Commit(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
@@ -0,0 +1,253 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/G7DAO/seer/logging"
+)
+
+// Customer databases could be shared by several customers. In that case labels tables have
+// customer_id column filled from session setting of seer connection, and row-level security
+// policies restrict seer writer to rows of customer it connected for and customer roles to
+// their own rows.
+const (
+	CustomerIDSetting      = "seer.customer_id"
+	CustomerRolesTableName = "seer_customer_roles"
+
+	customerRolePrefix = "seer_customer_"
+	writerPolicyName   = "seer_writer"
+	readerPolicyName   = "seer_customer_read"
+)
+
+var customerRoleRe = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// CustomerRoleName returns name of role template of customer in shared database.
+func CustomerRoleName(customerID string) string {
+	return customerRolePrefix + customerRoleRe.ReplaceAllString(strings.ToLower(customerID), "_")
+}
+
+// NewCustomerPostgreSQLpgx connects to customer database and sets customer ID for each session,
+// so rows written to shared database belong to the customer. For dedicated databases setting
+// is not used by anything.
+func NewCustomerPostgreSQLpgx(uri, customerID string) (*PostgreSQLpgx, error) {
+	if customerID == "" {
+		return NewPostgreSQLpgxWithCustomURI(uri)
+	}
+
+	config, err := pgxpool.ParseConfig(uri)
+	if err != nil {
+		slog.Error("Unable to parse database config", logging.CustomerKey, customerID, logging.ErrorKey, err)
+		return nil, err
+	}
+	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		_, err := conn.Exec(ctx, "SELECT set_config($1, $2, false)", CustomerIDSetting, customerID)
+		return err
+	}
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), config)
+	if err != nil {
+		slog.Error("Unable to create database pool", logging.CustomerKey, customerID, logging.ErrorKey, err)
+		return nil, err
+	}
+
+	return &PostgreSQLpgx{
+		pool: pool,
+	}, nil
+}
+
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// SharedDatabaseTables returns customer database tables of blockchain protected by policies.
+func SharedDatabaseTables(blockchain string) []string {
+	return []string{LabelsTableName(blockchain), CustomerDBTransactionsTableName(blockchain)}
+}
+
+// SharedDatabaseMigration generates statements which turn tables of customer database into
+// shared ones. Statements are idempotent, so migration could be re-run when new blockchain
+// tables appear. Rows existing before migration are assigned to ownerCustomerID if it is set,
+// otherwise they stay invisible for customer roles.
+func SharedDatabaseMigration(tables []string, writerRole, ownerCustomerID string) []string {
+	customerSetting := fmt.Sprintf("current_setting(%s, true)", quoteLiteral(CustomerIDSetting))
+	writer := pgx.Identifier{writerRole}.Sanitize()
+
+	statements := []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			role_name VARCHAR PRIMARY KEY,
+			customer_id VARCHAR NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+		)`, CustomerRolesTableName),
+	}
+
+	for _, table := range tables {
+		statements = append(statements,
+			fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS customer_id VARCHAR DEFAULT %s", table, customerSetting),
+		)
+		if ownerCustomerID != "" {
+			statements = append(statements, fmt.Sprintf("UPDATE %s SET customer_id = %s WHERE customer_id IS NULL", table, quoteLiteral(ownerCustomerID)))
+		}
+
+		// Unique indexes of dedicated database would drop labels of second customer indexing
+		// the same contract, they are recreated with customer_id as leading column
+		statements = append(statements,
+			fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_customer_id_idx ON %s (customer_id, block_number)", table, table),
+			fmt.Sprintf(`DO $$
+			DECLARE
+				idx record;
+			BEGIN
+				FOR idx IN
+					SELECT n.nspname AS schema_name, ic.relname AS name, pg_get_indexdef(i.indexrelid) AS def
+					FROM pg_index i
+					JOIN pg_class ic ON ic.oid = i.indexrelid
+					JOIN pg_namespace n ON n.oid = ic.relnamespace
+					WHERE i.indrelid = %s::regclass
+						AND i.indisunique
+						AND NOT i.indisprimary
+						AND NOT EXISTS (SELECT 1 FROM pg_constraint c WHERE c.conindid = i.indexrelid)
+						AND NOT EXISTS (SELECT 1 FROM pg_attribute a WHERE a.attrelid = i.indrelid AND a.attname = 'customer_id' AND a.attnum = ANY(i.indkey))
+				LOOP
+					EXECUTE replace(
+						replace(idx.def, ' INDEX ' || quote_ident(idx.name) || ' ON ', ' INDEX ' || quote_ident(idx.name || '_customer') || ' ON '),
+						' USING btree (', ' USING btree (customer_id, ');
+					EXECUTE format('DROP INDEX %%I.%%I', idx.schema_name, idx.name);
+				END LOOP;
+			END $$`, quoteLiteral(table)),
+			fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY", table),
+			fmt.Sprintf("ALTER TABLE %s FORCE ROW LEVEL SECURITY", table),
+			fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s", writerPolicyName, table),
+			fmt.Sprintf("CREATE POLICY %s ON %s FOR ALL TO %s USING (customer_id = %s) WITH CHECK (customer_id = %s)", writerPolicyName, table, writer, customerSetting, customerSetting),
+			fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s", readerPolicyName, table),
+			fmt.Sprintf("CREATE POLICY %s ON %s FOR SELECT USING (customer_id IN (SELECT customer_id FROM %s WHERE pg_has_role(current_user, role_name::name, 'MEMBER')))", readerPolicyName, table, CustomerRolesTableName),
+		)
+	}
+
+	return statements
+}
+
+// CustomerRoleTemplate generates statements creating role of customer with read access to its
+// rows in shared database. Role is created without login, users of customer are granted
+// membership in it.
+func CustomerRoleTemplate(tables []string, customerID string) []string {
+	roleName := CustomerRoleName(customerID)
+	role := pgx.Identifier{roleName}.Sanitize()
+
+	statements := []string{
+		fmt.Sprintf(`DO $$
+		BEGIN
+			IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = %s) THEN
+				CREATE ROLE %s NOLOGIN;
+			END IF;
+		END $$`, quoteLiteral(roleName), role),
+		fmt.Sprintf("INSERT INTO %s (role_name, customer_id) VALUES (%s, %s) ON CONFLICT (role_name) DO UPDATE SET customer_id = EXCLUDED.customer_id", CustomerRolesTableName, quoteLiteral(roleName), quoteLiteral(customerID)),
+		fmt.Sprintf("GRANT SELECT ON %s TO %s", CustomerRolesTableName, role),
+	}
+	for _, table := range tables {
+		statements = append(statements, fmt.Sprintf("GRANT SELECT ON %s TO %s", table, role))
+	}
+
+	return statements
+}
+
+// existingTables filters out tables which are not created in database yet.
+func (p *PostgreSQLpgx) existingTables(ctx context.Context, tables []string) ([]string, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	var existing []string
+	for _, table := range tables {
+		var exists bool
+		if err := conn.QueryRow(ctx, "SELECT to_regclass($1) IS NOT NULL", table).Scan(&exists); err != nil {
+			return nil, err
+		}
+		if !exists {
+			slog.Info("Table does not exist, skipping it", logging.TableKey, table)
+			continue
+		}
+		existing = append(existing, table)
+	}
+
+	return existing, nil
+}
+
+// execStatements runs statements in one transaction, in dry run they are only returned.
+func (p *PostgreSQLpgx) execStatements(ctx context.Context, statements []string, dryRun bool) ([]string, error) {
+	if dryRun {
+		return statements, nil
+	}
+
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, statement := range statements {
+		if _, err := tx.Exec(ctx, statement); err != nil {
+			return nil, fmt.Errorf("failed to execute %q: %w", statement, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return statements, nil
+}
+
+// ApplySharedDatabaseMigration enables row-level security on labels and raw transactions tables
+// of blockchains in customer database. It returns executed statements.
+func (p *PostgreSQLpgx) ApplySharedDatabaseMigration(blockchains []string, writerRole, ownerCustomerID string, dryRun bool) ([]string, error) {
+	ctx := context.Background()
+
+	var tables []string
+	for _, blockchain := range blockchains {
+		tables = append(tables, SharedDatabaseTables(blockchain)...)
+	}
+	tables, err := p.existingTables(ctx, tables)
+	if err != nil {
+		return nil, err
+	}
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("no labels tables of blockchains %s in database", strings.Join(blockchains, ", "))
+	}
+
+	return p.execStatements(ctx, SharedDatabaseMigration(tables, writerRole, ownerCustomerID), dryRun)
+}
+
+// CreateCustomerRole creates role of customer in shared database from template. It returns
+// executed statements.
+func (p *PostgreSQLpgx) CreateCustomerRole(blockchains []string, customerID string, dryRun bool) ([]string, error) {
+	ctx := context.Background()
+
+	var tables []string
+	for _, blockchain := range blockchains {
+		tables = append(tables, SharedDatabaseTables(blockchain)...)
+	}
+	tables, err := p.existingTables(ctx, tables)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.execStatements(ctx, CustomerRoleTemplate(tables, customerID), dryRun)
+}
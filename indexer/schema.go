@@ -0,0 +1,224 @@
+package indexer
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema is a plain representation of JSON Schema document.
+type JSONSchema map[string]interface{}
+
+var (
+	hexAddressSchema = JSONSchema{"type": "string", "pattern": "^0x[0-9a-fA-F]{40}$"}
+	hexHashSchema    = JSONSchema{"type": "string", "pattern": "^0x[0-9a-fA-F]{64}$"}
+)
+
+// StructJSONSchema generates schema for struct based on its json tags.
+func StructJSONSchema(title string, v interface{}) JSONSchema {
+	schema := reflectTypeSchema(reflect.TypeOf(v))
+	schema["$schema"] = JSONSchemaDraft
+	schema["title"] = title
+
+	return schema
+}
+
+func reflectTypeSchema(t reflect.Type) JSONSchema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return reflectTypeSchema(t.Elem())
+	case reflect.Bool:
+		return JSONSchema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return JSONSchema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return JSONSchema{"type": "number"}
+	case reflect.String:
+		return JSONSchema{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return JSONSchema{"type": "array", "items": reflectTypeSchema(t.Elem())}
+	case reflect.Struct:
+		properties := JSONSchema{}
+		var required []string
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			name := field.Name
+			omitEmpty := false
+			if tag, ok := field.Tag.Lookup("json"); ok {
+				tagParts := strings.Split(tag, ",")
+				if tagParts[0] == "-" {
+					continue
+				}
+				if tagParts[0] != "" {
+					name = tagParts[0]
+				}
+				for _, opt := range tagParts[1:] {
+					if opt == "omitempty" {
+						omitEmpty = true
+					}
+				}
+			}
+
+			properties[name] = reflectTypeSchema(field.Type)
+			if !omitEmpty && field.Type.Kind() != reflect.Ptr {
+				required = append(required, name)
+			}
+		}
+
+		schema := JSONSchema{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	default:
+		return JSONSchema{}
+	}
+}
+
+// LabelsJSONSchemas returns schemas of records seer writes into customer databases.
+func LabelsJSONSchemas() map[string]JSONSchema {
+	return map[string]JSONSchema{
+		"EventLabel":       StructJSONSchema("EventLabel", EventLabel{}),
+		"TransactionLabel": StructJSONSchema("TransactionLabel", TransactionLabel{}),
+		"RawTransaction":   StructJSONSchema("RawTransaction", RawTransaction{}),
+	}
+}
+
+// TupleFieldName returns key of i-th component of tuple in label data, unnamed components are
+// keyed by their position.
+func TupleFieldName(t abi.Type, i int) string {
+	if name := t.TupleRawNames[i]; name != "" {
+		return name
+	}
+	return fmt.Sprintf("field%d", i)
+}
+
+// hexBytesSchema is schema of bytes written as lowercase hex string, size is -1 for dynamic bytes.
+func hexBytesSchema(size int) JSONSchema {
+	if size < 0 {
+		return JSONSchema{"type": "string", "pattern": "^0x([0-9a-f]{2})*$"}
+	}
+	return JSONSchema{"type": "string", "pattern": fmt.Sprintf("^0x[0-9a-f]{%d}$", size*2)}
+}
+
+// abiTypeSchema maps ABI type to the shape it takes after decoding and JSON marshaling.
+func abiTypeSchema(t abi.Type) JSONSchema {
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		return JSONSchema{"type": "integer"}
+	case abi.BoolTy:
+		return JSONSchema{"type": "boolean"}
+	case abi.StringTy:
+		return JSONSchema{"type": "string"}
+	case abi.AddressTy:
+		return hexAddressSchema
+	case abi.HashTy:
+		return hexHashSchema
+	case abi.BytesTy:
+		return hexBytesSchema(-1)
+	case abi.FixedBytesTy:
+		return hexBytesSchema(t.Size)
+	case abi.FunctionTy:
+		// Address of contract followed by selector of its method
+		return hexBytesSchema(24)
+	case abi.SliceTy:
+		return JSONSchema{"type": "array", "items": abiTypeSchema(*t.Elem)}
+	case abi.ArrayTy:
+		return JSONSchema{"type": "array", "items": abiTypeSchema(*t.Elem), "minItems": t.Size, "maxItems": t.Size}
+	case abi.TupleTy:
+		properties := JSONSchema{}
+		required := make([]string, len(t.TupleElems))
+		for i, elem := range t.TupleElems {
+			required[i] = TupleFieldName(t, i)
+			properties[required[i]] = abiTypeSchema(*elem)
+		}
+		return JSONSchema{"type": "object", "properties": properties, "required": required}
+	default:
+		return JSONSchema{}
+	}
+}
+
+func abiArgumentsSchema(arguments abi.Arguments, isEvent bool) JSONSchema {
+	properties := JSONSchema{}
+	required := []string{}
+	for _, arg := range arguments {
+		argSchema := abiTypeSchema(arg.Type)
+		// Indexed dynamic event arguments are stored in topics as keccak256 hash only
+		if isEvent && arg.Indexed {
+			switch arg.Type.T {
+			case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+				argSchema = hexHashSchema
+			}
+		}
+		properties[arg.Name] = argSchema
+		required = append(required, arg.Name)
+	}
+
+	return JSONSchema{"type": "object", "properties": properties, "required": required}
+}
+
+// AbiLabelDataJSONSchemas generates schemas of label_data for each event and method of ABI,
+// keys are in format "<label_type>:<name>".
+func AbiLabelDataJSONSchemas(abiString string) (map[string]JSONSchema, error) {
+	abiObj, err := abi.JSON(strings.NewReader(abiString))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+
+	schemas := make(map[string]JSONSchema)
+
+	eventNames := make([]string, 0, len(abiObj.Events))
+	for name := range abiObj.Events {
+		eventNames = append(eventNames, name)
+	}
+	sort.Strings(eventNames)
+
+	for _, name := range eventNames {
+		event := abiObj.Events[name]
+		schemas["event:"+name] = JSONSchema{
+			"$schema": JSONSchemaDraft,
+			"title":   fmt.Sprintf("%s event label_data", event.RawName),
+			"type":    "object",
+			"properties": JSONSchema{
+				"type": JSONSchema{"const": "event"},
+				"name": JSONSchema{"const": event.RawName},
+				"args": abiArgumentsSchema(event.Inputs, true),
+			},
+			"required": []string{"type", "name", "args"},
+		}
+	}
+
+	methodNames := make([]string, 0, len(abiObj.Methods))
+	for name := range abiObj.Methods {
+		methodNames = append(methodNames, name)
+	}
+	sort.Strings(methodNames)
+
+	for _, name := range methodNames {
+		method := abiObj.Methods[name]
+		schemas["tx_call:"+name] = JSONSchema{
+			"$schema": JSONSchemaDraft,
+			"title":   fmt.Sprintf("%s transaction call label_data", method.RawName),
+			"type":    "object",
+			"properties": JSONSchema{
+				"type":     JSONSchema{"const": "tx_call"},
+				"gas_used": JSONSchema{"type": "integer"},
+				"status":   JSONSchema{"type": "integer", "enum": []int{0, 1}},
+				"args":     abiArgumentsSchema(method.Inputs, false),
+			},
+			"required": []string{"type", "args"},
+		}
+	}
+
+	return schemas, nil
+}
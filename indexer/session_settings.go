@@ -0,0 +1,86 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Classes of tables with their own session settings of write transactions.
+const (
+	TableClassIndexes = "indexes"
+	TableClassLabels  = "labels"
+)
+
+// Parameters allowed to be changed for write transactions, all of them are safe to set
+// at transaction level and do not require superuser.
+var allowedSessionSettings = map[string]bool{
+	"synchronous_commit":                  true,
+	"work_mem":                            true,
+	"maintenance_work_mem":                true,
+	"temp_buffers":                        true,
+	"statement_timeout":                   true,
+	"lock_timeout":                        true,
+	"idle_in_transaction_session_timeout": true,
+}
+
+var sessionSettingValueRe = regexp.MustCompile(`^[A-Za-z0-9_.]+$`)
+
+// SessionSetting is a Postgres parameter applied with SET LOCAL semantics, so it lasts
+// only until the end of write transaction.
+type SessionSetting struct {
+	Name  string
+	Value string
+}
+
+// ParseSessionSettings parses session settings configuration in format
+// "<class>:<name>=<value>,<name>=<value>;<class>:...", e.g.
+// "indexes:synchronous_commit=off,work_mem=256MB;labels:statement_timeout=60s".
+func ParseSessionSettings(raw string) (map[string][]SessionSetting, error) {
+	settings := make(map[string][]SessionSetting)
+	for _, entry := range strings.Split(raw, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		class, spec, found := strings.Cut(entry, ":")
+		class = strings.TrimSpace(class)
+		if !found || (class != TableClassIndexes && class != TableClassLabels) {
+			return nil, fmt.Errorf("invalid session settings %q, expected %s:<settings> or %s:<settings>", entry, TableClassIndexes, TableClassLabels)
+		}
+
+		for _, pair := range strings.Split(spec, ",") {
+			name, value, found := strings.Cut(pair, "=")
+			name = strings.ToLower(strings.TrimSpace(name))
+			value = strings.TrimSpace(value)
+			if !found || name == "" || value == "" {
+				return nil, fmt.Errorf("invalid session setting %q of class %s, expected <name>=<value>", pair, class)
+			}
+			if !allowedSessionSettings[name] {
+				return nil, fmt.Errorf("session setting %s is not allowed", name)
+			}
+			if !sessionSettingValueRe.MatchString(value) {
+				return nil, fmt.Errorf("invalid value %q of session setting %s", value, name)
+			}
+
+			settings[class] = append(settings[class], SessionSetting{Name: name, Value: value})
+		}
+	}
+
+	return settings, nil
+}
+
+// applySessionSettings sets configured parameters of table class for current transaction.
+func applySessionSettings(ctx context.Context, tx pgx.Tx, class string) error {
+	for _, setting := range SessionSettingsConfig[class] {
+		if _, err := tx.Exec(ctx, "SELECT set_config($1, $2, true)", setting.Name, setting.Value); err != nil {
+			return fmt.Errorf("failed to set %s=%s for %s write transaction: %w", setting.Name, setting.Value, class, err)
+		}
+	}
+
+	return nil
+}
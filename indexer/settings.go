@@ -10,7 +10,17 @@ var (
 	InsertMaxParametersPerBatch  = 65535
 	SeerCrawlerLabel             string
 	MOONSTREAM_DB_V3_INDEXES_URI string
-	SeerCrawlerRawLabel          string
+
+	// Optional read replica of index database for analytics queries
+	MOONSTREAM_DB_V3_INDEXES_READ_REPLICA_URI string
+	SeerCrawlerRawLabel                       string
+	SeerCrawlerSupersededLabel                string
+
+	// Explicit ON CONFLICT targets per table, see ParseConflictTargets for format
+	ConflictTargetsConfig map[string]ConflictTarget
+
+	// Session settings of write transactions per table class, see ParseSessionSettings for format
+	SessionSettingsConfig map[string][]SessionSetting
 )
 
 func CheckVariablesForIndexer() error {
@@ -20,11 +30,30 @@ func CheckVariablesForIndexer() error {
 	}
 
 	SeerCrawlerRawLabel = SeerCrawlerLabel + "-raw"
+	SeerCrawlerSupersededLabel = SeerCrawlerLabel + "-superseded"
 
 	MOONSTREAM_DB_V3_INDEXES_URI = os.Getenv("MOONSTREAM_DB_V3_INDEXES_URI")
 	if MOONSTREAM_DB_V3_INDEXES_URI == "" {
 		return fmt.Errorf("MOONSTREAM_DB_V3_INDEXES_URI environment variable is required")
 	}
 
+	MOONSTREAM_DB_V3_INDEXES_READ_REPLICA_URI = os.Getenv("MOONSTREAM_DB_V3_INDEXES_READ_REPLICA_URI")
+
+	var conflictTargetsErr error
+	ConflictTargetsConfig, conflictTargetsErr = ParseConflictTargets(os.Getenv("SEER_INDEXER_CONFLICT_TARGETS"))
+	if conflictTargetsErr != nil {
+		return fmt.Errorf("invalid SEER_INDEXER_CONFLICT_TARGETS environment variable: %v", conflictTargetsErr)
+	}
+
+	var sessionSettingsErr error
+	SessionSettingsConfig, sessionSettingsErr = ParseSessionSettings(os.Getenv("SEER_INDEXER_SESSION_SETTINGS"))
+	if sessionSettingsErr != nil {
+		return fmt.Errorf("invalid SEER_INDEXER_SESSION_SETTINGS environment variable: %v", sessionSettingsErr)
+	}
+
+	if _, localTablePrefixErr := LocalTablePrefix(); localTablePrefixErr != nil {
+		return localTablePrefixErr
+	}
+
 	return nil
 }
@@ -0,0 +1,67 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const StorageReplicationStatusTableName = "storage_replication_status"
+
+// EnsureStorageReplicationStatusTable creates table with replication status of batches
+// per replica region if it does not exist.
+func (p *PostgreSQLpgx) EnsureStorageReplicationStatusTable() error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		key TEXT NOT NULL,
+		region VARCHAR(128) NOT NULL,
+		status VARCHAR(32) NOT NULL,
+		error TEXT NOT NULL DEFAULT '',
+		attempts INTEGER NOT NULL DEFAULT 0,
+		created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+		updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+		PRIMARY KEY (key, region)
+	)`, StorageReplicationStatusTableName))
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_status_idx ON %s (status, updated_at)", StorageReplicationStatusTableName, StorageReplicationStatusTableName))
+
+	return err
+}
+
+// SetReplicationStatus records status of batch replication to region, attempts are
+// counted for every final status.
+func (p *PostgreSQLpgx) SetReplicationStatus(key, region, status, errMsg string) error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf(`INSERT INTO %s (key, region, status, error, attempts)
+		VALUES (@key, @region, @status, @error, CASE WHEN @status = 'pending' THEN 0 ELSE 1 END)
+		ON CONFLICT (key, region) DO UPDATE SET
+			status = EXCLUDED.status,
+			error = EXCLUDED.error,
+			attempts = %s.attempts + EXCLUDED.attempts,
+			updated_at = now()`, StorageReplicationStatusTableName, StorageReplicationStatusTableName), pgx.NamedArgs{
+		"key":    key,
+		"region": region,
+		"status": status,
+		"error":  errMsg,
+	})
+
+	return err
+}
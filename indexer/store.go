@@ -0,0 +1,39 @@
+package indexer
+
+// IndexWriter is a set of blocks index operations crawler depends on.
+type IndexWriter interface {
+	WriteIndexes(blockchain string, blocksIndexPack []BlockIndex) error
+	GetLatestDBBlockNumber(blockchain string, reverse ...bool) (uint64, error)
+	ReadIndexedBatches(blockchain string, fromBlock uint64) ([]IndexedBatch, error)
+	DeleteBlockIndexRange(blockchain string, fromBlock, toBlock uint64) (int64, error)
+	ReadUpdates(blockchain string, fromBlock uint64, customerIds []string, minBlocksToSync int) (uint64, uint64, []string, []CustomerUpdates, error)
+	RetrievePathsAndBlockBounds(blockchain string, blockNumber uint64, minBlocksToSync int) ([]string, uint64, uint64, error)
+}
+
+// JobStore is a set of ABI jobs operations, chain clients look up and fill deployment blocks
+// of jobs through it.
+type JobStore interface {
+	ReadABIJobs(blockchain string) ([]AbiJob, error)
+	SelectAbiJobs(blockchain string, addresses []string, customersIds []string, autoJobs, isDeployBlockNotNull bool, abiTypes []string) ([]AbiJob, error)
+	UpdateAbiJobsStatus(blockchain string) error
+	UpdateAbisAsDone(ids []string) error
+	UpdateAbisProgress(ids []string, process int) error
+	AppendAbisProgress(progresses []AbiJobProgress) error
+	GetAbiJobsWithoutDeployBlocks(blockchain string) (map[string]map[string][]string, error)
+	UpdateAbiJobsDeployBlock(blockNumber uint64, ids []string) error
+}
+
+// IndexStore is a set of index database operations synchronizer depends on.
+// PostgreSQLpgx is the production implementation, MemoryStore keeps everything in process.
+type IndexStore interface {
+	IndexWriter
+	JobStore
+}
+
+var (
+	_ IndexStore = (*PostgreSQLpgx)(nil)
+	_ IndexStore = (*MemoryStore)(nil)
+
+	// MemoryStore is also a customer database for tests of synchronizer
+	_ CustomerLabelsDB = (*MemoryStore)(nil)
+)
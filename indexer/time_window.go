@@ -0,0 +1,82 @@
+package indexer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// GetBlockRangeByTimestamps converts unix time window to range of blocks using blocks index,
+// zero timestamp means the window is not limited from that side and zero block is returned.
+func (p *PostgreSQLpgx) GetBlockRangeByTimestamps(ctx context.Context, blockchain string, fromTimestamp, toTimestamp uint64) (uint64, uint64, error) {
+	if fromTimestamp > 0 && toTimestamp > 0 && fromTimestamp > toTimestamp {
+		return 0, 0, fmt.Errorf("from timestamp %d is greater than to timestamp %d", fromTimestamp, toTimestamp)
+	}
+
+	blocksTableName, blocksTableErr := BlocksTableName(blockchain)
+	if blocksTableErr != nil {
+		return 0, 0, blocksTableErr
+	}
+
+	pool := p.GetReadPool()
+
+	conn, acquireErr := pool.Acquire(ctx)
+	if acquireErr != nil {
+		return 0, 0, acquireErr
+	}
+	defer conn.Release()
+
+	var fromBlock, toBlock uint64
+
+	if fromTimestamp > 0 {
+		var blockNumber sql.NullInt64
+		query := fmt.Sprintf("SELECT min(block_number) FROM %s WHERE block_timestamp >= $1", blocksTableName)
+		if err := conn.QueryRow(ctx, query, fromTimestamp).Scan(&blockNumber); err != nil {
+			return 0, 0, err
+		}
+		if !blockNumber.Valid {
+			return 0, 0, ErrNoRowsIndexed
+		}
+		fromBlock = uint64(blockNumber.Int64)
+	}
+
+	if toTimestamp > 0 {
+		var blockNumber sql.NullInt64
+		query := fmt.Sprintf("SELECT max(block_number) FROM %s WHERE block_timestamp <= $1", blocksTableName)
+		if err := conn.QueryRow(ctx, query, toTimestamp).Scan(&blockNumber); err != nil {
+			return 0, 0, err
+		}
+		if !blockNumber.Valid {
+			return 0, 0, ErrNoRowsIndexed
+		}
+		toBlock = uint64(blockNumber.Int64)
+	}
+
+	if toTimestamp > 0 && fromBlock > toBlock {
+		return 0, 0, ErrNoRowsIndexed
+	}
+
+	return fromBlock, toBlock, nil
+}
+
+// GetTransactionsVolumeInTimeWindow calculates volume between address pair for transactions
+// executed between from and to unix timestamps.
+func (p *PostgreSQLpgx) GetTransactionsVolumeInTimeWindow(ctx context.Context, blockchain, fromAddress, toAddress string, limit int, fromTimestamp, toTimestamp uint64, isBidirectional bool) (*TransactionsVolume, error) {
+	fromBlock, toBlock, rangeErr := p.GetBlockRangeByTimestamps(ctx, blockchain, fromTimestamp, toTimestamp)
+	if rangeErr != nil {
+		return nil, rangeErr
+	}
+
+	return p.getTransactionsVolumeInRange(ctx, blockchain, fromAddress, toAddress, limit, fromBlock, toBlock, isBidirectional)
+}
+
+// GetTransactionsInTimeWindow fetches transactions of source addresses executed between from
+// and to unix timestamps.
+func (p *PostgreSQLpgx) GetTransactionsInTimeWindow(ctx context.Context, blockchain string, sourceAddress []string, limit int, fromTimestamp, toTimestamp uint64, toAddrDistinct bool) ([]Transaction, error) {
+	fromBlock, toBlock, rangeErr := p.GetBlockRangeByTimestamps(ctx, blockchain, fromTimestamp, toTimestamp)
+	if rangeErr != nil {
+		return nil, rangeErr
+	}
+
+	return p.getTransactionsInRange(ctx, blockchain, sourceAddress, limit, fromBlock, toBlock, toAddrDistinct)
+}
@@ -0,0 +1,167 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// TokenTransfersVolume is an aggregation of Transfer events of one token contract between address pair.
+type TokenTransfersVolume struct {
+	TokenAddress   string   `json:"token_address"`
+	TokenStandard  string   `json:"token_standard"`
+	FromAddress    string   `json:"from_address"`
+	ToAddress      string   `json:"to_address"`
+	MinBlockNumber uint64   `json:"min_block_number"`
+	MaxBlockNumber uint64   `json:"max_block_number"`
+	Volume         *big.Int `json:"volume"`
+	TransfersCount uint64   `json:"transfers_count"`
+}
+
+// TokenTransfersFilter describes selection of Transfer event labels for volume aggregation,
+// empty fields are not applied.
+type TokenTransfersFilter struct {
+	Label           string
+	TokenAddresses  []string
+	FromAddress     string
+	ToAddress       string
+	IsBidirectional bool
+	LowestBlockNum  uint64
+	Limit           int
+}
+
+// Transfer volume expression per label:
+// - ERC20 Transfer(from, to, value) sums value
+// - ERC721 Transfer(from, to, tokenId) counts each token as 1
+// - ERC1155 TransferSingle(operator, from, to, id, value) sums value
+// - ERC1155 TransferBatch(operator, from, to, ids, values) sums all values
+const tokenTransferVolumeExpr = `CASE
+		WHEN label_name = 'TransferBatch' THEN (SELECT COALESCE(sum(v::numeric), 0) FROM jsonb_array_elements_text(label_data->'args'->'values') AS v)
+		WHEN label_data->'args' ? 'value' THEN (label_data->'args'->>'value')::numeric
+		ELSE 1
+	END`
+
+const tokenStandardExpr = `CASE
+		WHEN label_name IN ('TransferSingle', 'TransferBatch') THEN 'erc1155'
+		WHEN label_data->'args' ? 'tokenId' THEN 'erc721'
+		ELSE 'erc20'
+	END`
+
+// GetTokenTransfersVolume aggregates decoded Transfer, TransferSingle and TransferBatch event labels
+// by token contract, token standard and from-to address pair.
+func (p *PostgreSQLpgx) GetTokenTransfersVolume(ctx context.Context, blockchain string, filter TokenTransfersFilter) ([]TokenTransfersVolume, error) {
+	label := filter.Label
+	if label == "" {
+		label = SeerCrawlerLabel
+	}
+
+	conditions := []string{
+		"label = @label",
+		"label_type = 'event'",
+		"label_name IN ('Transfer', 'TransferSingle', 'TransferBatch')",
+	}
+	args := pgx.NamedArgs{"label": label}
+
+	if len(filter.TokenAddresses) > 0 {
+		var tokensBytes [][]byte
+		for _, tokenAddress := range filter.TokenAddresses {
+			tokenBytes, err := decodeAddress(tokenAddress)
+			if err != nil {
+				return nil, fmt.Errorf("invalid token address %s: %v", tokenAddress, err)
+			}
+			tokensBytes = append(tokensBytes, tokenBytes)
+		}
+		conditions = append(conditions, "address = ANY(@token_addresses)")
+		args["token_addresses"] = tokensBytes
+	}
+
+	fromExpr := "lower(label_data->'args'->>'from')"
+	toExpr := "lower(label_data->'args'->>'to')"
+
+	if filter.FromAddress != "" && filter.ToAddress != "" && filter.IsBidirectional {
+		conditions = append(conditions, fmt.Sprintf("((%s = @from_address AND %s = @to_address) OR (%s = @to_address AND %s = @from_address))", fromExpr, toExpr, fromExpr, toExpr))
+		args["from_address"] = strings.ToLower(filter.FromAddress)
+		args["to_address"] = strings.ToLower(filter.ToAddress)
+	} else {
+		if filter.FromAddress != "" {
+			conditions = append(conditions, fmt.Sprintf("%s = @from_address", fromExpr))
+			args["from_address"] = strings.ToLower(filter.FromAddress)
+		}
+		if filter.ToAddress != "" {
+			conditions = append(conditions, fmt.Sprintf("%s = @to_address", toExpr))
+			args["to_address"] = strings.ToLower(filter.ToAddress)
+		}
+	}
+
+	if filter.LowestBlockNum > 0 {
+		conditions = append(conditions, "block_number >= @lowest_block_number")
+		args["lowest_block_number"] = filter.LowestBlockNum
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultLabelsPageLimit
+	}
+	args["limit"] = limit
+
+	pool := p.GetReadPool()
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Release()
+
+	query := fmt.Sprintf(`SELECT
+			'0x' || encode(address, 'hex') AS token_address,
+			%s AS token_standard,
+			%s AS from_address,
+			%s AS to_address,
+			min(block_number),
+			max(block_number),
+			sum(%s)::text AS volume,
+			count(*)
+		FROM %s
+		WHERE %s
+		GROUP BY 1, 2, 3, 4
+		ORDER BY count(*) DESC
+		LIMIT @limit`, tokenStandardExpr, fromExpr, toExpr, tokenTransferVolumeExpr, LabelsTableName(blockchain), strings.Join(conditions, " AND "))
+
+	rows, err := conn.Query(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	volumes := []TokenTransfersVolume{}
+	for rows.Next() {
+		var volume TokenTransfersVolume
+		var volumeStr string
+
+		err = rows.Scan(
+			&volume.TokenAddress,
+			&volume.TokenStandard,
+			&volume.FromAddress,
+			&volume.ToAddress,
+			&volume.MinBlockNumber,
+			&volume.MaxBlockNumber,
+			&volumeStr,
+			&volume.TransfersCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		volume.Volume = new(big.Int)
+		// Sum of numeric could have fractional zeros part
+		volume.Volume.SetString(strings.Split(volumeStr, ".")[0], 10)
+
+		volumes = append(volumes, volume)
+	}
+
+	return volumes, rows.Err()
+}
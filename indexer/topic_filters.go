@@ -0,0 +1,294 @@
+package indexer
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jackc/pgx/v5"
+)
+
+// Topic filters of event job are stored as JSON array, element i lists values allowed in topic
+// i+1 of log and empty element allows any value. Column is not part of abi_jobs schema, jobs
+// are read with to_jsonb(abi_jobs) -> 'topic_filters', so queries work before it is added.
+
+// EnsureAbiJobsTopicFiltersColumn adds topic_filters column to abi_jobs.
+func (p *PostgreSQLpgx) EnsureAbiJobsTopicFiltersColumn() error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), "ALTER TABLE abi_jobs ADD COLUMN IF NOT EXISTS topic_filters JSONB")
+
+	return err
+}
+
+// MatchesTopics returns true if topics of log have allowed values in every filtered position.
+func (e *AbiEntry) MatchesTopics(topics []string) bool {
+	for i, values := range e.TopicFilters {
+		if len(values) == 0 {
+			continue
+		}
+		if i+1 >= len(topics) {
+			return false
+		}
+
+		matched := false
+		for _, value := range values {
+			if strings.EqualFold(value, topics[i+1]) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ParseTopicFilters parses filters in form <indexed argument>=<value>, values of the same
+// argument are joined, so log matches if argument has any of them.
+func ParseTopicFilters(raw []string) (map[string][]string, error) {
+	filters := make(map[string][]string)
+	for _, filter := range raw {
+		name, value, found := strings.Cut(filter, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !found || name == "" || value == "" {
+			return nil, fmt.Errorf("invalid topic filter %q, expected <indexed argument>=<value>", filter)
+		}
+		filters[name] = append(filters[name], value)
+	}
+
+	return filters, nil
+}
+
+// EventTopicFilters converts values of indexed arguments of event into topic filters of job.
+func EventTopicFilters(event abi.Event, filters map[string][]string) ([][]string, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	indexed := make(map[string]bool)
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed[input.Name] = true
+		}
+	}
+	var missing []string
+	for name := range filters {
+		if !indexed[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("event %s has no indexed arguments %s", event.Name, strings.Join(missing, ", "))
+	}
+
+	var topicFilters [][]string
+	position := 0
+	for _, input := range event.Inputs {
+		if !input.Indexed {
+			continue
+		}
+		position++
+
+		values, exists := filters[input.Name]
+		if !exists {
+			continue
+		}
+
+		for len(topicFilters) < position {
+			topicFilters = append(topicFilters, nil)
+		}
+		for _, value := range values {
+			topic, err := topicValue(input.Type, value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q of %s argument %s of event %s: %w", value, input.Type.String(), input.Name, event.Name, err)
+			}
+			topicFilters[position-1] = append(topicFilters[position-1], topic)
+		}
+	}
+
+	return topicFilters, nil
+}
+
+// topicValue encodes value of indexed argument the way it is stored in log topic. Dynamic
+// types are stored as hashes of their values.
+func topicValue(argType abi.Type, value string) (string, error) {
+	var topic common.Hash
+
+	switch argType.T {
+	case abi.AddressTy:
+		if !common.IsHexAddress(value) {
+			return "", fmt.Errorf("not an address")
+		}
+		topic = common.BytesToHash(common.HexToAddress(value).Bytes())
+	case abi.BoolTy:
+		boolValue, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", err
+		}
+		if boolValue {
+			topic[common.HashLength-1] = 1
+		}
+	case abi.IntTy, abi.UintTy:
+		number, ok := new(big.Int).SetString(value, 0)
+		if !ok {
+			return "", fmt.Errorf("not a number")
+		}
+		bits := argType.Size
+		if argType.T == abi.IntTy {
+			bits--
+		} else if number.Sign() < 0 {
+			return "", fmt.Errorf("negative value of unsigned integer")
+		}
+		if number.BitLen() > bits {
+			return "", fmt.Errorf("value does not fit into %s", argType.String())
+		}
+		topic = common.BytesToHash(math.U256Bytes(number))
+	case abi.FixedBytesTy:
+		data, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+		if err != nil {
+			return "", err
+		}
+		if len(data) != argType.Size {
+			return "", fmt.Errorf("expected %d bytes", argType.Size)
+		}
+		copy(topic[:], data)
+	case abi.StringTy:
+		topic = crypto.Keccak256Hash([]byte(value))
+	case abi.BytesTy:
+		data, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+		if err != nil {
+			return "", err
+		}
+		topic = crypto.Keccak256Hash(data)
+	default:
+		return "", fmt.Errorf("filters by values of this type are not supported")
+	}
+
+	return topic.Hex(), nil
+}
+
+// SetAbiJobsTopicFilters sets topic filters of event jobs of address and customer, with event
+// name only jobs of this event are changed. Without event name filters are set for events
+// which have all filtered arguments indexed. Empty filters remove filtering. IDs of changed
+// jobs are returned.
+func (p *PostgreSQLpgx) SetAbiJobsTopicFilters(chain, address, customerID, eventName string, filters map[string][]string) ([]string, error) {
+	addressBytes, err := decodeJobAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.EnsureAbiJobsTopicFiltersColumn(); err != nil {
+		return nil, fmt.Errorf("failed to add topic_filters column to abi_jobs: %w", err)
+	}
+
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(context.Background())
+
+	if err := lockAbiJobsOfAddress(tx, chain, addressBytes, customerID); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(context.Background(), "SELECT id, abi_name, abi FROM abi_jobs WHERE chain = @chain AND address IS NOT DISTINCT FROM @address AND customer_id = @customer_id AND (abi::jsonb)->>'type' = 'event' AND (@event_name = '' OR abi_name = @event_name) ORDER BY created_at", pgx.NamedArgs{
+		"chain":       chain,
+		"address":     addressBytes,
+		"customer_id": customerID,
+		"event_name":  eventName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type eventJob struct {
+		id      string
+		name    string
+		abiText string
+	}
+	var jobs []eventJob
+	for rows.Next() {
+		var job eventJob
+		if err := rows.Scan(&job.id, &job.name, &job.abiText); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("no event jobs of address %s and customer %s at %s", address, customerID, chain)
+	}
+
+	var updatedIDs []string
+	for _, job := range jobs {
+		contractAbi, err := abi.JSON(strings.NewReader("[" + job.abiText + "]"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ABI of job %s: %w", job.id, err)
+		}
+		event, exists := contractAbi.Events[job.name]
+		if !exists {
+			return nil, fmt.Errorf("ABI of job %s has no event %s", job.id, job.name)
+		}
+
+		topicFilters, err := EventTopicFilters(event, filters)
+		if err != nil {
+			if eventName == "" {
+				continue
+			}
+			return nil, err
+		}
+
+		var topicFiltersJSON *string
+		if len(topicFilters) > 0 {
+			data, err := json.Marshal(topicFilters)
+			if err != nil {
+				return nil, err
+			}
+			value := string(data)
+			topicFiltersJSON = &value
+		}
+
+		if _, err := tx.Exec(context.Background(), "UPDATE abi_jobs SET topic_filters = $1::jsonb, updated_at = now() WHERE id = $2", topicFiltersJSON, job.id); err != nil {
+			return nil, fmt.Errorf("failed to set topic filters of job %s: %w", job.id, err)
+		}
+		updatedIDs = append(updatedIDs, job.id)
+	}
+
+	if len(updatedIDs) == 0 {
+		return nil, fmt.Errorf("no event jobs of address %s have all filtered arguments indexed", address)
+	}
+
+	return updatedIDs, tx.Commit(context.Background())
+}
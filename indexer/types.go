@@ -1,6 +1,7 @@
 package indexer
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
@@ -20,6 +21,7 @@ type BlockIndex struct {
 	RowID          uint64
 	Path           string
 	L1BlockNumber  uint64
+	Miner          string
 }
 
 func (b *BlockIndex) SetChain(chain string) {
@@ -40,6 +42,15 @@ func NewBlockIndex(chain string, blockNumber uint64, blockHash string, blockTime
 	}
 }
 
+// IndexedBatch describes a storage batch with block bounds of index rows pointing to it.
+type IndexedBatch struct {
+	Path           string
+	MinBlockNumber uint64
+	MaxBlockNumber uint64
+	BlocksCount    uint64
+	Incomplete     bool
+}
+
 type IndexType string
 
 const (
@@ -48,6 +59,37 @@ const (
 	LogIndexType         IndexType = "logs"
 )
 
+// RangeIndex is a row of blocks, transactions and logs indexes joined over block range.
+// Transaction and log fields are empty for blocks without them. Selector is the first topic
+// of log, anonymous events and events with less indexed arguments leave topics empty.
+type RangeIndex struct {
+	BlockNumber      uint64  `db:"block_number"`
+	BlockHash        string  `db:"block_hash"`
+	BlockTimestamp   uint64  `db:"block_timestamp"`
+	TransactionHash  *string `db:"transaction_hash"`
+	TransactionIndex *uint64 `db:"transaction_index"`
+	TransactionPath  *string `db:"transaction_path"`
+	TransactionInput *string `db:"transaction_input"`
+	Selector         *string `db:"selector"`
+	Topic1           *string `db:"topic1"`
+	Topic2           *string `db:"topic2"`
+	Topic3           *string `db:"topic3"`
+	LogIndex         *uint64 `db:"log_index"`
+	EventPath        *string `db:"event_path"`
+}
+
+// Topics returns topics of log in order they were emitted, selector included.
+func (r RangeIndex) Topics() []string {
+	var topics []string
+	for _, topic := range []*string{r.Selector, r.Topic1, r.Topic2, r.Topic3} {
+		if topic == nil || *topic == "" {
+			break
+		}
+		topics = append(topics, *topic)
+	}
+	return topics
+}
+
 type BlockCache struct {
 	BlockNumber    uint64
 	BlockTimestamp uint64
@@ -71,6 +113,7 @@ type AbiJob struct {
 	CreatedAt             time.Time
 	UpdatedAt             time.Time
 	DeploymentBlockNumber *uint64
+	TopicFilters          [][]string
 }
 
 type CustomerUpdates struct {
@@ -93,32 +136,32 @@ type TaskForLog struct { // Assuming structure similar to TransactionIndex
 }
 
 type EventLabel struct {
-	Address         string
-	BlockNumber     uint64
-	BlockHash       string
-	CallerAddress   string
-	Label           string
-	LabelName       string
-	LabelType       string
-	OriginAddress   string
-	TransactionHash string
-	LabelData       string
-	BlockTimestamp  uint64
-	LogIndex        uint64
+	Address         string `json:"address"`
+	BlockNumber     uint64 `json:"block_number"`
+	BlockHash       string `json:"block_hash"`
+	CallerAddress   string `json:"caller_address"`
+	Label           string `json:"label"`
+	LabelName       string `json:"label_name"`
+	LabelType       string `json:"label_type"`
+	OriginAddress   string `json:"origin_address"`
+	TransactionHash string `json:"transaction_hash"`
+	LabelData       string `json:"label_data"`
+	BlockTimestamp  uint64 `json:"block_timestamp"`
+	LogIndex        uint64 `json:"log_index"`
 }
 
 type TransactionLabel struct {
-	Address         string
-	BlockNumber     uint64
-	BlockHash       string
-	CallerAddress   string
-	Label           string
-	LabelName       string
-	LabelType       string
-	OriginAddress   string
-	TransactionHash string
-	LabelData       string
-	BlockTimestamp  uint64
+	Address         string `json:"address"`
+	BlockNumber     uint64 `json:"block_number"`
+	BlockHash       string `json:"block_hash"`
+	CallerAddress   string `json:"caller_address"`
+	Label           string `json:"label"`
+	LabelName       string `json:"label_name"`
+	LabelType       string `json:"label_type"`
+	OriginAddress   string `json:"origin_address"`
+	TransactionHash string `json:"transaction_hash"`
+	LabelData       string `json:"label_data"`
+	BlockTimestamp  uint64 `json:"block_timestamp"`
 }
 
 type AbiJobsDeployInfo struct {
@@ -126,12 +169,26 @@ type AbiJobsDeployInfo struct {
 	IDs                 []string
 }
 
+// WildcardAddress is key of ABI map for jobs without address, their selectors are matched
+// for events and calls of every address which has no job of the same selector.
+const WildcardAddress = "*"
+
+// AbiJobAddress returns key of ABI map for address of job, NULL address is wildcard.
+func AbiJobAddress(address []byte) string {
+	if len(address) == 0 {
+		return WildcardAddress
+	}
+	return fmt.Sprintf("0x%x", address)
+}
+
 type AbiEntry struct {
 	AbiJSON string `json:"abi"`
 	Abi     *abi.ABI
 	AbiName string `json:"abi_name"`
 	AbiType string `json:"abi_type"`
-	Once    sync.Once
+	// Values of indexed arguments logs of event job are decoded for, see MatchesTopics
+	TopicFilters [][]string `json:"topic_filters"`
+	Once         sync.Once
 }
 
 type RawTransaction struct {
@@ -154,6 +211,10 @@ type RawTransaction struct {
 	TransactionIndex     uint64 `json:"transactionIndex"`
 	TransactionType      uint64 `json:"transactionType,omitempty"`
 
+	// Blob transactions (EIP-4844) only
+	MaxFeePerBlobGas    string   `json:"maxFeePerBlobGas,omitempty"`
+	BlobVersionedHashes []string `json:"blobVersionedHashes,omitempty"`
+
 	// Chain-specific optional fields
 	L1BlockNumber *uint64 `json:"l1BlockNumber,omitempty"` // L2 chains only
 }
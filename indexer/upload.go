@@ -2,10 +2,12 @@ package indexer
 
 import (
 	"encoding/json"
-	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"strings"
+
+	"github.com/G7DAO/seer/logging"
 )
 
 var DBConnection *PostgreSQLpgx
@@ -17,7 +19,14 @@ func InitDBConnection() {
 	DBConnection, err = NewPostgreSQLpgx(MOONSTREAM_DB_V3_INDEXES_URI)
 
 	if err != nil {
-		fmt.Println("Error initializing DBConnection: ", err)
+		slog.Error("Unable to initialize indexes database connection", logging.ErrorKey, err)
+		return
+	}
+
+	if MOONSTREAM_DB_V3_INDEXES_READ_REPLICA_URI != "" {
+		if replicaErr := DBConnection.AttachReadReplica(MOONSTREAM_DB_V3_INDEXES_READ_REPLICA_URI); replicaErr != nil {
+			slog.Warn("Unable to initialize read replica connection, analytics queries use primary database", logging.ErrorKey, replicaErr)
+		}
 	}
 }
 
@@ -0,0 +1,169 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const VerifyProgressTableName = "seer_verify_progress"
+
+// VerifyProgress is checkpoint of verification of blocks index of chain against storage.
+type VerifyProgress struct {
+	Chain      string    `json:"chain"`
+	LastBlock  uint64    `json:"last_block"`
+	Batches    uint64    `json:"batches"`
+	Mismatches uint64    `json:"mismatches"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// EnsureVerifyProgressTable creates table with verification checkpoints if it does not exist.
+func (p *PostgreSQLpgx) EnsureVerifyProgressTable() error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		chain VARCHAR(128) PRIMARY KEY,
+		last_block BIGINT NOT NULL,
+		batches BIGINT NOT NULL DEFAULT 0,
+		mismatches BIGINT NOT NULL DEFAULT 0,
+		updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+	)`, VerifyProgressTableName))
+
+	return err
+}
+
+// ReadVerifyProgress returns checkpoint of chain, nil if verification was not started.
+func (p *PostgreSQLpgx) ReadVerifyProgress(chain string) (*VerifyProgress, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	progress := VerifyProgress{Chain: chain}
+	err = conn.QueryRow(context.Background(), fmt.Sprintf("SELECT last_block, batches, mismatches, updated_at FROM %s WHERE chain = $1", VerifyProgressTableName), chain).Scan(&progress.LastBlock, &progress.Batches, &progress.Mismatches, &progress.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &progress, nil
+}
+
+// SaveVerifyProgress moves checkpoint of chain to lastBlock and adds verified batches and
+// found mismatches to its counters.
+func (p *PostgreSQLpgx) SaveVerifyProgress(chain string, lastBlock, batches, mismatches uint64) error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf(`INSERT INTO %s (chain, last_block, batches, mismatches)
+		VALUES (@chain, @last_block, @batches, @mismatches)
+		ON CONFLICT (chain) DO UPDATE SET
+			last_block = EXCLUDED.last_block,
+			batches = %s.batches + EXCLUDED.batches,
+			mismatches = %s.mismatches + EXCLUDED.mismatches,
+			updated_at = now()`, VerifyProgressTableName, VerifyProgressTableName, VerifyProgressTableName), pgx.NamedArgs{
+		"chain":      chain,
+		"last_block": lastBlock,
+		"batches":    batches,
+		"mismatches": mismatches,
+	})
+
+	return err
+}
+
+// DeleteVerifyProgress removes checkpoint of chain, so next verification starts from beginning.
+func (p *PostgreSQLpgx) DeleteVerifyProgress(chain string) error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf("DELETE FROM %s WHERE chain = $1", VerifyProgressTableName), chain)
+
+	return err
+}
+
+// ReadBlockIndexesRange returns at most limit block index rows in range ordered by block number.
+func (p *PostgreSQLpgx) ReadBlockIndexesRange(blockchain string, fromBlock, toBlock uint64, limit int) ([]BlockIndex, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	blocksTableName, blocksTableErr := BlocksTableName(blockchain)
+	if blocksTableErr != nil {
+		return nil, blocksTableErr
+	}
+
+	query := fmt.Sprintf(`SELECT block_number, block_hash, block_timestamp, parent_hash, row_id, path
+	FROM %s
+	WHERE block_number >= $1 AND block_number <= $2
+	ORDER BY block_number
+	LIMIT $3`, blocksTableName)
+
+	rows, err := conn.Query(context.Background(), query, fromBlock, toBlock, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var indexes []BlockIndex
+	for rows.Next() {
+		var index BlockIndex
+		if err := rows.Scan(&index.BlockNumber, &index.BlockHash, &index.BlockTimestamp, &index.ParentHash, &index.RowID, &index.Path); err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, index)
+	}
+
+	return indexes, rows.Err()
+}
+
+// UpdateBlockIndexPath points block index rows of batch in range to new path of its object in
+// storage, range keeps update on primary key instead of scan by path.
+func (p *PostgreSQLpgx) UpdateBlockIndexPath(blockchain, path, newPath string, fromBlock, toBlock uint64) (int64, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
+
+	blocksTableName, blocksTableErr := BlocksTableName(blockchain)
+	if blocksTableErr != nil {
+		return 0, blocksTableErr
+	}
+
+	commandTag, err := conn.Exec(context.Background(), fmt.Sprintf("UPDATE %s SET path = $1 WHERE path = $2 AND block_number >= $3 AND block_number <= $4", blocksTableName), newPath, path, fromBlock, toBlock)
+	if err != nil {
+		return 0, err
+	}
+
+	return commandTag.RowsAffected(), nil
+}
@@ -0,0 +1,414 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	WebhooksTableName          = "seer_webhooks"
+	WebhookDeliveriesTableName = "seer_webhook_deliveries"
+)
+
+// Statuses of webhook deliveries
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// Webhook is URL of customer which receives labels of chain written to customer databases.
+// Empty Addresses and LabelNames match any label. Payloads are signed with Secret.
+type Webhook struct {
+	ID         string    `json:"id"`
+	CustomerID string    `json:"customer_id"`
+	Chain      string    `json:"chain"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"-"`
+	Addresses  []string  `json:"addresses"`
+	LabelNames []string  `json:"label_names"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WebhookDelivery is one POST of labels to webhook with its outcome.
+type WebhookDelivery struct {
+	ID             string    `json:"id"`
+	WebhookID      string    `json:"webhook_id"`
+	FromBlock      uint64    `json:"from_block"`
+	ToBlock        uint64    `json:"to_block"`
+	LabelsCount    int       `json:"labels_count"`
+	Payload        []byte    `json:"-"`
+	Status         string    `json:"status"`
+	Attempts       int       `json:"attempts"`
+	ResponseStatus int       `json:"response_status,omitempty"`
+	LastError      string    `json:"last_error,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// EnsureWebhooksTables creates tables of webhooks and their deliveries if they do not exist.
+func (p *PostgreSQLpgx) EnsureWebhooksTables() error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		id UUID PRIMARY KEY,
+		customer_id VARCHAR NOT NULL,
+		chain VARCHAR NOT NULL,
+		url VARCHAR NOT NULL,
+		secret VARCHAR NOT NULL,
+		addresses TEXT[] NOT NULL DEFAULT '{}',
+		label_names TEXT[] NOT NULL DEFAULT '{}',
+		active BOOLEAN NOT NULL DEFAULT true,
+		created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+	)`, WebhooksTableName))
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		id UUID PRIMARY KEY,
+		webhook_id UUID NOT NULL REFERENCES %s (id) ON DELETE CASCADE,
+		from_block BIGINT NOT NULL,
+		to_block BIGINT NOT NULL,
+		labels_count INTEGER NOT NULL,
+		payload JSONB NOT NULL,
+		status VARCHAR NOT NULL,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		response_status INTEGER NOT NULL DEFAULT 0,
+		last_error TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+		updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+	)`, WebhookDeliveriesTableName, WebhooksTableName))
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf("CREATE INDEX IF NOT EXISTS %[1]s_status_idx ON %[1]s (webhook_id, status)", WebhookDeliveriesTableName))
+
+	return err
+}
+
+// CreateWebhook registers webhook, addresses are matched case-insensitively.
+func (p *PostgreSQLpgx) CreateWebhook(webhook Webhook) (*Webhook, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	webhook.ID = uuid.NewString()
+	webhook.Active = true
+	for i, address := range webhook.Addresses {
+		webhook.Addresses[i] = strings.ToLower(address)
+	}
+	if webhook.Addresses == nil {
+		webhook.Addresses = []string{}
+	}
+	if webhook.LabelNames == nil {
+		webhook.LabelNames = []string{}
+	}
+
+	err = conn.QueryRow(context.Background(), fmt.Sprintf(`INSERT INTO %s (id, customer_id, chain, url, secret, addresses, label_names)
+		VALUES (@id, @customer_id, @chain, @url, @secret, @addresses, @label_names)
+		RETURNING created_at`, WebhooksTableName), pgx.NamedArgs{
+		"id":          webhook.ID,
+		"customer_id": webhook.CustomerID,
+		"chain":       webhook.Chain,
+		"url":         webhook.URL,
+		"secret":      webhook.Secret,
+		"addresses":   webhook.Addresses,
+		"label_names": webhook.LabelNames,
+	}).Scan(&webhook.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// ListWebhooks returns webhooks of chain, or of all chains if chain is empty. Only active
+// webhooks are returned if activeOnly is set.
+func (p *PostgreSQLpgx) ListWebhooks(chain, customerID string, activeOnly bool) ([]Webhook, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(context.Background(), fmt.Sprintf(`SELECT id, customer_id, chain, url, secret, addresses, label_names, active, created_at
+		FROM %s
+		WHERE ($1 = '' OR chain = $1) AND ($2 = '' OR customer_id = $2) AND (NOT $3 OR active)
+		ORDER BY created_at`, WebhooksTableName), chain, customerID, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []Webhook
+	for rows.Next() {
+		var webhook Webhook
+		if err := rows.Scan(&webhook.ID, &webhook.CustomerID, &webhook.Chain, &webhook.URL, &webhook.Secret, &webhook.Addresses, &webhook.LabelNames, &webhook.Active, &webhook.CreatedAt); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// GetWebhook returns webhook by ID.
+func (p *PostgreSQLpgx) GetWebhook(id string) (*Webhook, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	var webhook Webhook
+	err = conn.QueryRow(context.Background(), fmt.Sprintf(`SELECT id, customer_id, chain, url, secret, addresses, label_names, active, created_at
+		FROM %s WHERE id = $1`, WebhooksTableName), id).Scan(&webhook.ID, &webhook.CustomerID, &webhook.Chain, &webhook.URL, &webhook.Secret, &webhook.Addresses, &webhook.LabelNames, &webhook.Active, &webhook.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("webhook %s not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// SetWebhookActive enables or disables webhook, returns false if webhook does not exist.
+func (p *PostgreSQLpgx) SetWebhookActive(id string, active bool) (bool, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return false, err
+	}
+	defer conn.Release()
+
+	commandTag, err := conn.Exec(context.Background(), fmt.Sprintf("UPDATE %s SET active = $2 WHERE id = $1", WebhooksTableName), id, active)
+	if err != nil {
+		return false, err
+	}
+
+	return commandTag.RowsAffected() > 0, nil
+}
+
+// DeleteWebhook deletes webhook with its deliveries, returns false if webhook does not exist.
+func (p *PostgreSQLpgx) DeleteWebhook(id string) (bool, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return false, err
+	}
+	defer conn.Release()
+
+	commandTag, err := conn.Exec(context.Background(), fmt.Sprintf("DELETE FROM %s WHERE id = $1", WebhooksTableName), id)
+	if err != nil {
+		return false, err
+	}
+
+	return commandTag.RowsAffected() > 0, nil
+}
+
+// CreateWebhookDelivery saves pending delivery with its payload, so it could be redelivered
+// if all attempts fail.
+func (p *PostgreSQLpgx) CreateWebhookDelivery(delivery WebhookDelivery) (*WebhookDelivery, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	if delivery.ID == "" {
+		delivery.ID = uuid.NewString()
+	}
+	delivery.Status = DeliveryPending
+
+	err = conn.QueryRow(context.Background(), fmt.Sprintf(`INSERT INTO %s (id, webhook_id, from_block, to_block, labels_count, payload, status)
+		VALUES (@id, @webhook_id, @from_block, @to_block, @labels_count, @payload, @status)
+		RETURNING created_at, updated_at`, WebhookDeliveriesTableName), pgx.NamedArgs{
+		"id":           delivery.ID,
+		"webhook_id":   delivery.WebhookID,
+		"from_block":   delivery.FromBlock,
+		"to_block":     delivery.ToBlock,
+		"labels_count": delivery.LabelsCount,
+		"payload":      string(delivery.Payload),
+		"status":       delivery.Status,
+	}).Scan(&delivery.CreatedAt, &delivery.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// UpdateWebhookDelivery records outcome of delivery attempts.
+func (p *PostgreSQLpgx) UpdateWebhookDelivery(id, status string, attempts, responseStatus int, lastError string) error {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(context.Background(), fmt.Sprintf(`UPDATE %s
+		SET status = $2, attempts = attempts + $3, response_status = $4, last_error = $5, updated_at = now()
+		WHERE id = $1`, WebhookDeliveriesTableName), id, status, attempts, responseStatus, lastError)
+
+	return err
+}
+
+const webhookDeliveryColumns = "id, webhook_id, from_block, to_block, labels_count, payload::text, status, attempts, response_status, last_error, created_at, updated_at"
+
+func scanWebhookDelivery(row pgx.Row) (*WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	var payload string
+	err := row.Scan(&delivery.ID, &delivery.WebhookID, &delivery.FromBlock, &delivery.ToBlock, &delivery.LabelsCount, &payload, &delivery.Status, &delivery.Attempts, &delivery.ResponseStatus, &delivery.LastError, &delivery.CreatedAt, &delivery.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	delivery.Payload = []byte(payload)
+	return &delivery, nil
+}
+
+// GetWebhookDelivery returns delivery by ID.
+func (p *PostgreSQLpgx) GetWebhookDelivery(id string) (*WebhookDelivery, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	delivery, err := scanWebhookDelivery(conn.QueryRow(context.Background(), fmt.Sprintf("SELECT %s FROM %s WHERE id = $1", webhookDeliveryColumns, WebhookDeliveriesTableName), id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("webhook delivery %s not found", id)
+	}
+
+	return delivery, err
+}
+
+// ListWebhookDeliveries returns the latest deliveries of webhook with status, or with any
+// status if it is empty.
+func (p *PostgreSQLpgx) ListWebhookDeliveries(webhookID, status string, limit int) ([]WebhookDelivery, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(context.Background(), fmt.Sprintf(`SELECT %s FROM %s
+		WHERE webhook_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC LIMIT $3`, webhookDeliveryColumns, WebhookDeliveriesTableName), webhookID, status, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// ExtendWebhookDeliveryClaims sets update time of pending and failed deliveries to now, so
+// they are not claimed by ClaimUndeliveredWebhookDeliveries while they wait in queue or are
+// being sent by process which holds them. Returns number of extended claims.
+func (p *PostgreSQLpgx) ExtendWebhookDeliveryClaims(ids []string) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
+
+	result, err := conn.Exec(context.Background(), fmt.Sprintf(`UPDATE %s SET updated_at = now()
+		WHERE id = ANY($1) AND status IN ($2, $3)`, WebhookDeliveriesTableName), ids, DeliveryPending, DeliveryFailed)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// ClaimUndeliveredWebhookDeliveries returns the oldest pending or failed deliveries of active
+// webhooks of chain which were not updated for olderThan and made less than maxAttempts
+// attempts. Update time of returned deliveries is set to now, so other replicas of
+// synchronizer do not claim them again within olderThan, holder of deliveries extends the
+// claim with ExtendWebhookDeliveryClaims while they are not sent.
+func (p *PostgreSQLpgx) ClaimUndeliveredWebhookDeliveries(chain string, olderThan time.Duration, maxAttempts, limit int) ([]WebhookDelivery, error) {
+	pool := p.GetPool()
+
+	conn, err := pool.Acquire(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(context.Background(), fmt.Sprintf(`UPDATE %[2]s SET updated_at = now()
+		WHERE id IN (
+			SELECT id FROM %[2]s
+			WHERE webhook_id IN (SELECT id FROM %[3]s WHERE chain = $1 AND active)
+				AND status IN ($2, $3) AND attempts < $4 AND updated_at < now() - make_interval(secs => $5)
+			ORDER BY created_at LIMIT $6
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING %[1]s`, webhookDeliveryColumns, WebhookDeliveriesTableName, WebhooksTableName),
+		chain, DeliveryPending, DeliveryFailed, maxAttempts, olderThan.Seconds(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt) })
+
+	return deliveries, rows.Err()
+}
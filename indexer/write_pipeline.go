@@ -0,0 +1,272 @@
+package indexer
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/G7DAO/seer/logging"
+)
+
+// LabelsWriter is a destination of decoded labels, implemented by PostgreSQLpgx and ClickHouse
+// customer databases and by MemoryStore in tests.
+type LabelsWriter interface {
+	WriteDataToCustomerDB(blockchain string, txCalls []TransactionLabel, events []EventLabel, rawTransactions []RawTransaction) error
+}
+
+// PipelineBatch is a unit of data submitted to write pipeline, batches of the same blockchain
+// are coalesced before flush.
+type PipelineBatch struct {
+	Blockchain      string
+	Indexes         []BlockIndex
+	Transactions    []TransactionLabel
+	Events          []EventLabel
+	RawTransactions []RawTransaction
+}
+
+func (b *PipelineBatch) Size() int {
+	return len(b.Indexes) + len(b.Transactions) + len(b.Events) + len(b.RawTransactions)
+}
+
+func (b *PipelineBatch) merge(other PipelineBatch) {
+	b.Indexes = append(b.Indexes, other.Indexes...)
+	b.Transactions = append(b.Transactions, other.Transactions...)
+	b.Events = append(b.Events, other.Events...)
+	b.RawTransactions = append(b.RawTransactions, other.RawTransactions...)
+}
+
+type WritePipelineConfig struct {
+	// Workers bounds flushes of different blockchains running at the same time, batches of
+	// one blockchain are always flushed one by one in submission order
+	Workers       int
+	QueueSize     int
+	FlushSize     int
+	FlushInterval time.Duration
+}
+
+// WritePipeline accepts batches on bounded queue and writes them in background, so producers
+// continue crawling while previous data is written to database. Submit blocks when queue is
+// full, which slows producers down to the speed of database.
+//
+// Batches of blockchain are committed in submission order and after failed flush the rest of
+// them are dropped. Synchronizer follows the newest indexed path, so batch committed ahead of
+// earlier one or after a gap would make it skip blocks for good.
+type WritePipeline struct {
+	indexStore   IndexWriter
+	labelsWriter LabelsWriter
+	config       WritePipelineConfig
+
+	queue chan PipelineBatch
+	wg    sync.WaitGroup
+	// slots bounds number of concurrent flushes
+	slots chan struct{}
+
+	// submitMu guards queue from being closed while producer sends to it
+	submitMu sync.RWMutex
+	closed   bool
+
+	mu        sync.Mutex
+	err       error
+	flushed   int
+	flushRows int
+}
+
+func NewWritePipeline(indexStore IndexWriter, labelsWriter LabelsWriter, config WritePipelineConfig) *WritePipeline {
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = config.Workers * 2
+	}
+	if config.FlushSize <= 0 {
+		config.FlushSize = InsertBatchSize
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = 5 * time.Second
+	}
+
+	wp := &WritePipeline{
+		indexStore:   indexStore,
+		labelsWriter: labelsWriter,
+		config:       config,
+		queue:        make(chan PipelineBatch, config.QueueSize),
+		slots:        make(chan struct{}, config.Workers),
+	}
+
+	wp.wg.Add(1)
+	go wp.run()
+
+	return wp
+}
+
+// Err returns first error of flush, after it pipeline does not accept new batches.
+func (wp *WritePipeline) Err() error {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	return wp.err
+}
+
+func (wp *WritePipeline) setErr(err error) {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	if wp.err == nil {
+		wp.err = err
+	}
+}
+
+// Submit puts batch to queue, returns error of previous flushes if any.
+func (wp *WritePipeline) Submit(batch PipelineBatch) error {
+	if err := wp.Err(); err != nil {
+		return err
+	}
+	if batch.Size() == 0 {
+		return nil
+	}
+	if len(batch.Indexes) > 0 && wp.indexStore == nil {
+		return fmt.Errorf("write pipeline has no index store for block indexes")
+	}
+	if batch.Size() > len(batch.Indexes) && wp.labelsWriter == nil {
+		return fmt.Errorf("write pipeline has no labels writer for labels")
+	}
+
+	wp.submitMu.RLock()
+	defer wp.submitMu.RUnlock()
+
+	if wp.closed {
+		return fmt.Errorf("write pipeline is closed")
+	}
+
+	wp.queue <- batch
+
+	return nil
+}
+
+func (wp *WritePipeline) SubmitIndexes(blockchain string, indexes []BlockIndex) error {
+	return wp.Submit(PipelineBatch{Blockchain: blockchain, Indexes: indexes})
+}
+
+// Close stops accepting new batches, flushes buffered data and returns first error happened.
+func (wp *WritePipeline) Close() error {
+	wp.submitMu.Lock()
+	if wp.closed {
+		wp.submitMu.Unlock()
+		return wp.Err()
+	}
+	wp.closed = true
+	close(wp.queue)
+	wp.submitMu.Unlock()
+
+	wp.wg.Wait()
+
+	wp.mu.Lock()
+	slog.Info("Write pipeline closed", "flushes", wp.flushed, "rows", wp.flushRows)
+	wp.mu.Unlock()
+
+	return wp.Err()
+}
+
+// run coalesces batches from queue per blockchain and hands them to flusher of blockchain.
+func (wp *WritePipeline) run() {
+	defer wp.wg.Done()
+
+	buffers := make(map[string]*PipelineBatch)
+	flushers := make(map[string]chan *PipelineBatch)
+	var flushersWg sync.WaitGroup
+	ticker := time.NewTicker(wp.config.FlushInterval)
+	defer ticker.Stop()
+
+	flush := func(blockchain string) {
+		buffer := buffers[blockchain]
+		delete(buffers, blockchain)
+		if buffer == nil || buffer.Size() == 0 {
+			return
+		}
+
+		flusher, exists := flushers[blockchain]
+		if !exists {
+			flusher = make(chan *PipelineBatch, 1)
+			flushers[blockchain] = flusher
+			flushersWg.Add(1)
+			go wp.runFlusher(blockchain, flusher, &flushersWg)
+		}
+		flusher <- buffer
+	}
+
+	for {
+		select {
+		case batch, ok := <-wp.queue:
+			if !ok {
+				for blockchain := range buffers {
+					flush(blockchain)
+				}
+				for _, flusher := range flushers {
+					close(flusher)
+				}
+				flushersWg.Wait()
+				return
+			}
+
+			buffer, exists := buffers[batch.Blockchain]
+			if !exists {
+				buffer = &PipelineBatch{Blockchain: batch.Blockchain}
+				buffers[batch.Blockchain] = buffer
+			}
+			buffer.merge(batch)
+
+			if buffer.Size() >= wp.config.FlushSize {
+				flush(batch.Blockchain)
+			}
+		case <-ticker.C:
+			for blockchain := range buffers {
+				flush(blockchain)
+			}
+		}
+	}
+}
+
+// runFlusher writes batches of blockchain one by one in order they were buffered. Once flush
+// fails, following batches are dropped instead of being committed after the gap.
+func (wp *WritePipeline) runFlusher(blockchain string, batches <-chan *PipelineBatch, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	failed := false
+	for batch := range batches {
+		if failed {
+			continue
+		}
+
+		wp.slots <- struct{}{}
+		err := wp.flush(batch)
+		<-wp.slots
+
+		if err != nil {
+			logging.Chain(blockchain).Error("Write pipeline failed to flush", "rows", batch.Size(), logging.ErrorKey, err)
+			wp.setErr(err)
+			failed = true
+		}
+	}
+}
+
+func (wp *WritePipeline) flush(batch *PipelineBatch) error {
+	if len(batch.Indexes) > 0 {
+		if err := wp.indexStore.WriteIndexes(batch.Blockchain, batch.Indexes); err != nil {
+			return fmt.Errorf("failed to write indexes: %w", err)
+		}
+	}
+
+	if len(batch.Transactions) > 0 || len(batch.Events) > 0 || len(batch.RawTransactions) > 0 {
+		if err := wp.labelsWriter.WriteDataToCustomerDB(batch.Blockchain, batch.Transactions, batch.Events, batch.RawTransactions); err != nil {
+			return fmt.Errorf("failed to write labels: %w", err)
+		}
+	}
+
+	wp.mu.Lock()
+	wp.flushed++
+	wp.flushRows += batch.Size()
+	wp.mu.Unlock()
+
+	return nil
+}
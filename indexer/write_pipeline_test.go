@@ -0,0 +1,80 @@
+package indexer
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingIndexWriter records first block number of each written pack, write of the first
+// pack is slow and write of failAt pack fails.
+type recordingIndexWriter struct {
+	IndexWriter
+
+	mu      sync.Mutex
+	written []uint64
+	failAt  uint64
+}
+
+func (w *recordingIndexWriter) WriteIndexes(blockchain string, blocksIndexPack []BlockIndex) error {
+	blockNumber := blocksIndexPack[0].BlockNumber
+	if blockNumber == 0 {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if w.failAt != 0 && blockNumber == w.failAt {
+		return errors.New("connection reset")
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.written = append(w.written, blockNumber)
+	return nil
+}
+
+func submitTestBatches(wp *WritePipeline, count int) error {
+	for i := 0; i < count; i++ {
+		if err := wp.SubmitIndexes("ethereum", []BlockIndex{{BlockNumber: uint64(i)}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestWritePipelineFlushesInSubmissionOrder(t *testing.T) {
+	writer := &recordingIndexWriter{}
+	wp := NewWritePipeline(writer, nil, WritePipelineConfig{Workers: 4, QueueSize: 8, FlushSize: 1, FlushInterval: time.Hour})
+
+	// First flush is slow, batches submitted after it must not overtake it
+	if err := submitTestBatches(wp, 6); err != nil {
+		t.Fatalf("SubmitIndexes: %v", err)
+	}
+	if err := wp.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	for i, blockNumber := range writer.written {
+		if blockNumber != uint64(i) {
+			t.Fatalf("batches written in order %v", writer.written)
+		}
+	}
+	if len(writer.written) != 6 {
+		t.Fatalf("written %d batches, want 6", len(writer.written))
+	}
+}
+
+func TestWritePipelineDropsBatchesAfterFailedFlush(t *testing.T) {
+	writer := &recordingIndexWriter{failAt: 2}
+	wp := NewWritePipeline(writer, nil, WritePipelineConfig{Workers: 4, QueueSize: 8, FlushSize: 1, FlushInterval: time.Hour})
+
+	// Submit starts failing once flush failed
+	submitTestBatches(wp, 5)
+	if err := wp.Close(); err == nil {
+		t.Fatal("Close does not return error of failed flush")
+	}
+
+	if len(writer.written) != 2 || writer.written[0] != 0 || writer.written[1] != 1 {
+		t.Fatalf("written batches %v, want only batches before failed one", writer.written)
+	}
+}
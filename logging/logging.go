@@ -0,0 +1,111 @@
+// Package logging configures structured logger of seer. Records of indexer and chain clients
+// carry chain, block range, customer and job fields, so logs of many chains and customers
+// could be filtered in log aggregation. Output of standard log package is passed through the
+// same handler.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Names of fields of log records
+const (
+	ChainKey     = "chain"
+	BlockKey     = "block"
+	FromBlockKey = "from_block"
+	ToBlockKey   = "to_block"
+	CustomerKey  = "customer_id"
+	JobKey       = "job_id"
+	TableKey     = "table"
+	TxKey        = "transaction_hash"
+	AddressKey   = "address"
+	ErrorKey     = "error"
+)
+
+// Formats of log output
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+var (
+	SEER_LOG_LEVEL  string
+	SEER_LOG_FORMAT string
+
+	envOnce sync.Once
+)
+
+func readEnv() {
+	envOnce.Do(func() {
+		SEER_LOG_LEVEL = os.Getenv("SEER_LOG_LEVEL")
+		SEER_LOG_FORMAT = os.Getenv("SEER_LOG_FORMAT")
+	})
+}
+
+// ParseLevel parses level name: debug, info, warn or error.
+func ParseLevel(level string) (slog.Level, error) {
+	var parsed slog.Level
+	if err := parsed.UnmarshalText([]byte(strings.TrimSpace(level))); err != nil {
+		return parsed, fmt.Errorf("unknown log level %q, it should be one of debug, info, warn, error", level)
+	}
+	return parsed, nil
+}
+
+// NewHandler creates handler of format writing records of level and above to w.
+func NewHandler(w io.Writer, level slog.Level, format string) (slog.Handler, error) {
+	options := &slog.HandlerOptions{Level: level}
+	switch strings.ToLower(format) {
+	case FormatText:
+		return slog.NewTextHandler(w, options), nil
+	case FormatJSON:
+		return slog.NewJSONHandler(w, options), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q, it should be %s or %s", format, FormatText, FormatJSON)
+	}
+}
+
+// Init sets default logger writing to stderr. Empty level and format are taken from
+// SEER_LOG_LEVEL and SEER_LOG_FORMAT environment variables, info level and text format are
+// used if they are not set either.
+func Init(level, format string) error {
+	readEnv()
+	if level == "" {
+		level = SEER_LOG_LEVEL
+	}
+	if level == "" {
+		level = "info"
+	}
+	if format == "" {
+		format = SEER_LOG_FORMAT
+	}
+	if format == "" {
+		format = FormatText
+	}
+
+	parsedLevel, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	handler, err := NewHandler(os.Stderr, parsedLevel, format)
+	if err != nil {
+		return err
+	}
+
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// Chain returns logger of records about chain.
+func Chain(chain string) *slog.Logger {
+	return slog.Default().With(ChainKey, chain)
+}
+
+// Customer returns logger of records about customer at chain.
+func Customer(chain, customerID string) *slog.Logger {
+	return slog.Default().With(ChainKey, chain, CustomerKey, customerID)
+}
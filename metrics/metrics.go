@@ -0,0 +1,92 @@
+// Package metrics exports Prometheus metrics of crawler and synchronizer. Long-running commands
+// serve them at /metrics when started with --metrics-addr.
+package metrics
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	BlocksCrawled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "seer",
+		Subsystem: "crawler",
+		Name:      "blocks_crawled_total",
+		Help:      "Number of blocks fetched from RPC by crawler.",
+	}, []string{"chain"})
+
+	BlocksBehindHead = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "seer",
+		Name:      "blocks_behind_head",
+		Help:      "Number of blocks between head and the last processed block, head of crawler is latest block of chain and head of synchronizer is latest indexed block.",
+	}, []string{"chain", "component"})
+
+	RPCRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "seer",
+		Subsystem: "rpc",
+		Name:      "requests_total",
+		Help:      "Number of JSON-RPC requests including failed ones, batch requests are counted as method batch.",
+	}, []string{"chain", "method"})
+
+	RPCErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "seer",
+		Subsystem: "rpc",
+		Name:      "errors_total",
+		Help:      "Number of JSON-RPC requests failed after failover and retries.",
+	}, []string{"chain", "method"})
+
+	DecodeFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "seer",
+		Subsystem: "synchronizer",
+		Name:      "decode_failures_total",
+		Help:      "Number of batches which could not be decoded and of raw labels of events and calls which did not match their ABI.",
+	}, []string{"chain", "kind"})
+
+	DBWriteDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "seer",
+		Subsystem: "db",
+		Name:      "write_duration_seconds",
+		Help:      "Duration of writes of blocks indexes and customer labels.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 14),
+	}, []string{"chain", "target"})
+
+	LabelsWritten = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "seer",
+		Subsystem: "synchronizer",
+		Name:      "labels_written_total",
+		Help:      "Number of labels written to databases of customer.",
+	}, []string{"chain", "customer", "label_type"})
+)
+
+func init() {
+	prometheus.MustRegister(BlocksCrawled, BlocksBehindHead, RPCRequests, RPCErrors, DecodeFailures, DBWriteDuration, LabelsWritten)
+}
+
+// ObserveDBWrite records duration of write started at started.
+func ObserveDBWrite(chain, target string, started time.Time) {
+	DBWriteDuration.WithLabelValues(chain, target).Observe(time.Since(started).Seconds())
+}
+
+// SetBlocksBehindHead sets lag of component, processed block above head is zero lag.
+func SetBlocksBehindHead(chain, component string, head, processed uint64) {
+	var behind uint64
+	if head > processed {
+		behind = head - processed
+	}
+	BlocksBehindHead.WithLabelValues(chain, component).Set(float64(behind))
+}
+
+// Serve exposes metrics at /metrics of addr, process exits if listener could not be started.
+func Serve(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	log.Printf("Serving metrics at %s/metrics", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Fatalf("Failed to serve metrics: %v", err)
+	}
+}
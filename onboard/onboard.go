@@ -0,0 +1,262 @@
+// Package onboard runs runbook of adding new EVM chain to seer: preflight of RPC, generation
+// of chain package, registration of chain in sources, build, migration of index database,
+// initial crawl and verification of crawled blocks. Generated package is linked only into
+// rebuilt binary, steps after build are run with it.
+package onboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	seer_common "github.com/G7DAO/seer/blockchain/common"
+)
+
+// Templates of chain packages, they match flags of seer blockchain generate
+const (
+	TemplateEVM       = "evm"
+	TemplateSideChain = "side-chain"
+	TemplateOPStack   = "opstack"
+	TemplateZkSync    = "zksync"
+)
+
+// Statuses of onboarding steps
+const (
+	StepOK      = "ok"
+	StepFailed  = "failed"
+	StepSkipped = "skipped"
+)
+
+// Options of chain onboarding.
+type Options struct {
+	Chain    string
+	RPCURL   string
+	Template string
+	// ChainID is verified against RPC, chain ID returned by RPC is used if it is not set
+	ChainID int64
+
+	// Root of seer sources and absolute path of binary built from them
+	Root   string
+	Output string
+
+	Timeout       int
+	CrawlBlocks   uint64
+	Confirmations uint64
+	SkipCrawl     bool
+
+	// Generate renders chain package from templates, see seer blockchain generate
+	Generate func(chain, template string) error
+}
+
+// StepResult is outcome of one step of onboarding.
+type StepResult struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Detail     string `json:"detail,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// Report is readiness report of onboarded chain, chain is ready if every step succeeded.
+type Report struct {
+	Chain    string                   `json:"chain"`
+	ChainID  int64                    `json:"chain_id"`
+	Template string                   `json:"template"`
+	Probe    *seer_common.ProbeReport `json:"probe,omitempty"`
+	Steps    []StepResult             `json:"steps"`
+	Ready    bool                     `json:"ready"`
+}
+
+// WithL1Chain reports whether blocks of chain of template carry L1 block number.
+func WithL1Chain(template string) bool {
+	return template == TemplateSideChain || template == TemplateZkSync
+}
+
+// ValidateTemplate checks that template is known.
+func ValidateTemplate(template string) error {
+	switch template {
+	case TemplateEVM, TemplateSideChain, TemplateOPStack, TemplateZkSync:
+		return nil
+	default:
+		return fmt.Errorf("unknown template %q, it should be one of %s, %s, %s, %s", template, TemplateEVM, TemplateSideChain, TemplateOPStack, TemplateZkSync)
+	}
+}
+
+type step struct {
+	name string
+	run  func(report *Report) (string, error)
+}
+
+// Run runs steps of onboarding in order. After failed step the rest are skipped, report is
+// returned in any case with error of failed step.
+func Run(opts Options) (*Report, error) {
+	report := &Report{Chain: opts.Chain, ChainID: opts.ChainID, Template: opts.Template}
+
+	var fromBlock, toBlock uint64
+
+	steps := []step{
+		{"preflight", func(report *Report) (string, error) {
+			return preflight(opts, report)
+		}},
+		{"scaffold", func(report *Report) (string, error) {
+			if err := opts.Generate(opts.Chain, opts.Template); err != nil {
+				return "", err
+			}
+			protoGoPath := filepath.Join(opts.Root, "blockchain", opts.Chain, fmt.Sprintf("%s_index_types.pb.go", opts.Chain))
+			if _, err := os.Stat(protoGoPath); err != nil {
+				return "", fmt.Errorf("proto messages of chain are not compiled, protoc is required: %w", err)
+			}
+			return fmt.Sprintf("blockchain/%s", opts.Chain), nil
+		}},
+		{"registry", func(report *Report) (string, error) {
+			changed, err := RegisterChainSources(opts.Root, opts.Chain, report.ChainID, WithL1Chain(opts.Template))
+			if err != nil {
+				return "", err
+			}
+			if len(changed) == 0 {
+				return "chain is already registered", nil
+			}
+			return fmt.Sprintf("updated %v", changed), nil
+		}},
+		{"build", func(report *Report) (string, error) {
+			if err := runCommand(opts.Root, "go", "build", "-o", opts.Output, "."); err != nil {
+				return "", err
+			}
+			return opts.Output, nil
+		}},
+		{"migrations", func(report *Report) (string, error) {
+			if err := runCommand(opts.Root, opts.Output, "chains", "migrate", "--chain", opts.Chain); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%s_blocks table", opts.Chain), nil
+		}},
+		{"crawl", func(report *Report) (string, error) {
+			if opts.SkipCrawl {
+				return "", errSkipped
+			}
+			latest := report.Probe.LatestBlock
+			if latest < opts.Confirmations+opts.CrawlBlocks {
+				return "", fmt.Errorf("chain has %d blocks, initial crawl of %d blocks with %d confirmations is not possible", latest, opts.CrawlBlocks, opts.Confirmations)
+			}
+			toBlock = latest - opts.Confirmations
+			fromBlock = toBlock - opts.CrawlBlocks + 1
+
+			err := runCommand(opts.Root, opts.Output, "crawler",
+				"--chain", opts.Chain,
+				"--rpc-url", opts.RPCURL,
+				"--start-block", strconv.FormatUint(fromBlock, 10),
+				"--final-block", strconv.FormatUint(toBlock, 10),
+				"--confirmations", strconv.FormatUint(opts.Confirmations, 10),
+				"--timeout", strconv.Itoa(opts.Timeout),
+			)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("blocks %d-%d", fromBlock, toBlock), nil
+		}},
+		{"verify", func(report *Report) (string, error) {
+			if opts.SkipCrawl {
+				return "", errSkipped
+			}
+			if err := runCommand(opts.Root, opts.Output, "chains", "verify",
+				"--chain", opts.Chain,
+				"--from-block", strconv.FormatUint(fromBlock, 10),
+				"--to-block", strconv.FormatUint(toBlock, 10),
+			); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("blocks %d-%d are indexed", fromBlock, toBlock), nil
+		}},
+	}
+
+	var runErr error
+	for _, s := range steps {
+		if runErr != nil {
+			report.Steps = append(report.Steps, StepResult{Name: s.name, Status: StepSkipped})
+			continue
+		}
+
+		log.Printf("Onboarding %s: %s", opts.Chain, s.name)
+		started := time.Now()
+		detail, err := s.run(report)
+		result := StepResult{Name: s.name, Status: StepOK, Detail: detail, DurationMs: time.Since(started).Milliseconds()}
+		if errors.Is(err, errSkipped) {
+			result.Status = StepSkipped
+		} else if err != nil {
+			result.Status = StepFailed
+			result.Detail = err.Error()
+			runErr = fmt.Errorf("onboarding step %s failed: %w", s.name, err)
+		}
+		report.Steps = append(report.Steps, result)
+	}
+
+	report.Ready = runErr == nil
+	for _, result := range report.Steps {
+		if result.Status != StepOK {
+			report.Ready = false
+		}
+	}
+
+	return report, runErr
+}
+
+var errSkipped = errors.New("step is skipped")
+
+// preflight probes RPC of chain before anything is generated, RPC should serve expected chain
+// and support eth_getLogs.
+func preflight(opts Options, report *Report) (string, error) {
+	timeout := time.Duration(opts.Timeout) * time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	pool, err := seer_common.DialRPCPool(ctx, opts.RPCURL)
+	cancel()
+	if err != nil {
+		return "", err
+	}
+	defer pool.Close()
+
+	probe, err := seer_common.ProbeRPC(context.Background(), opts.Chain, pool, timeout)
+	if err != nil {
+		return "", err
+	}
+	report.Probe = probe
+
+	if report.ChainID == 0 {
+		report.ChainID = int64(probe.ChainID)
+	} else if uint64(report.ChainID) != probe.ChainID {
+		return "", fmt.Errorf("chain ID mismatch: expected %d but got %d from RPC endpoint", report.ChainID, probe.ChainID)
+	}
+	probe.ExpectedChainID = uint64(report.ChainID)
+
+	if probe.MaxLogsRange == 0 {
+		return "", fmt.Errorf("RPC endpoint does not serve eth_getLogs: %s", probe.Errors["eth_getLogs"])
+	}
+
+	return fmt.Sprintf("chain ID %d, latest block %d, latency %d ms", probe.ChainID, probe.LatestBlock, probe.LatencyMs), nil
+}
+
+// runCommand runs command in directory, its output is passed to stderr of seer. Arguments
+// are not included in error, they could contain RPC URL with API key.
+func runCommand(dir, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		command := []string{filepath.Base(name)}
+		for _, arg := range args {
+			if strings.HasPrefix(arg, "-") {
+				break
+			}
+			command = append(command, arg)
+		}
+		return fmt.Errorf("%s: %w", strings.Join(command, " "), err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package onboard
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RegisterChainSources adds chain to chain IDs map of blockchain package and to table names
+// and L1 chain switches of indexer. Entries which already exist are kept, so onboarding could
+// be run again after failed step. Returns paths of changed files.
+func RegisterChainSources(root, chain string, chainID int64, withL1Chain bool) ([]string, error) {
+	handlersPath := filepath.Join(root, "blockchain", "handlers.go")
+	dbPath := filepath.Join(root, "indexer", "db.go")
+
+	var changed []string
+
+	handlersChanged, err := editSource(handlersPath, func(source string) (string, error) {
+		return insertMapEntry(source, "var BlockchainChainIDs = map[string]int64{", fmt.Sprintf("%q: %d,", chain, chainID), fmt.Sprintf("%q:", chain))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if handlersChanged {
+		changed = append(changed, handlersPath)
+	}
+
+	dbChanged, err := editSource(dbPath, func(source string) (string, error) {
+		cases := []struct {
+			function string
+			result   string
+		}{
+			{"func BlocksTableName(", fmt.Sprintf("%q, nil", chain+"_blocks")},
+			{"func TransactionsTableName(", fmt.Sprintf("%q, nil", chain+"_transactions")},
+			{"func IsBlockchainWithL1Chain(", fmt.Sprintf("%t", withL1Chain)},
+		}
+		for _, c := range cases {
+			source, err = insertSwitchCase(source, c.function, chain, c.result)
+			if err != nil {
+				return "", err
+			}
+		}
+		return source, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if dbChanged {
+		changed = append(changed, dbPath)
+	}
+
+	return changed, nil
+}
+
+// editSource applies edit to Go source file and formats result, file is written only if it
+// was changed.
+func editSource(path string, edit func(string) (string, error)) (bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	edited, err := edit(string(content))
+	if err != nil {
+		return false, fmt.Errorf("failed to edit %s: %w", path, err)
+	}
+	if edited == string(content) {
+		return false, nil
+	}
+
+	formatted, err := format.Source([]byte(edited))
+	if err != nil {
+		return false, fmt.Errorf("failed to format %s: %w", path, err)
+	}
+
+	return true, os.WriteFile(path, formatted, 0644)
+}
+
+// insertMapEntry adds entry as the last element of map literal declared by declaration line,
+// unless map already has element starting with key.
+func insertMapEntry(source, declaration, entry, key string) (string, error) {
+	start := strings.Index(source, declaration)
+	if start < 0 {
+		return "", fmt.Errorf("declaration %q not found", declaration)
+	}
+	end := strings.Index(source[start:], "\n}")
+	if end < 0 {
+		return "", fmt.Errorf("end of %q not found", declaration)
+	}
+	end += start
+
+	if strings.Contains(source[start:end], "\t"+key) {
+		return source, nil
+	}
+
+	return source[:end] + "\n\t" + entry + source[end:], nil
+}
+
+// insertSwitchCase adds case of chain before default branch of switch of function, unless
+// function already has case of chain.
+func insertSwitchCase(source, function, chain, result string) (string, error) {
+	start := strings.Index(source, function)
+	if start < 0 {
+		return "", fmt.Errorf("function %q not found", function)
+	}
+	end := strings.Index(source[start:], "\n}\n")
+	if end < 0 {
+		return "", fmt.Errorf("end of function %q not found", function)
+	}
+	end += start
+
+	if strings.Contains(source[start:end], fmt.Sprintf("case %q:", chain)) {
+		return source, nil
+	}
+
+	defaultBranch := strings.Index(source[start:end], "\tdefault:")
+	if defaultBranch < 0 {
+		return "", fmt.Errorf("default branch of function %q not found", function)
+	}
+	defaultBranch += start
+
+	return source[:defaultBranch] + fmt.Sprintf("\tcase %q:\n\t\treturn %s\n\t", chain, result) + source[defaultBranch:], nil
+}
@@ -12,7 +12,15 @@ done
 BLOCKCHAIN_NAMES_RAW=$(find blockchain/ -maxdepth 1 -type d | cut -f2 -d '/')
 for BLOCKCHAIN in $BLOCKCHAIN_NAMES_RAW; do
   if [ "$BLOCKCHAIN" != "" ] && [ "$BLOCKCHAIN" != "common" ]; then
-    if [ "$BLOCKCHAIN" != "ethereum" ] && [ "$BLOCKCHAIN" != "polygon" ] && [ "$BLOCKCHAIN" != "mantle" ] && [ "$BLOCKCHAIN" != "mantle_sepolia" ] && [ "$BLOCKCHAIN" != "sepolia" ] && [ "$BLOCKCHAIN" != "imx_zkevm" ] && [ "$BLOCKCHAIN" != "imx_zkevm_sepolia" ] && [ "$BLOCKCHAIN" != "b3" ] && [ "$BLOCKCHAIN" != "b3_sepolia" ] && [ "$BLOCKCHAIN" != "ronin" ] && [ "$BLOCKCHAIN" != "ronin_saigon" ]; then
+    if [ "$BLOCKCHAIN" = "solana" ] || [ "$BLOCKCHAIN" = "starknet" ]; then
+      echo "Skipped non-EVM blockchain $BLOCKCHAIN, its client is not generated"
+    elif [ "$BLOCKCHAIN" = "base" ] || [ "$BLOCKCHAIN" = "base_sepolia" ] || [ "$BLOCKCHAIN" = "optimism" ] || [ "$BLOCKCHAIN" = "op_sepolia" ]; then
+      ./seer blockchain generate -n $BLOCKCHAIN --op-stack
+      echo "Generated interface for OP stack blockchain $BLOCKCHAIN"
+    elif [ "$BLOCKCHAIN" = "zksync_era" ]; then
+      ./seer blockchain generate -n $BLOCKCHAIN --zksync
+      echo "Generated interface for zkSync blockchain $BLOCKCHAIN"
+    elif [ "$BLOCKCHAIN" != "ethereum" ] && [ "$BLOCKCHAIN" != "polygon" ] && [ "$BLOCKCHAIN" != "mantle" ] && [ "$BLOCKCHAIN" != "mantle_sepolia" ] && [ "$BLOCKCHAIN" != "sepolia" ] && [ "$BLOCKCHAIN" != "imx_zkevm" ] && [ "$BLOCKCHAIN" != "imx_zkevm_sepolia" ] && [ "$BLOCKCHAIN" != "b3" ] && [ "$BLOCKCHAIN" != "b3_sepolia" ] && [ "$BLOCKCHAIN" != "ronin" ] && [ "$BLOCKCHAIN" != "ronin_saigon" ] && [ "$BLOCKCHAIN" != "hyperevm" ] && [ "$BLOCKCHAIN" != "hyperevm_testnet" ]; then
       ./seer blockchain generate -n $BLOCKCHAIN --side-chain
       echo "Generated interface for side-chain blockchain $BLOCKCHAIN"
     else
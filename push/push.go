@@ -0,0 +1,299 @@
+// Package push sends labels written to customer databases to Moonstream API or any HTTP
+// endpoint ingesting labels. Labels are collected into batches and sent in background, when
+// endpoint falls behind callers are held back until queue has room.
+package push
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/G7DAO/seer/indexer"
+	"github.com/G7DAO/seer/logging"
+	"github.com/G7DAO/seer/version"
+)
+
+var (
+	DefaultTimeout       = 30 * time.Second
+	DefaultBatchSize     = 1000
+	DefaultFlushInterval = 5 * time.Second
+	DefaultQueueSize     = 100
+	// Caller waits at most DefaultEnqueueTimeout for room in full queue before batch is dropped
+	DefaultEnqueueTimeout = time.Minute
+	DefaultRetries        = 5
+	DefaultRetryDelay     = time.Second
+	MaxRetryDelay         = time.Minute
+)
+
+// ErrQueueFull is returned when batch could not be queued in time.
+var ErrQueueFull = errors.New("push queue is full")
+
+// Labels are labels of customer written for blocks range.
+type Labels struct {
+	CustomerID   string                     `json:"customer_id"`
+	FromBlock    uint64                     `json:"from_block"`
+	ToBlock      uint64                     `json:"to_block"`
+	Events       []indexer.EventLabel       `json:"events"`
+	Transactions []indexer.TransactionLabel `json:"transactions"`
+}
+
+// Request is body of POST to ingestion endpoint.
+type Request struct {
+	Chain  string   `json:"chain"`
+	Labels []Labels `json:"labels"`
+}
+
+func (r Request) count() int {
+	count := 0
+	for _, labels := range r.Labels {
+		count += len(labels.Events) + len(labels.Transactions)
+	}
+	return count
+}
+
+// Pusher batches labels of chain and posts them to URL with bearer token. Requests have at
+// most BatchSize labels, collected labels are sent after FlushInterval even if batch is not
+// full. Endpoint responding 429 or 5xx is retried with backoff, Retry-After is respected.
+type Pusher struct {
+	URL            string
+	Token          string
+	BatchSize      int
+	FlushInterval  time.Duration
+	EnqueueTimeout time.Duration
+	Retries        int
+	RetryDelay     time.Duration
+
+	chain  string
+	client *http.Client
+
+	mu             sync.Mutex
+	pending        Request
+	pendingLabels  int
+	pendingSinceAt time.Time
+	closed         bool
+
+	queue     chan Request
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func NewPusher(chain, url, token string, batchSize int, flushInterval time.Duration) *Pusher {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	if flushInterval <= 0 {
+		flushInterval = DefaultFlushInterval
+	}
+
+	pusher := &Pusher{
+		URL:            url,
+		Token:          token,
+		BatchSize:      batchSize,
+		FlushInterval:  flushInterval,
+		EnqueueTimeout: DefaultEnqueueTimeout,
+		Retries:        DefaultRetries,
+		RetryDelay:     DefaultRetryDelay,
+
+		chain:   chain,
+		client:  &http.Client{Timeout: DefaultTimeout},
+		pending: Request{Chain: chain},
+		queue:   make(chan Request, DefaultQueueSize),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	go pusher.run()
+	go pusher.flushLoop()
+
+	return pusher
+}
+
+// PublishLabels adds labels to batch, full batches are queued. Labels of one call are split
+// into parts if they do not fit into batch.
+func (p *Pusher) PublishLabels(customerID string, events []indexer.EventLabel, transactions []indexer.TransactionLabel, fromBlock, toBlock uint64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return fmt.Errorf("pusher of %s is closed", p.chain)
+	}
+
+	for len(events)+len(transactions) > 0 {
+		if p.pendingLabels >= p.BatchSize {
+			if err := p.flushLocked(); err != nil {
+				return err
+			}
+		}
+
+		part := Labels{CustomerID: customerID, FromBlock: fromBlock, ToBlock: toBlock}
+		room := p.BatchSize - p.pendingLabels
+		n := min(room, len(events))
+		part.Events, events = events[:n], events[n:]
+		n = min(room-n, len(transactions))
+		part.Transactions, transactions = transactions[:n], transactions[n:]
+
+		if p.pendingLabels == 0 {
+			p.pendingSinceAt = time.Now()
+		}
+		p.pending.Labels = append(p.pending.Labels, part)
+		p.pendingLabels += len(part.Events) + len(part.Transactions)
+	}
+
+	if p.pendingLabels >= p.BatchSize {
+		return p.flushLocked()
+	}
+
+	return nil
+}
+
+// PublishRawBlocks does nothing, only labels are pushed.
+func (p *Pusher) PublishRawBlocks(rawData []bytes.Buffer, fromBlock, toBlock uint64) error {
+	return nil
+}
+
+// flushLocked queues collected labels, caller is held while queue is full, so synchronizer
+// slows down to pace of endpoint.
+func (p *Pusher) flushLocked() error {
+	if p.pendingLabels == 0 {
+		return nil
+	}
+
+	request, count := p.pending, p.pendingLabels
+	p.pending, p.pendingLabels = Request{Chain: p.chain}, 0
+
+	select {
+	case p.queue <- request:
+		return nil
+	default:
+	}
+
+	logging.Chain(p.chain).Warn("Push queue is full, waiting for room", "labels", count)
+
+	timer := time.NewTimer(p.EnqueueTimeout)
+	defer timer.Stop()
+
+	select {
+	case p.queue <- request:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("%w after %s, %d labels are dropped", ErrQueueFull, p.EnqueueTimeout, count)
+	}
+}
+
+func (p *Pusher) flushLoop() {
+	ticker := time.NewTicker(p.FlushInterval / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.mu.Lock()
+			if p.pendingLabels > 0 && time.Since(p.pendingSinceAt) >= p.FlushInterval {
+				if err := p.flushLocked(); err != nil {
+					logging.Chain(p.chain).Error("Failed to push labels", logging.ErrorKey, err)
+				}
+			}
+			p.mu.Unlock()
+		}
+	}
+}
+
+// run sends queued requests one by one, so labels reach endpoint in order they were written.
+func (p *Pusher) run() {
+	defer close(p.done)
+
+	for request := range p.queue {
+		if err := p.send(request); err != nil {
+			logging.Chain(p.chain).Error("Failed to push labels, they are dropped", "labels", request.count(), logging.ErrorKey, err)
+		}
+	}
+}
+
+// send posts request with retries of transient failures.
+func (p *Pusher) send(request Request) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	delay := p.RetryDelay
+	for attempt := 0; ; attempt++ {
+		retryAfter, retryable, err := p.post(body)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt >= p.Retries {
+			return err
+		}
+
+		wait := delay
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		logging.Chain(p.chain).Warn("Push of labels failed, retrying", "attempt", attempt+1, "wait", wait, logging.ErrorKey, err)
+		time.Sleep(wait)
+
+		delay = min(delay*2, MaxRetryDelay)
+	}
+}
+
+// post returns delay requested by endpoint and whether request could be retried.
+func (p *Pusher) post(body []byte) (time.Duration, bool, error) {
+	req, err := http.NewRequest(http.MethodPost, p.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "seer/"+version.SeerVersion)
+	if p.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.Token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return 0, false, nil
+	}
+
+	err = fmt.Errorf("push endpoint responded with status %d", resp.StatusCode)
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		var retryAfter time.Duration
+		if seconds, parseErr := strconv.Atoi(resp.Header.Get("Retry-After")); parseErr == nil && seconds > 0 {
+			retryAfter = min(time.Duration(seconds)*time.Second, MaxRetryDelay)
+		}
+		return retryAfter, true, err
+	}
+
+	return 0, false, err
+}
+
+// Close sends collected labels and waits until queued requests are sent.
+func (p *Pusher) Close() error {
+	var err error
+	p.closeOnce.Do(func() {
+		close(p.stop)
+
+		p.mu.Lock()
+		err = p.flushLocked()
+		p.closed = true
+		p.mu.Unlock()
+
+		close(p.queue)
+		<-p.done
+	})
+	return err
+}
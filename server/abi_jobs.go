@@ -0,0 +1,77 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+// Jobs checked per request, keeps request within server write timeout. Client continues
+// with next_cursor from response until done is true.
+const ensureSelectorsMaxJobsPerRequest = 1000
+
+func (server *Server) abiJobsEnsureSelectorsRoute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Jobs of all customers of blockchain are checked and updated
+	if !server.isAdmin(r) {
+		http.Error(w, "ensure selectors is available only for admin users", http.StatusForbidden)
+		return
+	}
+
+	blockchainQe := r.URL.Query().Get("blockchain")
+	if blockchainQe == "" {
+		http.Error(w, "blockchain is required", http.StatusBadRequest)
+		return
+	}
+
+	options := indexer.EnsureSelectorsOptions{
+		Cursor:  r.URL.Query().Get("cursor"),
+		MaxJobs: ensureSelectorsMaxJobsPerRequest,
+	}
+	if options.Cursor != "" {
+		if uuidErr := uuid.Validate(options.Cursor); uuidErr != nil {
+			http.Error(w, "cursor should be next_cursor from previous response", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Selectors are updated only with explicit POST request
+	if r.URL.Query().Get("write_to_db") == "true" {
+		if r.Method != http.MethodPost {
+			http.Error(w, "write_to_db requires POST request", http.StatusMethodNotAllowed)
+			return
+		}
+		options.WriteToDB = true
+	}
+
+	maxJobsQe := r.URL.Query().Get("max_jobs")
+	if maxJobsQe != "" {
+		maxJobs, atoiErr := strconv.Atoi(maxJobsQe)
+		if atoiErr != nil || maxJobs < 1 || maxJobs > ensureSelectorsMaxJobsPerRequest {
+			http.Error(w, "max_jobs should be an integer between 1 and 1000", http.StatusBadRequest)
+			return
+		}
+		options.MaxJobs = maxJobs
+	}
+	options.ChunkSize = options.MaxJobs
+
+	result, ensureErr := server.DbPool.EnsureCorrectSelectors(blockchainQe, options)
+	if ensureErr != nil {
+		log.Printf("Unable to ensure ABI jobs selectors, err: %v", ensureErr)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	setAuditRowCount(r, result.Checked)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,201 @@
+package server
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	seer_common "github.com/G7DAO/seer/blockchain/common"
+	"github.com/G7DAO/seer/indexer"
+)
+
+var (
+	// ENS names are resolved within this time of request, addresses left unresolved are
+	// returned without labels and resolved by next requests
+	ENSLookupTimeout     = 3 * time.Second
+	ENSLookupConcurrency = 8
+	ENSCacheTTL          = 24 * time.Hour
+
+	// Lookups left in background are cancelled after this time
+	ENSBackgroundLookupTimeout = 30 * time.Second
+
+	ensCacheLimit = 100000
+)
+
+// ENSBlockchain is the only chain with ENS registry known to resolver.
+const ENSBlockchain = "ethereum"
+
+type ensCacheEntry struct {
+	name       string
+	resolvedAt time.Time
+}
+
+// AddressResolver labels addresses of analytics responses with entries of address book and,
+// on Ethereum, with primary ENS names. Address book entries take precedence over ENS names.
+type AddressResolver struct {
+	store *indexer.PostgreSQLpgx
+
+	// ensClient is Ethereum client for reverse lookups, nil disables ENS
+	ensClient seer_common.ChainClient
+
+	mu         sync.Mutex
+	ensCache   map[string]ensCacheEntry
+	ensPending map[string]bool
+}
+
+// NewAddressResolver creates address book table if required, ensClient could be nil.
+func NewAddressResolver(store *indexer.PostgreSQLpgx, ensClient seer_common.ChainClient) (*AddressResolver, error) {
+	if err := store.EnsureAddressBookTable(); err != nil {
+		return nil, err
+	}
+
+	return &AddressResolver{
+		store:      store,
+		ensClient:  ensClient,
+		ensCache:   make(map[string]ensCacheEntry),
+		ensPending: make(map[string]bool),
+	}, nil
+}
+
+// Resolve returns labels of addresses of blockchain keyed by lowercase address. Labels are
+// best effort, failed lookups are logged and addresses are left without labels.
+func (r *AddressResolver) Resolve(ctx context.Context, blockchain string, addresses []string) map[string]indexer.AddressBookEntry {
+	labels, err := r.store.GetAddressBookEntries(ctx, blockchain, addresses)
+	if err != nil {
+		log.Printf("Unable to read address book of %s, err: %v", blockchain, err)
+		labels = make(map[string]indexer.AddressBookEntry)
+	}
+
+	if r.ensClient == nil || blockchain != ENSBlockchain {
+		return labels
+	}
+
+	var unresolved []string
+	for _, address := range addresses {
+		address = strings.ToLower(address)
+		if _, exists := labels[address]; exists || !common.IsHexAddress(address) {
+			continue
+		}
+
+		name, cached := r.cachedENSName(address)
+		if !cached {
+			unresolved = append(unresolved, address)
+			continue
+		}
+		if name != "" {
+			labels[address] = ensEntry(blockchain, address, name)
+		}
+	}
+
+	for address, name := range r.resolveENSNames(ctx, unresolved) {
+		labels[address] = ensEntry(blockchain, address, name)
+	}
+
+	return labels
+}
+
+func ensEntry(blockchain, address, name string) indexer.AddressBookEntry {
+	return indexer.AddressBookEntry{
+		Blockchain: blockchain,
+		Address:    address,
+		Label:      name,
+		Category:   indexer.AddressCategoryENS,
+	}
+}
+
+// cachedENSName returns name of address if it was resolved within ENSCacheTTL, empty name of
+// cached entry means that address has no primary name.
+func (r *AddressResolver) cachedENSName(address string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, exists := r.ensCache[address]
+	if !exists || time.Since(entry.resolvedAt) > ENSCacheTTL {
+		return "", false
+	}
+	return entry.name, true
+}
+
+// resolveENSNames looks up names of addresses concurrently. Lookups continue in background
+// after ENSLookupTimeout and fill cache for next requests, addresses already being looked up
+// by other requests are skipped.
+func (r *AddressResolver) resolveENSNames(ctx context.Context, addresses []string) map[string]string {
+	names := make(map[string]string)
+	if len(addresses) == 0 {
+		return names
+	}
+
+	var lookups []string
+	r.mu.Lock()
+	for _, address := range addresses {
+		if r.ensPending[address] {
+			continue
+		}
+		r.ensPending[address] = true
+		lookups = append(lookups, address)
+	}
+	r.mu.Unlock()
+
+	var namesMu sync.Mutex
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		lookupCtx, cancel := context.WithTimeout(context.Background(), ENSBackgroundLookupTimeout)
+		defer cancel()
+
+		semaphore := make(chan struct{}, ENSLookupConcurrency)
+		var wg sync.WaitGroup
+		for _, address := range lookups {
+			wg.Add(1)
+			semaphore <- struct{}{}
+			go func(address string) {
+				defer wg.Done()
+				defer func() { <-semaphore }()
+
+				name, err := seer_common.ENSReverseName(lookupCtx, r.ensClient, common.HexToAddress(address))
+				r.mu.Lock()
+				delete(r.ensPending, address)
+				if err == nil {
+					if len(r.ensCache) >= ensCacheLimit {
+						r.ensCache = make(map[string]ensCacheEntry)
+					}
+					r.ensCache[address] = ensCacheEntry{name: name, resolvedAt: time.Now()}
+				}
+				r.mu.Unlock()
+
+				if err != nil {
+					log.Printf("Unable to resolve ENS name of %s, err: %v", address, err)
+					return
+				}
+				if name != "" {
+					namesMu.Lock()
+					names[address] = name
+					namesMu.Unlock()
+				}
+			}(address)
+		}
+		wg.Wait()
+	}()
+
+	timer := time.NewTimer(ENSLookupTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	namesMu.Lock()
+	defer namesMu.Unlock()
+
+	resolved := make(map[string]string, len(names))
+	for address, name := range names {
+		resolved[address] = name
+	}
+	return resolved
+}
@@ -0,0 +1,276 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bugout-dev/bugout-go/pkg/brood"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+var (
+	// Entries are written in batches, call is not logged if buffer is full
+	AuditLogBufferSize    = 10000
+	AuditLogBatchSize     = 500
+	AuditLogFlushInterval = 5 * time.Second
+
+	AuditLogRetentionCheckInterval = time.Hour
+
+	// Request bodies larger than this are recorded only by size
+	auditMaxBodySize = 64 * 1024
+)
+
+// AuditLogger records read API calls of consumers to audit log table. Entries are
+// buffered and written in background, so database latency does not slow down responses.
+type AuditLogger struct {
+	store     *indexer.PostgreSQLpgx
+	retention time.Duration
+
+	entries chan indexer.AuditLogEntry
+	dropped int64
+	mu      sync.Mutex
+}
+
+// NewAuditLogger creates audit log table if required. Entries older than retention are
+// removed periodically, zero retention keeps entries forever.
+func NewAuditLogger(store *indexer.PostgreSQLpgx, retention time.Duration) (*AuditLogger, error) {
+	if err := store.EnsureAuditLogTable(); err != nil {
+		return nil, err
+	}
+
+	return &AuditLogger{
+		store:     store,
+		retention: retention,
+		entries:   make(chan indexer.AuditLogEntry, AuditLogBufferSize),
+	}, nil
+}
+
+// Record queues entry for write, it never blocks.
+func (a *AuditLogger) Record(entry indexer.AuditLogEntry) {
+	select {
+	case a.entries <- entry:
+	default:
+		a.mu.Lock()
+		a.dropped++
+		a.mu.Unlock()
+	}
+}
+
+// Run writes queued entries and applies retention until context is done, remaining
+// entries are flushed before return.
+func (a *AuditLogger) Run(ctx context.Context) {
+	flushTicker := time.NewTicker(AuditLogFlushInterval)
+	defer flushTicker.Stop()
+
+	retentionTicker := time.NewTicker(AuditLogRetentionCheckInterval)
+	defer retentionTicker.Stop()
+
+	a.applyRetention()
+
+	batch := make([]indexer.AuditLogEntry, 0, AuditLogBatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case entry := <-a.entries:
+					batch = append(batch, entry)
+				default:
+					a.flush(batch)
+					return
+				}
+			}
+		case entry := <-a.entries:
+			batch = append(batch, entry)
+			if len(batch) >= AuditLogBatchSize {
+				a.flush(batch)
+				batch = batch[:0]
+			}
+		case <-flushTicker.C:
+			a.flush(batch)
+			batch = batch[:0]
+		case <-retentionTicker.C:
+			a.applyRetention()
+		}
+	}
+}
+
+func (a *AuditLogger) flush(batch []indexer.AuditLogEntry) {
+	a.mu.Lock()
+	dropped := a.dropped
+	a.dropped = 0
+	a.mu.Unlock()
+	if dropped > 0 {
+		log.Printf("Audit log buffer is full, %d entries were not recorded", dropped)
+	}
+
+	if len(batch) == 0 {
+		return
+	}
+	if err := a.store.InsertAuditLogEntries(batch); err != nil {
+		log.Printf("Failed to write %d audit log entries, err: %v", len(batch), err)
+	}
+}
+
+func (a *AuditLogger) applyRetention() {
+	if a.retention <= 0 {
+		return
+	}
+
+	deleted, err := a.store.DeleteAuditLogEntriesBefore(time.Now().Add(-a.retention))
+	if err != nil {
+		log.Printf("Failed to apply audit log retention, err: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("Removed %d audit log entries older than %s", deleted, a.retention)
+	}
+}
+
+// auditRecord is filled by handler with number of returned rows.
+type auditRecord struct {
+	rowCount int
+}
+
+type auditContextKey struct{}
+
+// setAuditRowCount records number of rows returned by handler to audit log entry of request.
+func setAuditRowCount(r *http.Request, rowCount int) {
+	if record, ok := r.Context().Value(auditContextKey{}).(*auditRecord); ok {
+		record.rowCount = rowCount
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+// auditMiddleware records consumer, parameters, response status, row count and duration of
+// call. It runs after access middleware, which puts authorized user into context.
+func (server *Server) auditMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if server.AuditLogger == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		parameters := map[string]interface{}{}
+		for key, values := range r.URL.Query() {
+			if len(values) == 1 {
+				parameters[key] = values[0]
+			} else {
+				parameters[key] = values
+			}
+		}
+		if r.Body != nil {
+			body, readErr := io.ReadAll(r.Body)
+			if readErr != nil {
+				http.Error(w, "Unable to read body", http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewBuffer(body))
+			if len(body) > auditMaxBodySize {
+				parameters["body_size"] = len(body)
+			} else if len(body) > 0 {
+				parameters["body"] = string(body)
+			}
+		}
+		parametersRaw, marshalErr := json.Marshal(parameters)
+		if marshalErr != nil {
+			parametersRaw = []byte("{}")
+		}
+
+		record := &auditRecord{}
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		started := time.Now()
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), auditContextKey{}, record)))
+		duration := time.Since(started)
+
+		entry := indexer.AuditLogEntry{
+			Method:     r.Method,
+			Endpoint:   r.URL.Path,
+			Parameters: parametersRaw,
+			Status:     recorder.status,
+			RowCount:   record.rowCount,
+			DurationMs: duration.Milliseconds(),
+			RemoteAddr: requestIP(r),
+			CreatedAt:  started,
+		}
+		if user, ok := r.Context().Value(userContextKey{}).(brood.AuthUser); ok {
+			entry.ConsumerID = user.UserId
+			entry.ConsumerName = user.Username
+		}
+
+		server.AuditLogger.Record(entry)
+	})
+}
+
+func requestIP(r *http.Request) string {
+	if realIp := r.Header.Get("X-Real-Ip"); realIp != "" {
+		return realIp
+	}
+	ip, _, splitErr := net.SplitHostPort(r.RemoteAddr)
+	if splitErr != nil {
+		return r.RemoteAddr
+	}
+	return ip
+}
+
+// WriteAuditLog writes entries to w as CSV with header or as JSON lines.
+func WriteAuditLog(w io.Writer, entries []indexer.AuditLogEntry, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		for _, entry := range entries {
+			if err := encoder.Encode(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "csv":
+		csvWriter := csv.NewWriter(w)
+		if err := csvWriter.Write([]string{"id", "created_at", "consumer_id", "consumer_name", "method", "endpoint", "parameters", "status", "row_count", "duration_ms", "remote_addr"}); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			record := []string{
+				strconv.FormatInt(entry.ID, 10),
+				entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+				entry.ConsumerID,
+				entry.ConsumerName,
+				entry.Method,
+				entry.Endpoint,
+				string(entry.Parameters),
+				strconv.Itoa(entry.Status),
+				strconv.Itoa(entry.RowCount),
+				strconv.FormatInt(entry.DurationMs, 10),
+				entry.RemoteAddr,
+			}
+			if err := csvWriter.Write(record); err != nil {
+				return err
+			}
+		}
+		csvWriter.Flush()
+		return csvWriter.Error()
+	default:
+		return fmt.Errorf("unsupported audit log export format %q, use csv or json", format)
+	}
+}
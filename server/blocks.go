@@ -0,0 +1,83 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+func (server *Server) blocksSearchRoute(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	blockchainQe := query.Get("blockchain")
+	if blockchainQe == "" {
+		http.Error(w, "blockchain is required", http.StatusBadRequest)
+		return
+	}
+
+	filter := indexer.BlocksFilter{
+		Hash:       query.Get("hash"),
+		ParentHash: query.Get("parent_hash"),
+		Miner:      query.Get("miner"),
+		Cursor:     query.Get("cursor"),
+		Descending: query.Get("order") == "desc",
+	}
+
+	if filter.Miner != "" && !common.IsHexAddress(filter.Miner) {
+		http.Error(w, "Incorrect miner address", http.StatusBadRequest)
+		return
+	}
+
+	uintParams := map[string]*uint64{
+		"from_block":     &filter.FromBlock,
+		"to_block":       &filter.ToBlock,
+		"from_timestamp": &filter.FromTimestamp,
+		"to_timestamp":   &filter.ToTimestamp,
+	}
+	for name, value := range uintParams {
+		valueQe := query.Get(name)
+		if valueQe == "" {
+			continue
+		}
+		var parseUintErr error
+		*value, parseUintErr = strconv.ParseUint(valueQe, 10, 64)
+		if parseUintErr != nil {
+			http.Error(w, name+" should be an integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	limitQe := query.Get("limit")
+	if limitQe != "" {
+		var atoiErr error
+		filter.Limit, atoiErr = strconv.Atoi(limitQe)
+		if atoiErr != nil || filter.Limit < 1 || filter.Limit > indexer.MaxBlocksPageLimit {
+			http.Error(w, "limit should be an integer between 1 and "+strconv.Itoa(indexer.MaxBlocksPageLimit), http.StatusBadRequest)
+			return
+		}
+	}
+
+	page, searchErr := server.DbPool.SearchBlocks(blockchainQe, filter)
+	if searchErr != nil {
+		switch {
+		case errors.Is(searchErr, indexer.ErrUnsupportedChain):
+			http.Error(w, "Unsupported blockchain", http.StatusBadRequest)
+		case errors.Is(searchErr, indexer.ErrInvalidFilter):
+			http.Error(w, searchErr.Error(), http.StatusBadRequest)
+		default:
+			log.Printf("Unable to search blocks, err: %v", searchErr)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	setAuditRowCount(r, len(page.Blocks))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
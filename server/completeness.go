@@ -0,0 +1,41 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+var (
+	CompletenessCacheTTL           = 30 * time.Second
+	CompletenessFullRescanInterval = 1 * time.Hour
+)
+
+func (server *Server) completenessRoute(w http.ResponseWriter, r *http.Request) {
+	blockchainQe := r.URL.Query().Get("blockchain")
+	if blockchainQe == "" {
+		http.Error(w, "blockchain is required", http.StatusBadRequest)
+		return
+	}
+
+	stats, statsErr := server.completeness.Get(blockchainQe)
+	if statsErr != nil {
+		switch {
+		case errors.Is(statsErr, indexer.ErrUnsupportedChain):
+			http.Error(w, "Unsupported blockchain", http.StatusBadRequest)
+		case errors.Is(statsErr, indexer.ErrNoRowsIndexed):
+			http.Error(w, "No blocks indexed yet", http.StatusNotFound)
+		default:
+			log.Printf("Unable to calculate chain completeness, err: %v", statsErr)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
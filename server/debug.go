@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/bugout-dev/bugout-go/pkg/brood"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+// DebugResponse wraps results of read API requested with debug=true.
+type DebugResponse struct {
+	Result interface{}              `json:"result"`
+	Debug  indexer.QueryDebugReport `json:"debug"`
+}
+
+// isAdmin returns true if request is authorized by user listed in SEER_API_ADMIN_USER_IDS.
+func (server *Server) isAdmin(r *http.Request) bool {
+	user, ok := r.Context().Value(userContextKey{}).(brood.AuthUser)
+	return ok && SEER_API_ADMIN_USER_IDS[user.UserId]
+}
+
+// authorizeCustomer returns true if labels of customer are available to user of request, admins
+// could read labels of any customer. False is returned after error was written to response.
+func (server *Server) authorizeCustomer(w http.ResponseWriter, r *http.Request, customerID string) bool {
+	if server.isAdmin(r) {
+		return true
+	}
+
+	user, ok := r.Context().Value(userContextKey{}).(brood.AuthUser)
+	if !ok || customerID == "" {
+		http.Error(w, "customer_id is required", http.StatusForbidden)
+		return false
+	}
+
+	owned, err := server.DbPool.IsCustomerOfUser(r.Context(), customerID, user.UserId)
+	if err != nil {
+		log.Printf("Unable to check customer %s of user %s, err: %v", customerID, user.UserId, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return false
+	}
+	if !owned {
+		http.Error(w, "Customer is not available for user", http.StatusForbidden)
+		return false
+	}
+
+	return true
+}
+
+// debugContext returns context for database queries of request. With debug=true executed
+// statements are recorded, such requests are allowed only for admins and false is returned
+// after error was written to response.
+func (server *Server) debugContext(w http.ResponseWriter, r *http.Request) (context.Context, *indexer.QueryDebug, bool) {
+	if r.URL.Query().Get("debug") != "true" {
+		return r.Context(), nil, true
+	}
+
+	if !server.isAdmin(r) {
+		http.Error(w, "debug is available only for admin users", http.StatusForbidden)
+		return nil, nil, false
+	}
+
+	ctx, debug := indexer.WithQueryDebug(r.Context())
+	return ctx, debug, true
+}
+
+// writeResult encodes response, in debug mode it is returned together with executed
+// statements, their timings and planner estimates.
+func (server *Server) writeResult(w http.ResponseWriter, r *http.Request, debug *indexer.QueryDebug, result interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if debug == nil {
+		json.NewEncoder(w).Encode(result)
+		return
+	}
+
+	json.NewEncoder(w).Encode(DebugResponse{
+		Result: result,
+		Debug:  server.DbPool.ExplainQueries(r.Context(), debug),
+	})
+}
@@ -0,0 +1,286 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bugout-dev/bugout-go/pkg/brood"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/G7DAO/seer/indexer"
+	"github.com/G7DAO/seer/server/labelstream"
+)
+
+// Number of labels in chunk of labels stream if client did not set it
+var DefaultLabelsStreamChunkSize = 1000
+
+// RunGRPC serves gRPC API for bulk consumers. It uses the same authorization and audit log
+// as HTTP API.
+func (server *Server) RunGRPC(host string, port int) {
+	listener, listenErr := net.Listen("tcp", fmt.Sprintf("%s:%d", host, port))
+	if listenErr != nil {
+		log.Printf("Failed to start gRPC server listener, err: %v", listenErr)
+		os.Exit(1)
+	}
+
+	grpcServer := grpc.NewServer(grpc.StreamInterceptor(server.grpcStreamInterceptor))
+	labelstream.RegisterLabelsStreamServer(grpcServer, &labelsStreamServer{server: server})
+
+	if serveErr := grpcServer.Serve(listener); serveErr != nil {
+		log.Printf("Failed to serve gRPC API, err: %v", serveErr)
+		os.Exit(1)
+	}
+}
+
+// grpcServerStream replaces context of stream and keeps request for audit log.
+type grpcServerStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	request proto.Message
+}
+
+func (s *grpcServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *grpcServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.request == nil {
+		s.request, _ = m.(proto.Message)
+	}
+	return err
+}
+
+// grpcAuthUser verifies Moonstream token passed in authorization metadata as with HTTP API.
+func (server *Server) grpcAuthUser(ctx context.Context) (brood.AuthUser, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	authHeaders := md.Get("authorization")
+	if len(authHeaders) == 0 {
+		return brood.AuthUser{}, status.Error(codes.Unauthenticated, "No authorization token was passed with the request")
+	}
+
+	authToken, found := strings.CutPrefix(authHeaders[0], "Bearer ")
+	if !found || uuid.Validate(authToken) != nil {
+		return brood.AuthUser{}, status.Error(codes.Unauthenticated, "Invalid authorization token provided")
+	}
+
+	userAuth, auErr := server.BugoutClient.Brood.Auth(authToken)
+	if auErr != nil {
+		if auErr.Error() == "Invalid status code in HTTP response: 404" {
+			return brood.AuthUser{}, status.Error(codes.Unauthenticated, "User not found")
+		}
+		log.Printf("Failed to fetch bugout user, err: %v", auErr)
+		return brood.AuthUser{}, status.Error(codes.Internal, "Internal server error")
+	}
+
+	if userAuth.ApplicationId != MOONSTREAM_APPLICATION_ID {
+		return brood.AuthUser{}, status.Error(codes.PermissionDenied, "Invalid user token provided. Use a valid Moonstream token")
+	}
+
+	return userAuth, nil
+}
+
+// grpcStreamInterceptor authorizes stream and records it to audit log once it is finished.
+func (server *Server) grpcStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	userAuth, authErr := server.grpcAuthUser(ss.Context())
+	if authErr != nil {
+		return authErr
+	}
+
+	record := &auditRecord{}
+	ctx := context.WithValue(ss.Context(), userContextKey{}, userAuth)
+	ctx = context.WithValue(ctx, auditContextKey{}, record)
+	stream := &grpcServerStream{ServerStream: ss, ctx: ctx}
+
+	started := time.Now()
+	handlerErr := handler(srv, stream)
+	duration := time.Since(started)
+
+	if server.AuditLogger != nil {
+		parameters := []byte("{}")
+		if stream.request != nil {
+			if requestJson, marshalErr := protojson.Marshal(stream.request); marshalErr == nil {
+				parameters = requestJson
+			}
+		}
+
+		var remoteAddr string
+		if p, ok := peer.FromContext(ss.Context()); ok {
+			remoteAddr = p.Addr.String()
+			if host, _, splitErr := net.SplitHostPort(remoteAddr); splitErr == nil {
+				remoteAddr = host
+			}
+		}
+
+		server.AuditLogger.Record(indexer.AuditLogEntry{
+			ConsumerID:   userAuth.UserId,
+			ConsumerName: userAuth.Username,
+			Method:       "GRPC",
+			Endpoint:     info.FullMethod,
+			Parameters:   parameters,
+			Status:       int(status.Code(handlerErr)),
+			RowCount:     record.rowCount,
+			DurationMs:   duration.Milliseconds(),
+			RemoteAddr:   remoteAddr,
+			CreatedAt:    started,
+		})
+	}
+
+	return handlerErr
+}
+
+type labelsStreamServer struct {
+	labelstream.UnimplementedLabelsStreamServer
+
+	server *Server
+}
+
+// StreamLabels sends labels page by page. Send blocks while flow control window of client is
+// full, so next page is not read from database until client consumed previous one.
+func (s *labelsStreamServer) StreamLabels(request *labelstream.StreamLabelsRequest, stream labelstream.LabelsStream_StreamLabelsServer) error {
+	if request.Blockchain == "" {
+		return status.Error(codes.InvalidArgument, "blockchain is required")
+	}
+	if request.CustomerId == "" {
+		return status.Error(codes.InvalidArgument, "customer_id is required")
+	}
+	if _, err := indexer.BlocksTableName(request.Blockchain); err != nil {
+		return status.Error(codes.InvalidArgument, "Unsupported blockchain")
+	}
+
+	labelType := request.LabelType
+	if labelType == "" {
+		labelType = "event"
+	}
+	if labelType != "event" && labelType != "tx_call" {
+		return status.Error(codes.InvalidArgument, "label_type should be event or tx_call")
+	}
+	if request.Address != "" && !common.IsHexAddress(request.Address) {
+		return status.Error(codes.InvalidArgument, "address should be a hex address")
+	}
+	if request.ToBlock != 0 && request.ToBlock < request.FromBlock {
+		return status.Error(codes.InvalidArgument, "to_block should not be lower than from_block")
+	}
+	if _, cursorErr := indexer.DecodeLabelsCursor(request.Cursor); cursorErr != nil {
+		return status.Error(codes.InvalidArgument, cursorErr.Error())
+	}
+
+	chunkSize := int(request.ChunkSize)
+	if chunkSize == 0 {
+		chunkSize = DefaultLabelsStreamChunkSize
+	}
+	if chunkSize > indexer.MaxLabelsPageLimit {
+		return status.Errorf(codes.InvalidArgument, "chunk_size should not exceed %d", indexer.MaxLabelsPageLimit)
+	}
+
+	filter := indexer.LabelsFilter{
+		Label:      request.Label,
+		Address:    request.Address,
+		LabelName:  request.LabelName,
+		FromBlock:  request.FromBlock,
+		ToBlock:    request.ToBlock,
+		Limit:      chunkSize,
+		Cursor:     request.Cursor,
+		CustomerID: request.CustomerId,
+	}
+
+	record, _ := stream.Context().Value(auditContextKey{}).(*auditRecord)
+
+	for {
+		if ctxErr := stream.Context().Err(); ctxErr != nil {
+			return status.FromContextError(ctxErr).Err()
+		}
+
+		chunk, nextCursor, readErr := s.readLabelsChunk(request.Blockchain, labelType, filter)
+		if readErr != nil {
+			if errors.Is(readErr, indexer.ErrUnsupportedChain) {
+				return status.Error(codes.InvalidArgument, "Unsupported blockchain")
+			}
+			log.Printf("Unable to read labels of stream, err: %v", readErr)
+			return status.Error(codes.Internal, "Internal server error")
+		}
+		if chunk.Cursor == "" {
+			chunk.Cursor = filter.Cursor
+		}
+		chunk.Done = nextCursor == ""
+
+		if sendErr := stream.Send(chunk); sendErr != nil {
+			return sendErr
+		}
+		if record != nil {
+			record.rowCount += len(chunk.Labels)
+		}
+
+		if chunk.Done {
+			return nil
+		}
+		filter.Cursor = nextCursor
+	}
+}
+
+// readLabelsChunk reads page of labels, cursor of chunk points to its last label and next
+// cursor is empty if there are no more labels.
+func (s *labelsStreamServer) readLabelsChunk(blockchain, labelType string, filter indexer.LabelsFilter) (*labelstream.LabelsChunk, string, error) {
+	chunk := &labelstream.LabelsChunk{}
+
+	if labelType == "event" {
+		page, err := s.server.DbPool.GetEventLabels(blockchain, filter)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, label := range page.Labels {
+			chunk.Labels = append(chunk.Labels, &labelstream.Label{
+				Address:         label.Address,
+				BlockNumber:     label.BlockNumber,
+				BlockHash:       label.BlockHash,
+				CallerAddress:   label.CallerAddress,
+				Label:           label.Label,
+				LabelName:       label.LabelName,
+				LabelType:       label.LabelType,
+				OriginAddress:   label.OriginAddress,
+				TransactionHash: label.TransactionHash,
+				LabelData:       label.LabelData,
+				BlockTimestamp:  label.BlockTimestamp,
+				LogIndex:        label.LogIndex,
+			})
+		}
+		chunk.Cursor = page.LastCursor
+		return chunk, page.NextCursor, nil
+	}
+
+	page, err := s.server.DbPool.GetTransactionLabels(blockchain, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, label := range page.Labels {
+		chunk.Labels = append(chunk.Labels, &labelstream.Label{
+			Address:         label.Address,
+			BlockNumber:     label.BlockNumber,
+			BlockHash:       label.BlockHash,
+			CallerAddress:   label.CallerAddress,
+			Label:           label.Label,
+			LabelName:       label.LabelName,
+			LabelType:       label.LabelType,
+			OriginAddress:   label.OriginAddress,
+			TransactionHash: label.TransactionHash,
+			LabelData:       label.LabelData,
+			BlockTimestamp:  label.BlockTimestamp,
+		})
+	}
+	chunk.Cursor = page.LastCursor
+	return chunk, page.NextCursor, nil
+}
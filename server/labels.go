@@ -0,0 +1,86 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+// labelCountsRoute returns number of customer labels grouped by label_name, address or day.
+// Users could count labels only of customers of their ABI jobs.
+func (server *Server) labelCountsRoute(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	blockchainQe := query.Get("blockchain")
+	if blockchainQe == "" {
+		http.Error(w, "blockchain is required", http.StatusBadRequest)
+		return
+	}
+
+	customerIDQe := query.Get("customer_id")
+	if !server.authorizeCustomer(w, r, customerIDQe) {
+		return
+	}
+
+	groupByQe := query.Get("group_by")
+	if groupByQe == "" {
+		groupByQe = indexer.LabelCountsByLabelName
+	}
+
+	filter := indexer.LabelCountsFilter{
+		Label:     query.Get("label"),
+		LabelType: query.Get("label_type"),
+	}
+
+	uintParams := map[string]*uint64{
+		"from_block": &filter.FromBlock,
+		"to_block":   &filter.ToBlock,
+	}
+	for name, value := range uintParams {
+		valueQe := query.Get(name)
+		if valueQe == "" {
+			continue
+		}
+		var parseUintErr error
+		*value, parseUintErr = strconv.ParseUint(valueQe, 10, 64)
+		if parseUintErr != nil {
+			http.Error(w, name+" should be an integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	limitQe := query.Get("limit")
+	if limitQe != "" {
+		var atoiErr error
+		filter.Limit, atoiErr = strconv.Atoi(limitQe)
+		if atoiErr != nil || filter.Limit < 1 || filter.Limit > indexer.MaxLabelsPageLimit {
+			http.Error(w, "limit should be an integer between 1 and "+strconv.Itoa(indexer.MaxLabelsPageLimit), http.StatusBadRequest)
+			return
+		}
+	}
+
+	ctx, debug, ok := server.debugContext(w, r)
+	if !ok {
+		return
+	}
+
+	counts, countsErr := server.DbPool.GetLabelCounts(ctx, blockchainQe, customerIDQe, groupByQe, filter)
+	if countsErr != nil {
+		switch {
+		case errors.Is(countsErr, indexer.ErrUnsupportedChain):
+			http.Error(w, "Unsupported blockchain", http.StatusBadRequest)
+		case errors.Is(countsErr, indexer.ErrInvalidFilter):
+			http.Error(w, countsErr.Error(), http.StatusBadRequest)
+		default:
+			log.Printf("Unable to count labels, err: %v", countsErr)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	setAuditRowCount(r, len(counts))
+	server.writeResult(w, r, debug, counts)
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"log"
@@ -94,6 +95,8 @@ func (server *Server) panicMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+type userContextKey struct{}
+
 func (server *Server) accessMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
@@ -143,6 +146,7 @@ func (server *Server) accessMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		ctx := context.WithValue(r.Context(), userContextKey{}, userAuth)
+		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
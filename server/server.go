@@ -1,7 +1,9 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
@@ -26,6 +28,12 @@ type Server struct {
 	CORSWhitelist map[string]bool
 	DbPool        *indexer.PostgreSQLpgx
 	BugoutClient  *bugout.BugoutClient
+	AuditLogger   *AuditLogger
+
+	// AddressResolver labels nodes of graphs, nil if responses have no labels
+	AddressResolver *AddressResolver
+
+	completeness *indexer.CompletenessTracker
 }
 
 type PingResponse struct {
@@ -55,6 +63,8 @@ func (server *Server) versionRoute(w http.ResponseWriter, r *http.Request) {
 type GraphNode struct {
 	Id       string `json:"id"`
 	SubNodes uint64 `json:"sub_nodes"`
+	Label    string `json:"label,omitempty"`
+	Category string `json:"category,omitempty"`
 }
 
 type GraphLinks struct {
@@ -78,6 +88,26 @@ func (server *Server) nowRoute(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// labelGraphNodes sets labels of nodes known to address book or ENS.
+func (server *Server) labelGraphNodes(ctx context.Context, blockchain string, nodes []GraphNode) {
+	if server.AddressResolver == nil || len(nodes) == 0 {
+		return
+	}
+
+	addresses := make([]string, len(nodes))
+	for i, node := range nodes {
+		addresses[i] = node.Id
+	}
+
+	labels := server.AddressResolver.Resolve(ctx, blockchain, addresses)
+	for i := range nodes {
+		if entry, exists := labels[strings.ToLower(nodes[i].Id)]; exists {
+			nodes[i].Label = entry.Label
+			nodes[i].Category = entry.Category
+		}
+	}
+}
+
 func weiToEther(wei *big.Int) *big.Float {
 	return new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(params.Ether))
 }
@@ -166,13 +196,56 @@ func (server *Server) graphsVolumeRoute(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	var fromTimestampQeUint, toTimestampQeUint uint64
+	fromTimestampQe := r.URL.Query().Get("from_timestamp")
+	if fromTimestampQe != "" {
+		var parseUintErr error
+		fromTimestampQeUint, parseUintErr = strconv.ParseUint(fromTimestampQe, 10, 64)
+		if parseUintErr != nil {
+			http.Error(w, "from_timestamp should be an integer", http.StatusBadRequest)
+			return
+		}
+	}
+	toTimestampQe := r.URL.Query().Get("to_timestamp")
+	if toTimestampQe != "" {
+		var parseUintErr error
+		toTimestampQeUint, parseUintErr = strconv.ParseUint(toTimestampQe, 10, 64)
+		if parseUintErr != nil {
+			http.Error(w, "to_timestamp should be an integer", http.StatusBadRequest)
+			return
+		}
+	}
+	if fromTimestampQeUint > 0 && toTimestampQeUint > 0 && fromTimestampQeUint > toTimestampQeUint {
+		http.Error(w, "from_timestamp should be less than to_timestamp", http.StatusBadRequest)
+		return
+	}
+	if (fromTimestampQeUint > 0 || toTimestampQeUint > 0) && lowestBlockNumQeUint > 0 {
+		http.Error(w, "lowest_block_number could not be used together with time window", http.StatusBadRequest)
+		return
+	}
+
+	ctx, debug, ok := server.debugContext(w, r)
+	if !ok {
+		return
+	}
+
 	limitTxs := 1000000
-	txsVol, txsErr := server.DbPool.GetTransactionsVolume(blockchainQe, fromAddressQe, toAddressQe, limitTxs, lowestBlockNumQeUint, false)
+	var txsVol *indexer.TransactionsVolume
+	var txsErr error
+	if fromTimestampQeUint > 0 || toTimestampQeUint > 0 {
+		txsVol, txsErr = server.DbPool.GetTransactionsVolumeInTimeWindow(ctx, blockchainQe, fromAddressQe, toAddressQe, limitTxs, fromTimestampQeUint, toTimestampQeUint, false)
+	} else {
+		txsVol, txsErr = server.DbPool.GetTransactionsVolume(ctx, blockchainQe, fromAddressQe, toAddressQe, limitTxs, lowestBlockNumQeUint, false)
+	}
 	if txsErr != nil {
-		if txsErr.Error() == "not found" {
+		if errors.Is(txsErr, indexer.ErrNoRowsIndexed) {
 			http.Error(w, "No transactions found", http.StatusNotFound)
 			return
 		}
+		if errors.Is(txsErr, indexer.ErrUnsupportedChain) || errors.Is(txsErr, indexer.ErrDecodeAddress) {
+			http.Error(w, txsErr.Error(), http.StatusBadRequest)
+			return
+		}
 		log.Printf("Unable to query the row, err: %v", txsErr)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
@@ -187,7 +260,8 @@ func (server *Server) graphsVolumeRoute(w http.ResponseWriter, r *http.Request)
 		TxsCount:       txsVol.TxsCount,
 	}
 
-	json.NewEncoder(w).Encode(response)
+	setAuditRowCount(r, 1)
+	server.writeResult(w, r, debug, response)
 }
 
 func (server *Server) graphsTxsRoute(w http.ResponseWriter, r *http.Request) {
@@ -219,6 +293,11 @@ func (server *Server) graphsTxsRoute(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	ctx, debug, ok := server.debugContext(w, r)
+	if !ok {
+		return
+	}
+
 	// TODO: decide, do we want to track where source_address appears in to_address or not
 
 	limitTxs := 100
@@ -227,7 +306,7 @@ func (server *Server) graphsTxsRoute(w http.ResponseWriter, r *http.Request) {
 	// Also it gives us lowest block_number for this address, so we do not
 	// query transactions for subnodes which were executed this address
 	// appeared in blockchain
-	txs, txsErr := server.DbPool.GetTransactions(blockchainQe, []string{sourceAddressQe}, limitTxs, lowestBlockNumQeUint, true)
+	txs, txsErr := server.DbPool.GetTransactions(ctx, blockchainQe, []string{sourceAddressQe}, limitTxs, lowestBlockNumQeUint, true)
 	if txsErr != nil {
 		log.Printf("Unable to query rows, err: %v", txsErr)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -242,7 +321,8 @@ func (server *Server) graphsTxsRoute(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if len(txs) == 0 {
-		json.NewEncoder(w).Encode(graphResponse)
+		setAuditRowCount(r, 0)
+		server.writeResult(w, r, debug, graphResponse)
 		return
 	}
 
@@ -257,7 +337,7 @@ func (server *Server) graphsTxsRoute(w http.ResponseWriter, r *http.Request) {
 
 	// Second iteration of parse depth equal 2
 	// Query subnodes for source address with txs greater then first tx of source address
-	subTxs, subTxsErr := server.DbPool.GetTransactions(blockchainQe, subAddressSls, limitTxs, lowestBlockNum, true)
+	subTxs, subTxsErr := server.DbPool.GetTransactions(ctx, blockchainQe, subAddressSls, limitTxs, lowestBlockNum, true)
 	if subTxsErr != nil {
 		log.Printf("Unable to query rows, err: %v", subTxsErr)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -277,13 +357,197 @@ func (server *Server) graphsTxsRoute(w http.ResponseWriter, r *http.Request) {
 		graphResponse.Links = append(graphResponse.Links, GraphLinks{Source: linkSls[0], Target: linkSls[1], Value: fmt.Sprintf("%.2f", valueEth)})
 	}
 
-	json.NewEncoder(w).Encode(graphResponse)
+	server.labelGraphNodes(ctx, blockchainQe, graphResponse.Nodes)
+
+	setAuditRowCount(r, len(graphResponse.Links))
+	server.writeResult(w, r, debug, graphResponse)
+}
+
+type TokenTransfersVolumeResponse struct {
+	TokenAddress   string `json:"token_address"`
+	TokenStandard  string `json:"token_standard"`
+	FromAddress    string `json:"from_address"`
+	ToAddress      string `json:"to_address"`
+	MinBlockNumber uint64 `json:"min_block_number"`
+	MaxBlockNumber uint64 `json:"max_block_number"`
+	Volume         string `json:"volume"`
+	TransfersCount uint64 `json:"transfers_count"`
+}
+
+func (server *Server) tokensVolumeRoute(w http.ResponseWriter, r *http.Request) {
+	blockchainQe := r.URL.Query().Get("blockchain")
+	if blockchainQe == "" {
+		http.Error(w, "blockchain is required", http.StatusBadRequest)
+		return
+	}
+
+	filter := indexer.TokenTransfersFilter{
+		TokenAddresses: r.URL.Query()["token_address"],
+		FromAddress:    r.URL.Query().Get("from_address"),
+		ToAddress:      r.URL.Query().Get("to_address"),
+		Limit:          100,
+	}
+
+	for _, address := range append([]string{filter.FromAddress, filter.ToAddress}, filter.TokenAddresses...) {
+		if address != "" && !common.IsHexAddress(address) {
+			http.Error(w, "Incorrect address type", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if r.URL.Query().Get("bidirectional") == "true" {
+		filter.IsBidirectional = true
+	}
+
+	lowestBlockNumQe := r.URL.Query().Get("lowest_block_number")
+	if lowestBlockNumQe != "" {
+		var parseUintErr error
+		filter.LowestBlockNum, parseUintErr = strconv.ParseUint(lowestBlockNumQe, 10, 64)
+		if parseUintErr != nil {
+			http.Error(w, "lowest_block_number should be an integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	ctx, debug, ok := server.debugContext(w, r)
+	if !ok {
+		return
+	}
+
+	volumes, volumesErr := server.DbPool.GetTokenTransfersVolume(ctx, blockchainQe, filter)
+	if volumesErr != nil {
+		log.Printf("Unable to query token transfers volume, err: %v", volumesErr)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := []TokenTransfersVolumeResponse{}
+	for _, volume := range volumes {
+		response = append(response, TokenTransfersVolumeResponse{
+			TokenAddress:   volume.TokenAddress,
+			TokenStandard:  volume.TokenStandard,
+			FromAddress:    volume.FromAddress,
+			ToAddress:      volume.ToAddress,
+			MinBlockNumber: volume.MinBlockNumber,
+			MaxBlockNumber: volume.MaxBlockNumber,
+			Volume:         volume.Volume.String(),
+			TransfersCount: volume.TransfersCount,
+		})
+	}
+
+	setAuditRowCount(r, len(response))
+	server.writeResult(w, r, debug, response)
+}
+
+type GraphExpandResponse struct {
+	Seeds             []string     `json:"seeds"`
+	Depth             int          `json:"depth"`
+	LowestBlockNumber uint64       `json:"lowest_block_number"`
+	Nodes             []GraphNode  `json:"nodes"`
+	Links             []GraphLinks `json:"links"`
+}
+
+func (server *Server) graphsExpandRoute(w http.ResponseWriter, r *http.Request) {
+	seedsQe := r.URL.Query()["source_address"]
+	if len(seedsQe) == 0 {
+		http.Error(w, "source_address is required", http.StatusBadRequest)
+		return
+	}
+
+	for _, seed := range seedsQe {
+		if !common.IsHexAddress(seed) {
+			http.Error(w, "Incorrect address type", http.StatusBadRequest)
+			return
+		}
+	}
+
+	blockchainQe := r.URL.Query().Get("blockchain")
+	if blockchainQe == "" {
+		http.Error(w, "blockchain is required", http.StatusBadRequest)
+		return
+	}
+
+	var lowestBlockNumQeUint uint64
+	lowestBlockNumQe := r.URL.Query().Get("lowest_block_number")
+	if lowestBlockNumQe != "" {
+		var parseUintErr error
+		lowestBlockNumQeUint, parseUintErr = strconv.ParseUint(lowestBlockNumQe, 10, 64)
+		if parseUintErr != nil {
+			http.Error(w, "lowest_block_number should be an integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	depth := 2
+	depthQe := r.URL.Query().Get("depth")
+	if depthQe != "" {
+		var atoiErr error
+		depth, atoiErr = strconv.Atoi(depthQe)
+		if atoiErr != nil || depth < 1 || depth > indexer.MaxTransactionGraphDepth {
+			http.Error(w, fmt.Sprintf("depth should be an integer between 1 and %d", indexer.MaxTransactionGraphDepth), http.StatusBadRequest)
+			return
+		}
+	}
+
+	limitTxs := 100
+	limitQe := r.URL.Query().Get("limit")
+	if limitQe != "" {
+		var atoiErr error
+		limitTxs, atoiErr = strconv.Atoi(limitQe)
+		if atoiErr != nil || limitTxs < 1 || limitTxs > 1000 {
+			http.Error(w, "limit should be an integer between 1 and 1000", http.StatusBadRequest)
+			return
+		}
+	}
+
+	ctx, debug, ok := server.debugContext(w, r)
+	if !ok {
+		return
+	}
+
+	graph, graphErr := server.DbPool.GetTransactionGraph(ctx, blockchainQe, seedsQe, depth, limitTxs, lowestBlockNumQeUint)
+	if graphErr != nil {
+		log.Printf("Unable to build transactions graph, err: %v", graphErr)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := GraphExpandResponse{
+		Seeds:             graph.Seeds,
+		Depth:             graph.Depth,
+		LowestBlockNumber: graph.LowestBlockNumber,
+		Nodes:             []GraphNode{},
+		Links:             []GraphLinks{},
+	}
+	for _, node := range graph.Nodes {
+		response.Nodes = append(response.Nodes, GraphNode{Id: node.Address, SubNodes: node.SubNodes})
+	}
+	for _, edge := range graph.Edges {
+		response.Links = append(response.Links, GraphLinks{Source: edge.FromAddress, Target: edge.ToAddress, Value: fmt.Sprintf("%.2f", weiToEther(edge.Value))})
+	}
+
+	server.labelGraphNodes(ctx, blockchainQe, response.Nodes)
+
+	setAuditRowCount(r, len(response.Links))
+	server.writeResult(w, r, debug, response)
 }
 
 func (server *Server) Run(host string, port int, corsWhitelist map[string]bool) {
+	server.completeness = indexer.NewCompletenessTracker(server.DbPool, CompletenessCacheTTL, CompletenessFullRescanInterval)
+
+	if server.AuditLogger != nil {
+		go server.AuditLogger.Run(context.Background())
+	}
+
 	serveMux := http.NewServeMux()
-	serveMux.Handle("/graphs/txs", server.accessMiddleware(http.HandlerFunc(server.graphsTxsRoute)))
-	serveMux.Handle("/graphs/volume", server.accessMiddleware(http.HandlerFunc(server.graphsVolumeRoute)))
+	serveMux.Handle("/graphs/txs", server.accessMiddleware(server.auditMiddleware(http.HandlerFunc(server.graphsTxsRoute))))
+	serveMux.Handle("/graphs/expand", server.accessMiddleware(server.auditMiddleware(http.HandlerFunc(server.graphsExpandRoute))))
+	serveMux.Handle("/graphs/volume", server.accessMiddleware(server.auditMiddleware(http.HandlerFunc(server.graphsVolumeRoute))))
+	serveMux.Handle("/tokens/volume", server.accessMiddleware(server.auditMiddleware(http.HandlerFunc(server.tokensVolumeRoute))))
+	serveMux.Handle("/abi-jobs/ensure-selectors", server.accessMiddleware(server.auditMiddleware(http.HandlerFunc(server.abiJobsEnsureSelectorsRoute))))
+	serveMux.Handle("/blocks/search", server.accessMiddleware(server.auditMiddleware(http.HandlerFunc(server.blocksSearchRoute))))
+	serveMux.Handle("/labels/counts", server.accessMiddleware(server.auditMiddleware(http.HandlerFunc(server.labelCountsRoute))))
+	serveMux.HandleFunc("/status/completeness", server.completenessRoute)
 	serveMux.HandleFunc("/now", server.nowRoute)
 	serveMux.HandleFunc("/ping", server.pingRoute)
 	serveMux.HandleFunc("/version", server.versionRoute)
@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	bugout "github.com/bugout-dev/bugout-go/pkg"
@@ -22,6 +23,10 @@ var (
 
 	SEER_BUGOUT_TIMEOUT_SECONDS     = 10
 	SEER_BUGOUT_TIMEOUT_SECONDS_RAW = os.Getenv("SEER_BUGOUT_TIMEOUT_SECONDS")
+
+	// Bugout users allowed to request debug output of read APIs
+	SEER_API_ADMIN_USER_IDS     = make(map[string]bool)
+	SEER_API_ADMIN_USER_IDS_RAW = os.Getenv("SEER_API_ADMIN_USER_IDS")
 )
 
 func CheckVariablesForServer() error {
@@ -35,6 +40,17 @@ func CheckVariablesForServer() error {
 		return fmt.Errorf("MOONSTREAM_APPLICATION_ID environment variable is required in UUID format")
 	}
 
+	for _, userID := range strings.Split(SEER_API_ADMIN_USER_IDS_RAW, ",") {
+		userID = strings.TrimSpace(userID)
+		if userID == "" {
+			continue
+		}
+		if err := uuid.Validate(userID); err != nil {
+			return fmt.Errorf("SEER_API_ADMIN_USER_IDS environment variable should contain comma separated user IDs in UUID format, got %q", userID)
+		}
+		SEER_API_ADMIN_USER_IDS[userID] = true
+	}
+
 	return nil
 }
 
@@ -9,9 +9,10 @@ import (
 	"golang.org/x/crypto/sha3"
 )
 
-// Represents a particular value in a Starknet ABI enum.
+// Represents a particular value in a Starknet ABI enum. Variants without value have type "()".
 type EnumVariant struct {
 	Name  string `json:"name"`
+	Type  string `json:"type"`
 	Index int    `json:"index"`
 }
 
@@ -44,6 +45,42 @@ type EventStruct struct {
 	Members []*StructMember `json:"members"`
 }
 
+// Represents an input of a Starknet ABI function or constructor.
+type FunctionInput struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Represents an output of a Starknet ABI function.
+type FunctionOutput struct {
+	Type string `json:"type"`
+}
+
+// Represents a function in a Starknet ABI. Functions with "view" state mutability only read contract
+// state, all other functions are invoked in transactions.
+type Function struct {
+	Type            string            `json:"type"`
+	Name            string            `json:"name"`
+	Inputs          []*FunctionInput  `json:"inputs"`
+	Outputs         []*FunctionOutput `json:"outputs"`
+	StateMutability string            `json:"state_mutability"`
+}
+
+// Represents an interface in a Starknet ABI. Cairo 1 contracts list functions of their embedded
+// interfaces as items of the interface rather than at the top level of the ABI.
+type Interface struct {
+	Type  string      `json:"type"`
+	Name  string      `json:"name"`
+	Items []*Function `json:"items"`
+}
+
+// Represents the constructor of a Starknet contract.
+type Constructor struct {
+	Type   string           `json:"type"`
+	Name   string           `json:"name"`
+	Inputs []*FunctionInput `json:"inputs"`
+}
+
 // Represents a single item in a Starknet ABI.
 type ABIItemType struct {
 	Type string `json:"type,omitempty"`
@@ -52,9 +89,11 @@ type ABIItemType struct {
 
 // Represents a parsed Starknet ABI.
 type ParsedABI struct {
-	Enums   []*Enum        `json:"enums"`
-	Structs []*Struct      `json:"structs"`
-	Events  []*EventStruct `json:"events"`
+	Enums       []*Enum        `json:"enums"`
+	Structs     []*Struct      `json:"structs"`
+	Events      []*EventStruct `json:"events"`
+	Functions   []*Function    `json:"functions"`
+	Constructor *Constructor   `json:"constructor,omitempty"`
 }
 
 // Internal representation of a Starknet ABI used while parsing the ABI into its Go representation as a
@@ -147,6 +186,37 @@ func ParseABI(rawABI []byte) (*ParsedABI, error) {
 		}
 	}
 
+	parsedABI.Functions = []*Function{}
+	for i, item := range itemTypes {
+		switch item.Type {
+		case "function":
+			var function *Function
+			functionUnmarshalErr := json.Unmarshal(rawMessages[i], &function)
+			if functionUnmarshalErr != nil {
+				return parsedABI, functionUnmarshalErr
+			}
+
+			parsedABI.Functions = append(parsedABI.Functions, function)
+		case "interface":
+			var interfaceItem *Interface
+			interfaceUnmarshalErr := json.Unmarshal(rawMessages[i], &interfaceItem)
+			if interfaceUnmarshalErr != nil {
+				return parsedABI, interfaceUnmarshalErr
+			}
+
+			for _, function := range interfaceItem.Items {
+				if function.Type == "function" {
+					parsedABI.Functions = append(parsedABI.Functions, function)
+				}
+			}
+		case "constructor":
+			constructorUnmarshalErr := json.Unmarshal(rawMessages[i], &parsedABI.Constructor)
+			if constructorUnmarshalErr != nil {
+				return parsedABI, constructorUnmarshalErr
+			}
+		}
+	}
+
 	return parsedABI, nil
 }
 
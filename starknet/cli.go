@@ -0,0 +1,746 @@
+package starknet
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/iancoleman/strcase"
+)
+
+// Specifies the commands which AddCLI generates for a Starknet contract.
+type CLISpecification struct {
+	StructName        string
+	Constructor       *GeneratedFunction
+	ViewFunctions     []GeneratedFunction
+	ExternalFunctions []GeneratedFunction
+}
+
+// ParseCLISpecification splits the functions of a parsed ABI into the commands of the generated CLI.
+func ParseCLISpecification(structName string, parsed *ParsedABI) (CLISpecification, error) {
+	result := CLISpecification{
+		StructName:        structName,
+		ViewFunctions:     []GeneratedFunction{},
+		ExternalFunctions: []GeneratedFunction{},
+	}
+
+	generatedFunctions, generatedFunctionsErr := GenerateFunctions(parsed)
+	if generatedFunctionsErr != nil {
+		return result, generatedFunctionsErr
+	}
+
+	for i, generated := range generatedFunctions {
+		if generated.Definition.Type == "constructor" {
+			result.Constructor = &generatedFunctions[i]
+		} else if generated.View {
+			result.ViewFunctions = append(result.ViewFunctions, generated)
+		} else {
+			result.ExternalFunctions = append(result.ExternalFunctions, generated)
+		}
+	}
+
+	return result, nil
+}
+
+// Flags which the generated commands define for themselves. Function arguments with these names get
+// their flags prefixed with "arg-".
+var reservedCLIFlags map[string]bool = map[string]bool{
+	"account":       true,
+	"block":         true,
+	"cairo-version": true,
+	"casm":          true,
+	"class-hash":    true,
+	"contract":      true,
+	"help":          true,
+	"max-fee":       true,
+	"private-key":   true,
+	"rpc":           true,
+	"salt":          true,
+	"sierra":        true,
+	"timeout":       true,
+	"unique":        true,
+}
+
+// Generates the name of the command-line flag for a function argument.
+func ArgumentFlag(name string) string {
+	flag := strcase.ToKebab(name)
+	if reservedCLIFlags[flag] {
+		flag = fmt.Sprintf("arg-%s", flag)
+	}
+	return flag
+}
+
+// Generates the code which parses the raw (string) value of a command-line flag into the Go value of a
+// function argument. Felts are passed through as strings, integers are accepted in decimal or hex, and all
+// other types (structs, enums and arrays) are expected as JSON.
+func ArgumentParserCode(name, goType string) string {
+	argumentName := ArgumentName(name)
+	flagName := ArgumentFlag(name)
+
+	switch goType {
+	case "string":
+		return fmt.Sprintf(`if %sRaw == "" {
+				return fmt.Errorf("--%s argument not specified")
+			}
+			%s = %sRaw`, argumentName, flagName, argumentName, argumentName)
+	case "uint64":
+		return fmt.Sprintf(`%s, parseErr = strconv.ParseUint(%sRaw, 0, 64)
+			if parseErr != nil {
+				return fmt.Errorf("--%s argument is not a valid uint64: %%s", parseErr.Error())
+			}`, argumentName, argumentName, flagName)
+	case "*big.Int":
+		return fmt.Sprintf(`var ok%s bool
+			%s, ok%s = new(big.Int).SetString(%sRaw, 0)
+			if !ok%s {
+				return fmt.Errorf("--%s argument is not a valid integer")
+			}`, argumentName, argumentName, argumentName, argumentName, argumentName, flagName)
+	default:
+		return fmt.Sprintf(`parseErr = json.Unmarshal([]byte(%sRaw), &%s)
+			if parseErr != nil {
+				return fmt.Errorf("--%s argument is not valid JSON for %s: %%s", parseErr.Error())
+			}`, argumentName, argumentName, flagName, goType)
+	}
+}
+
+// AddCLI adds CLI code (using github.com/spf13/cobra command-line framework) for code generated by the
+// Generate function. Like its EVM counterpart, the output of this function *contains* the input and should
+// be used as part of a chain rather than concatenated with the output of Generate. The input is expected to
+// start with a header generated with cli set to true, since that header carries the imports of the CLI.
+//
+// The generated CLI declares contract classes, deploys them through the Universal Deployer Contract, calls
+// view functions and invokes all other functions of the contract.
+func AddCLI(sourceCode, structName string, parsed *ParsedABI, includemain bool) (string, error) {
+	code := sourceCode
+
+	templateFuncs := map[string]any{
+		"ArgumentFlag":          ArgumentFlag,
+		"ArgumentName":          ArgumentName,
+		"ArgumentParserCode":    ArgumentParserCode,
+		"GenerateGoNameForType": GenerateGoNameForType,
+		"KebabCase":             strcase.ToKebab,
+		"ScreamingSnake":        strcase.ToScreamingSnake,
+	}
+
+	cliSpec, cliSpecErr := ParseCLISpecification(structName, parsed)
+	if cliSpecErr != nil {
+		return code, cliSpecErr
+	}
+
+	cliTemplates := []struct {
+		name       string
+		definition string
+	}{
+		{"deploy", DeclareAndDeployCommandsTemplate},
+		{"view", ViewFunctionCommandsTemplate},
+		{"invoke", InvokeFunctionCommandsTemplate},
+		{"cli", CLICodeTemplate},
+	}
+
+	for _, cliTemplate := range cliTemplates {
+		parsedTemplate, parseErr := template.New(cliTemplate.name).Funcs(templateFuncs).Parse(cliTemplate.definition)
+		if parseErr != nil {
+			return code, parseErr
+		}
+
+		var b bytes.Buffer
+		templateErr := parsedTemplate.Execute(&b, cliSpec)
+		if templateErr != nil {
+			return code, templateErr
+		}
+		code = code + "\n\n" + b.String()
+	}
+
+	if includemain {
+		mainFormatString := `func main() {
+	command := %s()
+	err := command.Execute()
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+}
+`
+		mainCode := fmt.Sprintf(mainFormatString, fmt.Sprintf("Create%sCommand", structName))
+		code = code + "\n\n" + mainCode
+	}
+
+	return code, nil
+}
+
+// This template is used to generate the skeleton of the CLI, along with all utility methods that can be
+// used by CLI handlers. It is expected to be applied to a CLISpecification struct.
+var CLICodeTemplate string = `
+var ErrNoRPCURL error = errors.New("no RPC URL provided -- please pass an RPC URL from the command line or set the {{(ScreamingSnake .StructName)}}_RPC_URL environment variable")
+var ErrNoAccount error = errors.New("no account provided -- please pass an account address and private key from the command line or set the {{(ScreamingSnake .StructName)}}_ACCOUNT_ADDRESS and {{(ScreamingSnake .StructName)}}_PRIVATE_KEY environment variables")
+var ErrNoMaxFee error = errors.New("no max fee provided -- please pass the maximum fee (in wei) for the transaction using --max-fee")
+
+// Address of the Universal Deployer Contract, which is used to deploy contracts from declared classes.
+var UniversalDeployerAddress string = "0x041a78e741e5af2fec34b695679bc6891742439f7afb8484ecd7766661ad02bf"
+
+// Generates a Starknet client to the JSONRPC API at the given URL. If rpcURL is empty, then it
+// attempts to read the RPC URL from the {{(ScreamingSnake .StructName)}}_RPC_URL environment variable. If that is empty,
+// too, then it returns an error.
+func NewProvider(rpcURL string) (*rpc.Provider, error) {
+	if rpcURL == "" {
+		rpcURL = os.Getenv("{{(ScreamingSnake .StructName)}}_RPC_URL")
+	}
+
+	if rpcURL == "" {
+		return nil, ErrNoRPCURL
+	}
+
+	return rpc.NewProvider(rpcURL)
+}
+
+// Creates a new context to be used when interacting with the chain client.
+func NewChainContext(timeout uint) (context.Context, context.CancelFunc) {
+	baseCtx := context.Background()
+	parsedTimeout := time.Duration(timeout) * time.Second
+	ctx, cancel := context.WithTimeout(baseCtx, parsedTimeout)
+	return ctx, cancel
+}
+
+// Parses a felt from its hex (0x-prefixed) or decimal representation.
+func ParseFelt(raw string) (*felt.Felt, error) {
+	if raw == "" {
+		return nil, ErrIncorrectParameters
+	}
+	return new(felt.Felt).SetString(raw)
+}
+
+// Parses a block identifier from the command line: "latest", "pending" or a block number.
+func BlockIDFromString(raw string) (rpc.BlockID, error) {
+	if raw == "" || raw == "latest" || raw == "pending" {
+		tag := raw
+		if tag == "" {
+			tag = "latest"
+		}
+		return rpc.BlockID{Tag: tag}, nil
+	}
+
+	blockNumber, parseErr := strconv.ParseUint(raw, 0, 64)
+	if parseErr != nil {
+		return rpc.BlockID{}, fmt.Errorf("invalid block: %s", parseErr.Error())
+	}
+	return rpc.BlockID{Number: &blockNumber}, nil
+}
+
+// Creates an account which signs transactions with the given private key. If the account address or the
+// private key are empty, then they are read from the {{(ScreamingSnake .StructName)}}_ACCOUNT_ADDRESS and
+// {{(ScreamingSnake .StructName)}}_PRIVATE_KEY environment variables.
+func NewAccount(provider *rpc.Provider, accountAddressRaw, privateKeyRaw string, cairoVersion int) (*account.Account, error) {
+	if accountAddressRaw == "" {
+		accountAddressRaw = os.Getenv("{{(ScreamingSnake .StructName)}}_ACCOUNT_ADDRESS")
+	}
+	if privateKeyRaw == "" {
+		privateKeyRaw = os.Getenv("{{(ScreamingSnake .StructName)}}_PRIVATE_KEY")
+	}
+	if accountAddressRaw == "" || privateKeyRaw == "" {
+		return nil, ErrNoAccount
+	}
+
+	accountAddress, addressErr := ParseFelt(accountAddressRaw)
+	if addressErr != nil {
+		return nil, fmt.Errorf("invalid account address: %s", addressErr.Error())
+	}
+
+	privateKey, ok := new(big.Int).SetString(privateKeyRaw, 0)
+	if !ok {
+		return nil, errors.New("invalid private key")
+	}
+
+	publicKeyX, _, pointErr := curve.Curve.PrivateToPoint(privateKey)
+	if pointErr != nil {
+		return nil, pointErr
+	}
+	publicKey := new(felt.Felt).SetBigInt(publicKeyX).String()
+
+	ks := account.NewMemKeystore()
+	ks.Put(publicKey, privateKey)
+
+	return account.NewAccount(provider, accountAddress, publicKey, ks, cairoVersion)
+}
+
+// Signs an invoke transaction containing the given calls with the account and submits it to the network.
+// Returns the hash of the submitted transaction.
+func SubmitInvoke(ctx context.Context, acc *account.Account, calls []rpc.FunctionCall, maxFee *felt.Felt) (*felt.Felt, error) {
+	nonce, nonceErr := acc.Nonce(ctx, rpc.BlockID{Tag: "latest"}, acc.AccountAddress)
+	if nonceErr != nil {
+		return nil, nonceErr
+	}
+
+	calldata, calldataErr := acc.FmtCalldata(calls)
+	if calldataErr != nil {
+		return nil, calldataErr
+	}
+
+	tx := rpc.InvokeTxnV1{
+		MaxFee:        maxFee,
+		Version:       rpc.TransactionV1,
+		Nonce:         nonce,
+		Type:          rpc.TransactionType_Invoke,
+		SenderAddress: acc.AccountAddress,
+		Calldata:      calldata,
+	}
+
+	signErr := acc.SignInvokeTransaction(ctx, &tx)
+	if signErr != nil {
+		return nil, signErr
+	}
+
+	response, submitErr := acc.AddInvokeTransaction(ctx, rpc.BroadcastInvokev1Txn{InvokeTxnV1: tx})
+	if submitErr != nil {
+		return nil, submitErr
+	}
+
+	return response.TransactionHash, nil
+}
+
+// Prints a value returned from a view function as JSON.
+func PrintJSON(cmd *cobra.Command, value interface{}) error {
+	valueJSON, marshalErr := json.MarshalIndent(value, "", "  ")
+	if marshalErr != nil {
+		return marshalErr
+	}
+	cmd.Println(string(valueJSON))
+	return nil
+}
+
+func Create{{.StructName}}Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "{{(KebabCase .StructName)}}",
+		Short: "Interact with the {{.StructName}} contract",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	cmd.SetOut(os.Stdout)
+
+	DeployGroup := &cobra.Group{
+		ID: "deploy", Title: "Commands which declare and deploy contracts",
+	}
+	ViewGroup := &cobra.Group{
+		ID: "view", Title: "Commands which view contract state",
+	}
+	InvokeGroup := &cobra.Group{
+		ID: "invoke", Title: "Commands which submit transactions",
+	}
+	cmd.AddGroup(DeployGroup, ViewGroup, InvokeGroup)
+
+	cmdDeclare := CreateDeclareCommand()
+	cmdDeclare.GroupID = DeployGroup.ID
+	cmd.AddCommand(cmdDeclare)
+
+	cmdDeploy := CreateDeployCommand()
+	cmdDeploy.GroupID = DeployGroup.ID
+	cmd.AddCommand(cmdDeploy)
+
+	{{range .ViewFunctions}}
+	cmdView{{.GoName}} := CreateView{{.GoName}}Command()
+	cmdView{{.GoName}}.GroupID = ViewGroup.ID
+	cmd.AddCommand(cmdView{{.GoName}})
+	{{- end}}
+
+	{{range .ExternalFunctions}}
+	cmdInvoke{{.GoName}} := CreateInvoke{{.GoName}}Command()
+	cmdInvoke{{.GoName}}.GroupID = InvokeGroup.ID
+	cmd.AddCommand(cmdInvoke{{.GoName}})
+	{{- end}}
+
+	return cmd
+}
+`
+
+// This template generates the handlers which declare a contract class and deploy a contract from a declared
+// class. It is intended to be used with a CLISpecification struct.
+var DeclareAndDeployCommandsTemplate string = `
+func CreateDeclareCommand() *cobra.Command {
+	var rpcURL, accountAddressRaw, privateKeyRaw, maxFeeRaw, sierraFile, casmFile string
+	var cairoVersion int
+	var timeout uint
+
+	var maxFee *felt.Felt
+
+	cmd := &cobra.Command{
+		Use:   "declare",
+		Short: "Declare the {{.StructName}} contract class",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if sierraFile == "" {
+				return fmt.Errorf("--sierra not specified")
+			}
+			if casmFile == "" {
+				return fmt.Errorf("--casm not specified")
+			}
+			if maxFeeRaw == "" {
+				return ErrNoMaxFee
+			}
+
+			var parseErr error
+			maxFee, parseErr = ParseFelt(maxFeeRaw)
+			if parseErr != nil {
+				return fmt.Errorf("--max-fee is not a valid felt: %s", parseErr.Error())
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sierraContent, readErr := os.ReadFile(sierraFile)
+			if readErr != nil {
+				return readErr
+			}
+
+			var contractClass rpc.ContractClass
+			unmarshalErr := json.Unmarshal(sierraContent, &contractClass)
+			if unmarshalErr != nil {
+				return fmt.Errorf("could not parse Sierra contract class: %s", unmarshalErr.Error())
+			}
+
+			casmClass, casmErr := contracts.UnmarshalCasmClass(casmFile)
+			if casmErr != nil {
+				return fmt.Errorf("could not parse CASM contract class: %s", casmErr.Error())
+			}
+
+			classHash, classHashErr := hash.ClassHash(contractClass)
+			if classHashErr != nil {
+				return classHashErr
+			}
+			compiledClassHash := hash.CompiledClassHash(*casmClass)
+
+			provider, providerErr := NewProvider(rpcURL)
+			if providerErr != nil {
+				return providerErr
+			}
+
+			acc, accountErr := NewAccount(provider, accountAddressRaw, privateKeyRaw, cairoVersion)
+			if accountErr != nil {
+				return accountErr
+			}
+
+			ctx, cancel := NewChainContext(timeout)
+			defer cancel()
+
+			nonce, nonceErr := acc.Nonce(ctx, rpc.BlockID{Tag: "latest"}, acc.AccountAddress)
+			if nonceErr != nil {
+				return nonceErr
+			}
+
+			tx := rpc.DeclareTxnV2{
+				Type:              rpc.TransactionType_Declare,
+				Version:           rpc.TransactionV2,
+				MaxFee:            maxFee,
+				Nonce:             nonce,
+				SenderAddress:     acc.AccountAddress,
+				ClassHash:         classHash,
+				CompiledClassHash: compiledClassHash,
+			}
+
+			signErr := acc.SignDeclareTransaction(ctx, &tx)
+			if signErr != nil {
+				return signErr
+			}
+
+			response, submitErr := acc.AddDeclareTransaction(ctx, rpc.BroadcastDeclareTxnV2{
+				Type:              tx.Type,
+				Version:           tx.Version,
+				MaxFee:            tx.MaxFee,
+				Nonce:             tx.Nonce,
+				SenderAddress:     tx.SenderAddress,
+				CompiledClassHash: tx.CompiledClassHash,
+				Signature:         tx.Signature,
+				ContractClass:     contractClass,
+			})
+			if submitErr != nil {
+				return submitErr
+			}
+
+			cmd.Printf("Transaction hash: %s\nClass hash: %s\n", response.TransactionHash.String(), response.ClassHash.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&rpcURL, "rpc", "", "URL of the JSONRPC API to use")
+	cmd.Flags().UintVar(&timeout, "timeout", 60, "Timeout (in seconds) for interactions with the JSONRPC API")
+	cmd.Flags().StringVar(&accountAddressRaw, "account", "", "Address of the account which signs the transaction")
+	cmd.Flags().StringVar(&privateKeyRaw, "private-key", "", "Private key of the account which signs the transaction")
+	cmd.Flags().IntVar(&cairoVersion, "cairo-version", 2, "Cairo version of the account contract")
+	cmd.Flags().StringVar(&maxFeeRaw, "max-fee", "", "Maximum fee (in wei) to pay for the transaction")
+	cmd.Flags().StringVar(&sierraFile, "sierra", "", "Path to the compiled Sierra contract class (JSON)")
+	cmd.Flags().StringVar(&casmFile, "casm", "", "Path to the compiled CASM contract class (JSON)")
+
+	return cmd
+}
+
+func CreateDeployCommand() *cobra.Command {
+	var rpcURL, accountAddressRaw, privateKeyRaw, maxFeeRaw, classHashRaw, saltRaw string
+	var cairoVersion int
+	var timeout uint
+	var unique bool
+
+	var maxFee, classHash, salt *felt.Felt
+
+	{{if .Constructor}}
+	{{range .Constructor.Definition.Inputs}}
+	var {{(ArgumentName .Name)}} {{(GenerateGoNameForType .Type)}}
+	var {{(ArgumentName .Name)}}Raw string
+	{{- end}}
+	{{end}}
+
+	cmd := &cobra.Command{
+		Use:   "deploy",
+		Short: "Deploy a new {{.StructName}} contract from a declared class using the Universal Deployer Contract",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if maxFeeRaw == "" {
+				return ErrNoMaxFee
+			}
+
+			var parseErr error
+			maxFee, parseErr = ParseFelt(maxFeeRaw)
+			if parseErr != nil {
+				return fmt.Errorf("--max-fee is not a valid felt: %s", parseErr.Error())
+			}
+
+			classHash, parseErr = ParseFelt(classHashRaw)
+			if parseErr != nil {
+				return fmt.Errorf("--class-hash is not a valid felt: %s", parseErr.Error())
+			}
+
+			salt, parseErr = ParseFelt(saltRaw)
+			if parseErr != nil {
+				return fmt.Errorf("--salt is not a valid felt: %s", parseErr.Error())
+			}
+
+			{{if .Constructor}}
+			{{range .Constructor.Definition.Inputs}}
+			{{(ArgumentParserCode .Name (GenerateGoNameForType .Type))}}
+			{{end}}
+			{{end}}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			constructorCalldata := []*felt.Felt{}
+			{{if .Constructor}}
+			var calldataErr error
+			constructorCalldata, calldataErr = {{.Constructor.CalldataName}}({{range .Constructor.Definition.Inputs}}{{(ArgumentName .Name)}}, {{end}})
+			if calldataErr != nil {
+				return calldataErr
+			}
+			{{end}}
+
+			uniqueFelt := new(felt.Felt).SetUint64(0)
+			if unique {
+				uniqueFelt = new(felt.Felt).SetUint64(1)
+			}
+
+			deployerAddress, deployerErr := ParseFelt(UniversalDeployerAddress)
+			if deployerErr != nil {
+				return deployerErr
+			}
+
+			calldata := []*felt.Felt{classHash, salt, uniqueFelt, new(felt.Felt).SetUint64(uint64(len(constructorCalldata)))}
+			calldata = append(calldata, constructorCalldata...)
+
+			provider, providerErr := NewProvider(rpcURL)
+			if providerErr != nil {
+				return providerErr
+			}
+
+			acc, accountErr := NewAccount(provider, accountAddressRaw, privateKeyRaw, cairoVersion)
+			if accountErr != nil {
+				return accountErr
+			}
+
+			ctx, cancel := NewChainContext(timeout)
+			defer cancel()
+
+			call := rpc.FunctionCall{ContractAddress: deployerAddress, EntryPointSelector: SelectorFromName("deployContract"), Calldata: calldata}
+			transactionHash, submitErr := SubmitInvoke(ctx, acc, []rpc.FunctionCall{call}, maxFee)
+			if submitErr != nil {
+				return submitErr
+			}
+
+			cmd.Printf("Transaction hash: %s\n", transactionHash.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&rpcURL, "rpc", "", "URL of the JSONRPC API to use")
+	cmd.Flags().UintVar(&timeout, "timeout", 60, "Timeout (in seconds) for interactions with the JSONRPC API")
+	cmd.Flags().StringVar(&accountAddressRaw, "account", "", "Address of the account which signs the transaction")
+	cmd.Flags().StringVar(&privateKeyRaw, "private-key", "", "Private key of the account which signs the transaction")
+	cmd.Flags().IntVar(&cairoVersion, "cairo-version", 2, "Cairo version of the account contract")
+	cmd.Flags().StringVar(&maxFeeRaw, "max-fee", "", "Maximum fee (in wei) to pay for the transaction")
+	cmd.Flags().StringVar(&classHashRaw, "class-hash", "", "Class hash of the declared {{.StructName}} contract class")
+	cmd.Flags().StringVar(&saltRaw, "salt", "0", "Salt used to derive the address of the deployed contract")
+	cmd.Flags().BoolVar(&unique, "unique", false, "Set this flag to mix the deployer account address into the contract address")
+
+	{{if .Constructor}}
+	{{range .Constructor.Definition.Inputs}}
+	cmd.Flags().StringVar(&{{(ArgumentName .Name)}}Raw, "{{(ArgumentFlag .Name)}}", "", "{{.Name}} argument ({{.Type}})")
+	{{- end}}
+	{{end}}
+
+	return cmd
+}
+`
+
+// This template generates the handlers for all view functions of a contract. It is intended to be used with a
+// CLISpecification struct.
+var ViewFunctionCommandsTemplate string = `{{$structName := .StructName}}
+{{range .ViewFunctions}}
+func CreateView{{.GoName}}Command() *cobra.Command {
+	var contractAddressRaw, rpcURL, blockRaw string
+	var contractAddress *felt.Felt
+	var blockID rpc.BlockID
+	var timeout uint
+
+	{{range .Definition.Inputs}}
+	var {{(ArgumentName .Name)}} {{(GenerateGoNameForType .Type)}}
+	var {{(ArgumentName .Name)}}Raw string
+	{{- end}}
+
+	cmd := &cobra.Command{
+		Use:   "{{(KebabCase .GoName)}}",
+		Short: "Call the {{.OriginalName}} view function on a {{$structName}} contract",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			var parseErr error
+			contractAddress, parseErr = ParseFelt(contractAddressRaw)
+			if parseErr != nil {
+				return fmt.Errorf("--contract is not a valid felt: %s", parseErr.Error())
+			}
+
+			blockID, parseErr = BlockIDFromString(blockRaw)
+			if parseErr != nil {
+				return parseErr
+			}
+
+			{{range .Definition.Inputs}}
+			{{(ArgumentParserCode .Name (GenerateGoNameForType .Type))}}
+			{{end}}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			provider, providerErr := NewProvider(rpcURL)
+			if providerErr != nil {
+				return providerErr
+			}
+
+			ctx, cancel := NewChainContext(timeout)
+			defer cancel()
+
+			{{range $index, $element := .Definition.Outputs}}result{{$index}}, {{end}}callErr := {{.CallerName}}(ctx, provider, contractAddress, blockID, {{range .Definition.Inputs}}{{(ArgumentName .Name)}}, {{end}})
+			if callErr != nil {
+				return callErr
+			}
+
+			{{range $index, $element := .Definition.Outputs}}
+			printErr{{$index}} := PrintJSON(cmd, result{{$index}})
+			if printErr{{$index}} != nil {
+				return printErr{{$index}}
+			}
+			{{- end}}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&rpcURL, "rpc", "", "URL of the JSONRPC API to use")
+	cmd.Flags().StringVar(&blockRaw, "block", "latest", "Block (number, \"latest\" or \"pending\") at which to call the view function")
+	cmd.Flags().UintVar(&timeout, "timeout", 60, "Timeout (in seconds) for interactions with the JSONRPC API")
+	cmd.Flags().StringVar(&contractAddressRaw, "contract", "", "Address of the contract to interact with")
+
+	{{range .Definition.Inputs}}
+	cmd.Flags().StringVar(&{{(ArgumentName .Name)}}Raw, "{{(ArgumentFlag .Name)}}", "", "{{.Name}} argument ({{.Type}})")
+	{{- end}}
+
+	return cmd
+}
+{{- end}}
+`
+
+// This template generates the handlers for all functions of a contract which are invoked in transactions. It is
+// intended to be used with a CLISpecification struct.
+var InvokeFunctionCommandsTemplate string = `{{$structName := .StructName}}
+{{range .ExternalFunctions}}
+func CreateInvoke{{.GoName}}Command() *cobra.Command {
+	var contractAddressRaw, rpcURL, accountAddressRaw, privateKeyRaw, maxFeeRaw string
+	var contractAddress, maxFee *felt.Felt
+	var cairoVersion int
+	var timeout uint
+
+	{{range .Definition.Inputs}}
+	var {{(ArgumentName .Name)}} {{(GenerateGoNameForType .Type)}}
+	var {{(ArgumentName .Name)}}Raw string
+	{{- end}}
+
+	cmd := &cobra.Command{
+		Use:   "{{(KebabCase .GoName)}}",
+		Short: "Invoke the {{.OriginalName}} function on a {{$structName}} contract",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			var parseErr error
+			contractAddress, parseErr = ParseFelt(contractAddressRaw)
+			if parseErr != nil {
+				return fmt.Errorf("--contract is not a valid felt: %s", parseErr.Error())
+			}
+
+			if maxFeeRaw == "" {
+				return ErrNoMaxFee
+			}
+			maxFee, parseErr = ParseFelt(maxFeeRaw)
+			if parseErr != nil {
+				return fmt.Errorf("--max-fee is not a valid felt: %s", parseErr.Error())
+			}
+
+			{{range .Definition.Inputs}}
+			{{(ArgumentParserCode .Name (GenerateGoNameForType .Type))}}
+			{{end}}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			call, callErr := {{.FunctionCallName}}(contractAddress, {{range .Definition.Inputs}}{{(ArgumentName .Name)}}, {{end}})
+			if callErr != nil {
+				return callErr
+			}
+
+			provider, providerErr := NewProvider(rpcURL)
+			if providerErr != nil {
+				return providerErr
+			}
+
+			acc, accountErr := NewAccount(provider, accountAddressRaw, privateKeyRaw, cairoVersion)
+			if accountErr != nil {
+				return accountErr
+			}
+
+			ctx, cancel := NewChainContext(timeout)
+			defer cancel()
+
+			transactionHash, submitErr := SubmitInvoke(ctx, acc, []rpc.FunctionCall{call}, maxFee)
+			if submitErr != nil {
+				return submitErr
+			}
+
+			cmd.Printf("Transaction hash: %s\n", transactionHash.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&rpcURL, "rpc", "", "URL of the JSONRPC API to use")
+	cmd.Flags().UintVar(&timeout, "timeout", 60, "Timeout (in seconds) for interactions with the JSONRPC API")
+	cmd.Flags().StringVar(&contractAddressRaw, "contract", "", "Address of the contract to interact with")
+	cmd.Flags().StringVar(&accountAddressRaw, "account", "", "Address of the account which signs the transaction")
+	cmd.Flags().StringVar(&privateKeyRaw, "private-key", "", "Private key of the account which signs the transaction")
+	cmd.Flags().IntVar(&cairoVersion, "cairo-version", 2, "Cairo version of the account contract")
+	cmd.Flags().StringVar(&maxFeeRaw, "max-fee", "", "Maximum fee (in wei) to pay for the transaction")
+
+	{{range .Definition.Inputs}}
+	cmd.Flags().StringVar(&{{(ArgumentName .Name)}}Raw, "{{(ArgumentFlag .Name)}}", "", "{{.Name}} argument ({{.Type}})")
+	{{- end}}
+
+	return cmd
+}
+{{- end}}
+`
@@ -20,18 +20,20 @@ type GenerationParameters struct {
 // The output of the code generation process for enum items in a Starknet ABI.
 type GeneratedEnum struct {
 	GenerationParameters
-	ParserName    string
-	EvaluatorName string
-	Definition    *Enum
-	Code          string
+	ParserName     string
+	SerializerName string
+	EvaluatorName  string
+	Definition     *Enum
+	Code           string
 }
 
 // The output of the code generation process for struct items in a Starknet ABI.
 type GeneratedStruct struct {
 	GenerationParameters
-	ParserName string
-	Definition *Struct
-	Code       string
+	ParserName     string
+	SerializerName string
+	Definition     *Struct
+	Code           string
 }
 
 type GeneratedEvent struct {
@@ -44,10 +46,24 @@ type GeneratedEvent struct {
 	Code         string
 }
 
+// The output of the code generation process for function items (and the constructor) in a Starknet ABI.
+type GeneratedFunction struct {
+	GenerationParameters
+	FunctionNameVar  string
+	CalldataName     string
+	CallerName       string
+	FunctionCallName string
+	View             bool
+	Definition       *Function
+	Code             string
+}
+
 // Defines the parameters used to create the header information for the generated code.
 type HeaderParameters struct {
 	Version     string
 	PackageName string
+	CLI         bool
+	StructName  string
 }
 
 func toCamelCase(s string) string {
@@ -57,6 +73,10 @@ func toCamelCase(s string) string {
 
 var resultEventParserKey string = "-eventparser"
 
+// Prefix for the keys of function snippets in the result of GenerateSnippets. Function names are not
+// fully qualified, so they are kept apart from the names of types.
+var resultFunctionKeyPrefix string = "-function:"
+
 // Generates a Go name for a Starknet ABI item given its fully qualified ABI name.
 // Qualified names for Starknet ABI items are of the form:
 // `core::starknet::contract_address::ContractAddress`
@@ -126,6 +146,48 @@ func ParserFunction(goType string) string {
 	return parserFunction
 }
 
+// Returns the name of the function that serializes the given Go type into calldata. This is the inverse of
+// the function returned by ParserFunction.
+func SerializerFunction(goType string) string {
+	baseType := goType
+	numWraps := 0
+	for strings.HasPrefix(baseType, "[]") {
+		baseType = strings.TrimPrefix(baseType, "[]")
+		numWraps++
+	}
+
+	var serializerFunction string
+
+	if numWraps == 0 {
+		switch goType {
+		case "uint64":
+			serializerFunction = "SerializeUint64"
+		case "*big.Int":
+			serializerFunction = "SerializeBigInt"
+		case "string":
+			serializerFunction = "SerializeString"
+		default:
+			serializerFunction = fmt.Sprintf("Serialize%s", goType)
+		}
+	} else {
+		baseSerializer := SerializerFunction(baseType)
+		serializerFunction = ""
+		for i := numWraps - 1; i >= 0; i-- {
+			arraySerializer := fmt.Sprintf("SerializeArray[%s%s](", strings.Repeat("[]", i), baseType)
+			serializerFunction = fmt.Sprintf("%s%s", serializerFunction, arraySerializer)
+		}
+		serializerFunction = fmt.Sprintf("%s%s%s", serializerFunction, baseSerializer, strings.Repeat(")", numWraps))
+	}
+
+	return serializerFunction
+}
+
+// Generates a Go name for a function argument. Arguments are prefixed so that they never clash with
+// Go keywords or with the variables used in the generated code.
+func ArgumentName(name string) string {
+	return fmt.Sprintf("arg%s", toCamelCase(name))
+}
+
 func ShouldGenerateStructType(goName string) bool {
 	if goName == "uint64" || goName == "*big.Int" || goName == "string" || strings.HasPrefix(goName, "[]") {
 		return false
@@ -139,6 +201,7 @@ func ShouldGenerateStructType(goName string) bool {
 // - Enums
 // - Structs
 // - Events
+// - Functions (calldata builders, view callers and invoke calls) and the constructor
 //
 // ABI names are used to depuplicate code snippets. The assumption is that the Starknet fully
 // qualified name for a type uniquely determines that type across the entire ABI. This way
@@ -147,15 +210,17 @@ func ShouldGenerateStructType(goName string) bool {
 func GenerateSnippets(parsed *ParsedABI) (map[string]string, error) {
 	result := map[string]string{}
 
-	enumTemplate, enumTemplateParseErr := template.New("enum").Parse(EnumTemplate)
-	if enumTemplateParseErr != nil {
-		return result, enumTemplateParseErr
-	}
-
 	templateFuncs := map[string]any{
+		"ArgumentName":          ArgumentName,
 		"CamelCase":             toCamelCase,
 		"GenerateGoNameForType": GenerateGoNameForType,
 		"ParserFunction":        ParserFunction,
+		"SerializerFunction":    SerializerFunction,
+	}
+
+	enumTemplate, enumTemplateParseErr := template.New("enum").Parse(EnumTemplate)
+	if enumTemplateParseErr != nil {
+		return result, enumTemplateParseErr
 	}
 
 	structTemplate, structTemplateParseErr := template.New("struct").Funcs(templateFuncs).Parse(StructTemplate)
@@ -173,9 +238,15 @@ func GenerateSnippets(parsed *ParsedABI) (map[string]string, error) {
 		return result, eventParserTemplatErr
 	}
 
+	functionTemplate, functionTemplateErr := template.New("function").Funcs(templateFuncs).Parse(FunctionTemplate)
+	if functionTemplateErr != nil {
+		return result, functionTemplateErr
+	}
+
 	for _, enum := range parsed.Enums {
 		goName := GenerateGoNameForType(enum.Name)
 		parseFunctionName := ParserFunction(goName)
+		serializeFunctionName := SerializerFunction(goName)
 		evaluateFunctionName := fmt.Sprintf("Evaluate%s", goName)
 
 		generated := GeneratedEnum{
@@ -183,10 +254,11 @@ func GenerateSnippets(parsed *ParsedABI) (map[string]string, error) {
 				OriginalName: enum.Name,
 				GoName:       goName,
 			},
-			ParserName:    parseFunctionName,
-			EvaluatorName: evaluateFunctionName,
-			Definition:    enum,
-			Code:          "",
+			ParserName:     parseFunctionName,
+			SerializerName: serializeFunctionName,
+			EvaluatorName:  evaluateFunctionName,
+			Definition:     enum,
+			Code:           "",
 		}
 
 		var b bytes.Buffer
@@ -209,9 +281,10 @@ func GenerateSnippets(parsed *ParsedABI) (map[string]string, error) {
 					OriginalName: structItem.Name,
 					GoName:       goName,
 				},
-				ParserName: parseFunctionName,
-				Definition: structItem,
-				Code:       "",
+				ParserName:     parseFunctionName,
+				SerializerName: SerializerFunction(goName),
+				Definition:     structItem,
+				Code:           "",
 			}
 
 			var b bytes.Buffer
@@ -273,11 +346,78 @@ func GenerateSnippets(parsed *ParsedABI) (map[string]string, error) {
 		result[resultEventParserKey] = b.String()
 	}
 
+	generatedFunctions, generatedFunctionsErr := GenerateFunctions(parsed)
+	if generatedFunctionsErr != nil {
+		return result, generatedFunctionsErr
+	}
+
+	for _, generated := range generatedFunctions {
+		var b bytes.Buffer
+		templateErr := functionTemplate.Execute(&b, generated)
+		if templateErr != nil {
+			return result, templateErr
+		}
+
+		generated.Code = b.String()
+
+		result[resultFunctionKeyPrefix+generated.OriginalName] = generated.Code
+	}
+
+	return result, nil
+}
+
+// GenerateFunctions derives the generation parameters for the functions and the constructor of a Starknet ABI.
+// Functions are returned in the order in which they appear in the ABI, with duplicates (by name) removed. The
+// constructor, if present, is the first item of the result.
+func GenerateFunctions(parsed *ParsedABI) ([]GeneratedFunction, error) {
+	result := []GeneratedFunction{}
+
+	if parsed.Constructor != nil {
+		result = append(result, GeneratedFunction{
+			GenerationParameters: GenerationParameters{
+				OriginalName: parsed.Constructor.Name,
+				GoName:       "Constructor",
+			},
+			CalldataName: "Calldata_Constructor",
+			Definition: &Function{
+				Type:   "constructor",
+				Name:   parsed.Constructor.Name,
+				Inputs: parsed.Constructor.Inputs,
+			},
+		})
+	}
+
+	seen := map[string]bool{}
+	for _, function := range parsed.Functions {
+		if seen[function.Name] {
+			continue
+		}
+		seen[function.Name] = true
+
+		goName := toCamelCase(function.Name)
+		if goName == "Constructor" {
+			return result, fmt.Errorf("function name %s clashes with the name used for the constructor", function.Name)
+		}
+
+		result = append(result, GeneratedFunction{
+			GenerationParameters: GenerationParameters{
+				OriginalName: function.Name,
+				GoName:       goName,
+			},
+			FunctionNameVar:  fmt.Sprintf("Function_%s", goName),
+			CalldataName:     fmt.Sprintf("Calldata_%s", goName),
+			CallerName:       fmt.Sprintf("Call_%s", goName),
+			FunctionCallName: fmt.Sprintf("FunctionCall_%s", goName),
+			View:             function.StateMutability == "view",
+			Definition:       function,
+		})
+	}
+
 	return result, nil
 }
 
 // Generates the header for the output code.
-func GenerateHeader(packageName string) (string, error) {
+func GenerateHeader(packageName string, cli bool, structName string) (string, error) {
 	headerTemplate, headerTemplateParseErr := template.New("struct").Parse(HeaderTemplate)
 	if headerTemplateParseErr != nil {
 		return "", headerTemplateParseErr
@@ -286,6 +426,8 @@ func GenerateHeader(packageName string) (string, error) {
 	parameters := HeaderParameters{
 		Version:     version.SeerVersion,
 		PackageName: packageName,
+		CLI:         cli,
+		StructName:  structName,
 	}
 
 	var b bytes.Buffer
@@ -304,7 +446,7 @@ func Generate(parsed *ParsedABI) (string, error) {
 		return "", snippetsErr
 	}
 
-	commonCode := strings.Join([]string{StructCommonCode, EventsCommonCode}, "\n\n")
+	commonCode := strings.Join([]string{StructCommonCode, EventsCommonCode, FunctionsCommonCode}, "\n\n")
 
 	sections := make([]string, len(snippets))
 	currentSection := 0
@@ -336,6 +478,11 @@ func {{.ParserName}} (parameters []*felt.Felt) ({{.GoName}}, int, error) {
 	return {{.GoName}}(parameters[0].Uint64()), 1, nil
 }
 
+// {{.SerializerName}} serializes a {{.GoName}} into a list of felts, as it is passed in calldata.
+func {{.SerializerName}}(value {{.GoName}}) ([]*felt.Felt, error) {
+	return SerializeUint64(value)
+}
+
 // This function returns the string representation of a {{.GoName}} enum. This is the enum value from the ABI definition of the enum.
 func {{.EvaluatorName}}(raw {{.GoName}}) string {
 	switch raw {
@@ -430,6 +577,22 @@ func {{.ParserName}}(parameters []*felt.Felt) ({{.GoName}}, int, error) {
 
 	return result, currentIndex, nil
 }
+
+// {{.SerializerName}} serializes a {{.GoName}} struct into a list of felts, as it is passed in calldata.
+func {{.SerializerName}}(value {{.GoName}}) ([]*felt.Felt, error) {
+	result := []*felt.Felt{}
+
+	{{range $index, $element := .Definition.Members}}
+	serialized{{$index}}, err := {{(SerializerFunction (GenerateGoNameForType .Type))}}(value.{{(CamelCase .Name)}})
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, serialized{{$index}}...)
+
+	{{end}}
+
+	return result, nil
+}
 `
 
 // Common code used in the code generated for events.
@@ -669,12 +832,121 @@ func (p *EventParser) Parse(event RawEvent) (ParsedEvent, error) {
 }
 `
 
+// Common code used in the code generated for functions.
+var FunctionsCommonCode string = `func SerializeUint64(value uint64) ([]*felt.Felt, error) {
+	return []*felt.Felt{new(felt.Felt).SetUint64(value)}, nil
+}
+
+func SerializeBigInt(value *big.Int) ([]*felt.Felt, error) {
+	if value == nil {
+		return nil, ErrIncorrectParameters
+	}
+	return []*felt.Felt{new(felt.Felt).SetBigInt(value)}, nil
+}
+
+// SerializeString accepts hex (0x-prefixed) and decimal representations of a felt.
+func SerializeString(value string) ([]*felt.Felt, error) {
+	if value == "" {
+		return nil, ErrIncorrectParameters
+	}
+	result, err := new(felt.Felt).SetString(value)
+	if err != nil {
+		return nil, err
+	}
+	return []*felt.Felt{result}, nil
+}
+
+func SerializeArray[T any](serializer func(value T) ([]*felt.Felt, error)) func(values []T) ([]*felt.Felt, error) {
+	return func(values []T) ([]*felt.Felt, error) {
+		result := []*felt.Felt{new(felt.Felt).SetUint64(uint64(len(values)))}
+		for _, value := range values {
+			serialized, err := serializer(value)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, serialized...)
+		}
+		return result, nil
+	}
+}
+
+// Returns the entry point selector of the function with the given name.
+func SelectorFromName(name string) *felt.Felt {
+	return utils.GetSelectorFromNameFelt(name)
+}
+`
+
+// This is the Go template which is used to generate the Go bindings to a Starknet ABI function. For the
+// constructor, only the calldata builder is generated.
+// This template should be applied to a GeneratedFunction struct.
+var FunctionTemplate string = `// ABI: {{.OriginalName}}
+{{if ne .Definition.Type "constructor"}}
+// ABI name for function
+var {{.FunctionNameVar}} string = "{{.OriginalName}}"
+{{end}}
+// {{.CalldataName}} serializes the arguments of {{.OriginalName}} into calldata.
+func {{.CalldataName}}({{range .Definition.Inputs}}{{(ArgumentName .Name)}} {{(GenerateGoNameForType .Type)}}, {{end}}) ([]*felt.Felt, error) {
+	calldata := []*felt.Felt{}
+
+	{{range $index, $element := .Definition.Inputs}}
+	serialized{{$index}}, err := {{(SerializerFunction (GenerateGoNameForType .Type))}}({{(ArgumentName .Name)}})
+	if err != nil {
+		return nil, err
+	}
+	calldata = append(calldata, serialized{{$index}}...)
+
+	{{end}}
+
+	return calldata, nil
+}
+{{if ne .Definition.Type "constructor"}}
+{{if .View}}
+// {{.CallerName}} calls the {{.OriginalName}} view function on the contract at the given address and parses its outputs.
+func {{.CallerName}}(ctx context.Context, provider *rpc.Provider, contractAddress *felt.Felt, blockID rpc.BlockID, {{range .Definition.Inputs}}{{(ArgumentName .Name)}} {{(GenerateGoNameForType .Type)}}, {{end}}) ({{range $index, $element := .Definition.Outputs}}result{{$index}} {{(GenerateGoNameForType .Type)}}, {{end}}err error) {
+	calldata, err := {{.CalldataName}}({{range .Definition.Inputs}}{{(ArgumentName .Name)}}, {{end}})
+	if err != nil {
+		return
+	}
+
+	{{if .Definition.Outputs}}response, err := {{else}}_, err = {{end}}provider.Call(ctx, rpc.FunctionCall{ContractAddress: contractAddress, EntryPointSelector: SelectorFromName({{.FunctionNameVar}}), Calldata: calldata}, blockID)
+	if err != nil {
+		return
+	}
+	{{if .Definition.Outputs}}
+	currentIndex := 0
+	{{range $index, $element := .Definition.Outputs}}
+	var consumed{{$index}} int
+	result{{$index}}, consumed{{$index}}, err = {{(ParserFunction (GenerateGoNameForType .Type))}}(response[currentIndex:])
+	if err != nil {
+		return
+	}
+	currentIndex += consumed{{$index}}
+	{{end}}
+	{{- end}}
+
+	return
+}
+{{end}}
+// {{.FunctionCallName}} builds the call of {{.OriginalName}} on the contract at the given address. Calls are
+// submitted to Starknet in invoke transactions signed by an account.
+func {{.FunctionCallName}}(contractAddress *felt.Felt, {{range .Definition.Inputs}}{{(ArgumentName .Name)}} {{(GenerateGoNameForType .Type)}}, {{end}}) (rpc.FunctionCall, error) {
+	calldata, err := {{.CalldataName}}({{range .Definition.Inputs}}{{(ArgumentName .Name)}}, {{end}})
+	if err != nil {
+		return rpc.FunctionCall{}, err
+	}
+
+	return rpc.FunctionCall{ContractAddress: contractAddress, EntryPointSelector: SelectorFromName({{.FunctionNameVar}}), Calldata: calldata}, nil
+}
+{{end}}
+`
+
 // This is the Go template used to create header information at the top of the generated code.
 // At a bare minimum, the header specifies the version of seer that was used to generate the code.
+// If the CLI is generated, the header also imports the packages used by the code AddCLI adds.
 // This template should be applied to a HeaderParameters struct.
 var HeaderTemplate string = `// This file was generated by seer: https://github.com/G7DAO/seer.
 // seer version: {{.Version}}
-// seer command: seer starknet generate {{if .PackageName}}--package {{.PackageName}}{{end}}
+// seer command: seer starknet generate {{if .PackageName}}--package {{.PackageName}}{{end}}{{if .CLI}} --cli --struct {{.StructName}}{{end}}
 // Warning: Edit at your own risk. Any edits you make will NOT survive the next code generation.
 
 {{if .PackageName}}package {{.PackageName}}{{end}}
@@ -684,11 +956,24 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	{{- if .CLI}}
+	"fmt"{{end}}
 	"math/big"
+	{{- if .CLI}}
+	"os"
+	"strconv"{{end}}
 	"time"
 
 	"github.com/NethermindEth/juno/core/felt"
+	{{- if .CLI}}
+	"github.com/NethermindEth/starknet.go/account"
+	"github.com/NethermindEth/starknet.go/contracts"
+	"github.com/NethermindEth/starknet.go/curve"
+	"github.com/NethermindEth/starknet.go/hash"{{end}}
 	"github.com/NethermindEth/starknet.go/rpc"
+	"github.com/NethermindEth/starknet.go/utils"
 	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	{{- if .CLI}}
+	"github.com/spf13/cobra"{{end}}
 )
 `
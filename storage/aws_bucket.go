@@ -1,42 +1,68 @@
 package storage
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"log"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 )
 
-// S3 implements the Storer interface for Amazon S3 Bucket
+// S3 implements the Storer interface for Amazon S3 Bucket. Region and credentials are taken
+// from AWS environment and shared config, custom endpoint makes it work with S3 compatible
+// storages such as MinIO.
 type S3 struct {
+	Client   *s3.S3
 	BasePath string
+
+	// Bucket overrides SEER_CRAWLER_STORAGE_BUCKET, it is set for replicas
+	Bucket string
+}
+
+func NewS3Storage(basePath string) (*S3, error) {
+	config := aws.NewConfig()
+	if AWSStorageRegion != "" {
+		config = config.WithRegion(AWSStorageRegion)
+	}
+	if AWSStorageEndpoint != "" {
+		config = config.WithEndpoint(AWSStorageEndpoint).WithS3ForcePathStyle(true)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *config,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %v", err)
+	}
+
+	return &S3{Client: s3.New(sess), BasePath: basePath}, nil
 }
 
-func NewS3Storage(basePath string) *S3 {
-	return &S3{BasePath: basePath}
+func (s *S3) bucketName() string {
+	if s.Bucket != "" {
+		return s.Bucket
+	}
+	return SeerCrawlerStorageBucket
 }
 
 func (s *S3) Save(batchDir, filename string, bf bytes.Buffer) error {
 	key := filepath.Join(s.BasePath, batchDir, filename)
 
-	sess := session.Must(session.NewSession(&aws.Config{
-		Region: aws.String("us-west-2"),
-	}))
-
-	svc := s3.New(sess)
-
-	// Upload the data to S3
-	_, err := svc.PutObject(&s3.PutObjectInput{
-		Bucket: aws.String("myBucket"),
+	_, err := s.Client.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(s.bucketName()),
 		Key:    aws.String(key),
 		Body:   bytes.NewReader(bf.Bytes()),
 		Metadata: map[string]*string{
-			"encoder": aws.String("default"),
+			"encoder": aws.String("varint-size-delimited"),
 		},
 	})
 	if err != nil {
@@ -47,17 +73,10 @@ func (s *S3) Save(batchDir, filename string, bf bytes.Buffer) error {
 }
 
 func (s *S3) Read(key string) (bytes.Buffer, error) {
-	sess := session.Must(session.NewSession(&aws.Config{
-		Region: aws.String("us-west-2"),
-	}))
-
-	svc := s3.New(sess)
-
-	result, err := svc.GetObject(&s3.GetObjectInput{
-		Bucket: aws.String("myBucket"),
+	result, err := s.Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucketName()),
 		Key:    aws.String(key),
 	})
-
 	if err != nil {
 		return bytes.Buffer{}, fmt.Errorf("failed to get object: %v", err)
 	}
@@ -73,31 +92,107 @@ func (s *S3) Read(key string) (bytes.Buffer, error) {
 }
 
 func (s *S3) Delete(key string) error {
-
-	sess := session.Must(session.NewSession(&aws.Config{
-		Region: aws.String("us-west-2"),
-	}))
-
-	svc := s3.New(sess)
-
-	_, err := svc.DeleteObject(&s3.DeleteObjectInput{
-		Bucket: aws.String("myBucket"),
+	_, err := s.Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucketName()),
 		Key:    aws.String(key),
 	})
-
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to delete object from bucket: %v", err)
 	}
 
 	return nil
-
 }
 
+var (
+	S3ListReturnNameFunc = func(item any) string {
+		if object, ok := item.(*s3.Object); ok {
+			return aws.StringValue(object.Key)
+		}
+		return ""
+	}
+
+	S3ListReturnPrefixFunc = func(item any) string {
+		if prefix, ok := item.(*s3.CommonPrefix); ok {
+			return aws.StringValue(prefix.Prefix)
+		}
+		return ""
+	}
+)
+
+// List passes objects and, if delim is set, common prefixes under base path to returnFunc,
+// the same way as GCS does.
 func (s *S3) List(ctx context.Context, delim, blockBatch string, timeout int, returnFunc ListReturnFunc) ([]string, error) {
-	return []string{}, nil
+	ctx, cancel := context.WithTimeout(ctx, time.Second*time.Duration(timeout))
+	defer cancel()
+
+	prefix := fmt.Sprintf("%s/", s.BasePath)
+	if blockBatch != "" {
+		prefix = fmt.Sprintf("%s%s/", prefix, blockBatch)
+	}
+	log.Printf("Loading bucket items with prefix: %s and delim: %s", prefix, delim)
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucketName()),
+		Prefix: aws.String(prefix),
+	}
+	if delim != "" {
+		input.Delimiter = aws.String(delim)
+	}
+
+	var items []string
+	err := s.Client.ListObjectsV2PagesWithContext(ctx, input, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, commonPrefix := range page.CommonPrefixes {
+			if returnVal := returnFunc(commonPrefix); returnVal != "" {
+				items = append(items, returnVal)
+			}
+		}
+		for _, object := range page.Contents {
+			if returnVal := returnFunc(object); returnVal != "" {
+				items = append(items, returnVal)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return []string{}, fmt.Errorf("failed to list objects of bucket %s: %w", s.bucketName(), err)
+	}
+
+	log.Printf("Listed %d items", len(items))
+
+	return items, nil
 }
 
 func (s *S3) ReadBatch(readItems []ReadItem) (map[string][]string, error) {
-	// Implement the ReadBatch method
-	return nil, nil
+	result := make(map[string][]string)
+
+	for _, item := range readItems {
+		data, err := s.Read(item.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		rowMap := make(map[uint64]bool)
+		for _, id := range item.RowIds {
+			rowMap[id] = true
+		}
+
+		reader := bufio.NewReader(&data)
+		var currentRow uint64 = 0
+		for {
+			line, err := reader.ReadString('\n')
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read object from bucket: %v", err)
+			}
+
+			if rowMap[currentRow] {
+				result[item.Key] = append(result[item.Key], strings.TrimSuffix(line, "\n"))
+			}
+			currentRow++
+		}
+	}
+
+	return result, nil
 }
@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Codecs of proto batches, codec is recorded as extension of batch filename
+const (
+	CompressionNone = "none"
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+const batchFilename = "data.proto"
+
+var compressionExtensions = map[string]string{
+	CompressionNone: "",
+	CompressionGzip: ".gz",
+	CompressionZstd: ".zst",
+}
+
+var (
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+	zstdErr     error
+)
+
+// zstdCodec returns encoder and decoder shared by all storages, both are safe for concurrent
+// EncodeAll and DecodeAll calls.
+func zstdCodec() (*zstd.Encoder, *zstd.Decoder, error) {
+	zstdOnce.Do(func() {
+		zstdEncoder, zstdErr = zstd.NewWriter(nil)
+		if zstdErr != nil {
+			return
+		}
+		zstdDecoder, zstdErr = zstd.NewReader(nil)
+	})
+	return zstdEncoder, zstdDecoder, zstdErr
+}
+
+// ParseCompression validates codec name, empty name means no compression.
+func ParseCompression(codec string) (string, error) {
+	if codec == "" {
+		return CompressionNone, nil
+	}
+	if _, ok := compressionExtensions[codec]; !ok {
+		return "", fmt.Errorf("unsupported compression %q, expected zstd, gzip or none", codec)
+	}
+	return codec, nil
+}
+
+// BatchFilename returns filename of proto batch compressed with codec in directory of batch
+// of BatchLayoutV1.
+func BatchFilename(codec string) string {
+	return batchFilename + compressionExtensions[codec]
+}
+
+// BatchFilenames returns filenames of proto batch of BatchLayoutV1 with all codecs, batches of
+// the same chain could be written with different codecs if compression was changed.
+func BatchFilenames() []string {
+	return []string{BatchFilename(CompressionNone), BatchFilename(CompressionZstd), BatchFilename(CompressionGzip)}
+}
+
+// CompressionOf returns codec recorded in extension of key.
+func CompressionOf(key string) string {
+	switch {
+	case strings.HasSuffix(key, compressionExtensions[CompressionZstd]):
+		return CompressionZstd
+	case strings.HasSuffix(key, compressionExtensions[CompressionGzip]):
+		return CompressionGzip
+	default:
+		return CompressionNone
+	}
+}
+
+func Compress(codec string, data []byte) ([]byte, error) {
+	switch codec {
+	case CompressionZstd:
+		encoder, _, err := zstdCodec()
+		if err != nil {
+			return nil, err
+		}
+		return encoder.EncodeAll(data, make([]byte, 0, len(data)/4)), nil
+	case CompressionGzip:
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write(data); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return data, nil
+	}
+}
+
+func Decompress(codec string, data []byte) ([]byte, error) {
+	switch codec {
+	case CompressionZstd:
+		_, decoder, err := zstdCodec()
+		if err != nil {
+			return nil, err
+		}
+		return decoder.DecodeAll(data, nil)
+	case CompressionGzip:
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return io.ReadAll(reader)
+	default:
+		return data, nil
+	}
+}
+
+// CompressedStorage compresses objects on save and decompresses them on read by extension of
+// their keys, objects without codec extension are passed as is.
+type CompressedStorage struct {
+	Storer
+}
+
+func (s *CompressedStorage) Save(batchDir, filename string, bf bytes.Buffer) error {
+	codec := CompressionOf(filename)
+	if codec == CompressionNone {
+		return s.Storer.Save(batchDir, filename, bf)
+	}
+
+	data, err := Compress(codec, bf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to compress %s with %s: %v", filename, codec, err)
+	}
+
+	return s.Storer.Save(batchDir, filename, *bytes.NewBuffer(data))
+}
+
+func (s *CompressedStorage) Read(key string) (bytes.Buffer, error) {
+	buf, err := s.Storer.Read(key)
+	if err != nil {
+		return buf, err
+	}
+
+	codec := CompressionOf(key)
+	if codec == CompressionNone {
+		return buf, nil
+	}
+
+	data, err := Decompress(codec, buf.Bytes())
+	if err != nil {
+		return bytes.Buffer{}, fmt.Errorf("failed to decompress %s with %s: %v", key, codec, err)
+	}
+
+	return *bytes.NewBuffer(data), nil
+}
+
+// Close closes wrapped storage if it has to be closed.
+func (s *CompressedStorage) Close() {
+	if closer, ok := s.Storer.(interface{ Close() }); ok {
+		closer.Close()
+	}
+}
@@ -9,12 +9,45 @@ import (
 	"google.golang.org/api/option"
 )
 
-// NewStorage initialize storage placement for protobuf batch data.
+// NewStorage initialize storage placement for protobuf batch data. If replicas are configured,
+// batches are replicated to them and read from storage of current region. Batches are
+// compressed and decompressed by codec extension of their filenames.
 func NewStorage(storageType, basePath string) (Storer, error) {
+	primary, err := newStorage(storageType, "", basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(SeerCrawlerStorageReplicas) == 0 {
+		return &CompressedStorage{Storer: primary}, nil
+	}
+
+	replicas := make(map[string]Storer)
+	for _, replicaConfig := range SeerCrawlerStorageReplicas {
+		replica, replicaErr := newStorage(replicaConfig.Type, replicaConfig.Location, basePath)
+		if replicaErr != nil {
+			return nil, fmt.Errorf("failed to create replica storage in region %s: %v", replicaConfig.Region, replicaErr)
+		}
+		replicas[replicaConfig.Region] = replica
+	}
+
+	log.Printf("Replicating storage from %s region to %d replicas, reading from %s region", SeerCrawlerStorageRegion, len(replicas), SeerCrawlerRegion)
+
+	return &CompressedStorage{Storer: NewReplicatedStorage(primary, SeerCrawlerStorageRegion, replicas, SeerCrawlerRegion, ReplicationStatusTracker)}, nil
+}
+
+// newStorage creates single storage, location is a bucket or root directory of replica
+// and empty for primary storage.
+func newStorage(storageType, location, basePath string) (Storer, error) {
 	switch storageType {
 	case "filesystem":
 		log.Println("Using filesystem storage")
-		return NewFileStorage(basePath), nil
+		fileStorage := NewFileStorage(basePath)
+		fileStorage.Root = location
+		if location == "" {
+			fileStorage.Root = SeerCrawlerStorageRoot
+		}
+		return fileStorage, nil
 	case "gcp-storage":
 		// Google Cloud Storage
 		ctx := context.Background()
@@ -31,12 +64,18 @@ func NewStorage(storageType, basePath string) (Storer, error) {
 			return nil, fmt.Errorf("failed to create GCS client: %v", clientErr)
 		}
 
-		return NewGCSStorage(client, basePath), nil
+		gcsStorage := NewGCSStorage(client, basePath)
+		gcsStorage.Bucket = location
+		return gcsStorage, nil
 	case "aws-bucket":
 		// Amazon S3 Bucket
-		// TODO: Add client initialization
-		log.Println("AWS bucket support not implemented yet")
-		return NewS3Storage(basePath), nil
+		log.Println("Creating S3 client")
+		s3Storage, s3Err := NewS3Storage(basePath)
+		if s3Err != nil {
+			return nil, s3Err
+		}
+		s3Storage.Bucket = location
+		return s3Storage, nil
 	default:
 		return nil, fmt.Errorf("unsupported storage type: %s", storageType)
 	}
@@ -13,34 +13,42 @@ import (
 
 type FileStorage struct {
 	BasePath string
+
+	// Root is a directory keys are resolved against, it is set for replicas
+	Root string
 }
 
 func NewFileStorage(basePath string) *FileStorage {
 	return &FileStorage{BasePath: basePath}
 }
 
+func (fs *FileStorage) path(key string) string {
+	if fs.Root == "" {
+		return key
+	}
+	return filepath.Join(fs.Root, key)
+}
+
 func (fs *FileStorage) Save(batchDir, filename string, bf bytes.Buffer) error {
-	keyDir := filepath.Join(fs.BasePath, batchDir)
+	keyDir := fs.path(filepath.Join(fs.BasePath, batchDir))
 	key := filepath.Join(keyDir, filename)
 
 	// Check if the directory exists
 	// If not, create it
 	if _, err := os.Stat(keyDir); os.IsNotExist(err) {
-		os.MkdirAll(keyDir, os.ModePerm)
+		if err := os.MkdirAll(keyDir, os.ModePerm); err != nil {
+			return fmt.Errorf("failed to create directory %s: %v", keyDir, err)
+		}
 	}
 
 	file, err := os.OpenFile(key, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) // that cool
-
 	if err != nil {
-		log.Fatalf("Failed to open file %s: %v", key, err)
+		return fmt.Errorf("failed to open file %s: %v", key, err)
 	}
-
 	defer file.Close()
 
-	_, err = io.Copy(file, &bf)
-
-	if err != nil {
-		log.Fatalf("Failed to write to file %s: %v", key, err)
+	if _, err := io.Copy(file, &bf); err != nil {
+		return fmt.Errorf("failed to write to file %s: %v", key, err)
 	}
 
 	return nil
@@ -49,7 +57,7 @@ func (fs *FileStorage) Save(batchDir, filename string, bf bytes.Buffer) error {
 
 func (fs *FileStorage) Read(key string) (bytes.Buffer, error) {
 
-	file, err := os.Open(key)
+	file, err := os.Open(fs.path(key))
 	if err != nil {
 		return bytes.Buffer{}, fmt.Errorf("failed to open file %s: %v", key, err)
 	}
@@ -85,7 +93,7 @@ func (fs *FileStorage) ReadBatch(readItems []ReadItem) (map[string][]string, err
 				}
 			}
 		} else {
-			file, err := os.Open(item.Key)
+			file, err := os.Open(fs.path(item.Key))
 			if err != nil {
 				return nil, err
 			}
@@ -119,9 +127,12 @@ func (fs *FileStorage) ReadBatch(readItems []ReadItem) (map[string][]string, err
 
 func (fs *FileStorage) List(ctx context.Context, delim, blockBatch string, timeout int, returnFunc ListReturnFunc) ([]string, error) {
 	prefix := fmt.Sprintf("%s/", fs.BasePath)
+	if blockBatch != "" {
+		prefix = fmt.Sprintf("%s%s/", prefix, blockBatch)
+	}
 	log.Printf("Loading directory items with prefix: %s", prefix)
 
-	dirs, readDirErr := os.ReadDir(prefix)
+	dirs, readDirErr := os.ReadDir(fs.path(prefix))
 	if readDirErr != nil {
 		return []string{}, readDirErr
 	}
@@ -140,7 +151,9 @@ func (fs *FileStorage) List(ctx context.Context, delim, blockBatch string, timeo
 }
 
 func (fs *FileStorage) Delete(key string) error {
+	if err := os.Remove(fs.path(key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete file %s: %v", key, err)
+	}
 
-	// Implement the Delete method
 	return nil
 }
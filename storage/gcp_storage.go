@@ -19,6 +19,9 @@ import (
 type GCS struct {
 	Client   *storage.Client
 	BasePath string
+
+	// Bucket overrides SEER_CRAWLER_STORAGE_BUCKET, it is set for replicas
+	Bucket string
 }
 
 // NewGCSStorage initializes a GCS storage with the provided client
@@ -29,12 +32,19 @@ func NewGCSStorage(client *storage.Client, basePath string) *GCS {
 	}
 }
 
+func (g *GCS) bucketName() string {
+	if g.Bucket != "" {
+		return g.Bucket
+	}
+	return SeerCrawlerStorageBucket
+}
+
 func (g *GCS) Save(batchDir, filename string, bf bytes.Buffer) error {
 	key := filepath.Join(g.BasePath, batchDir, filename)
 
 	ctx := context.Background()
 
-	bucket := g.Client.Bucket(SeerCrawlerStorageBucket)
+	bucket := g.Client.Bucket(g.bucketName())
 
 	obj := bucket.Object(key)
 
@@ -58,7 +68,7 @@ func (g *GCS) Read(key string) (bytes.Buffer, error) {
 
 	ctx := context.Background()
 
-	bucket := g.Client.Bucket(SeerCrawlerStorageBucket)
+	bucket := g.Client.Bucket(g.bucketName())
 
 	obj := bucket.Object(key)
 
@@ -120,7 +130,7 @@ func (g *GCS) List(ctx context.Context, delim, blockBatch string, timeout int, r
 	}
 	log.Printf("Loading bucket items with prefix: %s and delim: %s", prefix, delim)
 
-	it := g.Client.Bucket(SeerCrawlerStorageBucket).Objects(ctx, &storage.Query{
+	it := g.Client.Bucket(g.bucketName()).Objects(ctx, &storage.Query{
 		Prefix:    prefix,
 		Delimiter: delim,
 	})
@@ -134,7 +144,7 @@ func (g *GCS) List(ctx context.Context, delim, blockBatch string, timeout int, r
 			break
 		}
 		if err != nil {
-			return []string{}, fmt.Errorf("Bucket(%q).Objects: %w", SeerCrawlerStorageBucket, err)
+			return []string{}, fmt.Errorf("Bucket(%q).Objects: %w", g.bucketName(), err)
 		}
 
 		returnVal := returnFunc(attrs)
@@ -155,7 +165,7 @@ func (g *GCS) Delete(key string) error {
 
 	ctx := context.Background()
 
-	bucket := g.Client.Bucket(SeerCrawlerStorageBucket)
+	bucket := g.Client.Bucket(g.bucketName())
 
 	obj := bucket.Object(key)
 
@@ -170,7 +180,7 @@ func (g *GCS) Delete(key string) error {
 func (g *GCS) ReadBatch(readItems []ReadItem) (map[string][]string, error) {
 	ctx := context.Background()
 
-	bucket := g.Client.Bucket(SeerCrawlerStorageBucket)
+	bucket := g.Client.Bucket(g.bucketName())
 
 	result := make(map[string][]string)
 
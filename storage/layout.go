@@ -0,0 +1,190 @@
+package storage
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Placeholders of batch layout template
+const (
+	LayoutChain   = "{chain}"
+	LayoutVersion = "{version}"
+	LayoutStart   = "{start}"
+	LayoutEnd     = "{end}"
+	LayoutExt     = "{ext}"
+)
+
+// BatchLayout renders keys of proto batches relative to data directory of storage, version is
+// part of rendered key if template has {version}, so batches written by different layouts
+// could coexist in one storage.
+type BatchLayout struct {
+	Version  string
+	Template string
+
+	pattern *regexp.Regexp
+}
+
+var (
+	// BatchLayoutV1 is layout of batches written before layouts were configurable
+	BatchLayoutV1 = mustBatchLayout("v1", "{chain}/{start}-{end}/"+batchFilename+"{ext}")
+	BatchLayoutV2 = mustBatchLayout("v2", "{chain}/{version}/{start}-{end}.pb{ext}")
+
+	// KnownBatchLayouts are tried by readers of batches which are not referenced by index
+	KnownBatchLayouts = []*BatchLayout{BatchLayoutV1, BatchLayoutV2}
+
+	// Layout new batches are written with
+	SeerCrawlerStorageLayout = BatchLayoutV1
+)
+
+func mustBatchLayout(version, template string) *BatchLayout {
+	layout, err := NewBatchLayout(version, template)
+	if err != nil {
+		panic(err)
+	}
+	return layout
+}
+
+// NewBatchLayout validates template, it should have {start}, {end} and {ext} placeholders and
+// {version} placeholder requires version.
+func NewBatchLayout(version, template string) (*BatchLayout, error) {
+	for _, placeholder := range []string{LayoutStart, LayoutEnd, LayoutExt} {
+		if strings.Count(template, placeholder) != 1 {
+			return nil, fmt.Errorf("batch layout %q should contain %s exactly once", template, placeholder)
+		}
+	}
+	if !strings.HasSuffix(template, LayoutExt) {
+		return nil, fmt.Errorf("batch layout %q should end with %s, compression is recognized by extension of key", template, LayoutExt)
+	}
+	if strings.Contains(template, LayoutVersion) && version == "" {
+		return nil, fmt.Errorf("batch layout %q contains %s, version is required", template, LayoutVersion)
+	}
+	if strings.HasPrefix(template, "/") {
+		return nil, fmt.Errorf("batch layout %q should be relative", template)
+	}
+
+	// Start and end are captured, chain is any path element, version and the rest are literal
+	pattern := regexp.QuoteMeta(template)
+	pattern = strings.ReplaceAll(pattern, regexp.QuoteMeta(LayoutChain), `(?P<chain>[^/]+)`)
+	pattern = strings.ReplaceAll(pattern, regexp.QuoteMeta(LayoutVersion), regexp.QuoteMeta(version))
+	pattern = strings.Replace(pattern, regexp.QuoteMeta(LayoutStart), `(?P<start>\d+)`, 1)
+	pattern = strings.Replace(pattern, regexp.QuoteMeta(LayoutEnd), `(?P<end>\d+)`, 1)
+	pattern = strings.Replace(pattern, regexp.QuoteMeta(LayoutExt), `(?P<ext>\.zst|\.gz)?`, 1)
+
+	compiled, err := regexp.Compile(`(?:^|/)` + pattern + `$`)
+	if err != nil {
+		return nil, fmt.Errorf("invalid batch layout %q: %v", template, err)
+	}
+
+	return &BatchLayout{Version: version, Template: template, pattern: compiled}, nil
+}
+
+// ParseBatchLayout returns known layout by its version or layout with custom template.
+func ParseBatchLayout(layout, version string) (*BatchLayout, error) {
+	if layout == "" {
+		return BatchLayoutV1, nil
+	}
+	for _, known := range KnownBatchLayouts {
+		if layout == known.Version {
+			return known, nil
+		}
+	}
+	if !strings.Contains(layout, "{") {
+		return nil, fmt.Errorf("unknown batch layout version %q", layout)
+	}
+
+	return NewBatchLayout(version, layout)
+}
+
+// Key returns key of batch with blocks from start to end compressed with codec.
+func (l *BatchLayout) Key(chain string, start, end uint64, codec string) string {
+	return strings.NewReplacer(
+		LayoutChain, chain,
+		LayoutVersion, l.Version,
+		LayoutStart, strconv.FormatUint(start, 10),
+		LayoutEnd, strconv.FormatUint(end, 10),
+		LayoutExt, compressionExtensions[codec],
+	).Replace(l.Template)
+}
+
+// BatchKey is key of batch parsed by its layout.
+type BatchKey struct {
+	Layout *BatchLayout
+	// Root is part of key before rendered layout, it is data directory of storage
+	Root        string
+	Chain       string
+	Start       uint64
+	End         uint64
+	Compression string
+}
+
+// Parse returns parts of key if it was rendered by layout, key could have any root before it.
+func (l *BatchLayout) Parse(key string) (*BatchKey, bool) {
+	match := l.pattern.FindStringSubmatchIndex(key)
+	if match == nil {
+		return nil, false
+	}
+
+	parsed := &BatchKey{Layout: l, Root: strings.TrimSuffix(key[:match[0]], "/")}
+	var startErr, endErr error
+	for i, name := range l.pattern.SubexpNames() {
+		if name == "" || match[2*i] < 0 {
+			continue
+		}
+		value := key[match[2*i]:match[2*i+1]]
+		switch name {
+		case "chain":
+			parsed.Chain = value
+		case "start":
+			parsed.Start, startErr = strconv.ParseUint(value, 10, 64)
+		case "end":
+			parsed.End, endErr = strconv.ParseUint(value, 10, 64)
+		}
+	}
+	if startErr != nil || endErr != nil {
+		return nil, false
+	}
+	parsed.Compression = CompressionOf(key)
+
+	return parsed, true
+}
+
+// ParseBatchKey parses key with configured layout and then with known ones.
+func ParseBatchKey(key string) (*BatchKey, bool) {
+	if parsed, ok := SeerCrawlerStorageLayout.Parse(key); ok {
+		return parsed, true
+	}
+	for _, layout := range KnownBatchLayouts {
+		if parsed, ok := layout.Parse(key); ok {
+			return parsed, true
+		}
+	}
+	return nil, false
+}
+
+// BatchKeys returns keys which batch with blocks from start to end could be stored under in root,
+// configured layout and codec go first.
+func BatchKeys(root, chain string, start, end uint64) []string {
+	layouts := []*BatchLayout{SeerCrawlerStorageLayout}
+	for _, layout := range KnownBatchLayouts {
+		if layout != SeerCrawlerStorageLayout {
+			layouts = append(layouts, layout)
+		}
+	}
+	codecs := []string{SeerCrawlerStorageCompression}
+	for _, codec := range []string{CompressionNone, CompressionZstd, CompressionGzip} {
+		if codec != SeerCrawlerStorageCompression {
+			codecs = append(codecs, codec)
+		}
+	}
+
+	var keys []string
+	for _, layout := range layouts {
+		for _, codec := range codecs {
+			keys = append(keys, filepath.Join(root, layout.Key(chain, start, end, codec)))
+		}
+	}
+	return keys
+}
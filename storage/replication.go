@@ -0,0 +1,238 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Replication statuses of batch at replica storage
+const (
+	ReplicationPending    = "pending"
+	ReplicationReplicated = "replicated"
+	ReplicationFailed     = "failed"
+)
+
+var (
+	ReplicationWorkers    = 4
+	ReplicationQueueSize  = 1000
+	ReplicationRetries    = 3
+	ReplicationRetryDelay = 2 * time.Second
+)
+
+// StorageReplicaConfig describes replica storage placed in region.
+type StorageReplicaConfig struct {
+	Region   string
+	Type     string
+	Location string
+}
+
+// ParseStorageReplicas parses replicas configuration in format
+// "<region>=<type>:<bucket or root directory>,...", e.g.
+// "europe-west1=gcp-storage:seer-eu,us-east-1=aws-bucket:seer-us,us-east1=filesystem:/mnt/seer".
+func ParseStorageReplicas(raw string) ([]StorageReplicaConfig, error) {
+	var replicas []StorageReplicaConfig
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		region, spec, found := strings.Cut(entry, "=")
+		storageType, location, typeFound := strings.Cut(spec, ":")
+		if !found || !typeFound || region == "" || location == "" {
+			return nil, fmt.Errorf("invalid storage replica %q, expected <region>=<type>:<bucket or root directory>", entry)
+		}
+		if storageType != "gcp-storage" && storageType != "aws-bucket" && storageType != "filesystem" {
+			return nil, fmt.Errorf("unsupported storage type %s of replica in region %s", storageType, region)
+		}
+
+		replicas = append(replicas, StorageReplicaConfig{Region: region, Type: storageType, Location: location})
+	}
+
+	return replicas, nil
+}
+
+// ReplicationTracker records replication status of batches, implemented by index database.
+type ReplicationTracker interface {
+	SetReplicationStatus(key, region, status, errMsg string) error
+}
+
+type regionStorage struct {
+	region string
+	Storer
+}
+
+type replicationItem struct {
+	key      string
+	batchDir string
+	filename string
+	data     []byte
+	replica  regionStorage
+}
+
+// ReplicatedStorage writes batches to primary storage and copies them to replicas in
+// background, reads are served from storage of current region and fall back to primary and
+// other replicas if batch is not there yet.
+type ReplicatedStorage struct {
+	primary  regionStorage
+	replicas []regionStorage
+	region   string
+	tracker  ReplicationTracker
+
+	queue chan replicationItem
+	wg    sync.WaitGroup
+	once  sync.Once
+}
+
+func NewReplicatedStorage(primary Storer, primaryRegion string, replicas map[string]Storer, region string, tracker ReplicationTracker) *ReplicatedStorage {
+	s := &ReplicatedStorage{
+		primary: regionStorage{region: primaryRegion, Storer: primary},
+		region:  region,
+		tracker: tracker,
+		queue:   make(chan replicationItem, ReplicationQueueSize),
+	}
+	for replicaRegion, replica := range replicas {
+		s.replicas = append(s.replicas, regionStorage{region: replicaRegion, Storer: replica})
+	}
+
+	for i := 0; i < ReplicationWorkers; i++ {
+		s.wg.Add(1)
+		go s.replicationWorker()
+	}
+
+	return s
+}
+
+func (s *ReplicatedStorage) setStatus(key, region, status string, err error) {
+	if s.tracker == nil {
+		return
+	}
+
+	var errMsg string
+	if err != nil {
+		errMsg = err.Error()
+	}
+	if trackErr := s.tracker.SetReplicationStatus(key, region, status, errMsg); trackErr != nil {
+		log.Printf("Failed to record replication status %s of %s in region %s: %v", status, key, region, trackErr)
+	}
+}
+
+func (s *ReplicatedStorage) replicationWorker() {
+	defer s.wg.Done()
+
+	for item := range s.queue {
+		var err error
+		for attempt := 0; attempt < ReplicationRetries; attempt++ {
+			if attempt > 0 {
+				time.Sleep(ReplicationRetryDelay * time.Duration(attempt))
+			}
+			err = item.replica.Save(item.batchDir, item.filename, *bytes.NewBuffer(item.data))
+			if err == nil {
+				break
+			}
+		}
+
+		if err != nil {
+			log.Printf("Failed to replicate %s to region %s: %v", item.key, item.replica.region, err)
+			s.setStatus(item.key, item.replica.region, ReplicationFailed, err)
+			continue
+		}
+		s.setStatus(item.key, item.replica.region, ReplicationReplicated, nil)
+	}
+}
+
+// readOrder returns storage of current region first, then primary and other replicas.
+func (s *ReplicatedStorage) readOrder() []regionStorage {
+	order := make([]regionStorage, 0, len(s.replicas)+1)
+	for _, replica := range s.replicas {
+		if replica.region == s.region {
+			order = append(order, replica)
+		}
+	}
+	order = append(order, s.primary)
+	for _, replica := range s.replicas {
+		if replica.region != s.region {
+			order = append(order, replica)
+		}
+	}
+	return order
+}
+
+// Save writes batch to primary synchronously and queues it for replicas. Data is copied
+// since buffer is consumed by primary write.
+func (s *ReplicatedStorage) Save(batchDir, filename string, bf bytes.Buffer) error {
+	data := append([]byte(nil), bf.Bytes()...)
+
+	if err := s.primary.Save(batchDir, filename, bf); err != nil {
+		return err
+	}
+
+	key := filepath.Join(batchDir, filename)
+	for _, replica := range s.replicas {
+		s.setStatus(key, replica.region, ReplicationPending, nil)
+
+		item := replicationItem{key: key, batchDir: batchDir, filename: filename, data: data, replica: replica}
+		select {
+		case s.queue <- item:
+		default:
+			err := fmt.Errorf("replication queue is full")
+			log.Printf("Failed to queue replication of %s to region %s: %v", key, replica.region, err)
+			s.setStatus(key, replica.region, ReplicationFailed, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *ReplicatedStorage) Read(key string) (bytes.Buffer, error) {
+	var err error
+	for _, candidate := range s.readOrder() {
+		var buf bytes.Buffer
+		buf, err = candidate.Read(key)
+		if err == nil {
+			return buf, nil
+		}
+	}
+	return bytes.Buffer{}, err
+}
+
+func (s *ReplicatedStorage) ReadBatch(readItems []ReadItem) (map[string][]string, error) {
+	var err error
+	for _, candidate := range s.readOrder() {
+		var result map[string][]string
+		result, err = candidate.ReadBatch(readItems)
+		if err == nil {
+			return result, nil
+		}
+	}
+	return nil, err
+}
+
+// Delete removes object from primary and replicas, error of primary is returned.
+func (s *ReplicatedStorage) Delete(key string) error {
+	for _, replica := range s.replicas {
+		if err := replica.Delete(key); err != nil {
+			log.Printf("Failed to delete %s from replica in region %s: %v", key, replica.region, err)
+		}
+	}
+	return s.primary.Delete(key)
+}
+
+// List is served by primary, replicas could lag behind it.
+func (s *ReplicatedStorage) List(ctx context.Context, delim, blockBatch string, timeout int, returnFunc ListReturnFunc) ([]string, error) {
+	return s.primary.List(ctx, delim, blockBatch, timeout, returnFunc)
+}
+
+// Close waits until queued replications are finished.
+func (s *ReplicatedStorage) Close() {
+	s.once.Do(func() {
+		close(s.queue)
+		s.wg.Wait()
+	})
+}
@@ -11,6 +11,24 @@ var (
 	SeerCrawlerStorageBucket          string
 	GCPStorageServiceAccountCredsPath string
 	SeerCrawlerStoragePath            string = "data"
+
+	// Codec new proto batches are compressed with, batches are read with codec of their paths
+	SeerCrawlerStorageCompression string = CompressionNone
+
+	// Root directory of filesystem storage, keys are resolved against working directory if empty
+	SeerCrawlerStorageRoot string
+
+	// Region and endpoint of S3 storage, endpoint is set for S3 compatible storages
+	AWSStorageRegion   string
+	AWSStorageEndpoint string
+
+	// Multi-region replication of batches, see ParseStorageReplicas for format
+	SeerCrawlerStorageReplicas []StorageReplicaConfig
+	SeerCrawlerStorageRegion   string
+	SeerCrawlerRegion          string
+
+	// ReplicationStatusTracker is set by commands with access to index database
+	ReplicationStatusTracker ReplicationTracker
 )
 
 func SetStorageBucketFromEnv() error {
@@ -26,6 +44,7 @@ func CheckVariablesForStorage() error {
 	switch SeerCrawlerStorageTypeEnvVar {
 	case "filesystem":
 		SeerCrawlerStorageType = "filesystem"
+		SeerCrawlerStorageRoot = os.Getenv("SEER_CRAWLER_STORAGE_ROOT")
 	case "gcp-storage":
 		SeerCrawlerStorageType = "gcp-storage"
 
@@ -44,11 +63,41 @@ func CheckVariablesForStorage() error {
 		if bucketError != nil {
 			return bucketError
 		}
+
+		AWSStorageRegion = os.Getenv("SEER_CRAWLER_STORAGE_AWS_REGION")
+		AWSStorageEndpoint = os.Getenv("SEER_CRAWLER_STORAGE_AWS_ENDPOINT")
+		if AWSStorageEndpoint != "" {
+			log.Printf("SEER_CRAWLER_STORAGE_AWS_ENDPOINT environment variable is set, using S3 compatible storage at %s", AWSStorageEndpoint)
+		}
 	default:
 		SeerCrawlerStorageType = "filesystem"
+		SeerCrawlerStorageRoot = os.Getenv("SEER_CRAWLER_STORAGE_ROOT")
 		log.Printf("SEER_CRAWLER_STORAGE_TYPE environment variable is not set or unknown, using default: %s", SeerCrawlerStorageType)
 	}
 
+	var replicasErr error
+	SeerCrawlerStorageReplicas, replicasErr = ParseStorageReplicas(os.Getenv("SEER_CRAWLER_STORAGE_REPLICAS"))
+	if replicasErr != nil {
+		return replicasErr
+	}
+	SeerCrawlerStorageRegion = os.Getenv("SEER_CRAWLER_STORAGE_REGION")
+	SeerCrawlerRegion = os.Getenv("SEER_CRAWLER_REGION")
+	if len(SeerCrawlerStorageReplicas) > 0 && SeerCrawlerStorageRegion == "" {
+		return fmt.Errorf("SEER_CRAWLER_STORAGE_REGION environment variable is required with SEER_CRAWLER_STORAGE_REPLICAS")
+	}
+
+	var compressionErr error
+	SeerCrawlerStorageCompression, compressionErr = ParseCompression(os.Getenv("SEER_CRAWLER_STORAGE_COMPRESSION"))
+	if compressionErr != nil {
+		return compressionErr
+	}
+
+	var layoutErr error
+	SeerCrawlerStorageLayout, layoutErr = ParseBatchLayout(os.Getenv("SEER_CRAWLER_STORAGE_LAYOUT"), os.Getenv("SEER_CRAWLER_STORAGE_LAYOUT_VERSION"))
+	if layoutErr != nil {
+		return layoutErr
+	}
+
 	SeerCrawlerStoragePathEnvVar := os.Getenv("SEER_CRAWLER_STORAGE_PATH")
 	if SeerCrawlerStoragePathEnvVar != "" {
 		SeerCrawlerStoragePath = SeerCrawlerStoragePathEnvVar
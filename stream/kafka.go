@@ -0,0 +1,80 @@
+package stream
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+var DefaultTimeout = 10 * time.Second
+
+// KafkaPublisher produces messages to Kafka brokers. Records are acknowledged by all in-sync
+// replicas and produced by idempotent producer, so retries of client do not duplicate or
+// reorder records of partition.
+type KafkaPublisher struct {
+	Brokers []string
+	Timeout time.Duration
+
+	client *kgo.Client
+}
+
+// NewKafkaPublisher creates producer of brokers, with useTLS connections to brokers are
+// encrypted and verified with system roots.
+func NewKafkaPublisher(brokers []string, useTLS bool, timeout time.Duration) (*KafkaPublisher, error) {
+	if len(brokers) == 0 {
+		return nil, fmt.Errorf("at least one Kafka broker is required")
+	}
+
+	options := []kgo.Opt{
+		kgo.SeedBrokers(brokers...),
+		kgo.ClientID("seer"),
+		kgo.RequiredAcks(kgo.AllISRAcks()),
+		kgo.ProduceRequestTimeout(timeout),
+		kgo.RecordDeliveryTimeout(timeout),
+	}
+	if useTLS {
+		options = append(options, kgo.DialTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}))
+	}
+
+	client, err := kgo.NewClient(options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka client: %w", err)
+	}
+
+	return &KafkaPublisher{Brokers: brokers, Timeout: timeout, client: client}, nil
+}
+
+// Publish produces messages keyed by their keys and returns after all of them were
+// acknowledged or the first of them failed.
+func (p *KafkaPublisher) Publish(messages []Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	records := make([]*kgo.Record, 0, len(messages))
+	for _, message := range messages {
+		record := &kgo.Record{Topic: message.Topic, Value: message.Value}
+		if message.Key != "" {
+			record.Key = []byte(message.Key)
+		}
+		records = append(records, record)
+	}
+
+	// Delivery timeout of records bounds request, context only guards against stuck client
+	ctx, cancel := context.WithTimeout(context.Background(), 2*p.Timeout)
+	defer cancel()
+
+	if err := p.client.ProduceSync(ctx, records...).FirstErr(); err != nil {
+		return fmt.Errorf("failed to produce records to Kafka: %w", err)
+	}
+
+	return nil
+}
+
+func (p *KafkaPublisher) Close() error {
+	p.client.Close()
+	return nil
+}
@@ -0,0 +1,102 @@
+package stream
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// NATSPublisher publishes messages to NATS JetStream, so stream capturing subjects
+// <topic>.<key> should exist. Connection is kept alive and reopened by NATS client, TLS is
+// used with tls:// URLs or when server requires it.
+type NATSPublisher struct {
+	URL     string
+	Timeout time.Duration
+
+	conn      *nats.Conn
+	jetStream jetstream.JetStream
+}
+
+func NewNATSPublisher(natsUrl string, timeout time.Duration) (*NATSPublisher, error) {
+	conn, err := nats.Connect(natsUrl,
+		nats.Name("seer"),
+		nats.Timeout(timeout),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, disconnectErr error) {
+			if disconnectErr != nil {
+				log.Printf("Disconnected from NATS, err: %v", disconnectErr)
+			}
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			log.Printf("Reconnected to NATS %s", conn.ConnectedUrlRedacted())
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+
+	jetStream, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &NATSPublisher{URL: natsUrl, Timeout: timeout, conn: conn, jetStream: jetStream}, nil
+}
+
+// natsMsgID identifies message by its subject and value, so messages published again after
+// failed cycle are dropped by JetStream within duplicates window of stream.
+func natsMsgID(subject string, value []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(subject))
+	hash.Write([]byte{0})
+	hash.Write(value)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Publish sends all messages before it waits for acknowledgements, so batch costs one round
+// trip. Error is returned if any message was not acknowledged by JetStream within Timeout.
+func (p *NATSPublisher) Publish(messages []Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.Timeout)
+	defer cancel()
+
+	futures := make([]jetstream.PubAckFuture, 0, len(messages))
+	for _, message := range messages {
+		subject := message.Topic
+		if message.Key != "" {
+			subject = subject + "." + message.Key
+		}
+
+		future, err := p.jetStream.PublishAsync(subject, message.Value, jetstream.WithMsgID(natsMsgID(subject, message.Value)))
+		if err != nil {
+			return fmt.Errorf("failed to publish to %s: %w", subject, err)
+		}
+		futures = append(futures, future)
+	}
+
+	for _, future := range futures {
+		select {
+		case <-future.Ok():
+		case err := <-future.Err():
+			return fmt.Errorf("JetStream rejected message to %s, check that stream captures subject: %w", future.Msg().Subject, err)
+		case <-ctx.Done():
+			return fmt.Errorf("acknowledgement of JetStream for %s is missing after %s", future.Msg().Subject, p.Timeout)
+		}
+	}
+
+	return nil
+}
+
+func (p *NATSPublisher) Close() error {
+	return p.conn.Drain()
+}
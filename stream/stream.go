@@ -0,0 +1,143 @@
+// Package stream publishes decoded labels and raw proto blocks to message brokers, so consumers
+// could follow labels of chain without polling customer databases.
+package stream
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+var (
+	DefaultTopicPrefix = "seer"
+	// Labels of customer written for blocks range are split into messages of at most
+	// DefaultLabelsPerMessage events and transactions each
+	DefaultLabelsPerMessage = 1000
+)
+
+// Message is one record published to topic. Key is used as partition key by Kafka and as the
+// last token of subject by NATS.
+type Message struct {
+	Topic string
+	Key   string
+	Value []byte
+}
+
+// Publisher delivers messages to broker, Publish returns after broker acknowledged all of them.
+type Publisher interface {
+	Publish(messages []Message) error
+	Close() error
+}
+
+// LabelsMessage is value of message published to <prefix>.<chain>.labels topic.
+type LabelsMessage struct {
+	Chain        string                     `json:"chain"`
+	CustomerID   string                     `json:"customer_id"`
+	FromBlock    uint64                     `json:"from_block"`
+	ToBlock      uint64                     `json:"to_block"`
+	Part         int                        `json:"part"`
+	Parts        int                        `json:"parts"`
+	Events       []indexer.EventLabel       `json:"events"`
+	Transactions []indexer.TransactionLabel `json:"transactions"`
+}
+
+// Sink publishes labels written by synchronizer of chain, raw proto blocks are published to
+// <prefix>.<chain>.blocks topic only if RawBlocks is set.
+type Sink struct {
+	Publisher        Publisher
+	TopicPrefix      string
+	RawBlocks        bool
+	LabelsPerMessage int
+
+	chain string
+}
+
+func NewSink(chain string, publisher Publisher, topicPrefix string, rawBlocks bool) *Sink {
+	if topicPrefix == "" {
+		topicPrefix = DefaultTopicPrefix
+	}
+
+	return &Sink{
+		Publisher:        publisher,
+		TopicPrefix:      topicPrefix,
+		RawBlocks:        rawBlocks,
+		LabelsPerMessage: DefaultLabelsPerMessage,
+		chain:            chain,
+	}
+}
+
+func (s *Sink) LabelsTopic() string {
+	return fmt.Sprintf("%s.%s.labels", s.TopicPrefix, s.chain)
+}
+
+func (s *Sink) BlocksTopic() string {
+	return fmt.Sprintf("%s.%s.blocks", s.TopicPrefix, s.chain)
+}
+
+// PublishLabels publishes labels of customer keyed by customer ID, so labels of one customer
+// keep their order within partition.
+func (s *Sink) PublishLabels(customerID string, events []indexer.EventLabel, transactions []indexer.TransactionLabel, fromBlock, toBlock uint64) error {
+	if len(events)+len(transactions) == 0 {
+		return nil
+	}
+
+	perMessage := s.LabelsPerMessage
+	if perMessage <= 0 {
+		perMessage = DefaultLabelsPerMessage
+	}
+
+	var parts []LabelsMessage
+	for len(events)+len(transactions) > 0 {
+		part := LabelsMessage{
+			Chain:      s.chain,
+			CustomerID: customerID,
+			FromBlock:  fromBlock,
+			ToBlock:    toBlock,
+			Part:       len(parts) + 1,
+		}
+
+		n := min(perMessage, len(events))
+		part.Events, events = events[:n], events[n:]
+		n = min(perMessage-n, len(transactions))
+		part.Transactions, transactions = transactions[:n], transactions[n:]
+
+		parts = append(parts, part)
+	}
+
+	messages := make([]Message, 0, len(parts))
+	for _, part := range parts {
+		part.Parts = len(parts)
+		value, err := json.Marshal(part)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, Message{Topic: s.LabelsTopic(), Key: customerID, Value: value})
+	}
+
+	return s.Publisher.Publish(messages)
+}
+
+// PublishRawBlocks publishes batches of proto blocks as they are read from storage, keyed by
+// blocks range of batch and its position in it.
+func (s *Sink) PublishRawBlocks(rawData []bytes.Buffer, fromBlock, toBlock uint64) error {
+	if !s.RawBlocks || len(rawData) == 0 {
+		return nil
+	}
+
+	messages := make([]Message, 0, len(rawData))
+	for i, data := range rawData {
+		messages = append(messages, Message{
+			Topic: s.BlocksTopic(),
+			Key:   fmt.Sprintf("%d-%d-%d", fromBlock, toBlock, i),
+			Value: data.Bytes(),
+		})
+	}
+
+	return s.Publisher.Publish(messages)
+}
+
+func (s *Sink) Close() error {
+	return s.Publisher.Close()
+}
@@ -0,0 +1,120 @@
+package synchronizer
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+// AbiJobsReloader keeps ABIs of jobs of chain between batches of synchronizer and re-reads
+// abi_jobs once per interval. Entries of selectors with unchanged ABI are kept on reload, so
+// ABIs parsed for decoding are not parsed again, new and changed selectors are added and jobs
+// which were removed or deactivated are dropped. It is used from synchronization loop only.
+type AbiJobsReloader struct {
+	store    indexer.JobStore
+	chain    string
+	interval time.Duration
+
+	abis       map[string]map[string]map[string]*indexer.AbiEntry
+	reloadedAt time.Time
+}
+
+func NewAbiJobsReloader(store indexer.JobStore, chain string, interval time.Duration) (*AbiJobsReloader, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("ABI jobs reload interval should be positive")
+	}
+
+	return &AbiJobsReloader{
+		store:    store,
+		chain:    chain,
+		interval: interval,
+		abis:     make(map[string]map[string]map[string]*indexer.AbiEntry),
+	}, nil
+}
+
+// Reload reads jobs of chain and merges them into kept ABIs. Returns number of added or changed
+// selectors and number of removed ones.
+func (r *AbiJobsReloader) Reload() (added, removed int, err error) {
+	abiJobs, err := r.store.ReadABIJobs(r.chain)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read ABI jobs: %w", err)
+	}
+
+	abis := make(map[string]map[string]map[string]*indexer.AbiEntry)
+	for _, job := range abiJobs {
+		if job.Status == indexer.AbiJobStatusInactive {
+			continue
+		}
+
+		address := indexer.AbiJobAddress(job.Address)
+		if abis[job.CustomerID] == nil {
+			abis[job.CustomerID] = make(map[string]map[string]*indexer.AbiEntry)
+		}
+		if abis[job.CustomerID][address] == nil {
+			abis[job.CustomerID][address] = make(map[string]*indexer.AbiEntry)
+		}
+
+		existing := r.abis[job.CustomerID][address][job.AbiSelector]
+		if existing != nil && existing.AbiJSON == job.Abi && existing.AbiName == job.AbiName && existing.AbiType == job.AbiType && reflect.DeepEqual(existing.TopicFilters, job.TopicFilters) {
+			abis[job.CustomerID][address][job.AbiSelector] = existing
+			continue
+		}
+
+		abis[job.CustomerID][address][job.AbiSelector] = &indexer.AbiEntry{
+			AbiJSON:      job.Abi,
+			AbiName:      job.AbiName,
+			AbiType:      job.AbiType,
+			TopicFilters: job.TopicFilters,
+		}
+		added++
+	}
+
+	for customerID, addresses := range r.abis {
+		for address, selectors := range addresses {
+			for selector := range selectors {
+				if abis[customerID][address][selector] == nil {
+					removed++
+				}
+			}
+		}
+	}
+
+	r.abis = abis
+	r.reloadedAt = time.Now()
+
+	return added, removed, nil
+}
+
+// Updates returns ABIs of customers, jobs are reloaded first if interval passed since the
+// last reload. Kept ABIs are returned if reload fails.
+func (r *AbiJobsReloader) Updates() []indexer.CustomerUpdates {
+	if time.Since(r.reloadedAt) >= r.interval {
+		isFirst := r.reloadedAt.IsZero()
+		added, removed, err := r.Reload()
+		if err != nil {
+			log.Printf("Failed to reload ABI jobs of %s, using ABIs loaded at %s: %v", r.chain, r.reloadedAt.Format(time.RFC3339), err)
+		} else if !isFirst && (added > 0 || removed > 0) {
+			log.Printf("Reloaded ABI jobs of %s: %d selectors added or changed, %d removed", r.chain, added, removed)
+		}
+	}
+
+	customerIds := make([]string, 0, len(r.abis))
+	for customerID := range r.abis {
+		customerIds = append(customerIds, customerID)
+	}
+	sort.Strings(customerIds)
+
+	updates := make([]indexer.CustomerUpdates, 0, len(customerIds))
+	for _, customerID := range customerIds {
+		updates = append(updates, indexer.CustomerUpdates{
+			CustomerID: customerID,
+			Abis:       r.abis[customerID],
+		})
+	}
+
+	return updates
+}
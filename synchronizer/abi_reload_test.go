@@ -0,0 +1,69 @@
+package synchronizer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+func TestAbiJobsReloaderKeepsUnchangedEntries(t *testing.T) {
+	address := []byte{0xa0, 0xb8, 0x69, 0x91, 0xc6, 0x21, 0x8b, 0x36, 0xc1, 0xd1, 0x9d, 0x4a, 0x2e, 0x9e, 0xb0, 0xce, 0x36, 0x06, 0xeb, 0x48}
+	transferJob := indexer.AbiJob{
+		ID:          "job-1",
+		Address:     address,
+		CustomerID:  "customer-1",
+		AbiSelector: "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+		Chain:       testChain,
+		AbiName:     "Transfer",
+		Status:      "active",
+		Abi:         `[{"type":"event","name":"Transfer","inputs":[]}]`,
+		AbiType:     "event",
+	}
+	approvalJob := transferJob
+	approvalJob.ID = "job-2"
+	approvalJob.CustomerID = "customer-2"
+	approvalJob.AbiSelector = "0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"
+	approvalJob.AbiName = "Approval"
+	approvalJob.Abi = `[{"type":"event","name":"Approval","inputs":[]}]`
+
+	store := indexer.NewMemoryStore()
+	store.AddAbiJobs(transferJob)
+
+	reloader, err := NewAbiJobsReloader(store, testChain, time.Hour)
+	if err != nil {
+		t.Fatalf("NewAbiJobsReloader: %v", err)
+	}
+
+	updates := reloader.Updates()
+	if len(updates) != 1 || updates[0].CustomerID != "customer-1" {
+		t.Fatalf("unexpected updates %+v", updates)
+	}
+	addressStr := indexer.AbiJobAddress(address)
+	transferEntry := updates[0].Abis[addressStr][transferJob.AbiSelector]
+	if transferEntry == nil || transferEntry.AbiName != "Transfer" {
+		t.Fatalf("entry of Transfer job is missing: %+v", updates[0].Abis)
+	}
+
+	// Jobs added before interval passed are not visible until reload
+	store.AddAbiJobs(approvalJob)
+	if updates := reloader.Updates(); len(updates) != 1 {
+		t.Fatalf("jobs are reloaded before interval passed: %+v", updates)
+	}
+
+	added, removed, err := reloader.Reload()
+	if err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if added != 1 || removed != 0 {
+		t.Fatalf("reload added %d and removed %d selectors, want 1 and 0", added, removed)
+	}
+
+	updates = reloader.Updates()
+	if len(updates) != 2 || updates[1].CustomerID != "customer-2" {
+		t.Fatalf("unexpected updates %+v", updates)
+	}
+	if updates[0].Abis[addressStr][transferJob.AbiSelector] != transferEntry {
+		t.Fatal("entry of unchanged job is replaced on reload")
+	}
+}
@@ -0,0 +1,364 @@
+package synchronizer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	seer_common "github.com/G7DAO/seer/blockchain/common"
+	"github.com/G7DAO/seer/indexer"
+	"github.com/G7DAO/seer/logging"
+	"github.com/G7DAO/seer/metrics"
+)
+
+// Backfill crawls history of address of customer from RPC in chunks planned ahead. Progress of
+// each chunk is persisted in index database, so restarted backfill continues with chunks which
+// are not done, and chunks are claimed by workers of one or several processes without overlap.
+type Backfill struct {
+	synchronizer *Synchronizer
+	store        *indexer.PostgreSQLpgx
+
+	// Chunk is marked as failed after MaxAttempts failed attempts
+	MaxAttempts int
+	// Chunks left running longer than StaleAfter are taken by other workers
+	StaleAfter time.Duration
+}
+
+// BackfillPlanOptions describes range of backfill, zero blocks are resolved from jobs and chain.
+type BackfillPlanOptions struct {
+	Address       string
+	CustomerID    string
+	FromBlock     uint64
+	ToBlock       uint64
+	ChunkSize     uint64
+	Confirmations uint64
+}
+
+func NewBackfill(synchronizer *Synchronizer, store *indexer.PostgreSQLpgx) (*Backfill, error) {
+	if err := store.EnsureBackfillTables(); err != nil {
+		return nil, fmt.Errorf("failed to create backfill tables: %w", err)
+	}
+
+	return &Backfill{
+		synchronizer: synchronizer,
+		store:        store,
+		MaxAttempts:  3,
+		StaleAfter:   30 * time.Minute,
+	}, nil
+}
+
+// abiJobs returns jobs of address of customer with functions and events ABIs.
+func (b *Backfill) abiJobs(address, customerID string) ([]indexer.AbiJob, error) {
+	d := b.synchronizer
+
+	abiJobs, err := d.Store.SelectAbiJobs(d.blockchain, []string{address}, []string{customerID}, false, false, []string{"function", "event"})
+	if err != nil {
+		return nil, fmt.Errorf("error selecting ABI jobs: %w", err)
+	}
+	if len(abiJobs) == 0 {
+		return nil, fmt.Errorf("no ABI jobs of address %s of customer %s at %s", address, customerID, d.blockchain)
+	}
+
+	return abiJobs, nil
+}
+
+// Plan splits range of backfill into chunks and saves them. If address of customer already
+// has unfinished backfill, it is returned instead of new one and created is false.
+func (b *Backfill) Plan(opts BackfillPlanOptions) (job *indexer.BackfillJob, created bool, err error) {
+	d := b.synchronizer
+	address := strings.ToLower(opts.Address)
+
+	existing, err := b.store.FindUnfinishedBackfillJob(d.blockchain, address, opts.CustomerID)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing != nil {
+		return existing, false, nil
+	}
+
+	if opts.ChunkSize == 0 {
+		return nil, false, fmt.Errorf("chunk size should be positive")
+	}
+
+	abiJobs, err := b.abiJobs(address, opts.CustomerID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	fromBlock := opts.FromBlock
+	if fromBlock == 0 {
+		for _, abiJob := range abiJobs {
+			if abiJob.DeploymentBlockNumber != nil && (fromBlock == 0 || *abiJob.DeploymentBlockNumber < fromBlock) {
+				fromBlock = *abiJob.DeploymentBlockNumber
+			}
+		}
+	}
+	if fromBlock == 0 {
+		deployBlock, deployErr := d.Client.FindDeploymentBlock(context.Background(), common.HexToAddress(address))
+		if deployErr != nil {
+			return nil, false, fmt.Errorf("failed to find deployment block of %s: %w", address, deployErr)
+		}
+		fromBlock = deployBlock
+
+		ids := make([]string, len(abiJobs))
+		for i, abiJob := range abiJobs {
+			ids[i] = abiJob.ID
+		}
+		if updateErr := d.Store.UpdateAbiJobsDeployBlock(deployBlock, ids); updateErr != nil {
+			log.Printf("Failed to save deployment block %d of %s: %v", deployBlock, address, updateErr)
+		}
+	}
+
+	toBlock := opts.ToBlock
+	if toBlock == 0 {
+		latestBlock, latestErr := d.Client.GetLatestBlockNumber()
+		if latestErr != nil {
+			return nil, false, fmt.Errorf("failed to get latest block number: %w", latestErr)
+		}
+		if latestBlock.Uint64() < opts.Confirmations {
+			return nil, false, fmt.Errorf("chain has %d blocks, less than %d confirmations", latestBlock.Uint64(), opts.Confirmations)
+		}
+		toBlock = latestBlock.Uint64() - opts.Confirmations
+	}
+
+	if toBlock < fromBlock {
+		return nil, false, fmt.Errorf("last block %d of backfill is before its first block %d", toBlock, fromBlock)
+	}
+
+	chunks := indexer.PlanBackfillChunks(fromBlock, toBlock, opts.ChunkSize)
+	job, err = b.store.CreateBackfillJob(indexer.BackfillJob{
+		Chain:      d.blockchain,
+		Address:    address,
+		CustomerID: opts.CustomerID,
+		FromBlock:  fromBlock,
+		ToBlock:    toBlock,
+		ChunkSize:  opts.ChunkSize,
+	}, chunks)
+	if err != nil {
+		return nil, false, err
+	}
+
+	logging.Customer(d.blockchain, opts.CustomerID).Info("Planned backfill", logging.JobKey, job.ID, logging.AddressKey, address, logging.FromBlockKey, fromBlock, logging.ToBlockKey, toBlock, "chunks", len(chunks))
+
+	return job, true, nil
+}
+
+// Run crawls pending chunks of job with workers concurrently. Each chunk is written to all
+// instances of customer before it is marked as done and progress of ABI jobs is updated. On
+// cancellation of ctx workers finish their current chunks and Run returns nil, remaining
+// chunks are crawled by next run.
+func (b *Backfill) Run(ctx context.Context, customerDbUriFlag, jobID string, workers int) error {
+	d := b.synchronizer
+
+	job, err := b.store.GetBackfillJob(jobID)
+	if err != nil {
+		return err
+	}
+	if job.Chain != d.blockchain {
+		return fmt.Errorf("backfill job %s is of chain %s, not %s", job.ID, job.Chain, d.blockchain)
+	}
+	if job.Status == indexer.BackfillDone {
+		log.Printf("Backfill job %s is already done", job.ID)
+		return nil
+	}
+
+	logger := logging.Customer(d.blockchain, job.CustomerID).With(logging.JobKey, job.ID)
+
+	reset, err := b.store.ResetStaleBackfillChunks(job.ID, b.StaleAfter)
+	if err != nil {
+		return fmt.Errorf("failed to reset stale chunks: %w", err)
+	}
+	if reset > 0 {
+		logger.Info("Stale chunks returned to pending", "chunks", reset)
+	}
+
+	abiJobs, err := b.abiJobs(job.Address, job.CustomerID)
+	if err != nil {
+		return err
+	}
+	abiJobIds := make([]string, len(abiJobs))
+	for i, abiJob := range abiJobs {
+		abiJobIds[i] = abiJob.ID
+	}
+
+	customerDBConnections, _, err := d.getCustomers(customerDbUriFlag, []string{job.CustomerID})
+	if err != nil {
+		return fmt.Errorf("error getting customers: %w", err)
+	}
+	defer d.CloseIndexerDBConnections(customerDBConnections)
+
+	connections := customerDBConnections[job.CustomerID]
+	if len(connections) == 0 {
+		return fmt.Errorf("no database connection of customer %s", job.CustomerID)
+	}
+
+	if err := b.store.SetBackfillJobStatus(job.ID, indexer.BackfillRunning); err != nil {
+		return err
+	}
+
+	if workers <= 0 {
+		workers = 1
+	}
+
+	var wg sync.WaitGroup
+	errChan := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if workerErr := b.work(ctx, job, abiJobs, abiJobIds, connections, logger); workerErr != nil {
+				errChan <- workerErr
+			}
+		}()
+	}
+	wg.Wait()
+	close(errChan)
+
+	var errMsg string
+	for workerErr := range errChan {
+		errMsg += workerErr.Error() + "\n"
+	}
+
+	progress, err := b.store.GetBackfillProgress(job.ID)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Err() != nil || errMsg != "" || progress.Pending+progress.Running > 0 {
+		// Chunks are left for next run or are still crawled by other processes
+		if progress.Running == 0 {
+			if statusErr := b.store.SetBackfillJobStatus(job.ID, indexer.BackfillPending); statusErr != nil {
+				logger.Error("Failed to update status of backfill job", logging.ErrorKey, statusErr)
+			}
+		}
+		logger.Info("Backfill is stopped", "done", progress.Done, "chunks", progress.Chunks)
+		if errMsg != "" {
+			return fmt.Errorf("errors of backfill workers:\n%s", errMsg)
+		}
+		return nil
+	}
+
+	if progress.Failed > 0 {
+		if statusErr := b.store.SetBackfillJobStatus(job.ID, indexer.BackfillFailed); statusErr != nil {
+			return statusErr
+		}
+		return fmt.Errorf("%d chunks of backfill job %s failed, retry them with --retry-failed", progress.Failed, job.ID)
+	}
+
+	if err := b.store.SetBackfillJobStatus(job.ID, indexer.BackfillDone); err != nil {
+		return err
+	}
+	if err := d.Store.UpdateAbisAsDone(abiJobIds); err != nil {
+		return err
+	}
+
+	logger.Info("Backfill is finished", "chunks", progress.Chunks)
+
+	return nil
+}
+
+// work claims and crawls chunks until there are no pending chunks or ctx is cancelled. Failed
+// chunk is recorded and returned to pending, it does not stop the worker.
+func (b *Backfill) work(ctx context.Context, job *indexer.BackfillJob, abiJobs []indexer.AbiJob, abiJobIds []string, connections map[int]CustomerDBConnection, logger *slog.Logger) error {
+	// Parsed ABIs are cached in entries, so each worker decodes with its own copy
+	customerUpdates, _, err := indexer.ConvertToCustomerUpdatedAndDeployBlockDicts(abiJobs)
+	if err != nil {
+		return fmt.Errorf("error parsing ABI jobs: %w", err)
+	}
+	abiMap := customerUpdates[0].Abis
+
+	for ctx.Err() == nil {
+		chunk, claimErr := b.store.ClaimBackfillChunk(job.ID)
+		if claimErr != nil {
+			return fmt.Errorf("failed to claim chunk: %w", claimErr)
+		}
+		if chunk == nil {
+			return nil
+		}
+
+		if crawlErr := b.crawlChunk(job, chunk, abiMap, connections); crawlErr != nil {
+			logger.Error("Failed to crawl chunk", logging.FromBlockKey, chunk.FromBlock, logging.ToBlockKey, chunk.ToBlock, "attempt", chunk.Attempts, logging.ErrorKey, crawlErr)
+			if failErr := b.store.FailBackfillChunk(job.ID, chunk.FromBlock, crawlErr, b.MaxAttempts); failErr != nil {
+				return fmt.Errorf("failed to record error of chunk %d-%d: %w", chunk.FromBlock, chunk.ToBlock, failErr)
+			}
+			continue
+		}
+
+		if completeErr := b.store.CompleteBackfillChunk(job.ID, chunk.FromBlock); completeErr != nil {
+			return fmt.Errorf("failed to complete chunk %d-%d: %w", chunk.FromBlock, chunk.ToBlock, completeErr)
+		}
+
+		progress, progressErr := b.store.GetBackfillProgress(job.ID)
+		if progressErr != nil {
+			logger.Error("Failed to get progress of backfill", logging.ErrorKey, progressErr)
+			continue
+		}
+		if updateErr := b.synchronizer.Store.UpdateAbisProgress(abiJobIds, progress.Percent()); updateErr != nil {
+			logger.Error("Failed to update progress of ABI jobs", logging.ErrorKey, updateErr)
+		}
+
+		logger.Info("Crawled chunk", logging.FromBlockKey, chunk.FromBlock, logging.ToBlockKey, chunk.ToBlock, "progress", progress.Percent())
+	}
+
+	return nil
+}
+
+// crawlChunk fetches labels of chunk from RPC and writes them to all instances of customer.
+func (b *Backfill) crawlChunk(job *indexer.BackfillJob, chunk *indexer.BackfillChunk, abiMap map[string]map[string]*indexer.AbiEntry, connections map[int]CustomerDBConnection) error {
+	d := b.synchronizer
+
+	hasFunctions := false
+	for _, entries := range abiMap {
+		for _, entry := range entries {
+			if entry.AbiType == "function" {
+				hasFunctions = true
+			}
+		}
+	}
+
+	item := CustomerLabels{CustomerID: job.CustomerID}
+
+	// Blocks fetched for transactions are reused for timestamps of events
+	var blocksCache map[uint64]seer_common.BlockWithTransactions
+	if hasFunctions {
+		transactions, cache, err := d.Client.GetTransactionsLabels(chunk.FromBlock, chunk.ToBlock, abiMap, d.threads)
+		if err != nil {
+			return fmt.Errorf("failed to get transactions labels: %w", err)
+		}
+		item.Transactions = transactions
+		blocksCache = cache
+	}
+
+	events, err := d.Client.GetEventsLabels(chunk.FromBlock, chunk.ToBlock, abiMap, blocksCache)
+	if err != nil {
+		return fmt.Errorf("failed to get events labels: %w", err)
+	}
+	item.Events = events
+
+	d.Transforms.Apply(job.CustomerID, item.Events, item.Transactions)
+
+	var items []CustomerLabels
+	for instanceId, connection := range connections {
+		instanceItem := item
+		instanceItem.InstanceID = instanceId
+		instanceItem.Connection = connection
+		items = append(items, instanceItem)
+	}
+
+	results := NewFanOutWriter(d.blockchain, d.writeThreads, 3, 1*time.Second).Write(items)
+	for i, result := range results {
+		if result.Err != nil {
+			continue
+		}
+		metrics.LabelsWritten.WithLabelValues(d.blockchain, items[i].CustomerID, "event").Add(float64(len(items[i].Events)))
+		metrics.LabelsWritten.WithLabelValues(d.blockchain, items[i].CustomerID, "tx_call").Add(float64(len(items[i].Transactions)))
+	}
+
+	return FanOutErrors(results)
+}
@@ -0,0 +1,146 @@
+package synchronizer
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+// CustomerLabels is a set of decoded labels ready to be written into one customer instance database.
+type CustomerLabels struct {
+	CustomerID string
+	InstanceID int
+	Connection CustomerDBConnection
+
+	Transactions    []indexer.TransactionLabel
+	Events          []indexer.EventLabel
+	RawTransactions []indexer.RawTransaction
+}
+
+// FanOutResult represents the outcome of write to one customer instance database.
+type FanOutResult struct {
+	CustomerID string
+	InstanceID int
+	Attempts   int
+	Duration   time.Duration
+	Err        error
+
+	// Buffered is set when labels were not written but saved to outbox
+	Buffered bool
+}
+
+// FanOutWriter dispatches labels of each customer instance to its database concurrently.
+// Failure of one customer does not interrupt writes of others, all results are returned
+// to the caller.
+type FanOutWriter struct {
+	blockchain  string
+	concurrency int
+	retries     int
+	retryWait   time.Duration
+}
+
+func NewFanOutWriter(blockchain string, concurrency, retries int, retryWait time.Duration) *FanOutWriter {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	return &FanOutWriter{
+		blockchain:  blockchain,
+		concurrency: concurrency,
+		retries:     retries,
+		retryWait:   retryWait,
+	}
+}
+
+func (w *FanOutWriter) writeOne(item CustomerLabels) (result FanOutResult) {
+	result = FanOutResult{CustomerID: item.CustomerID, InstanceID: item.InstanceID}
+	startTs := time.Now()
+
+	defer func() {
+		if r := recover(); r != nil {
+			result.Err = fmt.Errorf("panic during write for customer %s, instance %d: %v", item.CustomerID, item.InstanceID, r)
+		}
+		result.Duration = time.Since(startTs)
+	}()
+
+	for {
+		result.Attempts++
+		err := item.Connection.Writer.WriteDataToCustomerDB(w.blockchain, item.Transactions, item.Events, item.RawTransactions)
+		if err == nil {
+			result.Err = nil
+			return result
+		}
+
+		// Sections are already retried by writer, only transient failures such as dropped
+		// connection are worth another attempt of whole write
+		result.Err = err
+		if result.Attempts > w.retries || !indexer.IsTransientWriteError(err) {
+			return result
+		}
+
+		// Committed sections are not written again
+		var writeErr *indexer.LabelsWriteError
+		if errors.As(err, &writeErr) {
+			if writeErr.IsPersisted(indexer.LabelsSectionTransactions) {
+				item.Transactions = nil
+			}
+			if writeErr.IsPersisted(indexer.LabelsSectionEvents) {
+				item.Events = nil
+			}
+			if writeErr.IsPersisted(indexer.LabelsSectionRawTransactions) {
+				item.RawTransactions = nil
+			}
+		}
+		time.Sleep(w.retryWait)
+	}
+}
+
+// Write pushes labels to customer databases with bounded parallelism.
+func (w *FanOutWriter) Write(items []CustomerLabels) []FanOutResult {
+	results := make([]FanOutResult, len(items))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, w.concurrency)
+
+	for i, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, item CustomerLabels) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			results[i] = w.writeOne(item)
+		}(i, item)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// FanOutErrors joins errors of failed writes, returns nil if all writes succeeded.
+func FanOutErrors(results []FanOutResult) error {
+	var errMsg string
+	var failed int
+	for _, result := range results {
+		if result.Err == nil {
+			continue
+		}
+		failed++
+		errMsg += fmt.Sprintf("customer %s, instance %d after %d attempts: %v\n", result.CustomerID, result.InstanceID, result.Attempts, result.Err)
+	}
+
+	if failed == 0 {
+		return nil
+	}
+
+	log.Printf("Failed to write labels for %d of %d customer instances", failed, len(results))
+
+	return fmt.Errorf("errors writing customer labels:\n%s", errMsg)
+}
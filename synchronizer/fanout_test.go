@@ -0,0 +1,121 @@
+package synchronizer
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+const testChain = "ethereum"
+
+func testLabels(customerID string, blockNumber uint64) ([]indexer.TransactionLabel, []indexer.EventLabel) {
+	transactions := []indexer.TransactionLabel{{
+		Address:         "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+		BlockNumber:     blockNumber,
+		Label:           "seer",
+		LabelName:       "transfer",
+		LabelType:       "tx_call",
+		TransactionHash: "0x" + customerID,
+		LabelData:       `{"type":"tx_call"}`,
+	}}
+	events := []indexer.EventLabel{{
+		Address:         "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+		BlockNumber:     blockNumber,
+		Label:           "seer",
+		LabelName:       "Transfer",
+		LabelType:       "event",
+		TransactionHash: "0x" + customerID,
+		LabelData:       `{"type":"event"}`,
+		LogIndex:        1,
+	}}
+
+	return transactions, events
+}
+
+func TestFanOutWriterWritesToCustomerStores(t *testing.T) {
+	stores := map[string]*indexer.MemoryStore{
+		"customer-1": indexer.NewMemoryStore(),
+		"customer-2": indexer.NewMemoryStore(),
+	}
+
+	var items []CustomerLabels
+	for i, customerID := range []string{"customer-1", "customer-2"} {
+		transactions, events := testLabels(customerID, uint64(100+i))
+		items = append(items, CustomerLabels{
+			CustomerID:   customerID,
+			InstanceID:   1,
+			Connection:   CustomerDBConnection{Writer: stores[customerID]},
+			Transactions: transactions,
+			Events:       events,
+		})
+	}
+
+	writer := NewFanOutWriter(testChain, 2, 2, 0)
+	// Labels written again are skipped by customer database
+	for i := 0; i < 2; i++ {
+		results := writer.Write(items)
+		if err := FanOutErrors(results); err != nil {
+			t.Fatalf("write %d failed: %v", i, err)
+		}
+		for _, result := range results {
+			if result.Attempts != 1 {
+				t.Fatalf("customer %s written in %d attempts, want 1", result.CustomerID, result.Attempts)
+			}
+		}
+	}
+
+	for i, customerID := range []string{"customer-1", "customer-2"} {
+		events := stores[customerID].EventLabels(testChain)
+		transactions := stores[customerID].TransactionLabels(testChain)
+		if len(events) != 1 || len(transactions) != 1 {
+			t.Fatalf("customer %s has %d events and %d transactions, want 1 and 1", customerID, len(events), len(transactions))
+		}
+		if events[0].BlockNumber != uint64(100+i) || transactions[0].TransactionHash != "0x"+customerID {
+			t.Fatalf("customer %s has labels of other customer", customerID)
+		}
+	}
+}
+
+func TestFanOutWriterRetriesTransientErrorsOnly(t *testing.T) {
+	const retries = 2
+
+	testCases := []struct {
+		name             string
+		writeErr         error
+		expectedAttempts int
+	}{
+		{"transient error", io.ErrUnexpectedEOF, retries + 1},
+		{"permanent error", errors.New("column \"label_data\" is of type jsonb"), 1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			failingStore := indexer.NewMemoryStore()
+			failingStore.SetWriteError(testCase.writeErr)
+			healthyStore := indexer.NewMemoryStore()
+
+			transactions, events := testLabels("customer-1", 100)
+			items := []CustomerLabels{
+				{CustomerID: "customer-1", Connection: CustomerDBConnection{Writer: failingStore}, Transactions: transactions, Events: events},
+				{CustomerID: "customer-2", Connection: CustomerDBConnection{Writer: healthyStore}, Transactions: transactions, Events: events},
+			}
+
+			results := NewFanOutWriter(testChain, 2, retries, 0).Write(items)
+
+			if !errors.Is(results[0].Err, testCase.writeErr) {
+				t.Fatalf("got error %v, want %v", results[0].Err, testCase.writeErr)
+			}
+			if results[0].Attempts != testCase.expectedAttempts {
+				t.Fatalf("failed write made %d attempts, want %d", results[0].Attempts, testCase.expectedAttempts)
+			}
+			if results[1].Err != nil || len(healthyStore.EventLabels(testChain)) != 1 {
+				t.Fatalf("write of other customer is affected by failure: %v", results[1].Err)
+			}
+			if FanOutErrors(results) == nil {
+				t.Fatal("FanOutErrors does not report failed write")
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package synchronizer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+// CustomerLeases divides customers of chain between synchronizer replicas. Each replica keeps
+// member lease while it runs and takes leases of customers up to its fair share: number of
+// customers divided by number of live replicas. Leases are renewed by heartbeat, leases of
+// stopped replica expire and are taken over by others on their next cycle.
+type CustomerLeases struct {
+	store  *indexer.PostgreSQLpgx
+	chain  string
+	holder string
+	ttl    time.Duration
+
+	mu   sync.Mutex
+	held map[string]bool
+}
+
+// NewReplicaID returns identifier of replica unique across hosts and restarts.
+func NewReplicaID() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), uuid.NewString()[:8])
+}
+
+func NewCustomerLeases(store *indexer.PostgreSQLpgx, chain, holder string, ttl time.Duration) (*CustomerLeases, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("lease TTL should be positive")
+	}
+	if holder == "" {
+		holder = NewReplicaID()
+	}
+
+	if err := store.EnsureLeasesTable(); err != nil {
+		return nil, fmt.Errorf("failed to create leases table: %w", err)
+	}
+
+	return &CustomerLeases{
+		store:  store,
+		chain:  chain,
+		holder: holder,
+		ttl:    ttl,
+		held:   make(map[string]bool),
+	}, nil
+}
+
+func (l *CustomerLeases) prefix() string {
+	return fmt.Sprintf("synchronizer/%s/", l.chain)
+}
+
+func (l *CustomerLeases) replicaResource() string {
+	return l.prefix() + "replica/" + l.holder
+}
+
+func (l *CustomerLeases) customerResource(customerId string) string {
+	return l.prefix() + "customer/" + customerId
+}
+
+// Holder returns identifier of this replica.
+func (l *CustomerLeases) Holder() string {
+	return l.holder
+}
+
+// Held returns sorted IDs of customers leased by this replica.
+func (l *CustomerLeases) Held() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	customerIds := make([]string, 0, len(l.held))
+	for customerId := range l.held {
+		customerIds = append(customerIds, customerId)
+	}
+	sort.Strings(customerIds)
+	return customerIds
+}
+
+// Assign rebalances leases over customerIds and returns customers this replica should sync.
+// Leases of customers which are not in customerIds anymore and leases above fair share are
+// released, so they could be taken by other replicas.
+func (l *CustomerLeases) Assign(customerIds []string) ([]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := l.store.AcquireLease(l.replicaResource(), l.holder, l.ttl); err != nil {
+		return nil, fmt.Errorf("failed to register replica %s: %w", l.holder, err)
+	}
+
+	leases, err := l.store.ListLeases(l.prefix())
+	if err != nil {
+		return nil, err
+	}
+
+	replicas := 0
+	leasedByOthers := make(map[string]bool)
+	for _, lease := range leases {
+		if lease.Expired {
+			continue
+		}
+		if strings.HasPrefix(lease.Resource, l.prefix()+"replica/") {
+			replicas++
+		} else if lease.Holder != l.holder {
+			leasedByOthers[strings.TrimPrefix(lease.Resource, l.prefix()+"customer/")] = true
+		}
+	}
+	if replicas == 0 {
+		replicas = 1
+	}
+
+	sort.Strings(customerIds)
+	share := (len(customerIds) + replicas - 1) / replicas
+
+	current := make(map[string]bool, len(customerIds))
+	for _, customerId := range customerIds {
+		current[customerId] = true
+	}
+
+	// Renew leases of customers which are still in the list
+	var held []string
+	for _, customerId := range customerIds {
+		if !l.held[customerId] {
+			continue
+		}
+		acquired, err := l.store.AcquireLease(l.customerResource(customerId), l.holder, l.ttl)
+		if err != nil {
+			return nil, err
+		}
+		if !acquired {
+			log.Printf("Lease of customer %s at %s was taken by other replica", customerId, l.chain)
+			delete(l.held, customerId)
+			continue
+		}
+		held = append(held, customerId)
+	}
+
+	for customerId := range l.held {
+		if !current[customerId] {
+			l.release(customerId)
+		}
+	}
+
+	for len(held) > share {
+		l.release(held[len(held)-1])
+		held = held[:len(held)-1]
+	}
+
+	for _, customerId := range customerIds {
+		if len(held) >= share {
+			break
+		}
+		if l.held[customerId] || leasedByOthers[customerId] {
+			continue
+		}
+		acquired, err := l.store.AcquireLease(l.customerResource(customerId), l.holder, l.ttl)
+		if err != nil {
+			return nil, err
+		}
+		if acquired {
+			l.held[customerId] = true
+			held = append(held, customerId)
+		}
+	}
+
+	sort.Strings(held)
+	log.Printf("Replica %s holds %d of %d customers at %s with %d live replicas", l.holder, len(held), len(customerIds), l.chain, replicas)
+
+	return held, nil
+}
+
+// release frees lease of customer, caller should hold lock.
+func (l *CustomerLeases) release(customerId string) {
+	delete(l.held, customerId)
+	if err := l.store.ReleaseLease(l.customerResource(customerId), l.holder); err != nil {
+		log.Printf("Failed to release lease of customer %s at %s: %v", customerId, l.chain, err)
+	}
+}
+
+// Heartbeat renews leases of replica every third of TTL until ctx is done. Customers which
+// leases could not be renewed are dropped and synced by replica which took them over.
+func (l *CustomerLeases) Heartbeat(ctx context.Context) {
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		l.mu.Lock()
+		if _, err := l.store.AcquireLease(l.replicaResource(), l.holder, l.ttl); err != nil {
+			log.Printf("Failed to renew lease of replica %s: %v", l.holder, err)
+		}
+		for customerId := range l.held {
+			acquired, err := l.store.AcquireLease(l.customerResource(customerId), l.holder, l.ttl)
+			if err != nil {
+				log.Printf("Failed to renew lease of customer %s at %s: %v", customerId, l.chain, err)
+				continue
+			}
+			if !acquired {
+				log.Printf("Lease of customer %s at %s was taken by other replica", customerId, l.chain)
+				delete(l.held, customerId)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
+
+// Release frees all leases of replica, it is called on shutdown so other replicas do not
+// wait for expiration.
+func (l *CustomerLeases) Release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for customerId := range l.held {
+		l.release(customerId)
+	}
+	if err := l.store.ReleaseLease(l.replicaResource(), l.holder); err != nil {
+		log.Printf("Failed to release lease of replica %s: %v", l.holder, err)
+	}
+}
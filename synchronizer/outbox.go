@@ -0,0 +1,177 @@
+package synchronizer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/G7DAO/seer/indexer"
+	"github.com/G7DAO/seer/logging"
+	"github.com/G7DAO/seer/storage"
+)
+
+// LabelsOutboxReplayLimit is a maximum number of outbox entries replayed per cycle
+var LabelsOutboxReplayLimit = 1000
+
+// LabelsOutbox buffers labels of customer instances which databases are unreachable, so sync
+// of chain does not stall on outage of one customer. Labels are saved to outbox storage under
+// prefix of customer instance and recorded in index database, Replay writes them to customer
+// database once it is reachable again. Labels are inserted skipping conflicts, so replay of
+// entry which was partially written before outage is harmless.
+type LabelsOutbox struct {
+	storage    storage.Storer
+	store      *indexer.PostgreSQLpgx
+	blockchain string
+	basePath   string
+}
+
+func NewLabelsOutbox(storageInstance storage.Storer, store *indexer.PostgreSQLpgx, blockchain, basePath string) (*LabelsOutbox, error) {
+	if err := store.EnsureLabelsOutboxTable(); err != nil {
+		return nil, fmt.Errorf("failed to create labels outbox table: %w", err)
+	}
+
+	return &LabelsOutbox{
+		storage:    storageInstance,
+		store:      store,
+		blockchain: blockchain,
+		basePath:   basePath,
+	}, nil
+}
+
+type outboxPayload struct {
+	Transactions    []indexer.TransactionLabel `json:"transactions,omitempty"`
+	Events          []indexer.EventLabel       `json:"events,omitempty"`
+	RawTransactions []indexer.RawTransaction   `json:"raw_transactions,omitempty"`
+}
+
+func outboxDir(customerId string, instanceId int) string {
+	return filepath.Join(customerId, fmt.Sprint(instanceId))
+}
+
+// Buffer saves labels of item to storage and records outbox entry, writeErr is the error
+// write to customer database failed with.
+func (o *LabelsOutbox) Buffer(item CustomerLabels, fromBlock, toBlock uint64, writeErr error) error {
+	payload, err := json.Marshal(outboxPayload{
+		Transactions:    item.Transactions,
+		Events:          item.Events,
+		RawTransactions: item.RawTransactions,
+	})
+	if err != nil {
+		return err
+	}
+
+	id := uuid.NewString()
+	dir := outboxDir(item.CustomerID, item.InstanceID)
+	filename := id + ".json"
+	if err := o.storage.Save(dir, filename, *bytes.NewBuffer(payload)); err != nil {
+		return fmt.Errorf("failed to save labels to outbox: %w", err)
+	}
+
+	entry := indexer.LabelsOutboxEntry{
+		ID:              id,
+		Chain:           o.blockchain,
+		CustomerID:      item.CustomerID,
+		InstanceID:      item.InstanceID,
+		Path:            filepath.Join(o.basePath, dir, filename),
+		FromBlock:       fromBlock,
+		ToBlock:         toBlock,
+		Events:          len(item.Events),
+		Transactions:    len(item.Transactions),
+		RawTransactions: len(item.RawTransactions),
+	}
+	if writeErr != nil {
+		entry.LastError = writeErr.Error()
+	}
+	if err := o.store.InsertLabelsOutboxEntry(entry); err != nil {
+		if deleteErr := o.storage.Delete(entry.Path); deleteErr != nil {
+			log.Printf("Failed to delete orphaned outbox object %s: %v", entry.Path, deleteErr)
+		}
+		return fmt.Errorf("failed to record outbox entry: %w", err)
+	}
+
+	logging.Customer(o.blockchain, item.CustomerID).Info("Buffered labels to outbox", "instance", item.InstanceID, logging.FromBlockKey, fromBlock, logging.ToBlockKey, toBlock, "events", entry.Events, "transactions", entry.Transactions, "raw_transactions", entry.RawTransactions)
+
+	return nil
+}
+
+// Replay writes buffered labels of customer instances with connections to their databases in
+// order they were buffered. Instance is skipped for the rest of replay after first failed
+// entry, entries of instances without connections are kept. Returns number of replayed entries.
+func (o *LabelsOutbox) Replay(customerDBConnections map[string]map[int]CustomerDBConnection) (int, error) {
+	customerIds := make([]string, 0, len(customerDBConnections))
+	for customerId := range customerDBConnections {
+		customerIds = append(customerIds, customerId)
+	}
+	if len(customerIds) == 0 {
+		return 0, nil
+	}
+	sort.Strings(customerIds)
+
+	entries, err := o.store.SelectLabelsOutboxEntries(o.blockchain, customerIds, LabelsOutboxReplayLimit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read outbox entries: %w", err)
+	}
+
+	var replayed int
+	var errs []error
+	failedInstances := make(map[string]bool)
+	for _, entry := range entries {
+		instanceKey := fmt.Sprintf("%s/%d", entry.CustomerID, entry.InstanceID)
+		if failedInstances[instanceKey] {
+			continue
+		}
+		connection, exists := customerDBConnections[entry.CustomerID][entry.InstanceID]
+		if !exists {
+			continue
+		}
+
+		if replayErr := o.replayEntry(entry, connection); replayErr != nil {
+			failedInstances[instanceKey] = true
+			if recordErr := o.store.RecordLabelsOutboxAttempt(entry.ID, replayErr); recordErr != nil {
+				log.Printf("Failed to record attempt of outbox entry %s: %v", entry.ID, recordErr)
+			}
+			errs = append(errs, fmt.Errorf("customer %s, instance %d, entry %s: %w", entry.CustomerID, entry.InstanceID, entry.ID, replayErr))
+			continue
+		}
+
+		replayed++
+	}
+
+	if replayed > 0 {
+		log.Printf("Replayed %d outbox entries of %s to customer databases", replayed, o.blockchain)
+	}
+
+	return replayed, errors.Join(errs...)
+}
+
+func (o *LabelsOutbox) replayEntry(entry indexer.LabelsOutboxEntry, connection CustomerDBConnection) error {
+	data, err := o.storage.Read(entry.Path)
+	if err != nil {
+		return err
+	}
+
+	var payload outboxPayload
+	if err := json.Unmarshal(data.Bytes(), &payload); err != nil {
+		return fmt.Errorf("failed to parse outbox object %s: %w", entry.Path, err)
+	}
+
+	if err := connection.Writer.WriteDataToCustomerDB(o.blockchain, payload.Transactions, payload.Events, payload.RawTransactions); err != nil {
+		return err
+	}
+
+	// Entry is deleted first, object left after failed delete is only garbage
+	if err := o.store.DeleteLabelsOutboxEntry(entry.ID); err != nil {
+		return fmt.Errorf("labels are written but entry is not deleted: %w", err)
+	}
+	if err := o.storage.Delete(entry.Path); err != nil {
+		log.Printf("Failed to delete replayed outbox object %s: %v", entry.Path, err)
+	}
+
+	return nil
+}
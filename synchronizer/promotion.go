@@ -0,0 +1,254 @@
+package synchronizer
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	seer_common "github.com/G7DAO/seer/blockchain/common"
+	"github.com/G7DAO/seer/indexer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Keys of raw label data which are not produced by decoding and are moved to promoted label
+var promotedLabelDataKeys = []string{"status", "inner_call", "safe"}
+
+// decodeRawLabel decodes raw payload of label with ABI of job, it returns label data of
+// decoded label.
+func decodeRawLabel(contractABI *abi.ABI, rawLabel indexer.RawLabel) (map[string]interface{}, error) {
+	inputRaw, ok := rawLabel.LabelData["input_raw"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("raw label has no input_raw payload")
+	}
+
+	var labelData map[string]interface{}
+	switch rawLabel.LabelType {
+	case "tx_call":
+		input, _ := inputRaw["input"].(string)
+		if input == "" {
+			// Safe inner calls keep call data of target in data field
+			input, _ = inputRaw["data"].(string)
+		}
+		inputData, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid input data: %w", err)
+		}
+		if len(inputData) < 4 {
+			return nil, fmt.Errorf("input data is shorter than selector")
+		}
+		if _, err := contractABI.MethodById(inputData[:4]); err != nil {
+			return nil, err
+		}
+
+		labelData, err = seer_common.DecodeTransactionInputDataToInterface(contractABI, inputData)
+		if err != nil {
+			return nil, err
+		}
+	case "event":
+		rawTopics, _ := inputRaw["topics"].([]interface{})
+		topics := make([]string, 0, len(rawTopics))
+		for _, rawTopic := range rawTopics {
+			topic, _ := rawTopic.(string)
+			topics = append(topics, topic)
+		}
+		if len(topics) == 0 {
+			return nil, fmt.Errorf("event has no topics")
+		}
+		if _, err := contractABI.EventByID(common.HexToHash(topics[0])); err != nil {
+			return nil, err
+		}
+
+		data, _ := inputRaw["data"].(string)
+		if _, err := hex.DecodeString(strings.TrimPrefix(data, "0x")); err != nil {
+			return nil, fmt.Errorf("invalid event data: %w", err)
+		}
+
+		var err error
+		labelData, err = seer_common.DecodeLogArgsToLabelData(contractABI, topics, data)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported label type %s", rawLabel.LabelType)
+	}
+
+	for _, key := range promotedLabelDataKeys {
+		if value, exists := rawLabel.LabelData[key]; exists {
+			labelData[key] = value
+		}
+	}
+
+	return labelData, nil
+}
+
+// promoteJobRawLabels re-decodes raw labels of job in customer database batch by batch.
+func promoteJobRawLabels(dbConn *indexer.PostgreSQLpgx, blockchain string, job indexer.AbiJob, batchLimit int, report *indexer.RawLabelsPromotionReport) error {
+	contractABI, err := seer_common.GetABI(job.Abi)
+	if err != nil {
+		return fmt.Errorf("unable to parse ABI of job %s: %w", job.ID, err)
+	}
+
+	address := fmt.Sprintf("0x%x", job.Address)
+
+	var afterID string
+	for {
+		rawLabels, readErr := dbConn.ReadRawLabels(blockchain, address, job.AbiSelector, job.Abi, afterID, batchLimit)
+		if readErr != nil {
+			return readErr
+		}
+		if len(rawLabels) == 0 {
+			return nil
+		}
+
+		var txCalls []indexer.TransactionLabel
+		var events []indexer.EventLabel
+		var supersededIDs []string
+		var failures []indexer.RawLabelFailure
+		for _, rawLabel := range rawLabels {
+			afterID = rawLabel.ID
+
+			labelData, decodeErr := decodeRawLabel(contractABI, rawLabel)
+			if decodeErr != nil {
+				failures = append(failures, indexer.RawLabelFailure{ID: rawLabel.ID, Abi: job.Abi, Error: decodeErr.Error()})
+				continue
+			}
+
+			labelDataBytes, marshalErr := json.Marshal(labelData)
+			if marshalErr != nil {
+				failures = append(failures, indexer.RawLabelFailure{ID: rawLabel.ID, Abi: job.Abi, Error: marshalErr.Error()})
+				continue
+			}
+
+			if rawLabel.LabelType == "event" {
+				var logIndex uint64
+				if rawLabel.LogIndex != nil {
+					logIndex = *rawLabel.LogIndex
+				}
+				events = append(events, indexer.EventLabel{
+					Label:           indexer.SeerCrawlerLabel,
+					LabelName:       job.AbiName,
+					LabelType:       "event",
+					BlockNumber:     rawLabel.BlockNumber,
+					BlockHash:       rawLabel.BlockHash,
+					Address:         rawLabel.Address,
+					CallerAddress:   rawLabel.CallerAddress,
+					OriginAddress:   rawLabel.OriginAddress,
+					TransactionHash: rawLabel.TransactionHash,
+					LabelData:       string(labelDataBytes),
+					BlockTimestamp:  rawLabel.BlockTimestamp,
+					LogIndex:        logIndex,
+				})
+			} else {
+				txCalls = append(txCalls, indexer.TransactionLabel{
+					Label:           indexer.SeerCrawlerLabel,
+					LabelName:       job.AbiName,
+					LabelType:       "tx_call",
+					BlockNumber:     rawLabel.BlockNumber,
+					BlockHash:       rawLabel.BlockHash,
+					Address:         rawLabel.Address,
+					CallerAddress:   rawLabel.CallerAddress,
+					OriginAddress:   rawLabel.OriginAddress,
+					TransactionHash: rawLabel.TransactionHash,
+					LabelData:       string(labelDataBytes),
+					BlockTimestamp:  rawLabel.BlockTimestamp,
+				})
+			}
+			supersededIDs = append(supersededIDs, rawLabel.ID)
+		}
+
+		if err := dbConn.PromoteRawLabels(blockchain, txCalls, events, supersededIDs, failures); err != nil {
+			return err
+		}
+
+		report.Checked += len(rawLabels)
+		report.Promoted += len(supersededIDs)
+		report.Failed += len(failures)
+
+		if len(rawLabels) < batchLimit {
+			return nil
+		}
+	}
+}
+
+// PromoteRawLabels finds raw fallback labels matching address and selector of jobs in
+// customer databases and re-decodes them with current ABI of jobs. Raw labels which still
+// could not be decoded are attempted again only after ABI of job is changed.
+func PromoteRawLabels(blockchain string, customerIds []string, batchLimit int) ([]indexer.RawLabelsPromotionReport, error) {
+	jobs, err := indexer.DBConnection.SelectAbiJobs(blockchain, []string{}, customerIds, false, false, []string{"function", "event"})
+	if err != nil {
+		return nil, err
+	}
+
+	jobsByCustomer := make(map[string][]indexer.AbiJob)
+	for _, job := range jobs {
+		if job.Status == indexer.AbiJobStatusInactive {
+			continue
+		}
+		jobsByCustomer[job.CustomerID] = append(jobsByCustomer[job.CustomerID], job)
+	}
+
+	var reports []indexer.RawLabelsPromotionReport
+	for customerId, customerJobs := range jobsByCustomer {
+		instances, instancesErr := GetCustomerInstances(customerId)
+		if instancesErr != nil {
+			log.Printf("Unable to get instances of customer %s, err: %v", customerId, instancesErr)
+			continue
+		}
+
+		report := indexer.RawLabelsPromotionReport{CustomerID: customerId}
+		for _, instance := range instances {
+			connectionString, dbConnErr := GetDBConnection(customerId, instance, "seer")
+			if dbConnErr != nil {
+				log.Printf("Unable to get connection database URI for customer %s, err: %v", customerId, dbConnErr)
+				continue
+			}
+
+			dbConn, pgxErr := indexer.NewCustomerPostgreSQLpgx(connectionString, customerId)
+			if pgxErr != nil {
+				log.Printf("Error creating RDS connection for customer %s, err: %v", customerId, pgxErr)
+				continue
+			}
+
+			for _, job := range customerJobs {
+				if promoteErr := promoteJobRawLabels(dbConn, blockchain, job, batchLimit, &report); promoteErr != nil {
+					log.Printf("Unable to promote raw labels of job %s of customer %s, err: %v", job.ID, customerId, promoteErr)
+				}
+			}
+
+			dbConn.Close()
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// RunRawLabelsPromotion promotes raw labels every interval until context is canceled.
+func RunRawLabelsPromotion(ctx context.Context, blockchain string, customerIds []string, interval time.Duration, batchLimit int) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		reports, err := PromoteRawLabels(blockchain, customerIds, batchLimit)
+		if err != nil {
+			log.Printf("Raw labels promotion failed: %v", err)
+		}
+		for _, report := range reports {
+			if report.Checked > 0 {
+				log.Printf("Customer %s: checked %d raw labels, promoted %d, failed %d", report.CustomerID, report.Checked, report.Promoted, report.Failed)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
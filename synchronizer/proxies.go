@@ -0,0 +1,115 @@
+package synchronizer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	seer_common "github.com/G7DAO/seer/blockchain/common"
+	"github.com/G7DAO/seer/indexer"
+)
+
+// ProxyAbis merges ABIs of implementation contracts into ABIs of proxies. Logs and calls of
+// proxy carry proxy address, so they are decoded only with jobs of proxy address. Jobs
+// registered by customer for implementation address are added to ABIs of proxy for blocks in
+// which proxy delegated to it, jobs of proxy itself have priority over them.
+type ProxyAbis struct {
+	resolver   *seer_common.ProxyResolver
+	store      indexer.JobStore
+	blockchain string
+}
+
+func NewProxyAbis(client seer_common.ChainClient, store indexer.JobStore, blockchain string) (*ProxyAbis, error) {
+	resolver, err := seer_common.NewProxyResolver(client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProxyAbis{resolver: resolver, store: store, blockchain: blockchain}, nil
+}
+
+// Merge returns update with ABIs of implementations active in block range merged into ABIs
+// of proxies, ABIs of input update are not modified.
+func (p *ProxyAbis) Merge(update indexer.CustomerUpdates, fromBlock, toBlock uint64) (indexer.CustomerUpdates, error) {
+	implementationsOf := make(map[string][]string)
+	var missing []string
+	for address := range update.Abis {
+		if !common.IsHexAddress(address) {
+			continue
+		}
+
+		ranges, err := p.resolver.Implementations(context.Background(), common.HexToAddress(address), fromBlock, toBlock)
+		if err != nil {
+			return update, fmt.Errorf("failed to resolve implementation of %s: %w", address, err)
+		}
+
+		for _, implementationRange := range ranges {
+			implementation := strings.ToLower(implementationRange.Implementation.Hex())
+			implementationsOf[address] = append(implementationsOf[address], implementation)
+			if _, exists := update.Abis[implementation]; !exists {
+				missing = append(missing, implementation)
+			}
+		}
+	}
+
+	if len(implementationsOf) == 0 {
+		return update, nil
+	}
+
+	implementationAbis := make(map[string]map[string]*indexer.AbiEntry)
+	if len(missing) > 0 {
+		abiJobs, err := p.store.SelectAbiJobs(p.blockchain, missing, []string{update.CustomerID}, false, false, nil)
+		if err != nil {
+			return update, fmt.Errorf("failed to read jobs of implementations: %w", err)
+		}
+		for _, abiJob := range abiJobs {
+			implementation := strings.ToLower(common.BytesToAddress(abiJob.Address).Hex())
+			if implementationAbis[implementation] == nil {
+				implementationAbis[implementation] = make(map[string]*indexer.AbiEntry)
+			}
+			implementationAbis[implementation][abiJob.AbiSelector] = &indexer.AbiEntry{
+				AbiJSON:      abiJob.Abi,
+				AbiName:      abiJob.AbiName,
+				AbiType:      abiJob.AbiType,
+				TopicFilters: abiJob.TopicFilters,
+			}
+		}
+	}
+
+	merged := update
+	merged.Abis = make(map[string]map[string]*indexer.AbiEntry, len(update.Abis))
+	for address, abis := range update.Abis {
+		merged.Abis[address] = abis
+	}
+
+	for proxy, implementations := range implementationsOf {
+		proxyAbis := make(map[string]*indexer.AbiEntry, len(update.Abis[proxy]))
+		for selector, entry := range update.Abis[proxy] {
+			proxyAbis[selector] = entry
+		}
+
+		added := 0
+		for _, implementation := range implementations {
+			abis, exists := update.Abis[implementation]
+			if !exists {
+				abis = implementationAbis[implementation]
+			}
+			for selector, entry := range abis {
+				if _, exists := proxyAbis[selector]; !exists {
+					proxyAbis[selector] = entry
+					added++
+				}
+			}
+		}
+
+		if added > 0 {
+			log.Printf("Merged %d ABIs of implementations %v into proxy %s of customer %s for blocks %d-%d", added, implementations, proxy, update.CustomerID, fromBlock, toBlock)
+		}
+		merged.Abis[proxy] = proxyAbis
+	}
+
+	return merged, nil
+}
@@ -0,0 +1,72 @@
+package synchronizer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/G7DAO/seer/indexer"
+	"github.com/G7DAO/seer/logging"
+)
+
+var (
+	// Publishes to sinks are retried before cycle is failed
+	SinkPublishAttempts = 3
+	SinkRetryWait       = 2 * time.Second
+)
+
+// LabelsSink receives labels committed to customer databases and raw proto blocks they were
+// decoded from, so they could be published to destinations alongside customer databases.
+type LabelsSink interface {
+	PublishLabels(customerID string, events []indexer.EventLabel, transactions []indexer.TransactionLabel, fromBlock, toBlock uint64) error
+	PublishRawBlocks(rawData []bytes.Buffer, fromBlock, toBlock uint64) error
+	Close() error
+}
+
+// publishWithRetries calls publish up to SinkPublishAttempts times.
+func publishWithRetries(publish func() error) error {
+	var err error
+	for attempt := 1; attempt <= SinkPublishAttempts; attempt++ {
+		if err = publish(); err == nil {
+			return nil
+		}
+		if attempt < SinkPublishAttempts {
+			time.Sleep(SinkRetryWait)
+		}
+	}
+	return err
+}
+
+// processSinks publishes raw blocks of batch and labels of each customer written to at least
+// one of its instances. Failed publishes are retried and then fail the cycle, failed cycle is
+// synced again, so sinks receive its batch at least once.
+func (d *Synchronizer) processSinks(items []CustomerLabels, results []FanOutResult, rawDataList []bytes.Buffer, fromBlock, toBlock uint64) error {
+	var errs []error
+	for _, sink := range d.Sinks {
+		if err := publishWithRetries(func() error { return sink.PublishRawBlocks(rawDataList, fromBlock, toBlock) }); err != nil {
+			logging.Chain(d.blockchain).Error("Failed to publish raw blocks", logging.FromBlockKey, fromBlock, logging.ToBlockKey, toBlock, logging.ErrorKey, err)
+			errs = append(errs, fmt.Errorf("raw blocks: %w", err))
+		}
+
+		processed := make(map[string]bool)
+		for i, item := range items {
+			if results[i].Err != nil || results[i].Buffered || processed[item.CustomerID] {
+				continue
+			}
+			processed[item.CustomerID] = true
+
+			if err := publishWithRetries(func() error {
+				return sink.PublishLabels(item.CustomerID, item.Events, item.Transactions, fromBlock, toBlock)
+			}); err != nil {
+				logging.Customer(d.blockchain, item.CustomerID).Error("Failed to publish labels", logging.FromBlockKey, fromBlock, logging.ToBlockKey, toBlock, logging.ErrorKey, err)
+				errs = append(errs, fmt.Errorf("labels of customer %s: %w", item.CustomerID, err))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("errors publishing blocks %d-%d to sinks: %w", fromBlock, toBlock, errors.Join(errs...))
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -15,15 +16,59 @@ import (
 	"sync"
 	"time"
 
+	"github.com/G7DAO/seer/alerts"
 	seer_blockchain "github.com/G7DAO/seer/blockchain"
+	seer_common "github.com/G7DAO/seer/blockchain/common"
+	"github.com/G7DAO/seer/cdc"
 	"github.com/G7DAO/seer/crawler"
+	"github.com/G7DAO/seer/health"
 	"github.com/G7DAO/seer/indexer"
+	"github.com/G7DAO/seer/logging"
+	"github.com/G7DAO/seer/metrics"
 	"github.com/G7DAO/seer/storage"
+	"github.com/G7DAO/seer/transform"
+	"github.com/G7DAO/seer/webhooks"
 )
 
 type Synchronizer struct {
-	Client          seer_blockchain.BlockchainClient
+	Client          seer_blockchain.ChainClient
 	StorageInstance storage.Storer
+	Store           indexer.IndexStore
+	AlertsEngine    *alerts.Engine
+	CDC             *cdc.Emitter
+	Webhooks        *webhooks.Dispatcher
+
+	// Transforms change label_data of decoded labels before they are written, nil if labels
+	// are written as decoded
+	Transforms *transform.Hooks
+
+	// Sinks receive labels and raw blocks in addition to customer databases
+	Sinks []LabelsSink
+
+	// ProgressEvents makes historical sync append progress events instead of updating abi_jobs
+	ProgressEvents bool
+
+	// Leases divides customers of chain between replicas, nil if replica syncs all customers
+	Leases *CustomerLeases
+
+	// FinalizedOnly makes sync cycle emit labels only of batches below safe head of chain
+	// defined by Finality
+	FinalizedOnly bool
+	Finality      seer_common.Finality
+
+	// Proxies merges ABIs of implementations into ABIs of proxies, nil if proxies are not resolved
+	Proxies *ProxyAbis
+
+	// AbiJobs keeps ABIs of jobs between batches and reloads them by interval, nil if jobs are
+	// read with each batch
+	AbiJobs *AbiJobsReloader
+
+	// Outbox buffers labels of unreachable customer databases, nil if failed writes fail the cycle
+	Outbox *LabelsOutbox
+
+	// CreateMissingTables makes writes create labels and transactions tables missing in
+	// customer databases
+	CreateMissingTables bool
 
 	blockchain         string
 	startBlock         uint64
@@ -32,12 +77,28 @@ type Synchronizer struct {
 	baseDir            string
 	basePath           string
 	threads            int
+	writeThreads       int
 	minBlocksToSync    int
 	addRawTransactions bool
+
+	crawlSchedule *seer_common.CrawlSchedule
+
+	leasedCustomers string
+}
+
+// SetCrawlSchedule limits historical sync to crawl windows, chunks are started only inside of
+// window and RPC requests of client are counted against window budget.
+func (d *Synchronizer) SetCrawlSchedule(schedule *seer_common.CrawlSchedule) {
+	d.crawlSchedule = schedule
+	if scheduledClient, ok := d.Client.(seer_common.ScheduledClient); ok {
+		scheduledClient.SetCrawlSchedule(schedule)
+	} else {
+		log.Printf("Client of %s does not support crawl windows budget, only start of chunks is scheduled", d.blockchain)
+	}
 }
 
 // NewSynchronizer creates a new synchronizer instance with the given blockchain handler.
-func NewSynchronizer(blockchain, rpcUrl, baseDir string, startBlock, endBlock, batchSize uint64, timeout int, threads int, minBlocksToSync int, addRawTransactions bool) (*Synchronizer, error) {
+func NewSynchronizer(blockchain, rpcUrl, baseDir string, startBlock, endBlock, batchSize uint64, timeout int, threads, writeThreads int, minBlocksToSync int, addRawTransactions bool) (*Synchronizer, error) {
 	var synchronizer Synchronizer
 
 	basePath := filepath.Join(baseDir, crawler.SeerCrawlerStoragePrefix, "data", blockchain)
@@ -59,9 +120,14 @@ func NewSynchronizer(blockchain, rpcUrl, baseDir string, startBlock, endBlock, b
 		threads = 1
 	}
 
+	if writeThreads <= 0 {
+		writeThreads = threads
+	}
+
 	synchronizer = Synchronizer{
 		Client:          client,
 		StorageInstance: storageInstance,
+		Store:           indexer.DBConnection,
 
 		blockchain:         blockchain,
 		startBlock:         startBlock,
@@ -70,6 +136,7 @@ func NewSynchronizer(blockchain, rpcUrl, baseDir string, startBlock, endBlock, b
 		baseDir:            baseDir,
 		basePath:           basePath,
 		threads:            threads,
+		writeThreads:       writeThreads,
 		minBlocksToSync:    minBlocksToSync,
 		addRawTransactions: addRawTransactions,
 	}
@@ -77,6 +144,24 @@ func NewSynchronizer(blockchain, rpcUrl, baseDir string, startBlock, endBlock, b
 	return &synchronizer, nil
 }
 
+// EnableLabelsOutbox buffers labels of unreachable customer databases in storage with outbox
+// base path of chain, apart from batches of crawler.
+func (d *Synchronizer) EnableLabelsOutbox() error {
+	basePath := filepath.Join(d.baseDir, crawler.SeerCrawlerStoragePrefix, "outbox", d.blockchain)
+	storageInstance, err := storage.NewStorage(storage.SeerCrawlerStorageType, basePath)
+	if err != nil {
+		return fmt.Errorf("failed to create outbox storage: %w", err)
+	}
+
+	outbox, err := NewLabelsOutbox(storageInstance, indexer.DBConnection, d.blockchain, basePath)
+	if err != nil {
+		return err
+	}
+	d.Outbox = outbox
+
+	return nil
+}
+
 // Read index storage
 
 // -------------------------------------------------------------------------------------------------------------------------------
@@ -212,7 +297,7 @@ func GetCustomerInstances(uuid string) ([]int, error) {
 }
 
 func (d *Synchronizer) ReadAbiJobsFromDatabase(blockchain string) ([]indexer.AbiJob, error) {
-	abiJobs, err := indexer.DBConnection.ReadABIJobs(blockchain)
+	abiJobs, err := d.Store.ReadABIJobs(blockchain)
 	if err != nil {
 		return nil, err
 	}
@@ -225,6 +310,11 @@ func ensurePortInConnectionString(connStr string) (string, error) {
 		return "", err
 	}
 
+	// ClickHouse ports depend on secure parameter and are set on connect
+	if indexer.IsClickHouseURI(connStr) {
+		return connStr, nil
+	}
+
 	host := parsedURL.Host
 	if !strings.Contains(host, ":") {
 		host = host + ":5432" // Add the default PostgreSQL port
@@ -237,13 +327,14 @@ func ensurePortInConnectionString(connStr string) (string, error) {
 type CustomerDBConnection struct {
 	Uri string
 
-	Pgx *indexer.PostgreSQLpgx
+	// Writer is PostgreSQL or ClickHouse database of customer instance
+	Writer indexer.CustomerLabelsDB
 }
 
 func (d *Synchronizer) CloseIndexerDBConnections(customerDBConnections map[string]map[int]CustomerDBConnection) {
 	for _, customer := range customerDBConnections {
 		for _, instance := range customer {
-			instance.Pgx.Close()
+			instance.Writer.Close()
 		}
 	}
 }
@@ -252,15 +343,7 @@ func CustomersLatestBlocks(customerDBConnections map[string]map[int]CustomerDBCo
 	latestBlocks := make(map[string]uint64)
 	for id, customerInstances := range customerDBConnections {
 		for _, instanceConnection := range customerInstances {
-			pool := instanceConnection.Pgx.GetPool()
-			conn, err := pool.Acquire(context.Background())
-			if err != nil {
-				log.Println("Error acquiring pool connection: ", err)
-				return nil, err
-			}
-			defer conn.Release()
-
-			latestLabelBlock, err := instanceConnection.Pgx.ReadLastLabel(blockchain)
+			latestLabelBlock, err := instanceConnection.Writer.ReadLastLabel(blockchain)
 			if err != nil {
 				log.Println("Error reading latest block: ", err)
 				return nil, err
@@ -274,7 +357,7 @@ func CustomersLatestBlocks(customerDBConnections map[string]map[int]CustomerDBCo
 			} else {
 				latestBlocks[id] = latestLabelBlock
 			}
-			log.Printf("Latest block for customer %s is: %d\n", id, latestLabelBlock)
+			logging.Customer(blockchain, id).Info("Found latest labeled block of customer", logging.BlockKey, latestLabelBlock)
 
 		}
 	}
@@ -345,8 +428,7 @@ func (d *Synchronizer) getCustomers(customerDbUriFlag string, customerIds []stri
 		instances, instancesErr := GetCustomerInstances(id)
 
 		if instancesErr != nil {
-			log.Printf("Error getting customer instances for customer %s, err: %v", id, instancesErr)
-			log.Println("Skipping customer", id)
+			logging.Customer(d.blockchain, id).Warn("Unable to get customer instances, customer is skipped", logging.ErrorKey, instancesErr)
 			continue
 		}
 
@@ -355,25 +437,48 @@ func (d *Synchronizer) getCustomers(customerDbUriFlag string, customerIds []stri
 			if customerDbUriFlag == "" {
 				connectionString, dbConnErr = GetDBConnection(id, instance, "seer")
 				if dbConnErr != nil {
-					log.Printf("Unable to get connection database URI for customer %s, err: %v", id, dbConnErr)
+					logging.Customer(d.blockchain, id).Warn("Unable to get connection database URI", logging.ErrorKey, dbConnErr)
 					continue
 				}
 			} else {
 				connectionString = customerDbUriFlag
 			}
 
-			pgx, pgxErr := indexer.NewPostgreSQLpgxWithCustomURI(connectionString)
-			if pgxErr != nil {
-				log.Printf("Error creating RDS connection for customer %s, err: %v", id, pgxErr)
+			writer, writerErr := indexer.NewCustomerLabelsDB(connectionString, id)
+			if writerErr != nil {
+				logging.Customer(d.blockchain, id).Warn("Unable to connect to customer database", logging.ErrorKey, writerErr)
 				continue
 			}
 
+			switch db := writer.(type) {
+			case *indexer.PostgreSQLpgx:
+				if d.CreateMissingTables {
+					db.EnableCreateMissingTables()
+				}
+
+				// Databases of devnets are not migrated, labels table is created on first sync
+				if d.blockchain == indexer.LocalChain {
+					if ensureErr := db.EnsureLabelsTable(d.blockchain); ensureErr != nil {
+						logging.Customer(d.blockchain, id).Warn("Unable to create labels table", logging.ErrorKey, ensureErr)
+						db.Close()
+						continue
+					}
+				}
+			case *indexer.ClickHouse:
+				// ClickHouse databases are not migrated, tables are created by seer
+				if ensureErr := db.EnsureTables(d.blockchain); ensureErr != nil {
+					logging.Customer(d.blockchain, id).Warn("Unable to create ClickHouse tables", logging.ErrorKey, ensureErr)
+					db.Close()
+					continue
+				}
+			}
+
 			if _, ok := customerDBConnections[id]; !ok {
 				customerDBConnections[id] = make(map[int]CustomerDBConnection)
 			}
 			customerDBConnections[id][instance] = CustomerDBConnection{
-				Uri: connectionString,
-				Pgx: pgx,
+				Uri:    connectionString,
+				Writer: writer,
 			}
 		}
 		finalCustomerIds = append(finalCustomerIds, id)
@@ -384,13 +489,24 @@ func (d *Synchronizer) getCustomers(customerDbUriFlag string, customerIds []stri
 	return customerDBConnections, finalCustomerIds, nil
 }
 
-func (d *Synchronizer) Start(customerDbUriFlag string, cycleTickerWaitTime int) {
+// Start runs synchronization cycles until end block is reached or ctx is cancelled. Cycle
+// in progress stops after its current batch is written to customer databases, leases of
+// customers are released before return.
+func (d *Synchronizer) Start(ctx context.Context, customerDbUriFlag string, cycleTickerWaitTime int) {
 	var isEnd bool
 
 	ticker := time.NewTicker(time.Duration(cycleTickerWaitTime) * time.Second)
 	defer ticker.Stop()
 
-	isEnd, err := d.SyncCycle(customerDbUriFlag)
+	if d.Leases != nil {
+		heartbeatCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		defer d.Leases.Release()
+
+		go d.Leases.Heartbeat(heartbeatCtx)
+	}
+
+	isEnd, err := d.SyncCycle(ctx, customerDbUriFlag)
 	if err != nil {
 		log.Println("Error during initial synchronization cycle:", err)
 	}
@@ -401,21 +517,69 @@ func (d *Synchronizer) Start(customerDbUriFlag string, cycleTickerWaitTime int)
 	for {
 		select {
 		case <-ticker.C:
-			isEnd, err := d.SyncCycle(customerDbUriFlag)
+			isEnd, err := d.SyncCycle(ctx, customerDbUriFlag)
 			if err != nil {
-				log.Fatalf("Error during synchronization cycle:", err)
+				log.Fatalf("Error during synchronization cycle: %v", err)
 			}
 			if isEnd {
 				return
 			}
+		case <-ctx.Done():
+			log.Printf("Synchronizer of %s is stopped at block %d", d.blockchain, d.startBlock)
+			return
 		}
 	}
 }
 
-func (d *Synchronizer) SyncCycle(customerDbUriFlag string) (bool, error) {
+// leasedCustomerIds returns customers with ABI jobs at chain leased by this replica. When set
+// of leased customers changes, start block is looked up again from their databases.
+func (d *Synchronizer) leasedCustomerIds() ([]string, error) {
+	abiJobs, err := d.ReadAbiJobsFromDatabase(d.blockchain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ABI jobs: %w", err)
+	}
+
+	customerIdSet := make(map[string]bool)
+	var customerIds []string
+	for _, job := range abiJobs {
+		if !customerIdSet[job.CustomerID] {
+			customerIdSet[job.CustomerID] = true
+			customerIds = append(customerIds, job.CustomerID)
+		}
+	}
+
+	leasedIds, err := d.Leases.Assign(customerIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign customer leases: %w", err)
+	}
+
+	leasedCustomers := strings.Join(leasedIds, ",")
+	if d.leasedCustomers != "" && leasedCustomers != d.leasedCustomers {
+		log.Printf("Leased customers changed from %s to %s, start block will be looked up again", d.leasedCustomers, leasedCustomers)
+		d.startBlock = 0
+	}
+	d.leasedCustomers = leasedCustomers
+
+	return leasedIds, nil
+}
+
+func (d *Synchronizer) SyncCycle(ctx context.Context, customerDbUriFlag string) (bool, error) {
 	var isEnd bool
 
-	customerDBConnections, customerIds, customersErr := d.getCustomers(customerDbUriFlag, nil)
+	var leasedIds []string
+	if d.Leases != nil {
+		var leaseErr error
+		leasedIds, leaseErr = d.leasedCustomerIds()
+		if leaseErr != nil {
+			return isEnd, leaseErr
+		}
+		if len(leasedIds) == 0 {
+			log.Printf("Replica %s has no leased customers at %s, waiting next iteration..", d.Leases.Holder(), d.blockchain)
+			return isEnd, nil
+		}
+	}
+
+	customerDBConnections, customerIds, customersErr := d.getCustomers(customerDbUriFlag, leasedIds)
 	if customersErr != nil {
 		return isEnd, customersErr
 	}
@@ -423,6 +587,14 @@ func (d *Synchronizer) SyncCycle(customerDbUriFlag string) (bool, error) {
 	// close the indexer db connection
 	defer d.CloseIndexerDBConnections(customerDBConnections)
 
+	// Customers whose connections were requested in this cycle
+	connectedCustomers := make(map[string]bool)
+	for _, id := range customerIds {
+		connectedCustomers[id] = true
+	}
+
+	d.replayOutbox(customerDBConnections)
+
 	// Set start block if 0
 	if d.startBlock == 0 {
 		startBlock, startErr := d.StartBlockLookUp(customerDBConnections, d.blockchain, crawler.SeerDefaultBlockShift)
@@ -433,11 +605,23 @@ func (d *Synchronizer) SyncCycle(customerDbUriFlag string) (bool, error) {
 	}
 
 	// Get the latest block from the indexer db
-	indexedLatestBlock, idxLatestErr := indexer.DBConnection.GetLatestDBBlockNumber(d.blockchain, false)
+	indexedLatestBlock, idxLatestErr := d.Store.GetLatestDBBlockNumber(d.blockchain, false)
 	if idxLatestErr != nil {
 		return isEnd, idxLatestErr
 	}
 
+	var safeHead uint64
+	if d.FinalizedOnly {
+		var safeErr error
+		safeHead, safeErr = d.updateSafeHead()
+		if safeErr != nil {
+			return isEnd, safeErr
+		}
+		if indexedLatestBlock > safeHead {
+			indexedLatestBlock = safeHead
+		}
+	}
+
 	if d.startBlock > indexedLatestBlock && d.endBlock == 0 {
 		log.Printf("Value in startBlock %d greater then indexedLatestBlock %d, waiting next iteration..", d.startBlock, indexedLatestBlock)
 		return isEnd, nil
@@ -451,6 +635,12 @@ func (d *Synchronizer) SyncCycle(customerDbUriFlag string) (bool, error) {
 	//var noUpdatesFoundErr *indexer.NoUpdatesFoundError
 	var isCycleFinished bool
 	for {
+		// Batches are checkpointed by labels written to customer databases, so cycle could stop
+		// between them
+		if ctx.Err() != nil {
+			break
+		}
+
 		// Check if end block is reached or start block exceeds end block
 		if d.endBlock > 0 && d.startBlock >= d.endBlock {
 			isEnd = true
@@ -464,8 +654,9 @@ func (d *Synchronizer) SyncCycle(customerDbUriFlag string) (bool, error) {
 		}
 
 		// Read updates from the indexer db
-		// This function will return a list of customer updates 1 update is 1 customer
-		_, lastBlockOfChank, paths, updates, err := indexer.DBConnection.ReadUpdates(d.blockchain, d.startBlock, customerIds, d.minBlocksToSync)
+		// This function will return a list of customer updates 1 update is 1 customer,
+		// with leasing only jobs of leased customers are read
+		_, lastBlockOfChank, paths, updates, err := d.Store.ReadUpdates(d.blockchain, d.startBlock, leasedIds, d.minBlocksToSync)
 		if err != nil {
 			return isEnd, fmt.Errorf("error reading updates: %w", err)
 		}
@@ -475,6 +666,21 @@ func (d *Synchronizer) SyncCycle(customerDbUriFlag string) (bool, error) {
 			return isEnd, nil
 		}
 
+		if d.AbiJobs != nil {
+			updates = d.AbiJobs.Updates()
+			if d.Leases == nil {
+				d.connectNewCustomers(customerDbUriFlag, updates, customerDBConnections, connectedCustomers)
+			} else {
+				updates = filterCustomerUpdates(updates, leasedIds)
+			}
+		}
+
+		// Batch is decoded as a whole, so it waits until its last block is final
+		if d.FinalizedOnly && lastBlockOfChank > safeHead {
+			log.Printf("Batch ends at block %d above safe head %d with finality %s, waiting next iteration..", lastBlockOfChank, safeHead, d.Finality)
+			return isEnd, nil
+		}
+
 		if crawler.SEER_CRAWLER_DEBUG {
 			log.Printf("Read batch key: %s", paths)
 		}
@@ -487,55 +693,84 @@ func (d *Synchronizer) SyncCycle(customerDbUriFlag string) (bool, error) {
 		}
 
 		log.Printf("Read %d users updates from the indexer db in range of blocks %d-%d\n", len(updates), d.startBlock, lastBlockOfChank)
-		// Process customer updates in parallel
-		var wg sync.WaitGroup
-
-		// count the number of goroutines that will be running
-		var totalGoroutines int
-		for _, update := range updates {
-			totalGoroutines += len(customerDBConnections[update.CustomerID])
+		if processErr := d.processCustomerUpdates(updates, rawData, customerDBConnections, d.startBlock, lastBlockOfChank); processErr != nil {
+			return isEnd, processErr
 		}
 
-		sem := make(chan struct{}, d.threads)        // Semaphore to control concurrency
-		errChan := make(chan error, totalGoroutines) // Channel to collect errors from goroutines
+		d.startBlock = lastBlockOfChank + 1
+		metrics.SetBlocksBehindHead(d.blockchain, "synchronizer", indexedLatestBlock, lastBlockOfChank)
+		health.SetProcessedBlock(lastBlockOfChank)
 
-		var errs []error
-		for _, update := range updates {
-			for instanceId := range customerDBConnections[update.CustomerID] {
-				wg.Add(1)
-				go d.processProtoCustomerUpdate(update, rawData, customerDBConnections, instanceId, sem, errChan, &wg, d.addRawTransactions)
-			}
+		if isCycleFinished {
+			break
 		}
+		// close the indexer db connection
+	}
 
-		wg.Wait()
-		close(errChan) // Close the channel to signal that all goroutines have finished
+	return isEnd, nil
+}
 
-		// Check if there were any errors
-		for err := range errChan {
-			errs = append(errs, err)
+// filterCustomerUpdates returns updates of customers from customerIds.
+func filterCustomerUpdates(updates []indexer.CustomerUpdates, customerIds []string) []indexer.CustomerUpdates {
+	customerIdSet := make(map[string]bool, len(customerIds))
+	for _, id := range customerIds {
+		customerIdSet[id] = true
+	}
+
+	var filtered []indexer.CustomerUpdates
+	for _, update := range updates {
+		if customerIdSet[update.CustomerID] {
+			filtered = append(filtered, update)
 		}
+	}
 
-		if len(errs) > 0 {
-			var errMsg string
-			for _, e := range errs {
-				errMsg += e.Error() + "\n"
-			}
+	return filtered
+}
 
-			return isEnd, fmt.Errorf("errors processing customer updates:\n%s", errMsg)
+// connectNewCustomers connects databases of customers whose jobs were added during cycle, so
+// their labels are written from the next batch instead of the next cycle. Each customer is
+// tried once per cycle.
+func (d *Synchronizer) connectNewCustomers(customerDbUriFlag string, updates []indexer.CustomerUpdates, customerDBConnections map[string]map[int]CustomerDBConnection, connectedCustomers map[string]bool) {
+	var newIds []string
+	for _, update := range updates {
+		if !connectedCustomers[update.CustomerID] {
+			connectedCustomers[update.CustomerID] = true
+			newIds = append(newIds, update.CustomerID)
 		}
+	}
+	if len(newIds) == 0 {
+		return
+	}
 
-		d.startBlock = lastBlockOfChank + 1
+	newConnections, _, err := d.getCustomers(customerDbUriFlag, newIds)
+	if err != nil {
+		log.Printf("Failed to connect databases of new customers %v: %v", newIds, err)
+		return
+	}
+	for id, instances := range newConnections {
+		customerDBConnections[id] = instances
+	}
+}
 
-		if isCycleFinished {
-			break
-		}
-		// close the indexer db connection
+// updateSafeHead fetches latest block of chain and returns the highest block which satisfies
+// finality of synchronizer.
+func (d *Synchronizer) updateSafeHead() (uint64, error) {
+	latestBlockNumber, latestErr := d.Client.GetLatestBlockNumber()
+	if latestErr != nil {
+		return 0, fmt.Errorf("error getting latest block number: %w", latestErr)
 	}
+	crawler.CurrentBlockchainState.RaiseLatestBlockNumber(latestBlockNumber)
 
-	return isEnd, nil
+	safeBlockNumber, safeErr := seer_common.SafeHead(context.Background(), d.Client, d.Finality, latestBlockNumber)
+	if safeErr != nil {
+		return 0, fmt.Errorf("error getting safe head with finality %s: %w", d.Finality, safeErr)
+	}
+	crawler.CurrentBlockchainState.RaiseSafeBlockNumber(safeBlockNumber)
+
+	return safeBlockNumber.Uint64(), nil
 }
 
-func (d *Synchronizer) HistoricalSyncRef(customerDbUriFlag string, addresses []string, customerIds []string, batchSize uint64, autoJobs bool) error {
+func (d *Synchronizer) HistoricalSyncRef(ctx context.Context, customerDbUriFlag string, addresses []string, customerIds []string, batchSize uint64, autoJobs bool) error {
 	var isCycleFinished bool
 	var updateDeadline time.Time
 	var initialStartBlock uint64
@@ -546,7 +781,7 @@ func (d *Synchronizer) HistoricalSyncRef(customerDbUriFlag string, addresses []s
 	// Initialize start block if 0
 	if d.startBlock == 0 {
 		// Get the latest block from the indexer db
-		indexedLatestBlock, err := indexer.DBConnection.GetLatestDBBlockNumber(d.blockchain, false)
+		indexedLatestBlock, err := d.Store.GetLatestDBBlockNumber(d.blockchain, false)
 		if err != nil {
 			return fmt.Errorf("error getting latest block number: %w", err)
 		}
@@ -554,7 +789,7 @@ func (d *Synchronizer) HistoricalSyncRef(customerDbUriFlag string, addresses []s
 		fmt.Printf("Start block is %d\n", d.startBlock)
 	}
 
-	earlyIndexedBlock, err := indexer.DBConnection.GetLatestDBBlockNumber(d.blockchain, true)
+	earlyIndexedBlock, err := d.Store.GetLatestDBBlockNumber(d.blockchain, true)
 
 	if err != nil {
 		return fmt.Errorf("error getting early indexer block: %w", err)
@@ -562,13 +797,18 @@ func (d *Synchronizer) HistoricalSyncRef(customerDbUriFlag string, addresses []s
 
 	// Automatically update ABI jobs as active if auto mode is enabled
 	if autoJobs {
-		if err := indexer.DBConnection.UpdateAbiJobsStatus(d.blockchain); err != nil {
+		// Jobs without deployment block are not selected for historical sync
+		if _, err := seer_blockchain.FillDeploymentBlocks(d.Client, d.Store, d.blockchain, d.threads); err != nil {
+			log.Printf("Failed to fill deployment blocks of jobs, they are synced on next run: %v", err)
+		}
+
+		if err := d.Store.UpdateAbiJobsStatus(d.blockchain); err != nil {
 			return fmt.Errorf("error updating ABI: %w", err)
 		}
 	}
 
 	// Retrieve customer updates and deployment blocks
-	abiJobs, selectJobsErr := indexer.DBConnection.SelectAbiJobs(d.blockchain, addresses, customerIds, autoJobs, true, []string{"function", "event"})
+	abiJobs, selectJobsErr := d.Store.SelectAbiJobs(d.blockchain, addresses, customerIds, autoJobs, true, []string{"function", "event"})
 	if selectJobsErr != nil {
 		return fmt.Errorf("error selecting ABI jobs: %w", selectJobsErr)
 	}
@@ -631,7 +871,7 @@ func (d *Synchronizer) HistoricalSyncRef(customerDbUriFlag string, addresses []s
 					log.Printf("Finished crawling for address %s at block %d\n", address, abisInfo.DeployedBlockNumber)
 
 					// update the status of the address for the customer to done
-					err := indexer.DBConnection.UpdateAbisAsDone(abisInfo.IDs)
+					err := d.Store.UpdateAbisAsDone(abisInfo.IDs)
 					if err != nil {
 						return err
 					}
@@ -642,28 +882,23 @@ func (d *Synchronizer) HistoricalSyncRef(customerDbUriFlag string, addresses []s
 
 				// Check if the deadline for the update has passed
 				if updateDeadline.Add(1 * time.Minute).Before(time.Now()) {
-					for address, abisInfo := range addressesAbisInfo {
-						ids := abisInfo.IDs
-
-						// calculate progress as a percentage between 0 and 100
-
-						progress := 100 - int(100*(d.startBlock-abisInfo.DeployedBlockNumber)/(d.startBlock-initialStartBlock))
-
-						err := indexer.DBConnection.UpdateAbisProgress(ids, progress)
-						if err != nil {
-							continue
-						}
-
-						log.Printf("Updated progress for address %s to %d%%\n", address, progress)
-
-					}
+					d.saveAbisProgress(addressesAbisInfo, initialStartBlock)
 					updateDeadline = time.Now()
-
 				}
 			}
 
 		}
 
+		// Progress of jobs is the checkpoint of historical sync, it is saved before exit and
+		// next run continues from it
+		if ctx.Err() != nil {
+			if autoJobs {
+				d.saveAbisProgress(addressesAbisInfo, initialStartBlock)
+			}
+			log.Printf("Historical sync of %s is stopped at block %d", d.blockchain, d.startBlock)
+			return nil
+		}
+
 		if len(addressesAbisInfo) == 0 {
 			log.Println("No addresses to crawl")
 			break
@@ -674,12 +909,19 @@ func (d *Synchronizer) HistoricalSyncRef(customerDbUriFlag string, addresses []s
 			break
 		}
 
+		if scheduleErr := d.crawlSchedule.Wait(ctx); scheduleErr != nil {
+			if ctx.Err() != nil {
+				continue
+			}
+			return scheduleErr
+		}
+
 		// Determine the processing strategy (RPC or storage)
 		var paths []string
-		var firstBlockOfChunk uint64
+		var firstBlockOfChunk, lastBlockOfChunk uint64
 
 		for {
-			paths, firstBlockOfChunk, _, err = indexer.DBConnection.RetrievePathsAndBlockBounds(d.blockchain, d.startBlock, d.minBlocksToSync)
+			paths, firstBlockOfChunk, lastBlockOfChunk, err = d.Store.RetrievePathsAndBlockBounds(d.blockchain, d.startBlock, d.minBlocksToSync)
 			if err != nil {
 				return fmt.Errorf("error finding batch path: %w", err)
 			}
@@ -704,43 +946,8 @@ func (d *Synchronizer) HistoricalSyncRef(customerDbUriFlag string, addresses []s
 
 		log.Printf("Processing %d customer updates for block range %d-%d", len(customerUpdates), d.startBlock, d.endBlock)
 
-		// Process customer updates in parallel
-		var wg sync.WaitGroup
-
-		// count the number of goroutines that will be running
-		var totalGoroutines int
-		for _, update := range customerUpdates {
-			totalGoroutines += len(customerDBConnections[update.CustomerID])
-		}
-
-		sem := make(chan struct{}, d.threads)        // Semaphore to control concurrency
-		errChan := make(chan error, totalGoroutines) // Channel to collect errors from goroutines
-
-		var errs []error
-
-		for _, update := range customerUpdates {
-
-			for instanceId := range customerDBConnections[update.CustomerID] {
-				wg.Add(1)
-				go d.processProtoCustomerUpdate(update, rawData, customerDBConnections, instanceId, sem, errChan, &wg, d.addRawTransactions)
-			}
-
-		}
-
-		wg.Wait()
-		close(errChan) // Close the channel to signal that all goroutines have finished
-
-		// Check if there were any errors
-		for err := range errChan {
-			errs = append(errs, err)
-		}
-
-		if len(errs) > 0 {
-			var errMsg string
-			for _, e := range errs {
-				errMsg += e.Error() + "\n"
-			}
-			return fmt.Errorf("errors processing customer updates:\n%s", errMsg)
+		if processErr := d.processCustomerUpdates(customerUpdates, rawData, customerDBConnections, firstBlockOfChunk, lastBlockOfChunk); processErr != nil {
+			return processErr
 		}
 
 		d.startBlock = d.endBlock - 1
@@ -754,7 +961,7 @@ func (d *Synchronizer) HistoricalSyncRef(customerDbUriFlag string, addresses []s
 					log.Printf("Finished crawling for address %s at block %d\n", address, abisInfo.DeployedBlockNumber)
 
 					// update the status of the address for the customer to done
-					err := indexer.DBConnection.UpdateAbisAsDone(abisInfo.IDs)
+					err := d.Store.UpdateAbisAsDone(abisInfo.IDs)
 					if err != nil {
 						return err
 					}
@@ -768,72 +975,249 @@ func (d *Synchronizer) HistoricalSyncRef(customerDbUriFlag string, addresses []s
 	return nil
 }
 
-func (d *Synchronizer) processProtoCustomerUpdate(
-	update indexer.CustomerUpdates,
-	rawDataList []bytes.Buffer,
-	customerDBConnections map[string]map[int]CustomerDBConnection,
-	id int,
-	sem chan struct{},
-	errChan chan error,
-	wg *sync.WaitGroup,
-	addRawTransactions bool,
-) {
-	// Decode input raw proto data using ABIs
-	// Write decoded data to the user Database
-
-	defer func() {
-		if r := recover(); r != nil {
-			errChan <- fmt.Errorf("panic in goroutine for customer %s, instance %d: %v", update.CustomerID, id, r)
-		}
-		wg.Done()
-	}()
-
-	sem <- struct{}{}        // Acquire semaphore
-	defer func() { <-sem }() // Release semaphore
-
-	customer, exists := customerDBConnections[update.CustomerID][id]
-	if !exists {
-		errChan <- fmt.Errorf("no DB connection for customer %s", update.CustomerID)
-		return
+// saveAbisProgress saves progress of ABI jobs of addresses which are still synced, as events
+// if progress events are enabled or directly in jobs otherwise.
+func (d *Synchronizer) saveAbisProgress(addressesAbisInfo map[string]indexer.AbiJobsDeployInfo, initialStartBlock uint64) {
+	var progressEvents []indexer.AbiJobProgress
+	for address, abisInfo := range addressesAbisInfo {
+		ids := abisInfo.IDs
+
+		// calculate progress as a percentage between 0 and 100
+
+		progress := 100 - int(100*(d.startBlock-abisInfo.DeployedBlockNumber)/(d.startBlock-initialStartBlock))
+
+		if d.ProgressEvents {
+			for _, id := range ids {
+				progressEvents = append(progressEvents, indexer.AbiJobProgress{ID: id, Progress: progress})
+			}
+			continue
+		}
+
+		err := d.Store.UpdateAbisProgress(ids, progress)
+		if err != nil {
+			continue
+		}
+
+		log.Printf("Updated progress for address %s to %d%%\n", address, progress)
+
 	}
 
-	conn, err := customer.Pgx.GetPool().Acquire(context.Background())
-	if err != nil {
-		errChan <- fmt.Errorf("error acquiring connection for customer %s: %w", update.CustomerID, err)
+	if len(progressEvents) > 0 {
+		if err := d.Store.AppendAbisProgress(progressEvents); err != nil {
+			log.Printf("Failed to append progress events of %d abi jobs: %v", len(progressEvents), err)
+		} else {
+			log.Printf("Appended progress events of %d abi jobs\n", len(progressEvents))
+		}
+	}
+}
+
+// processCustomerUpdates decodes raw data of blocks range for each customer update in parallel
+// and then writes labels to all customer instances with fan-out writer.
+func (d *Synchronizer) processCustomerUpdates(updates []indexer.CustomerUpdates, rawDataList []bytes.Buffer, customerDBConnections map[string]map[int]CustomerDBConnection, fromBlock, toBlock uint64) error {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	sem := make(chan struct{}, d.threads)     // Semaphore to control concurrency
+	errChan := make(chan error, len(updates)) // Channel to collect errors from goroutines
+
+	var items []CustomerLabels
+	for _, update := range updates {
+		if len(customerDBConnections[update.CustomerID]) == 0 {
+			logging.Customer(d.blockchain, update.CustomerID).Warn("No database connection of customer, skipping")
+			continue
+		}
+
+		wg.Add(1)
+		go func(update indexer.CustomerUpdates) {
+			defer func() {
+				if r := recover(); r != nil {
+					errChan <- fmt.Errorf("panic in goroutine for customer %s: %v", update.CustomerID, r)
+				}
+				wg.Done()
+			}()
+
+			sem <- struct{}{}        // Acquire semaphore
+			defer func() { <-sem }() // Release semaphore
+
+			if d.Proxies != nil {
+				merged, mergeErr := d.Proxies.Merge(update, fromBlock, toBlock)
+				if mergeErr != nil {
+					errChan <- mergeErr
+					return
+				}
+				update = merged
+			}
+
+			customerItem, decodeErr := d.decodeCustomerUpdate(update, rawDataList)
+			if decodeErr != nil {
+				errChan <- decodeErr
+				return
+			}
+
+			mu.Lock()
+			for instanceId, connection := range customerDBConnections[update.CustomerID] {
+				item := customerItem
+				item.InstanceID = instanceId
+				item.Connection = connection
+				items = append(items, item)
+			}
+			mu.Unlock()
+		}(update)
+	}
+
+	wg.Wait()
+	close(errChan) // Close the channel to signal that all goroutines have finished
+
+	var errMsg string
+	for err := range errChan {
+		errMsg += err.Error() + "\n"
+	}
+	if errMsg != "" {
+		return fmt.Errorf("errors processing customer updates:\n%s", errMsg)
+	}
+
+	writer := NewFanOutWriter(d.blockchain, d.writeThreads, 3, 1*time.Second)
+	results := writer.Write(items)
+
+	if d.Outbox != nil {
+		d.bufferFailedWrites(items, results, fromBlock, toBlock)
+	}
+
+	if crawler.SEER_CRAWLER_DEBUG {
+		for _, result := range results {
+			logging.Customer(d.blockchain, result.CustomerID).Info("Labels written", "instance", result.InstanceID, "duration", result.Duration.String(), "attempts", result.Attempts)
+		}
+	}
+
+	for i, item := range items {
+		if results[i].Err != nil || results[i].Buffered {
+			continue
+		}
+		metrics.LabelsWritten.WithLabelValues(d.blockchain, item.CustomerID, "event").Add(float64(len(item.Events)))
+		metrics.LabelsWritten.WithLabelValues(d.blockchain, item.CustomerID, "tx_call").Add(float64(len(item.Transactions)))
+		metrics.LabelsWritten.WithLabelValues(d.blockchain, item.CustomerID, "raw_transaction").Add(float64(len(item.RawTransactions)))
+	}
+
+	if d.AlertsEngine != nil {
+		d.processAlerts(items, results)
+	}
+
+	if d.CDC != nil {
+		d.processCDC(items, results)
+	}
+
+	if d.Webhooks != nil {
+		d.processWebhooks(items, results, fromBlock, toBlock)
+	}
+
+	var sinksErr error
+	if len(d.Sinks) > 0 {
+		sinksErr = d.processSinks(items, results, rawDataList, fromBlock, toBlock)
+	}
+
+	return errors.Join(FanOutErrors(results), sinksErr)
+}
+
+// bufferFailedWrites moves labels of instances which databases are unreachable to outbox and
+// clears errors of their results, so the cycle goes on. Other errors are left to fail it.
+func (d *Synchronizer) bufferFailedWrites(items []CustomerLabels, results []FanOutResult, fromBlock, toBlock uint64) {
+	for i, item := range items {
+		if results[i].Err == nil || !indexer.IsTransientWriteError(results[i].Err) {
+			continue
+		}
+
+		if err := d.Outbox.Buffer(item, fromBlock, toBlock, results[i].Err); err != nil {
+			logging.Customer(d.blockchain, item.CustomerID).Error("Failed to buffer labels to outbox", "instance", item.InstanceID, logging.ErrorKey, err)
+			continue
+		}
+		results[i].Err = nil
+		results[i].Buffered = true
+	}
+}
+
+// replayOutbox writes labels buffered in outbox to customer databases which are reachable
+// again, entries which still fail are left for next cycle.
+func (d *Synchronizer) replayOutbox(customerDBConnections map[string]map[int]CustomerDBConnection) {
+	if d.Outbox == nil {
 		return
 	}
-	defer conn.Release()
 
-	var listDecodedEvents []indexer.EventLabel
-	var listDecodedTransactions []indexer.TransactionLabel
-	var listDecodedRawTransactions []indexer.RawTransaction
-	for _, rawData := range rawDataList {
-		// Decode the raw data to transactions
-		decodedEvents, decodedTransactions, decodedRawTransactions, err := d.Client.DecodeProtoEntireBlockToLabels(&rawData, update.Abis, addRawTransactions, d.threads)
+	if _, err := d.Outbox.Replay(customerDBConnections); err != nil {
+		log.Printf("Outbox of %s is not drained: %v", d.blockchain, err)
+	}
+}
 
-		listDecodedEvents = append(listDecodedEvents, decodedEvents...)
-		listDecodedTransactions = append(listDecodedTransactions, decodedTransactions...)
-		listDecodedRawTransactions = append(listDecodedRawTransactions, decodedRawTransactions...)
-		if err != nil {
-			errChan <- fmt.Errorf("error decoding data for customer %s: %w", update.CustomerID, err)
-			return
+// processAlerts evaluates alerting rules once per customer for labels written to at least one
+// of customer instances.
+func (d *Synchronizer) processAlerts(items []CustomerLabels, results []FanOutResult) {
+	processed := make(map[string]bool)
+	for i, item := range items {
+		if results[i].Err != nil || results[i].Buffered || processed[item.CustomerID] {
+			continue
 		}
+		processed[item.CustomerID] = true
 
+		d.AlertsEngine.Process(item.CustomerID, item.Events, item.Transactions)
 	}
-	// make retrying
-	retry := 0
-	for {
-		err = customer.Pgx.WriteDataToCustomerDB(d.blockchain, listDecodedTransactions, listDecodedEvents, listDecodedRawTransactions)
+}
+
+// processCDC emits change events of labels written to each customer instance database,
+// failures of sink are logged and do not fail the cycle since labels are already committed.
+func (d *Synchronizer) processCDC(items []CustomerLabels, results []FanOutResult) {
+	for i, item := range items {
+		if results[i].Err != nil || results[i].Buffered {
+			continue
+		}
+
+		if err := d.CDC.EmitLabels(d.blockchain, item.CustomerID, item.InstanceID, item.Events, item.Transactions); err != nil {
+			logging.Customer(d.blockchain, item.CustomerID).Error("Failed to emit CDC events", "instance", item.InstanceID, logging.ErrorKey, err)
+		}
+	}
+}
+
+// processWebhooks posts labels to webhooks of customer once per customer, after labels are
+// committed to at least one of customer instances.
+func (d *Synchronizer) processWebhooks(items []CustomerLabels, results []FanOutResult, fromBlock, toBlock uint64) {
+	processed := make(map[string]bool)
+	for i, item := range items {
+		if results[i].Err != nil || results[i].Buffered || processed[item.CustomerID] {
+			continue
+		}
+		processed[item.CustomerID] = true
+
+		d.Webhooks.Process(item.CustomerID, item.Events, item.Transactions, fromBlock, toBlock)
+	}
+}
 
+// decodeCustomerUpdate decodes input raw proto data using ABIs of customer update.
+func (d *Synchronizer) decodeCustomerUpdate(update indexer.CustomerUpdates, rawDataList []bytes.Buffer) (CustomerLabels, error) {
+	customerLabels := CustomerLabels{CustomerID: update.CustomerID}
+
+	for _, rawData := range rawDataList {
+		// Decode the raw data to transactions
+		decodedEvents, decodedTransactions, decodedRawTransactions, err := d.Client.DecodeProtoEntireBlockToLabels(&rawData, update.Abis, d.addRawTransactions, d.threads)
 		if err != nil {
-			retry++
-			if retry > 3 {
-				errChan <- fmt.Errorf("error writing labels for customer %s: %w", update.CustomerID, err)
-				return
+			metrics.DecodeFailures.WithLabelValues(d.blockchain, "batch").Inc()
+			return customerLabels, fmt.Errorf("error decoding data for customer %s: %w", update.CustomerID, err)
+		}
+
+		for _, event := range decodedEvents {
+			if event.Label == indexer.SeerCrawlerRawLabel && !seer_common.IsSignatureDatabaseLabel(event.LabelData) {
+				metrics.DecodeFailures.WithLabelValues(d.blockchain, "event").Inc()
+			}
+		}
+		for _, transaction := range decodedTransactions {
+			if transaction.Label == indexer.SeerCrawlerRawLabel {
+				metrics.DecodeFailures.WithLabelValues(d.blockchain, "tx_call").Inc()
 			}
-			time.Sleep(1 * time.Second)
-		} else {
-			break
 		}
+
+		customerLabels.Events = append(customerLabels.Events, decodedEvents...)
+		customerLabels.Transactions = append(customerLabels.Transactions, decodedTransactions...)
+		customerLabels.RawTransactions = append(customerLabels.RawTransactions, decodedRawTransactions...)
 	}
+
+	d.Transforms.Apply(update.CustomerID, customerLabels.Events, customerLabels.Transactions)
+
+	return customerLabels, nil
 }
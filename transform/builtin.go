@@ -0,0 +1,212 @@
+package transform
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Fields of label_data are addressed by paths of keys joined with dots, e.g. args.value.
+
+func getPath(data map[string]interface{}, path string) (interface{}, bool) {
+	keys := strings.Split(path, ".")
+	current := data
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+
+	value, exists := current[keys[len(keys)-1]]
+	return value, exists
+}
+
+// setPath sets value of field, missing objects on path are created.
+func setPath(data map[string]interface{}, path string, value interface{}) error {
+	keys := strings.Split(path, ".")
+	current := data
+	for _, key := range keys[:len(keys)-1] {
+		next, exists := current[key]
+		if !exists {
+			next = make(map[string]interface{})
+			current[key] = next
+		}
+		nextMap, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("field %s of path %s is not an object", key, path)
+		}
+		current = nextMap
+	}
+
+	current[keys[len(keys)-1]] = value
+	return nil
+}
+
+func deletePath(data map[string]interface{}, path string) {
+	keys := strings.Split(path, ".")
+	current := data
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			return
+		}
+		current = next
+	}
+
+	delete(current, keys[len(keys)-1])
+}
+
+// decodeOptions parses options of rule, unknown fields are rejected to catch typos.
+func decodeOptions(options json.RawMessage, target interface{}) error {
+	if len(options) == 0 {
+		return fmt.Errorf("options are required")
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(options)))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(target)
+}
+
+// RenameOptions maps paths of fields to their new paths.
+type RenameOptions struct {
+	Fields map[string]string `json:"fields"`
+}
+
+func newRename(options json.RawMessage) (Func, error) {
+	var opts RenameOptions
+	if err := decodeOptions(options, &opts); err != nil {
+		return nil, fmt.Errorf("invalid options of rename: %w", err)
+	}
+	if len(opts.Fields) == 0 {
+		return nil, fmt.Errorf("rename requires fields")
+	}
+
+	return func(label Label, labelData map[string]interface{}) (map[string]interface{}, error) {
+		for from, to := range opts.Fields {
+			value, exists := getPath(labelData, from)
+			if !exists {
+				continue
+			}
+			deletePath(labelData, from)
+			if err := setPath(labelData, to, value); err != nil {
+				return nil, err
+			}
+		}
+		return labelData, nil
+	}, nil
+}
+
+// ScaleOptions divides integer amounts by 10^decimals. Decimals of token contracts are set in
+// DecimalsByAddress, Decimals is used for other addresses, labels of addresses without
+// decimals are not changed. Scaled amount is written as decimal string to field with Suffix
+// appended, empty suffix replaces amount.
+type ScaleOptions struct {
+	Fields            []string       `json:"fields"`
+	Decimals          *int           `json:"decimals,omitempty"`
+	DecimalsByAddress map[string]int `json:"decimals_by_address,omitempty"`
+	Suffix            *string        `json:"suffix,omitempty"`
+}
+
+const DefaultScaleSuffix = "_scaled"
+
+func newScale(options json.RawMessage) (Func, error) {
+	var opts ScaleOptions
+	if err := decodeOptions(options, &opts); err != nil {
+		return nil, fmt.Errorf("invalid options of scale: %w", err)
+	}
+	if len(opts.Fields) == 0 {
+		return nil, fmt.Errorf("scale requires fields")
+	}
+	if opts.Decimals == nil && len(opts.DecimalsByAddress) == 0 {
+		return nil, fmt.Errorf("scale requires decimals or decimals_by_address")
+	}
+
+	decimalsByAddress := make(map[string]int, len(opts.DecimalsByAddress))
+	for address, decimals := range opts.DecimalsByAddress {
+		decimalsByAddress[strings.ToLower(address)] = decimals
+	}
+	suffix := DefaultScaleSuffix
+	if opts.Suffix != nil {
+		suffix = *opts.Suffix
+	}
+
+	return func(label Label, labelData map[string]interface{}) (map[string]interface{}, error) {
+		decimals, exists := decimalsByAddress[strings.ToLower(label.Address)]
+		if !exists {
+			if opts.Decimals == nil {
+				return labelData, nil
+			}
+			decimals = *opts.Decimals
+		}
+
+		for _, field := range opts.Fields {
+			value, exists := getPath(labelData, field)
+			if !exists {
+				continue
+			}
+
+			scaled, err := scaleAmount(value, decimals)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", field, err)
+			}
+			if err := setPath(labelData, field+suffix, scaled); err != nil {
+				return nil, err
+			}
+		}
+		return labelData, nil
+	}, nil
+}
+
+// scaleAmount returns amount divided by 10^decimals as decimal string without trailing zeros.
+func scaleAmount(value interface{}, decimals int) (string, error) {
+	amount, ok := new(big.Rat).SetString(fmt.Sprint(value))
+	if !ok {
+		return "", fmt.Errorf("value %v is not a number", value)
+	}
+
+	divisor := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	scaled := amount.Quo(amount, divisor).FloatString(decimals)
+	if strings.Contains(scaled, ".") {
+		scaled = strings.TrimRight(strings.TrimRight(scaled, "0"), ".")
+	}
+	return scaled, nil
+}
+
+// SetOptions adds fields with static values, e.g. symbols of tokens. Values of ByAddress are
+// set for labels of address after Fields.
+type SetOptions struct {
+	Fields    map[string]interface{}            `json:"fields,omitempty"`
+	ByAddress map[string]map[string]interface{} `json:"by_address,omitempty"`
+}
+
+func newSet(options json.RawMessage) (Func, error) {
+	var opts SetOptions
+	if err := decodeOptions(options, &opts); err != nil {
+		return nil, fmt.Errorf("invalid options of set: %w", err)
+	}
+	if len(opts.Fields) == 0 && len(opts.ByAddress) == 0 {
+		return nil, fmt.Errorf("set requires fields or by_address")
+	}
+
+	byAddress := make(map[string]map[string]interface{}, len(opts.ByAddress))
+	for address, fields := range opts.ByAddress {
+		byAddress[strings.ToLower(address)] = fields
+	}
+
+	return func(label Label, labelData map[string]interface{}) (map[string]interface{}, error) {
+		for path, value := range opts.Fields {
+			if err := setPath(labelData, path, value); err != nil {
+				return nil, err
+			}
+		}
+		for path, value := range byAddress[strings.ToLower(label.Address)] {
+			if err := setPath(labelData, path, value); err != nil {
+				return nil, err
+			}
+		}
+		return labelData, nil
+	}, nil
+}
@@ -0,0 +1,161 @@
+package transform
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/G7DAO/seer/indexer"
+)
+
+type compiledRule struct {
+	rule      Rule
+	transform Func
+}
+
+// Hooks applies transforms of rules to labels of blockchain before they are written.
+type Hooks struct {
+	blockchain string
+	rules      []compiledRule
+}
+
+// NewHooks builds transforms of rules of blockchain, rules without blockchain apply to any chain.
+func NewHooks(blockchain string, rules []Rule) (*Hooks, error) {
+	hooks := &Hooks{blockchain: blockchain}
+
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		if rule.Blockchain != "" && rule.Blockchain != blockchain {
+			continue
+		}
+
+		factory, _ := lookup(rule.Transform)
+		transform, err := factory(rule.Options)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.ID, err)
+		}
+
+		hooks.rules = append(hooks.rules, compiledRule{rule: rule, transform: transform})
+	}
+
+	log.Printf("Initialized label transforms with %d rules for blockchain %s", len(hooks.rules), blockchain)
+
+	return hooks, nil
+}
+
+// Apply transforms label_data of decoded labels of customer in place. Raw labels are not
+// changed. If transform fails, label is written with label_data it had before the rule.
+func (h *Hooks) Apply(customerID string, events []indexer.EventLabel, transactions []indexer.TransactionLabel) {
+	if h == nil || len(h.rules) == 0 {
+		return
+	}
+
+	for i := range events {
+		if events[i].Label == indexer.SeerCrawlerRawLabel {
+			continue
+		}
+		label := Label{
+			Blockchain: h.blockchain,
+			CustomerID: customerID,
+			Address:    events[i].Address,
+			LabelType:  events[i].LabelType,
+			LabelName:  events[i].LabelName,
+		}
+		events[i].LabelData = h.apply(label, events[i].LabelData, events[i].TransactionHash)
+	}
+
+	for i := range transactions {
+		if transactions[i].Label == indexer.SeerCrawlerRawLabel {
+			continue
+		}
+		label := Label{
+			Blockchain: h.blockchain,
+			CustomerID: customerID,
+			Address:    transactions[i].Address,
+			LabelType:  transactions[i].LabelType,
+			LabelName:  transactions[i].LabelName,
+		}
+		transactions[i].LabelData = h.apply(label, transactions[i].LabelData, transactions[i].TransactionHash)
+	}
+}
+
+func (h *Hooks) apply(label Label, labelData string, transactionHash string) string {
+	var data map[string]interface{}
+	for _, compiled := range h.rules {
+		if !compiled.rule.matches(label) {
+			continue
+		}
+
+		// label_data is decoded only for labels matched by any rule
+		if data == nil {
+			decoder := json.NewDecoder(strings.NewReader(labelData))
+			decoder.UseNumber()
+			if err := decoder.Decode(&data); err != nil || data == nil {
+				log.Printf("Failed to decode label_data of %s label %s in transaction %s for transforms: %v", label.LabelType, label.LabelName, transactionHash, err)
+				return labelData
+			}
+		}
+
+		transformed, err := runTransform(compiled.transform, label, copyData(data))
+		if err != nil {
+			log.Printf("Transform rule %s failed for %s label %s in transaction %s: %v", compiled.rule.ID, label.LabelType, label.LabelName, transactionHash, err)
+			continue
+		}
+		data = transformed
+	}
+
+	if data == nil {
+		return labelData
+	}
+
+	transformedLabelData, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("Failed to encode transformed label_data of %s label %s in transaction %s: %v", label.LabelType, label.LabelName, transactionHash, err)
+		return labelData
+	}
+
+	return string(transformedLabelData)
+}
+
+// runTransform calls transform, panic of registered transform fails only its rule.
+func runTransform(transform Func, label Label, data map[string]interface{}) (transformed map[string]interface{}, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("transform panicked: %v", recovered)
+		}
+	}()
+
+	transformed, err = transform(label, data)
+	if err == nil && transformed == nil {
+		err = fmt.Errorf("transform returned no label_data")
+	}
+	return transformed, err
+}
+
+// copyData returns deep copy of objects of label_data, so failed transform does not leave
+// partial changes.
+func copyData(data map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(data))
+	for key, value := range data {
+		copied[key] = copyValue(value)
+	}
+	return copied
+}
+
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return copyData(v)
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, element := range v {
+			copied[i] = copyValue(element)
+		}
+		return copied
+	default:
+		return value
+	}
+}
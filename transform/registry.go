@@ -0,0 +1,62 @@
+package transform
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
+// Label identifies label which label_data is transformed.
+type Label struct {
+	Blockchain string
+	CustomerID string
+	Address    string
+	LabelType  string
+	LabelName  string
+}
+
+// Func transforms decoded label_data of label, returned map is written instead of it. Numbers
+// of label_data are json.Number, so big integers are not rounded.
+type Func func(label Label, labelData map[string]interface{}) (map[string]interface{}, error)
+
+// Factory builds transform from options of rule.
+type Factory func(options json.RawMessage) (Func, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{
+		"rename": newRename,
+		"scale":  newScale,
+		"set":    newSet,
+	}
+)
+
+// Register adds transform under name, so rules could refer to it. Transforms of deployment are
+// registered from init of package linked into seer binary, existing transform is replaced.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry[name] = factory
+}
+
+func lookup(name string) (Factory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	factory, exists := registry[name]
+	return factory, exists
+}
+
+// Names returns names of registered transforms.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
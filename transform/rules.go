@@ -0,0 +1,95 @@
+// Package transform changes decoded label_data before labels are written to customer
+// databases. Rules select labels of customer by chain, address and label name and apply named
+// transforms to them. Transforms are Go functions registered with Register, built-in ones
+// rename fields, scale token amounts by decimals and add static fields such as token symbols.
+package transform
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Rule applies transform with options to labels of customer. Empty filters match any label.
+type Rule struct {
+	ID         string          `json:"id"`
+	CustomerID string          `json:"customer_id"`
+	Blockchain string          `json:"blockchain,omitempty"`
+	Addresses  []string        `json:"addresses,omitempty"`
+	LabelType  string          `json:"label_type,omitempty"`
+	LabelNames []string        `json:"label_names,omitempty"`
+	Transform  string          `json:"transform"`
+	Options    json.RawMessage `json:"options,omitempty"`
+}
+
+func (r *Rule) Validate() error {
+	if r.ID == "" {
+		return fmt.Errorf("rule id is required")
+	}
+	if r.CustomerID == "" {
+		return fmt.Errorf("rule %s: customer_id is required", r.ID)
+	}
+	if r.LabelType != "" && r.LabelType != "event" && r.LabelType != "tx_call" {
+		return fmt.Errorf("rule %s: label_type should be event or tx_call", r.ID)
+	}
+	if _, exists := lookup(r.Transform); !exists {
+		return fmt.Errorf("rule %s: unknown transform %q, registered transforms: %s", r.ID, r.Transform, strings.Join(Names(), ", "))
+	}
+
+	return nil
+}
+
+// matches returns true if label is selected by filters of rule.
+func (r *Rule) matches(label Label) bool {
+	if r.CustomerID != label.CustomerID {
+		return false
+	}
+	if r.LabelType != "" && r.LabelType != label.LabelType {
+		return false
+	}
+	if len(r.Addresses) > 0 && !containsFold(r.Addresses, label.Address) {
+		return false
+	}
+	if len(r.LabelNames) > 0 && !containsFold(r.LabelNames, label.LabelName) {
+		return false
+	}
+
+	return true
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadRules reads JSON list of rules from file and validates them. Rules are applied in order
+// of file, so transform could work on result of previous one.
+func LoadRules(path string) ([]Rule, error) {
+	rulesBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transforms file: %v", err)
+	}
+
+	var rules []Rule
+	if err := json.Unmarshal(rulesBytes, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse transforms file: %v", err)
+	}
+
+	ids := make(map[string]bool)
+	for i := range rules {
+		if err := rules[i].Validate(); err != nil {
+			return nil, err
+		}
+		if ids[rules[i].ID] {
+			return nil, fmt.Errorf("duplicated rule id %s", rules[i].ID)
+		}
+		ids[rules[i].ID] = true
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,323 @@
+// Package verify walks blocks index of chain and checks that batches referenced by it are
+// stored and contain exactly the indexed blocks. Verified range is saved as checkpoint, so
+// interrupted verification continues where it stopped.
+package verify
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	seer_common "github.com/G7DAO/seer/blockchain/common"
+	"github.com/G7DAO/seer/indexer"
+	"github.com/G7DAO/seer/storage"
+)
+
+// Kinds of mismatches between index and storage
+const (
+	KindMissingObject = "missing_object"
+	KindPathMismatch  = "path_mismatch"
+	KindUndecodable   = "undecodable"
+	KindRowCount      = "row_count"
+	KindBlockRange    = "block_range"
+	KindMissingBlocks = "missing_blocks"
+	KindBlockHash     = "block_hash"
+	KindParentHash    = "parent_hash"
+)
+
+var DefaultPageSize = 10000
+
+// Mismatch is problem found in one batch.
+type Mismatch struct {
+	Path        string   `json:"path"`
+	FromBlock   uint64   `json:"from_block"`
+	ToBlock     uint64   `json:"to_block"`
+	Kind        string   `json:"kind"`
+	Details     string   `json:"details"`
+	Blocks      []uint64 `json:"blocks,omitempty"`
+	Repaired    bool     `json:"repaired"`
+	RepairError string   `json:"repair_error,omitempty"`
+}
+
+// Report summarizes one verification run.
+type Report struct {
+	Chain       string `json:"chain"`
+	FromBlock   uint64 `json:"from_block"`
+	ToBlock     uint64 `json:"to_block"`
+	LastBlock   uint64 `json:"last_block"`
+	Batches     uint64 `json:"batches"`
+	Blocks      uint64 `json:"blocks"`
+	Mismatches  uint64 `json:"mismatches"`
+	Repaired    uint64 `json:"repaired"`
+	Interrupted bool   `json:"interrupted"`
+}
+
+// Recoverer crawls batch again, crawler.Crawler implements it.
+type Recoverer interface {
+	RecoverBatch(batch indexer.IndexedBatch, reason string, threads int) error
+}
+
+// Verifier compares blocks index of chain with batches in storage. With Repair index rows are
+// pointed to batch object stored under other compression or layout, other mismatches are repaired by
+// Recoverer if it is set.
+type Verifier struct {
+	Store     *indexer.PostgreSQLpgx
+	Storage   storage.Storer
+	Client    seer_common.ChainClient
+	Recoverer Recoverer
+
+	Repair   bool
+	Threads  int
+	PageSize int
+
+	chain string
+}
+
+func NewVerifier(chain string, store *indexer.PostgreSQLpgx, storageInstance storage.Storer, client seer_common.ChainClient) (*Verifier, error) {
+	if err := store.EnsureVerifyProgressTable(); err != nil {
+		return nil, fmt.Errorf("failed to create verify progress table: %w", err)
+	}
+
+	return &Verifier{
+		Store:    store,
+		Storage:  storageInstance,
+		Client:   client,
+		Threads:  1,
+		PageSize: DefaultPageSize,
+		chain:    chain,
+	}, nil
+}
+
+// StartBlock returns first block to verify, checkpoint is used if it is above fromBlock.
+func (v *Verifier) StartBlock(fromBlock uint64) (uint64, error) {
+	progress, err := v.Store.ReadVerifyProgress(v.chain)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read verify progress of %s: %w", v.chain, err)
+	}
+	if progress != nil && progress.LastBlock+1 > fromBlock {
+		return progress.LastBlock + 1, nil
+	}
+
+	return fromBlock, nil
+}
+
+// Run verifies batches indexed in range of blocks and passes found mismatches to report.
+// Checkpoint is saved after each batch, cancelled ctx stops run before next batch.
+func (v *Verifier) Run(ctx context.Context, fromBlock, toBlock uint64, report func(Mismatch)) (*Report, error) {
+	result := &Report{Chain: v.chain, FromBlock: fromBlock, ToBlock: toBlock}
+
+	pageSize := v.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	for fromBlock <= toBlock {
+		indexes, readErr := v.Store.ReadBlockIndexesRange(v.chain, fromBlock, toBlock, pageSize)
+		if readErr != nil {
+			return result, fmt.Errorf("failed to read blocks index from %d: %w", fromBlock, readErr)
+		}
+		if len(indexes) == 0 {
+			break
+		}
+
+		batches := splitByPath(indexes)
+		// Last batch of full page could continue on next one
+		if len(indexes) == pageSize {
+			if len(batches) == 1 {
+				pageSize *= 2
+				continue
+			}
+			batches = batches[:len(batches)-1]
+		}
+
+		for _, batch := range batches {
+			if ctx.Err() != nil {
+				result.Interrupted = true
+				return result, nil
+			}
+
+			mismatches := v.verifyBatch(batch)
+			for _, mismatch := range mismatches {
+				if mismatch.Repaired {
+					result.Repaired++
+				}
+				report(mismatch)
+			}
+
+			lastBlock := batch[len(batch)-1].BlockNumber
+			if err := v.Store.SaveVerifyProgress(v.chain, lastBlock, 1, uint64(len(mismatches))); err != nil {
+				return result, fmt.Errorf("failed to save verify progress of %s: %w", v.chain, err)
+			}
+
+			result.LastBlock = lastBlock
+			result.Batches++
+			result.Blocks += uint64(len(batch))
+			result.Mismatches += uint64(len(mismatches))
+			fromBlock = lastBlock + 1
+		}
+	}
+
+	return result, nil
+}
+
+// splitByPath splits index rows ordered by block number into runs of rows with the same path.
+func splitByPath(indexes []indexer.BlockIndex) [][]indexer.BlockIndex {
+	var batches [][]indexer.BlockIndex
+	start := 0
+	for i := 1; i <= len(indexes); i++ {
+		if i == len(indexes) || indexes[i].Path != indexes[start].Path {
+			batches = append(batches, indexes[start:i])
+			start = i
+		}
+	}
+	return batches
+}
+
+// verifyBatch reads batch of index rows from storage and compares its blocks with them.
+func (v *Verifier) verifyBatch(indexes []indexer.BlockIndex) []Mismatch {
+	path := indexes[0].Path
+	batch := indexer.IndexedBatch{
+		Path:           path,
+		MinBlockNumber: indexes[0].BlockNumber,
+		MaxBlockNumber: indexes[len(indexes)-1].BlockNumber,
+		BlocksCount:    uint64(len(indexes)),
+	}
+	newMismatch := func(kind, details string, blocks []uint64) Mismatch {
+		return Mismatch{Path: path, FromBlock: batch.MinBlockNumber, ToBlock: batch.MaxBlockNumber, Kind: kind, Details: details, Blocks: blocks}
+	}
+
+	var mismatches []Mismatch
+
+	data, readErr := v.Storage.Read(path)
+	if readErr != nil {
+		// Batch could be stored with other compression or layout than index points to
+		storedPath := ""
+		if parsed, ok := storage.ParseBatchKey(path); ok {
+			for _, key := range storage.BatchKeys(parsed.Root, parsed.Chain, parsed.Start, parsed.End) {
+				if key == path {
+					continue
+				}
+				if stored, err := v.Storage.Read(key); err == nil {
+					storedPath, data = key, stored
+					break
+				}
+			}
+		}
+
+		if storedPath == "" {
+			return v.repair(batch, []Mismatch{newMismatch(KindMissingObject, fmt.Sprintf("batch object is not available in storage: %v", readErr), nil)})
+		}
+
+		mismatch := newMismatch(KindPathMismatch, fmt.Sprintf("batch is stored at %s", storedPath), nil)
+		if v.Repair {
+			if _, err := v.Store.UpdateBlockIndexPath(v.chain, path, storedPath, batch.MinBlockNumber, batch.MaxBlockNumber); err != nil {
+				mismatch.RepairError = err.Error()
+			} else {
+				mismatch.Repaired = true
+				batch.Path = storedPath
+			}
+		}
+		mismatches = append(mismatches, mismatch)
+	}
+
+	batchJson, decodeErr := v.Client.DecodeProtoEntireBlockToJson(&data)
+	if decodeErr != nil {
+		return append(mismatches, v.repair(batch, []Mismatch{newMismatch(KindUndecodable, fmt.Sprintf("failed to decode batch: %v", decodeErr), nil)})...)
+	}
+
+	blocks := make(map[uint64]seer_common.BlockJson, len(batchJson.Blocks))
+	var batchFrom, batchTo uint64
+	for i, block := range batchJson.Blocks {
+		number, err := strconv.ParseUint(block.BlockNumber, 10, 64)
+		if err != nil {
+			return append(mismatches, v.repair(batch, []Mismatch{newMismatch(KindUndecodable, fmt.Sprintf("invalid number %q of block %d of batch", block.BlockNumber, i), nil)})...)
+		}
+		if i == 0 || number < batchFrom {
+			batchFrom = number
+		}
+		if i == 0 || number > batchTo {
+			batchTo = number
+		}
+		blocks[number] = block
+	}
+
+	var contentMismatches []Mismatch
+	if len(batchJson.Blocks) != len(indexes) {
+		contentMismatches = append(contentMismatches, newMismatch(KindRowCount, fmt.Sprintf("index has %d rows, batch has %d blocks", len(indexes), len(batchJson.Blocks)), nil))
+	}
+
+	var rangeProblems []string
+	if len(blocks) > 0 && (batchFrom != batch.MinBlockNumber || batchTo != batch.MaxBlockNumber) {
+		rangeProblems = append(rangeProblems, fmt.Sprintf("batch has blocks %d-%d", batchFrom, batchTo))
+	}
+	// Key of batch is rendered with range of crawled pack
+	if parsed, ok := storage.ParseBatchKey(batch.Path); ok && (batch.MinBlockNumber < parsed.Start || batch.MaxBlockNumber > parsed.End) {
+		rangeProblems = append(rangeProblems, fmt.Sprintf("batch key covers blocks %d-%d", parsed.Start, parsed.End))
+	}
+	if len(rangeProblems) > 0 {
+		contentMismatches = append(contentMismatches, newMismatch(KindBlockRange, fmt.Sprintf("index has blocks %d-%d, %s", batch.MinBlockNumber, batch.MaxBlockNumber, strings.Join(rangeProblems, ", ")), nil))
+	}
+
+	var missingBlocks, hashMismatches, parentMismatches []uint64
+	for _, index := range indexes {
+		block, exists := blocks[index.BlockNumber]
+		if !exists {
+			missingBlocks = append(missingBlocks, index.BlockNumber)
+			continue
+		}
+		if !strings.EqualFold(block.Hash, index.BlockHash) {
+			hashMismatches = append(hashMismatches, index.BlockNumber)
+		}
+		if !strings.EqualFold(block.ParentHash, index.ParentHash) {
+			parentMismatches = append(parentMismatches, index.BlockNumber)
+		}
+	}
+	if len(missingBlocks) > 0 {
+		contentMismatches = append(contentMismatches, newMismatch(KindMissingBlocks, fmt.Sprintf("%d indexed blocks are not in batch", len(missingBlocks)), missingBlocks))
+	}
+	if len(hashMismatches) > 0 {
+		contentMismatches = append(contentMismatches, newMismatch(KindBlockHash, fmt.Sprintf("hashes of %d blocks differ from index", len(hashMismatches)), hashMismatches))
+	}
+	if len(parentMismatches) > 0 {
+		contentMismatches = append(contentMismatches, newMismatch(KindParentHash, fmt.Sprintf("parent hashes of %d blocks differ from index", len(parentMismatches)), parentMismatches))
+	}
+
+	if len(contentMismatches) == 0 {
+		return mismatches
+	}
+
+	return append(mismatches, v.repair(batch, contentMismatches)...)
+}
+
+// repair crawls batch again if Repair is set, result of recovery is recorded in mismatches.
+func (v *Verifier) repair(batch indexer.IndexedBatch, mismatches []Mismatch) []Mismatch {
+	if !v.Repair {
+		return mismatches
+	}
+
+	var repairErr error
+	if v.Recoverer == nil {
+		repairErr = fmt.Errorf("batch could be repaired only by crawling it again, RPC of chain is not configured")
+	} else {
+		kinds := make([]string, len(mismatches))
+		for i, mismatch := range mismatches {
+			kinds[i] = mismatch.Kind
+		}
+		repairErr = v.Recoverer.RecoverBatch(batch, "verification found "+strings.Join(kinds, ", "), v.Threads)
+	}
+
+	for i := range mismatches {
+		if repairErr != nil {
+			mismatches[i].RepairError = repairErr.Error()
+			continue
+		}
+		mismatches[i].Repaired = true
+	}
+	if repairErr != nil {
+		log.Printf("Failed to repair batch %s: %v", batch.Path, repairErr)
+	}
+
+	return mismatches
+}
@@ -0,0 +1,504 @@
+// Package webhooks posts labels written to customer databases to webhooks registered by
+// customers. Each POST is saved as delivery in index database before it is sent, so deliveries
+// which failed all attempts could be sent again.
+package webhooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/G7DAO/seer/indexer"
+	"github.com/G7DAO/seer/logging"
+)
+
+// Headers of webhook requests
+const (
+	DeliveryHeader  = "X-Seer-Delivery"
+	TimestampHeader = "X-Seer-Timestamp"
+	SignatureHeader = "X-Seer-Signature"
+)
+
+var (
+	DefaultTimeout    = 10 * time.Second
+	DefaultRetries    = 3
+	DefaultRetryDelay = 2 * time.Second
+	// Webhooks of chain are read from database again after RefreshInterval
+	RefreshInterval = time.Minute
+	// Workers limits number of webhooks receiving deliveries at once
+	Workers = 16
+	// Undelivered deliveries are read from database every SweepInterval if they were not
+	// updated for SweepDelay and made less than MaxDeliveryAttempts attempts. Claims of
+	// deliveries held by dispatcher are extended every SweepInterval, it should be shorter
+	// than SweepDelay
+	SweepInterval       = time.Minute
+	SweepDelay          = 5 * time.Minute
+	SweepLimit          = 1000
+	MaxDeliveryAttempts = 20
+	// queueSize limits number of deliveries queued for one webhook
+	queueSize = 1000
+)
+
+// Payload is body of webhook request.
+type Payload struct {
+	DeliveryID   string                     `json:"delivery_id"`
+	WebhookID    string                     `json:"webhook_id"`
+	CustomerID   string                     `json:"customer_id"`
+	Chain        string                     `json:"chain"`
+	FromBlock    uint64                     `json:"from_block"`
+	ToBlock      uint64                     `json:"to_block"`
+	Events       []indexer.EventLabel       `json:"events"`
+	Transactions []indexer.TransactionLabel `json:"transactions"`
+}
+
+// GenerateSecret returns random secret for webhook registered without one.
+func GenerateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// Sign returns signature of body sent at timestamp, it is hex encoded HMAC-SHA256 of
+// "<timestamp>.<body>" with secret of webhook prefixed with "sha256=".
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks signature of webhook request, receivers should also reject old timestamps.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// Filter returns labels matching addresses and label names of webhook.
+func Filter(webhook indexer.Webhook, events []indexer.EventLabel, transactions []indexer.TransactionLabel) ([]indexer.EventLabel, []indexer.TransactionLabel) {
+	matches := func(address, labelName string) bool {
+		if len(webhook.Addresses) > 0 && !contains(webhook.Addresses, strings.ToLower(address)) {
+			return false
+		}
+		return len(webhook.LabelNames) == 0 || contains(webhook.LabelNames, labelName)
+	}
+
+	var matchedEvents []indexer.EventLabel
+	for _, event := range events {
+		if matches(event.Address, event.LabelName) {
+			matchedEvents = append(matchedEvents, event)
+		}
+	}
+
+	var matchedTransactions []indexer.TransactionLabel
+	for _, transaction := range transactions {
+		if matches(transaction.Address, transaction.LabelName) {
+			matchedTransactions = append(matchedTransactions, transaction)
+		}
+	}
+
+	return matchedEvents, matchedTransactions
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+type deliveryItem struct {
+	delivery *indexer.WebhookDelivery
+	webhook  indexer.Webhook
+}
+
+// webhookQueue holds deliveries of one webhook, they are sent one by one in order of queueing.
+type webhookQueue struct {
+	items   []deliveryItem
+	running bool
+}
+
+// Dispatcher matches written labels against webhooks of chain and delivers them in
+// background, so labels processing is not slowed down by receivers. Deliveries of each
+// webhook are sent in order by their own goroutine and at most Workers of them are sent at
+// once, so slow or unavailable receiver delays only its own deliveries. Pending and failed
+// deliveries are picked up from database again every SweepInterval.
+type Dispatcher struct {
+	Retries    int
+	RetryDelay time.Duration
+
+	store  *indexer.PostgreSQLpgx
+	chain  string
+	client *http.Client
+
+	mu       sync.Mutex
+	webhooks map[string][]indexer.Webhook
+	byID     map[string]indexer.Webhook
+	loadedAt time.Time
+
+	queuesMu sync.Mutex
+	queues   map[string]*webhookQueue
+	// queued keeps IDs of deliveries in queues or in flight, so sweep does not send them twice
+	// and their claims are extended until they are sent
+	queued map[string]bool
+	closed bool
+
+	workers chan struct{}
+	wg      sync.WaitGroup
+	stop    chan struct{}
+	swept   chan struct{}
+}
+
+func NewDispatcher(store *indexer.PostgreSQLpgx, chain string, timeout time.Duration) (*Dispatcher, error) {
+	if err := store.EnsureWebhooksTables(); err != nil {
+		return nil, fmt.Errorf("failed to create webhooks tables: %w", err)
+	}
+
+	dispatcher := &Dispatcher{
+		Retries:    DefaultRetries,
+		RetryDelay: DefaultRetryDelay,
+
+		store:   store,
+		chain:   chain,
+		client:  &http.Client{Timeout: timeout},
+		queues:  make(map[string]*webhookQueue),
+		queued:  make(map[string]bool),
+		workers: make(chan struct{}, max(Workers, 1)),
+		stop:    make(chan struct{}),
+		swept:   make(chan struct{}),
+	}
+
+	if err := dispatcher.refresh(); err != nil {
+		return nil, err
+	}
+
+	go dispatcher.sweep()
+
+	return dispatcher, nil
+}
+
+// refresh reads active webhooks of chain.
+func (d *Dispatcher) refresh() error {
+	webhooks, err := d.store.ListWebhooks(d.chain, "", true)
+	if err != nil {
+		return fmt.Errorf("failed to read webhooks of %s: %w", d.chain, err)
+	}
+
+	byCustomer := make(map[string][]indexer.Webhook)
+	byID := make(map[string]indexer.Webhook)
+	for _, webhook := range webhooks {
+		byCustomer[webhook.CustomerID] = append(byCustomer[webhook.CustomerID], webhook)
+		byID[webhook.ID] = webhook
+	}
+
+	d.mu.Lock()
+	d.webhooks = byCustomer
+	d.byID = byID
+	d.loadedAt = time.Now()
+	d.mu.Unlock()
+
+	return nil
+}
+
+// refreshIfStale reads webhooks again if they were read more than RefreshInterval ago.
+func (d *Dispatcher) refreshIfStale() {
+	d.mu.Lock()
+	stale := time.Since(d.loadedAt) >= RefreshInterval
+	d.mu.Unlock()
+
+	if !stale {
+		return
+	}
+	if err := d.refresh(); err != nil {
+		logging.Chain(d.chain).Warn("Failed to refresh webhooks, previously read ones are used", logging.ErrorKey, err)
+		d.mu.Lock()
+		d.loadedAt = time.Now()
+		d.mu.Unlock()
+	}
+}
+
+func (d *Dispatcher) customerWebhooks(customerID string) []indexer.Webhook {
+	d.refreshIfStale()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.webhooks[customerID]
+}
+
+// Process saves deliveries of labels of customer written for blocks range and queues them.
+// Deliveries which do not fit into queue of webhook are left pending and are sent by sweep.
+func (d *Dispatcher) Process(customerID string, events []indexer.EventLabel, transactions []indexer.TransactionLabel, fromBlock, toBlock uint64) {
+	logger := logging.Customer(d.chain, customerID)
+
+	for _, webhook := range d.customerWebhooks(customerID) {
+		matchedEvents, matchedTransactions := Filter(webhook, events, transactions)
+		if len(matchedEvents)+len(matchedTransactions) == 0 {
+			continue
+		}
+
+		payload := Payload{
+			DeliveryID:   uuid.NewString(),
+			WebhookID:    webhook.ID,
+			CustomerID:   customerID,
+			Chain:        d.chain,
+			FromBlock:    fromBlock,
+			ToBlock:      toBlock,
+			Events:       matchedEvents,
+			Transactions: matchedTransactions,
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error("Failed to marshal webhook payload", "webhook_id", webhook.ID, logging.ErrorKey, err)
+			continue
+		}
+
+		delivery, err := d.store.CreateWebhookDelivery(indexer.WebhookDelivery{
+			ID:          payload.DeliveryID,
+			WebhookID:   webhook.ID,
+			FromBlock:   fromBlock,
+			ToBlock:     toBlock,
+			LabelsCount: len(matchedEvents) + len(matchedTransactions),
+			Payload:     body,
+		})
+		if err != nil {
+			logger.Error("Failed to save webhook delivery", "webhook_id", webhook.ID, logging.ErrorKey, err)
+			continue
+		}
+
+		if !d.enqueue(deliveryItem{delivery: delivery, webhook: webhook}) {
+			logger.Warn("Queue of webhook is full, delivery is left pending", "webhook_id", webhook.ID, "delivery_id", delivery.ID)
+		}
+	}
+}
+
+// enqueue adds delivery to queue of its webhook and starts goroutine sending deliveries of
+// webhook if it is not running. Returns false if queue is full or dispatcher is closed.
+func (d *Dispatcher) enqueue(item deliveryItem) bool {
+	d.queuesMu.Lock()
+	defer d.queuesMu.Unlock()
+
+	if d.closed {
+		return false
+	}
+	if d.queued[item.delivery.ID] {
+		return true
+	}
+
+	queue := d.queues[item.webhook.ID]
+	if queue == nil {
+		queue = &webhookQueue{}
+		d.queues[item.webhook.ID] = queue
+	}
+	if len(queue.items) >= queueSize {
+		return false
+	}
+
+	queue.items = append(queue.items, item)
+	d.queued[item.delivery.ID] = true
+	if !queue.running {
+		queue.running = true
+		d.wg.Add(1)
+		go d.run(item.webhook.ID, queue)
+	}
+
+	return true
+}
+
+// run sends deliveries of webhook until its queue is empty.
+func (d *Dispatcher) run(webhookID string, queue *webhookQueue) {
+	defer d.wg.Done()
+
+	for {
+		d.queuesMu.Lock()
+		if len(queue.items) == 0 {
+			queue.running = false
+			delete(d.queues, webhookID)
+			d.queuesMu.Unlock()
+			return
+		}
+		item := queue.items[0]
+		queue.items = queue.items[1:]
+		d.queuesMu.Unlock()
+
+		d.workers <- struct{}{}
+		if err := d.deliver(item.delivery, item.webhook); err != nil {
+			logging.Customer(d.chain, item.webhook.CustomerID).Error("Failed to deliver labels to webhook", "webhook_id", item.webhook.ID, "delivery_id", item.delivery.ID, logging.ErrorKey, err)
+		}
+		<-d.workers
+
+		d.queuesMu.Lock()
+		delete(d.queued, item.delivery.ID)
+		d.queuesMu.Unlock()
+	}
+}
+
+// sweep queues pending and failed deliveries of chain every SweepInterval. Deliveries are
+// claimed after SweepDelay since their last update, so deliveries just saved by Process are
+// not sent twice and failed ones wait before the next attempts. Deliveries which are still
+// queued or in flight get their claims extended first, so other replicas do not claim them
+// while they wait behind slow receiver.
+func (d *Dispatcher) sweep() {
+	defer close(d.swept)
+
+	ticker := time.NewTicker(SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stop:
+			return
+		case <-ticker.C:
+		}
+
+		if queued, err := d.sweepOnce(); err != nil {
+			logging.Chain(d.chain).Error("Failed to read undelivered webhook deliveries", logging.ErrorKey, err)
+		} else if queued > 0 {
+			logging.Chain(d.chain).Info("Queued undelivered webhook deliveries", "deliveries", queued)
+		}
+	}
+}
+
+func (d *Dispatcher) sweepOnce() (int, error) {
+	if _, err := d.store.ExtendWebhookDeliveryClaims(d.heldDeliveries()); err != nil {
+		return 0, fmt.Errorf("failed to extend claims of queued deliveries: %w", err)
+	}
+
+	deliveries, err := d.store.ClaimUndeliveredWebhookDeliveries(d.chain, SweepDelay, MaxDeliveryAttempts, SweepLimit)
+	if err != nil {
+		return 0, err
+	}
+
+	d.refreshIfStale()
+
+	var queued int
+	for i := range deliveries {
+		d.mu.Lock()
+		webhook, exists := d.byID[deliveries[i].WebhookID]
+		d.mu.Unlock()
+		if !exists {
+			continue
+		}
+
+		if d.enqueue(deliveryItem{delivery: &deliveries[i], webhook: webhook}) {
+			queued++
+		}
+	}
+
+	return queued, nil
+}
+
+// heldDeliveries returns IDs of deliveries which are queued or in flight.
+func (d *Dispatcher) heldDeliveries() []string {
+	d.queuesMu.Lock()
+	defer d.queuesMu.Unlock()
+
+	ids := make([]string, 0, len(d.queued))
+	for id := range d.queued {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Close stops sweep and accepting new deliveries and waits until queued ones are sent.
+func (d *Dispatcher) Close() {
+	close(d.stop)
+	<-d.swept
+
+	d.queuesMu.Lock()
+	d.closed = true
+	d.queuesMu.Unlock()
+
+	d.wg.Wait()
+}
+
+// deliver posts payload of delivery with retries and records the outcome.
+func (d *Dispatcher) deliver(delivery *indexer.WebhookDelivery, webhook indexer.Webhook) error {
+	var responseStatus, attempts int
+	var err error
+	for attempts < d.Retries+1 {
+		if attempts > 0 {
+			time.Sleep(d.RetryDelay * time.Duration(attempts))
+		}
+		attempts++
+
+		responseStatus, err = d.post(delivery, webhook)
+		if err == nil {
+			break
+		}
+	}
+
+	status, lastError := indexer.DeliveryDelivered, ""
+	if err != nil {
+		status, lastError = indexer.DeliveryFailed, err.Error()
+	}
+
+	if updateErr := d.store.UpdateWebhookDelivery(delivery.ID, status, attempts, responseStatus, lastError); updateErr != nil {
+		return fmt.Errorf("failed to update status of delivery: %w", updateErr)
+	}
+
+	return err
+}
+
+func (d *Dispatcher) post(delivery *indexer.WebhookDelivery, webhook indexer.Webhook) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(DeliveryHeader, delivery.ID)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Redeliver sends saved delivery again, used for deliveries which failed all attempts or were
+// left pending.
+func Redeliver(store *indexer.PostgreSQLpgx, deliveryID string, timeout time.Duration) error {
+	delivery, err := store.GetWebhookDelivery(deliveryID)
+	if err != nil {
+		return err
+	}
+
+	webhook, err := store.GetWebhook(delivery.WebhookID)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook of delivery %s: %w", deliveryID, err)
+	}
+
+	dispatcher := &Dispatcher{
+		Retries:    DefaultRetries,
+		RetryDelay: DefaultRetryDelay,
+		store:      store,
+		chain:      webhook.Chain,
+		client:     &http.Client{Timeout: timeout},
+	}
+	return dispatcher.deliver(delivery, *webhook)
+}